@@ -132,3 +132,17 @@ func BenchmarkEncodingNested(b *testing.B) {
 		_, _ = Marshal(nestedInstance)
 	}
 }
+
+func BenchmarkDecodingNestedParallel(b *testing.B) {
+	data, err := Marshal(nestedInstance)
+	if err != nil {
+		b.Fatalf("Marshal error: %v", err)
+	}
+
+	b.RunParallel(func(pb *testing.PB) {
+		for pb.Next() {
+			var out nestedtest1
+			_ = Unmarshal(data, &out)
+		}
+	})
+}
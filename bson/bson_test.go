@@ -10,6 +10,7 @@ import (
 	"bytes"
 	"fmt"
 	"reflect"
+	"strconv"
 	"strings"
 	"testing"
 	"time"
@@ -145,6 +146,56 @@ func (kb keyBool) UnmarshalKey(key string) error {
 	return nil
 }
 
+type textKey struct {
+	Kind string
+	ID   int
+}
+
+func (tk textKey) MarshalText() ([]byte, error) {
+	return []byte(tk.Kind + "-" + strconv.Itoa(tk.ID)), nil
+}
+
+func (tk *textKey) UnmarshalText(text []byte) error {
+	idx := bytes.LastIndexByte(text, '-')
+	if idx < 0 {
+		return fmt.Errorf("invalid key %q", text)
+	}
+	id, err := strconv.Atoi(string(text[idx+1:]))
+	if err != nil {
+		return err
+	}
+	tk.Kind, tk.ID = string(text[:idx]), id
+	return nil
+}
+
+type textString string
+
+func (ts textString) MarshalText() ([]byte, error) {
+	return []byte("text:" + ts), nil
+}
+
+func (ts *textString) UnmarshalText(text []byte) error {
+	*ts = textString(strings.TrimPrefix(string(text), "text:"))
+	return nil
+}
+
+type textColor int
+
+func (tc textColor) MarshalText() ([]byte, error) {
+	if tc == 1 {
+		return []byte("green"), nil
+	}
+	return nil, fmt.Errorf("bad color %d", int(tc))
+}
+
+func (tc *textColor) UnmarshalText(text []byte) error {
+	if string(text) != "green" {
+		return fmt.Errorf("bad color %q", text)
+	}
+	*tc = 1
+	return nil
+}
+
 func TestMapCodec(t *testing.T) {
 	t.Run("EncodeKeysWithStringer", func(t *testing.T) {
 		strstr := stringerString("foo")
@@ -184,6 +235,51 @@ func TestMapCodec(t *testing.T) {
 		assert.Equal(t, mapObj, got, "expected result %v, got %v", mapObj, got)
 
 	})
+	t.Run("keys implement TextMarshaler and TextUnmarshaler", func(t *testing.T) {
+		mapObj := map[textKey]string{{"user", 1}: "a", {"team-a", 2}: "b"}
+
+		doc, err := Marshal(mapObj)
+		assert.Nil(t, err, "Marshal error: %v", err)
+		for _, key := range []string{"user-1", "team-a-2"} {
+			_, err = Raw(doc).LookupErr(key)
+			assert.Nil(t, err, "expected key %q in %v", key, Raw(doc))
+		}
+
+		var got map[textKey]string
+		err = Unmarshal(doc, &got)
+		assert.Nil(t, err, "Unmarshal error: %v", err)
+		assert.Equal(t, mapObj, got, "expected result %v, got %v", mapObj, got)
+
+		bad, _ := Marshal(M{"invalid": "x"})
+		err = Unmarshal(bad, &got)
+		assert.NotNil(t, err, "expected Unmarshal error for invalid key, got nil")
+	})
+	t.Run("string keys are not marshaled as text", func(t *testing.T) {
+		mapObj := map[textString]int{"foo": 1}
+
+		doc, err := Marshal(mapObj)
+		assert.Nil(t, err, "Marshal error: %v", err)
+		_, err = Raw(doc).LookupErr("foo")
+		assert.Nil(t, err, "expected key foo in %v", Raw(doc))
+
+		var got map[textString]int
+		err = Unmarshal(doc, &got)
+		assert.Nil(t, err, "Unmarshal error: %v", err)
+		assert.Equal(t, mapObj, got, "expected result %v, got %v", mapObj, got)
+	})
+	t.Run("integer keys are not marshaled as text", func(t *testing.T) {
+		mapObj := map[textColor]int{1: 5}
+
+		doc, err := Marshal(mapObj)
+		assert.Nil(t, err, "Marshal error: %v", err)
+		_, err = Raw(doc).LookupErr("1")
+		assert.Nil(t, err, "expected key 1 in %v", Raw(doc))
+
+		var got map[textColor]int
+		err = Unmarshal(doc, &got)
+		assert.Nil(t, err, "Unmarshal error: %v", err)
+		assert.Equal(t, mapObj, got, "expected result %v, got %v", mapObj, got)
+	})
 }
 
 func TestExtJSONEscapeKey(t *testing.T) {
@@ -111,6 +111,47 @@ func (vde ValueDecoderError) Error() string {
 type EncodeContext struct {
 	*Registry
 	MinSize bool
+
+	// NilSliceAsEmpty causes nil slices to be encoded as empty BSON arrays, or as empty BSON binary data for []byte,
+	// instead of BSON null. This can also be set for all encoding done with a Registry through
+	// RegistryBuilder.SetNilSliceAsEmpty.
+	NilSliceAsEmpty bool
+
+	// NilMapAsEmpty causes nil maps to be encoded as empty BSON documents instead of BSON null. This can also be set for
+	// all encoding done with a Registry through RegistryBuilder.SetNilMapAsEmpty.
+	NilMapAsEmpty bool
+
+	// OmitZeroStruct causes struct fields tagged with omitempty to be omitted if their value is a zero struct. This can
+	// also be set for all encoding done with a Registry through RegistryBuilder.SetOmitZeroStruct.
+	OmitZeroStruct bool
+
+	// MaxDepth is the maximum nesting depth of embedded documents and arrays. A MaxDepthError is returned if a value
+	// would be nested more deeply. The top-level document has a depth of 1. The default value of 0 means there is no
+	// limit.
+	MaxDepth int
+
+	// MaxSize is the maximum number of bytes that can be encoded. A MaxSizeError is returned once the encoded bytes
+	// exceed this size. The size is checked each time a document or array is started, so the limit is only enforced
+	// for ValueWriters created by the bsonrw package. The default value of 0 means there is no limit.
+	MaxSize int
+
+	// DetectCycles causes a CycleError to be returned if a pointer, map, or slice refers to a value that contains it
+	// instead of recursing until the stack is exhausted.
+	DetectCycles bool
+
+	limits *encodeLimits
+}
+
+func (ec EncodeContext) nilSliceAsEmpty() bool {
+	return ec.NilSliceAsEmpty || (ec.Registry != nil && ec.Registry.encodeNilSliceAsEmpty)
+}
+
+func (ec EncodeContext) nilMapAsEmpty() bool {
+	return ec.NilMapAsEmpty || (ec.Registry != nil && ec.Registry.encodeNilMapAsEmpty)
+}
+
+func (ec EncodeContext) omitZeroStruct() bool {
+	return ec.OmitZeroStruct || (ec.Registry != nil && ec.Registry.encodeOmitZeroStruct)
 }
 
 // DecodeContext is the contextual information required for a Codec to decode a
@@ -123,6 +164,49 @@ type DecodeContext struct {
 	// Ancestor is a bson.M, BSON embedded document values being decoded into an empty interface
 	// will be decoded into a bson.M.
 	Ancestor reflect.Type
+
+	// DuplicateKeyPolicy specifies how documents that contain the same key more than once are decoded into structs,
+	// maps, and primitive.D values. The zero value is DefaultDuplicateKeys.
+	DuplicateKeyPolicy DuplicateKeyPolicy
+
+	// NumericConversionPolicy specifies how BSON numbers that cannot be represented exactly by the Go numeric type they
+	// are decoded into are handled. The zero value is DefaultNumericConversion.
+	NumericConversionPolicy NumericConversionPolicy
+
+	// SliceCapacityHint is the capacity allocated for the slices and primitive.D values created while decoding BSON
+	// arrays and documents. The zero value allocates no capacity up front.
+	SliceCapacityHint int
+
+	// MapCapacityHint is the size hint used to allocate the maps created while decoding BSON documents. The zero value
+	// uses the default map size.
+	MapCapacityHint int
+
+	// SizeFromBSON specifies that the slices and maps created while decoding are sized with the number of elements of
+	// the BSON array or document being decoded, which is counted from its length prefixes before it is decoded. This
+	// trades an extra walk over the bytes for fewer allocations when decoding large arrays and documents. The capacity
+	// hints are used if the ValueReader does not implement bsonrw.ElementCounter.
+	SizeFromBSON bool
+
+	// StringInterner, if set, is used to intern the BSON string and symbol values decoded into Go strings, so that
+	// repeated values such as those of enum-like fields share their memory when decoding large result sets. A
+	// StringPool can be used as a built-in interner.
+	StringInterner StringInterner
+}
+
+// capacity returns the number of elements to allocate for the array or document that vr is positioned at, given the
+// configured hint.
+func (dc DecodeContext) capacity(vr bsonrw.ValueReader, hint int) int {
+	if dc.SizeFromBSON {
+		if counter, ok := vr.(bsonrw.ElementCounter); ok {
+			if count, ok := counter.ElementCount(); ok {
+				return count
+			}
+		}
+	}
+	if hint < 0 {
+		return 0
+	}
+	return hint
 }
 
 // ValueCodec is the interface that groups the methods to encode and decode
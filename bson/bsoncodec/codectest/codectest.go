@@ -0,0 +1,186 @@
+// Copyright (C) MongoDB, Inc. 2021-present.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
+
+// Package codectest provides a conformance harness for ValueEncoder and ValueDecoder implementations. Authors of custom
+// codecs can use it from their own tests to check that values survive a round trip through BSON and that decoding
+// arbitrary, possibly malformed, input never panics.
+//
+// A typical test registers the codec under test on a registry and runs both checks:
+//
+//	func TestMyCodec(t *testing.T) {
+//		rb := bson.NewRegistryBuilder()
+//		rb.RegisterTypeEncoder(tMyType, myCodec).RegisterTypeDecoder(tMyType, myCodec)
+//		reg := rb.Build()
+//
+//		codectest.RoundTrip(t, reg, MyType{...}, MyType{...})
+//		codectest.RoundTripProperty(t, reg, tMyType, nil)
+//		codectest.Decode(t, reg, tMyType, codectest.Seeds()...)
+//	}
+package codectest
+
+import (
+	"fmt"
+	"math/rand"
+	"reflect"
+	"testing"
+	"testing/quick"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/bsoncodec"
+	"go.mongodb.org/mongo-driver/x/bsonx/bsoncore"
+)
+
+// RoundTrip encodes each of the values with reg as the value of a document field, decodes the document into a new
+// value of the same type, and reports an error on t if the result is not deeply equal to the original value.
+func RoundTrip(t testing.TB, reg *bsoncodec.Registry, values ...interface{}) {
+	t.Helper()
+
+	for _, val := range values {
+		if err := roundTrip(reg, reflect.ValueOf(val)); err != nil {
+			t.Errorf("round trip of %#v failed: %v", val, err)
+		}
+	}
+}
+
+// RoundTripProperty runs RoundTrip for values of type typ generated by the testing/quick package. The cfg parameter
+// can be used to configure the number of values and how they are generated. It can be nil, in which case the
+// testing/quick defaults are used. Types that testing/quick cannot generate, e.g. types with interface fields, require
+// cfg.Values to be set.
+func RoundTripProperty(t testing.TB, reg *bsoncodec.Registry, typ reflect.Type, cfg *quick.Config) {
+	t.Helper()
+
+	if cfg == nil {
+		cfg = &quick.Config{}
+	}
+	rnd := cfg.Rand
+	if rnd == nil {
+		rnd = rand.New(rand.NewSource(rand.Int63()))
+	}
+
+	for i := 0; i < maxCount(cfg); i++ {
+		var val reflect.Value
+		if cfg.Values != nil {
+			args := make([]reflect.Value, 1)
+			cfg.Values(args, rnd)
+			val = args[0]
+		} else {
+			var ok bool
+			if val, ok = quick.Value(typ, rnd); !ok {
+				t.Fatalf("cannot generate values of type %s; set quick.Config.Values to generate them", typ)
+			}
+		}
+
+		if err := roundTrip(reg, val); err != nil {
+			t.Errorf("round trip of generated value %#v failed: %v", val.Interface(), err)
+			return
+		}
+	}
+}
+
+// Decode decodes each of the documents into a new value of type typ with reg and reports an error on t if decoding
+// panics. If a document is decoded without error, the value must also encode without error. Decode does not check
+// whether the documents can be decoded because they are usually malformed on purpose, as with those returned by Seeds.
+// Types that hold the undecoded bytes of a value without validating them, such as bson.Raw, are not suitable for Decode.
+func Decode(t testing.TB, reg *bsoncodec.Registry, typ reflect.Type, docs ...[]byte) {
+	t.Helper()
+
+	for _, doc := range docs {
+		if err := decode(reg, typ, doc); err != nil {
+			t.Errorf("decoding %x into %s failed: %v", doc, typ, err)
+		}
+	}
+}
+
+// roundTrip encodes val within a document, decodes it into a new value, and compares the two.
+func roundTrip(reg *bsoncodec.Registry, val reflect.Value) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("panic: %v", r)
+		}
+	}()
+
+	if !val.IsValid() {
+		return fmt.Errorf("cannot round trip an untyped nil value")
+	}
+
+	wrapperType := wrapperOf(val.Type())
+	in := reflect.New(wrapperType).Elem()
+	in.Field(0).Set(val)
+
+	b, err := bson.MarshalWithRegistry(reg, in.Interface())
+	if err != nil {
+		return fmt.Errorf("encoding error: %v", err)
+	}
+
+	out := reflect.New(wrapperType)
+	if err = bson.UnmarshalWithRegistry(reg, b, out.Interface()); err != nil {
+		return fmt.Errorf("decoding error: %v; encoded document: %v", err, bson.Raw(b))
+	}
+
+	got := out.Elem().Field(0).Interface()
+	if !reflect.DeepEqual(val.Interface(), got) {
+		return fmt.Errorf("decoded value %#v does not equal the original value; encoded document: %v", got, bson.Raw(b))
+	}
+	return nil
+}
+
+// decode decodes doc into a new value of type typ and each of the values in doc into a field of type typ. A value that
+// is decoded without error must also be encoded without error.
+func decode(reg *bsoncodec.Registry, typ reflect.Type, doc []byte) error {
+	if err := decodeAndEncode(reg, typ, doc, false); err != nil {
+		return err
+	}
+
+	// The elements are read on a best effort basis so the valid prefix of a malformed document is still used.
+	elems, _ := bsoncore.Document(doc).Elements()
+	for _, elem := range elems {
+		val, err := elem.ValueErr()
+		if err != nil {
+			continue
+		}
+
+		wrapped := bsoncore.BuildDocumentFromElements(nil, bsoncore.AppendValueElement(nil, "v", val))
+		if err = decodeAndEncode(reg, typ, wrapped, true); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func decodeAndEncode(reg *bsoncodec.Registry, typ reflect.Type, doc []byte, wrapped bool) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("panic: %v", r)
+		}
+	}()
+
+	if wrapped {
+		typ = wrapperOf(typ)
+	}
+	out := reflect.New(typ)
+	if bson.UnmarshalWithRegistry(reg, doc, out.Interface()) != nil {
+		return nil
+	}
+	if _, err = bson.MarshalWithRegistry(reg, out.Interface()); err != nil {
+		return fmt.Errorf("decoded value %#v cannot be encoded: %v", out.Elem().Interface(), err)
+	}
+	return nil
+}
+
+// wrapperOf returns a struct type with a single field of type typ so values that are not documents can be encoded.
+func wrapperOf(typ reflect.Type) reflect.Type {
+	return reflect.StructOf([]reflect.StructField{{Name: "V", Type: typ, Tag: `bson:"v"`}})
+}
+
+func maxCount(cfg *quick.Config) int {
+	if cfg.MaxCount > 0 {
+		return cfg.MaxCount
+	}
+	if cfg.MaxCountScale > 0 {
+		return int(100 * cfg.MaxCountScale)
+	}
+	return 100
+}
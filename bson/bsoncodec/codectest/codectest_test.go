@@ -0,0 +1,121 @@
+// Copyright (C) MongoDB, Inc. 2021-present.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
+
+package codectest
+
+import (
+	"fmt"
+	"math/rand"
+	"reflect"
+	"testing"
+	"testing/quick"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/bsoncodec"
+	"go.mongodb.org/mongo-driver/bson/bsonrw"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/internal/testutil/assert"
+)
+
+// recorder is a testing.TB that records failures instead of reporting them.
+type recorder struct {
+	testing.TB
+	failures []string
+}
+
+func (r *recorder) Helper() {}
+
+func (r *recorder) Errorf(format string, args ...interface{}) {
+	r.failures = append(r.failures, fmt.Sprintf(format, args...))
+}
+
+func (r *recorder) Fatalf(format string, args ...interface{}) {
+	r.Errorf(format, args...)
+}
+
+type propertyStruct struct {
+	Str     string
+	I32     int32
+	I64     int64
+	F64     float64
+	Bool    bool
+	Bytes   []byte
+	Strs    []string
+	Nested  *propertyStruct
+	Counts  map[string]int64
+	Unnamed struct{ A, B int32 }
+}
+
+type smallInt int32
+
+// brokenEncoder drops the lowest bit of smallInt values so the round trip check fails.
+func brokenEncoder(_ bsoncodec.EncodeContext, vw bsonrw.ValueWriter, val reflect.Value) error {
+	return vw.WriteInt32(int32(val.Int()) &^ 1)
+}
+
+// panickingDecoder panics for every value so the Decode check fails.
+func panickingDecoder(bsoncodec.DecodeContext, bsonrw.ValueReader, reflect.Value) error {
+	panic("decoder panic")
+}
+
+func TestCodecTest(t *testing.T) {
+	t.Run("round trip default codecs", func(t *testing.T) {
+		RoundTrip(t, bson.DefaultRegistry,
+			"hello", int32(1), int64(-1), 3.5, true, []byte{1, 2}, []string{"a", "b"},
+			map[string]int32{"a": 1}, primitive.NewObjectID(), primitive.DateTime(1600000000000),
+			primitive.Timestamp{T: 1, I: 2}, primitive.Regex{Pattern: "a", Options: "i"},
+			bson.D{{"a", int32(1)}}, propertyStruct{Str: "x", Counts: map[string]int64{"a": 1}},
+		)
+	})
+	t.Run("round trip property default codecs", func(t *testing.T) {
+		cfg := &quick.Config{MaxCount: 50, Rand: rand.New(rand.NewSource(1))}
+		for _, typ := range []reflect.Type{
+			reflect.TypeOf(int64(0)),
+			reflect.TypeOf(float64(0)),
+			reflect.TypeOf(""),
+			reflect.TypeOf([]int32{}),
+			reflect.TypeOf(propertyStruct{}),
+		} {
+			RoundTripProperty(t, bson.DefaultRegistry, typ, cfg)
+		}
+	})
+	t.Run("decode seeds default codecs", func(t *testing.T) {
+		for _, typ := range []reflect.Type{
+			reflect.TypeOf(bson.D{}),
+			reflect.TypeOf(bson.M{}),
+			reflect.TypeOf(propertyStruct{}),
+		} {
+			Decode(t, bson.DefaultRegistry, typ, Seeds()...)
+		}
+	})
+	t.Run("seeds include malformed documents", func(t *testing.T) {
+		var valid, invalid int
+		for _, seed := range Seeds() {
+			if bson.Raw(seed).Validate() == nil {
+				valid++
+			} else {
+				invalid++
+			}
+		}
+		assert.True(t, valid > 0, "expected valid seeds, got none")
+		assert.True(t, invalid > 0, "expected invalid seeds, got none")
+	})
+	t.Run("detects broken codecs", func(t *testing.T) {
+		tSmallInt := reflect.TypeOf(smallInt(0))
+		reg := bson.NewRegistryBuilder().
+			RegisterTypeEncoder(tSmallInt, bsoncodec.ValueEncoderFunc(brokenEncoder)).
+			RegisterTypeDecoder(tSmallInt, bsoncodec.ValueDecoderFunc(panickingDecoder)).
+			Build()
+
+		r := &recorder{TB: t}
+		RoundTrip(r, reg, smallInt(3))
+		assert.Equal(t, 1, len(r.failures), "expected 1 RoundTrip failure, got %v", r.failures)
+
+		r = &recorder{TB: t}
+		Decode(r, reg, tSmallInt, Seeds()...)
+		assert.True(t, len(r.failures) > 0, "expected Decode failures, got none")
+	})
+}
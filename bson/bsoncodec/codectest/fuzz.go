@@ -0,0 +1,57 @@
+// Copyright (C) MongoDB, Inc. 2021-present.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
+
+// +build gofuzz
+
+package codectest
+
+import (
+	"reflect"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// fuzzStruct has a field for every kind of value handled by the default codecs.
+type fuzzStruct struct {
+	Double   float64
+	Float    float32
+	String   string
+	Document map[string]interface{}
+	Array    []interface{}
+	Binary   []byte
+	OID      primitive.ObjectID
+	Bool     bool
+	DateTime primitive.DateTime
+	Int32    int32
+	Int64    int64
+	Int8     int8
+	Uint     uint
+	Ptr      *string
+	Inline   map[string]interface{} `bson:",inline"`
+}
+
+var fuzzTypes = []reflect.Type{
+	reflect.TypeOf(bson.D{}),
+	reflect.TypeOf(bson.M{}),
+	reflect.TypeOf(fuzzStruct{}),
+}
+
+// Fuzz is the go-fuzz entry point for the core codecs. It decodes data into several types with the default registry
+// and panics if decoding panics or a decoded value cannot be encoded. The documents returned by Seeds can be used as
+// its initial corpus.
+func Fuzz(data []byte) int {
+	for _, typ := range fuzzTypes {
+		if err := decode(bson.DefaultRegistry, typ, data); err != nil {
+			panic(err)
+		}
+	}
+
+	if bson.Raw(data).Validate() != nil {
+		return 0
+	}
+	return 1
+}
@@ -0,0 +1,125 @@
+// Copyright (C) MongoDB, Inc. 2021-present.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
+
+package codectest
+
+import (
+	"encoding/binary"
+	"math"
+
+	"go.mongodb.org/mongo-driver/bson/bsontype"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/x/bsonx/bsoncore"
+)
+
+// Seeds returns BSON documents that can be used as inputs for Decode or as the seed corpus of a fuzzer. They are modeled
+// on the BSON corpus and contain a document with one element for every BSON type, documents with boundary values for the
+// numeric types, and malformed variants of those documents. A new slice is returned by every call, so the documents can
+// be modified by the caller.
+func Seeds() [][]byte {
+	valid := [][]byte{
+		bsoncore.BuildDocument(nil),
+		allTypesDocument(),
+		bsoncore.BuildDocumentFromElements(nil,
+			bsoncore.AppendInt32Element(nil, "min", math.MinInt32),
+			bsoncore.AppendInt32Element(nil, "max", math.MaxInt32),
+		),
+		bsoncore.BuildDocumentFromElements(nil,
+			bsoncore.AppendInt64Element(nil, "min", math.MinInt64),
+			bsoncore.AppendInt64Element(nil, "max", math.MaxInt64),
+		),
+		bsoncore.BuildDocumentFromElements(nil,
+			bsoncore.AppendDoubleElement(nil, "inf", math.Inf(1)),
+			bsoncore.AppendDoubleElement(nil, "-inf", math.Inf(-1)),
+			bsoncore.AppendDoubleElement(nil, "nan", math.NaN()),
+			bsoncore.AppendDoubleElement(nil, "max", math.MaxFloat64),
+			bsoncore.AppendDoubleElement(nil, "frac", 1.5),
+		),
+		bsoncore.BuildDocumentFromElements(nil,
+			bsoncore.AppendStringElement(nil, "empty", ""),
+			bsoncore.AppendStringElement(nil, "unicode", "é世\U0001f600"),
+			bsoncore.AppendStringElement(nil, "null", "a\x00b"),
+		),
+		bsoncore.BuildDocumentFromElements(nil,
+			bsoncore.AppendInt32Element(nil, "dup", 1),
+			bsoncore.AppendStringElement(nil, "dup", "two"),
+		),
+		nestedDocument(100),
+	}
+
+	seeds := make([][]byte, 0, 4*len(valid))
+	for _, doc := range valid {
+		seeds = append(seeds, doc)
+		seeds = append(seeds, malformed(doc)...)
+	}
+	return seeds
+}
+
+// allTypesDocument returns a document with one element of every BSON type.
+func allTypesDocument() []byte {
+	oid := primitive.ObjectID{0x5f, 0x3e, 0x2d, 0x1c, 0x0b, 0xfa, 0xe9, 0xd8, 0xc7, 0xb6, 0xa5, 0x94}
+	sub := bsoncore.BuildDocumentFromElements(nil, bsoncore.AppendStringElement(nil, "a", "b"))
+	arr := bsoncore.BuildArray(nil,
+		bsoncore.Value{Type: bsontype.Int32, Data: bsoncore.AppendInt32(nil, 1)},
+		bsoncore.Value{Type: bsontype.String, Data: bsoncore.AppendString(nil, "two")},
+	)
+
+	return bsoncore.BuildDocumentFromElements(nil,
+		bsoncore.AppendDoubleElement(nil, "double", 3.14159),
+		bsoncore.AppendStringElement(nil, "string", "hello"),
+		bsoncore.AppendDocumentElement(nil, "document", sub),
+		bsoncore.AppendArrayElement(nil, "array", arr),
+		bsoncore.AppendBinaryElement(nil, "binary", bsontype.BinaryGeneric, []byte{0x01, 0x02}),
+		bsoncore.AppendBinaryElement(nil, "uuid", bsontype.BinaryUUID, make([]byte, 16)),
+		bsoncore.AppendUndefinedElement(nil, "undefined"),
+		bsoncore.AppendObjectIDElement(nil, "oid", oid),
+		bsoncore.AppendBooleanElement(nil, "bool", true),
+		bsoncore.AppendDateTimeElement(nil, "datetime", 1600000000000),
+		bsoncore.AppendNullElement(nil, "null"),
+		bsoncore.AppendRegexElement(nil, "regex", "^a.*b$", "i"),
+		bsoncore.AppendDBPointerElement(nil, "dbpointer", "db.coll", oid),
+		bsoncore.AppendJavaScriptElement(nil, "javascript", "function() { return 1; }"),
+		bsoncore.AppendSymbolElement(nil, "symbol", "sym"),
+		bsoncore.AppendCodeWithScopeElement(nil, "codewithscope", "function() { return x; }", sub),
+		bsoncore.AppendInt32Element(nil, "int32", 42),
+		bsoncore.AppendTimestampElement(nil, "timestamp", 1600000000, 1),
+		bsoncore.AppendInt64Element(nil, "int64", 1<<40),
+		bsoncore.AppendDecimal128Element(nil, "decimal128", primitive.NewDecimal128(0x3040000000000000, 12345)),
+		bsoncore.AppendMaxKeyElement(nil, "maxkey"),
+		bsoncore.AppendMinKeyElement(nil, "minkey"),
+	)
+}
+
+// nestedDocument returns a document with embedded documents nested depth levels deep.
+func nestedDocument(depth int) []byte {
+	doc := bsoncore.BuildDocument(nil)
+	for i := 0; i < depth; i++ {
+		doc = bsoncore.BuildDocumentFromElements(nil, bsoncore.AppendDocumentElement(nil, "n", doc))
+	}
+	return doc
+}
+
+// malformed returns invalid variants of doc: a truncated copy, a copy with an inflated length, and a copy without the
+// trailing null byte.
+func malformed(doc []byte) [][]byte {
+	var docs [][]byte
+
+	if len(doc) > 8 {
+		truncated := append([]byte(nil), doc[:len(doc)/2]...)
+		binary.LittleEndian.PutUint32(truncated, uint32(len(truncated)))
+		docs = append(docs, truncated)
+	}
+
+	inflated := append([]byte(nil), doc...)
+	binary.LittleEndian.PutUint32(inflated, uint32(len(doc)+8))
+	docs = append(docs, inflated)
+
+	unterminated := append([]byte(nil), doc[:len(doc)-1]...)
+	binary.LittleEndian.PutUint32(unterminated, uint32(len(unterminated)))
+	docs = append(docs, unterminated)
+
+	return docs
+}
@@ -167,6 +167,7 @@ func (dvd DefaultValueDecoders) DDecodeValue(dc DecodeContext, vr bsonrw.ValueRe
 		elems = make(primitive.D, 0)
 	}
 
+	dupKeys := newDuplicateKeys(dc.DuplicateKeyPolicy)
 	for {
 		key, elemVr, err := dr.ReadElement()
 		if err == bsonrw.ErrEOD {
@@ -175,13 +176,31 @@ func (dvd DefaultValueDecoders) DDecodeValue(dc DecodeContext, vr bsonrw.ValueRe
 			return err
 		}
 
+		first, action, err := dupKeys.observe(key, len(elems))
+		if err != nil {
+			return err
+		}
+		if action == skipDuplicateKey {
+			if err = elemVr.Skip(); err != nil {
+				return err
+			}
+			continue
+		}
+
 		// Pass false for convert because we don't need to call reflect.Value.Convert for tEmpty.
 		elem, err := decodeTypeOrValueWithInfo(decoder, tEmptyTypeDecoder, dc, elemVr, tEmpty, false)
 		if err != nil {
 			return err
 		}
 
-		elems = append(elems, primitive.E{Key: key, Value: elem.Interface()})
+		switch action {
+		case replaceDuplicateKey:
+			elems[first].Value = elem.Interface()
+		case collectDuplicateKey:
+			elems[first].Value = dupKeys.collect(key, elems[first].Value, elem.Interface())
+		default:
+			elems = append(elems, primitive.E{Key: key, Value: elem.Interface()})
+		}
 	}
 
 	val.Set(reflect.ValueOf(elems))
@@ -269,13 +288,10 @@ func (DefaultValueDecoders) intDecodeType(dc DecodeContext, vr bsonrw.ValueReade
 		if err != nil {
 			return emptyValue, err
 		}
-		if !dc.Truncate && math.Floor(f64) != f64 {
-			return emptyValue, errCannotTruncate
-		}
-		if f64 > float64(math.MaxInt64) {
-			return emptyValue, fmt.Errorf("%g overflows int64", f64)
+		i64, err = dc.doubleToInt64(f64)
+		if err != nil {
+			return emptyValue, err
 		}
-		i64 = int64(f64)
 	case bsontype.Boolean:
 		b, err := vr.ReadBoolean()
 		if err != nil {
@@ -298,28 +314,32 @@ func (DefaultValueDecoders) intDecodeType(dc DecodeContext, vr bsonrw.ValueReade
 
 	switch t.Kind() {
 	case reflect.Int8:
-		if i64 < math.MinInt8 || i64 > math.MaxInt8 {
-			return emptyValue, fmt.Errorf("%d overflows int8", i64)
+		i64, err = dc.convertInt(i64, math.MinInt8, math.MaxInt8, "int8")
+		if err != nil {
+			return emptyValue, err
 		}
 
 		return reflect.ValueOf(int8(i64)), nil
 	case reflect.Int16:
-		if i64 < math.MinInt16 || i64 > math.MaxInt16 {
-			return emptyValue, fmt.Errorf("%d overflows int16", i64)
+		i64, err = dc.convertInt(i64, math.MinInt16, math.MaxInt16, "int16")
+		if err != nil {
+			return emptyValue, err
 		}
 
 		return reflect.ValueOf(int16(i64)), nil
 	case reflect.Int32:
-		if i64 < math.MinInt32 || i64 > math.MaxInt32 {
-			return emptyValue, fmt.Errorf("%d overflows int32", i64)
+		i64, err = dc.convertInt(i64, math.MinInt32, math.MaxInt32, "int32")
+		if err != nil {
+			return emptyValue, err
 		}
 
 		return reflect.ValueOf(int32(i64)), nil
 	case reflect.Int64:
 		return reflect.ValueOf(i64), nil
 	case reflect.Int:
-		if int64(int(i64)) != i64 { // Can we fit this inside of an int
-			return emptyValue, fmt.Errorf("%d overflows int", i64)
+		i64, err = dc.convertInt(i64, minInt, maxInt, "int")
+		if err != nil {
+			return emptyValue, err
 		}
 
 		return reflect.ValueOf(int(i64)), nil
@@ -449,7 +469,10 @@ func (dvd DefaultValueDecoders) floatDecodeType(ec DecodeContext, vr bsonrw.Valu
 		if err != nil {
 			return emptyValue, err
 		}
-		f = float64(i64)
+		f, err = ec.int64ToDouble(i64)
+		if err != nil {
+			return emptyValue, err
+		}
 	case bsontype.Double:
 		f, err = vr.ReadDouble()
 		if err != nil {
@@ -477,11 +500,12 @@ func (dvd DefaultValueDecoders) floatDecodeType(ec DecodeContext, vr bsonrw.Valu
 
 	switch t.Kind() {
 	case reflect.Float32:
-		if !ec.Truncate && float64(float32(f)) != f {
-			return emptyValue, errCannotTruncate
+		f32, err := ec.doubleToFloat32(f)
+		if err != nil {
+			return emptyValue, err
 		}
 
-		return reflect.ValueOf(float32(f)), nil
+		return reflect.ValueOf(f32), nil
 	case reflect.Float64:
 		return reflect.ValueOf(f), nil
 	default:
@@ -1566,7 +1590,7 @@ func (DefaultValueDecoders) CoreDocumentDecodeValue(dc DecodeContext, vr bsonrw.
 }
 
 func (dvd DefaultValueDecoders) decodeDefault(dc DecodeContext, vr bsonrw.ValueReader, val reflect.Value) ([]reflect.Value, error) {
-	elems := make([]reflect.Value, 0)
+	elems := make([]reflect.Value, 0, dc.capacity(vr, dc.SliceCapacityHint))
 
 	ar, err := vr.ReadArray()
 	if err != nil {
@@ -1611,7 +1635,7 @@ func (dvd DefaultValueDecoders) readCodeWithScope(dc DecodeContext, vr bsonrw.Va
 	}
 
 	scope := reflect.New(tD).Elem()
-	elems, err := dvd.decodeElemsFromDocumentReader(dc, dr)
+	elems, err := dvd.decodeElemsFromDocumentReader(dc, dr, dc.capacity(nil, dc.SliceCapacityHint))
 	if err != nil {
 		return cws, err
 	}
@@ -1676,21 +1700,23 @@ func (dvd DefaultValueDecoders) decodeD(dc DecodeContext, vr bsonrw.ValueReader,
 		return nil, fmt.Errorf("cannot decode %v into a D", vr.Type())
 	}
 
+	capacity := dc.capacity(vr, dc.SliceCapacityHint)
 	dr, err := vr.ReadDocument()
 	if err != nil {
 		return nil, err
 	}
 
-	return dvd.decodeElemsFromDocumentReader(dc, dr)
+	return dvd.decodeElemsFromDocumentReader(dc, dr, capacity)
 }
 
-func (DefaultValueDecoders) decodeElemsFromDocumentReader(dc DecodeContext, dr bsonrw.DocumentReader) ([]reflect.Value, error) {
+func (DefaultValueDecoders) decodeElemsFromDocumentReader(dc DecodeContext, dr bsonrw.DocumentReader, capacity int) ([]reflect.Value, error) {
 	decoder, err := dc.LookupDecoder(tEmpty)
 	if err != nil {
 		return nil, err
 	}
 
-	elems := make([]reflect.Value, 0)
+	elems := make(primitive.D, 0, capacity)
+	dupKeys := newDuplicateKeys(dc.DuplicateKeyPolicy)
 	for {
 		key, vr, err := dr.ReadElement()
 		if err == bsonrw.ErrEOD {
@@ -1700,14 +1726,36 @@ func (DefaultValueDecoders) decodeElemsFromDocumentReader(dc DecodeContext, dr b
 			return nil, err
 		}
 
+		first, action, err := dupKeys.observe(key, len(elems))
+		if err != nil {
+			return nil, err
+		}
+		if action == skipDuplicateKey {
+			if err = vr.Skip(); err != nil {
+				return nil, err
+			}
+			continue
+		}
+
 		val := reflect.New(tEmpty).Elem()
 		err = decoder.DecodeValue(dc, vr, val)
 		if err != nil {
 			return nil, newDecodeError(key, err)
 		}
 
-		elems = append(elems, reflect.ValueOf(primitive.E{Key: key, Value: val.Interface()}))
+		switch action {
+		case replaceDuplicateKey:
+			elems[first].Value = val.Interface()
+		case collectDuplicateKey:
+			elems[first].Value = dupKeys.collect(key, elems[first].Value, val.Interface())
+		default:
+			elems = append(elems, primitive.E{Key: key, Value: val.Interface()})
+		}
 	}
 
-	return elems, nil
+	vals := make([]reflect.Value, 0, len(elems))
+	for _, elem := range elems {
+		vals = append(vals, reflect.ValueOf(elem))
+	}
+	return vals, nil
 }
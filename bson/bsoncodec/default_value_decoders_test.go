@@ -2764,6 +2764,42 @@ func TestDefaultValueDecoders(t *testing.T) {
 				buildDocument(bsoncore.AppendStringElement(nil, "foo", "bar")),
 				nil,
 			},
+			{
+				"inline map with typed values",
+				struct {
+					A   string
+					Foo map[string]int32 `bson:",inline"`
+				}{
+					A:   "bar",
+					Foo: map[string]int32{"foo": 42},
+				},
+				buildDocument(bsoncore.AppendInt32Element(bsoncore.AppendStringElement(nil, "a", "bar"), "foo", 42)),
+				nil,
+			},
+			{
+				"inline map with struct values",
+				struct {
+					Foo map[string]struct {
+						B string
+					} `bson:",inline"`
+				}{
+					Foo: map[string]struct {
+						B string
+					}{"foo": {B: "baz"}},
+				},
+				buildDocument(bsoncore.AppendDocumentElement(nil, "foo", buildDocument(bsoncore.AppendStringElement(nil, "b", "baz")))),
+				nil,
+			},
+			{
+				"inline map with named string keys",
+				struct {
+					Foo map[mystring]string `bson:",inline"`
+				}{
+					Foo: map[mystring]string{"foo": "bar"},
+				},
+				buildDocument(bsoncore.AppendStringElement(nil, "foo", "bar")),
+				nil,
+			},
 			{
 				"alternate name bson:name",
 				struct {
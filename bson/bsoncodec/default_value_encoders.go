@@ -355,6 +355,12 @@ func (dve DefaultValueEncoders) ArrayEncodeValue(ec EncodeContext, vw bsonrw.Val
 		return ValueEncoderError{Name: "ArrayEncodeValue", Kinds: []reflect.Kind{reflect.Array}, Received: val}
 	}
 
+	ec, exit, err := ec.enter(vw, val, val.Type().Elem() != tByte)
+	if err != nil {
+		return err
+	}
+	defer exit()
+
 	// If we have a []primitive.E we want to treat it as a document instead of as an array.
 	if val.Type().Elem() == tE {
 		dw, err := vw.WriteDocument()
@@ -739,6 +745,11 @@ func (dve DefaultValueEncoders) CodeWithScopeEncodeValue(ec EncodeContext, vw bs
 		return err
 	}
 
+	// A nil scope is written as an empty document.
+	if cws.Scope == nil {
+		return dw.WriteDocumentEnd()
+	}
+
 	sw := sliceWriterPool.Get().(*bsonrw.SliceWriter)
 	defer sliceWriterPool.Put(sw)
 	*sw = (*sw)[:0]
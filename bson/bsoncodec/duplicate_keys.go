@@ -0,0 +1,126 @@
+// Copyright (C) MongoDB, Inc. 2021-present.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
+
+package bsoncodec
+
+import (
+	"fmt"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// DuplicateKeyPolicy specifies how decoders handle a document that contains the same key more than once.
+type DuplicateKeyPolicy int
+
+// These constants specify the valid values for DuplicateKeyPolicy.
+const (
+	// DefaultDuplicateKeys keeps the historical behavior: the last value wins when decoding into a struct or a map and
+	// every element is kept when decoding into a primitive.D.
+	DefaultDuplicateKeys DuplicateKeyPolicy = iota
+
+	// ErrorOnDuplicateKeys causes decoding to fail with a DuplicateKeyError.
+	ErrorOnDuplicateKeys
+
+	// FirstDuplicateKeyWins keeps the value of the first occurrence of a key and skips the others.
+	FirstDuplicateKeyWins
+
+	// LastDuplicateKeyWins keeps the value of the last occurrence of a key. When decoding into a primitive.D, the
+	// element stays at the position of the first occurrence.
+	LastDuplicateKeyWins
+
+	// CollectDuplicateKeys gathers the values of all occurrences of a key into a primitive.A. This is only possible
+	// for maps with interface{} values and for primitive.D. Duplicate keys that cannot be collected, such as those
+	// for struct fields, result in a DuplicateKeyError.
+	CollectDuplicateKeys
+)
+
+func (dkp DuplicateKeyPolicy) String() string {
+	switch dkp {
+	case DefaultDuplicateKeys:
+		return "default"
+	case ErrorOnDuplicateKeys:
+		return "error"
+	case FirstDuplicateKeyWins:
+		return "first wins"
+	case LastDuplicateKeyWins:
+		return "last wins"
+	case CollectDuplicateKeys:
+		return "collect"
+	default:
+		return fmt.Sprintf("unknown DuplicateKeyPolicy %d", int(dkp))
+	}
+}
+
+// DuplicateKeyError is returned when a document contains a duplicate key that is not allowed by the
+// DecodeContext.DuplicateKeyPolicy.
+type DuplicateKeyError struct {
+	Key string
+}
+
+func (dke DuplicateKeyError) Error() string {
+	return fmt.Sprintf("duplicate key %q in document", dke.Key)
+}
+
+type duplicateKeyAction int
+
+const (
+	decodeDuplicateKey duplicateKeyAction = iota
+	skipDuplicateKey
+	replaceDuplicateKey
+	collectDuplicateKey
+)
+
+// duplicateKeys tracks the keys seen while decoding a single document. A nil *duplicateKeys applies the default policy.
+type duplicateKeys struct {
+	policy    DuplicateKeyPolicy
+	seen      map[string]int
+	collected map[string]struct{}
+}
+
+func newDuplicateKeys(policy DuplicateKeyPolicy) *duplicateKeys {
+	if policy == DefaultDuplicateKeys {
+		return nil
+	}
+	return &duplicateKeys{policy: policy, seen: make(map[string]int)}
+}
+
+// observe records that key was read at position idx and returns the position of its first occurrence along with the
+// action the decoder should take for the value.
+func (dk *duplicateKeys) observe(key string, idx int) (int, duplicateKeyAction, error) {
+	if dk == nil {
+		return idx, decodeDuplicateKey, nil
+	}
+
+	first, ok := dk.seen[key]
+	if !ok {
+		dk.seen[key] = idx
+		return idx, decodeDuplicateKey, nil
+	}
+
+	switch dk.policy {
+	case ErrorOnDuplicateKeys:
+		return first, decodeDuplicateKey, DuplicateKeyError{Key: key}
+	case FirstDuplicateKeyWins:
+		return first, skipDuplicateKey, nil
+	case CollectDuplicateKeys:
+		return first, collectDuplicateKey, nil
+	default:
+		return first, replaceDuplicateKey, nil
+	}
+}
+
+// collect returns the result of adding val to the values already decoded for key.
+func (dk *duplicateKeys) collect(key string, existing, val interface{}) primitive.A {
+	if dk.collected == nil {
+		dk.collected = make(map[string]struct{})
+	}
+	if _, ok := dk.collected[key]; ok {
+		return append(existing.(primitive.A), val)
+	}
+
+	dk.collected[key] = struct{}{}
+	return primitive.A{existing, val}
+}
@@ -0,0 +1,130 @@
+// Copyright (C) MongoDB, Inc. 2021-present.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
+
+package bsoncodec
+
+import (
+	"fmt"
+	"reflect"
+
+	"go.mongodb.org/mongo-driver/bson/bsonrw"
+)
+
+// MaxDepthError is returned when encoding a value would nest documents or arrays more deeply than
+// EncodeContext.MaxDepth allows.
+type MaxDepthError struct {
+	MaxDepth int
+}
+
+func (mde MaxDepthError) Error() string {
+	return fmt.Sprintf("cannot encode value: maximum nesting depth of %d exceeded", mde.MaxDepth)
+}
+
+// MaxSizeError is returned when the encoded bytes exceed the size allowed by EncodeContext.MaxSize.
+type MaxSizeError struct {
+	MaxSize int
+	Size    int
+}
+
+func (mse MaxSizeError) Error() string {
+	return fmt.Sprintf("cannot encode value: encoded size of %d bytes exceeds maximum of %d bytes", mse.Size, mse.MaxSize)
+}
+
+// CycleError is returned when EncodeContext.DetectCycles is true and a value of Type refers to a value that
+// contains it.
+type CycleError struct {
+	Type reflect.Type
+}
+
+func (ce CycleError) Error() string {
+	return fmt.Sprintf("cannot encode value: encountered a cycle via %s", ce.Type)
+}
+
+// encodeLimits holds the state used to enforce the MaxDepth, MaxSize, and DetectCycles settings while a single value is
+// being encoded.
+type encodeLimits struct {
+	depth int
+	start int
+	seen  map[encodeVisit]struct{}
+}
+
+type encodeVisit struct {
+	ptr uintptr
+	len int
+	typ reflect.Type
+}
+
+// lenWriter is implemented by the bsonrw ValueWriters that can report how many bytes have been written.
+type lenWriter interface {
+	Len() int
+}
+
+func noopExit() {}
+
+// enter must be called by codecs before encoding a value that can contain other values. The nests parameter
+// specifies whether val is encoded as an embedded document or array. The returned EncodeContext must be used to
+// encode the contents of val and the returned function must be called once val has been encoded.
+func (ec EncodeContext) enter(vw bsonrw.ValueWriter, val reflect.Value, nests bool) (EncodeContext, func(), error) {
+	if ec.MaxDepth <= 0 && ec.MaxSize <= 0 && !ec.DetectCycles {
+		return ec, noopExit, nil
+	}
+
+	lw, hasLen := vw.(lenWriter)
+	if ec.limits == nil {
+		ec.limits = &encodeLimits{}
+		if hasLen {
+			ec.limits.start = lw.Len()
+		}
+	}
+	limits := ec.limits
+
+	if ec.MaxSize > 0 && hasLen {
+		if size := lw.Len() - limits.start; size > ec.MaxSize {
+			return ec, noopExit, MaxSizeError{MaxSize: ec.MaxSize, Size: size}
+		}
+	}
+
+	var visit *encodeVisit
+	if ec.DetectCycles {
+		switch val.Kind() {
+		case reflect.Ptr, reflect.Map, reflect.Slice:
+			if !val.IsNil() {
+				v := encodeVisit{ptr: val.Pointer(), typ: val.Type()}
+				if val.Kind() == reflect.Slice {
+					v.len = val.Len()
+				}
+				if _, ok := limits.seen[v]; ok {
+					return ec, noopExit, CycleError{Type: val.Type()}
+				}
+				if limits.seen == nil {
+					limits.seen = make(map[encodeVisit]struct{})
+				}
+				limits.seen[v] = struct{}{}
+				visit = &v
+			}
+		}
+	}
+
+	if nests {
+		limits.depth++
+		if ec.MaxDepth > 0 && limits.depth > ec.MaxDepth {
+			limits.depth--
+			if visit != nil {
+				delete(limits.seen, *visit)
+			}
+			return ec, noopExit, MaxDepthError{MaxDepth: ec.MaxDepth}
+		}
+	}
+
+	return ec, func() {
+		if nests {
+			limits.depth--
+		}
+		if visit != nil {
+			delete(limits.seen, *visit)
+		}
+	}, nil
+}
@@ -7,6 +7,7 @@
 package bsoncodec
 
 import (
+	"encoding"
 	"fmt"
 	"reflect"
 	"strconv"
@@ -66,7 +67,7 @@ func (mc *MapCodec) EncodeValue(ec EncodeContext, vw bsonrw.ValueWriter, val ref
 		return ValueEncoderError{Name: "MapEncodeValue", Kinds: []reflect.Kind{reflect.Map}, Received: val}
 	}
 
-	if val.IsNil() && !mc.EncodeNilAsEmpty {
+	if val.IsNil() && !mc.EncodeNilAsEmpty && !ec.nilMapAsEmpty() {
 		// If we have a nil map but we can't WriteNull, that means we're probably trying to encode
 		// to a TopLevel document. We can't currently tell if this is what actually happened, but if
 		// there's a deeper underlying problem, the error will also be returned from WriteDocument,
@@ -78,6 +79,12 @@ func (mc *MapCodec) EncodeValue(ec EncodeContext, vw bsonrw.ValueWriter, val ref
 		}
 	}
 
+	ec, exit, err := ec.enter(vw, val, true)
+	if err != nil {
+		return err
+	}
+	defer exit()
+
 	dw, err := vw.WriteDocument()
 	if err != nil {
 		return err
@@ -160,13 +167,14 @@ func (mc *MapCodec) DecodeValue(dc DecodeContext, vr bsonrw.ValueReader, val ref
 		return fmt.Errorf("cannot decode %v into a %s", vrType, val.Type())
 	}
 
+	capacity := dc.capacity(vr, dc.MapCapacityHint)
 	dr, err := vr.ReadDocument()
 	if err != nil {
 		return err
 	}
 
 	if val.IsNil() {
-		val.Set(reflect.MakeMap(val.Type()))
+		val.Set(reflect.MakeMapWithSize(val.Type(), capacity))
 	}
 
 	if val.Len() > 0 && mc.DecodeZerosMap {
@@ -185,6 +193,7 @@ func (mc *MapCodec) DecodeValue(dc DecodeContext, vr bsonrw.ValueReader, val ref
 	}
 
 	keyType := val.Type().Key()
+	dupKeys := newDuplicateKeys(dc.DuplicateKeyPolicy)
 
 	for {
 		key, vr, err := dr.ReadElement()
@@ -195,6 +204,20 @@ func (mc *MapCodec) DecodeValue(dc DecodeContext, vr bsonrw.ValueReader, val ref
 			return err
 		}
 
+		_, action, err := dupKeys.observe(key, -1)
+		if err != nil {
+			return err
+		}
+		if action == skipDuplicateKey {
+			if err = vr.Skip(); err != nil {
+				return err
+			}
+			continue
+		}
+		if action == collectDuplicateKey && eType != tEmpty {
+			return DuplicateKeyError{Key: key}
+		}
+
 		k, err := mc.decodeKey(key, keyType)
 		if err != nil {
 			return err
@@ -205,6 +228,9 @@ func (mc *MapCodec) DecodeValue(dc DecodeContext, vr bsonrw.ValueReader, val ref
 			return newDecodeError(key, err)
 		}
 
+		if action == collectDuplicateKey {
+			elem = reflect.ValueOf(dupKeys.collect(key, val.MapIndex(k).Interface(), elem.Interface()))
+		}
 		val.SetMapIndex(k, elem)
 	}
 	return nil
@@ -244,10 +270,25 @@ func (mc *MapCodec) encodeKey(val reflect.Value) (string, error) {
 	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
 		return strconv.FormatUint(val.Uint(), 10), nil
 	}
+
+	// TextMarshalers are marshaled like KeyMarshalers, which allows key types written for encoding/json to be used.
+	// This is only done for key kinds that are not supported otherwise, so the encoding of existing keys of integer
+	// types does not change.
+	if tm, ok := val.Interface().(encoding.TextMarshaler); ok {
+		if val.Kind() == reflect.Ptr && val.IsNil() {
+			return "", nil
+		}
+		buf, err := tm.MarshalText()
+		if err != nil {
+			return "", err
+		}
+		return string(buf), nil
+	}
 	return "", fmt.Errorf("unsupported key type: %v", val.Type())
 }
 
 var keyUnmarshalerType = reflect.TypeOf((*KeyUnmarshaler)(nil)).Elem()
+var textUnmarshalerType = reflect.TypeOf((*encoding.TextUnmarshaler)(nil)).Elem()
 
 func (mc *MapCodec) decodeKey(key string, keyType reflect.Type) (reflect.Value, error) {
 	keyVal := reflect.ValueOf(key)
@@ -259,6 +300,14 @@ func (mc *MapCodec) decodeKey(key string, keyType reflect.Type) (reflect.Value,
 		v := keyVal.Interface().(KeyUnmarshaler)
 		err = v.UnmarshalKey(key)
 		keyVal = keyVal.Elem()
+	// Then try to decode with a TextUnmarshaler. Keys of string and integer types are encoded directly rather than
+	// with MarshalText, so they are also decoded directly to round-trip.
+	case !mc.EncodeKeysWithStringer && !isDirectKeyKind(keyType.Kind()) &&
+		reflect.PtrTo(keyType).Implements(textUnmarshalerType):
+		keyVal = reflect.New(keyType)
+		v := keyVal.Interface().(encoding.TextUnmarshaler)
+		err = v.UnmarshalText([]byte(key))
+		keyVal = keyVal.Elem()
 	// Otherwise, go to type specific behavior
 	default:
 		switch keyType.Kind() {
@@ -295,3 +344,14 @@ func (mc *MapCodec) decodeKey(key string, keyType reflect.Type) (reflect.Value,
 	}
 	return keyVal, err
 }
+
+// isDirectKeyKind returns whether keys of kind are encoded directly from their value rather than with MarshalText.
+func isDirectKeyKind(kind reflect.Kind) bool {
+	switch kind {
+	case reflect.String,
+		reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return true
+	}
+	return false
+}
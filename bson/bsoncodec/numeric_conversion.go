@@ -0,0 +1,177 @@
+// Copyright (C) MongoDB, Inc. 2021-present.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
+
+package bsoncodec
+
+import (
+	"fmt"
+	"math"
+)
+
+const (
+	maxInt  = int64(^uint(0) >> 1)
+	minInt  = -maxInt - 1
+	maxUint = uint64(^uint(0))
+)
+
+// NumericConversionPolicy specifies how decoders handle a BSON number that cannot be represented exactly by the Go
+// numeric type it is being decoded into, e.g. an int64 that overflows an int32 or a double with a fractional part that
+// is decoded into an int.
+type NumericConversionPolicy int
+
+// These constants specify the valid values for NumericConversionPolicy.
+const (
+	// DefaultNumericConversion keeps the historical behavior: integer overflows result in an error and doubles can
+	// only lose precision when DecodeContext.Truncate or the "truncate" struct tag is set.
+	DefaultNumericConversion NumericConversionPolicy = iota
+
+	// ErrorOnLossyConversion causes every lossy conversion to fail with a NumericConversionError, including
+	// conversions allowed by DecodeContext.Truncate and int64 values that cannot be represented exactly by a float64.
+	ErrorOnLossyConversion
+
+	// SaturateLossyConversion clamps values that overflow the destination type to its minimum or maximum value and
+	// truncates the fractional part of doubles decoded into integer types. Doubles decoded into unsigned integer types
+	// are clamped to the range of an int64 first.
+	SaturateLossyConversion
+
+	// AllowLossyConversion converts values as a Go conversion would, so integer overflows wrap around. Because Go
+	// does not define the result of converting an out of range float to an integer, such doubles are clamped as
+	// with SaturateLossyConversion.
+	AllowLossyConversion
+)
+
+func (ncp NumericConversionPolicy) String() string {
+	switch ncp {
+	case DefaultNumericConversion:
+		return "default"
+	case ErrorOnLossyConversion:
+		return "error"
+	case SaturateLossyConversion:
+		return "saturate"
+	case AllowLossyConversion:
+		return "allow"
+	default:
+		return fmt.Sprintf("unknown NumericConversionPolicy %d", int(ncp))
+	}
+}
+
+// NumericConversionError is returned when a BSON number cannot be decoded into a Go numeric type without losing
+// information and the DecodeContext.NumericConversionPolicy does not allow it.
+type NumericConversionError struct {
+	Value interface{}
+	Type  string
+}
+
+func (nce NumericConversionError) Error() string {
+	return fmt.Sprintf("%v cannot be converted to %s without loss", nce.Value, nce.Type)
+}
+
+// doubleToInt64 converts f64 to an int64 according to the NumericConversionPolicy of dc.
+func (dc DecodeContext) doubleToInt64(f64 float64) (int64, error) {
+	switch dc.NumericConversionPolicy {
+	case ErrorOnLossyConversion:
+		if math.Trunc(f64) != f64 || f64 < math.MinInt64 || f64 >= math.MaxInt64 {
+			return 0, NumericConversionError{Value: f64, Type: "int64"}
+		}
+	case SaturateLossyConversion, AllowLossyConversion:
+		switch {
+		case math.IsNaN(f64):
+			return 0, NumericConversionError{Value: f64, Type: "int64"}
+		case f64 <= math.MinInt64:
+			return math.MinInt64, nil
+		case f64 >= math.MaxInt64:
+			return math.MaxInt64, nil
+		}
+	default:
+		if !dc.Truncate && math.Floor(f64) != f64 {
+			return 0, errCannotTruncate
+		}
+		if f64 > float64(math.MaxInt64) {
+			return 0, fmt.Errorf("%g overflows int64", f64)
+		}
+	}
+	return int64(f64), nil
+}
+
+// convertInt checks that i64 fits in the range [min, max] of the named signed integer type and applies the
+// NumericConversionPolicy of dc if it does not. With AllowLossyConversion, i64 is returned unchanged so the caller's
+// conversion wraps it around.
+func (dc DecodeContext) convertInt(i64, min, max int64, typeName string) (int64, error) {
+	if i64 >= min && i64 <= max {
+		return i64, nil
+	}
+
+	switch dc.NumericConversionPolicy {
+	case ErrorOnLossyConversion:
+		return 0, NumericConversionError{Value: i64, Type: typeName}
+	case SaturateLossyConversion:
+		if i64 < min {
+			return min, nil
+		}
+		return max, nil
+	case AllowLossyConversion:
+		return i64, nil
+	default:
+		return 0, fmt.Errorf("%d overflows %s", i64, typeName)
+	}
+}
+
+// convertUint is the unsigned equivalent of convertInt for the range [0, max].
+func (dc DecodeContext) convertUint(i64 int64, max uint64, typeName string) (uint64, error) {
+	if i64 >= 0 && uint64(i64) <= max {
+		return uint64(i64), nil
+	}
+
+	switch dc.NumericConversionPolicy {
+	case ErrorOnLossyConversion:
+		return 0, NumericConversionError{Value: i64, Type: typeName}
+	case SaturateLossyConversion:
+		if i64 < 0 {
+			return 0, nil
+		}
+		return max, nil
+	case AllowLossyConversion:
+		return uint64(i64), nil
+	default:
+		return 0, fmt.Errorf("%d overflows %s", i64, typeName)
+	}
+}
+
+// int64ToDouble converts i64 to a float64 according to the NumericConversionPolicy of dc. Only ErrorOnLossyConversion
+// rejects values that cannot be represented exactly.
+func (dc DecodeContext) int64ToDouble(i64 int64) (float64, error) {
+	f64 := float64(i64)
+	if dc.NumericConversionPolicy == ErrorOnLossyConversion && (f64 >= math.MaxInt64 || int64(f64) != i64) {
+		return 0, NumericConversionError{Value: i64, Type: "float64"}
+	}
+	return f64, nil
+}
+
+// doubleToFloat32 converts f64 to a float32 according to the NumericConversionPolicy of dc.
+func (dc DecodeContext) doubleToFloat32(f64 float64) (float32, error) {
+	f32 := float32(f64)
+	if float64(f32) == f64 {
+		return f32, nil
+	}
+
+	switch dc.NumericConversionPolicy {
+	case ErrorOnLossyConversion:
+		if math.IsNaN(f64) {
+			return f32, nil
+		}
+		return 0, NumericConversionError{Value: f64, Type: "float32"}
+	case SaturateLossyConversion:
+		if !math.IsInf(f64, 0) && math.IsInf(float64(f32), 0) {
+			return float32(math.Copysign(math.MaxFloat32, f64)), nil
+		}
+	case AllowLossyConversion:
+	default:
+		if !dc.Truncate {
+			return 0, errCannotTruncate
+		}
+	}
+	return f32, nil
+}
@@ -46,6 +46,12 @@ func (pc *PointerCodec) EncodeValue(ec EncodeContext, vw bsonrw.ValueWriter, val
 		return vw.WriteNull()
 	}
 
+	ec, exit, err := ec.enter(vw, val, false)
+	if err != nil {
+		return err
+	}
+	defer exit()
+
 	pc.l.RLock()
 	enc, ok := pc.ecache[val.Type()]
 	pc.l.RUnlock()
@@ -56,7 +62,7 @@ func (pc *PointerCodec) EncodeValue(ec EncodeContext, vw bsonrw.ValueWriter, val
 		return enc.EncodeValue(ec, vw, val.Elem())
 	}
 
-	enc, err := ec.LookupEncoder(val.Type().Elem())
+	enc, err = ec.LookupEncoder(val.Type().Elem())
 	pc.l.Lock()
 	pc.ecache[val.Type()] = enc
 	pc.l.Unlock()
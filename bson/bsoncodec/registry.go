@@ -11,6 +11,7 @@ import (
 	"fmt"
 	"reflect"
 	"sync"
+	"sync/atomic"
 
 	"go.mongodb.org/mongo-driver/bson/bsontype"
 )
@@ -72,6 +73,10 @@ type RegistryBuilder struct {
 	kindDecoders      map[reflect.Kind]ValueDecoder
 
 	typeMap map[bsontype.Type]reflect.Type
+
+	encodeNilSliceAsEmpty bool
+	encodeNilMapAsEmpty   bool
+	encodeOmitZeroStruct  bool
 }
 
 // A Registry is used to store and retrieve codecs for types and interfaces. This type is the main
@@ -88,7 +93,17 @@ type Registry struct {
 
 	typeMap map[bsontype.Type]reflect.Type
 
-	mu sync.RWMutex
+	encodeNilSliceAsEmpty bool
+	encodeNilMapAsEmpty   bool
+	encodeOmitZeroStruct  bool
+
+	// encoderCache and decoderCache hold the results of LookupEncoder and LookupDecoder, including the types for which
+	// no codec was found. A stored map is never modified, so lookups that hit the cache do not take a lock. A lookup
+	// that misses the cache stores a copy of the map with the new entry while holding cacheMu. The number of distinct
+	// types used by an application is small, so the copies are cheap.
+	encoderCache atomic.Value // map[reflect.Type]ValueEncoder
+	decoderCache atomic.Value // map[reflect.Type]ValueDecoder
+	cacheMu      sync.Mutex
 }
 
 // NewRegistryBuilder creates a new empty RegistryBuilder.
@@ -266,6 +281,29 @@ func (rb *RegistryBuilder) RegisterTypeMapEntry(bt bsontype.Type, rt reflect.Typ
 	return rb
 }
 
+// SetNilSliceAsEmpty specifies whether nil slices should be encoded as empty BSON arrays, or as empty BSON binary data
+// for []byte, instead of BSON null by every codec that uses the built Registry. This applies regardless of the options
+// of the registered slice and []byte codecs. The default is false.
+func (rb *RegistryBuilder) SetNilSliceAsEmpty(b bool) *RegistryBuilder {
+	rb.encodeNilSliceAsEmpty = b
+	return rb
+}
+
+// SetNilMapAsEmpty specifies whether nil maps should be encoded as empty BSON documents instead of BSON null by every
+// codec that uses the built Registry. This applies regardless of the options of the registered map codec. The default
+// is false.
+func (rb *RegistryBuilder) SetNilMapAsEmpty(b bool) *RegistryBuilder {
+	rb.encodeNilMapAsEmpty = b
+	return rb
+}
+
+// SetOmitZeroStruct specifies whether struct fields tagged with omitempty should be omitted when their value is a zero
+// struct. This is equivalent to setting EncodeOmitDefaultStruct on the registered struct codec. The default is false.
+func (rb *RegistryBuilder) SetOmitZeroStruct(b bool) *RegistryBuilder {
+	rb.encodeOmitZeroStruct = b
+	return rb
+}
+
 // Build creates a Registry from the current state of this RegistryBuilder.
 func (rb *RegistryBuilder) Build() *Registry {
 	registry := new(Registry)
@@ -301,6 +339,23 @@ func (rb *RegistryBuilder) Build() *Registry {
 		registry.typeMap[bt] = rt
 	}
 
+	registry.encodeNilSliceAsEmpty = rb.encodeNilSliceAsEmpty
+	registry.encodeNilMapAsEmpty = rb.encodeNilMapAsEmpty
+	registry.encodeOmitZeroStruct = rb.encodeOmitZeroStruct
+
+	// Describe the struct types that are registered with a StructCodec so the first value of each type that is
+	// encoded or decoded does not need to. Errors are returned when a value of the type is encoded or decoded.
+	for t, enc := range registry.typeEncoders {
+		if sc, ok := enc.(*StructCodec); ok && t != nil && t.Kind() == reflect.Struct {
+			_, _ = sc.describeStruct(registry, t)
+		}
+	}
+	for t, dec := range registry.typeDecoders {
+		if sc, ok := dec.(*StructCodec); ok && t != nil && t.Kind() == reflect.Struct {
+			_, _ = sc.describeStruct(registry, t)
+		}
+	}
+
 	return registry
 }
 
@@ -316,44 +371,41 @@ func (rb *RegistryBuilder) Build() *Registry {
 //
 // If no encoder is found, an error of type ErrNoEncoder is returned.
 func (r *Registry) LookupEncoder(t reflect.Type) (ValueEncoder, error) {
-	encodererr := ErrNoEncoder{Type: t}
-	r.mu.RLock()
-	enc, found := r.lookupTypeEncoder(t)
-	r.mu.RUnlock()
-	if found {
-		if enc == nil {
-			return nil, ErrNoEncoder{Type: t}
+	cache, _ := r.encoderCache.Load().(map[reflect.Type]ValueEncoder)
+	enc, found := cache[t]
+	if !found {
+		enc, found = r.lookupTypeEncoder(t)
+		if !found {
+			enc, found = r.lookupInterfaceEncoder(t, true)
+		}
+		if !found && t != nil {
+			enc = r.kindEncoders[t.Kind()]
 		}
-		return enc, nil
+		r.storeEncoder(t, enc)
 	}
 
-	enc, found = r.lookupInterfaceEncoder(t, true)
-	if found {
-		r.mu.Lock()
-		r.typeEncoders[t] = enc
-		r.mu.Unlock()
-		return enc, nil
+	if enc == nil {
+		return nil, ErrNoEncoder{Type: t}
 	}
+	return enc, nil
+}
 
-	if t == nil {
-		r.mu.Lock()
-		r.typeEncoders[t] = nil
-		r.mu.Unlock()
-		return nil, encodererr
-	}
+// storeEncoder adds the result of an encoder lookup for t to the cache. A nil encoder means that no encoder was found.
+func (r *Registry) storeEncoder(t reflect.Type, enc ValueEncoder) {
+	r.cacheMu.Lock()
+	defer r.cacheMu.Unlock()
 
-	enc, found = r.kindEncoders[t.Kind()]
-	if !found {
-		r.mu.Lock()
-		r.typeEncoders[t] = nil
-		r.mu.Unlock()
-		return nil, encodererr
+	cache, _ := r.encoderCache.Load().(map[reflect.Type]ValueEncoder)
+	if _, found := cache[t]; found {
+		// Another goroutine stored the result while this one was looking it up.
+		return
 	}
-
-	r.mu.Lock()
-	r.typeEncoders[t] = enc
-	r.mu.Unlock()
-	return enc, nil
+	updated := make(map[reflect.Type]ValueEncoder, len(cache)+1)
+	for k, v := range cache {
+		updated[k] = v
+	}
+	updated[t] = enc
+	r.encoderCache.Store(updated)
 }
 
 func (r *Registry) lookupTypeEncoder(t reflect.Type) (ValueEncoder, bool) {
@@ -397,37 +449,41 @@ func (r *Registry) LookupDecoder(t reflect.Type) (ValueDecoder, error) {
 	if t == nil {
 		return nil, ErrNilType
 	}
-	decodererr := ErrNoDecoder{Type: t}
-	r.mu.RLock()
-	dec, found := r.lookupTypeDecoder(t)
-	r.mu.RUnlock()
-	if found {
-		if dec == nil {
-			return nil, ErrNoDecoder{Type: t}
+
+	cache, _ := r.decoderCache.Load().(map[reflect.Type]ValueDecoder)
+	dec, found := cache[t]
+	if !found {
+		dec, found = r.lookupTypeDecoder(t)
+		if !found {
+			dec, found = r.lookupInterfaceDecoder(t, true)
+		}
+		if !found {
+			dec = r.kindDecoders[t.Kind()]
 		}
-		return dec, nil
+		r.storeDecoder(t, dec)
 	}
 
-	dec, found = r.lookupInterfaceDecoder(t, true)
-	if found {
-		r.mu.Lock()
-		r.typeDecoders[t] = dec
-		r.mu.Unlock()
-		return dec, nil
+	if dec == nil {
+		return nil, ErrNoDecoder{Type: t}
 	}
+	return dec, nil
+}
 
-	dec, found = r.kindDecoders[t.Kind()]
-	if !found {
-		r.mu.Lock()
-		r.typeDecoders[t] = nil
-		r.mu.Unlock()
-		return nil, decodererr
-	}
+// storeDecoder adds the result of a decoder lookup for t to the cache. A nil decoder means that no decoder was found.
+func (r *Registry) storeDecoder(t reflect.Type, dec ValueDecoder) {
+	r.cacheMu.Lock()
+	defer r.cacheMu.Unlock()
 
-	r.mu.Lock()
-	r.typeDecoders[t] = dec
-	r.mu.Unlock()
-	return dec, nil
+	cache, _ := r.decoderCache.Load().(map[reflect.Type]ValueDecoder)
+	if _, found := cache[t]; found {
+		return
+	}
+	updated := make(map[reflect.Type]ValueDecoder, len(cache)+1)
+	for k, v := range cache {
+		updated[k] = v
+	}
+	updated[t] = dec
+	r.decoderCache.Store(updated)
 }
 
 func (r *Registry) lookupTypeDecoder(t reflect.Type) (ValueDecoder, bool) {
@@ -8,6 +8,7 @@ package bsoncodec
 
 import (
 	"reflect"
+	"sync"
 	"testing"
 
 	"github.com/google/go-cmp/cmp"
@@ -375,6 +376,70 @@ func TestRegistry(t *testing.T) {
 			})
 		})
 	})
+	t.Run("Lookup cache", func(t *testing.T) {
+		t.Run("concurrent lookups", func(t *testing.T) {
+			reg := buildDefaultRegistry()
+			types := []reflect.Type{tInt32, tString, reflect.TypeOf(fakeType1{}), reflect.TypeOf([]fakeType2{})}
+
+			var wg sync.WaitGroup
+			for i := 0; i < 8; i++ {
+				wg.Add(1)
+				go func() {
+					defer wg.Done()
+					for _, typ := range types {
+						if _, err := reg.LookupEncoder(typ); err != nil {
+							t.Errorf("LookupEncoder error for %v: %v", typ, err)
+						}
+						if _, err := reg.LookupDecoder(typ); err != nil {
+							t.Errorf("LookupDecoder error for %v: %v", typ, err)
+						}
+					}
+				}()
+			}
+			wg.Wait()
+
+			encoders, _ := reg.encoderCache.Load().(map[reflect.Type]ValueEncoder)
+			decoders, _ := reg.decoderCache.Load().(map[reflect.Type]ValueDecoder)
+			for _, typ := range types {
+				if _, ok := encoders[typ]; !ok {
+					t.Errorf("expected encoder for %v to be cached", typ)
+				}
+				if _, ok := decoders[typ]; !ok {
+					t.Errorf("expected decoder for %v to be cached", typ)
+				}
+			}
+		})
+		t.Run("missing codecs are cached", func(t *testing.T) {
+			reg := NewRegistryBuilder().Build()
+			typ := reflect.TypeOf(fakeType1{})
+			for i := 0; i < 2; i++ {
+				if _, err := reg.LookupEncoder(typ); err != (ErrNoEncoder{Type: typ}) {
+					t.Errorf("expected error %v, got %v", ErrNoEncoder{Type: typ}, err)
+				}
+				if _, err := reg.LookupDecoder(typ); err != (ErrNoDecoder{Type: typ}) {
+					t.Errorf("expected error %v, got %v", ErrNoDecoder{Type: typ}, err)
+				}
+			}
+		})
+		t.Run("registered struct types are described on build", func(t *testing.T) {
+			type registered struct {
+				Foo string
+			}
+			typ := reflect.TypeOf(registered{})
+			sc, err := NewStructCodec(DefaultStructTagParser)
+			noerr(t, err)
+
+			rb := NewRegistryBuilder().RegisterCodec(typ, sc)
+			defaultValueEncoders.RegisterDefaultEncoders(rb)
+			defaultValueDecoders.RegisterDefaultDecoders(rb)
+			_ = rb.Build()
+
+			cache, _ := sc.cache.Load().(map[reflect.Type]*structDescription)
+			if _, ok := cache[typ]; !ok {
+				t.Errorf("expected description of %v to be cached after Build", typ)
+			}
+		})
+	})
 	t.Run("Type Map", func(t *testing.T) {
 		reg := NewRegistryBuilder().
 			RegisterTypeMapEntry(bsontype.String, reflect.TypeOf(string(""))).
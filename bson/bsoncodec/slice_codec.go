@@ -42,7 +42,7 @@ func (sc SliceCodec) EncodeValue(ec EncodeContext, vw bsonrw.ValueWriter, val re
 		return ValueEncoderError{Name: "SliceEncodeValue", Kinds: []reflect.Kind{reflect.Slice}, Received: val}
 	}
 
-	if val.IsNil() && !sc.EncodeNilAsEmpty {
+	if val.IsNil() && !sc.EncodeNilAsEmpty && !ec.nilSliceAsEmpty() {
 		return vw.WriteNull()
 	}
 
@@ -55,6 +55,12 @@ func (sc SliceCodec) EncodeValue(ec EncodeContext, vw bsonrw.ValueWriter, val re
 		return vw.WriteBinary(byteSlice)
 	}
 
+	ec, exit, err := ec.enter(vw, val, true)
+	if err != nil {
+		return err
+	}
+	defer exit()
+
 	// If we have a []primitive.E we want to treat it as a document instead of as an array.
 	if val.Type().ConvertibleTo(tD) {
 		d := val.Convert(tD).Interface().(primitive.D)
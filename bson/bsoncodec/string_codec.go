@@ -63,6 +63,9 @@ func (sc *StringCodec) decodeType(dc DecodeContext, vr bsonrw.ValueReader, t ref
 		if err != nil {
 			return emptyValue, err
 		}
+		if dc.StringInterner != nil {
+			str = dc.StringInterner.Intern(str)
+		}
 	case bsontype.ObjectID:
 		oid, err := vr.ReadObjectID()
 		if err != nil {
@@ -79,6 +82,9 @@ func (sc *StringCodec) decodeType(dc DecodeContext, vr bsonrw.ValueReader, t ref
 		if err != nil {
 			return emptyValue, err
 		}
+		if dc.StringInterner != nil {
+			str = dc.StringInterner.Intern(str)
+		}
 	case bsontype.Binary:
 		data, subtype, err := vr.ReadBinary()
 		if err != nil {
@@ -8,6 +8,7 @@ package bsoncodec
 
 import (
 	"reflect"
+	"strings"
 	"testing"
 
 	"go.mongodb.org/mongo-driver/bson/bsonoptions"
@@ -15,6 +16,7 @@ import (
 	"go.mongodb.org/mongo-driver/bson/bsontype"
 	"go.mongodb.org/mongo-driver/bson/primitive"
 	"go.mongodb.org/mongo-driver/internal/testutil/assert"
+	"go.mongodb.org/mongo-driver/x/bsonx/bsoncore"
 )
 
 func TestStringCodec(t *testing.T) {
@@ -45,4 +47,54 @@ func TestStringCodec(t *testing.T) {
 			})
 		}
 	})
+	t.Run("StringInterner", func(t *testing.T) {
+		var interned []string
+		interner := StringInternerFunc(func(s string) string {
+			interned = append(interned, s)
+			return "interned"
+		})
+		dc := DecodeContext{StringInterner: interner}
+		testCases := []struct {
+			name   string
+			reader *bsonrwtest.ValueReaderWriter
+			result string
+		}{
+			{"string", &bsonrwtest.ValueReaderWriter{BSONType: bsontype.String, Return: "foo"}, "interned"},
+			{"symbol", &bsonrwtest.ValueReaderWriter{BSONType: bsontype.Symbol, Return: "foo"}, "interned"},
+			{"binary", &bsonrwtest.ValueReaderWriter{
+				BSONType: bsontype.Binary,
+				Return:   bsoncore.Value{Type: bsontype.Binary, Data: bsoncore.AppendBinary(nil, 0, []byte("foo"))},
+			}, "foo"},
+		}
+		for _, tc := range testCases {
+			t.Run(tc.name, func(t *testing.T) {
+				actual := reflect.New(reflect.TypeOf("")).Elem()
+				err := defaultStringCodec.DecodeValue(dc, tc.reader, actual)
+				assert.Nil(t, err, "StringCodec.DecodeValue error: %v", err)
+				assert.Equal(t, tc.result, actual.String(), "Expected string %v, got %v", tc.result, actual.String())
+			})
+		}
+		assert.Equal(t, []string{"foo", "foo"}, interned, "expected strings %v to be interned, got %v",
+			[]string{"foo", "foo"}, interned)
+	})
+	t.Run("StringPool", func(t *testing.T) {
+		pool := NewStringPool(2, 3)
+		a := string([]byte("abc"))
+		assert.Equal(t, a, pool.Intern(a), "expected Intern to return %q", a)
+		assert.Equal(t, a, pool.Intern(string([]byte("abc"))), "expected Intern to return %q", a)
+		assert.Equal(t, 1, pool.Len(), "expected 1 string in the pool, got %d", pool.Len())
+
+		assert.Equal(t, "abcd", pool.Intern("abcd"), "expected Intern to return a string that is too long")
+		assert.Equal(t, 1, pool.Len(), "expected 1 string in the pool, got %d", pool.Len())
+
+		pool.Intern("b")
+		assert.Equal(t, "c", pool.Intern("c"), "expected Intern to return a string that does not fit")
+		assert.Equal(t, 2, pool.Len(), "expected 2 strings in the pool, got %d", pool.Len())
+
+		unbounded := NewStringPool(0, 0)
+		for i := 0; i < 10; i++ {
+			unbounded.Intern(strings.Repeat("x", i))
+		}
+		assert.Equal(t, 10, unbounded.Len(), "expected 10 strings in the pool, got %d", unbounded.Len())
+	})
 }
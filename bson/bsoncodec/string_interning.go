@@ -0,0 +1,73 @@
+// Copyright (C) MongoDB, Inc. 2021-present.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
+
+package bsoncodec
+
+import "sync"
+
+// StringInterner is implemented by types that can intern strings. Intern returns a string equal to s, which should be
+// a previously returned string if s has been interned before so that equal strings share their memory.
+type StringInterner interface {
+	Intern(s string) string
+}
+
+// StringInternerFunc is an adapter function that allows a function with the correct signature to be used as a
+// StringInterner.
+type StringInternerFunc func(string) string
+
+// Intern implements the StringInterner interface.
+func (fn StringInternerFunc) Intern(s string) string {
+	return fn(s)
+}
+
+// StringPool is a StringInterner that keeps the interned strings in a map. The number of strings and the length of the
+// strings that are interned can be bounded so that decoding fields with many distinct or long values does not make the
+// pool grow without limit. A StringPool is safe for concurrent use and can be shared by several DecodeContexts.
+type StringPool struct {
+	mu         sync.Mutex
+	strs       map[string]string
+	maxStrings int
+	maxLength  int
+}
+
+var _ StringInterner = (*StringPool)(nil)
+
+// NewStringPool creates a StringPool that interns at most maxStrings strings of at most maxLength bytes. Strings that do
+// not fit in the pool are returned as-is. A limit that is not positive means that there is no limit.
+func NewStringPool(maxStrings, maxLength int) *StringPool {
+	return &StringPool{
+		strs:       make(map[string]string),
+		maxStrings: maxStrings,
+		maxLength:  maxLength,
+	}
+}
+
+// Intern implements the StringInterner interface.
+func (sp *StringPool) Intern(s string) string {
+	if sp.maxLength > 0 && len(s) > sp.maxLength {
+		return s
+	}
+
+	sp.mu.Lock()
+	defer sp.mu.Unlock()
+
+	if interned, ok := sp.strs[s]; ok {
+		return interned
+	}
+	if sp.maxStrings > 0 && len(sp.strs) >= sp.maxStrings {
+		return s
+	}
+	sp.strs[s] = s
+	return s
+}
+
+// Len returns the number of strings in the pool.
+func (sp *StringPool) Len() int {
+	sp.mu.Lock()
+	defer sp.mu.Unlock()
+
+	return len(sp.strs)
+}
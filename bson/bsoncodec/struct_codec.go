@@ -13,6 +13,7 @@ import (
 	"sort"
 	"strings"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"go.mongodb.org/mongo-driver/bson/bsonoptions"
@@ -60,8 +61,8 @@ type Zeroer interface {
 
 // StructCodec is the Codec used for struct values.
 type StructCodec struct {
-	cache                            map[reflect.Type]*structDescription
-	l                                sync.RWMutex
+	cache                            atomic.Value // map[reflect.Type]*structDescription
+	l                                sync.Mutex
 	parser                           StructTagParser
 	DecodeZeroStruct                 bool
 	DecodeDeepZeroInline             bool
@@ -82,7 +83,6 @@ func NewStructCodec(p StructTagParser, opts ...*bsonoptions.StructCodecOptions)
 	structOpt := bsonoptions.MergeStructCodecOptions(opts...)
 
 	codec := &StructCodec{
-		cache:  make(map[reflect.Type]*structDescription),
 		parser: p,
 	}
 
@@ -111,6 +111,12 @@ func (sc *StructCodec) EncodeValue(r EncodeContext, vw bsonrw.ValueWriter, val r
 		return ValueEncoderError{Name: "StructCodec.EncodeValue", Kinds: []reflect.Kind{reflect.Struct}, Received: val}
 	}
 
+	r, exit, err := r.enter(vw, val, true)
+	if err != nil {
+		return err
+	}
+	defer exit()
+
 	sd, err := sc.describeStruct(r.Registry, val.Type())
 	if err != nil {
 		return err
@@ -120,6 +126,7 @@ func (sc *StructCodec) EncodeValue(r EncodeContext, vw bsonrw.ValueWriter, val r
 	if err != nil {
 		return err
 	}
+	omitDefaultStruct := sc.EncodeOmitDefaultStruct || r.omitZeroStruct()
 	var rv reflect.Value
 	for _, desc := range sd.fl {
 		if desc.inline == nil {
@@ -166,7 +173,7 @@ func (sc *StructCodec) EncodeValue(r EncodeContext, vw bsonrw.ValueWriter, val r
 			// sc.isZero will not treat an interface rv as an interface, so we need to check for the zero interface separately.
 			isZero = rv.IsNil()
 		} else {
-			isZero = sc.isZero(rvInterface)
+			isZero = sc.isZeroValue(rvInterface, omitDefaultStruct)
 		}
 		if desc.omitEmpty && isZero {
 			continue
@@ -177,7 +184,8 @@ func (sc *StructCodec) EncodeValue(r EncodeContext, vw bsonrw.ValueWriter, val r
 			return err
 		}
 
-		ectx := EncodeContext{Registry: r.Registry, MinSize: desc.minSize}
+		ectx := r
+		ectx.MinSize = desc.minSize
 		err = encoder.EncodeValue(ectx, vw2, rv)
 		if err != nil {
 			return err
@@ -251,6 +259,7 @@ func (sc *StructCodec) DecodeValue(r DecodeContext, vr bsonrw.ValueReader, val r
 	}
 
 	var decoder ValueDecoder
+	var eTypeDecoder typeDecoder
 	var inlineMap reflect.Value
 	if sd.inlineMap >= 0 {
 		inlineMap = val.Field(sd.inlineMap)
@@ -258,6 +267,7 @@ func (sc *StructCodec) DecodeValue(r DecodeContext, vr bsonrw.ValueReader, val r
 		if err != nil {
 			return err
 		}
+		eTypeDecoder, _ = decoder.(typeDecoder)
 	}
 
 	dr, err := vr.ReadDocument()
@@ -265,6 +275,8 @@ func (sc *StructCodec) DecodeValue(r DecodeContext, vr bsonrw.ValueReader, val r
 		return err
 	}
 
+	dupKeys := newDuplicateKeys(r.DuplicateKeyPolicy)
+
 	for {
 		name, vr, err := dr.ReadElement()
 		if err == bsonrw.ErrEOD {
@@ -282,6 +294,25 @@ func (sc *StructCodec) DecodeValue(r DecodeContext, vr bsonrw.ValueReader, val r
 			fd, exists = sd.fm[strings.ToLower(name)]
 		}
 
+		// Keys that map to the same struct field are duplicates, e.g. "foo" and "Foo" for an untagged field Foo.
+		dupKey := name
+		if exists {
+			dupKey = fd.name
+		}
+		_, action, err := dupKeys.observe(dupKey, -1)
+		if err != nil {
+			return err
+		}
+		if action == skipDuplicateKey {
+			if err = vr.Skip(); err != nil {
+				return err
+			}
+			continue
+		}
+		if action == collectDuplicateKey && (exists || (sd.inlineMap >= 0 && inlineMap.Type().Elem() != tEmpty)) {
+			return DuplicateKeyError{Key: name}
+		}
+
 		if !exists {
 			if sd.inlineMap < 0 {
 				// The encoding/json package requires a flag to return on error for non-existent fields.
@@ -297,13 +328,31 @@ func (sc *StructCodec) DecodeValue(r DecodeContext, vr bsonrw.ValueReader, val r
 				inlineMap.Set(reflect.MakeMap(inlineMap.Type()))
 			}
 
-			elem := reflect.New(inlineMap.Type().Elem()).Elem()
-			r.Ancestor = inlineMap.Type()
-			err = decoder.DecodeValue(r, vr, elem)
+			mapType := inlineMap.Type()
+			dctx := DecodeContext{
+				Registry:                r.Registry,
+				Truncate:                r.Truncate,
+				DuplicateKeyPolicy:      r.DuplicateKeyPolicy,
+				NumericConversionPolicy: r.NumericConversionPolicy,
+				SliceCapacityHint:       r.SliceCapacityHint,
+				MapCapacityHint:         r.MapCapacityHint,
+				SizeFromBSON:            r.SizeFromBSON,
+				StringInterner:          r.StringInterner,
+			}
+			if mapType.Elem() == tEmpty {
+				// Only propagate the map type as the ancestor for interface{} values so embedded documents in typed
+				// values are not forced into the inline map's type.
+				dctx.Ancestor = mapType
+			}
+			elem, err := decodeTypeOrValueWithInfo(decoder, eTypeDecoder, dctx, vr, mapType.Elem(), true)
 			if err != nil {
-				return err
+				return newDecodeError(name, err)
+			}
+			key := reflect.ValueOf(name).Convert(mapType.Key())
+			if action == collectDuplicateKey {
+				elem = reflect.ValueOf(dupKeys.collect(name, inlineMap.MapIndex(key).Interface(), elem.Interface()))
 			}
-			inlineMap.SetMapIndex(reflect.ValueOf(name), elem)
+			inlineMap.SetMapIndex(key, elem)
 			continue
 		}
 
@@ -326,7 +375,16 @@ func (sc *StructCodec) DecodeValue(r DecodeContext, vr bsonrw.ValueReader, val r
 		}
 		field = field.Addr()
 
-		dctx := DecodeContext{Registry: r.Registry, Truncate: fd.truncate || r.Truncate}
+		dctx := DecodeContext{
+			Registry:                r.Registry,
+			Truncate:                fd.truncate || r.Truncate,
+			DuplicateKeyPolicy:      r.DuplicateKeyPolicy,
+			NumericConversionPolicy: r.NumericConversionPolicy,
+			SliceCapacityHint:       r.SliceCapacityHint,
+			MapCapacityHint:         r.MapCapacityHint,
+			SizeFromBSON:            r.SizeFromBSON,
+			StringInterner:          r.StringInterner,
+		}
 		if fd.decoder == nil {
 			return newDecodeError(fd.name, ErrNoDecoder{Type: field.Elem().Type()})
 		}
@@ -348,6 +406,10 @@ func (sc *StructCodec) DecodeValue(r DecodeContext, vr bsonrw.ValueReader, val r
 }
 
 func (sc *StructCodec) isZero(i interface{}) bool {
+	return sc.isZeroValue(i, sc.EncodeOmitDefaultStruct)
+}
+
+func (sc *StructCodec) isZeroValue(i interface{}, omitDefaultStruct bool) bool {
 	v := reflect.ValueOf(i)
 
 	// check the value validity
@@ -373,7 +435,7 @@ func (sc *StructCodec) isZero(i interface{}) bool {
 	case reflect.Interface, reflect.Ptr:
 		return v.IsNil()
 	case reflect.Struct:
-		if sc.EncodeOmitDefaultStruct {
+		if omitDefaultStruct {
 			vt := v.Type()
 			if vt == tTime {
 				return v.Interface().(time.Time).IsZero()
@@ -383,7 +445,7 @@ func (sc *StructCodec) isZero(i interface{}) bool {
 					continue // Private field
 				}
 				fld := v.Field(i)
-				if !sc.isZero(fld.Interface()) {
+				if !sc.isZeroValue(fld.Interface(), omitDefaultStruct) {
 					return false
 				}
 			}
@@ -445,10 +507,8 @@ func (bi byIndex) Less(i, j int) bool {
 func (sc *StructCodec) describeStruct(r *Registry, t reflect.Type) (*structDescription, error) {
 	// We need to analyze the struct, including getting the tags, collecting
 	// information about inlining, and create a map of the field name to the field.
-	sc.l.RLock()
-	ds, exists := sc.cache[t]
-	sc.l.RUnlock()
-	if exists {
+	cache, _ := sc.cache.Load().(map[reflect.Type]*structDescription)
+	if ds, exists := cache[t]; exists {
 		return ds, nil
 	}
 
@@ -503,7 +563,7 @@ func (sc *StructCodec) describeStruct(r *Registry, t reflect.Type) (*structDescr
 				if sd.inlineMap >= 0 {
 					return nil, errors.New("(struct " + t.String() + ") multiple inline maps")
 				}
-				if sfType.Key() != tString {
+				if sfType.Key().Kind() != reflect.String {
 					return nil, errors.New("(struct " + t.String() + ") inline map must have a string keys")
 				}
 				sd.inlineMap = description.idx
@@ -575,13 +635,29 @@ func (sc *StructCodec) describeStruct(r *Registry, t reflect.Type) (*structDescr
 
 	sort.Sort(byIndex(sd.fl))
 
-	sc.l.Lock()
-	sc.cache[t] = sd
-	sc.l.Unlock()
+	sc.storeDescription(t, sd)
 
 	return sd, nil
 }
 
+// storeDescription adds sd to the cache of struct descriptions. Like the codec caches of a Registry, the cache is
+// copied on write so describeStruct does not take a lock if the description of t is cached.
+func (sc *StructCodec) storeDescription(t reflect.Type, sd *structDescription) {
+	sc.l.Lock()
+	defer sc.l.Unlock()
+
+	cache, _ := sc.cache.Load().(map[reflect.Type]*structDescription)
+	if _, exists := cache[t]; exists {
+		return
+	}
+	updated := make(map[reflect.Type]*structDescription, len(cache)+1)
+	for k, v := range cache {
+		updated[k] = v
+	}
+	updated[t] = sd
+	sc.cache.Store(updated)
+}
+
 // dominantField looks through the fields, all of which are known to
 // have the same name, to find the single field that dominates the
 // others using Go's inlining rules. If there are multiple top-level
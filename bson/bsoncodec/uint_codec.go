@@ -86,13 +86,10 @@ func (uic *UIntCodec) decodeType(dc DecodeContext, vr bsonrw.ValueReader, t refl
 		if err != nil {
 			return emptyValue, err
 		}
-		if !dc.Truncate && math.Floor(f64) != f64 {
-			return emptyValue, errCannotTruncate
-		}
-		if f64 > float64(math.MaxInt64) {
-			return emptyValue, fmt.Errorf("%g overflows int64", f64)
+		i64, err = dc.doubleToInt64(f64)
+		if err != nil {
+			return emptyValue, err
 		}
-		i64 = int64(f64)
 	case bsontype.Boolean:
 		b, err := vr.ReadBoolean()
 		if err != nil {
@@ -115,35 +112,40 @@ func (uic *UIntCodec) decodeType(dc DecodeContext, vr bsonrw.ValueReader, t refl
 
 	switch t.Kind() {
 	case reflect.Uint8:
-		if i64 < 0 || i64 > math.MaxUint8 {
-			return emptyValue, fmt.Errorf("%d overflows uint8", i64)
+		u64, err := dc.convertUint(i64, math.MaxUint8, "uint8")
+		if err != nil {
+			return emptyValue, err
 		}
 
-		return reflect.ValueOf(uint8(i64)), nil
+		return reflect.ValueOf(uint8(u64)), nil
 	case reflect.Uint16:
-		if i64 < 0 || i64 > math.MaxUint16 {
-			return emptyValue, fmt.Errorf("%d overflows uint16", i64)
+		u64, err := dc.convertUint(i64, math.MaxUint16, "uint16")
+		if err != nil {
+			return emptyValue, err
 		}
 
-		return reflect.ValueOf(uint16(i64)), nil
+		return reflect.ValueOf(uint16(u64)), nil
 	case reflect.Uint32:
-		if i64 < 0 || i64 > math.MaxUint32 {
-			return emptyValue, fmt.Errorf("%d overflows uint32", i64)
+		u64, err := dc.convertUint(i64, math.MaxUint32, "uint32")
+		if err != nil {
+			return emptyValue, err
 		}
 
-		return reflect.ValueOf(uint32(i64)), nil
+		return reflect.ValueOf(uint32(u64)), nil
 	case reflect.Uint64:
-		if i64 < 0 {
-			return emptyValue, fmt.Errorf("%d overflows uint64", i64)
+		u64, err := dc.convertUint(i64, math.MaxUint64, "uint64")
+		if err != nil {
+			return emptyValue, err
 		}
 
-		return reflect.ValueOf(uint64(i64)), nil
+		return reflect.ValueOf(u64), nil
 	case reflect.Uint:
-		if i64 < 0 || int64(uint(i64)) != i64 { // Can we fit this inside of an uint
-			return emptyValue, fmt.Errorf("%d overflows uint", i64)
+		u64, err := dc.convertUint(i64, maxUint, "uint")
+		if err != nil {
+			return emptyValue, err
 		}
 
-		return reflect.ValueOf(uint(i64)), nil
+		return reflect.ValueOf(uint(u64)), nil
 	default:
 		return emptyValue, ValueDecoderError{
 			Name:     "UintDecodeValue",
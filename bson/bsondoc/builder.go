@@ -0,0 +1,55 @@
+// Copyright (C) MongoDB, Inc. 2021-present.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
+
+package bsondoc
+
+import (
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/x/bsonx/bsoncore"
+)
+
+// Builder appends elements to an encoded BSON document. Unlike a Document, the elements of a Builder cannot be modified
+// after they are appended, so a Builder does not need to encode the document again when it is built. A Builder is not
+// safe for concurrent use.
+type Builder struct {
+	idx int32
+	doc []byte
+}
+
+// NewBuilder creates a new Builder for an empty document.
+func NewBuilder() *Builder {
+	b := &Builder{}
+	b.idx, b.doc = bsoncore.AppendDocumentStart(nil)
+	return b
+}
+
+// Append appends an element with the key and value provided to the document.
+func (b *Builder) Append(key string, val bson.RawValue) *Builder {
+	b.doc = bsoncore.AppendHeader(b.doc, val.Type, key)
+	b.doc = append(b.doc, val.Value...)
+	return b
+}
+
+// AppendDocument appends an element with the provided key whose value is the document built by sub.
+func (b *Builder) AppendDocument(key string, sub *Builder) *Builder {
+	return b.Append(key, EmbeddedRaw(sub.Build()))
+}
+
+// AppendElements appends the elements of doc to the document.
+func (b *Builder) AppendElements(doc Document) *Builder {
+	for _, e := range doc {
+		b.Append(e.Key, e.Value)
+	}
+	return b
+}
+
+// Build returns the encoded document. Elements can still be appended to the Builder afterwards, which does not modify
+// the returned document.
+func (b *Builder) Build() bson.Raw {
+	doc := append([]byte(nil), b.doc...)
+	doc, _ = bsoncore.AppendDocumentEnd(doc, b.idx)
+	return doc
+}
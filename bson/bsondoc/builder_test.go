@@ -0,0 +1,48 @@
+// Copyright (C) MongoDB, Inc. 2021-present.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
+
+package bsondoc
+
+import (
+	"testing"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/internal/testutil/assert"
+)
+
+func TestBuilder(t *testing.T) {
+	t.Run("build", func(t *testing.T) {
+		got := NewBuilder().
+			Append("a", String("foo")).
+			AppendDocument("b", NewBuilder().Append("c", Int32(1))).
+			AppendElements(Document{{"d", Boolean(true)}, {"e", Null()}}).
+			Build()
+
+		expected, err := bson.Marshal(bson.D{
+			{"a", "foo"},
+			{"b", bson.D{{"c", int32(1)}}},
+			{"d", true},
+			{"e", nil},
+		})
+		assert.Nil(t, err, "Marshal error: %v", err)
+		assert.Equal(t, bson.Raw(expected), got, "expected document %v, got %v", bson.Raw(expected), got)
+	})
+	t.Run("append after build", func(t *testing.T) {
+		b := NewBuilder().Append("a", Int32(1))
+		first := b.Build()
+		second := b.Append("b", Int32(2)).Build()
+
+		expected := Document{{"a", Int32(1)}}
+		assert.Equal(t, bson.Raw(expected.appendDocument(nil)), first, "expected document %v, got %v", expected, first)
+		expected = expected.Append("b", Int32(2))
+		assert.Equal(t, bson.Raw(expected.appendDocument(nil)), second, "expected document %v, got %v", expected, second)
+	})
+	t.Run("empty", func(t *testing.T) {
+		got := NewBuilder().Build()
+		expected := bson.Raw{0x05, 0x00, 0x00, 0x00, 0x00}
+		assert.Equal(t, expected, got, "expected document %v, got %v", expected, got)
+	})
+}
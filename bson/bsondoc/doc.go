@@ -0,0 +1,28 @@
+// Copyright (C) MongoDB, Inc. 2021-present.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
+
+// Package bsondoc provides a supported API for building and manipulating BSON documents without reflection. It is the
+// successor to the x/bsonx package, which is not covered by the API stability guarantees of the driver.
+//
+// A Document is an ordered list of elements that can be modified one element at a time. Its values are bson.RawValue,
+// which are created with the constructor functions in this package:
+//
+//	doc := bsondoc.Document{}.
+//		Append("name", bsondoc.String("pineapple")).
+//		Append("qty", bsondoc.Int32(5)).
+//		Append("tags", bsondoc.Array(bsondoc.String("fruit"), bsondoc.String("yellow")))
+//	doc = doc.Set("qty", bsondoc.Int32(10)).Delete("tags")
+//
+// A Document implements the bson.Marshaler and bson.Unmarshaler interfaces, so it can be passed anywhere the driver
+// accepts a document. A Builder can be used instead when a document only needs to be written once, because it appends
+// elements directly to the encoded document.
+//
+// Migrating from x/bsonx
+//
+// The bsonx.Doc, bsonx.Elem, and bsonx.Val types correspond to the Document, Element, and bson.RawValue types. The
+// bsonx constructor functions, e.g. bsonx.String, have counterparts with the same names in this package, except for
+// bsonx.Document, which corresponds to Embedded.
+package bsondoc // import "go.mongodb.org/mongo-driver/bson/bsondoc"
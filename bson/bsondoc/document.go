@@ -0,0 +1,236 @@
+// Copyright (C) MongoDB, Inc. 2021-present.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
+
+package bsondoc
+
+import (
+	"fmt"
+	"strconv"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/bsontype"
+	"go.mongodb.org/mongo-driver/x/bsonx/bsoncore"
+)
+
+// ErrElementNotFound is returned by LookupErr when the document does not contain the key. It is the same error that is
+// returned by bson.Raw.LookupErr.
+var ErrElementNotFound = bsoncore.ErrElementNotFound
+
+// ErrEmptyKey is returned by LookupErr when no key is provided.
+var ErrEmptyKey = bsoncore.ErrEmptyKey
+
+// InvalidDepthTraversalError is returned by LookupErr when one component of the key, except the last, is neither an
+// embedded document nor an array.
+type InvalidDepthTraversalError struct {
+	Key  string
+	Type bsontype.Type
+}
+
+func (idte InvalidDepthTraversalError) Error() string {
+	return fmt.Sprintf(
+		"attempt to traverse into %s, but it's type is %s, not %s nor %s",
+		idte.Key, idte.Type, bsontype.EmbeddedDocument, bsontype.Array,
+	)
+}
+
+// Element is a key-value pair of a Document.
+type Element struct {
+	Key   string
+	Value bson.RawValue
+}
+
+// Equal compares e and e2 and returns true if they have the same key and value.
+func (e Element) Equal(e2 Element) bool {
+	return e.Key == e2.Key && e.Value.Equal(e2.Value)
+}
+
+// Document is an ordered BSON document. The methods that modify a Document return the updated Document, which can
+// share its elements with the original one, so the result should be used in place of the original, as with append.
+type Document []Element
+
+// ReadDocument creates a Document from an encoded BSON document. An error is returned if b is not a valid BSON
+// document.
+func ReadDocument(b []byte) (Document, error) {
+	doc := Document{}
+	if err := doc.UnmarshalBSON(b); err != nil {
+		return nil, err
+	}
+	return doc, nil
+}
+
+// Copy makes a shallow copy of the document.
+func (d Document) Copy() Document {
+	d2 := make(Document, len(d))
+	copy(d2, d)
+	return d2
+}
+
+// Append adds an element with the key and value provided to the end of the document.
+func (d Document) Append(key string, val bson.RawValue) Document {
+	return append(d, Element{Key: key, Value: val})
+}
+
+// Prepend adds an element with the key and value provided to the beginning of the document.
+func (d Document) Prepend(key string, val bson.RawValue) Document {
+	return d.Insert(0, key, val)
+}
+
+// Insert adds an element with the key and value provided at index i of the document, shifting the elements after it.
+// Insert panics if i is out of range.
+func (d Document) Insert(i int, key string, val bson.RawValue) Document {
+	d = append(d, Element{})
+	copy(d[i+1:], d[i:])
+	d[i] = Element{Key: key, Value: val}
+	return d
+}
+
+// Set replaces the value of the first element with the provided key. If the document does not have an element with
+// that key, an element is appended to the document instead.
+func (d Document) Set(key string, val bson.RawValue) Document {
+	idx := d.IndexOf(key)
+	if idx == -1 {
+		return d.Append(key, val)
+	}
+	d[idx].Value = val
+	return d
+}
+
+// IndexOf returns the index of the first element with the provided key, or -1 if there is no such element.
+func (d Document) IndexOf(key string) int {
+	for i, e := range d {
+		if e.Key == key {
+			return i
+		}
+	}
+	return -1
+}
+
+// Delete removes the first element with the provided key, if one exists.
+func (d Document) Delete(key string) Document {
+	idx := d.IndexOf(key)
+	if idx == -1 {
+		return d
+	}
+	return append(d[:idx], d[idx+1:]...)
+}
+
+// Lookup searches the document and potentially embedded documents or arrays for the provided key. Each key provided to
+// this method represents a layer of depth.
+//
+// This method will return a zero bson.RawValue if the key does not exist. To know if the key actually exists, use
+// LookupErr.
+func (d Document) Lookup(key ...string) bson.RawValue {
+	val, _ := d.LookupErr(key...)
+	return val
+}
+
+// LookupErr searches the document and potentially embedded documents or arrays for the provided key. Each key provided
+// to this method represents a layer of depth. Array elements are looked up by their index, e.g. "0".
+func (d Document) LookupErr(key ...string) (bson.RawValue, error) {
+	if len(key) == 0 {
+		return bson.RawValue{}, ErrEmptyKey
+	}
+
+	idx := d.IndexOf(key[0])
+	if idx == -1 {
+		return bson.RawValue{}, ErrElementNotFound
+	}
+	val := d[idx].Value
+	if len(key) == 1 {
+		return val, nil
+	}
+
+	var cv bsoncore.Value
+	var err error
+	switch val.Type {
+	case bsontype.EmbeddedDocument, bsontype.Array:
+		// Arrays are encoded as documents whose keys are the indexes of the values.
+		cv, err = bsoncore.Document(val.Value).LookupErr(key[1:]...)
+	default:
+		return bson.RawValue{}, InvalidDepthTraversalError{Key: key[0], Type: val.Type}
+	}
+	if idte, ok := err.(bsoncore.InvalidDepthTraversalError); ok {
+		return bson.RawValue{}, InvalidDepthTraversalError(idte)
+	}
+	if err != nil {
+		return bson.RawValue{}, err
+	}
+	return bson.RawValue{Type: cv.Type, Value: cv.Data}, nil
+}
+
+// Equal compares d and d2 and returns true if they have the same elements in the same order.
+func (d Document) Equal(d2 Document) bool {
+	if len(d) != len(d2) {
+		return false
+	}
+	for i := range d {
+		if !d[i].Equal(d2[i]) {
+			return false
+		}
+	}
+	return true
+}
+
+// MarshalBSON implements the bson.Marshaler interface.
+//
+// This method will never return an error.
+func (d Document) MarshalBSON() ([]byte, error) { return d.appendDocument(nil), nil }
+
+// MarshalBSONValue implements the bsoncodec.ValueMarshaler interface.
+//
+// This method will never return an error.
+func (d Document) MarshalBSONValue() (bsontype.Type, []byte, error) {
+	return bsontype.EmbeddedDocument, d.appendDocument(nil), nil
+}
+
+// UnmarshalBSON implements the bson.Unmarshaler interface. The elements of the document are replaced with the elements
+// of b, which is copied.
+func (d *Document) UnmarshalBSON(b []byte) error {
+	if d == nil {
+		return fmt.Errorf("cannot unmarshal into a nil Document")
+	}
+	if err := bsoncore.Document(b).Validate(); err != nil {
+		return err
+	}
+
+	b = append([]byte(nil), b...)
+	elems, err := bsoncore.Document(b).Elements()
+	if err != nil {
+		return err
+	}
+	doc := make(Document, 0, len(elems))
+	for _, elem := range elems {
+		val := elem.Value()
+		doc = append(doc, Element{Key: elem.Key(), Value: bson.RawValue{Type: val.Type, Value: val.Data}})
+	}
+	*d = doc
+	return nil
+}
+
+// UnmarshalBSONValue implements the bsoncodec.ValueUnmarshaler interface.
+func (d *Document) UnmarshalBSONValue(t bsontype.Type, data []byte) error {
+	if t != bsontype.EmbeddedDocument {
+		return fmt.Errorf("cannot unmarshal %s into a Document", t)
+	}
+	return d.UnmarshalBSON(data)
+}
+
+// String implements the fmt.Stringer interface. The document is formatted as Extended JSON.
+func (d Document) String() string {
+	return bsoncore.Document(d.appendDocument(nil)).String()
+}
+
+func (d Document) appendDocument(dst []byte) []byte {
+	idx, dst := bsoncore.AppendDocumentStart(dst)
+	for _, e := range d {
+		dst = bsoncore.AppendHeader(dst, e.Value.Type, e.Key)
+		dst = append(dst, e.Value.Value...)
+	}
+	dst, _ = bsoncore.AppendDocumentEnd(dst, idx)
+	return dst
+}
+
+func arrayKey(i int) string { return strconv.Itoa(i) }
@@ -0,0 +1,148 @@
+// Copyright (C) MongoDB, Inc. 2021-present.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
+
+package bsondoc
+
+import (
+	"testing"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/bsontype"
+	"go.mongodb.org/mongo-driver/internal/testutil/assert"
+)
+
+func TestDocument(t *testing.T) {
+	t.Run("manipulation", func(t *testing.T) {
+		testCases := []struct {
+			name     string
+			fn       func(Document) Document
+			expected Document
+		}{
+			{
+				"append",
+				func(d Document) Document { return d.Append("c", Int32(3)) },
+				Document{{"a", Int32(1)}, {"b", Int32(2)}, {"c", Int32(3)}},
+			},
+			{
+				"prepend",
+				func(d Document) Document { return d.Prepend("c", Int32(3)) },
+				Document{{"c", Int32(3)}, {"a", Int32(1)}, {"b", Int32(2)}},
+			},
+			{
+				"insert",
+				func(d Document) Document { return d.Insert(1, "c", Int32(3)) },
+				Document{{"a", Int32(1)}, {"c", Int32(3)}, {"b", Int32(2)}},
+			},
+			{
+				"set existing",
+				func(d Document) Document { return d.Set("a", String("foo")) },
+				Document{{"a", String("foo")}, {"b", Int32(2)}},
+			},
+			{
+				"set new",
+				func(d Document) Document { return d.Set("c", Int32(3)) },
+				Document{{"a", Int32(1)}, {"b", Int32(2)}, {"c", Int32(3)}},
+			},
+			{
+				"delete existing",
+				func(d Document) Document { return d.Delete("a") },
+				Document{{"b", Int32(2)}},
+			},
+			{
+				"delete missing",
+				func(d Document) Document { return d.Delete("c") },
+				Document{{"a", Int32(1)}, {"b", Int32(2)}},
+			},
+		}
+		for _, tc := range testCases {
+			t.Run(tc.name, func(t *testing.T) {
+				doc := Document{{"a", Int32(1)}, {"b", Int32(2)}}
+				got := tc.fn(doc)
+				assert.True(t, got.Equal(tc.expected), "expected document %v, got %v", tc.expected, got)
+			})
+		}
+	})
+	t.Run("lookup", func(t *testing.T) {
+		doc := Document{}.
+			Append("a", Embedded(Document{{"b", String("foo")}})).
+			Append("c", Array(Int32(1), Embedded(Document{{"d", Boolean(true)}}))).
+			Append("e", Int64(5))
+
+		testCases := []struct {
+			name     string
+			key      []string
+			expected bson.RawValue
+			err      error
+		}{
+			{"top level", []string{"e"}, Int64(5), nil},
+			{"embedded document", []string{"a", "b"}, String("foo"), nil},
+			{"array", []string{"c", "0"}, Int32(1), nil},
+			{"document in array", []string{"c", "1", "d"}, Boolean(true), nil},
+			{"empty key", nil, bson.RawValue{}, ErrEmptyKey},
+			{"missing", []string{"x"}, bson.RawValue{}, ErrElementNotFound},
+			{"missing in embedded document", []string{"a", "x"}, bson.RawValue{}, ErrElementNotFound},
+			{"traverse non-document", []string{"e", "x"}, bson.RawValue{},
+				InvalidDepthTraversalError{Key: "e", Type: bsontype.Int64}},
+			{"traverse non-document in embedded document", []string{"a", "b", "x"}, bson.RawValue{},
+				InvalidDepthTraversalError{Key: "b", Type: bsontype.String}},
+		}
+		for _, tc := range testCases {
+			t.Run(tc.name, func(t *testing.T) {
+				got, err := doc.LookupErr(tc.key...)
+				assert.Equal(t, tc.err, err, "expected error %v, got %v", tc.err, err)
+				assert.True(t, tc.expected.Equal(got), "expected value %v, got %v", tc.expected, got)
+			})
+		}
+	})
+	t.Run("marshal and unmarshal", func(t *testing.T) {
+		doc := Document{}.
+			Append("a", String("foo")).
+			Append("b", Embedded(Document{{"c", Int32(1)}})).
+			Append("d", Array(Null(), Double(3.14)))
+
+		b, err := bson.Marshal(doc)
+		assert.Nil(t, err, "Marshal error: %v", err)
+		expected, err := bson.Marshal(bson.D{
+			{"a", "foo"},
+			{"b", bson.D{{"c", int32(1)}}},
+			{"d", bson.A{nil, 3.14}},
+		})
+		assert.Nil(t, err, "Marshal error: %v", err)
+		assert.Equal(t, bson.Raw(expected), bson.Raw(b), "expected document %v, got %v", bson.Raw(expected), bson.Raw(b))
+
+		var got Document
+		err = bson.Unmarshal(b, &got)
+		assert.Nil(t, err, "Unmarshal error: %v", err)
+		assert.True(t, doc.Equal(got), "expected document %v, got %v", doc, got)
+
+		// The decoded values must not reference the original bytes.
+		for i := range b {
+			b[i] = 0
+		}
+		assert.True(t, doc.Equal(got), "expected document %v, got %v", doc, got)
+	})
+	t.Run("marshal as value", func(t *testing.T) {
+		b, err := bson.Marshal(bson.D{{"doc", Document{{"a", Int32(1)}}}})
+		assert.Nil(t, err, "Marshal error: %v", err)
+
+		var got struct {
+			Doc Document
+		}
+		err = bson.Unmarshal(b, &got)
+		assert.Nil(t, err, "Unmarshal error: %v", err)
+		expected := Document{{"a", Int32(1)}}
+		assert.True(t, expected.Equal(got.Doc), "expected document %v, got %v", expected, got.Doc)
+	})
+	t.Run("read invalid document", func(t *testing.T) {
+		_, err := ReadDocument([]byte{0x05, 0x00, 0x00})
+		assert.NotNil(t, err, "expected error, got nil")
+	})
+	t.Run("string", func(t *testing.T) {
+		doc := Document{{"a", Int32(1)}, {"b", String("foo")}}
+		expected := `{"a": {"$numberInt":"1"},"b": "foo"}`
+		assert.Equal(t, expected, doc.String(), "expected %s, got %s", expected, doc.String())
+	})
+}
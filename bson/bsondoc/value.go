@@ -0,0 +1,133 @@
+// Copyright (C) MongoDB, Inc. 2021-present.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
+
+package bsondoc
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/bsontype"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/x/bsonx/bsoncore"
+)
+
+func newValue(t bsontype.Type, data []byte) bson.RawValue {
+	return bson.RawValue{Type: t, Value: data}
+}
+
+// Double constructs a BSON double value.
+func Double(f64 float64) bson.RawValue {
+	return newValue(bsontype.Double, bsoncore.AppendDouble(nil, f64))
+}
+
+// String constructs a BSON string value.
+func String(str string) bson.RawValue {
+	return newValue(bsontype.String, bsoncore.AppendString(nil, str))
+}
+
+// Embedded constructs a BSON embedded document value from doc.
+func Embedded(doc Document) bson.RawValue {
+	return newValue(bsontype.EmbeddedDocument, doc.appendDocument(nil))
+}
+
+// EmbeddedRaw constructs a BSON embedded document value from an encoded document. The document is not validated.
+func EmbeddedRaw(doc bson.Raw) bson.RawValue {
+	return newValue(bsontype.EmbeddedDocument, doc)
+}
+
+// Array constructs a BSON array value from the provided values.
+func Array(vals ...bson.RawValue) bson.RawValue {
+	idx, arr := bsoncore.AppendArrayStart(nil)
+	for i, val := range vals {
+		arr = bsoncore.AppendHeader(arr, val.Type, arrayKey(i))
+		arr = append(arr, val.Value...)
+	}
+	arr, _ = bsoncore.AppendArrayEnd(arr, idx)
+	return newValue(bsontype.Array, arr)
+}
+
+// Binary constructs a BSON binary value.
+func Binary(subtype byte, data []byte) bson.RawValue {
+	return newValue(bsontype.Binary, bsoncore.AppendBinary(nil, subtype, data))
+}
+
+// Undefined constructs a BSON undefined value.
+func Undefined() bson.RawValue { return newValue(bsontype.Undefined, nil) }
+
+// ObjectID constructs a BSON objectid value.
+func ObjectID(oid primitive.ObjectID) bson.RawValue {
+	return newValue(bsontype.ObjectID, bsoncore.AppendObjectID(nil, oid))
+}
+
+// Boolean constructs a BSON boolean value.
+func Boolean(b bool) bson.RawValue {
+	return newValue(bsontype.Boolean, bsoncore.AppendBoolean(nil, b))
+}
+
+// DateTime constructs a BSON datetime value from the number of milliseconds since the Unix epoch.
+func DateTime(dt int64) bson.RawValue {
+	return newValue(bsontype.DateTime, bsoncore.AppendDateTime(nil, dt))
+}
+
+// Time constructs a BSON datetime value from t. The time is truncated to millisecond precision.
+func Time(t time.Time) bson.RawValue {
+	return newValue(bsontype.DateTime, bsoncore.AppendTime(nil, t))
+}
+
+// Null constructs a BSON null value.
+func Null() bson.RawValue { return newValue(bsontype.Null, nil) }
+
+// Regex constructs a BSON regex value.
+func Regex(pattern, options string) bson.RawValue {
+	return newValue(bsontype.Regex, bsoncore.AppendRegex(nil, pattern, options))
+}
+
+// DBPointer constructs a BSON dbpointer value.
+func DBPointer(ns string, ptr primitive.ObjectID) bson.RawValue {
+	return newValue(bsontype.DBPointer, bsoncore.AppendDBPointer(nil, ns, ptr))
+}
+
+// JavaScript constructs a BSON javascript value.
+func JavaScript(js string) bson.RawValue {
+	return newValue(bsontype.JavaScript, bsoncore.AppendJavaScript(nil, js))
+}
+
+// Symbol constructs a BSON symbol value.
+func Symbol(symbol string) bson.RawValue {
+	return newValue(bsontype.Symbol, bsoncore.AppendSymbol(nil, symbol))
+}
+
+// CodeWithScope constructs a BSON code with scope value.
+func CodeWithScope(code string, scope Document) bson.RawValue {
+	return newValue(bsontype.CodeWithScope, bsoncore.AppendCodeWithScope(nil, code, scope.appendDocument(nil)))
+}
+
+// Int32 constructs a BSON int32 value.
+func Int32(i32 int32) bson.RawValue {
+	return newValue(bsontype.Int32, bsoncore.AppendInt32(nil, i32))
+}
+
+// Timestamp constructs a BSON timestamp value.
+func Timestamp(t, i uint32) bson.RawValue {
+	return newValue(bsontype.Timestamp, bsoncore.AppendTimestamp(nil, t, i))
+}
+
+// Int64 constructs a BSON int64 value.
+func Int64(i64 int64) bson.RawValue {
+	return newValue(bsontype.Int64, bsoncore.AppendInt64(nil, i64))
+}
+
+// Decimal128 constructs a BSON decimal128 value.
+func Decimal128(d128 primitive.Decimal128) bson.RawValue {
+	return newValue(bsontype.Decimal128, bsoncore.AppendDecimal128(nil, d128))
+}
+
+// MinKey constructs a BSON minkey value.
+func MinKey() bson.RawValue { return newValue(bsontype.MinKey, nil) }
+
+// MaxKey constructs a BSON maxkey value.
+func MaxKey() bson.RawValue { return newValue(bsontype.MaxKey, nil) }
@@ -0,0 +1,65 @@
+// Copyright (C) MongoDB, Inc. 2021-present.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
+
+package bsondoc
+
+import (
+	"testing"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/internal/testutil/assert"
+)
+
+func TestValueConstructors(t *testing.T) {
+	oid := primitive.NewObjectID()
+	now := time.Now().Truncate(time.Millisecond)
+
+	// Each constructed value must be encoded the same way as the equivalent Go value is by the bson package.
+	testCases := []struct {
+		name     string
+		val      bson.RawValue
+		expected interface{}
+	}{
+		{"double", Double(3.14), 3.14},
+		{"string", String("foo"), "foo"},
+		{"embedded", Embedded(Document{{"a", Int32(1)}}), bson.D{{"a", int32(1)}}},
+		{"embedded raw", EmbeddedRaw(bson.Raw(Document{{"a", Int32(1)}}.appendDocument(nil))), bson.D{{"a", int32(1)}}},
+		{"array", Array(Int32(1), String("foo")), bson.A{int32(1), "foo"}},
+		{"empty array", Array(), bson.A{}},
+		{"binary", Binary(0x80, []byte{0x01}), primitive.Binary{Subtype: 0x80, Data: []byte{0x01}}},
+		{"undefined", Undefined(), primitive.Undefined{}},
+		{"objectid", ObjectID(oid), oid},
+		{"boolean", Boolean(true), true},
+		{"datetime", DateTime(1000), primitive.DateTime(1000)},
+		{"time", Time(now), now},
+		{"null", Null(), nil},
+		{"regex", Regex("foo", "i"), primitive.Regex{Pattern: "foo", Options: "i"}},
+		{"dbpointer", DBPointer("foo.bar", oid), primitive.DBPointer{DB: "foo.bar", Pointer: oid}},
+		{"javascript", JavaScript("var a;"), primitive.JavaScript("var a;")},
+		{"symbol", Symbol("foo"), primitive.Symbol("foo")},
+		{"code with scope", CodeWithScope("var a;", Document{{"a", Int32(1)}}),
+			primitive.CodeWithScope{Code: "var a;", Scope: bson.D{{"a", int32(1)}}}},
+		{"int32", Int32(1), int32(1)},
+		{"timestamp", Timestamp(1, 2), primitive.Timestamp{T: 1, I: 2}},
+		{"int64", Int64(1), int64(1)},
+		{"decimal128", Decimal128(primitive.NewDecimal128(1, 2)), primitive.NewDecimal128(1, 2)},
+		{"minkey", MinKey(), primitive.MinKey{}},
+		{"maxkey", MaxKey(), primitive.MaxKey{}},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			b, err := bson.Marshal(bson.D{{"v", tc.expected}})
+			assert.Nil(t, err, "Marshal error: %v", err)
+			expected := bson.Raw(b).Lookup("v")
+
+			assert.True(t, expected.Equal(tc.val), "expected value %v, got %v", expected, tc.val)
+			err = tc.val.Validate()
+			assert.Nil(t, err, "Validate error: %v", err)
+		})
+	}
+}
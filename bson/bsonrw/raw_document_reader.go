@@ -0,0 +1,274 @@
+// Copyright (C) MongoDB, Inc. 2021-present.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
+
+package bsonrw
+
+import (
+	"errors"
+	"fmt"
+	"io"
+
+	"go.mongodb.org/mongo-driver/bson/bsontype"
+	"go.mongodb.org/mongo-driver/x/bsonx/bsoncore"
+)
+
+var errNilReader = errors.New("cannot create a RawDocumentReader from a nil io.Reader")
+
+type rdrFrame struct {
+	end   int64 // offset of the first byte after the document or array
+	array bool
+}
+
+// RawDocumentReader reads a BSON document from an io.Reader one element at a time, so documents larger than the
+// available memory can be consumed as long as each element that is read as a value fits in memory. Embedded
+// documents and arrays can be descended into and read element by element as well.
+//
+// A RawDocumentReader reads exactly the bytes of one document from the underlying io.Reader. Because elements are read
+// in small pieces, wrapping an unbuffered io.Reader in a bufio.Reader is recommended.
+//
+// A RawDocumentReader is not safe for concurrent use.
+type RawDocumentReader struct {
+	r       io.Reader
+	offset  int64
+	buf     []byte
+	stack   []rdrFrame
+	pending bsontype.Type
+}
+
+// NewRawDocumentReader creates a RawDocumentReader that reads a document from r. It reads the length of the document
+// before returning.
+func NewRawDocumentReader(r io.Reader) (*RawDocumentReader, error) {
+	if r == nil {
+		return nil, errNilReader
+	}
+
+	rdr := &RawDocumentReader{r: r}
+	if err := rdr.push(false); err != nil {
+		return nil, err
+	}
+	return rdr, nil
+}
+
+// ReadElement reads the type and key of the next element in the current document or array. The value of the element
+// must be read with ReadValue or Descend before ReadElement is called again. ErrEOD or ErrEOA is returned once the end
+// of the current document or array has been reached and reading continues with the enclosing document. io.EOF is
+// returned after the end of the top level document.
+func (rdr *RawDocumentReader) ReadElement() (bsontype.Type, string, error) {
+	if rdr.pending != bsontype.Type(0) {
+		return bsontype.Type(0), "", fmt.Errorf("the value of the previous %s element must be read first", rdr.pending)
+	}
+	if len(rdr.stack) == 0 {
+		return bsontype.Type(0), "", io.EOF
+	}
+
+	frame := rdr.stack[len(rdr.stack)-1]
+	switch {
+	case rdr.offset == frame.end-1:
+		b, err := rdr.read(1)
+		if err != nil {
+			return bsontype.Type(0), "", err
+		}
+		if b[0] != 0x00 {
+			return bsontype.Type(0), "", rdr.corrupted("missing terminator")
+		}
+		rdr.stack = rdr.stack[:len(rdr.stack)-1]
+		if frame.array {
+			return bsontype.Type(0), "", ErrEOA
+		}
+		return bsontype.Type(0), "", ErrEOD
+	case rdr.offset >= frame.end:
+		return bsontype.Type(0), "", rdr.corrupted("element extends past the end of its parent")
+	}
+
+	b, err := rdr.read(1)
+	if err != nil {
+		return bsontype.Type(0), "", err
+	}
+	t := bsontype.Type(b[0])
+
+	key, err := rdr.readCString(nil)
+	if err != nil {
+		return bsontype.Type(0), "", err
+	}
+
+	rdr.pending = t
+	return t, string(key), nil
+}
+
+// ReadValue reads the value of the element returned by the last call to ReadElement. The returned value is only valid
+// until the next call to a method on the RawDocumentReader.
+func (rdr *RawDocumentReader) ReadValue() (bsoncore.Value, error) {
+	t := rdr.pending
+	if t == bsontype.Type(0) {
+		return bsoncore.Value{}, errors.New("ReadElement must be called before ReadValue")
+	}
+
+	var data []byte
+	var err error
+	switch t {
+	case bsontype.Undefined, bsontype.Null, bsontype.MinKey, bsontype.MaxKey:
+		data = rdr.buf[:0]
+	case bsontype.Boolean:
+		data, err = rdr.read(1)
+	case bsontype.Int32:
+		data, err = rdr.read(4)
+	case bsontype.Double, bsontype.DateTime, bsontype.Timestamp, bsontype.Int64:
+		data, err = rdr.read(8)
+	case bsontype.ObjectID:
+		data, err = rdr.read(12)
+	case bsontype.Decimal128:
+		data, err = rdr.read(16)
+	case bsontype.String, bsontype.JavaScript, bsontype.Symbol:
+		data, err = rdr.readLengthPrefixed(0, 0)
+	case bsontype.Binary:
+		data, err = rdr.readLengthPrefixed(0, 1)
+	case bsontype.DBPointer:
+		data, err = rdr.readLengthPrefixed(0, 12)
+	case bsontype.EmbeddedDocument, bsontype.Array, bsontype.CodeWithScope:
+		data, err = rdr.readLengthPrefixed(-4, 0)
+	case bsontype.Regex:
+		data, err = rdr.readCString(rdr.buf[:0])
+		if err == nil {
+			data, err = rdr.readCString(data)
+		}
+	default:
+		err = fmt.Errorf("attempted to read bytes of unknown BSON type %v", t)
+	}
+	if err != nil {
+		return bsoncore.Value{}, err
+	}
+
+	rdr.pending = bsontype.Type(0)
+	return bsoncore.Value{Type: t, Data: data}, nil
+}
+
+// Descend enters the embedded document or array returned by the last call to ReadElement. Subsequent calls to
+// ReadElement return its elements until ErrEOD or ErrEOA is returned.
+func (rdr *RawDocumentReader) Descend() error {
+	switch rdr.pending {
+	case bsontype.EmbeddedDocument, bsontype.Array:
+	default:
+		return fmt.Errorf("cannot descend into a value of type %s", rdr.pending)
+	}
+
+	array := rdr.pending == bsontype.Array
+	rdr.pending = bsontype.Type(0)
+	return rdr.push(array)
+}
+
+// push reads the length of a document or array and enters it.
+func (rdr *RawDocumentReader) push(array bool) error {
+	start := rdr.offset
+	b, err := rdr.read(4)
+	if err != nil {
+		return err
+	}
+	length, _, _ := bsoncore.ReadLength(b)
+	if length < 5 {
+		return rdr.corrupted(fmt.Sprintf("invalid length %d", length))
+	}
+
+	end := start + int64(length)
+	if len(rdr.stack) > 0 && end > rdr.stack[len(rdr.stack)-1].end-1 {
+		return rdr.corrupted("length extends past the end of its parent")
+	}
+	rdr.stack = append(rdr.stack, rdrFrame{end: end, array: array})
+	return nil
+}
+
+// readLengthPrefixed reads a value that starts with an int32 length. The number of bytes following the length is the
+// length plus adjust plus extra.
+func (rdr *RawDocumentReader) readLengthPrefixed(adjust, extra int32) ([]byte, error) {
+	b, err := rdr.read(4)
+	if err != nil {
+		return nil, err
+	}
+	length, _, _ := bsoncore.ReadLength(b)
+	n := length + adjust
+	if n < 0 {
+		return nil, rdr.corrupted(fmt.Sprintf("invalid length %d", length))
+	}
+	return rdr.appendRead(b, int64(n)+int64(extra))
+}
+
+// readCString appends bytes up to and excluding the next null byte to dst. The null byte is consumed and, for a
+// non-nil dst, appended.
+func (rdr *RawDocumentReader) readCString(dst []byte) ([]byte, error) {
+	var str []byte
+	for {
+		b, err := rdr.readByte()
+		if err != nil {
+			return nil, err
+		}
+		if b == 0x00 {
+			break
+		}
+		str = append(str, b)
+	}
+	if dst == nil {
+		return str, nil
+	}
+	dst = append(dst, str...)
+	dst = append(dst, 0x00)
+	rdr.buf = dst
+	return dst, nil
+}
+
+func (rdr *RawDocumentReader) readByte() (byte, error) {
+	if err := rdr.checkBounds(1); err != nil {
+		return 0, err
+	}
+	var b [1]byte
+	if _, err := io.ReadFull(rdr.r, b[:]); err != nil {
+		return 0, unexpectedEOF(err)
+	}
+	rdr.offset++
+	return b[0], nil
+}
+
+// read reads n bytes into the reader's buffer.
+func (rdr *RawDocumentReader) read(n int64) ([]byte, error) {
+	return rdr.appendRead(rdr.buf[:0], n)
+}
+
+func (rdr *RawDocumentReader) appendRead(dst []byte, n int64) ([]byte, error) {
+	if err := rdr.checkBounds(n); err != nil {
+		return nil, err
+	}
+
+	l := int64(len(dst))
+	if int64(cap(dst)) < l+n {
+		buf := make([]byte, l, l+n)
+		copy(buf, dst)
+		dst = buf
+	}
+	dst = dst[:l+n]
+	if _, err := io.ReadFull(rdr.r, dst[l:]); err != nil {
+		return nil, unexpectedEOF(err)
+	}
+	rdr.offset += n
+	rdr.buf = dst
+	return dst, nil
+}
+
+// checkBounds returns an error if reading n more bytes would read past the end of the current document or array.
+func (rdr *RawDocumentReader) checkBounds(n int64) error {
+	if len(rdr.stack) > 0 && rdr.offset+n > rdr.stack[len(rdr.stack)-1].end {
+		return rdr.corrupted("value extends past the end of its parent")
+	}
+	return nil
+}
+
+func (rdr *RawDocumentReader) corrupted(msg string) error {
+	return fmt.Errorf("invalid BSON document at offset %d: %s", rdr.offset, msg)
+}
+
+func unexpectedEOF(err error) error {
+	if err == io.EOF {
+		return io.ErrUnexpectedEOF
+	}
+	return err
+}
@@ -0,0 +1,154 @@
+// Copyright (C) MongoDB, Inc. 2021-present.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
+
+package bsonrw
+
+import (
+	"bytes"
+	"io"
+	"testing"
+
+	"go.mongodb.org/mongo-driver/bson/bsontype"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/x/bsonx/bsoncore"
+)
+
+func TestRawDocumentReader(t *testing.T) {
+	arr := bsoncore.BuildArray(nil,
+		bsoncore.Value{Type: bsontype.Int32, Data: bsoncore.AppendInt32(nil, 1)},
+		bsoncore.Value{Type: bsontype.EmbeddedDocument, Data: bsoncore.BuildDocumentFromElements(nil,
+			bsoncore.AppendStringElement(nil, "x", "y"),
+		)},
+	)
+	doc := bsoncore.BuildDocumentFromElements(nil,
+		bsoncore.AppendDoubleElement(nil, "double", 3.14),
+		bsoncore.AppendStringElement(nil, "string", "foo"),
+		bsoncore.AppendBinaryElement(nil, "binary", 0x80, []byte{0x01, 0x02}),
+		bsoncore.AppendNullElement(nil, "null"),
+		bsoncore.AppendObjectIDElement(nil, "oid", primitive.NewObjectID()),
+		bsoncore.AppendRegexElement(nil, "regex", "^foo", "i"),
+		bsoncore.AppendDBPointerElement(nil, "dbpointer", "db.coll", primitive.NewObjectID()),
+		bsoncore.AppendCodeWithScopeElement(nil, "cws", "x", bsoncore.BuildDocumentFromElements(nil)),
+		bsoncore.AppendDecimal128Element(nil, "decimal", primitive.NewDecimal128(1, 2)),
+		bsoncore.AppendArrayElement(nil, "array", arr),
+		bsoncore.AppendInt64Element(nil, "int64", 42),
+	)
+
+	t.Run("ReadValue", func(t *testing.T) {
+		elems, err := bsoncore.Document(doc).Elements()
+		noerr(t, err)
+
+		rdr, err := NewRawDocumentReader(bytes.NewReader(doc))
+		noerr(t, err)
+		for _, elem := range elems {
+			typ, key, err := rdr.ReadElement()
+			noerr(t, err)
+			if key != elem.Key() || typ != elem.Value().Type {
+				t.Fatalf("element mismatch. got %s %v; want %s %v", key, typ, elem.Key(), elem.Value().Type)
+			}
+			val, err := rdr.ReadValue()
+			noerr(t, err)
+			if !val.Equal(elem.Value()) {
+				t.Errorf("value mismatch for %s. got %v; want %v", key, val, elem.Value())
+			}
+		}
+		if _, _, err = rdr.ReadElement(); err != ErrEOD {
+			t.Errorf("expected %v, got %v", ErrEOD, err)
+		}
+		if _, _, err = rdr.ReadElement(); err != io.EOF {
+			t.Errorf("expected %v, got %v", io.EOF, err)
+		}
+	})
+	t.Run("Descend", func(t *testing.T) {
+		rdr, err := NewRawDocumentReader(bytes.NewReader(doc))
+		noerr(t, err)
+		for {
+			typ, key, err := rdr.ReadElement()
+			noerr(t, err)
+			if key == "array" {
+				noerr(t, rdr.Descend())
+				break
+			}
+			if typ == bsontype.EmbeddedDocument {
+				t.Fatalf("unexpected embedded document %s", key)
+			}
+			_, err = rdr.ReadValue()
+			noerr(t, err)
+		}
+
+		_, key, err := rdr.ReadElement()
+		noerr(t, err)
+		val, err := rdr.ReadValue()
+		noerr(t, err)
+		if key != "0" || val.Int32() != 1 {
+			t.Errorf("expected element 0 to be 1, got %s %v", key, val)
+		}
+
+		typ, _, err := rdr.ReadElement()
+		noerr(t, err)
+		if typ != bsontype.EmbeddedDocument {
+			t.Fatalf("expected %v, got %v", bsontype.EmbeddedDocument, typ)
+		}
+		noerr(t, rdr.Descend())
+		_, key, err = rdr.ReadElement()
+		noerr(t, err)
+		val, err = rdr.ReadValue()
+		noerr(t, err)
+		if key != "x" || val.StringValue() != "y" {
+			t.Errorf("expected x to be y, got %s %v", key, val)
+		}
+		if _, _, err = rdr.ReadElement(); err != ErrEOD {
+			t.Errorf("expected %v, got %v", ErrEOD, err)
+		}
+		if _, _, err = rdr.ReadElement(); err != ErrEOA {
+			t.Errorf("expected %v, got %v", ErrEOA, err)
+		}
+
+		_, key, err = rdr.ReadElement()
+		noerr(t, err)
+		val, err = rdr.ReadValue()
+		noerr(t, err)
+		if key != "int64" || val.Int64() != 42 {
+			t.Errorf("expected int64 to be 42, got %s %v", key, val)
+		}
+		if _, _, err = rdr.ReadElement(); err != ErrEOD {
+			t.Errorf("expected %v, got %v", ErrEOD, err)
+		}
+	})
+	t.Run("value must be read", func(t *testing.T) {
+		rdr, err := NewRawDocumentReader(bytes.NewReader(doc))
+		noerr(t, err)
+		_, _, err = rdr.ReadElement()
+		noerr(t, err)
+		if _, _, err = rdr.ReadElement(); err == nil {
+			t.Errorf("expected an error reading an element before the previous value")
+		}
+	})
+	t.Run("truncated", func(t *testing.T) {
+		rdr, err := NewRawDocumentReader(bytes.NewReader(doc[:30]))
+		noerr(t, err)
+		_, _, err = rdr.ReadElement()
+		noerr(t, err)
+		_, err = rdr.ReadValue()
+		noerr(t, err)
+		_, _, err = rdr.ReadElement()
+		noerr(t, err)
+		if _, err = rdr.ReadValue(); err != io.ErrUnexpectedEOF {
+			t.Errorf("expected %v, got %v", io.ErrUnexpectedEOF, err)
+		}
+	})
+	t.Run("invalid length", func(t *testing.T) {
+		_, err := NewRawDocumentReader(bytes.NewReader([]byte{0x01, 0x00, 0x00, 0x00}))
+		if err == nil {
+			t.Errorf("expected an error for an invalid document length")
+		}
+	})
+	t.Run("nil reader", func(t *testing.T) {
+		if _, err := NewRawDocumentReader(nil); err != errNilReader {
+			t.Errorf("expected %v, got %v", errNilReader, err)
+		}
+	})
+}
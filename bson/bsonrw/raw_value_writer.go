@@ -0,0 +1,111 @@
+// Copyright (C) MongoDB, Inc. 2021-present.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
+
+package bsonrw
+
+import (
+	"go.mongodb.org/mongo-driver/bson/bsontype"
+)
+
+// RawValueWriter incrementally builds a BSON document directly into a caller provided buffer. Each element is
+// appended to the buffer as soon as it is written, so a large document can be built element by element without
+// constructing its values separately first. The buffer may come from a pool and is only grown when needed.
+//
+// A RawValueWriter is not safe for concurrent use.
+type RawValueWriter struct {
+	vw *valueWriter
+}
+
+// NewRawValueWriter creates a RawValueWriter that appends a document to buf.
+func NewRawValueWriter(buf []byte) *RawValueWriter {
+	vw := newValueWriterFromSlice(buf)
+	_, _ = vw.WriteDocument() // cannot fail in top level mode
+	return &RawValueWriter{vw: vw}
+}
+
+// Element returns a ValueWriter for the element with the given key. Exactly one value must be written to the
+// returned ValueWriter before another element is started or the document is ended.
+func (rvw *RawValueWriter) Element(key string) (ValueWriter, error) {
+	return rvw.vw.WriteDocumentElement(key)
+}
+
+// AppendValueBytes appends an element with the given key, type, and already encoded value.
+func (rvw *RawValueWriter) AppendValueBytes(key string, t bsontype.Type, b []byte) error {
+	vw, err := rvw.Element(key)
+	if err != nil {
+		return err
+	}
+	return vw.(BytesWriter).WriteValueBytes(t, b)
+}
+
+// Array starts an array element with the given key and returns a RawArrayBuilder that writes its elements into the
+// same buffer. The array must be ended with RawArrayBuilder.End before another element is started or the document is
+// ended.
+func (rvw *RawValueWriter) Array(key string) (*RawArrayBuilder, error) {
+	vw, err := rvw.Element(key)
+	if err != nil {
+		return nil, err
+	}
+	if _, err = vw.WriteArray(); err != nil {
+		return nil, err
+	}
+	return &RawArrayBuilder{vw: rvw.vw}, nil
+}
+
+// End writes the document terminator and length.
+func (rvw *RawValueWriter) End() error {
+	return rvw.vw.WriteDocumentEnd()
+}
+
+// Bytes returns the buffer the document has been appended to. The document is only valid once End has been called.
+func (rvw *RawValueWriter) Bytes() []byte {
+	return rvw.vw.buf
+}
+
+// RawArrayBuilder incrementally builds a BSON array directly into a caller provided buffer. It is the array companion
+// to RawValueWriter and can either be created standalone with NewRawArrayBuilder or as an element of a document with
+// RawValueWriter.Array. Array keys are generated automatically.
+//
+// A RawArrayBuilder is not safe for concurrent use.
+type RawArrayBuilder struct {
+	vw *valueWriter
+}
+
+// NewRawArrayBuilder creates a RawArrayBuilder that appends an array to buf.
+func NewRawArrayBuilder(buf []byte) *RawArrayBuilder {
+	vw := newValueWriterFromSlice(buf)
+	// Push an element frame without writing a header so the array can be written at the top level. WriteArrayEnd
+	// pops both frames and leaves the writer in top level mode.
+	vw.push(mElement)
+	vw.push(mArray)
+	return &RawArrayBuilder{vw: vw}
+}
+
+// Element returns a ValueWriter for the next element of the array. Exactly one value must be written to the
+// returned ValueWriter before another element is started or the array is ended.
+func (rab *RawArrayBuilder) Element() (ValueWriter, error) {
+	return rab.vw.WriteArrayElement()
+}
+
+// AppendValueBytes appends an element with the given type and already encoded value.
+func (rab *RawArrayBuilder) AppendValueBytes(t bsontype.Type, b []byte) error {
+	vw, err := rab.Element()
+	if err != nil {
+		return err
+	}
+	return vw.(BytesWriter).WriteValueBytes(t, b)
+}
+
+// End writes the array terminator and length.
+func (rab *RawArrayBuilder) End() error {
+	return rab.vw.WriteArrayEnd()
+}
+
+// Bytes returns the buffer the array has been appended to. For a builder created with NewRawArrayBuilder the array is
+// only valid once End has been called.
+func (rab *RawArrayBuilder) Bytes() []byte {
+	return rab.vw.buf
+}
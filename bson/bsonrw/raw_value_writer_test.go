@@ -0,0 +1,92 @@
+// Copyright (C) MongoDB, Inc. 2021-present.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
+
+package bsonrw
+
+import (
+	"bytes"
+	"testing"
+
+	"go.mongodb.org/mongo-driver/bson/bsontype"
+	"go.mongodb.org/mongo-driver/x/bsonx/bsoncore"
+)
+
+func TestRawValueWriter(t *testing.T) {
+	t.Run("document", func(t *testing.T) {
+		prefix := []byte{0x01, 0x02}
+		rvw := NewRawValueWriter(append(make([]byte, 0, 64), prefix...))
+
+		vw, err := rvw.Element("a")
+		noerr(t, err)
+		noerr(t, vw.WriteInt32(1))
+		noerr(t, rvw.AppendValueBytes("b", bsontype.String, bsoncore.AppendString(nil, "foo")))
+
+		arr, err := rvw.Array("c")
+		noerr(t, err)
+		for i := int64(0); i < 3; i++ {
+			vw, err = arr.Element()
+			noerr(t, err)
+			noerr(t, vw.WriteInt64(i))
+		}
+		noerr(t, arr.End())
+
+		vw, err = rvw.Element("d")
+		noerr(t, err)
+		noerr(t, vw.WriteBoolean(true))
+		noerr(t, rvw.End())
+
+		want := bsoncore.BuildDocumentFromElements(prefix,
+			bsoncore.AppendInt32Element(nil, "a", 1),
+			bsoncore.AppendStringElement(nil, "b", "foo"),
+			bsoncore.AppendArrayElement(nil, "c", bsoncore.BuildArray(nil,
+				bsoncore.Value{Type: bsontype.Int64, Data: bsoncore.AppendInt64(nil, 0)},
+				bsoncore.Value{Type: bsontype.Int64, Data: bsoncore.AppendInt64(nil, 1)},
+				bsoncore.Value{Type: bsontype.Int64, Data: bsoncore.AppendInt64(nil, 2)},
+			)),
+			bsoncore.AppendBooleanElement(nil, "d", true),
+		)
+		if got := rvw.Bytes(); !bytes.Equal(got, want) {
+			t.Errorf("documents do not match. got %v; want %v", bsoncore.Document(got[2:]), bsoncore.Document(want[2:]))
+		}
+	})
+	t.Run("array", func(t *testing.T) {
+		rab := NewRawArrayBuilder(nil)
+		vw, err := rab.Element()
+		noerr(t, err)
+		noerr(t, vw.WriteString("foo"))
+
+		noerr(t, rab.AppendValueBytes(bsontype.Int32, bsoncore.AppendInt32(nil, 42)))
+
+		vw, err = rab.Element()
+		noerr(t, err)
+		dw, err := vw.WriteDocument()
+		noerr(t, err)
+		vw, err = dw.WriteDocumentElement("x")
+		noerr(t, err)
+		noerr(t, vw.WriteNull())
+		noerr(t, dw.WriteDocumentEnd())
+		noerr(t, rab.End())
+
+		want := bsoncore.BuildArray(nil,
+			bsoncore.Value{Type: bsontype.String, Data: bsoncore.AppendString(nil, "foo")},
+			bsoncore.Value{Type: bsontype.Int32, Data: bsoncore.AppendInt32(nil, 42)},
+			bsoncore.Value{Type: bsontype.EmbeddedDocument, Data: bsoncore.BuildDocumentFromElements(nil,
+				bsoncore.AppendNullElement(nil, "x"),
+			)},
+		)
+		if got := rab.Bytes(); !bytes.Equal(got, want) {
+			t.Errorf("arrays do not match. got %v; want %v", bsoncore.Array(got), bsoncore.Array(want))
+		}
+	})
+	t.Run("element before array end", func(t *testing.T) {
+		rvw := NewRawValueWriter(nil)
+		_, err := rvw.Array("a")
+		noerr(t, err)
+		if _, err = rvw.Element("b"); err == nil {
+			t.Errorf("expected an error starting an element before the array was ended")
+		}
+	})
+}
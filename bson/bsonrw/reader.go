@@ -53,6 +53,13 @@ type ValueReader interface {
 	ReadUndefined() error
 }
 
+// ElementCounter is implemented by ValueReaders that can report the number of elements of a BSON document or array
+// before it is read. ElementCount returns the number of elements of the document or array that the ValueReader is
+// positioned at, and false if it is not positioned at a document or array or the count is not available.
+type ElementCounter interface {
+	ElementCount() (int, bool)
+}
+
 // BytesReader is a generic interface used to read BSON bytes from a
 // ValueReader. This imterface is meant to be a superset of ValueReader, so that
 // types that implement ValueReader may also implement this interface.
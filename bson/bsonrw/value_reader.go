@@ -17,6 +17,7 @@ import (
 
 	"go.mongodb.org/mongo-driver/bson/bsontype"
 	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/x/bsonx/bsoncore"
 )
 
 var _ ValueReader = (*valueReader)(nil)
@@ -69,6 +70,11 @@ var ErrEOA = errors.New("end of array")
 // ErrEOD is the error returned when the end of a BSON document has been reached.
 var ErrEOD = errors.New("end of document")
 
+// MaxStrictDepth is the maximum number of levels that documents, arrays, and code with scope values can be nested in
+// BSON read by a ValueReader returned from NewStrictBSONDocumentReader. It matches the default maximum depth of BSON
+// accepted by the server.
+const MaxStrictDepth = 200
+
 type vrState struct {
 	mode  mode
 	vType bsontype.Type
@@ -82,6 +88,11 @@ type valueReader struct {
 
 	stack []vrState
 	frame int64
+
+	// strict enables the checks for untrusted input described in NewStrictBSONDocumentReader. maxDepth is the nesting
+	// limit for strict mode.
+	strict   bool
+	maxDepth int64
 }
 
 // NewBSONDocumentReader returns a ValueReader using b for the underlying BSON
@@ -95,6 +106,15 @@ func NewBSONDocumentReader(b []byte) ValueReader {
 	return newValueReader(b)
 }
 
+// NewStrictBSONDocumentReader returns a ValueReader like NewBSONDocumentReader that is hardened for reading BSON
+// from untrusted sources. The length of every embedded document, array, code with scope, string, and binary value must
+// fit within the value that contains it, the bytes returned by ReadValueBytes are fully validated, and values cannot be
+// nested more than MaxStrictDepth levels deep. Because the lengths read from b are never trusted beyond the bounds of
+// the enclosing value, the memory allocated while reading is bounded by len(b).
+func NewStrictBSONDocumentReader(b []byte) ValueReader {
+	return newStrictValueReader(b, MaxStrictDepth)
+}
+
 // NewBSONValueReader returns a ValueReader that starts in the Value mode instead of in top
 // level document mode. This enables the creation of a ValueReader for a single BSON value.
 func NewBSONValueReader(t bsontype.Type, val []byte) ValueReader {
@@ -120,6 +140,13 @@ func newValueReader(b []byte) *valueReader {
 	}
 }
 
+func newStrictValueReader(b []byte, maxDepth int64) *valueReader {
+	vr := newValueReader(b)
+	vr.strict = true
+	vr.maxDepth = maxDepth
+	return vr
+}
+
 func (vr *valueReader) reset(b []byte) {
 	if vr.stack == nil {
 		vr.stack = make([]vrState, 1, 5)
@@ -129,6 +156,8 @@ func (vr *valueReader) reset(b []byte) {
 	vr.d = b
 	vr.offset = 0
 	vr.frame = 0
+	vr.strict = false
+	vr.maxDepth = 0
 }
 
 func (vr *valueReader) advanceFrame() {
@@ -159,6 +188,9 @@ func (vr *valueReader) pushDocument() error {
 	if err != nil {
 		return err
 	}
+	if err = vr.checkLength(size); err != nil {
+		return err
+	}
 	vr.stack[vr.frame].end = int64(size) + vr.offset - 4
 
 	return nil
@@ -173,6 +205,9 @@ func (vr *valueReader) pushArray() error {
 	if err != nil {
 		return err
 	}
+	if err = vr.checkLength(size); err != nil {
+		return err
+	}
 	vr.stack[vr.frame].end = int64(size) + vr.offset - 4
 
 	return nil
@@ -201,11 +236,75 @@ func (vr *valueReader) pushCodeWithScope() (int64, error) {
 	if err != nil {
 		return 0, err
 	}
+	if err = vr.checkLength(size); err != nil {
+		return 0, err
+	}
 	vr.stack[vr.frame].end = int64(size) + vr.offset - 4
 
 	return int64(size), nil
 }
 
+// checkLength ensures in strict mode that the document, array, or code with scope pushed onto the stack, whose length
+// has just been read, is not nested too deeply, is at least as long as an empty document, and fits within the value
+// that contains it.
+func (vr *valueReader) checkLength(length int32) error {
+	if !vr.strict {
+		return nil
+	}
+	if vr.frame/2 > vr.maxDepth {
+		return fmt.Errorf("document is nested more than %d levels deep", vr.maxDepth)
+	}
+	if length < 5 {
+		return fmt.Errorf("invalid document length: %d", length)
+	}
+	if vr.offset-4+int64(length) > vr.limit() {
+		return fmt.Errorf("document length %d exceeds the length of the value containing it", length)
+	}
+	return nil
+}
+
+// limit returns the offset that reads cannot go past. In strict mode, this is the end of the innermost document,
+// array, or code with scope being read. Otherwise it is the end of the underlying bytes.
+func (vr *valueReader) limit() int64 {
+	if vr.strict {
+		for i := vr.frame; i >= 0; i-- {
+			switch vr.stack[i].mode {
+			case mTopLevel, mDocument, mArray, mCodeWithScope:
+				if end := vr.stack[i].end; end > 0 && end <= int64(len(vr.d)) {
+					return end
+				}
+			}
+		}
+	}
+	return int64(len(vr.d))
+}
+
+// remaining returns the bytes between the current offset and the limit.
+func (vr *valueReader) remaining() []byte {
+	limit := vr.limit()
+	if vr.offset > limit {
+		return nil
+	}
+	return vr.d[vr.offset:limit]
+}
+
+// validateValueBytes fully reads b, which contains a value of type t or a document if t is 0, with a strict
+// ValueReader that shares the remaining nesting depth of vr.
+func (vr *valueReader) validateValueBytes(t bsontype.Type, b []byte) error {
+	svr := newStrictValueReader(b, vr.maxDepth-vr.frame/2)
+
+	vw := vwPool.Get().(*valueWriter)
+	defer vwPool.Put(vw)
+	vw.reset(nil)
+
+	if t == bsontype.Type(0) {
+		return Copier{}.CopyDocument(vw, svr)
+	}
+	svr.stack[0] = vrState{mode: mValue, vType: t}
+	vw.push(mElement)
+	return Copier{}.CopyValue(vw, svr)
+}
+
 func (vr *valueReader) pop() {
 	switch vr.stack[vr.frame].mode {
 	case mElement, mValue:
@@ -282,12 +381,13 @@ func (vr *valueReader) nextElementLength() (int32, error) {
 	case bsontype.ObjectID:
 		length = 12
 	case bsontype.Regex:
-		regex := bytes.IndexByte(vr.d[vr.offset:], 0x00)
+		rem := vr.remaining()
+		regex := bytes.IndexByte(rem, 0x00)
 		if regex < 0 {
 			err = io.EOF
 			break
 		}
-		pattern := bytes.IndexByte(vr.d[vr.offset+int64(regex)+1:], 0x00)
+		pattern := bytes.IndexByte(rem[regex+1:], 0x00)
 		if pattern < 0 {
 			err = io.EOF
 			break
@@ -307,10 +407,19 @@ func (vr *valueReader) ReadValueBytes(dst []byte) (bsontype.Type, []byte, error)
 		if err != nil {
 			return bsontype.Type(0), nil, err
 		}
+		if vr.strict && int(length) != len(vr.d) {
+			return bsontype.Type(0), nil, fmt.Errorf("invalid document length")
+		}
+		start := len(dst)
 		dst, err = vr.appendBytes(dst, length)
 		if err != nil {
 			return bsontype.Type(0), nil, err
 		}
+		if vr.strict {
+			if err = vr.validateValueBytes(bsontype.Type(0), dst[start:]); err != nil {
+				return bsontype.Type(0), nil, err
+			}
+		}
 		return bsontype.Type(0), dst, nil
 	case mElement, mValue:
 		length, err := vr.nextElementLength()
@@ -318,8 +427,12 @@ func (vr *valueReader) ReadValueBytes(dst []byte) (bsontype.Type, []byte, error)
 			return bsontype.Type(0), dst, err
 		}
 
+		start := len(dst)
 		dst, err = vr.appendBytes(dst, length)
 		t := vr.stack[vr.frame].vType
+		if err == nil && vr.strict {
+			err = vr.validateValueBytes(t, dst[start:])
+		}
 		vr.pop()
 		return t, dst, err
 	default:
@@ -374,10 +487,14 @@ func (vr *valueReader) ReadBinary() (b []byte, btype byte, err error) {
 
 	// Check length in case it is an old binary without a length.
 	if btype == 0x02 && length > 4 {
+		outer := length
 		length, err = vr.readLength()
 		if err != nil {
 			return nil, 0, err
 		}
+		if vr.strict && length != outer-4 {
+			return nil, 0, fmt.Errorf("invalid length for binary subtype 2: %d", length)
+		}
 	}
 
 	b, err = vr.readBytes(length)
@@ -742,7 +859,7 @@ func (vr *valueReader) readBytes(length int32) ([]byte, error) {
 		return nil, fmt.Errorf("invalid length: %d", length)
 	}
 
-	if vr.offset+int64(length) > int64(len(vr.d)) {
+	if vr.offset+int64(length) > vr.limit() {
 		return nil, io.EOF
 	}
 
@@ -752,7 +869,7 @@ func (vr *valueReader) readBytes(length int32) ([]byte, error) {
 }
 
 func (vr *valueReader) appendBytes(dst []byte, length int32) ([]byte, error) {
-	if vr.offset+int64(length) > int64(len(vr.d)) {
+	if vr.offset+int64(length) > vr.limit() {
 		return nil, io.EOF
 	}
 
@@ -762,7 +879,7 @@ func (vr *valueReader) appendBytes(dst []byte, length int32) ([]byte, error) {
 }
 
 func (vr *valueReader) skipBytes(length int32) error {
-	if vr.offset+int64(length) > int64(len(vr.d)) {
+	if vr.offset+int64(length) > vr.limit() {
 		return io.EOF
 	}
 
@@ -771,7 +888,7 @@ func (vr *valueReader) skipBytes(length int32) error {
 }
 
 func (vr *valueReader) readByte() (byte, error) {
-	if vr.offset+1 > int64(len(vr.d)) {
+	if vr.offset+1 > vr.limit() {
 		return 0x0, io.EOF
 	}
 
@@ -780,7 +897,7 @@ func (vr *valueReader) readByte() (byte, error) {
 }
 
 func (vr *valueReader) readCString() (string, error) {
-	idx := bytes.IndexByte(vr.d[vr.offset:], 0x00)
+	idx := bytes.IndexByte(vr.remaining(), 0x00)
 	if idx < 0 {
 		return "", io.EOF
 	}
@@ -791,7 +908,7 @@ func (vr *valueReader) readCString() (string, error) {
 }
 
 func (vr *valueReader) skipCString() error {
-	idx := bytes.IndexByte(vr.d[vr.offset:], 0x00)
+	idx := bytes.IndexByte(vr.remaining(), 0x00)
 	if idx < 0 {
 		return io.EOF
 	}
@@ -806,7 +923,7 @@ func (vr *valueReader) readString() (string, error) {
 		return "", err
 	}
 
-	if int64(length)+vr.offset > int64(len(vr.d)) {
+	if int64(length)+vr.offset > vr.limit() {
 		return "", io.EOF
 	}
 
@@ -823,8 +940,40 @@ func (vr *valueReader) readString() (string, error) {
 	return string(vr.d[start : start+int64(length)-1]), nil
 }
 
+// ElementCount implements the ElementCounter interface. The elements are counted by walking the length prefixes of the
+// document or array without decoding the values.
+func (vr *valueReader) ElementCount() (int, bool) {
+	switch vr.stack[vr.frame].mode {
+	case mTopLevel:
+	case mElement, mValue:
+		switch vr.stack[vr.frame].vType {
+		case bsontype.Array, bsontype.EmbeddedDocument:
+		default:
+			return 0, false
+		}
+	default:
+		return 0, false
+	}
+
+	length, err := vr.peekLength()
+	if err != nil || length < 5 || vr.offset+int64(length) > vr.limit() {
+		return 0, false
+	}
+	elems := vr.d[vr.offset+4 : vr.offset+int64(length)-1]
+	var count int
+	for len(elems) > 0 {
+		var ok bool
+		_, elems, ok = bsoncore.ReadElement(elems)
+		if !ok {
+			return 0, false
+		}
+		count++
+	}
+	return count, true
+}
+
 func (vr *valueReader) peekLength() (int32, error) {
-	if vr.offset+4 > int64(len(vr.d)) {
+	if vr.offset+4 > vr.limit() {
 		return 0, io.EOF
 	}
 
@@ -835,7 +984,7 @@ func (vr *valueReader) peekLength() (int32, error) {
 func (vr *valueReader) readLength() (int32, error) { return vr.readi32() }
 
 func (vr *valueReader) readi32() (int32, error) {
-	if vr.offset+4 > int64(len(vr.d)) {
+	if vr.offset+4 > vr.limit() {
 		return 0, io.EOF
 	}
 
@@ -845,7 +994,7 @@ func (vr *valueReader) readi32() (int32, error) {
 }
 
 func (vr *valueReader) readu32() (uint32, error) {
-	if vr.offset+4 > int64(len(vr.d)) {
+	if vr.offset+4 > vr.limit() {
 		return 0, io.EOF
 	}
 
@@ -855,7 +1004,7 @@ func (vr *valueReader) readu32() (uint32, error) {
 }
 
 func (vr *valueReader) readi64() (int64, error) {
-	if vr.offset+8 > int64(len(vr.d)) {
+	if vr.offset+8 > vr.limit() {
 		return 0, io.EOF
 	}
 
@@ -866,7 +1015,7 @@ func (vr *valueReader) readi64() (int64, error) {
 }
 
 func (vr *valueReader) readu64() (uint64, error) {
-	if vr.offset+8 > int64(len(vr.d)) {
+	if vr.offset+8 > vr.limit() {
 		return 0, io.EOF
 	}
 
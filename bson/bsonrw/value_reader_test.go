@@ -10,6 +10,7 @@ import (
 	"bytes"
 	"fmt"
 	"io"
+	"io/ioutil"
 	"math"
 	"testing"
 
@@ -1496,6 +1497,154 @@ func TestValueReader(t *testing.T) {
 		})
 	})
 
+	t.Run("strict", func(t *testing.T) {
+		nested := func(depth int) []byte {
+			doc := bsoncore.BuildDocument(nil)
+			for i := 0; i < depth; i++ {
+				doc = bsoncore.BuildDocumentFromElements(nil, bsoncore.AppendDocumentElement(nil, "n", doc))
+			}
+			return doc
+		}
+		// {"d": {"s": "ab"}, "x": "cccc"} with the length of "s" as the given value. The offset of the length is 14.
+		stringLength := func(length byte) []byte {
+			doc := bsoncore.BuildDocumentFromElements(nil,
+				bsoncore.AppendDocumentElement(nil, "d", bsoncore.BuildDocumentFromElements(nil,
+					bsoncore.AppendStringElement(nil, "s", "ab"),
+				)),
+				bsoncore.AppendStringElement(nil, "x", "cccc"),
+			)
+			doc[14] = length
+			return doc
+		}
+		// {"d": {"b": true}} with the boolean byte replaced by an invalid value.
+		invalidBoolean := bsoncore.BuildDocumentFromElements(nil,
+			bsoncore.AppendDocumentElement(nil, "d", bsoncore.BuildDocumentFromElements(nil,
+				bsoncore.AppendBooleanElement(nil, "b", true),
+			)),
+		)
+		invalidBoolean[len(invalidBoolean)-3] = 0x02
+		// {"b": <binary subtype 2>} with an inner length that excludes the bytes of a null element {"z": null} at the
+		// end of the data.
+		invalidBinary := bsoncore.BuildDocumentFromElements(nil,
+			bsoncore.AppendBinaryElement(nil, "b", 0x02, []byte{0x01, 0x02, 0x03, 0x04, 0x0A, 'z', 0x00}),
+		)
+		invalidBinary[12] = 4
+		// {"d": {"a": 1}, "b": 2} with the length of "d" extended over the "b" element.
+		documentLength := bsoncore.BuildDocumentFromElements(nil,
+			bsoncore.AppendDocumentElement(nil, "d", bsoncore.BuildDocumentFromElements(nil,
+				bsoncore.AppendInt32Element(nil, "a", 1),
+			)),
+			bsoncore.AppendInt32Element(nil, "b", 2),
+		)
+		documentLength[7] += 7
+
+		testCases := []struct {
+			name      string
+			data      []byte
+			strictErr bool
+			err       bool
+		}{
+			{"valid", stringLength(3), false, false},
+			{"maximum depth", nested(MaxStrictDepth), false, false},
+			{"depth exceeded", nested(MaxStrictDepth + 1), true, false},
+			{"string exceeds embedded document", stringLength(10), true, true},
+			{"document exceeds embedded document", documentLength, true, true},
+			{"invalid binary subtype 2 length", invalidBinary, true, false},
+		}
+
+		for _, tc := range testCases {
+			t.Run(tc.name, func(t *testing.T) {
+				vw, err := NewBSONValueWriter(ioutil.Discard)
+				noerr(t, err)
+				err = Copier{}.CopyDocument(vw, NewStrictBSONDocumentReader(tc.data))
+				if gotErr := err != nil; gotErr != tc.strictErr {
+					t.Errorf("expected error %v from strict reader, got %v", tc.strictErr, err)
+				}
+
+				vw, err = NewBSONValueWriter(ioutil.Discard)
+				noerr(t, err)
+				err = Copier{}.CopyDocument(vw, NewBSONDocumentReader(tc.data))
+				if gotErr := err != nil; gotErr != tc.err {
+					t.Errorf("expected error %v from reader, got %v", tc.err, err)
+				}
+			})
+		}
+		t.Run("ReadValueBytes validates value", func(t *testing.T) {
+			_, err := Copier{}.CopyDocumentToBytes(NewBSONDocumentReader(invalidBoolean))
+			noerr(t, err)
+
+			_, err = Copier{}.CopyDocumentToBytes(NewStrictBSONDocumentReader(invalidBoolean))
+			if err == nil {
+				t.Errorf("expected error reading invalid document bytes, got nil")
+			}
+
+			dr, err := NewStrictBSONDocumentReader(invalidBoolean).ReadDocument()
+			noerr(t, err)
+			_, vr, err := dr.ReadElement()
+			noerr(t, err)
+			if _, _, err = vr.(BytesReader).ReadValueBytes(nil); err == nil {
+				t.Errorf("expected error reading invalid value bytes, got nil")
+			}
+		})
+	})
+	t.Run("ElementCount", func(t *testing.T) {
+		arr := bsoncore.BuildArray(nil,
+			bsoncore.Value{Type: bsontype.Int32, Data: bsoncore.AppendInt32(nil, 1)},
+			bsoncore.Value{Type: bsontype.String, Data: bsoncore.AppendString(nil, "foo")},
+		)
+		doc := bsoncore.BuildDocumentFromElements(nil,
+			bsoncore.AppendArrayElement(nil, "a", arr),
+			bsoncore.AppendDocumentElement(nil, "d", bsoncore.BuildDocumentFromElements(nil)),
+			bsoncore.AppendInt32Element(nil, "i", 1),
+		)
+		counter := func(vr ValueReader) ElementCounter {
+			counter, ok := vr.(ElementCounter)
+			if !ok {
+				t.Fatalf("expected %T to implement ElementCounter", vr)
+			}
+			return counter
+		}
+		expectCount := func(vr ValueReader, expected int, expectedOK bool) {
+			t.Helper()
+			count, ok := counter(vr).ElementCount()
+			if count != expected || ok != expectedOK {
+				t.Errorf("expected ElementCount to return (%d, %v), got (%d, %v)", expected, expectedOK, count, ok)
+			}
+		}
+
+		vr := NewBSONDocumentReader(doc)
+		expectCount(vr, 3, true)
+		dr, err := vr.ReadDocument()
+		noerr(t, err)
+		expectCount(vr, 0, false)
+
+		_, evr, err := dr.ReadElement()
+		noerr(t, err)
+		expectCount(evr, 2, true)
+		ar, err := evr.ReadArray()
+		noerr(t, err)
+		avr, err := ar.ReadValue()
+		noerr(t, err)
+		expectCount(avr, 0, false)
+		noerr(t, avr.Skip())
+		avr, err = ar.ReadValue()
+		noerr(t, err)
+		noerr(t, avr.Skip())
+		if _, err = ar.ReadValue(); err != ErrEOA {
+			t.Fatalf("expected ErrEOA, got %v", err)
+		}
+
+		_, evr, err = dr.ReadElement()
+		noerr(t, err)
+		expectCount(evr, 0, true)
+		noerr(t, evr.Skip())
+		_, evr, err = dr.ReadElement()
+		noerr(t, err)
+		expectCount(evr, 0, false)
+
+		// A length prefix that exceeds the bytes is not counted.
+		expectCount(NewBSONDocumentReader(doc[:len(doc)-1]), 0, false)
+	})
 	t.Run("invalid transition", func(t *testing.T) {
 		t.Run("Skip", func(t *testing.T) {
 			vr := &valueReader{stack: []vrState{{mode: mTopLevel}}}
@@ -535,6 +535,11 @@ func (vw *valueWriter) WriteDocumentEnd() error {
 	return nil
 }
 
+// Len returns the number of bytes that have been written to the valueWriter's buffer and not yet flushed.
+func (vw *valueWriter) Len() int {
+	return len(vw.buf)
+}
+
 func (vw *valueWriter) Flush() error {
 	if vw.w == nil {
 		return nil
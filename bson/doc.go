@@ -69,6 +69,11 @@
 //       inclusive, and BSON int64 otherwise.
 //       7. BSON null and undefined values will unmarshal into the zero value of a field (e.g. unmarshalling a BSON null or
 //       undefined value into a string will yield the empty string.).
+//       8. Maps marshal to BSON documents. Map keys of a string type are used as the document keys, integer keys are
+//       formatted in base 10, and keys of other types must implement bsoncodec.KeyMarshaler or
+//       encoding.TextMarshaler. Keys of string and integer types are encoded from their value even if they implement
+//       encoding.TextMarshaler. When unmarshalling, key types of other kinds must implement the corresponding
+//       bsoncodec.KeyUnmarshaler or encoding.TextUnmarshaler interface on their pointer type.
 //
 // Structs
 //
@@ -86,6 +91,10 @@
 //     4. A pointer field is marshalled as the underlying type if the pointer is non-nil. If the pointer is nil, it is
 //     marshalled as a BSON null value.
 //
+//     Nil slices and maps are also marshalled as BSON null values by default. To marshal them as an empty array (or
+//     empty binary data for a []byte) or document instead, use the SetNilSliceAsEmpty and SetNilMapAsEmpty methods on
+//     a RegistryBuilder or the corresponding fields on bsoncodec.EncodeContext.
+//
 //     5. When unmarshalling, a field of type interface{} will follow the D/M type mappings listed above. BSON documents
 //     unmarshalled into an interface{} field will be unmarshalled as a D.
 //
@@ -124,7 +133,9 @@
 //     marshalling and "un-flattened" when unmarshalling. This means that all of the fields in that struct/map will be
 //     pulled up one level and will become top-level fields rather than being fields in a nested document. For example, if a
 //     map field named "Map" with value map[string]interface{}{"foo": "bar"} is inlined, the resulting document will be
-//     {"foo": "bar"} instead of {"map": {"foo": "bar"}}. There can only be one inlined map field in a struct. If there are
+//     {"foo": "bar"} instead of {"map": {"foo": "bar"}}. Inlined maps must have string keys but may have any value type
+//     (e.g. map[string]int32 or map[string]SomeStruct), in which case every extra element is decoded into that type.
+//     There can only be one inlined map field in a struct. If there are
 //     duplicated fields in the resulting document when an inlined struct is marshalled, the inlined field will be overwritten.
 //     If there are duplicated fields in the resulting document when an inlined map is marshalled, an error will be returned.
 //     This tag can be used with fields that are pointers to structs. If an inlined pointer field is nil, it will not be
@@ -0,0 +1,30 @@
+// Copyright (C) MongoDB, Inc. 2021-present.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
+
+// +build gofuzz
+
+package bson
+
+import "fmt"
+
+// Fuzz is the go-fuzz entry point for UnmarshalStrict. It unmarshals data into several types and panics if
+// UnmarshalStrict panics, accepts data that is not a valid document, or returns a value that cannot be marshaled.
+func Fuzz(data []byte) int {
+	valid := 0
+	for _, val := range []interface{}{&D{}, &M{}, &Raw{}} {
+		if err := UnmarshalStrict(data, val); err != nil {
+			continue
+		}
+		if err := Raw(data).Validate(); err != nil {
+			panic(fmt.Errorf("UnmarshalStrict into %T accepted an invalid document: %v", val, err))
+		}
+		if _, err := Marshal(val); err != nil {
+			panic(fmt.Errorf("value unmarshaled into %T cannot be marshaled: %v", val, err))
+		}
+		valid = 1
+	}
+	return valid
+}
@@ -95,6 +95,9 @@ func MarshalAppendWithContext(ec bsoncodec.EncodeContext, dst []byte, val interf
 	if err != nil {
 		return nil, err
 	}
+	if err = checkMaxSize(ec, len(*sw)-len(dst)); err != nil {
+		return nil, err
+	}
 
 	return *sw, nil
 }
@@ -159,9 +162,21 @@ func MarshalValueAppendWithContext(ec bsoncodec.EncodeContext, dst []byte, val i
 		return 0, nil, err
 	}
 	buffer := *sw
+	if err := checkMaxSize(ec, len(buffer)-len(dst)-2); err != nil {
+		return 0, nil, err
+	}
 	return bsontype.Type(buffer[0]), buffer[2:], nil
 }
 
+// checkMaxSize returns a bsoncodec.MaxSizeError if size exceeds the MaxSize of ec. The size is also checked while
+// encoding, but values that do not contain documents or arrays are only checked here.
+func checkMaxSize(ec bsoncodec.EncodeContext, size int) error {
+	if ec.MaxSize > 0 && size > ec.MaxSize {
+		return bsoncodec.MaxSizeError{MaxSize: ec.MaxSize, Size: size}
+	}
+	return nil
+}
+
 // MarshalExtJSON returns the extended JSON encoding of val.
 func MarshalExtJSON(val interface{}, canonical, escapeHTML bool) ([]byte, error) {
 	return MarshalExtJSONWithRegistry(DefaultRegistry, val, canonical, escapeHTML)
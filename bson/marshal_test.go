@@ -267,3 +267,131 @@ func TestCachingEncodersNotSharedAcrossRegistries(t *testing.T) {
 		})
 	})
 }
+
+func TestMarshalNilAsEmptyAndOmitZeroStruct(t *testing.T) {
+	type inner struct {
+		A int32
+	}
+	type outer struct {
+		S []string
+		B []byte
+		M map[string]int32
+		I inner `bson:",omitempty"`
+	}
+
+	defaultDoc := D{{"s", nil}, {"b", nil}, {"m", nil}, {"i", D{{"a", int32(0)}}}}
+	emptyDoc := D{{"s", A{}}, {"b", []byte{}}, {"m", D{}}}
+
+	marshalDoc := func(t *testing.T, d D) Raw {
+		t.Helper()
+		b, err := Marshal(d)
+		assert.Nil(t, err, "Marshal error: %v", err)
+		return b
+	}
+
+	t.Run("default", func(t *testing.T) {
+		got, err := Marshal(outer{})
+		assert.Nil(t, err, "Marshal error: %v", err)
+		want := marshalDoc(t, defaultDoc)
+		assert.Equal(t, want, Raw(got), "expected document %v, got %v", want, Raw(got))
+	})
+	t.Run("registry", func(t *testing.T) {
+		reg := NewRegistryBuilder().
+			SetNilSliceAsEmpty(true).
+			SetNilMapAsEmpty(true).
+			SetOmitZeroStruct(true).
+			Build()
+		got, err := MarshalWithRegistry(reg, outer{})
+		assert.Nil(t, err, "Marshal error: %v", err)
+		want := marshalDoc(t, emptyDoc)
+		assert.Equal(t, want, Raw(got), "expected document %v, got %v", want, Raw(got))
+	})
+	t.Run("context", func(t *testing.T) {
+		ec := bsoncodec.EncodeContext{
+			Registry:        DefaultRegistry,
+			NilSliceAsEmpty: true,
+			NilMapAsEmpty:   true,
+			OmitZeroStruct:  true,
+		}
+		got, err := MarshalWithContext(ec, outer{})
+		assert.Nil(t, err, "Marshal error: %v", err)
+		want := marshalDoc(t, emptyDoc)
+		assert.Equal(t, want, Raw(got), "expected document %v, got %v", want, Raw(got))
+	})
+}
+
+func TestMarshalLimits(t *testing.T) {
+	type node struct {
+		Name string
+		Next *node
+	}
+
+	t.Run("max depth", func(t *testing.T) {
+		val := D{{"a", D{{"b", D{{"c", 1}}}}}}
+		ec := bsoncodec.EncodeContext{Registry: DefaultRegistry, MaxDepth: 3}
+		_, err := MarshalWithContext(ec, val)
+		assert.Nil(t, err, "MarshalWithContext error: %v", err)
+
+		ec.MaxDepth = 2
+		_, err = MarshalWithContext(ec, val)
+		expected := bsoncodec.MaxDepthError{MaxDepth: 2}
+		assert.Equal(t, expected, err, "expected error %v, got %v", expected, err)
+	})
+	t.Run("max depth is reset between siblings", func(t *testing.T) {
+		val := struct {
+			A []int
+			B map[string]int
+			C [2]int
+		}{}
+		ec := bsoncodec.EncodeContext{Registry: DefaultRegistry, MaxDepth: 2}
+		_, err := MarshalWithContext(ec, val)
+		assert.Nil(t, err, "MarshalWithContext error: %v", err)
+	})
+	t.Run("max size", func(t *testing.T) {
+		val := D{{"a", "hello"}, {"b", D{{"c", "world"}}}}
+		doc, err := Marshal(val)
+		assert.Nil(t, err, "Marshal error: %v", err)
+
+		ec := bsoncodec.EncodeContext{Registry: DefaultRegistry, MaxSize: len(doc)}
+		_, err = MarshalAppendWithContext(ec, []byte("prefix"), val)
+		assert.Nil(t, err, "MarshalAppendWithContext error: %v", err)
+
+		ec.MaxSize = len(doc) - 1
+		_, err = MarshalWithContext(ec, val)
+		_, ok := err.(bsoncodec.MaxSizeError)
+		assert.True(t, ok, "expected error of type %T, got %v", bsoncodec.MaxSizeError{}, err)
+
+		ec.MaxSize = 4
+		_, _, err = MarshalValueWithContext(ec, "hello")
+		_, ok = err.(bsoncodec.MaxSizeError)
+		assert.True(t, ok, "expected error of type %T, got %v", bsoncodec.MaxSizeError{}, err)
+	})
+	t.Run("cycles", func(t *testing.T) {
+		ec := bsoncodec.EncodeContext{Registry: DefaultRegistry, DetectCycles: true}
+
+		shared := &node{Name: "shared"}
+		_, err := MarshalWithContext(ec, struct{ A, B *node }{shared, shared})
+		assert.Nil(t, err, "MarshalWithContext error: %v", err)
+
+		first := &node{Name: "first"}
+		first.Next = &node{Name: "second", Next: first}
+		_, err = MarshalWithContext(ec, first)
+		expected := bsoncodec.CycleError{Type: reflect.TypeOf(first)}
+		assert.Equal(t, expected, err, "expected error %v, got %v", expected, err)
+
+		m := M{}
+		m["self"] = m
+		_, err = MarshalWithContext(ec, m)
+		expected = bsoncodec.CycleError{Type: reflect.TypeOf(m)}
+		assert.Equal(t, expected, err, "expected error %v, got %v", expected, err)
+	})
+}
+
+func TestMarshalCodeWithScopeNilScope(t *testing.T) {
+	got, err := Marshal(D{{"cws", primitive.CodeWithScope{Code: "x"}}})
+	assert.Nil(t, err, "Marshal error: %v", err)
+
+	expected := bsoncore.BuildDocumentFromElements(nil,
+		bsoncore.AppendCodeWithScopeElement(nil, "cws", "x", bsoncore.BuildDocument(nil)))
+	assert.Equal(t, expected, got, "expected %v, got %v", bsoncore.Document(expected), bsoncore.Document(got))
+}
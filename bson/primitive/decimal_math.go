@@ -0,0 +1,322 @@
+// Copyright (C) MongoDB, Inc. 2021-present.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
+
+package primitive
+
+import (
+	"errors"
+	"math/big"
+)
+
+// decimal128Digits is the maximum number of significant digits in a decimal128 value.
+const decimal128Digits = 34
+
+// decimal128BigFloatPrec is the precision used when converting a Decimal128 to a *big.Float. It is large enough to
+// hold any 34 digit significand exactly.
+const decimal128BigFloatPrec = 128
+
+// ErrBigFloatNaN is returned when NaN is converted to a *big.Float, which cannot represent it.
+var ErrBigFloatNaN = errors.New("cannot convert NaN to a *big.Float")
+
+// Neg returns d with its sign inverted.
+func (d Decimal128) Neg() Decimal128 {
+	return Decimal128{h: d.h ^ 1<<63, l: d.l}
+}
+
+// Abs returns the absolute value of d.
+func (d Decimal128) Abs() Decimal128 {
+	return Decimal128{h: d.h &^ (1 << 63), l: d.l}
+}
+
+// Add returns the sum d + o. As with the other arithmetic methods, the result is rounded to 34 significant digits
+// using round half to even, overflows to ±Infinity, and follows IEEE 754 semantics for NaN and Infinity operands.
+func (d Decimal128) Add(o Decimal128) Decimal128 {
+	switch {
+	case d.IsNaN() || o.IsNaN():
+		return dNaN
+	case d.IsInf() != 0 && o.IsInf() != 0:
+		if d.IsInf() != o.IsInf() {
+			return dNaN
+		}
+		return d
+	case d.IsInf() != 0:
+		return d
+	case o.IsInf() != 0:
+		return o
+	}
+
+	dbi, dexp, _ := d.BigInt()
+	obi, oexp, _ := o.BigInt()
+	exp := alignExponents(dbi, dexp, obi, oexp)
+	return decimal128FromBigIntRounded(dbi.Add(dbi, obi), exp)
+}
+
+// Sub returns the difference d - o.
+func (d Decimal128) Sub(o Decimal128) Decimal128 {
+	return d.Add(o.Neg())
+}
+
+// Mul returns the product d * o.
+func (d Decimal128) Mul(o Decimal128) Decimal128 {
+	if d.IsNaN() || o.IsNaN() {
+		return dNaN
+	}
+
+	neg := d.signBit() != o.signBit()
+	if d.IsInf() != 0 || o.IsInf() != 0 {
+		if d.isZeroValue() || o.isZeroValue() {
+			return dNaN
+		}
+		if neg {
+			return dNegInf
+		}
+		return dPosInf
+	}
+
+	dbi, dexp, _ := d.BigInt()
+	obi, oexp, _ := o.BigInt()
+	return decimal128FromBigIntRounded(dbi.Mul(dbi, obi), dexp+oexp)
+}
+
+// Div returns the quotient d / o. Dividing a non-zero value by zero returns ±Infinity and dividing zero by zero
+// returns NaN.
+func (d Decimal128) Div(o Decimal128) Decimal128 {
+	if d.IsNaN() || o.IsNaN() {
+		return dNaN
+	}
+
+	neg := d.signBit() != o.signBit()
+	switch {
+	case d.IsInf() != 0 && o.IsInf() != 0:
+		return dNaN
+	case d.IsInf() != 0:
+		if neg {
+			return dNegInf
+		}
+		return dPosInf
+	case o.IsInf() != 0:
+		zero := decimal128FromBigIntRounded(new(big.Int), 0)
+		if neg {
+			return zero.Neg()
+		}
+		return zero
+	case o.isZeroValue():
+		if d.isZeroValue() {
+			return dNaN
+		}
+		if neg {
+			return dNegInf
+		}
+		return dPosInf
+	}
+
+	dbi, dexp, _ := d.BigInt()
+	obi, oexp, _ := o.BigInt()
+	idealExp := dexp - oexp
+
+	// Scale the dividend so the quotient has more digits than can be stored and then append a sticky digit if the
+	// division is inexact so that rounding is correct.
+	scale := decimal128Digits + 1 + numDigits(obi) - numDigits(dbi)
+	if scale < 0 {
+		scale = 0
+	}
+	dbi.Mul(dbi, pow10(scale))
+	exp := idealExp - scale
+
+	q, r := new(big.Int).QuoRem(dbi, obi, new(big.Int))
+	if r.Sign() != 0 {
+		q.Mul(q, ten)
+		if neg {
+			q.Sub(q, big.NewInt(1))
+		} else {
+			q.Add(q, big.NewInt(1))
+		}
+		exp--
+	} else {
+		// The division is exact, so remove trailing zeros down to the ideal exponent.
+		for exp < idealExp && q.Sign() != 0 {
+			qq, rr := new(big.Int).QuoRem(q, ten, new(big.Int))
+			if rr.Sign() != 0 {
+				break
+			}
+			q = qq
+			exp++
+		}
+	}
+
+	return decimal128FromBigIntRounded(q, exp)
+}
+
+// Round returns d rounded to scale digits after the decimal point using round half to even. A negative scale rounds
+// to a power of ten, e.g. a scale of -2 rounds to the nearest hundred. NaN and Infinity values are returned unchanged.
+func (d Decimal128) Round(scale int) Decimal128 {
+	if d.IsNaN() || d.IsInf() != 0 {
+		return d
+	}
+
+	bi, exp, _ := d.BigInt()
+	if -exp <= scale {
+		return d
+	}
+
+	drop := -exp - scale
+	bi = roundHalfEven(bi, drop)
+	return decimal128FromBigIntRounded(bi, exp+drop)
+}
+
+// Cmp compares d and o and returns -1 if d < o, 0 if d == o, and +1 if d > o. Values in the same cohort, such as 1.0
+// and 1.00, are equal. NaN values are ordered before all other values and are equal to each other, which matches the
+// ordering used by the server when sorting.
+func (d Decimal128) Cmp(o Decimal128) int {
+	switch {
+	case d.IsNaN() && o.IsNaN():
+		return 0
+	case d.IsNaN():
+		return -1
+	case o.IsNaN():
+		return 1
+	case d.IsInf() != 0 || o.IsInf() != 0:
+		if d.IsInf() == o.IsInf() {
+			return 0
+		}
+		if d.IsInf() < o.IsInf() {
+			return -1
+		}
+		return 1
+	}
+
+	dbi, dexp, _ := d.BigInt()
+	obi, oexp, _ := o.BigInt()
+	alignExponents(dbi, dexp, obi, oexp)
+	return dbi.Cmp(obi)
+}
+
+// BigFloat returns d as a *big.Float with enough precision to hold the significand of d. Because the conversion is
+// from a decimal to a binary representation, values with a negative exponent may be rounded.
+func (d Decimal128) BigFloat() (*big.Float, error) {
+	if d.IsNaN() {
+		return nil, ErrBigFloatNaN
+	}
+	if inf := d.IsInf(); inf != 0 {
+		return new(big.Float).SetInf(inf < 0), nil
+	}
+
+	bi, exp, _ := d.BigInt()
+	f := new(big.Float).SetPrec(decimal128BigFloatPrec).SetInt(bi)
+	if exp >= 0 {
+		return f.Mul(f, new(big.Float).SetInt(pow10(exp))), nil
+	}
+	return f.Quo(f, new(big.Float).SetInt(pow10(-exp))), nil
+}
+
+// ParseDecimal128FromBigFloat converts f to a Decimal128, rounding it to 34 significant digits. Infinite values are
+// converted to ±Infinity. ok is false if f is out of the range of a Decimal128.
+func ParseDecimal128FromBigFloat(f *big.Float) (d Decimal128, ok bool) {
+	if f.IsInf() {
+		if f.Signbit() {
+			return dNegInf, true
+		}
+		return dPosInf, true
+	}
+
+	d, err := ParseDecimal128(f.Text('g', decimal128Digits))
+	return d, err == nil
+}
+
+func (d Decimal128) signBit() bool {
+	return d.h>>63&1 == 1
+}
+
+// isZeroValue returns true if d is a finite zero with any sign and exponent.
+func (d Decimal128) isZeroValue() bool {
+	if d.IsNaN() || d.IsInf() != 0 {
+		return false
+	}
+	bi, _, _ := d.BigInt()
+	return bi.Sign() == 0
+}
+
+// alignExponents scales the significand with the larger exponent so both significands share the smaller exponent,
+// which is returned.
+func alignExponents(x *big.Int, xexp int, y *big.Int, yexp int) int {
+	switch {
+	case xexp > yexp:
+		x.Mul(x, pow10(xexp-yexp))
+		return yexp
+	case yexp > xexp:
+		y.Mul(y, pow10(yexp-xexp))
+	}
+	return xexp
+}
+
+// decimal128FromBigIntRounded converts bi * 10^exp to a Decimal128, rounding bi to 34 significant digits using round
+// half to even. Values too large to be represented are converted to ±Infinity.
+func decimal128FromBigIntRounded(bi *big.Int, exp int) Decimal128 {
+	neg := bi.Sign() < 0
+
+	drop := numDigits(bi) - decimal128Digits
+	if exp+drop < MinDecimal128Exp {
+		// Subnormal values lose precision to keep the exponent in range.
+		drop = MinDecimal128Exp - exp
+	}
+	if drop > 0 {
+		bi = roundHalfEven(bi, drop)
+		exp += drop
+		if numDigits(bi) > decimal128Digits {
+			// Rounding carried into a new digit, e.g. 999...9 to 1000...0, so the last digit is zero.
+			bi.Quo(bi, ten)
+			exp++
+		}
+	}
+
+	if bi.Sign() == 0 {
+		if exp > MaxDecimal128Exp {
+			exp = MaxDecimal128Exp
+		}
+		if exp < MinDecimal128Exp {
+			exp = MinDecimal128Exp
+		}
+	}
+
+	d, ok := ParseDecimal128FromBigInt(bi, exp)
+	if !ok {
+		if neg {
+			return dNegInf
+		}
+		return dPosInf
+	}
+	return d
+}
+
+// roundHalfEven returns bi divided by 10^drop, rounded using round half to even.
+func roundHalfEven(bi *big.Int, drop int) *big.Int {
+	div := pow10(drop)
+	q, r := new(big.Int).QuoRem(bi, div, new(big.Int))
+
+	// Compare twice the absolute remainder to the divisor to determine the rounding direction.
+	r.Abs(r).Lsh(r, 1)
+	c := r.Cmp(div)
+	if c > 0 || (c == 0 && q.Bit(0) == 1) {
+		if bi.Sign() < 0 {
+			q.Sub(q, big.NewInt(1))
+		} else {
+			q.Add(q, big.NewInt(1))
+		}
+	}
+	return q
+}
+
+// numDigits returns the number of decimal digits in the absolute value of bi. Zero has zero digits.
+func numDigits(bi *big.Int) int {
+	if bi.Sign() == 0 {
+		return 0
+	}
+	return len(bigIntAbsValue(bi).String())
+}
+
+func pow10(n int) *big.Int {
+	return new(big.Int).Exp(ten, big.NewInt(int64(n)), nil)
+}
@@ -0,0 +1,137 @@
+// Copyright (C) MongoDB, Inc. 2021-present.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
+
+package primitive
+
+import (
+	"math/big"
+	"testing"
+
+	"go.mongodb.org/mongo-driver/internal/testutil/assert"
+)
+
+func mustParseDecimal128(t *testing.T, s string) Decimal128 {
+	t.Helper()
+
+	d, err := ParseDecimal128(s)
+	assert.Nil(t, err, "ParseDecimal128 error for %q: %v", s, err)
+	return d
+}
+
+func TestDecimal128Arithmetic(t *testing.T) {
+	testCases := []struct {
+		name     string
+		op       func(Decimal128, Decimal128) Decimal128
+		x, y     string
+		expected string
+	}{
+		{"add", Decimal128.Add, "1.1", "2.25", "3.35"},
+		{"add negative", Decimal128.Add, "1.1", "-2.25", "-1.15"},
+		{"add different exponents", Decimal128.Add, "1E+10", "1E-10", "10000000000.0000000001"},
+		{"add rounds half to even", Decimal128.Add, "9999999999999999999999999999999999", "0.5",
+			"1.000000000000000000000000000000000E+34"},
+		{"add overflow", Decimal128.Add, "9.999999999999999999999999999999999E+6144",
+			"9.999999999999999999999999999999999E+6144", "Infinity"},
+		{"add infinities", Decimal128.Add, "Infinity", "-Infinity", "NaN"},
+		{"add NaN", Decimal128.Add, "NaN", "1", "NaN"},
+		{"sub", Decimal128.Sub, "10.50", "0.75", "9.75"},
+		{"mul", Decimal128.Mul, "1.5", "-2.5", "-3.75"},
+		{"mul rounds", Decimal128.Mul, "1.000000000000000000000000000000001", "1.000000000000000000000000000000001",
+			"1.000000000000000000000000000000002"},
+		{"mul infinity by zero", Decimal128.Mul, "Infinity", "0", "NaN"},
+		{"mul infinity", Decimal128.Mul, "-Infinity", "2", "-Infinity"},
+		{"div exact", Decimal128.Div, "10", "4", "2.5"},
+		{"div ideal exponent", Decimal128.Div, "1.00", "1", "1.00"},
+		{"div inexact", Decimal128.Div, "1", "3", "0.3333333333333333333333333333333333"},
+		{"div inexact rounds up", Decimal128.Div, "2", "3", "0.6666666666666666666666666666666667"},
+		{"div negative", Decimal128.Div, "-2", "3", "-0.6666666666666666666666666666666667"},
+		{"div by zero", Decimal128.Div, "1", "0", "Infinity"},
+		{"div zero by zero", Decimal128.Div, "0", "0", "NaN"},
+		{"div by infinity", Decimal128.Div, "1", "Infinity", "0"},
+		{"div negative by infinity", Decimal128.Div, "-1", "Infinity", "-0"},
+		{"div by negative infinity", Decimal128.Div, "1", "-Infinity", "-0"},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			got := tc.op(mustParseDecimal128(t, tc.x), mustParseDecimal128(t, tc.y))
+			assert.Equal(t, tc.expected, got.String(), "expected %v, got %v", tc.expected, got)
+		})
+	}
+}
+
+func TestDecimal128Round(t *testing.T) {
+	testCases := []struct {
+		d        string
+		scale    int
+		expected string
+	}{
+		{"1.2345", 2, "1.23"},
+		{"1.235", 2, "1.24"},
+		{"1.245", 2, "1.24"},
+		{"-1.245", 2, "-1.24"},
+		{"1.2", 4, "1.2"},
+		{"1250", -2, "1.2E+3"},
+		{"Infinity", 2, "Infinity"},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.d, func(t *testing.T) {
+			got := mustParseDecimal128(t, tc.d).Round(tc.scale)
+			assert.Equal(t, tc.expected, got.String(), "expected %v, got %v", tc.expected, got)
+		})
+	}
+}
+
+func TestDecimal128Cmp(t *testing.T) {
+	testCases := []struct {
+		x, y     string
+		expected int
+	}{
+		{"1", "2", -1},
+		{"2", "1", 1},
+		{"1.0", "1.00", 0},
+		{"-1", "1", -1},
+		{"1E+10", "1E-10", 1},
+		{"-Infinity", "-1E+6144", -1},
+		{"Infinity", "Infinity", 0},
+		{"NaN", "-Infinity", -1},
+		{"NaN", "NaN", 0},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.x+" "+tc.y, func(t *testing.T) {
+			got := mustParseDecimal128(t, tc.x).Cmp(mustParseDecimal128(t, tc.y))
+			assert.Equal(t, tc.expected, got, "expected %v, got %v", tc.expected, got)
+		})
+	}
+}
+
+func TestDecimal128BigFloat(t *testing.T) {
+	t.Run("round trip", func(t *testing.T) {
+		for _, s := range []string{"0", "1", "-12.5", "1.5E+300", "1234567890123456789012345678901234", "Infinity"} {
+			d := mustParseDecimal128(t, s)
+			f, err := d.BigFloat()
+			assert.Nil(t, err, "BigFloat error for %v: %v", d, err)
+			got, ok := ParseDecimal128FromBigFloat(f)
+			assert.True(t, ok, "expected %v to be converted", f)
+			assert.Equal(t, 0, got.Cmp(d), "expected %v, got %v", d, got)
+		}
+	})
+	t.Run("NaN", func(t *testing.T) {
+		_, err := mustParseDecimal128(t, "NaN").BigFloat()
+		assert.Equal(t, ErrBigFloatNaN, err, "expected error %v, got %v", ErrBigFloatNaN, err)
+	})
+	t.Run("rounds to 34 digits", func(t *testing.T) {
+		f := new(big.Float).SetPrec(256).Quo(big.NewFloat(1), big.NewFloat(3))
+		got, ok := ParseDecimal128FromBigFloat(f)
+		assert.True(t, ok, "expected %v to be converted", f)
+		expected := "0.3333333333333333333333333333333333"
+		assert.Equal(t, expected, got.String(), "expected %v, got %v", expected, got)
+	})
+	t.Run("out of range", func(t *testing.T) {
+		f := new(big.Float).SetMantExp(big.NewFloat(1), 30000)
+		_, ok := ParseDecimal128FromBigFloat(f)
+		assert.False(t, ok, "expected %v to be out of range", f)
+	})
+}
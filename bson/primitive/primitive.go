@@ -132,7 +132,12 @@ type CodeWithScope struct {
 }
 
 func (cws CodeWithScope) String() string {
-	return fmt.Sprintf(`{"code": "%s", "scope": %v}`, cws.Code, cws.Scope)
+	// Escape the code so quotes and newlines in it don't produce invalid output.
+	var code bytes.Buffer
+	enc := json.NewEncoder(&code)
+	enc.SetEscapeHTML(false)
+	_ = enc.Encode(string(cws.Code))
+	return fmt.Sprintf(`{"code": %s, "scope": %v}`, bytes.TrimSpace(code.Bytes()), cws.Scope)
 }
 
 // Timestamp represents a BSON timestamp value.
@@ -120,3 +120,9 @@ func TestDateTime(t *testing.T) {
 		})
 	})
 }
+
+func TestCodeWithScopeString(t *testing.T) {
+	cws := CodeWithScope{Code: "function() { return \"a\\nb\" < x; }", Scope: map[string]int{"x": 1}}
+	expected := `{"code": "function() { return \"a\\nb\" < x; }", "scope": map[x:1]}`
+	assert.Equal(t, expected, cws.String(), "expected %q, got %q", expected, cws.String())
+}
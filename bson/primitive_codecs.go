@@ -8,10 +8,12 @@ package bson
 
 import (
 	"errors"
+	"fmt"
 	"reflect"
 
 	"go.mongodb.org/mongo-driver/bson/bsoncodec"
 	"go.mongodb.org/mongo-driver/bson/bsonrw"
+	"go.mongodb.org/mongo-driver/bson/bsontype"
 )
 
 var primitiveCodecs PrimitiveCodecs
@@ -30,8 +32,10 @@ func (pc PrimitiveCodecs) RegisterPrimitiveCodecs(rb *bsoncodec.RegistryBuilder)
 	rb.
 		RegisterTypeEncoder(tRawValue, bsoncodec.ValueEncoderFunc(pc.RawValueEncodeValue)).
 		RegisterTypeEncoder(tRaw, bsoncodec.ValueEncoderFunc(pc.RawEncodeValue)).
+		RegisterTypeEncoder(tRawArray, bsoncodec.ValueEncoderFunc(pc.RawArrayEncodeValue)).
 		RegisterTypeDecoder(tRawValue, bsoncodec.ValueDecoderFunc(pc.RawValueDecodeValue)).
-		RegisterTypeDecoder(tRaw, bsoncodec.ValueDecoderFunc(pc.RawDecodeValue))
+		RegisterTypeDecoder(tRaw, bsoncodec.ValueDecoderFunc(pc.RawDecodeValue)).
+		RegisterTypeDecoder(tRawArray, bsoncodec.ValueDecoderFunc(pc.RawArrayDecodeValue))
 }
 
 // RawValueEncodeValue is the ValueEncoderFunc for RawValue.
@@ -88,6 +92,37 @@ func (PrimitiveCodecs) RawDecodeValue(dc bsoncodec.DecodeContext, vr bsonrw.Valu
 	return err
 }
 
+// RawArrayEncodeValue is the ValueEncoderFunc for RawArray.
+func (PrimitiveCodecs) RawArrayEncodeValue(ec bsoncodec.EncodeContext, vw bsonrw.ValueWriter, val reflect.Value) error {
+	if !val.IsValid() || val.Type() != tRawArray {
+		return bsoncodec.ValueEncoderError{Name: "RawArrayEncodeValue", Types: []reflect.Type{tRawArray}, Received: val}
+	}
+
+	arr := val.Interface().(RawArray)
+
+	return bsonrw.Copier{}.CopyArrayFromBytes(vw, arr)
+}
+
+// RawArrayDecodeValue is the ValueDecoderFunc for RawArray.
+func (PrimitiveCodecs) RawArrayDecodeValue(dc bsoncodec.DecodeContext, vr bsonrw.ValueReader, val reflect.Value) error {
+	if !val.CanSet() || val.Type() != tRawArray {
+		return bsoncodec.ValueDecoderError{Name: "RawArrayDecodeValue", Types: []reflect.Type{tRawArray}, Received: val}
+	}
+	if vr.Type() != bsontype.Array {
+		return fmt.Errorf("cannot decode %v into a RawArray", vr.Type())
+	}
+
+	if val.IsNil() {
+		val.Set(reflect.MakeSlice(val.Type(), 0, 0))
+	}
+
+	val.SetLen(0)
+
+	arr, err := bsonrw.Copier{}.AppendArrayBytes(val.Interface().(RawArray), vr)
+	val.Set(reflect.ValueOf(RawArray(arr)))
+	return err
+}
+
 func (pc PrimitiveCodecs) encodeRaw(ec bsoncodec.EncodeContext, dw bsonrw.DocumentWriter, raw Raw) error {
 	var copier bsonrw.Copier
 	elems, err := raw.Elements()
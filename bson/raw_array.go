@@ -0,0 +1,93 @@
+// Copyright (C) MongoDB, Inc. 2021-present.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
+
+package bson
+
+import (
+	"go.mongodb.org/mongo-driver/x/bsonx/bsoncore"
+)
+
+// RawArray is a wrapper around a byte slice. It will interpret the slice as a BSON array. The values of the array are
+// read from the slice when they are accessed, so the values before an accessed value are skipped using their lengths
+// and the values after it are not read at all. This type is a wrapper around a bsoncore.Array. Errors returned from
+// the methods on this type and associated types come from the bsoncore package.
+type RawArray []byte
+
+// Validate validates the array and the values contained within it. This method only validates the first array in the
+// slice, to validate other arrays, the slice must be resliced.
+func (a RawArray) Validate() error { return bsoncore.Array(a).Validate() }
+
+// Index searches for and retrieves the value at the given index. This method will panic if the array is invalid or if
+// the index is out of bounds.
+func (a RawArray) Index(index uint) RawValue {
+	return convertFromCoreValue(bsoncore.Array(a).Index(index))
+}
+
+// IndexErr searches for and retrieves the value at the given index.
+func (a RawArray) IndexErr(index uint) (RawValue, error) {
+	val, err := bsoncore.Array(a).IndexErr(index)
+	return convertFromCoreValue(val), err
+}
+
+// Len returns the number of values in the array. If the array is not valid, the number of values up to the invalid
+// point is returned.
+func (a RawArray) Len() int {
+	var n int
+	for it := a.Iterator(); it.Next(); {
+		n++
+	}
+	return n
+}
+
+// Values returns this array as a slice of values. The returned slice will contain valid values. If the array is not
+// valid, the values up to the invalid point will be returned along with an error.
+func (a RawArray) Values() ([]RawValue, error) {
+	vals, err := bsoncore.Array(a).Values()
+	rvals := make([]RawValue, 0, len(vals))
+	for _, val := range vals {
+		rvals = append(rvals, convertFromCoreValue(val))
+	}
+	return rvals, err
+}
+
+// Iterator returns a RawArrayIterator over the values of the array.
+func (a RawArray) Iterator() *RawArrayIterator {
+	return &RawArrayIterator{it: Raw(a).Iterator(), index: -1}
+}
+
+// String implements the fmt.Stringer interface.
+func (a RawArray) String() string { return bsoncore.Array(a).String() }
+
+// DebugString outputs a human readable version of RawArray. It will attempt to stringify the valid components of the
+// array even if the entire array is not valid.
+func (a RawArray) DebugString() string { return bsoncore.Array(a).DebugString() }
+
+// RawArrayIterator iterates over the values of a RawArray. Each value is read when Next is called, so iteration can be
+// stopped at any point without reading the rest of the array. The value returned by Value refers to the bytes of the
+// array and is only valid as long as the array is not modified.
+type RawArrayIterator struct {
+	it    *RawIterator
+	index int
+}
+
+// Next advances the iterator to the next value of the array and returns true if there is one. It returns false when
+// the end of the array is reached or if the array is invalid, in which case Err returns the error.
+func (ai *RawArrayIterator) Next() bool {
+	if !ai.it.Next() {
+		return false
+	}
+	ai.index++
+	return true
+}
+
+// Value returns the current value of the iterator.
+func (ai *RawArrayIterator) Value() RawValue { return ai.it.Element().Value() }
+
+// Index returns the index of the current value of the iterator in the array, or -1 if Next has not been called.
+func (ai *RawArrayIterator) Index() int { return ai.index }
+
+// Err returns the error that stopped the iteration, if any.
+func (ai *RawArrayIterator) Err() error { return ai.it.Err() }
@@ -0,0 +1,89 @@
+// Copyright (C) MongoDB, Inc. 2021-present.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
+
+package bson
+
+import (
+	"testing"
+
+	"go.mongodb.org/mongo-driver/bson/bsontype"
+	"go.mongodb.org/mongo-driver/internal/testutil/assert"
+	"go.mongodb.org/mongo-driver/x/bsonx/bsoncore"
+)
+
+func TestRawArray(t *testing.T) {
+	arr := RawArray(bsoncore.NewArrayBuilder().
+		AppendInt32(1).
+		AppendString("foo").
+		AppendDocument(bsoncore.NewDocumentBuilder().AppendInt32("x", 1).Build()).
+		Build())
+
+	t.Run("Len", func(t *testing.T) {
+		assert.Equal(t, 3, arr.Len(), "expected length 3, got %v", arr.Len())
+		assert.Equal(t, 0, RawArray(bsoncore.NewArrayBuilder().Build()).Len(), "expected empty array")
+		assert.Equal(t, 0, RawArray(nil).Len(), "expected nil array to have length 0")
+	})
+	t.Run("IndexErr", func(t *testing.T) {
+		val, err := arr.IndexErr(1)
+		assert.Nil(t, err, "IndexErr error: %v", err)
+		assert.Equal(t, "foo", val.StringValue(), "expected foo, got %v", val)
+
+		_, err = arr.IndexErr(3)
+		assert.Equal(t, bsoncore.ErrOutOfBounds, err, "expected error %v, got %v", bsoncore.ErrOutOfBounds, err)
+	})
+	t.Run("iterator", func(t *testing.T) {
+		var types []bsontype.Type
+		it := arr.Iterator()
+		assert.Equal(t, -1, it.Index(), "expected index -1 before Next, got %v", it.Index())
+		for it.Next() {
+			assert.Equal(t, len(types), it.Index(), "expected index %v, got %v", len(types), it.Index())
+			types = append(types, it.Value().Type)
+		}
+		assert.Nil(t, it.Err(), "iterator error: %v", it.Err())
+		want := []bsontype.Type{bsontype.Int32, bsontype.String, bsontype.EmbeddedDocument}
+		assert.Equal(t, want, types, "expected types %v, got %v", want, types)
+	})
+	t.Run("partial scan of truncated array", func(t *testing.T) {
+		// The length prefix covers the whole array, but the last value is cut short.
+		truncated := append(RawArray(nil), arr[:len(arr)-6]...)
+		truncated = append(truncated, 0x00)
+		truncated[0] = byte(len(truncated))
+
+		it := truncated.Iterator()
+		assert.True(t, it.Next(), "expected first value; error: %v", it.Err())
+		assert.Equal(t, int32(1), it.Value().Int32(), "expected 1, got %v", it.Value())
+		assert.True(t, it.Next(), "expected second value; error: %v", it.Err())
+		assert.False(t, it.Next(), "expected no third value")
+		assert.NotNil(t, it.Err(), "expected iterator error, got nil")
+		assert.Equal(t, 2, truncated.Len(), "expected length 2, got %v", truncated.Len())
+	})
+	t.Run("RawValue", func(t *testing.T) {
+		doc := Raw(bsoncore.NewDocumentBuilder().AppendArray("a", arr).AppendInt32("b", 1).Build())
+		got, ok := doc.Lookup("a").RawArrayOK()
+		assert.True(t, ok, "expected RawArrayOK to return true")
+		assert.Equal(t, arr, got, "expected array %v, got %v", arr, got)
+
+		_, ok = doc.Lookup("b").RawArrayOK()
+		assert.False(t, ok, "expected RawArrayOK to return false for an int32")
+	})
+	t.Run("codec", func(t *testing.T) {
+		type withArray struct {
+			A RawArray
+		}
+		b, err := Marshal(withArray{A: arr})
+		assert.Nil(t, err, "Marshal error: %v", err)
+
+		var got withArray
+		err = Unmarshal(b, &got)
+		assert.Nil(t, err, "Unmarshal error: %v", err)
+		assert.Equal(t, arr, got.A, "expected array %v, got %v", arr, got.A)
+
+		b, err = Marshal(D{{"A", "foo"}})
+		assert.Nil(t, err, "Marshal error: %v", err)
+		err = Unmarshal(b, &got)
+		assert.NotNil(t, err, "expected Unmarshal error for a string, got nil")
+	})
+}
@@ -0,0 +1,109 @@
+// Copyright (C) MongoDB, Inc. 2021-present.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
+
+package bson
+
+import (
+	"go.mongodb.org/mongo-driver/x/bsonx/bsoncore"
+)
+
+// RawIterator iterates over the elements of a Raw document. Each element is read when Next is called, so iteration can
+// be stopped at any point without reading the rest of the document, and elements are skipped using their lengths
+// without parsing their values. The iterator does not allocate per element: the element returned by Element refers to
+// the bytes of the document and is only valid as long as the document is not modified.
+//
+// The position of the iterator is the byte offset of the next element in the document, which is returned by Offset.
+// It can be stored to resume the iteration later with Seek, e.g. by an analyzer that processes a large document in
+// several passes.
+type RawIterator struct {
+	doc     Raw
+	end     int // offset of the trailing null byte of the document
+	pos     int // offset of the next element
+	elem    RawElement
+	err     error
+	initErr error
+}
+
+// Iterator returns a RawIterator over the elements of the document. If the length of the document is invalid, the
+// iterator returns no elements and its Err method returns the error.
+func (r Raw) Iterator() *RawIterator {
+	it := &RawIterator{doc: r}
+	length, rem, ok := bsoncore.ReadLength(r)
+	switch {
+	case !ok:
+		it.initErr = bsoncore.NewInsufficientBytesError(r, rem)
+	case length < 5 || int(length) > len(r):
+		it.initErr = bsoncore.NewDocumentLengthError(int(length), len(r))
+	case r[length-1] != 0x00:
+		it.initErr = bsoncore.ErrMissingNull
+	default:
+		it.end = int(length) - 1
+	}
+	it.Reset()
+	return it
+}
+
+// Next advances the iterator to the next element of the document and returns true if there is one. It returns false
+// when the end of the document is reached or if the document is invalid, in which case Err returns the error.
+func (it *RawIterator) Next() bool {
+	it.elem = nil
+	if it.err != nil || it.pos >= it.end {
+		return false
+	}
+
+	elem, _, ok := bsoncore.ReadElement(it.doc[it.pos:it.end])
+	if !ok {
+		it.err = bsoncore.NewInsufficientBytesError(it.doc, it.doc[it.pos:])
+		return false
+	}
+	it.elem = RawElement(elem)
+	it.pos += len(elem)
+	return true
+}
+
+// Skip advances the iterator past the next n elements without returning them and returns the number of elements that
+// were skipped, which is less than n if the end of the document is reached or the document is invalid. If all n
+// elements are skipped, Element returns the last of them. Otherwise, Element returns nil, as it does after Next returns
+// false.
+func (it *RawIterator) Skip(n int) int {
+	var skipped int
+	for skipped < n && it.Next() {
+		skipped++
+	}
+	return skipped
+}
+
+// Element returns the current element of the iterator, or nil if Next has not been called or returned false.
+func (it *RawIterator) Element() RawElement { return it.elem }
+
+// Offset returns the byte offset in the document of the element that will be returned by the next call to Next.
+func (it *RawIterator) Offset() int { return it.pos }
+
+// Seek moves the iterator to the given byte offset in the document, which must be an offset returned by Offset for an
+// iterator over the same document. The next call to Next returns the element at the offset. An error is returned if
+// the offset is outside of the elements of the document.
+func (it *RawIterator) Seek(offset int) error {
+	if it.initErr != nil {
+		return it.initErr
+	}
+	if offset < 4 || offset > it.end {
+		return bsoncore.ErrOutOfBounds
+	}
+	it.pos = offset
+	it.elem = nil
+	it.err = nil
+	return nil
+}
+
+// Reset moves the iterator back to the first element of the document.
+func (it *RawIterator) Reset() {
+	it.pos = 4
+	it.elem = nil
+	it.err = it.initErr
+}
+
+// Err returns the error that stopped the iteration, if any.
+func (it *RawIterator) Err() error { return it.err }
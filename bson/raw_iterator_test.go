@@ -0,0 +1,82 @@
+// Copyright (C) MongoDB, Inc. 2021-present.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
+
+package bson
+
+import (
+	"testing"
+
+	"go.mongodb.org/mongo-driver/internal/testutil/assert"
+	"go.mongodb.org/mongo-driver/x/bsonx/bsoncore"
+)
+
+func TestRawIterator(t *testing.T) {
+	doc := Raw(bsoncore.NewDocumentBuilder().
+		AppendInt32("a", 1).
+		AppendString("b", "foo").
+		AppendDocument("c", bsoncore.NewDocumentBuilder().AppendInt32("x", 1).Build()).
+		AppendBoolean("d", true).
+		Build())
+	keys := func(it *RawIterator) []string {
+		var keys []string
+		for it.Next() {
+			keys = append(keys, it.Element().Key())
+		}
+		return keys
+	}
+
+	t.Run("Next", func(t *testing.T) {
+		it := doc.Iterator()
+		assert.Nil(t, it.Element(), "expected nil element before Next, got %v", it.Element())
+		got := keys(it)
+		assert.Nil(t, it.Err(), "iterator error: %v", it.Err())
+		assert.Equal(t, []string{"a", "b", "c", "d"}, got, "unexpected keys %v", got)
+		assert.Nil(t, it.Element(), "expected nil element after the last element, got %v", it.Element())
+	})
+	t.Run("Skip", func(t *testing.T) {
+		it := doc.Iterator()
+		skipped := it.Skip(2)
+		assert.Equal(t, 2, skipped, "expected 2 elements to be skipped, got %v", skipped)
+		assert.Equal(t, "b", it.Element().Key(), "expected last skipped element b, got %v", it.Element())
+		assert.Equal(t, []string{"c", "d"}, keys(it), "unexpected keys after Skip")
+
+		it.Reset()
+		skipped = it.Skip(10)
+		assert.Equal(t, 4, skipped, "expected 4 elements to be skipped, got %v", skipped)
+		assert.Nil(t, it.Element(), "expected nil element after skipping past the end, got %v", it.Element())
+	})
+	t.Run("Seek", func(t *testing.T) {
+		it := doc.Iterator()
+		it.Skip(1)
+		offset := it.Offset()
+		assert.Equal(t, []string{"b", "c", "d"}, keys(it), "unexpected keys")
+
+		err := it.Seek(offset)
+		assert.Nil(t, err, "Seek error: %v", err)
+		assert.Equal(t, []string{"b", "c", "d"}, keys(it), "unexpected keys after Seek")
+
+		err = doc.Iterator().Seek(len(doc))
+		assert.Equal(t, bsoncore.ErrOutOfBounds, err, "expected error %v, got %v", bsoncore.ErrOutOfBounds, err)
+		err = doc.Iterator().Seek(0)
+		assert.Equal(t, bsoncore.ErrOutOfBounds, err, "expected error %v, got %v", bsoncore.ErrOutOfBounds, err)
+	})
+	t.Run("invalid document", func(t *testing.T) {
+		it := Raw{0x05, 0x00}.Iterator()
+		assert.False(t, it.Next(), "expected Next to return false")
+		assert.NotNil(t, it.Err(), "expected iterator error, got nil")
+		assert.NotNil(t, it.Seek(4), "expected Seek error, got nil")
+	})
+	t.Run("no allocations per element", func(t *testing.T) {
+		it := doc.Iterator()
+		allocs := testing.AllocsPerRun(10, func() {
+			it.Reset()
+			for it.Next() {
+				_ = it.Element().Value()
+			}
+		})
+		assert.Equal(t, float64(0), allocs, "expected no allocations, got %v", allocs)
+	})
+}
@@ -162,6 +162,17 @@ func (rv RawValue) ArrayOK() (Raw, bool) {
 	return Raw(doc), ok
 }
 
+// RawArray returns the BSON array the Value represents as a RawArray. It panics if the value is a
+// BSON type other than array.
+func (rv RawValue) RawArray() RawArray { return RawArray(convertToCoreValue(rv).Array()) }
+
+// RawArrayOK is the same as RawArray, except it returns a boolean instead
+// of panicking.
+func (rv RawValue) RawArrayOK() (RawArray, bool) {
+	arr, ok := convertToCoreValue(rv).ArrayOK()
+	return RawArray(arr), ok
+}
+
 // Binary returns the BSON binary value the Value represents. It panics if the value is a BSON type
 // other than binary.
 func (rv RawValue) Binary() (subtype byte, data []byte) { return convertToCoreValue(rv).Binary() }
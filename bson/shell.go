@@ -0,0 +1,590 @@
+// Copyright (C) MongoDB, Inc. 2021-present.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
+
+package bson
+
+import (
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// ParseShell parses a document written in the syntax of the MongoDB shell, such as a query filter copied from mongosh,
+// and returns it as a D. In addition to JSON, the syntax allows unquoted keys, single-quoted strings, trailing commas,
+// comments, regular expression literals, and the following shell helpers:
+//
+//	ObjectId("5f8d0d55b54764421b7156c1")
+//	ISODate("2021-01-02T15:04:05Z"), new Date("2021-01-02"), Date(1609600000000)
+//	NumberInt(1), NumberLong("9007199254740993"), NumberDecimal("1.5"), Decimal128("1.5")
+//	Timestamp(1609600000, 1), Timestamp({t: 1609600000, i: 1})
+//	BinData(0, "aGVsbG8="), UUID("f47ac10b-58cc-4372-a567-0e02b2c3d479")
+//	RegExp("^a", "i"), MinKey, MaxKey, undefined
+//
+// Arrays are returned as A and nested documents as D. Integers that fit in 32 bits are returned as int32, larger
+// integers as int64, and other numbers as float64. Dates without a time zone are interpreted as UTC.
+func ParseShell(s string) (D, error) {
+	p := &shellParser{src: s}
+	p.skipSpace()
+	doc, err := p.parseDocument()
+	if err != nil {
+		return nil, err
+	}
+	p.skipSpace()
+	if p.pos < len(p.src) {
+		return nil, p.errorf("unexpected %q after document", p.src[p.pos])
+	}
+	return doc, nil
+}
+
+type shellParser struct {
+	src string
+	pos int
+}
+
+func (p *shellParser) errorf(format string, args ...interface{}) error {
+	return fmt.Errorf("invalid shell syntax at offset %d: %s", p.pos, fmt.Sprintf(format, args...))
+}
+
+// skipSpace skips whitespace and comments.
+func (p *shellParser) skipSpace() {
+	for p.pos < len(p.src) {
+		switch c := p.src[p.pos]; {
+		case c == ' ' || c == '\t' || c == '\n' || c == '\r':
+			p.pos++
+		case strings.HasPrefix(p.src[p.pos:], "//"):
+			end := strings.IndexByte(p.src[p.pos:], '\n')
+			if end < 0 {
+				p.pos = len(p.src)
+				return
+			}
+			p.pos += end + 1
+		case strings.HasPrefix(p.src[p.pos:], "/*"):
+			end := strings.Index(p.src[p.pos+2:], "*/")
+			if end < 0 {
+				p.pos = len(p.src)
+				return
+			}
+			p.pos += end + 4
+		default:
+			return
+		}
+	}
+}
+
+// peek returns the next byte, or 0 at the end of the input.
+func (p *shellParser) peek() byte {
+	if p.pos < len(p.src) {
+		return p.src[p.pos]
+	}
+	return 0
+}
+
+func (p *shellParser) expect(c byte) error {
+	p.skipSpace()
+	if p.peek() != c {
+		if p.pos >= len(p.src) {
+			return p.errorf("expected %q, got end of input", c)
+		}
+		return p.errorf("expected %q, got %q", c, p.peek())
+	}
+	p.pos++
+	return nil
+}
+
+func (p *shellParser) parseDocument() (D, error) {
+	if err := p.expect('{'); err != nil {
+		return nil, err
+	}
+
+	doc := D{}
+	for {
+		p.skipSpace()
+		if p.peek() == '}' {
+			p.pos++
+			return doc, nil
+		}
+
+		key, err := p.parseKey()
+		if err != nil {
+			return nil, err
+		}
+		if err = p.expect(':'); err != nil {
+			return nil, err
+		}
+		val, err := p.parseValue()
+		if err != nil {
+			return nil, err
+		}
+		doc = append(doc, E{Key: key, Value: val})
+
+		p.skipSpace()
+		switch p.peek() {
+		case ',':
+			p.pos++
+		case '}':
+		default:
+			return nil, p.errorf("expected ',' or '}' in document")
+		}
+	}
+}
+
+func (p *shellParser) parseArray() (A, error) {
+	if err := p.expect('['); err != nil {
+		return nil, err
+	}
+
+	arr := A{}
+	for {
+		p.skipSpace()
+		if p.peek() == ']' {
+			p.pos++
+			return arr, nil
+		}
+
+		val, err := p.parseValue()
+		if err != nil {
+			return nil, err
+		}
+		arr = append(arr, val)
+
+		p.skipSpace()
+		switch p.peek() {
+		case ',':
+			p.pos++
+		case ']':
+		default:
+			return nil, p.errorf("expected ',' or ']' in array")
+		}
+	}
+}
+
+func (p *shellParser) parseKey() (string, error) {
+	switch c := p.peek(); {
+	case c == '"' || c == '\'':
+		return p.parseString()
+	case isIdentifierStart(c):
+		return p.parseIdentifier(), nil
+	case c >= '0' && c <= '9':
+		start := p.pos
+		for p.pos < len(p.src) && p.src[p.pos] >= '0' && p.src[p.pos] <= '9' {
+			p.pos++
+		}
+		return p.src[start:p.pos], nil
+	}
+	return "", p.errorf("expected key")
+}
+
+func isIdentifierStart(c byte) bool {
+	return c == '_' || c == '$' || (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z')
+}
+
+func (p *shellParser) parseIdentifier() string {
+	start := p.pos
+	for p.pos < len(p.src) {
+		c := p.src[p.pos]
+		if !isIdentifierStart(c) && !(c >= '0' && c <= '9') {
+			break
+		}
+		p.pos++
+	}
+	return p.src[start:p.pos]
+}
+
+func (p *shellParser) parseString() (string, error) {
+	quote := p.src[p.pos]
+	p.pos++
+
+	var sb strings.Builder
+	for p.pos < len(p.src) {
+		c := p.src[p.pos]
+		switch {
+		case c == quote:
+			p.pos++
+			return sb.String(), nil
+		case c == '\\':
+			if p.pos+1 >= len(p.src) {
+				return "", p.errorf("unterminated string")
+			}
+			p.pos++
+			esc := p.src[p.pos]
+			p.pos++
+			switch esc {
+			case 'n':
+				sb.WriteByte('\n')
+			case 't':
+				sb.WriteByte('\t')
+			case 'r':
+				sb.WriteByte('\r')
+			case 'b':
+				sb.WriteByte('\b')
+			case 'f':
+				sb.WriteByte('\f')
+			case 'u':
+				if p.pos+4 > len(p.src) {
+					return "", p.errorf("invalid unicode escape")
+				}
+				r, err := strconv.ParseUint(p.src[p.pos:p.pos+4], 16, 32)
+				if err != nil {
+					return "", p.errorf("invalid unicode escape")
+				}
+				p.pos += 4
+				sb.WriteRune(rune(r))
+			default:
+				sb.WriteByte(esc)
+			}
+		default:
+			sb.WriteByte(c)
+			p.pos++
+		}
+	}
+	return "", p.errorf("unterminated string")
+}
+
+func (p *shellParser) parseValue() (interface{}, error) {
+	p.skipSpace()
+	switch c := p.peek(); {
+	case c == '{':
+		return p.parseDocument()
+	case c == '[':
+		return p.parseArray()
+	case c == '"' || c == '\'':
+		return p.parseString()
+	case c == '/':
+		return p.parseRegexLiteral()
+	case c == '-' || c == '+' || c == '.' || (c >= '0' && c <= '9'):
+		return p.parseNumber()
+	case isIdentifierStart(c):
+		return p.parseIdentifierValue()
+	case c == 0:
+		return nil, p.errorf("expected value, got end of input")
+	}
+	return nil, p.errorf("unexpected %q", p.peek())
+}
+
+func (p *shellParser) parseNumber() (interface{}, error) {
+	start := p.pos
+	if c := p.peek(); c == '-' || c == '+' {
+		p.pos++
+	}
+	if strings.HasPrefix(p.src[p.pos:], "Infinity") {
+		p.pos += len("Infinity")
+		if p.src[start] == '-' {
+			return math.Inf(-1), nil
+		}
+		return math.Inf(1), nil
+	}
+	for p.pos < len(p.src) && strings.IndexByte("0123456789.eE+-", p.src[p.pos]) >= 0 {
+		p.pos++
+	}
+
+	text := p.src[start:p.pos]
+	if i, err := strconv.ParseInt(text, 10, 64); err == nil {
+		if i >= math.MinInt32 && i <= math.MaxInt32 {
+			return int32(i), nil
+		}
+		return i, nil
+	}
+	f, err := strconv.ParseFloat(text, 64)
+	if err != nil {
+		p.pos = start
+		return nil, p.errorf("invalid number %q", text)
+	}
+	return f, nil
+}
+
+func (p *shellParser) parseRegexLiteral() (interface{}, error) {
+	p.pos++
+
+	var sb strings.Builder
+	for {
+		if p.pos >= len(p.src) || p.src[p.pos] == '\n' {
+			return nil, p.errorf("unterminated regular expression")
+		}
+		c := p.src[p.pos]
+		p.pos++
+		if c == '/' {
+			break
+		}
+		sb.WriteByte(c)
+		if c == '\\' && p.pos < len(p.src) {
+			sb.WriteByte(p.src[p.pos])
+			p.pos++
+		}
+	}
+	options := p.parseIdentifier()
+	return primitive.Regex{Pattern: sb.String(), Options: options}, nil
+}
+
+// parseArgs parses the parenthesized arguments of a shell helper.
+func (p *shellParser) parseArgs() ([]interface{}, error) {
+	if err := p.expect('('); err != nil {
+		return nil, err
+	}
+
+	var args []interface{}
+	for {
+		p.skipSpace()
+		if p.peek() == ')' {
+			p.pos++
+			return args, nil
+		}
+
+		arg, err := p.parseValue()
+		if err != nil {
+			return nil, err
+		}
+		args = append(args, arg)
+
+		p.skipSpace()
+		switch p.peek() {
+		case ',':
+			p.pos++
+		case ')':
+		default:
+			return nil, p.errorf("expected ',' or ')' in arguments")
+		}
+	}
+}
+
+func (p *shellParser) parseIdentifierValue() (interface{}, error) {
+	start := p.pos
+	name := p.parseIdentifier()
+	if name == "new" {
+		p.skipSpace()
+		if !isIdentifierStart(p.peek()) {
+			return nil, p.errorf("expected constructor after new")
+		}
+		start = p.pos
+		name = p.parseIdentifier()
+	}
+
+	switch name {
+	case "true":
+		return true, nil
+	case "false":
+		return false, nil
+	case "null":
+		return nil, nil
+	case "undefined":
+		return primitive.Undefined{}, nil
+	case "Infinity":
+		return math.Inf(1), nil
+	case "NaN":
+		return math.NaN(), nil
+	}
+
+	p.skipSpace()
+	var args []interface{}
+	hasArgs := p.peek() == '('
+	if hasArgs {
+		var err error
+		if args, err = p.parseArgs(); err != nil {
+			return nil, err
+		}
+	}
+
+	val, err := shellHelper(name, args, hasArgs)
+	if err != nil {
+		p.pos = start
+		return nil, p.errorf("%v", err)
+	}
+	return val, nil
+}
+
+// shellHelper returns the value created by calling the shell helper with the given name and arguments. The hasArgs
+// parameter is false if the helper was referenced without parentheses, which is only valid for MinKey and MaxKey.
+func shellHelper(name string, args []interface{}, hasArgs bool) (interface{}, error) {
+	switch name {
+	case "MinKey":
+		return primitive.MinKey{}, nil
+	case "MaxKey":
+		return primitive.MaxKey{}, nil
+	}
+	if !hasArgs {
+		return nil, fmt.Errorf("unknown identifier %q", name)
+	}
+
+	switch name {
+	case "ObjectId", "ObjectID":
+		if len(args) == 0 {
+			return primitive.NewObjectID(), nil
+		}
+		s, err := stringArg(name, args, 1)
+		if err != nil {
+			return nil, err
+		}
+		return primitive.ObjectIDFromHex(s)
+	case "ISODate", "Date":
+		if len(args) == 0 {
+			return primitive.NewDateTimeFromTime(time.Now()), nil
+		}
+		if len(args) != 1 {
+			return nil, fmt.Errorf("expected at most 1 argument for %s, got %d", name, len(args))
+		}
+		if ms, ok := integerValue(args[0]); ok {
+			return primitive.DateTime(ms), nil
+		}
+		s, ok := args[0].(string)
+		if !ok {
+			return nil, fmt.Errorf("expected a string or number argument for %s", name)
+		}
+		return parseShellDate(s)
+	case "NumberInt", "Int32":
+		n, err := numericArg(name, args)
+		if err != nil {
+			return nil, err
+		}
+		i, err := strconv.ParseInt(n, 10, 32)
+		if err != nil {
+			return nil, fmt.Errorf("invalid %s value %q", name, n)
+		}
+		return int32(i), nil
+	case "NumberLong", "Long":
+		n, err := numericArg(name, args)
+		if err != nil {
+			return nil, err
+		}
+		i, err := strconv.ParseInt(n, 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid %s value %q", name, n)
+		}
+		return i, nil
+	case "NumberDecimal", "Decimal128":
+		n, err := numericArg(name, args)
+		if err != nil {
+			return nil, err
+		}
+		return primitive.ParseDecimal128(n)
+	case "Timestamp":
+		if len(args) == 1 {
+			if doc, ok := args[0].(D); ok {
+				m := doc.Map()
+				args = []interface{}{m["t"], m["i"]}
+			}
+		}
+		if len(args) != 2 {
+			return nil, fmt.Errorf("expected 2 arguments for Timestamp, got %d", len(args))
+		}
+		t, okT := integerValue(args[0])
+		i, okI := integerValue(args[1])
+		if !okT || !okI || t < 0 || t > math.MaxUint32 || i < 0 || i > math.MaxUint32 {
+			return nil, fmt.Errorf("expected two unsigned 32-bit integers for Timestamp")
+		}
+		return primitive.Timestamp{T: uint32(t), I: uint32(i)}, nil
+	case "BinData":
+		if len(args) != 2 {
+			return nil, fmt.Errorf("expected 2 arguments for BinData, got %d", len(args))
+		}
+		subtype, ok := integerValue(args[0])
+		if !ok || subtype < 0 || subtype > math.MaxUint8 {
+			return nil, fmt.Errorf("expected a subtype between 0 and 255 for BinData")
+		}
+		s, ok := args[1].(string)
+		if !ok {
+			return nil, fmt.Errorf("expected a base64 string for BinData")
+		}
+		data, err := base64.StdEncoding.DecodeString(s)
+		if err != nil {
+			return nil, fmt.Errorf("invalid BinData base64 string: %v", err)
+		}
+		return primitive.Binary{Subtype: byte(subtype), Data: data}, nil
+	case "UUID":
+		s, err := stringArg(name, args, 1)
+		if err != nil {
+			return nil, err
+		}
+		data, err := hex.DecodeString(strings.Replace(s, "-", "", -1))
+		if err != nil || len(data) != 16 {
+			return nil, fmt.Errorf("invalid UUID %q", s)
+		}
+		return primitive.Binary{Subtype: 4, Data: data}, nil
+	case "RegExp":
+		if len(args) == 0 || len(args) > 2 {
+			return nil, fmt.Errorf("expected 1 or 2 arguments for RegExp, got %d", len(args))
+		}
+		pattern, ok := args[0].(string)
+		if !ok {
+			return nil, fmt.Errorf("expected a string pattern for RegExp")
+		}
+		var options string
+		if len(args) == 2 {
+			if options, ok = args[1].(string); !ok {
+				return nil, fmt.Errorf("expected a string of flags for RegExp")
+			}
+		}
+		return primitive.Regex{Pattern: pattern, Options: options}, nil
+	}
+	return nil, fmt.Errorf("unsupported shell helper %s()", name)
+}
+
+// stringArg returns the only argument of a helper, which must be a string.
+func stringArg(name string, args []interface{}, n int) (string, error) {
+	if len(args) != n {
+		return "", fmt.Errorf("expected %d argument for %s, got %d", n, name, len(args))
+	}
+	s, ok := args[0].(string)
+	if !ok {
+		return "", fmt.Errorf("expected a string argument for %s", name)
+	}
+	return s, nil
+}
+
+// numericArg returns the only argument of a numeric helper, which can be a string or a number, as a string.
+func numericArg(name string, args []interface{}) (string, error) {
+	if len(args) != 1 {
+		return "", fmt.Errorf("expected 1 argument for %s, got %d", name, len(args))
+	}
+	switch v := args[0].(type) {
+	case string:
+		return v, nil
+	case int32:
+		return strconv.FormatInt(int64(v), 10), nil
+	case int64:
+		return strconv.FormatInt(v, 10), nil
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64), nil
+	}
+	return "", fmt.Errorf("expected a string or number argument for %s", name)
+}
+
+func integerValue(v interface{}) (int64, bool) {
+	switch n := v.(type) {
+	case int32:
+		return int64(n), true
+	case int64:
+		return n, true
+	case float64:
+		if n == math.Trunc(n) && !math.IsInf(n, 0) {
+			return int64(n), true
+		}
+	}
+	return 0, false
+}
+
+// shellDateLayouts are the date formats accepted by ISODate. Layouts without a time zone are interpreted as UTC.
+var shellDateLayouts = []string{
+	time.RFC3339Nano,
+	"2006-01-02T15:04:05.999999999Z0700",
+	"2006-01-02T15:04:05.999999999",
+	"2006-01-02T15:04",
+	"2006-01-02 15:04:05.999999999",
+	"2006-01-02",
+}
+
+func parseShellDate(s string) (primitive.DateTime, error) {
+	for _, layout := range shellDateLayouts {
+		if t, err := time.Parse(layout, s); err == nil {
+			return primitive.NewDateTimeFromTime(t), nil
+		}
+	}
+	return 0, fmt.Errorf("invalid date %q", s)
+}
@@ -0,0 +1,107 @@
+// Copyright (C) MongoDB, Inc. 2021-present.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
+
+package bson
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/internal/testutil/assert"
+)
+
+func TestParseShell(t *testing.T) {
+	oid, err := primitive.ObjectIDFromHex("5f8d0d55b54764421b7156c1")
+	assert.Nil(t, err, "ObjectIDFromHex error: %v", err)
+	date := primitive.NewDateTimeFromTime(time.Date(2021, 1, 2, 15, 4, 5, 0, time.UTC))
+	day := primitive.NewDateTimeFromTime(time.Date(2021, 1, 2, 0, 0, 0, 0, time.UTC))
+
+	testCases := []struct {
+		name     string
+		input    string
+		expected D
+	}{
+		{"empty", "{}", D{}},
+		{"json", `{"a": 1, "b": "x", "c": true, "d": null}`, D{{"a", int32(1)}, {"b", "x"}, {"c", true}, {"d", nil}}},
+		{"unquoted keys", `{ name: 'alice', $or: [{age: {$gte: 18}}, {_id: 2}], }`, D{
+			{"name", "alice"},
+			{"$or", A{D{{"age", D{{"$gte", int32(18)}}}}, D{{"_id", int32(2)}}}},
+		}},
+		{"numbers", `{a: -1, b: 2.5, c: 1e3, d: 4294967296, e: -Infinity}`, D{
+			{"a", int32(-1)}, {"b", 2.5}, {"c", 1000.0}, {"d", int64(4294967296)}, {"e", math.Inf(-1)},
+		}},
+		{"comments", "{a: 1, // first\n /* second */ b: 2}", D{{"a", int32(1)}, {"b", int32(2)}}},
+		{"escapes", `{a: "say \"hi\"\n", b: 'it\'s', c: "é"}`, D{{"a", "say \"hi\"\n"}, {"b", "it's"}, {"c", "é"}}},
+		{"ObjectId", `{_id: ObjectId("5f8d0d55b54764421b7156c1")}`, D{{"_id", oid}}},
+		{"dates", `{a: ISODate("2021-01-02T15:04:05Z"), b: new Date("2021-01-02"), c: ISODate("2021-01-02T15:04:05")}`,
+			D{{"a", date}, {"b", day}, {"c", date}}},
+		{"numeric helpers", `{a: NumberInt(5), b: NumberLong("9007199254740993"), c: NumberLong(7)}`, D{
+			{"a", int32(5)}, {"b", int64(9007199254740993)}, {"c", int64(7)},
+		}},
+		{"Timestamp", `{a: Timestamp(10, 1), b: Timestamp({t: 10, i: 2})}`, D{
+			{"a", primitive.Timestamp{T: 10, I: 1}}, {"b", primitive.Timestamp{T: 10, I: 2}},
+		}},
+		{"binary", `{a: BinData(0, "aGVsbG8="), b: UUID("00112233-4455-6677-8899-aabbccddeeff")}`, D{
+			{"a", primitive.Binary{Subtype: 0, Data: []byte("hello")}},
+			{"b", primitive.Binary{Subtype: 4, Data: []byte{
+				0x00, 0x11, 0x22, 0x33, 0x44, 0x55, 0x66, 0x77, 0x88, 0x99, 0xaa, 0xbb, 0xcc, 0xdd, 0xee, 0xff,
+			}}},
+		}},
+		{"regex", `{a: /^al\/i/i, b: RegExp("x+", "m")}`, D{
+			{"a", primitive.Regex{Pattern: `^al\/i`, Options: "i"}}, {"b", primitive.Regex{Pattern: "x+", Options: "m"}},
+		}},
+		{"keys", `{min: MinKey, max: MaxKey(), u: undefined}`, D{
+			{"min", primitive.MinKey{}}, {"max", primitive.MaxKey{}}, {"u", primitive.Undefined{}},
+		}},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := ParseShell(tc.input)
+			assert.Nil(t, err, "ParseShell error: %v", err)
+			assert.Equal(t, tc.expected, got, "expected %v, got %v", tc.expected, got)
+		})
+	}
+
+	t.Run("NumberDecimal", func(t *testing.T) {
+		doc, err := ParseShell(`{a: NumberDecimal("1.5"), b: Decimal128("-2E+3")}`)
+		assert.Nil(t, err, "ParseShell error: %v", err)
+		for i, expected := range []string{"1.5", "-2E+3"} {
+			dec, ok := doc[i].Value.(primitive.Decimal128)
+			assert.True(t, ok, "expected Decimal128, got %T", doc[i].Value)
+			assert.Equal(t, expected, dec.String(), "expected %v, got %v", expected, dec.String())
+		}
+	})
+	t.Run("marshals", func(t *testing.T) {
+		doc, err := ParseShell(`{createdAt: {$gt: ISODate("2021-01-02")}, tags: ["a", 'b']}`)
+		assert.Nil(t, err, "ParseShell error: %v", err)
+		_, err = Marshal(doc)
+		assert.Nil(t, err, "Marshal error: %v", err)
+	})
+	t.Run("errors", func(t *testing.T) {
+		inputs := []string{
+			"",
+			"[1]",
+			"{a 1}",
+			"{a: 1",
+			"{a: 1} x",
+			"{a: 'unterminated}",
+			"{a: foo}",
+			"{a: Foo(1)}",
+			`{a: ObjectId("xyz")}`,
+			`{a: ISODate("yesterday")}`,
+			"{a: Timestamp(1)}",
+			"{a: NumberInt(4294967296)}",
+			`{a: UUID("1234")}`,
+			"{a: /abc}",
+		}
+		for _, input := range inputs {
+			_, err := ParseShell(input)
+			assert.NotNil(t, err, "expected ParseShell error for %q, got nil", input)
+		}
+	})
+}
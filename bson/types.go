@@ -60,6 +60,7 @@ var tInt64 = reflect.TypeOf(int64(0))
 var tJavaScript = reflect.TypeOf(primitive.JavaScript(""))
 var tOID = reflect.TypeOf(primitive.ObjectID{})
 var tRaw = reflect.TypeOf(Raw(nil))
+var tRawArray = reflect.TypeOf(RawArray(nil))
 var tRegex = reflect.TypeOf(primitive.Regex{})
 var tString = reflect.TypeOf("")
 var tSymbol = reflect.TypeOf(primitive.Symbol(""))
@@ -53,6 +53,21 @@ func UnmarshalWithContext(dc bsoncodec.DecodeContext, data []byte, val interface
 	return unmarshalFromReader(dc, vr, val)
 }
 
+// UnmarshalStrict parses the BSON-encoded data like Unmarshal, but uses the hardened ValueReader returned by
+// bsonrw.NewStrictBSONDocumentReader. It should be used for BSON received from sources other than a MongoDB server.
+// Malformed data results in an error and the memory allocated while parsing is bounded by the length of data.
+func UnmarshalStrict(data []byte, val interface{}) error {
+	return UnmarshalStrictWithContext(bsoncodec.DecodeContext{Registry: DefaultRegistry}, data, val)
+}
+
+// UnmarshalStrictWithContext parses the BSON-encoded data like UnmarshalStrict using DecodeContext dc and stores the
+// result in the value pointed to by val. If val is nil or not a pointer, UnmarshalStrictWithContext returns
+// InvalidUnmarshalError.
+func UnmarshalStrictWithContext(dc bsoncodec.DecodeContext, data []byte, val interface{}) error {
+	vr := bsonrw.NewStrictBSONDocumentReader(data)
+	return unmarshalFromReader(dc, vr, val)
+}
+
 // UnmarshalExtJSON parses the extended JSON-encoded data and stores the result
 // in the value pointed to by val. If val is nil or not a pointer, Unmarshal
 // returns InvalidUnmarshalError.
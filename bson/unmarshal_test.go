@@ -7,12 +7,15 @@
 package bson
 
 import (
+	"math"
 	"reflect"
+	"strconv"
 	"testing"
 
 	"github.com/google/go-cmp/cmp"
 	"go.mongodb.org/mongo-driver/bson/bsoncodec"
 	"go.mongodb.org/mongo-driver/bson/bsonrw"
+	"go.mongodb.org/mongo-driver/bson/bsontype"
 	"go.mongodb.org/mongo-driver/internal/testutil/assert"
 	"go.mongodb.org/mongo-driver/x/bsonx/bsoncore"
 )
@@ -429,3 +432,269 @@ func TestUnmarshalBSONWithUndefinedField(t *testing.T) {
 		})
 	}
 }
+
+func TestUnmarshalDuplicateKeys(t *testing.T) {
+	// {"a": 1, "b": 2, "a": 3}
+	data := bsoncore.BuildDocumentFromElements(nil,
+		bsoncore.AppendInt32Element(nil, "a", 1),
+		bsoncore.AppendInt32Element(nil, "b", 2),
+		bsoncore.AppendInt32Element(nil, "a", 3),
+	)
+	type ab struct {
+		A int32
+		B int32
+	}
+
+	testCases := []struct {
+		name      string
+		policy    bsoncodec.DuplicateKeyPolicy
+		wantErr   bool
+		structVal ab
+		mapVal    M
+		dVal      D
+	}{
+		{"default", bsoncodec.DefaultDuplicateKeys, false,
+			ab{3, 2}, M{"a": int32(3), "b": int32(2)}, D{{"a", int32(1)}, {"b", int32(2)}, {"a", int32(3)}}},
+		{"error", bsoncodec.ErrorOnDuplicateKeys, true, ab{}, nil, nil},
+		{"first wins", bsoncodec.FirstDuplicateKeyWins, false,
+			ab{1, 2}, M{"a": int32(1), "b": int32(2)}, D{{"a", int32(1)}, {"b", int32(2)}}},
+		{"last wins", bsoncodec.LastDuplicateKeyWins, false,
+			ab{3, 2}, M{"a": int32(3), "b": int32(2)}, D{{"a", int32(3)}, {"b", int32(2)}}},
+		{"collect", bsoncodec.CollectDuplicateKeys, false,
+			ab{}, M{"a": A{int32(1), int32(3)}, "b": int32(2)}, D{{"a", A{int32(1), int32(3)}}, {"b", int32(2)}}},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			dc := bsoncodec.DecodeContext{Registry: DefaultRegistry, DuplicateKeyPolicy: tc.policy}
+
+			var s ab
+			err := UnmarshalWithContext(dc, data, &s)
+			if tc.wantErr || tc.policy == bsoncodec.CollectDuplicateKeys {
+				_, ok := err.(bsoncodec.DuplicateKeyError)
+				assert.True(t, ok, "expected DuplicateKeyError for struct, got %v", err)
+			} else {
+				assert.Nil(t, err, "UnmarshalWithContext error for struct: %v", err)
+				assert.Equal(t, tc.structVal, s, "expected struct %v, got %v", tc.structVal, s)
+			}
+
+			var m M
+			err = UnmarshalWithContext(dc, data, &m)
+			var d D
+			derr := UnmarshalWithContext(dc, data, &d)
+			if tc.wantErr {
+				_, ok := err.(bsoncodec.DuplicateKeyError)
+				assert.True(t, ok, "expected DuplicateKeyError for map, got %v", err)
+				_, ok = derr.(bsoncodec.DuplicateKeyError)
+				assert.True(t, ok, "expected DuplicateKeyError for D, got %v", derr)
+				return
+			}
+			assert.Nil(t, err, "UnmarshalWithContext error for map: %v", err)
+			assert.Equal(t, tc.mapVal, m, "expected map %v, got %v", tc.mapVal, m)
+			assert.Nil(t, derr, "UnmarshalWithContext error for D: %v", derr)
+			assert.Equal(t, tc.dVal, d, "expected D %v, got %v", tc.dVal, d)
+		})
+	}
+	t.Run("collect into typed map", func(t *testing.T) {
+		dc := bsoncodec.DecodeContext{Registry: DefaultRegistry, DuplicateKeyPolicy: bsoncodec.CollectDuplicateKeys}
+		var m map[string]int32
+		err := UnmarshalWithContext(dc, data, &m)
+		_, ok := err.(bsoncodec.DuplicateKeyError)
+		assert.True(t, ok, "expected DuplicateKeyError, got %v", err)
+	})
+}
+
+func TestUnmarshalNumericConversion(t *testing.T) {
+	type numbers struct {
+		I8  int8
+		U16 uint16
+		I   int64
+		F32 float32
+	}
+	overflow := bsoncore.BuildDocumentFromElements(nil,
+		bsoncore.AppendInt32Element(nil, "i8", 300),
+		bsoncore.AppendInt32Element(nil, "u16", -1),
+		bsoncore.AppendDoubleElement(nil, "i", 2.75),
+		bsoncore.AppendDoubleElement(nil, "f32", 1e300),
+	)
+	lossless := bsoncore.BuildDocumentFromElements(nil,
+		bsoncore.AppendInt32Element(nil, "i8", -128),
+		bsoncore.AppendInt64Element(nil, "u16", 65535),
+		bsoncore.AppendDoubleElement(nil, "i", 3),
+		bsoncore.AppendDoubleElement(nil, "f32", 0.5),
+	)
+
+	testCases := []struct {
+		name     string
+		policy   bsoncodec.NumericConversionPolicy
+		expected *numbers // nil if decoding overflow should fail
+	}{
+		{"default", bsoncodec.DefaultNumericConversion, nil},
+		{"error", bsoncodec.ErrorOnLossyConversion, nil},
+		{"saturate", bsoncodec.SaturateLossyConversion, &numbers{127, 0, 2, math.MaxFloat32}},
+		{"allow", bsoncodec.AllowLossyConversion, &numbers{44, 65535, 2, float32(math.Inf(1))}},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			dc := bsoncodec.DecodeContext{Registry: DefaultRegistry, NumericConversionPolicy: tc.policy}
+
+			var got numbers
+			err := UnmarshalWithContext(dc, lossless, &got)
+			assert.Nil(t, err, "UnmarshalWithContext error for lossless document: %v", err)
+			expected := numbers{-128, 65535, 3, 0.5}
+			assert.Equal(t, expected, got, "expected %v, got %v", expected, got)
+
+			got = numbers{}
+			err = UnmarshalWithContext(dc, overflow, &got)
+			if tc.expected == nil {
+				assert.NotNil(t, err, "expected UnmarshalWithContext error, got nil")
+				return
+			}
+			assert.Nil(t, err, "UnmarshalWithContext error: %v", err)
+			assert.Equal(t, *tc.expected, got, "expected %v, got %v", *tc.expected, got)
+		})
+	}
+	t.Run("error ignores truncate", func(t *testing.T) {
+		doc := bsoncore.BuildDocumentFromElements(nil, bsoncore.AppendDoubleElement(nil, "i", 2.5))
+		var got struct {
+			I int64 `bson:"i,truncate"`
+		}
+
+		err := UnmarshalWithContext(bsoncodec.DecodeContext{Registry: DefaultRegistry}, doc, &got)
+		assert.Nil(t, err, "UnmarshalWithContext error with default policy: %v", err)
+		assert.Equal(t, int64(2), got.I, "expected 2, got %v", got.I)
+
+		dc := bsoncodec.DecodeContext{Registry: DefaultRegistry, NumericConversionPolicy: bsoncodec.ErrorOnLossyConversion}
+		err = UnmarshalWithContext(dc, doc, &got)
+		de, ok := err.(*bsoncodec.DecodeError)
+		assert.True(t, ok, "expected DecodeError, got %v", err)
+		_, ok = de.Unwrap().(bsoncodec.NumericConversionError)
+		assert.True(t, ok, "expected NumericConversionError, got %v", de.Unwrap())
+	})
+}
+
+func TestUnmarshalCapacityHints(t *testing.T) {
+	type values struct {
+		A []int32
+		M map[string]int32
+		D D
+	}
+	aidx, arr := bsoncore.AppendArrayStart(nil)
+	didx, embedded := bsoncore.AppendDocumentStart(nil)
+	for i := 0; i < 100; i++ {
+		arr = bsoncore.AppendInt32Element(arr, strconv.Itoa(i), int32(i))
+		embedded = bsoncore.AppendInt32Element(embedded, "k"+strconv.Itoa(i), int32(i))
+	}
+	arr, _ = bsoncore.AppendArrayEnd(arr, aidx)
+	embedded, _ = bsoncore.AppendDocumentEnd(embedded, didx)
+	doc := bsoncore.BuildDocumentFromElements(nil,
+		bsoncore.AppendArrayElement(nil, "a", arr),
+		bsoncore.AppendDocumentElement(nil, "m", embedded),
+		bsoncore.AppendDocumentElement(nil, "d", embedded),
+	)
+
+	var expected values
+	err := Unmarshal(doc, &expected)
+	assert.Nil(t, err, "Unmarshal error: %v", err)
+	assert.Equal(t, 100, len(expected.A), "expected 100 array elements, got %v", len(expected.A))
+
+	testCases := []struct {
+		name string
+		dc   bsoncodec.DecodeContext
+	}{
+		{"hints", bsoncodec.DecodeContext{Registry: DefaultRegistry, SliceCapacityHint: 8, MapCapacityHint: 8}},
+		{"negative hints", bsoncodec.DecodeContext{Registry: DefaultRegistry, SliceCapacityHint: -1, MapCapacityHint: -1}},
+		{"size from BSON", bsoncodec.DecodeContext{Registry: DefaultRegistry, SizeFromBSON: true}},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			var got values
+			err := UnmarshalWithContext(tc.dc, doc, &got)
+			assert.Nil(t, err, "UnmarshalWithContext error: %v", err)
+			assert.Equal(t, expected, got, "expected %v, got %v", expected, got)
+		})
+	}
+	t.Run("size from BSON allocates less", func(t *testing.T) {
+		allocs := func(dc bsoncodec.DecodeContext) float64 {
+			return testing.AllocsPerRun(10, func() {
+				var got values
+				_ = UnmarshalWithContext(dc, doc, &got)
+			})
+		}
+		unsized := allocs(bsoncodec.DecodeContext{Registry: DefaultRegistry})
+		sized := allocs(bsoncodec.DecodeContext{Registry: DefaultRegistry, SizeFromBSON: true})
+		assert.True(t, sized < unsized, "expected fewer than %v allocations, got %v", unsized, sized)
+	})
+}
+
+func TestUnmarshalStringInterner(t *testing.T) {
+	doc := bsoncore.BuildDocumentFromElements(nil,
+		bsoncore.AppendStringElement(nil, "status", "active"),
+		bsoncore.AppendDocumentElement(nil, "m", bsoncore.BuildDocumentFromElements(nil,
+			bsoncore.AppendStringElement(nil, "kind", "user"),
+		)),
+		bsoncore.AppendArrayElement(nil, "tags", bsoncore.BuildArray(nil,
+			bsoncore.Value{Type: bsontype.String, Data: bsoncore.AppendString(nil, "active")},
+		)),
+	)
+	var got struct {
+		Status string
+		M      M
+		Tags   []string
+	}
+
+	pool := bsoncodec.NewStringPool(0, 0)
+	dc := bsoncodec.DecodeContext{Registry: DefaultRegistry, StringInterner: pool}
+	err := UnmarshalWithContext(dc, doc, &got)
+	assert.Nil(t, err, "UnmarshalWithContext error: %v", err)
+	assert.Equal(t, "active", got.Status, "expected status active, got %v", got.Status)
+	assert.Equal(t, M{"kind": "user"}, got.M, "expected m {kind: user}, got %v", got.M)
+	assert.Equal(t, []string{"active"}, got.Tags, "expected tags [active], got %v", got.Tags)
+	assert.Equal(t, 2, pool.Len(), "expected 2 interned strings, got %d", pool.Len())
+}
+
+func TestUnmarshalStrict(t *testing.T) {
+	// {"d": {"b": true}} with an invalid boolean byte in the embedded document.
+	invalid := bsoncore.BuildDocumentFromElements(nil,
+		bsoncore.AppendDocumentElement(nil, "d", bsoncore.BuildDocumentFromElements(nil,
+			bsoncore.AppendBooleanElement(nil, "b", true),
+		)),
+	)
+	invalid[len(invalid)-3] = 0x02
+	type rawStruct struct {
+		D Raw
+	}
+
+	t.Run("valid", func(t *testing.T) {
+		doc := bsoncore.BuildDocumentFromElements(nil,
+			bsoncore.AppendStringElement(nil, "s", "foo"),
+			bsoncore.AppendDocumentElement(nil, "d", bsoncore.BuildDocumentFromElements(nil,
+				bsoncore.AppendInt32Element(nil, "i", 1),
+			)),
+		)
+		var expected, got D
+		err := Unmarshal(doc, &expected)
+		assert.Nil(t, err, "Unmarshal error: %v", err)
+		err = UnmarshalStrict(doc, &got)
+		assert.Nil(t, err, "UnmarshalStrict error: %v", err)
+		assert.Equal(t, expected, got, "expected %v, got %v", expected, got)
+	})
+	t.Run("invalid raw value", func(t *testing.T) {
+		var got rawStruct
+		err := Unmarshal(invalid, &got)
+		assert.Nil(t, err, "Unmarshal error: %v", err)
+
+		err = UnmarshalStrict(invalid, &got)
+		assert.NotNil(t, err, "expected UnmarshalStrict error, got nil")
+	})
+	t.Run("with context", func(t *testing.T) {
+		dc := bsoncodec.DecodeContext{Registry: DefaultRegistry, DuplicateKeyPolicy: bsoncodec.ErrorOnDuplicateKeys}
+		doc := bsoncore.BuildDocumentFromElements(nil,
+			bsoncore.AppendInt32Element(nil, "a", 1),
+			bsoncore.AppendInt32Element(nil, "a", 2),
+		)
+		var got M
+		err := UnmarshalStrictWithContext(dc, doc, &got)
+		_, ok := err.(bsoncodec.DuplicateKeyError)
+		assert.True(t, ok, "expected DuplicateKeyError, got %v", err)
+	})
+}
@@ -8,6 +8,8 @@ package event // import "go.mongodb.org/mongo-driver/event"
 
 import (
 	"context"
+	"crypto/x509"
+	"time"
 
 	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/bson/primitive"
@@ -22,6 +24,16 @@ type CommandStartedEvent struct {
 	CommandName  string
 	RequestID    int64
 	ConnectionID string
+	// Options contains the maxTimeMS, batchSize, hint, and collation fields that were sent with the command after all
+	// option levels were merged, which is useful to check which value of an option took effect. For aggregate commands,
+	// the batchSize is taken from the cursor document. It is empty if none of the fields were sent or the command is
+	// redacted.
+	Options bson.Raw
+	// OperationID is generated by the driver for each operation and is shared by the events of all of the commands,
+	// server selections, and connection checkouts performed by the operation, including its retries. CorrelationID is
+	// the ID set by the application with mongo.WithCorrelationID on the Context of the operation.
+	OperationID   int64
+	CorrelationID string
 }
 
 // CommandFinishedEvent represents a generic command finishing.
@@ -30,6 +42,8 @@ type CommandFinishedEvent struct {
 	CommandName   string
 	RequestID     int64
 	ConnectionID  string
+	OperationID   int64  // The same as the OperationID of the corresponding CommandStartedEvent
+	CorrelationID string // The same as the CorrelationID of the corresponding CommandStartedEvent
 }
 
 // CommandSucceededEvent represents an event generated when a command's execution succeeds.
@@ -87,6 +101,19 @@ type PoolEvent struct {
 	ConnectionID uint64              `json:"connectionId"`
 	PoolOptions  *MonitorPoolOptions `json:"options"`
 	Reason       string              `json:"reason"`
+	// ServiceID is only set for PoolCleared events in load balanced mode and is the service ID of the connections that
+	// were cleared.
+	ServiceID *primitive.ObjectID `json:"serviceId"`
+	// PeerCertificates and PeerCertificatesNotAfter are only set for ConnectionReady events on TLS connections.
+	// PeerCertificates is the certificate chain presented by the server and PeerCertificatesNotAfter is the earliest
+	// expiration time of any certificate in that chain.
+	PeerCertificates         []*x509.Certificate `json:"-"`
+	PeerCertificatesNotAfter time.Time           `json:"-"`
+	// OperationID and CorrelationID are only set for GetSucceeded and GetFailed events and identify the operation that
+	// checked out the connection (see CommandStartedEvent). OperationID is 0 if the connection was not checked out by
+	// an operation.
+	OperationID   int64  `json:"operationId"`
+	CorrelationID string `json:"correlationId"`
 }
 
 // PoolMonitor is a function that allows the user to gain access to events occurring in the pool
@@ -131,6 +158,43 @@ type TopologyClosedEvent struct {
 	TopologyID primitive.ObjectID // A unique identifier for the topology this server is a part of
 }
 
+// TopologyDegradedEvent is an event generated when the topology loses its last writable server after previously
+// having had one.
+type TopologyDegradedEvent struct {
+	TopologyID          primitive.ObjectID // A unique identifier for the topology this server is a part of
+	PreviousDescription description.Topology
+	NewDescription      description.Topology
+}
+
+// TopologyRecoveredEvent is an event generated when a degraded topology has a writable server again.
+type TopologyRecoveredEvent struct {
+	TopologyID          primitive.ObjectID // A unique identifier for the topology this server is a part of
+	Downtime            time.Duration      // The time elapsed since the corresponding TopologyDegradedEvent
+	PreviousDescription description.Topology
+	NewDescription      description.Topology
+}
+
+// ServerSelectionSucceededEvent is an event generated when a server is selected. OperationID and CorrelationID identify
+// the operation that the server was selected for (see CommandStartedEvent). OperationID is 0 if the server was not
+// selected for an operation.
+type ServerSelectionSucceededEvent struct {
+	TopologyID    primitive.ObjectID // A unique identifier for the topology the server was selected from
+	Address       address.Address
+	DurationNanos int64
+	OperationID   int64
+	CorrelationID string
+}
+
+// ServerSelectionFailedEvent is an event generated when server selection fails, e.g. because it timed out.
+// OperationID and CorrelationID are the same as for ServerSelectionSucceededEvent.
+type ServerSelectionFailedEvent struct {
+	TopologyID    primitive.ObjectID // A unique identifier for the topology the server was selected from
+	Failure       error
+	DurationNanos int64
+	OperationID   int64
+	CorrelationID string
+}
+
 // ServerHeartbeatStartedEvent is an event generated when the heartbeat is started.
 type ServerHeartbeatStartedEvent struct {
 	ConnectionID string // The address this heartbeat was sent to with a unique identifier
@@ -141,16 +205,48 @@ type ServerHeartbeatStartedEvent struct {
 type ServerHeartbeatSucceededEvent struct {
 	DurationNanos int64
 	Reply         description.Server
-	ConnectionID  string // The address this heartbeat was sent to with a unique identifier
-	Awaited       bool   // If this heartbeat was awaitable
+	ConnectionID  string   // The address this heartbeat was sent to with a unique identifier
+	Awaited       bool     // If this heartbeat was awaitable
+	RTT           RTTStats // The round trip time statistics of the server after this heartbeat
+}
+
+// RTTStats contains round trip time statistics for a server. The statistics are computed from the samples taken by
+// the server monitor, which include the duration of connection handshakes and of the isMaster commands sent by the RTT
+// monitor. Streaming heartbeats are not sampled because they block on the server. The samples are discarded when a
+// heartbeat fails.
+type RTTStats struct {
+	// The exponentially weighted moving average of all samples since the samples were last discarded.
+	Average time.Duration
+
+	// The minimum and 90th percentile of the most recent samples.
+	Min time.Duration
+	P90 time.Duration
+
+	// The number of recent samples used to compute Min and P90. All statistics are zero if this is zero.
+	Samples int
 }
 
+// HeartbeatFailureReason classifies the cause of a failed heartbeat.
+type HeartbeatFailureReason string
+
+// reasons for a failed heartbeat
+const (
+	HeartbeatFailureUnknown HeartbeatFailureReason = "unknown"
+	HeartbeatFailureDNS     HeartbeatFailureReason = "dns"     // the server's host name could not be resolved
+	HeartbeatFailureConnect HeartbeatFailureReason = "connect" // the TCP connection could not be established
+	HeartbeatFailureTLS     HeartbeatFailureReason = "tls"     // the TLS handshake or certificate verification failed
+	HeartbeatFailureTimeout HeartbeatFailureReason = "timeout" // the heartbeat or connection establishment timed out
+	HeartbeatFailureNetwork HeartbeatFailureReason = "network" // an established connection failed to read or write
+	HeartbeatFailureCommand HeartbeatFailureReason = "command" // the server responded with an error
+)
+
 // ServerHeartbeatFailedEvent is an event generated when the heartbeat fails.
 type ServerHeartbeatFailedEvent struct {
 	DurationNanos int64
 	Failure       error
-	ConnectionID  string // The address this heartbeat was sent to with a unique identifier
-	Awaited       bool   // If this heartbeat was awaitable
+	Reason        HeartbeatFailureReason // The classified cause of Failure
+	ConnectionID  string                 // The address this heartbeat was sent to with a unique identifier
+	Awaited       bool                   // If this heartbeat was awaitable
 }
 
 // ServerMonitor represents a monitor that is triggered for different server events. The client
@@ -166,7 +262,85 @@ type ServerMonitor struct {
 	TopologyDescriptionChanged func(*TopologyDescriptionChangedEvent)
 	TopologyOpening            func(*TopologyOpeningEvent)
 	TopologyClosed             func(*TopologyClosedEvent)
-	ServerHeartbeatStarted     func(*ServerHeartbeatStartedEvent)
-	ServerHeartbeatSucceeded   func(*ServerHeartbeatSucceededEvent)
-	ServerHeartbeatFailed      func(*ServerHeartbeatFailedEvent)
+	// TopologyDegraded and TopologyRecovered are called when the topology is locked, so the callbacks
+	// should not attempt any operation that requires server selection on the same client.
+	TopologyDegraded         func(*TopologyDegradedEvent)
+	TopologyRecovered        func(*TopologyRecoveredEvent)
+	ServerHeartbeatStarted   func(*ServerHeartbeatStartedEvent)
+	ServerHeartbeatSucceeded func(*ServerHeartbeatSucceededEvent)
+	ServerHeartbeatFailed    func(*ServerHeartbeatFailedEvent)
+	ServerSelectionSucceeded func(*ServerSelectionSucceededEvent)
+	ServerSelectionFailed    func(*ServerSelectionFailedEvent)
+}
+
+// CursorLeakedEvent is an event generated when a cursor has not been closed or exhausted within the timeout configured
+// with the CursorLeakTimeout client option. A leaked cursor keeps its resources on the server until the server times
+// it out.
+type CursorLeakedEvent struct {
+	// The ID of the cursor on the server.
+	CursorID int64
+	// The time at which the cursor was created.
+	CreatedAt time.Time
+	// The timeout after which the cursor was reported.
+	Timeout time.Duration
+	// The stack trace of the goroutine that created the cursor.
+	Stack string
+}
+
+// CursorMonitor represents a monitor that is triggered for cursor events. Events are only published if cursor leak
+// detection is enabled with the CursorLeakTimeout client option.
+type CursorMonitor struct {
+	Leaked func(*CursorLeakedEvent)
+}
+
+// OutstandingSession describes an explicit session that has been started but not ended.
+type OutstandingSession struct {
+	// The ID of the server session used by the session.
+	SessionID bson.Raw
+	// The time at which the session was started.
+	StartedAt time.Time
+	// The stack trace of the goroutine that started the session.
+	Stack string
+}
+
+// SessionThresholdExceededEvent is an event generated when the number of server sessions checked out of a client's
+// session pool grows beyond the threshold configured with the SessionLeakThreshold client option.
+type SessionThresholdExceededEvent struct {
+	// The number of checked out server sessions. This includes the implicit sessions used by in-progress operations and
+	// open cursors.
+	CheckedOut int
+	// The configured threshold.
+	Threshold int
+	// The explicit sessions that have been started but not ended.
+	Sessions []OutstandingSession
+}
+
+// strings for session monitoring types
+const (
+	SessionCreated   = "ServerSessionCreated"   // the pool had no unexpired session, so a new one was created
+	SessionReused    = "ServerSessionReused"    // an unexpired session was checked out of the pool
+	SessionEnded     = "ServerSessionEnded"     // the client session using the server session was ended
+	SessionExpired   = "ServerSessionExpired"   // the session was removed from the pool because it expired
+	SessionDiscarded = "ServerSessionDiscarded" // the session was not returned to the pool after a network error
+)
+
+// SessionEvent is an event generated during the lifecycle of a server session in a client's session pool.
+type SessionEvent struct {
+	Type      string
+	SessionID bson.Raw
+	// Implicit is only set for SessionEnded events and reports whether the ended session was an implicit session
+	// started by the driver for a single operation or cursor.
+	Implicit bool
+	// CheckedOut and Pooled are the number of server sessions checked out of the pool and the number of server sessions
+	// waiting in the pool when the event occurred.
+	CheckedOut int
+	Pooled     int
+}
+
+// SessionMonitor represents a monitor that is triggered for session events. ThresholdExceeded events are only
+// published if session leak detection is enabled with the SessionLeakThreshold client option. Event is called for each
+// SessionEvent, without holding the lock of the session pool.
+type SessionMonitor struct {
+	ThresholdExceeded func(*SessionThresholdExceededEvent)
+	Event             func(*SessionEvent)
 }
@@ -0,0 +1,98 @@
+// Copyright (C) MongoDB, Inc. 2021-present.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
+
+package mongo
+
+import (
+	"errors"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// Accumulator represents a $accumulator aggregation operator, which computes the result of a $group stage with
+// JavaScript functions. The JavaScript code is sent as BSON JavaScript values, so it does not need to be escaped. An
+// Accumulator can be used as a value anywhere in an aggregation pipeline because it marshals to a
+// {"$accumulator": {...}} document. This operator is only valid for MongoDB versions >= 4.4. See
+// https://docs.mongodb.com/manual/reference/operator/aggregation/accumulator/ for more information.
+type Accumulator struct {
+	// The function used to initialize the state. It is required.
+	Init string
+
+	// The arguments passed to the Init function. The default value is nil, meaning no arguments are passed.
+	InitArgs []interface{}
+
+	// The function used to accumulate documents into the state. It is required.
+	Accumulate string
+
+	// The arguments passed to the Accumulate function in addition to the state. These are typically expressions
+	// referencing fields of the input documents, e.g. "$price".
+	AccumulateArgs []interface{}
+
+	// The function used to merge two internal states. It is required.
+	Merge string
+
+	// The function used to compute the final result from the state. The default value is "", meaning the state is
+	// returned as the result.
+	Finalize string
+}
+
+// MarshalBSON implements the bson.Marshaler interface.
+func (a Accumulator) MarshalBSON() ([]byte, error) {
+	if a.Init == "" || a.Accumulate == "" || a.Merge == "" {
+		return nil, errors.New("the Init, Accumulate, and Merge functions of an Accumulator are required")
+	}
+
+	acc := bson.D{{"init", primitive.JavaScript(a.Init)}}
+	if a.InitArgs != nil {
+		acc = append(acc, bson.E{"initArgs", bson.A(a.InitArgs)})
+	}
+	acc = append(acc,
+		bson.E{"accumulate", primitive.JavaScript(a.Accumulate)},
+		bson.E{"accumulateArgs", javaScriptArgs(a.AccumulateArgs)},
+		bson.E{"merge", primitive.JavaScript(a.Merge)},
+	)
+	if a.Finalize != "" {
+		acc = append(acc, bson.E{"finalize", primitive.JavaScript(a.Finalize)})
+	}
+	acc = append(acc, bson.E{"lang", "js"})
+
+	return bson.Marshal(bson.D{{"$accumulator", acc}})
+}
+
+// Function represents a $function aggregation operator, which computes a value with a JavaScript function. The
+// JavaScript code is sent as a BSON JavaScript value, so it does not need to be escaped. A Function can be used as an
+// aggregation expression because it marshals to a {"$function": {...}} document. This operator is only valid for
+// MongoDB versions >= 4.4. See https://docs.mongodb.com/manual/reference/operator/aggregation/function/ for more
+// information.
+type Function struct {
+	// The function definition. It is required.
+	Body string
+
+	// The arguments passed to the function. The default value is nil, meaning no arguments are passed.
+	Args []interface{}
+}
+
+// MarshalBSON implements the bson.Marshaler interface.
+func (f Function) MarshalBSON() ([]byte, error) {
+	if f.Body == "" {
+		return nil, errors.New("the Body of a Function is required")
+	}
+
+	return bson.Marshal(bson.D{{"$function", bson.D{
+		{"body", primitive.JavaScript(f.Body)},
+		{"args", javaScriptArgs(f.Args)},
+		{"lang", "js"},
+	}}})
+}
+
+// javaScriptArgs returns args as an array, which is required by the server even if there are no arguments.
+func javaScriptArgs(args []interface{}) bson.A {
+	if args == nil {
+		return bson.A{}
+	}
+	return bson.A(args)
+}
@@ -0,0 +1,65 @@
+// Copyright (C) MongoDB, Inc. 2021-present.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
+
+package mongo
+
+import (
+	"context"
+	"testing"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/internal/testutil/assert"
+)
+
+func TestAccumulator(t *testing.T) {
+	t.Run("marshal accumulator", func(t *testing.T) {
+		acc := Accumulator{
+			Init:           `function() { return {count: 0, label: "it's \"quoted\""}; }`,
+			Accumulate:     "function(state, x) { state.count += x; return state; }",
+			AccumulateArgs: []interface{}{"$x"},
+			Merge:          "function(a, b) { a.count += b.count; return a; }",
+		}
+		got, err := bson.Marshal(bson.D{{"total", acc}})
+		assert.Nil(t, err, "Marshal error: %v", err)
+
+		expected, err := bson.Marshal(bson.D{{"total", bson.D{{"$accumulator", bson.D{
+			{"init", primitive.JavaScript(acc.Init)},
+			{"accumulate", primitive.JavaScript(acc.Accumulate)},
+			{"accumulateArgs", bson.A{"$x"}},
+			{"merge", primitive.JavaScript(acc.Merge)},
+			{"lang", "js"},
+		}}}}})
+		assert.Nil(t, err, "Marshal error: %v", err)
+		assert.Equal(t, bson.Raw(expected), bson.Raw(got), "expected %v, got %v", bson.Raw(expected), bson.Raw(got))
+	})
+	t.Run("marshal function", func(t *testing.T) {
+		got, err := bson.Marshal(bson.D{{"len", Function{Body: "function(s) { return s.length; }"}}})
+		assert.Nil(t, err, "Marshal error: %v", err)
+
+		expected, err := bson.Marshal(bson.D{{"len", bson.D{{"$function", bson.D{
+			{"body", primitive.JavaScript("function(s) { return s.length; }")},
+			{"args", bson.A{}},
+			{"lang", "js"},
+		}}}}})
+		assert.Nil(t, err, "Marshal error: %v", err)
+		assert.Equal(t, bson.Raw(expected), bson.Raw(got), "expected %v, got %v", bson.Raw(expected), bson.Raw(got))
+	})
+	t.Run("missing functions", func(t *testing.T) {
+		_, err := bson.Marshal(bson.D{{"total", Accumulator{Init: "function() { return 0; }"}}})
+		assert.NotNil(t, err, "expected Marshal error for Accumulator, got nil")
+
+		_, err = bson.Marshal(bson.D{{"len", Function{}}})
+		assert.NotNil(t, err, "expected Marshal error for Function, got nil")
+	})
+	t.Run("invalid output field", func(t *testing.T) {
+		coll := setupColl("accumulator")
+		for _, field := range []string{"", "_id"} {
+			_, err := coll.AggregateWithAccumulator(context.Background(), nil, nil, field, Accumulator{})
+			assert.NotNil(t, err, "expected AggregateWithAccumulator error for output field %q, got nil", field)
+		}
+	})
+}
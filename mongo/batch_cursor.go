@@ -2,6 +2,7 @@ package mongo
 
 import (
 	"context"
+	"time"
 
 	"go.mongodb.org/mongo-driver/x/bsonx/bsoncore"
 	"go.mongodb.org/mongo-driver/x/mongo/driver"
@@ -40,3 +41,13 @@ type changeStreamCursor interface {
 	// KillCursor kills cursor on server without closing batch cursor
 	KillCursor(context.Context) error
 }
+
+// awaitBatchCursor is the interface implemented by batch cursors that allow the await time of getMore commands to be
+// changed and report statistics about them.
+type awaitBatchCursor interface {
+	// SetMaxTime sets the maxTimeMS of subsequent getMore commands.
+	SetMaxTime(time.Duration)
+
+	// GetMoreStats returns statistics about the getMore commands sent by the cursor.
+	GetMoreStats() driver.GetMoreStats
+}
@@ -0,0 +1,108 @@
+// Copyright (C) MongoDB, Inc. 2021-present.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
+
+package mongo
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"go.mongodb.org/mongo-driver/mongo/readpref"
+)
+
+// DeleteManyInBatches deletes the documents in the collection that match filter like DeleteMany, but in batches of a
+// bounded size with optional pauses between them, so that purging a large number of documents does not flood the
+// oplog and the replication of the secondaries or evict the working set from the cache.
+//
+// Each batch is found with a query on the primary for the _id of the first matching documents in ascending order of
+// _id, which are then deleted with a filter on both the _id values and the original filter, so documents that change
+// to no longer match the filter in the meantime are not deleted. The operation ends when a batch has fewer documents
+// than the batch size. Documents that are inserted while the operation runs are deleted if they match the filter.
+//
+// If an error occurs, the returned DeleteResult contains the number of documents deleted by the previous batches.
+//
+// The opts parameter can be used to specify the batch size, the pause between batches, and a progress callback (see
+// the options.BatchedDeleteOptions documentation).
+func (coll *Collection) DeleteManyInBatches(ctx context.Context, filter interface{},
+	opts ...*options.BatchedDeleteOptions) (*DeleteResult, error) {
+
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	bo := options.MergeBatchedDeleteOptions(opts...)
+	batchSize := options.DefaultDeleteBatchSize
+	if bo.BatchSize != nil {
+		batchSize = *bo.BatchSize
+	}
+	if batchSize <= 0 {
+		return nil, fmt.Errorf("batch size must be positive, got %d", batchSize)
+	}
+
+	f, err := transformBsoncoreDocument(coll.registry, filter, true, "filter")
+	if err != nil {
+		return nil, err
+	}
+
+	findOpts := options.Find().
+		SetSort(bson.D{{"_id", 1}}).
+		SetProjection(bson.D{{"_id", 1}}).
+		SetLimit(int64(batchSize)).
+		SetBatchSize(batchSize).
+		SetReadPreference(readpref.Primary()).
+		SetUnbounded(true)
+	if bo.Hint != nil {
+		findOpts.SetHint(bo.Hint)
+	}
+
+	res := &DeleteResult{}
+	for {
+		cursor, err := coll.Find(ctx, bson.Raw(f), findOpts)
+		if err != nil {
+			return res, err
+		}
+		var docs []struct {
+			ID bson.RawValue `bson:"_id"`
+		}
+		if err = cursor.All(ctx, &docs); err != nil {
+			return res, err
+		}
+		if len(docs) == 0 {
+			return res, nil
+		}
+
+		ids := make(bson.A, len(docs))
+		for i, doc := range docs {
+			ids[i] = doc.ID
+		}
+		batchFilter := bson.D{{"$and", bson.A{bson.Raw(f), bson.D{{"_id", bson.D{{"$in", ids}}}}}}}
+		deleted, err := coll.DeleteMany(ctx, batchFilter)
+		if err != nil {
+			return res, err
+		}
+		res.DeletedCount += deleted.DeletedCount
+		res.Raw = deleted.Raw
+		if bo.Progress != nil {
+			bo.Progress(res.DeletedCount)
+		}
+
+		if len(docs) < int(batchSize) {
+			return res, nil
+		}
+		if bo.Pause != nil && *bo.Pause > 0 {
+			timer := time.NewTimer(*bo.Pause)
+			select {
+			case <-timer.C:
+			case <-ctx.Done():
+				timer.Stop()
+				return res, ctx.Err()
+			}
+		}
+	}
+}
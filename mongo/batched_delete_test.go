@@ -0,0 +1,95 @@
+// Copyright (C) MongoDB, Inc. 2021-present.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
+
+package mongo_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/internal/testutil/assert"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/mongotest"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+func TestDeleteManyInBatches(t *testing.T) {
+	d := mongotest.New()
+	client, err := d.NewClient()
+	assert.Nil(t, err, "NewClient error: %v", err)
+	defer func() { _ = client.Disconnect(context.Background()) }()
+	coll := client.Database("db").Collection("events")
+	filter := bson.D{{"expired", true}}
+
+	t.Run("batches", func(t *testing.T) {
+		d.Reset()
+		d.AddReplies(
+			mongotest.CursorReply("db.events", bson.D{{"_id", 1}}, bson.D{{"_id", 2}}),
+			mongotest.SuccessReply(bson.E{"n", 2}),
+			mongotest.CursorReply("db.events", bson.D{{"_id", 3}}),
+			mongotest.SuccessReply(bson.E{"n", 1}),
+		)
+
+		var progress []int64
+		opts := options.BatchedDelete().SetBatchSize(2).SetPause(time.Millisecond).
+			SetProgress(func(deleted int64) { progress = append(progress, deleted) })
+		res, err := coll.DeleteManyInBatches(context.Background(), filter, opts)
+		assert.Nil(t, err, "DeleteManyInBatches error: %v", err)
+		assert.Equal(t, int64(3), res.DeletedCount, "expected 3 documents deleted, got %v", res.DeletedCount)
+		assert.Equal(t, []int64{2, 3}, progress, "expected progress [2 3], got %v", progress)
+
+		cmds := d.Commands()
+		assert.Equal(t, 4, len(cmds), "expected 4 commands, got %v", len(cmds))
+		find := cmds[0].Document
+		assert.Equal(t, int64(2), find.Lookup("limit").Int64(), "expected limit 2, got %v", find.Lookup("limit"))
+		sort := find.Lookup("sort").Document()
+		assert.Equal(t, int32(1), sort.Lookup("_id").Int32(), "expected sort on _id, got %v", sort)
+
+		var deleteCmd struct {
+			Deletes []struct {
+				Q     bson.D `bson:"q"`
+				Limit int32  `bson:"limit"`
+			} `bson:"deletes"`
+		}
+		err = bson.Unmarshal(cmds[1].Document, &deleteCmd)
+		assert.Nil(t, err, "Unmarshal error: %v", err)
+		expected := bson.D{{"$and", bson.A{
+			bson.D{{"expired", true}},
+			bson.D{{"_id", bson.D{{"$in", bson.A{int32(1), int32(2)}}}}},
+		}}}
+		assert.Equal(t, expected, deleteCmd.Deletes[0].Q, "expected filter %v, got %v", expected, deleteCmd.Deletes[0].Q)
+		assert.Equal(t, int32(0), deleteCmd.Deletes[0].Limit, "expected limit 0, got %v", deleteCmd.Deletes[0].Limit)
+	})
+	t.Run("no matching documents", func(t *testing.T) {
+		d.Reset()
+		d.AddReplies(mongotest.CursorReply("db.events"))
+
+		res, err := coll.DeleteManyInBatches(context.Background(), filter)
+		assert.Nil(t, err, "DeleteManyInBatches error: %v", err)
+		assert.Equal(t, int64(0), res.DeletedCount, "expected no documents deleted, got %v", res.DeletedCount)
+		assert.Equal(t, 1, len(d.Commands()), "expected 1 command, got %v", len(d.Commands()))
+	})
+	t.Run("partial result on error", func(t *testing.T) {
+		d.Reset()
+		d.AddReplies(
+			mongotest.CursorReply("db.events", bson.D{{"_id", 1}}),
+			mongotest.SuccessReply(bson.E{"n", 1}),
+			mongotest.ErrorReply(2, "bad query"),
+		)
+
+		res, err := coll.DeleteManyInBatches(context.Background(), filter, options.BatchedDelete().SetBatchSize(1))
+		assert.NotNil(t, err, "expected error, got nil")
+		assert.Equal(t, int64(1), res.DeletedCount, "expected 1 document deleted, got %v", res.DeletedCount)
+	})
+	t.Run("invalid arguments", func(t *testing.T) {
+		_, err := coll.DeleteManyInBatches(context.Background(), filter, options.BatchedDelete().SetBatchSize(0))
+		assert.NotNil(t, err, "expected error for zero batch size, got nil")
+		_, err = coll.DeleteManyInBatches(context.Background(), nil)
+		assert.Equal(t, mongo.ErrNilDocument, err, "expected error %v, got %v", mongo.ErrNilDocument, err)
+	})
+}
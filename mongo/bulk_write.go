@@ -9,6 +9,7 @@ package mongo
 import (
 	"context"
 
+	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/bson/bsoncodec"
 	"go.mongodb.org/mongo-driver/mongo/description"
 	"go.mongodb.org/mongo-driver/mongo/options"
@@ -29,6 +30,8 @@ type bulkWriteBatch struct {
 type bulkWrite struct {
 	ordered                  *bool
 	bypassDocumentValidation *bool
+	comment                  interface{}
+	let                      interface{}
 	models                   []WriteModel
 	session                  *session.Client
 	collection               *Collection
@@ -119,6 +122,7 @@ func (bw *bulkWrite) runBatch(ctx context.Context, batch bulkWriteBatch) (BulkWr
 			batchErr.WriteConcernError = convertDriverWriteConcernError(writeErr.WriteConcernError)
 		}
 		batchRes.InsertedCount = int64(res.N)
+		batchRes.RawReplies = rawReplies(res.Replies)
 	case *DeleteOneModel, *DeleteManyModel:
 		res, err := bw.runDelete(ctx, batch)
 		if err != nil {
@@ -131,6 +135,7 @@ func (bw *bulkWrite) runBatch(ctx context.Context, batch bulkWriteBatch) (BulkWr
 			batchErr.WriteConcernError = convertDriverWriteConcernError(writeErr.WriteConcernError)
 		}
 		batchRes.DeletedCount = int64(res.N)
+		batchRes.RawReplies = rawReplies(res.Replies)
 	case *ReplaceOneModel, *UpdateOneModel, *UpdateManyModel:
 		res, err := bw.runUpdate(ctx, batch)
 		if err != nil {
@@ -145,6 +150,7 @@ func (bw *bulkWrite) runBatch(ctx context.Context, batch bulkWriteBatch) (BulkWr
 		batchRes.MatchedCount = int64(res.N)
 		batchRes.ModifiedCount = int64(res.NModified)
 		batchRes.UpsertedCount = int64(len(res.Upserted))
+		batchRes.RawReplies = rawReplies(res.Replies)
 		for _, upsert := range res.Upserted {
 			batchRes.UpsertedIDs[int64(batch.indexes[upsert.Index])] = upsert.ID
 		}
@@ -176,6 +182,7 @@ func (bw *bulkWrite) runInsert(ctx context.Context, batch bulkWriteBatch) (opera
 		docs[i] = doc
 		i++
 	}
+	bw.collection.checkTTLFields(docs)
 
 	op := operation.NewInsert(docs...).
 		Session(bw.session).WriteConcern(bw.writeConcern).CommandMonitor(bw.collection.client.monitor).
@@ -189,6 +196,13 @@ func (bw *bulkWrite) runInsert(ctx context.Context, batch bulkWriteBatch) (opera
 	if bw.ordered != nil {
 		op = op.Ordered(*bw.ordered)
 	}
+	if bw.comment != nil {
+		comment, err := transformValue(bw.collection.registry, bw.comment, true, "comment")
+		if err != nil {
+			return operation.InsertResult{}, err
+		}
+		op = op.Comment(comment)
+	}
 
 	retry := driver.RetryNone
 	if bw.collection.client.retryWrites && batch.canRetry {
@@ -207,15 +221,19 @@ func (bw *bulkWrite) runDelete(ctx context.Context, batch bulkWriteBatch) (opera
 	var hasHint bool
 
 	for _, model := range batch.models {
-		var doc bsoncore.Document
+		var doc, filter bsoncore.Document
 		var err error
 
 		switch converted := model.(type) {
 		case *DeleteOneModel:
-			doc, err = createDeleteDoc(converted.Filter, converted.Collation, converted.Hint, true, bw.collection.registry)
+			if filter, err = bw.collection.transformFilter(ctx, converted.Filter); err == nil {
+				doc, err = createDeleteDoc(filter, converted.Collation, converted.Hint, true, bw.collection.registry)
+			}
 			hasHint = hasHint || (converted.Hint != nil)
 		case *DeleteManyModel:
-			doc, err = createDeleteDoc(converted.Filter, converted.Collation, converted.Hint, false, bw.collection.registry)
+			if filter, err = bw.collection.transformFilter(ctx, converted.Filter); err == nil {
+				doc, err = createDeleteDoc(filter, converted.Collation, converted.Hint, false, bw.collection.registry)
+			}
 			hasHint = hasHint || (converted.Hint != nil)
 		}
 
@@ -236,6 +254,20 @@ func (bw *bulkWrite) runDelete(ctx context.Context, batch bulkWriteBatch) (opera
 	if bw.ordered != nil {
 		op = op.Ordered(*bw.ordered)
 	}
+	if bw.comment != nil {
+		comment, err := transformValue(bw.collection.registry, bw.comment, true, "comment")
+		if err != nil {
+			return operation.DeleteResult{}, err
+		}
+		op = op.Comment(comment)
+	}
+	if bw.let != nil {
+		let, err := transformBsoncoreDocument(bw.collection.registry, bw.let, true, "let")
+		if err != nil {
+			return operation.DeleteResult{}, err
+		}
+		op = op.Let(let)
+	}
 	retry := driver.RetryNone
 	if bw.collection.client.retryWrites && batch.canRetry {
 		retry = driver.RetryOncePerCommand
@@ -282,22 +314,28 @@ func (bw *bulkWrite) runUpdate(ctx context.Context, batch bulkWriteBatch) (opera
 	var hasHint bool
 	var hasArrayFilters bool
 	for i, model := range batch.models {
-		var doc bsoncore.Document
+		var doc, filter bsoncore.Document
 		var err error
 
 		switch converted := model.(type) {
 		case *ReplaceOneModel:
-			doc, err = createUpdateDoc(converted.Filter, converted.Replacement, converted.Hint, nil, converted.Collation, converted.Upsert, false,
-				false, bw.collection.registry)
+			if filter, err = bw.collection.transformFilter(ctx, converted.Filter); err == nil {
+				doc, err = createUpdateDoc(filter, converted.Replacement, converted.Hint, nil, converted.Collation, converted.Upsert, false,
+					false, bw.collection.registry)
+			}
 			hasHint = hasHint || (converted.Hint != nil)
 		case *UpdateOneModel:
-			doc, err = createUpdateDoc(converted.Filter, converted.Update, converted.Hint, converted.ArrayFilters, converted.Collation, converted.Upsert, false,
-				true, bw.collection.registry)
+			if filter, err = bw.collection.transformFilter(ctx, converted.Filter); err == nil {
+				doc, err = createUpdateDoc(filter, converted.Update, converted.Hint, converted.ArrayFilters, converted.Collation, converted.Upsert, false,
+					true, bw.collection.registry)
+			}
 			hasHint = hasHint || (converted.Hint != nil)
 			hasArrayFilters = hasArrayFilters || (converted.ArrayFilters != nil)
 		case *UpdateManyModel:
-			doc, err = createUpdateDoc(converted.Filter, converted.Update, converted.Hint, converted.ArrayFilters, converted.Collation, converted.Upsert, true,
-				true, bw.collection.registry)
+			if filter, err = bw.collection.transformFilter(ctx, converted.Filter); err == nil {
+				doc, err = createUpdateDoc(filter, converted.Update, converted.Hint, converted.ArrayFilters, converted.Collation, converted.Upsert, true,
+					true, bw.collection.registry)
+			}
 			hasHint = hasHint || (converted.Hint != nil)
 			hasArrayFilters = hasArrayFilters || (converted.ArrayFilters != nil)
 		}
@@ -317,6 +355,20 @@ func (bw *bulkWrite) runUpdate(ctx context.Context, batch bulkWriteBatch) (opera
 	if bw.ordered != nil {
 		op = op.Ordered(*bw.ordered)
 	}
+	if bw.comment != nil {
+		comment, err := transformValue(bw.collection.registry, bw.comment, true, "comment")
+		if err != nil {
+			return operation.UpdateResult{}, err
+		}
+		op = op.Comment(comment)
+	}
+	if bw.let != nil {
+		let, err := transformBsoncoreDocument(bw.collection.registry, bw.let, true, "let")
+		if err != nil {
+			return operation.UpdateResult{}, err
+		}
+		op = op.Let(let)
+	}
 	if bw.bypassDocumentValidation != nil && *bw.bypassDocumentValidation {
 		op = op.BypassDocumentValidation(*bw.bypassDocumentValidation)
 	}
@@ -486,6 +538,18 @@ func (bw *bulkWrite) mergeResults(newResult BulkWriteResult) {
 	for index, upsertID := range newResult.UpsertedIDs {
 		bw.result.UpsertedIDs[index] = upsertID
 	}
+	bw.result.RawReplies = append(bw.result.RawReplies, newResult.RawReplies...)
+}
+
+func rawReplies(replies []bsoncore.Document) []bson.Raw {
+	if len(replies) == 0 {
+		return nil
+	}
+	raw := make([]bson.Raw, 0, len(replies))
+	for _, reply := range replies {
+		raw = append(raw, bson.Raw(reply))
+	}
+	return raw
 }
 
 // WriteCommandKind is the type of command represented by a Write
@@ -33,9 +33,7 @@ var (
 	// ErrNilCursor indicates that the underlying cursor for the change stream is nil.
 	ErrNilCursor = errors.New("cursor is nil")
 
-	minResumableLabelWireVersion int32 = 9 // Wire version at which the server includes the resumable error label
-	networkErrorLabel                  = "NetworkError"
-	resumableErrorLabel                = "ResumableChangeStreamError"
+	minResumableLabelWireVersion int32 = 9  // Wire version at which the server includes the resumable error label
 	errorCursorNotFound          int32 = 43 // CursorNotFound error code
 
 	// Whitelist of error codes that are considered resumable.
@@ -83,6 +81,8 @@ type ChangeStream struct {
 	selector      description.ServerSelector
 	operationTime *primitive.Timestamp
 	wireVersion   *description.VersionRange
+	// getMoreStats contains the statistics of the cursors that were replaced when the change stream was resumed.
+	getMoreStats driver.GetMoreStats
 }
 
 type changeStreamConfig struct {
@@ -275,6 +275,9 @@ func (cs *ChangeStream) executeOperation(ctx context.Context, resuming bool) err
 	cr := cs.aggregate.ResultCursorResponse()
 	cr.Server = server
 
+	if bc, ok := cs.cursor.(awaitBatchCursor); ok {
+		cs.getMoreStats = cs.getMoreStats.Add(bc.GetMoreStats())
+	}
 	cs.cursor, cs.err = driver.NewBatchCursor(cr, cs.sess, cs.client.clock, cs.cursorOptions)
 	if cs.err = replaceErrors(cs.err); cs.err != nil {
 		return cs.Err()
@@ -485,6 +488,26 @@ func (cs *ChangeStream) Close(ctx context.Context) error {
 	return cs.Err()
 }
 
+// SetMaxAwaitTime sets the maximum amount of time the server waits for new events in the getMore commands sent after
+// this call, overriding the MaxAwaitTime option the change stream was created with. The new value is also used if the
+// change stream is resumed. A duration of 0 means the server default is used.
+func (cs *ChangeStream) SetMaxAwaitTime(d time.Duration) {
+	cs.cursorOptions.MaxTimeMS = int64(d / time.Millisecond)
+	if bc, ok := cs.cursor.(awaitBatchCursor); ok {
+		bc.SetMaxTime(d)
+	}
+}
+
+// Stats returns statistics about the getMore commands sent by the change stream, including the getMore commands sent
+// before the change stream was resumed.
+func (cs *ChangeStream) Stats() CursorStats {
+	stats := cs.getMoreStats
+	if bc, ok := cs.cursor.(awaitBatchCursor); ok {
+		stats = stats.Add(bc.GetMoreStats())
+	}
+	return newCursorStats(stats)
+}
+
 // ResumeToken returns the last cached resume token for this change stream, or nil if a resume token has not been
 // stored.
 func (cs *ChangeStream) ResumeToken() bson.Raw {
@@ -590,7 +613,7 @@ func (cs *ChangeStream) loopNext(ctx context.Context, nonBlocking bool) {
 
 func (cs *ChangeStream) isResumableError() bool {
 	commandErr, ok := cs.err.(CommandError)
-	if !ok || commandErr.HasErrorLabel(networkErrorLabel) {
+	if !ok || commandErr.HasErrorLabel(NetworkErrorLabel) {
 		// All non-server errors or network errors are resumable.
 		return true
 	}
@@ -601,7 +624,7 @@ func (cs *ChangeStream) isResumableError() bool {
 
 	// For wire versions 9 and above, a server error is resumable if it has the ResumableChangeStreamError label.
 	if cs.wireVersion != nil && cs.wireVersion.Includes(minResumableLabelWireVersion) {
-		return commandErr.HasErrorLabel(resumableErrorLabel)
+		return commandErr.HasErrorLabel(ResumableChangeStreamErrorLabel)
 	}
 
 	// For wire versions below 9, a server error is resumable if its code is on the whitelist.
@@ -0,0 +1,175 @@
+// Copyright (C) MongoDB, Inc. 2021-present.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
+
+package mongo
+
+import (
+	"context"
+	"sync"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/bsontype"
+)
+
+// ChangeEventHandler handles a change event dispatched by a ChangeStreamDemux. The event is only valid until the
+// handler returns. If continued access is required, a copy must be made.
+type ChangeEventHandler func(ctx context.Context, event bson.Raw) error
+
+// ChangeStreamDemux demultiplexes the events of a database or deployment change stream, e.g. one created by
+// Client.Watch, to handlers registered per namespace, and keeps a resume token for each namespace. This allows a
+// single change stream to feed several independent consumers, such as one per collection in a change data capture
+// service:
+//
+//	demux := mongo.NewChangeStreamDemux()
+//	demux.Handle("shop.orders", handleOrder)
+//	demux.Handle("shop", handleOtherShopEvents)
+//	cs, err := client.Watch(ctx, mongo.Pipeline{})
+//	if err != nil {
+//		return err
+//	}
+//	defer cs.Close(ctx)
+//	err = demux.Run(ctx, cs)
+//
+// The resume token of a namespace is the _id of the last event of that namespace that was handled successfully. It
+// can be used as the ResumeAfter option of a change stream on that collection to restart its consumer on its own.
+//
+// A ChangeStreamDemux is safe for concurrent use, but events are dispatched to the handlers one at a time.
+type ChangeStreamDemux struct {
+	mu          sync.Mutex
+	handlers    map[string]ChangeEventHandler
+	fallback    ChangeEventHandler
+	tokens      map[string]bson.Raw
+	streamToken bson.Raw
+}
+
+// NewChangeStreamDemux creates a ChangeStreamDemux with no handlers.
+func NewChangeStreamDemux() *ChangeStreamDemux {
+	return &ChangeStreamDemux{
+		handlers: make(map[string]ChangeEventHandler),
+		tokens:   make(map[string]bson.Raw),
+	}
+}
+
+// Handle registers handler for the events of namespace, which is either a collection namespace of the form
+// "<database>.<collection>", or a database name to handle the events of all of the collections in the database that
+// do not have their own handler, as well as the database events such as dropDatabase. A nil handler removes the
+// handler of namespace.
+func (d *ChangeStreamDemux) Handle(namespace string, handler ChangeEventHandler) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	if handler == nil {
+		delete(d.handlers, namespace)
+		return
+	}
+	d.handlers[namespace] = handler
+}
+
+// HandleDefault registers handler for the events that do not match the namespace of any handler, including events
+// without a namespace such as invalidate. Events that do not match any handler are dropped if there is no default
+// handler. A nil handler removes the default handler.
+func (d *ChangeStreamDemux) HandleDefault(handler ChangeEventHandler) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	d.fallback = handler
+}
+
+// Dispatch calls the handler that matches the namespace of event and returns its error. If the handler succeeds, the
+// _id of event is stored as the resume token of the namespace.
+func (d *ChangeStreamDemux) Dispatch(ctx context.Context, event bson.Raw) error {
+	namespace, dbName := changeEventNamespace(event)
+
+	d.mu.Lock()
+	handler, ok := d.handlers[namespace]
+	if !ok && dbName != namespace {
+		handler, ok = d.handlers[dbName]
+	}
+	if !ok {
+		handler = d.fallback
+	}
+	d.mu.Unlock()
+
+	if handler != nil {
+		if err := handler(ctx, event); err != nil {
+			return err
+		}
+	}
+
+	token, ok := event.Lookup("_id").DocumentOK()
+	if !ok {
+		return nil
+	}
+	token = append(bson.Raw(nil), token...)
+	d.mu.Lock()
+	if namespace != "" {
+		d.tokens[namespace] = token
+	}
+	d.streamToken = token
+	d.mu.Unlock()
+	return nil
+}
+
+// Run dispatches the events of cs until ctx expires, cs returns an error, or a handler returns an error, and returns
+// that error. Run returns nil if cs is closed by the server, e.g. after an invalidate event. Run does not close cs.
+func (d *ChangeStreamDemux) Run(ctx context.Context, cs *ChangeStream) error {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	for cs.Next(ctx) {
+		if err := d.Dispatch(ctx, cs.Current); err != nil {
+			return err
+		}
+	}
+	return cs.Err()
+}
+
+// ResumeToken returns the resume token of namespace, or nil if no event of namespace has been handled. The namespace
+// has the same format as for Handle, but the token of a database name only tracks the database events.
+func (d *ChangeStreamDemux) ResumeToken(namespace string) bson.Raw {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	return d.tokens[namespace]
+}
+
+// ResumeTokens returns the resume tokens of all of the namespaces that have had events handled, keyed by namespace.
+func (d *ChangeStreamDemux) ResumeTokens() map[string]bson.Raw {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	tokens := make(map[string]bson.Raw, len(d.tokens))
+	for ns, token := range d.tokens {
+		tokens[ns] = token
+	}
+	return tokens
+}
+
+// StreamResumeToken returns the _id of the last event that was dispatched successfully, whatever its namespace, or
+// nil if no event has been dispatched. Because events are dispatched in order, it can be used as the ResumeAfter
+// option of a new change stream with the same scope to restart all of the consumers.
+func (d *ChangeStreamDemux) StreamResumeToken() bson.Raw {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	return d.streamToken
+}
+
+// changeEventNamespace returns the namespace of the collection of a change event, or the database name if the event
+// does not have a collection, and the database name of the event.
+func changeEventNamespace(event bson.Raw) (string, string) {
+	ns, ok := event.Lookup("ns").DocumentOK()
+	if !ok {
+		return "", ""
+	}
+	dbName, _ := ns.Lookup("db").StringValueOK()
+	coll := ns.Lookup("coll")
+	if coll.Type != bsontype.String || dbName == "" {
+		return dbName, dbName
+	}
+	return dbName + "." + coll.StringValue(), dbName
+}
@@ -0,0 +1,95 @@
+// Copyright (C) MongoDB, Inc. 2021-present.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
+
+package mongo
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/internal/testutil/assert"
+)
+
+func TestChangeStreamDemux(t *testing.T) {
+	event := func(t *testing.T, id int32, ns bson.D) bson.Raw {
+		t.Helper()
+
+		doc := bson.D{{"_id", bson.D{{"_data", id}}}, {"operationType", "insert"}}
+		if ns != nil {
+			doc = append(doc, bson.E{"ns", ns})
+		}
+		b, err := bson.Marshal(doc)
+		assert.Nil(t, err, "Marshal error: %v", err)
+		return b
+	}
+	token := func(event bson.Raw) bson.Raw { return event.Lookup("_id").Document() }
+
+	orders := event(t, 1, bson.D{{"db", "shop"}, {"coll", "orders"}})
+	users := event(t, 2, bson.D{{"db", "shop"}, {"coll", "users"}})
+	dropDB := event(t, 3, bson.D{{"db", "shop"}})
+	logs := event(t, 4, bson.D{{"db", "app"}, {"coll", "logs"}})
+	invalidate := event(t, 5, nil)
+
+	t.Run("routing", func(t *testing.T) {
+		var got []string
+		record := func(name string) ChangeEventHandler {
+			return func(_ context.Context, event bson.Raw) error {
+				got = append(got, name)
+				return nil
+			}
+		}
+		demux := NewChangeStreamDemux()
+		demux.Handle("shop.orders", record("orders"))
+		demux.Handle("shop", record("shop"))
+		demux.HandleDefault(record("default"))
+
+		for _, e := range []bson.Raw{orders, users, dropDB, logs, invalidate} {
+			err := demux.Dispatch(bgCtx, e)
+			assert.Nil(t, err, "Dispatch error: %v", err)
+		}
+		expected := []string{"orders", "shop", "shop", "default", "default"}
+		assert.Equal(t, expected, got, "expected handlers %v, got %v", expected, got)
+
+		expectedTokens := map[string]bson.Raw{
+			"shop.orders": token(orders),
+			"shop.users":  token(users),
+			"shop":        token(dropDB),
+			"app.logs":    token(logs),
+		}
+		assert.Equal(t, expectedTokens, demux.ResumeTokens(), "expected tokens %v, got %v", expectedTokens,
+			demux.ResumeTokens())
+		assert.Equal(t, token(invalidate), demux.StreamResumeToken(), "expected stream token %v, got %v",
+			token(invalidate), demux.StreamResumeToken())
+	})
+	t.Run("handler error", func(t *testing.T) {
+		handlerErr := errors.New("handler error")
+		demux := NewChangeStreamDemux()
+		demux.Handle("shop.orders", func(context.Context, bson.Raw) error { return handlerErr })
+
+		err := demux.Dispatch(bgCtx, users)
+		assert.Nil(t, err, "Dispatch error: %v", err)
+		err = demux.Dispatch(bgCtx, orders)
+		assert.Equal(t, handlerErr, err, "expected error %v, got %v", handlerErr, err)
+		assert.Nil(t, demux.ResumeToken("shop.orders"), "expected no token, got %v", demux.ResumeToken("shop.orders"))
+		assert.Equal(t, token(users), demux.StreamResumeToken(), "expected stream token %v, got %v", token(users),
+			demux.StreamResumeToken())
+	})
+	t.Run("remove handler", func(t *testing.T) {
+		called := false
+		demux := NewChangeStreamDemux()
+		demux.Handle("shop.orders", func(context.Context, bson.Raw) error {
+			called = true
+			return nil
+		})
+		demux.Handle("shop.orders", nil)
+
+		err := demux.Dispatch(bgCtx, orders)
+		assert.Nil(t, err, "Dispatch error: %v", err)
+		assert.False(t, called, "expected removed handler not to be called")
+	})
+}
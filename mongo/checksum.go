@@ -0,0 +1,228 @@
+// Copyright (C) MongoDB, Inc. 2021-present.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
+
+package mongo
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/hex"
+	"errors"
+	"sort"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// ErrMissingID is returned by CompareCollections and SyncDocuments if a document read from a collection does not have
+// an _id field, e.g. because it was excluded by the projection.
+var ErrMissingID = errors.New("document does not have an _id field")
+
+// ChecksumResult is the result of a Checksum operation.
+type ChecksumResult struct {
+	// The number of documents included in the checksum.
+	Count int64
+
+	// The hex-encoded checksum of the documents. The checksum does not depend on the order in which the documents are
+	// returned, so two collections have the same checksum if they contain the same documents. Documents whose fields
+	// are in a different order are considered to be different.
+	Checksum string
+}
+
+// CollectionDiff is the result of a CompareCollections operation.
+type CollectionDiff struct {
+	// The checksums of the compared collections.
+	Source *ChecksumResult
+	Target *ChecksumResult
+
+	// The _id values of the documents that are only in the source collection, ordered by their BSON representation.
+	MissingFromTarget []bson.RawValue
+
+	// The _id values of the documents that are only in the target collection, in the order they were returned.
+	MissingFromSource []bson.RawValue
+
+	// The _id values of the documents that are in both collections but differ, in the order they were returned from
+	// the target collection.
+	Mismatched []bson.RawValue
+}
+
+// Equal returns true if the compared collections contain the same documents.
+func (cd *CollectionDiff) Equal() bool {
+	return cd.Source.Count == cd.Target.Count && cd.Source.Checksum == cd.Target.Checksum
+}
+
+// checksum is an order-independent checksum of a multiset of documents. Each document is hashed with SHA-256 and the
+// hashes are combined by adding each of their 64-bit words modulo 2^64. Unlike XOR, the addition does not cancel out
+// the hashes of equal documents, which occur when the projection excludes _id, so duplicated documents change the
+// checksum.
+type checksum struct {
+	sum   [sha256.Size / 8]uint64
+	count int64
+}
+
+func (c *checksum) add(doc bson.Raw) [sha256.Size]byte {
+	digest := sha256.Sum256(doc)
+	for i := range c.sum {
+		c.sum[i] += binary.BigEndian.Uint64(digest[i*8:])
+	}
+	c.count++
+	return digest
+}
+
+func (c *checksum) result() *ChecksumResult {
+	var sum [sha256.Size]byte
+	for i, word := range c.sum {
+		binary.BigEndian.PutUint64(sum[i*8:], word)
+	}
+	return &ChecksumResult{
+		Count:    c.count,
+		Checksum: hex.EncodeToString(sum[:]),
+	}
+}
+
+// Checksum computes a checksum of the documents in the collection, which can be compared with the checksum of another
+// collection to verify that both contain the same documents, e.g. after a migration or between clusters. The documents
+// are read with the read preference and read concern of the collection and hashed client-side, so this reads the
+// whole collection over the network.
+//
+// The opts parameter can be used to specify options for the operation (see the options.ChecksumOptions documentation).
+func (coll *Collection) Checksum(ctx context.Context, opts ...*options.ChecksumOptions) (*ChecksumResult, error) {
+	var sum checksum
+	err := coll.forEachDocument(ctx, options.MergeChecksumOptions(opts...), func(doc bson.Raw) error {
+		sum.add(doc)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return sum.result(), nil
+}
+
+// CompareCollections computes the checksums of two collections like Collection.Checksum and reports the documents
+// that differ between them. The documents are matched by their _id field.
+//
+// The _id values and hashes of the documents in source are held in memory while target is read, so this requires
+// memory proportional to the number of documents in source.
+//
+// The opts parameter can be used to specify options for the operation (see the options.ChecksumOptions
+// documentation). The options are used for both collections.
+func CompareCollections(ctx context.Context, source, target *Collection,
+	opts ...*options.ChecksumOptions) (*CollectionDiff, error) {
+
+	co := options.MergeChecksumOptions(opts...)
+	maxDiffs := -1
+	if co.MaxDifferences != nil {
+		maxDiffs = *co.MaxDifferences
+	}
+	report := func(ids []bson.RawValue, id bson.RawValue) []bson.RawValue {
+		if maxDiffs >= 0 && len(ids) >= maxDiffs {
+			return ids
+		}
+		return append(ids, id)
+	}
+
+	type sourceDoc struct {
+		id     bson.RawValue
+		digest [sha256.Size]byte
+	}
+	var sourceSum checksum
+	sourceDocs := make(map[string]sourceDoc)
+	err := source.forEachDocument(ctx, co, func(doc bson.Raw) error {
+		id, err := documentID(doc)
+		if err != nil {
+			return err
+		}
+		sourceDocs[idKey(id)] = sourceDoc{id: id, digest: sourceSum.add(doc)}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	diff := &CollectionDiff{Source: sourceSum.result()}
+	var targetSum checksum
+	err = target.forEachDocument(ctx, co, func(doc bson.Raw) error {
+		id, err := documentID(doc)
+		if err != nil {
+			return err
+		}
+		digest := targetSum.add(doc)
+
+		key := idKey(id)
+		sd, ok := sourceDocs[key]
+		switch {
+		case !ok:
+			diff.MissingFromSource = report(diff.MissingFromSource, id)
+		case sd.digest != digest:
+			diff.Mismatched = report(diff.Mismatched, id)
+		}
+		delete(sourceDocs, key)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	diff.Target = targetSum.result()
+
+	keys := make([]string, 0, len(sourceDocs))
+	for key := range sourceDocs {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		diff.MissingFromTarget = report(diff.MissingFromTarget, sourceDocs[key].id)
+	}
+	return diff, nil
+}
+
+// documentID returns a copy of the _id value of doc that does not refer to the cursor's batch.
+func documentID(doc bson.Raw) (bson.RawValue, error) {
+	id, err := doc.LookupErr("_id")
+	if err != nil {
+		return bson.RawValue{}, ErrMissingID
+	}
+	id.Value = append([]byte(nil), id.Value...)
+	return id, nil
+}
+
+// idKey returns a map key for an _id value. Values of different BSON types are different keys.
+func idKey(id bson.RawValue) string {
+	return string(id.Type) + string(id.Value)
+}
+
+// forEachDocument calls fn for each document in the collection that matches the filter of co.
+func (coll *Collection) forEachDocument(ctx context.Context, co *options.ChecksumOptions, fn func(bson.Raw) error) error {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	filter := co.Filter
+	if filter == nil {
+		filter = bson.D{}
+	}
+	// The checksum must include every matching document, so the default find limit of the client is not applied.
+	fo := options.Find().SetUnbounded(true)
+	if co.Projection != nil {
+		fo.SetProjection(co.Projection)
+	}
+	if co.BatchSize != nil {
+		fo.SetBatchSize(*co.BatchSize)
+	}
+
+	cursor, err := coll.Find(ctx, filter, fo)
+	if err != nil {
+		return err
+	}
+	defer cursor.Close(ctx)
+
+	for cursor.Next(ctx) {
+		if err = fn(cursor.Current); err != nil {
+			return err
+		}
+	}
+	return cursor.Err()
+}
@@ -0,0 +1,62 @@
+// Copyright (C) MongoDB, Inc. 2021-present.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
+
+package mongo
+
+import (
+	"testing"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/internal/testutil/assert"
+)
+
+func TestChecksum(t *testing.T) {
+	var docs []bson.Raw
+	for _, doc := range []bson.D{{{"_id", 1}}, {{"_id", 2}}, {{"_id", 3}, {"x", "y"}}} {
+		b, err := bson.Marshal(doc)
+		assert.Nil(t, err, "Marshal error: %v", err)
+		docs = append(docs, b)
+	}
+
+	var forward, backward, partial checksum
+	for i := range docs {
+		forward.add(docs[i])
+		backward.add(docs[len(docs)-1-i])
+	}
+	partial.add(docs[0])
+	partial.add(docs[1])
+
+	assert.Equal(t, forward.result(), backward.result(), "expected checksum %v, got %v", forward.result(),
+		backward.result())
+	assert.Equal(t, int64(3), forward.result().Count, "expected count 3, got %v", forward.result().Count)
+	assert.NotEqual(t, forward.result().Checksum, partial.result().Checksum, "expected checksums to differ")
+
+	// Without _id, equal documents must not cancel each other out.
+	projected, err := bson.Marshal(bson.D{{"x", "y"}})
+	assert.Nil(t, err, "Marshal error: %v", err)
+	other, err := bson.Marshal(bson.D{{"x", "z"}})
+	assert.Nil(t, err, "Marshal error: %v", err)
+	var once, twice, thrice, mixed, mixedTwice, none checksum
+	once.add(projected)
+	for i := 0; i < 2; i++ {
+		twice.add(projected)
+		mixedTwice.add(projected)
+	}
+	for i := 0; i < 3; i++ {
+		thrice.add(projected)
+	}
+	mixed.add(projected)
+	mixed.add(other)
+	mixed.add(other)
+	mixedTwice.add(other)
+	assert.NotEqual(t, none.result().Checksum, twice.result().Checksum, "expected duplicates not to cancel out")
+	assert.NotEqual(t, once.result().Checksum, thrice.result().Checksum, "expected duplicates not to cancel out")
+	assert.NotEqual(t, mixed.result().Checksum, mixedTwice.result().Checksum,
+		"expected checksums of collections that differ by duplicated documents to differ")
+
+	var empty checksum
+	assert.Equal(t, 64, len(empty.result().Checksum), "expected 64 hex digits, got %v", len(empty.result().Checksum))
+}
@@ -26,6 +26,7 @@ import (
 	"go.mongodb.org/mongo-driver/x/mongo/driver"
 	"go.mongodb.org/mongo-driver/x/mongo/driver/auth"
 	"go.mongodb.org/mongo-driver/x/mongo/driver/connstring"
+	"go.mongodb.org/mongo-driver/x/mongo/driver/dns"
 	"go.mongodb.org/mongo-driver/x/mongo/driver/ocsp"
 	"go.mongodb.org/mongo-driver/x/mongo/driver/operation"
 	"go.mongodb.org/mongo-driver/x/mongo/driver/session"
@@ -66,6 +67,23 @@ type Client struct {
 	serverAPI       *driver.ServerAPIOptions
 	serverMonitor   *event.ServerMonitor
 	sessionPool     *session.Pool
+	facade          bool
+
+	// fields for clients created by NewClientFromDeployment, which do not own their deployment
+	sharedDeployment bool
+	subscription     *driver.Subscription
+
+	defaultDatabase    string
+	defaultFindLimit   *int64
+	defaultFindMaxTime *time.Duration
+	inMemorySortCheck  options.InMemorySortCheck
+	inMemorySortFunc   func(error)
+	restartCursors     bool
+	pinnedCursors      *driver.PinnedCursorCounters
+	collectionNamer    options.CollectionNamer
+	cursorLeaks        *cursorLeakDetector
+	sessionLeaks       *sessionLeakDetector
+	sessionMonitor     *event.SessionMonitor
 
 	// client-side encryption fields
 	keyVaultClientFLE *Client
@@ -130,7 +148,7 @@ func NewClient(opts ...*options.ClientOptions) (*Client, error) {
 	if err != nil {
 		return nil, err
 	}
-	client := &Client{id: id}
+	client := &Client{id: id, pinnedCursors: &driver.PinnedCursorCounters{}}
 
 	err = client.configure(clientOpt)
 	if err != nil {
@@ -146,13 +164,43 @@ func NewClient(opts ...*options.ClientOptions) (*Client, error) {
 	return client, nil
 }
 
+// NewClientFromDeployment creates a new Client that uses an existing deployment, such as a *topology.Topology that is
+// managed centrally and shared by multiple modules of an application. The deployment must already be connected if it
+// needs to be; the Client does not connect or disconnect it.
+//
+// As with NewClient, the Client.Connect method must be called before the Client can be used. Connect starts a session
+// pool for the Client without connecting the deployment, and Disconnect ends the sessions of the Client and leaves the
+// deployment connected for other users.
+//
+// The opts parameter can be used to specify options that do not configure the deployment, such as the read concern,
+// write concern, registry, or command monitor. An error is returned if opts contains topology, server, or connection
+// options (e.g. hosts or a connection pool size) because those options are determined by the deployment.
+func NewClientFromDeployment(deployment driver.Deployment, opts ...*options.ClientOptions) (*Client, error) {
+	if deployment == nil {
+		return nil, errors.New("deployment must not be nil")
+	}
+
+	opts = append(opts, &options.ClientOptions{Deployment: deployment})
+	client, err := NewClient(opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	client.sharedDeployment = true
+	return client, nil
+}
+
 // Connect initializes the Client by starting background monitoring goroutines.
 // If the Client was created using the NewClient function, this method must be called before a Client can be used.
 //
 // Connect starts background goroutines to monitor the state of the deployment and does not do any I/O in the main
 // goroutine. The Client.Ping method can be used to verify that the connection was created successfully.
 func (c *Client) Connect(ctx context.Context) error {
-	if connector, ok := c.deployment.(driver.Connector); ok {
+	if c.facade {
+		return nil
+	}
+
+	if connector, ok := c.deployment.(driver.Connector); ok && !c.sharedDeployment {
 		err := connector.Connect()
 		if err != nil {
 			return replaceErrors(err)
@@ -183,18 +231,135 @@ func (c *Client) Connect(ctx context.Context) error {
 		}
 	}
 
-	var updateChan <-chan description.Topology
-	if subscriber, ok := c.deployment.(driver.Subscriber); ok {
-		sub, err := subscriber.Subscribe()
-		if err != nil {
-			return replaceErrors(err)
-		}
-		updateChan = sub.Updates
+	updateChan, err := c.subscribe()
+	if err != nil {
+		return err
+	}
+	if c.sessionPool != nil {
+		// The Client is being reconnected. The pooled sessions were ended by Disconnect, so they are discarded.
+		c.sessionPool.Reset(updateChan)
+		return nil
 	}
 	c.sessionPool = session.NewPool(updateChan)
+	if c.sessionLeaks != nil {
+		c.sessionPool.SetCheckedOutThreshold(c.sessionLeaks.threshold, c.sessionLeaks.thresholdExceeded)
+	}
+	if c.sessionMonitor != nil {
+		c.sessionPool.SetMonitor(c.sessionMonitor)
+	}
+	return nil
+}
+
+// subscribe subscribes to the updates of the deployment, which the session pool uses to expire sessions. It returns a
+// nil channel if the deployment does not support subscriptions.
+func (c *Client) subscribe() (<-chan description.Topology, error) {
+	subscriber, ok := c.deployment.(driver.Subscriber)
+	if !ok {
+		return nil, nil
+	}
+	sub, err := subscriber.Subscribe()
+	if err != nil {
+		return nil, replaceErrors(err)
+	}
+	c.subscription = sub
+	return sub.Updates, nil
+}
+
+// Reconnect connects a Client again after it has been disconnected with Disconnect. The Client keeps the configuration
+// it was created with, so the options do not have to be merged, parsed, and validated again, and Databases and
+// Collections created from the Client can be used again after Reconnect returns. Like Connect, Reconnect does not do
+// any I/O in the main goroutine.
+//
+// An error is returned if the Client has never been connected, is connected, or has automatic encryption enabled
+// because the resources used for encryption cannot be recreated once they have been released by Disconnect.
+func (c *Client) Reconnect(ctx context.Context) error {
+	if c.facade {
+		return nil
+	}
+	if c.sessionPool == nil {
+		return errors.New("client has never been connected")
+	}
+	if c.cryptFLE != nil {
+		return errors.New("cannot reconnect a client with automatic encryption enabled")
+	}
+	return c.Connect(ctx)
+}
+
+// Reset closes all connections of the Client, including connections that are in use, stops its monitoring goroutines,
+// and starts them again with the same configuration. This can be used when the existing connections can no longer be
+// used safely, e.g. in a child process after a fork or after the network namespace of the process has changed. The
+// Clients used internally for automatic encryption are also reset.
+//
+// Operations that are in progress when Reset is called fail with network errors, and cursors created before Reset
+// cannot be iterated after it. Unlike Disconnect, Reset does not end the pooled server sessions because they may still
+// be in use by another process. They are discarded instead and expire on the server after the server's session
+// timeout.
+//
+// An error is returned if the Client is not connected or was created with NewClientFromDeployment, because the
+// deployment of such a Client is not owned by it.
+func (c *Client) Reset(ctx context.Context) error {
+	if c.facade {
+		return nil
+	}
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	if c.sharedDeployment {
+		return errors.New("cannot reset a client created from a deployment")
+	}
+	if c.sessionPool == nil {
+		return ErrClientDisconnected
+	}
+
+	connector, ok := c.deployment.(driver.Connector)
+	if !ok {
+		return fmt.Errorf("cannot reset a client with a deployment of type %T", c.deployment)
+	}
+	disconnector, ok := c.deployment.(driver.Disconnector)
+	if !ok {
+		return fmt.Errorf("cannot reset a client with a deployment of type %T", c.deployment)
+	}
+	if err := disconnector.Disconnect(ctx); err != nil {
+		return replaceErrors(err)
+	}
+	if err := connector.Connect(); err != nil {
+		return replaceErrors(err)
+	}
+	updateChan, err := c.subscribe()
+	if err != nil {
+		return err
+	}
+	c.sessionPool.Reset(updateChan)
+
+	for _, client := range c.internalClients() {
+		if err := client.Reset(ctx); err != nil {
+			return err
+		}
+	}
 	return nil
 }
 
+// internalClients returns the Clients used internally for automatic encryption, excluding c.
+func (c *Client) internalClients() []*Client {
+	var clients []*Client
+	if c.mongocryptdFLE != nil {
+		clients = append(clients, c.mongocryptdFLE.clients...)
+	}
+	for _, client := range []*Client{c.internalClientFLE, c.keyVaultClientFLE, c.metadataClientFLE} {
+		if client == nil || client == c {
+			continue
+		}
+		duplicate := false
+		for _, added := range clients {
+			duplicate = duplicate || added == client
+		}
+		if !duplicate {
+			clients = append(clients, client)
+		}
+	}
+	return clients
+}
+
 // Disconnect closes sockets to the topology referenced by this Client. It will
 // shut down any monitoring goroutines, close the idle connection pool, and will
 // wait until all the in use connections have been returned to the connection
@@ -202,43 +367,131 @@ func (c *Client) Connect(ctx context.Context) error {
 // deadline, or timeout before the in use connections have returned, the in use
 // connections will be closed, resulting in the failure of any in flight read
 // or write operations. If this method returns with no errors, all connections
-// associated with this Client have been closed.
+// associated with this Client have been closed. A disconnected Client can be
+// connected again with Reconnect.
 func (c *Client) Disconnect(ctx context.Context) error {
+	_, err := c.DisconnectWithReport(ctx)
+	return err
+}
+
+// DisconnectReport summarizes the resources that were cleaned up by Client.DisconnectWithReport. Open cursors are not
+// killed when a Client is disconnected, but the implicit sessions they use are included in SessionsInProgress.
+type DisconnectReport struct {
+	// SessionsEnded is the number of pooled server sessions for which an endSessions command was sent. Errors from the
+	// command are ignored.
+	SessionsEnded int
+
+	// SessionsInProgress is the number of sessions that were still in use, e.g. explicit sessions for which EndSession
+	// was not called and implicit sessions used by open cursors or in-progress operations. These sessions are not ended
+	// and expire on the server after the server's session timeout.
+	SessionsInProgress int
+
+	// ConnectionsClosed is the total number of connections that were closed.
+	ConnectionsClosed int
+
+	// InUseConnectionsClosed is the number of connections that were closed while still in use because ctx expired
+	// or had no deadline. The operations using these connections fail.
+	InUseConnectionsClosed int
+}
+
+// DisconnectWithReport disconnects the Client like Disconnect and returns a report of the resources that were cleaned
+// up, which can be used to debug slow or incomplete shutdowns. Connection counts are only reported for Clients that
+// own their deployment, i.e. Clients that were not created with NewClientFromDeployment. The report contains the
+// resources cleaned up before an error occurred.
+func (c *Client) DisconnectWithReport(ctx context.Context) (*DisconnectReport, error) {
+	report := &DisconnectReport{}
+	if c.facade {
+		return report, nil
+	}
 	if ctx == nil {
 		ctx = context.Background()
 	}
 
-	c.endSessions(ctx)
+	if c.sessionPool != nil {
+		report.SessionsInProgress = c.sessionPool.CheckedOut()
+	}
+	report.SessionsEnded = c.endSessions(ctx)
 	if c.mongocryptdFLE != nil {
 		if err := c.mongocryptdFLE.disconnect(ctx); err != nil {
-			return err
+			return report, err
 		}
 	}
 
 	if c.internalClientFLE != nil {
 		if err := c.internalClientFLE.Disconnect(ctx); err != nil {
-			return err
+			return report, err
 		}
 	}
 
 	if c.keyVaultClientFLE != nil && c.keyVaultClientFLE != c.internalClientFLE && c.keyVaultClientFLE != c {
 		if err := c.keyVaultClientFLE.Disconnect(ctx); err != nil {
-			return err
+			return report, err
 		}
 	}
 	if c.metadataClientFLE != nil && c.metadataClientFLE != c.internalClientFLE && c.metadataClientFLE != c {
 		if err := c.metadataClientFLE.Disconnect(ctx); err != nil {
-			return err
+			return report, err
 		}
 	}
 	if c.cryptFLE != nil {
 		c.cryptFLE.Close()
 	}
 
-	if disconnector, ok := c.deployment.(driver.Disconnector); ok {
-		return replaceErrors(disconnector.Disconnect(ctx))
+	if c.sharedDeployment {
+		// The deployment is used by others, so only stop listening for its updates.
+		if subscriber, ok := c.deployment.(driver.Subscriber); ok && c.subscription != nil {
+			if err := subscriber.Unsubscribe(c.subscription); err != nil {
+				return report, replaceErrors(err)
+			}
+			c.subscription = nil
+		}
+		return report, nil
 	}
-	return nil
+
+	switch deployment := c.deployment.(type) {
+	case *topology.Topology:
+		stats, err := deployment.DisconnectWithStats(ctx)
+		report.ConnectionsClosed = stats.ConnectionsClosed
+		report.InUseConnectionsClosed = stats.InUseConnectionsClosed
+		return report, replaceErrors(err)
+	case driver.Disconnector:
+		return report, replaceErrors(deployment.Disconnect(ctx))
+	}
+	return report, nil
+}
+
+// NewFacade creates a lightweight Client that shares the deployment, connection pools, and session pool of c but uses
+// different defaults for the operations executed on it. This can be used to vary the read concern, write concern, read
+// preference, or registry without creating another connection pool. Sessions started from c or any of its facades can
+// be used with all of them.
+//
+// The facade shares the lifecycle of c. Calling Connect or Disconnect on the facade has no effect, and disconnecting c
+// also disconnects the facade. ErrClientDisconnected is returned if c has not been connected.
+//
+// The opts parameter can be used to specify options for the facade (see the options.ClientFacadeOptions
+// documentation).
+func (c *Client) NewFacade(opts ...*options.ClientFacadeOptions) (*Client, error) {
+	if c.sessionPool == nil {
+		return nil, ErrClientDisconnected
+	}
+
+	facade := *c
+	facade.facade = true
+
+	fo := options.MergeClientFacadeOptions(opts...)
+	if fo.ReadConcern != nil {
+		facade.readConcern = fo.ReadConcern
+	}
+	if fo.WriteConcern != nil {
+		facade.writeConcern = fo.WriteConcern
+	}
+	if fo.ReadPreference != nil {
+		facade.readPreference = fo.ReadPreference
+	}
+	if fo.Registry != nil {
+		facade.registry = fo.Registry
+	}
+	return &facade, nil
 }
 
 // Ping sends a ping command to verify that the client can connect to the deployment.
@@ -269,6 +522,73 @@ func (c *Client) Ping(ctx context.Context, rp *readpref.ReadPref) error {
 	return replaceErrors(res.Err())
 }
 
+// WarmUp establishes the minimum number of connections configured through ClientOptions.SetMinPoolSize to each server
+// that is currently known to the client and waits until they are connected. It can be called after Connect so that
+// connection handshakes do not delay the first operations run by an application. The returned map contains the result
+// for each server, keyed by the server's address. The result is nil if the server's connection pool was warmed up
+// successfully.
+//
+// Servers that are discovered after WarmUp is called, e.g. the members of a replica set that are not in the seed list,
+// are not warmed up. An error is returned if the client is disconnected.
+func (c *Client) WarmUp(ctx context.Context) (map[string]error, error) {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	t, ok := c.deployment.(*topology.Topology)
+	if !ok {
+		return map[string]error{}, nil
+	}
+
+	res, err := t.WarmUp(ctx)
+	if err != nil {
+		return nil, replaceErrors(err)
+	}
+
+	results := make(map[string]error, len(res))
+	for addr, err := range res {
+		results[addr.String()] = replaceErrors(err)
+	}
+	return results, nil
+}
+
+// ServerRTTStats returns the round trip time statistics of the servers currently known to the client, keyed by the
+// server's address. The statistics are also published with every ServerHeartbeatSucceededEvent. See the event.RTTStats
+// documentation for more information about how the statistics are computed. The returned map is empty if the client
+// was created with NewClientFromDeployment using a deployment that is not a *topology.Topology.
+func (c *Client) ServerRTTStats() map[string]event.RTTStats {
+	t, ok := c.deployment.(*topology.Topology)
+	if !ok {
+		return map[string]event.RTTStats{}
+	}
+
+	res := t.RTTStats()
+	stats := make(map[string]event.RTTStats, len(res))
+	for addr, s := range res {
+		stats[addr.String()] = s
+	}
+	return stats
+}
+
+// ServerCompressors returns the compressors negotiated with the servers currently known to the client, keyed by the
+// server's address. The compressor for a server is the first compressor specified through ClientOptions.SetCompressors
+// that is also supported by the server, or an empty string if compression is not used or no connection to the server
+// has been established yet. The returned map is empty if the client was created with NewClientFromDeployment using a
+// deployment that is not a *topology.Topology.
+func (c *Client) ServerCompressors() map[string]string {
+	t, ok := c.deployment.(*topology.Topology)
+	if !ok {
+		return map[string]string{}
+	}
+
+	res := t.Compressors()
+	compressors := make(map[string]string, len(res))
+	for addr, compressor := range res {
+		compressors[addr.String()] = compressor
+	}
+	return compressors
+}
+
 // StartSession starts a new session configured with the given options.
 //
 // If the DefaultReadConcern, DefaultWriteConcern, or DefaultReadPreference options are not set, the client's read
@@ -279,13 +599,20 @@ func (c *Client) StartSession(opts ...*options.SessionOptions) (Session, error)
 	}
 
 	sopts := options.MergeSessionOptions(opts...)
+	if sopts.CausalConsistency != nil && *sopts.CausalConsistency && sopts.Snapshot != nil && *sopts.Snapshot {
+		return nil, errors.New("causal consistency and snapshot cannot both be set for a session")
+	}
 	coreOpts := &session.ClientOptions{
 		DefaultReadConcern:    c.readConcern,
 		DefaultReadPreference: c.readPreference,
 		DefaultWriteConcern:   c.writeConcern,
 	}
-	if sopts.CausalConsistency != nil {
+	switch {
+	case sopts.CausalConsistency != nil:
 		coreOpts.CausalConsistency = sopts.CausalConsistency
+	case sopts.Snapshot == nil || !*sopts.Snapshot:
+		causalConsistency := options.DefaultCausalConsistency
+		coreOpts.CausalConsistency = &causalConsistency
 	}
 	if sopts.DefaultReadConcern != nil {
 		coreOpts.DefaultReadConcern = sopts.DefaultReadConcern
@@ -299,6 +626,9 @@ func (c *Client) StartSession(opts ...*options.SessionOptions) (Session, error)
 	if sopts.DefaultMaxCommitTime != nil {
 		coreOpts.DefaultMaxCommitTime = sopts.DefaultMaxCommitTime
 	}
+	if sopts.Snapshot != nil {
+		coreOpts.Snapshot = sopts.Snapshot
+	}
 
 	sess, err := session.NewClientSession(c.sessionPool, c.id, session.Explicit, coreOpts)
 	if err != nil {
@@ -308,6 +638,7 @@ func (c *Client) StartSession(opts ...*options.SessionOptions) (Session, error)
 	// Writes are not retryable on standalones, so let operation determine whether to retry
 	sess.RetryWrite = false
 	sess.RetryRead = c.retryReads
+	c.trackSession(sess)
 
 	return &sessionImpl{
 		clientSession: sess,
@@ -316,9 +647,11 @@ func (c *Client) StartSession(opts ...*options.SessionOptions) (Session, error)
 	}, nil
 }
 
-func (c *Client) endSessions(ctx context.Context) {
+// endSessions ends the server sessions in the session pool and returns the number of sessions for which an
+// endSessions command was sent.
+func (c *Client) endSessions(ctx context.Context) int {
 	if c.sessionPool == nil {
-		return
+		return 0
 	}
 
 	sessionIDs := c.sessionPool.IDSlice()
@@ -342,6 +675,7 @@ func (c *Client) endSessions(ctx context.Context) {
 			currentBatch = currentBatch[:0]
 		}
 	}
+	return totalNumIDs
 }
 
 func (c *Client) configure(opts *options.ClientOptions) error {
@@ -383,6 +717,19 @@ func (c *Client) configure(opts *options.ClientOptions) error {
 			return appName
 		}))
 	}
+	// DriverInfo
+	var driverInfo *driver.DriverInfo
+	if opts.DriverInfo != nil {
+		driverInfo = &driver.DriverInfo{
+			Name:     opts.DriverInfo.Name,
+			Version:  opts.DriverInfo.Version,
+			Platform: opts.DriverInfo.Platform,
+		}
+
+		serverOpts = append(serverOpts, topology.WithServerDriverInfo(func(*driver.DriverInfo) *driver.DriverInfo {
+			return driverInfo
+		}))
+	}
 	// Compressors & ZlibLevel
 	var comps []string
 	if len(opts.Compressors) > 0 {
@@ -407,14 +754,27 @@ func (c *Client) configure(opts *options.ClientOptions) error {
 			}
 		}
 
+		if opts.DisableLocalCompression != nil {
+			connOpts = append(connOpts, topology.WithDisableLocalhostCompression(
+				func(bool) bool { return *opts.DisableLocalCompression },
+			))
+		}
+
 		serverOpts = append(serverOpts, topology.WithCompressionOptions(
 			func(opts ...string) []string { return append(opts, comps...) },
 		))
 	}
+	// LoadBalanced
+	loadBalanced := opts.LoadBalanced != nil && *opts.LoadBalanced
+	if loadBalanced {
+		topologyOpts = append(topologyOpts, topology.WithLoadBalanced(func(bool) bool { return true }))
+		serverOpts = append(serverOpts, topology.WithServerLoadBalanced(func(bool) bool { return true }))
+		connOpts = append(connOpts, topology.WithConnectionLoadBalanced(func(bool) bool { return true }))
+	}
 	// Handshaker
 	var handshaker = func(driver.Handshaker) driver.Handshaker {
-		return operation.NewIsMaster().AppName(appName).Compressors(comps).ClusterClock(c.clock).
-			ServerAPI(c.serverAPI)
+		return operation.NewIsMaster().AppName(appName).DriverInfo(driverInfo).Compressors(comps).
+			ClusterClock(c.clock).ServerAPI(c.serverAPI).LoadBalanced(loadBalanced)
 	}
 	// Auth & Database & Password & Username
 	if opts.Auth != nil {
@@ -443,10 +803,12 @@ func (c *Client) configure(opts *options.ClientOptions) error {
 
 		handshakeOpts := &auth.HandshakeOptions{
 			AppName:       appName,
+			DriverInfo:    driverInfo,
 			Authenticator: authenticator,
 			Compressors:   comps,
 			ClusterClock:  c.clock,
 			ServerAPI:     c.serverAPI,
+			LoadBalanced:  loadBalanced,
 		}
 		if mechanism == "" {
 			// Required for SASL mechanism negotiation during handshake
@@ -479,6 +841,35 @@ func (c *Client) configure(opts *options.ClientOptions) error {
 			func(topology.Dialer) topology.Dialer { return opts.Dialer },
 		))
 	}
+	// DefaultDatabase
+	if opts.DefaultDatabase != nil {
+		c.defaultDatabase = *opts.DefaultDatabase
+	}
+	// DefaultFindLimit
+	c.defaultFindLimit = opts.DefaultFindLimit
+	// DefaultFindMaxTime
+	c.defaultFindMaxTime = opts.DefaultFindMaxTime
+	// InMemorySortCheck
+	if opts.InMemorySortCheck != nil {
+		c.inMemorySortCheck = *opts.InMemorySortCheck
+	}
+	c.inMemorySortFunc = opts.InMemorySortHandler
+	// RestartPinnedCursors
+	c.restartCursors = opts.RestartPinnedCursors != nil && *opts.RestartPinnedCursors
+	// CollectionNamer
+	c.collectionNamer = opts.CollectionNamer
+	// CursorLeakTimeout
+	if opts.CursorLeakTimeout != nil && *opts.CursorLeakTimeout > 0 && opts.CursorMonitor != nil &&
+		opts.CursorMonitor.Leaked != nil {
+
+		c.cursorLeaks = &cursorLeakDetector{timeout: *opts.CursorLeakTimeout, monitor: opts.CursorMonitor}
+	}
+	// SessionLeakThreshold
+	if opts.SessionLeakThreshold != nil && opts.SessionMonitor != nil && opts.SessionMonitor.ThresholdExceeded != nil {
+		c.sessionLeaks = newSessionLeakDetector(int(*opts.SessionLeakThreshold), opts.SessionMonitor)
+	}
+	// SessionMonitor
+	c.sessionMonitor = opts.SessionMonitor
 	// Direct
 	if opts.Direct != nil && *opts.Direct {
 		topologyOpts = append(topologyOpts, topology.WithMode(
@@ -491,6 +882,18 @@ func (c *Client) configure(opts *options.ClientOptions) error {
 			func(time.Duration) time.Duration { return *opts.HeartbeatInterval },
 		))
 	}
+	// MinHeartbeatInterval
+	if opts.MinHeartbeatInterval != nil {
+		serverOpts = append(serverOpts, topology.WithMinHeartbeatInterval(
+			func(time.Duration) time.Duration { return *opts.MinHeartbeatInterval },
+		))
+	}
+	// ServerFailureBackoff
+	if opts.ServerFailureBackoff != nil {
+		serverOpts = append(serverOpts, topology.WithServerFailureBackoff(
+			func(time.Duration) time.Duration { return *opts.ServerFailureBackoff },
+		))
+	}
 	// Hosts
 	hosts := []string{"localhost:27017"} // default host
 	if len(opts.Hosts) > 0 {
@@ -517,6 +920,13 @@ func (c *Client) configure(opts *options.ClientOptions) error {
 			topology.WithMaxConnections(func(uint64) uint64 { return *opts.MaxPoolSize }),
 		)
 	}
+	// MaxConnecting
+	if opts.MaxConnecting != nil {
+		serverOpts = append(
+			serverOpts,
+			topology.WithMaxConnecting(func(uint64) uint64 { return *opts.MaxConnecting }),
+		)
+	}
 	// MinPoolSize
 	if opts.MinPoolSize != nil {
 		serverOpts = append(
@@ -533,9 +943,13 @@ func (c *Client) configure(opts *options.ClientOptions) error {
 	}
 	// Monitor
 	if opts.Monitor != nil {
-		c.monitor = opts.Monitor
+		monitor := opts.Monitor
+		if opts.CommandMonitorRedactor != nil {
+			monitor = redactingCommandMonitor(monitor, opts.CommandMonitorRedactor)
+		}
+		c.monitor = monitor
 		connOpts = append(connOpts, topology.WithMonitor(
-			func(*event.CommandMonitor) *event.CommandMonitor { return opts.Monitor },
+			func(*event.CommandMonitor) *event.CommandMonitor { return monitor },
 		))
 	}
 	// ServerMonitor
@@ -581,6 +995,12 @@ func (c *Client) configure(opts *options.ClientOptions) error {
 	if opts.RetryReads != nil {
 		c.retryReads = *opts.RetryReads
 	}
+	// SeedlistCache
+	if opts.SeedlistCache != nil {
+		topologyOpts = append(topologyOpts, topology.WithSeedlistCache(
+			func(dns.SeedlistCache) dns.SeedlistCache { return opts.SeedlistCache },
+		))
+	}
 	// ServerSelectionTimeout
 	if opts.ServerSelectionTimeout != nil {
 		topologyOpts = append(topologyOpts, topology.WithServerSelectionTimeout(
@@ -651,6 +1071,23 @@ func (c *Client) configure(opts *options.ClientOptions) error {
 	return nil
 }
 
+// redactingCommandMonitor returns a copy of monitor that passes the command of each CommandStartedEvent through redact
+// before publishing the event. Commands that were already redacted by the driver are empty and are published as is.
+func redactingCommandMonitor(monitor *event.CommandMonitor, redact func(bson.Raw) bson.Raw) *event.CommandMonitor {
+	if monitor.Started == nil {
+		return monitor
+	}
+
+	redacting := *monitor
+	redacting.Started = func(ctx context.Context, evt *event.CommandStartedEvent) {
+		if len(evt.Command) > 0 {
+			evt.Command = redact(evt.Command)
+		}
+		monitor.Started(ctx, evt)
+	}
+	return &redacting
+}
+
 func (c *Client) configureAutoEncryption(clientOpts *options.ClientOptions) error {
 	if err := c.configureKeyVaultClientFLE(clientOpts); err != nil {
 		return err
@@ -783,11 +1220,21 @@ func convertToDriverAPIOptions(s *options.ServerAPIOptions) *driver.ServerAPIOpt
 	return driverOpts
 }
 
-// Database returns a handle for a database with the given name configured with the given DatabaseOptions.
+// Database returns a handle for a database with the given name configured with the given DatabaseOptions. If name is
+// empty, the default database of the client is used (see DefaultDatabase).
 func (c *Client) Database(name string, opts ...*options.DatabaseOptions) *Database {
+	if name == "" {
+		name = c.defaultDatabase
+	}
 	return newDatabase(c, name, opts...)
 }
 
+// DefaultDatabase returns the name of the default database of the client, which is set by the database in the path of
+// the URI or by ClientOptions.SetDefaultDatabase, or an empty string if there is no default database.
+func (c *Client) DefaultDatabase() string {
+	return c.defaultDatabase
+}
+
 // ListDatabases executes a listDatabases command and returns the result.
 //
 // The filter parameter must be a document containing query operators and can be used to select which
@@ -917,6 +1364,55 @@ func (c *Client) UseSessionWithOptions(ctx context.Context, opts *options.Sessio
 	return fn(NewSessionContext(ctx, defaultSess))
 }
 
+// WithSnapshotSession creates a new snapshot session and uses it to create a new SessionContext, which is used to call
+// the fn callback. All read operations executed with the SessionContext read from the same point in time, which is
+// chosen by the server for the first read. If the server reports that the snapshot is unavailable, e.g. because it is
+// no longer in the server's history, the session is ended and fn is called again with a new snapshot session until it
+// succeeds or 120 seconds have elapsed. Because fn may be called multiple times, it should not have side effects
+// other than the reads it performs.
+//
+// The opts parameter can be used to specify additional options for the session. The Snapshot option is always set to
+// true and the CausalConsistency option must not be set to true. Snapshot reads are only supported by MongoDB versions
+// >= 5.0.
+//
+// If fn returns an error that is not retried, it will be returned without any modifications.
+func (c *Client) WithSnapshotSession(ctx context.Context, fn func(SessionContext) error,
+	opts ...*options.SessionOptions) error {
+
+	sopts := options.MergeSessionOptions(opts...).SetSnapshot(true)
+	timeout := time.NewTimer(withTransactionTimeout)
+	defer timeout.Stop()
+	for {
+		err := c.UseSessionWithOptions(ctx, sopts, fn)
+		if err == nil || !isSnapshotUnavailableError(err) {
+			return err
+		}
+
+		select {
+		case <-timeout.C:
+			return err
+		default:
+		}
+	}
+}
+
+// WithCausalSession creates a new causally consistent session and uses it to create a new SessionContext, which is
+// used to call the fn callback. Operations executed with the SessionContext observe the results of the operations that
+// precede them in fn, so a read that follows a write will see the write even if the read is sent to a secondary. For
+// this guarantee to hold when members of a replica set fail, operations must use a "majority" read concern and
+// write concern.
+//
+// The opts parameter can be used to specify additional options for the session. The CausalConsistency option is
+// always set to true and the Snapshot option must not be set to true.
+//
+// Any error returned by the fn callback will be returned without any modifications.
+func (c *Client) WithCausalSession(ctx context.Context, fn func(SessionContext) error,
+	opts ...*options.SessionOptions) error {
+
+	sopts := options.MergeSessionOptions(opts...).SetCausalConsistency(true)
+	return c.UseSessionWithOptions(ctx, sopts, fn)
+}
+
 // Watch returns a change stream for all changes on the deployment. See
 // https://docs.mongodb.com/manual/changeStreams/ for more information about change streams.
 //
@@ -948,8 +1444,35 @@ func (c *Client) Watch(ctx context.Context, pipeline interface{},
 	return newChangeStream(ctx, csConfig, pipeline, opts...)
 }
 
+// ReadConcern returns the default read concern of the Client, which is inherited by Databases that do not specify a
+// read concern. If no read concern was specified in the ClientOptions, this is an empty read concern, meaning the
+// server default is used.
+func (c *Client) ReadConcern() *readconcern.ReadConcern {
+	return c.readConcern
+}
+
+// ReadPreference returns the default read preference of the Client, which is inherited by Databases that do not
+// specify a read preference. If no read preference was specified in the ClientOptions, this is readpref.Primary().
+func (c *Client) ReadPreference() *readpref.ReadPref {
+	return c.readPreference
+}
+
+// WriteConcern returns the default write concern of the Client, which is inherited by Databases that do not specify a
+// write concern. If no write concern was specified in the ClientOptions, this is nil, meaning the server default is
+// used.
+func (c *Client) WriteConcern() *writeconcern.WriteConcern {
+	return c.writeConcern
+}
+
 // NumberSessionsInProgress returns the number of sessions that have been started for this client but have not been
 // closed (i.e. EndSession has not been called).
 func (c *Client) NumberSessionsInProgress() int {
 	return c.sessionPool.CheckedOut()
 }
+
+// NumberSessionsInPool returns the number of server sessions that are waiting in the session pool of this client to
+// be reused. Together with NumberSessionsInProgress, this can be used to diagnose session leaks: sessions that are
+// started but never ended are not returned to the pool.
+func (c *Client) NumberSessionsInPool() int {
+	return c.sessionPool.Pooled()
+}
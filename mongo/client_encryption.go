@@ -13,6 +13,7 @@ import (
 
 	"github.com/pkg/errors"
 	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/bsoncodec"
 	"go.mongodb.org/mongo-driver/bson/primitive"
 	"go.mongodb.org/mongo-driver/mongo/options"
 	"go.mongodb.org/mongo-driver/x/bsonx/bsoncore"
@@ -93,6 +94,98 @@ func (ce *ClientEncryption) CreateDataKey(ctx context.Context, kmsProvider strin
 	return primitive.Binary{Subtype: subtype, Data: data}, nil
 }
 
+// CreateEncryptedCollection creates a collection for Queryable Encryption with the EncryptedFields option of
+// createOpts (see Database.CreateCollection). A new data key is created with the given KMS provider and master key
+// for each encrypted field whose keyId is null, and the keyId of the field is set to the _id of the data key. The
+// masterKey parameter can be nil for KMS providers that do not require one.
+//
+// Returns the created collection and the encrypted fields it was created with. If an error occurs, the returned
+// encrypted fields contain the keyIds of the data keys that were created before the error, so they can be reused.
+func (ce *ClientEncryption) CreateEncryptedCollection(ctx context.Context, db *Database, coll string,
+	createOpts *options.CreateCollectionOptions, kmsProvider string, masterKey interface{}) (*Collection, bson.M, error) {
+
+	if createOpts == nil || createOpts.EncryptedFields == nil {
+		return nil, nil, errors.New("no EncryptedFields defined for the collection")
+	}
+	encryptedFields, err := encryptedFieldsMap(db.registry, createOpts.EncryptedFields)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	dko := options.DataKey()
+	if masterKey != nil {
+		dko.SetMasterKey(masterKey)
+	}
+	err = createMissingDataKeys(encryptedFields, func() (primitive.Binary, error) {
+		return ce.CreateDataKey(ctx, kmsProvider, dko)
+	})
+	if err != nil {
+		return nil, encryptedFields, err
+	}
+
+	cco := options.MergeCreateCollectionOptions(createOpts, options.CreateCollection().SetEncryptedFields(encryptedFields))
+	if err = db.CreateCollection(ctx, coll, cco); err != nil {
+		return nil, encryptedFields, err
+	}
+	return db.Collection(coll), encryptedFields, nil
+}
+
+// SetupEncryptedCollection creates a collection for Queryable Encryption with the given encrypted fields like
+// ClientEncryption.CreateEncryptedCollection, using the key vault and KMS providers of the client of db. The client
+// must be configured with AutoEncryptionOptions.
+func SetupEncryptedCollection(ctx context.Context, db *Database, name string, encryptedFields interface{},
+	kmsProvider string, masterKey interface{}) (*Collection, bson.M, error) {
+
+	c := db.client
+	if c.cryptFLE == nil {
+		return nil, nil, errors.New("client must be configured with AutoEncryptionOptions to set up an encrypted collection")
+	}
+	ce := &ClientEncryption{
+		crypt:          c.cryptFLE,
+		keyVaultClient: c.keyVaultClientFLE,
+		keyVaultColl:   c.keyVaultCollFLE,
+	}
+	return ce.CreateEncryptedCollection(ctx, db, name, options.CreateCollection().SetEncryptedFields(encryptedFields),
+		kmsProvider, masterKey)
+}
+
+// encryptedFieldsMap returns a copy of encryptedFields as a bson.M whose fields array can be modified.
+func encryptedFieldsMap(registry *bsoncodec.Registry, encryptedFields interface{}) (bson.M, error) {
+	doc, err := transformBsoncoreDocument(registry, encryptedFields, true, "encryptedFields")
+	if err != nil {
+		return nil, err
+	}
+	var m bson.M
+	if err = bson.Unmarshal(doc, &m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// createMissingDataKeys sets the keyId of each field in encryptedFields whose keyId is null to a data key created with
+// createDataKey. It stops at the first error and leaves the remaining keyIds null.
+func createMissingDataKeys(encryptedFields bson.M, createDataKey func() (primitive.Binary, error)) error {
+	fields, ok := encryptedFields["fields"].(bson.A)
+	if !ok {
+		return errors.New("encryptedFields must have a fields array")
+	}
+	for i, field := range fields {
+		fieldMap, ok := field.(bson.M)
+		if !ok {
+			return fmt.Errorf("encrypted field at index %d must be a document", i)
+		}
+		if keyID, ok := fieldMap["keyId"]; !ok || keyID != nil {
+			continue
+		}
+		keyID, err := createDataKey()
+		if err != nil {
+			return err
+		}
+		fieldMap["keyId"] = keyID
+	}
+	return nil
+}
+
 // Encrypt encrypts a BSON value with the given key and algorithm. Returns an encrypted value (BSON binary of subtype 6).
 func (ce *ClientEncryption) Encrypt(ctx context.Context, val bson.RawValue, opts ...*options.EncryptOptions) (primitive.Binary, error) {
 	eo := options.MergeEncryptOptions(opts...)
@@ -0,0 +1,76 @@
+// Copyright (C) MongoDB, Inc. 2021-present.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
+
+package mongo
+
+import (
+	"errors"
+	"testing"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/internal/testutil/assert"
+)
+
+func TestEncryptedCollection(t *testing.T) {
+	existingKey := primitive.Binary{Subtype: 4, Data: []byte{1}}
+	newEncryptedFields := func(t *testing.T) bson.M {
+		t.Helper()
+
+		ef, err := encryptedFieldsMap(bson.DefaultRegistry, bson.D{{"fields", bson.A{
+			bson.D{{"path", "ssn"}, {"bsonType", "string"}, {"keyId", nil}},
+			bson.D{{"path", "dob"}, {"bsonType", "date"}, {"keyId", existingKey}},
+			bson.D{{"path", "phone"}, {"bsonType", "string"}, {"keyId", nil}},
+		}}})
+		assert.Nil(t, err, "encryptedFieldsMap error: %v", err)
+		return ef
+	}
+	keyID := func(ef bson.M, i int) interface{} {
+		return ef["fields"].(bson.A)[i].(bson.M)["keyId"]
+	}
+
+	t.Run("null keyIds are replaced", func(t *testing.T) {
+		ef := newEncryptedFields(t)
+		var created byte
+		err := createMissingDataKeys(ef, func() (primitive.Binary, error) {
+			created++
+			return primitive.Binary{Subtype: 4, Data: []byte{created + 1}}, nil
+		})
+		assert.Nil(t, err, "createMissingDataKeys error: %v", err)
+
+		assert.Equal(t, byte(2), created, "expected 2 data keys to be created, got %v", created)
+		assert.Equal(t, primitive.Binary{Subtype: 4, Data: []byte{2}}, keyID(ef, 0), "unexpected keyId %v", keyID(ef, 0))
+		assert.Equal(t, existingKey, keyID(ef, 1), "expected keyId %v, got %v", existingKey, keyID(ef, 1))
+		assert.Equal(t, primitive.Binary{Subtype: 4, Data: []byte{3}}, keyID(ef, 2), "unexpected keyId %v", keyID(ef, 2))
+	})
+	t.Run("error keeps created keys", func(t *testing.T) {
+		ef := newEncryptedFields(t)
+		createErr := errors.New("kms error")
+		calls := 0
+		err := createMissingDataKeys(ef, func() (primitive.Binary, error) {
+			calls++
+			if calls > 1 {
+				return primitive.Binary{}, createErr
+			}
+			return primitive.Binary{Subtype: 4, Data: []byte{2}}, nil
+		})
+		assert.Equal(t, createErr, err, "expected error %v, got %v", createErr, err)
+
+		assert.NotNil(t, keyID(ef, 0), "expected first keyId to be set")
+		assert.Nil(t, keyID(ef, 2), "expected last keyId to be null, got %v", keyID(ef, 2))
+	})
+	t.Run("fields array is required", func(t *testing.T) {
+		err := createMissingDataKeys(bson.M{}, func() (primitive.Binary, error) {
+			return primitive.Binary{}, nil
+		})
+		assert.NotNil(t, err, "expected createMissingDataKeys error, got nil")
+	})
+	t.Run("setup requires auto encryption", func(t *testing.T) {
+		db := setupDb("db")
+		_, _, err := SetupEncryptedCollection(bgCtx, db, "coll", bson.D{{"fields", bson.A{}}}, "local", nil)
+		assert.NotNil(t, err, "expected SetupEncryptedCollection error, got nil")
+	})
+}
@@ -10,6 +10,7 @@ import (
 	"context"
 	"errors"
 	"math"
+	"strings"
 	"testing"
 	"time"
 
@@ -17,6 +18,7 @@ import (
 	"go.mongodb.org/mongo-driver/event"
 	"go.mongodb.org/mongo-driver/internal/testutil"
 	"go.mongodb.org/mongo-driver/internal/testutil/assert"
+	"go.mongodb.org/mongo-driver/mongo/address"
 	"go.mongodb.org/mongo-driver/mongo/description"
 	"go.mongodb.org/mongo-driver/mongo/options"
 	"go.mongodb.org/mongo-driver/mongo/readconcern"
@@ -25,6 +27,7 @@ import (
 	"go.mongodb.org/mongo-driver/tag"
 	"go.mongodb.org/mongo-driver/x/mongo/driver"
 	"go.mongodb.org/mongo-driver/x/mongo/driver/session"
+	"go.mongodb.org/mongo-driver/x/mongo/driver/topology"
 )
 
 var bgCtx = context.Background()
@@ -61,6 +64,18 @@ func TestClient(t *testing.T) {
 		assert.Equal(t, dbName, db.Name(), "expected db name %v, got %v", dbName, db.Name())
 		assert.Equal(t, client, db.Client(), "expected client %v, got %v", client, db.Client())
 	})
+	t.Run("default database", func(t *testing.T) {
+		client := setupClient(options.Client().ApplyURI("mongodb://localhost/foo"))
+		assert.Equal(t, "foo", client.DefaultDatabase(), "expected default database foo, got %v",
+			client.DefaultDatabase())
+		db := client.Database("")
+		assert.Equal(t, "foo", db.Name(), "expected db name foo, got %v", db.Name())
+		db = client.Database("bar")
+		assert.Equal(t, "bar", db.Name(), "expected db name bar, got %v", db.Name())
+
+		client = setupClient()
+		assert.Equal(t, "", client.DefaultDatabase(), "expected no default database, got %v", client.DefaultDatabase())
+	})
 	t.Run("replace topology error", func(t *testing.T) {
 		client := setupClient()
 
@@ -78,6 +93,9 @@ func TestClient(t *testing.T) {
 
 		_, err = client.Watch(bgCtx, []bson.D{})
 		assert.Equal(t, ErrClientDisconnected, err, "expected error %v, got %v", ErrClientDisconnected, err)
+
+		_, err = client.WarmUp(bgCtx)
+		assert.Equal(t, ErrClientDisconnected, err, "expected error %v, got %v", ErrClientDisconnected, err)
 	})
 	t.Run("nil document error", func(t *testing.T) {
 		// manually set session pool to non-nil because Watch will return ErrClientDisconnected
@@ -102,6 +120,8 @@ func TestClient(t *testing.T) {
 			assert.Equal(t, gotMode, wantMode, "expected mode %v, got %v", wantMode, gotMode)
 			_, flag := client.readPreference.MaxStaleness()
 			assert.False(t, flag, "expected max staleness to not be set but was")
+			assert.Equal(t, client.readPreference, client.ReadPreference(), "expected read preference %v, got %v",
+				client.readPreference, client.ReadPreference())
 		})
 		t.Run("specified", func(t *testing.T) {
 			tags := []tag.Set{
@@ -174,10 +194,19 @@ func TestClient(t *testing.T) {
 			})
 		}
 	})
+	t.Run("default find options", func(t *testing.T) {
+		client := setupClient(options.Client().SetDefaultFindLimit(10).SetDefaultFindMaxTime(time.Second))
+		assert.NotNil(t, client.defaultFindLimit, "expected defaultFindLimit to be set, got nil")
+		assert.Equal(t, int64(10), *client.defaultFindLimit, "expected defaultFindLimit 10, got %v", *client.defaultFindLimit)
+		assert.NotNil(t, client.defaultFindMaxTime, "expected defaultFindMaxTime to be set, got nil")
+		assert.Equal(t, time.Second, *client.defaultFindMaxTime,
+			"expected defaultFindMaxTime %v, got %v", time.Second, *client.defaultFindMaxTime)
+	})
 	t.Run("read concern", func(t *testing.T) {
 		rc := readconcern.Majority()
 		client := setupClient(options.Client().SetReadConcern(rc))
 		assert.Equal(t, rc, client.readConcern, "expected read concern %v, got %v", rc, client.readConcern)
+		assert.Equal(t, rc, client.ReadConcern(), "expected read concern %v, got %v", rc, client.ReadConcern())
 	})
 	t.Run("retry writes", func(t *testing.T) {
 		retryWritesURI := "mongodb://localhost:27017/?retryWrites=false"
@@ -239,12 +268,322 @@ func TestClient(t *testing.T) {
 		wc := writeconcern.New(writeconcern.WMajority())
 		client := setupClient(options.Client().SetWriteConcern(wc))
 		assert.Equal(t, wc, client.writeConcern, "mismatch; expected write concern %v, got %v", wc, client.writeConcern)
+		assert.Equal(t, wc, client.WriteConcern(), "mismatch; expected write concern %v, got %v", wc, client.WriteConcern())
+	})
+	t.Run("command monitor redactor", func(t *testing.T) {
+		var started *event.CommandStartedEvent
+		monitor := &event.CommandMonitor{
+			Started: func(_ context.Context, evt *event.CommandStartedEvent) {
+				started = evt
+			},
+		}
+		redactor := func(cmd bson.Raw) bson.Raw {
+			redacted, err := bson.Marshal(bson.D{{"insert", cmd.Lookup("insert").StringValue()}})
+			assert.Nil(t, err, "Marshal error: %v", err)
+			return redacted
+		}
+		client := setupClient(options.Client().SetMonitor(monitor).SetCommandMonitorRedactor(redactor))
+
+		cmd, err := bson.Marshal(bson.D{{"insert", "users"}, {"documents", bson.A{bson.D{{"ssn", "123-45-6789"}}}}})
+		assert.Nil(t, err, "Marshal error: %v", err)
+		client.monitor.Started(bgCtx, &event.CommandStartedEvent{Command: cmd, CommandName: "insert"})
+		expected, err := bson.Marshal(bson.D{{"insert", "users"}})
+		assert.Nil(t, err, "Marshal error: %v", err)
+		assert.Equal(t, bson.Raw(expected), started.Command, "expected command %v, got %v", expected, started.Command)
+
+		client.monitor.Started(bgCtx, &event.CommandStartedEvent{Command: bson.Raw{}, CommandName: "saslStart"})
+		assert.Equal(t, 0, len(started.Command), "expected redacted command to stay empty, got %v", started.Command)
 	})
 	t.Run("server monitor", func(t *testing.T) {
 		monitor := &event.ServerMonitor{}
 		client := setupClient(options.Client().SetServerMonitor(monitor))
 		assert.Equal(t, monitor, client.serverMonitor, "expected sdam monitor %v, got %v", monitor, client.serverMonitor)
 	})
+	t.Run("session leak detection", func(t *testing.T) {
+		var events []*event.SessionThresholdExceededEvent
+		monitor := &event.SessionMonitor{
+			ThresholdExceeded: func(evt *event.SessionThresholdExceededEvent) {
+				events = append(events, evt)
+			},
+		}
+		client := setupClient(options.Client().SetSessionLeakThreshold(1).SetSessionMonitor(monitor))
+		client.sessionPool = session.NewPool(nil)
+		client.sessionPool.SetCheckedOutThreshold(client.sessionLeaks.threshold, client.sessionLeaks.thresholdExceeded)
+
+		first, err := client.StartSession()
+		assert.Nil(t, err, "StartSession error: %v", err)
+		ended, err := client.StartSession()
+		assert.Nil(t, err, "StartSession error: %v", err)
+		assert.Equal(t, 1, len(events), "expected 1 event, got %v", len(events))
+		ended.EndSession(bgCtx)
+
+		_, err = client.StartSession()
+		assert.Nil(t, err, "StartSession error: %v", err)
+		assert.Equal(t, 2, len(events), "expected 2 events, got %v", len(events))
+
+		evt := events[1]
+		assert.Equal(t, 2, evt.CheckedOut, "expected 2 checked out sessions, got %v", evt.CheckedOut)
+		assert.Equal(t, 1, evt.Threshold, "expected threshold 1, got %v", evt.Threshold)
+		assert.Equal(t, 1, len(evt.Sessions), "expected 1 outstanding session, got %v", len(evt.Sessions))
+		firstID := bson.Raw(first.(*sessionImpl).clientSession.SessionID)
+		assert.Equal(t, firstID, evt.Sessions[0].SessionID,
+			"expected session ID %v, got %v", firstID, evt.Sessions[0].SessionID)
+		assert.True(t, strings.Contains(evt.Sessions[0].Stack, "StartSession"),
+			"expected stack to contain StartSession, got %v", evt.Sessions[0].Stack)
+	})
+	t.Run("session leak detection without a handler", func(t *testing.T) {
+		client := setupClient(options.Client().SetSessionLeakThreshold(1))
+		assert.Nil(t, client.sessionLeaks, "expected session leak detection to be disabled, got %v", client.sessionLeaks)
+	})
+	t.Run("session monitor", func(t *testing.T) {
+		var events []*event.SessionEvent
+		monitor := &event.SessionMonitor{
+			Event: func(evt *event.SessionEvent) {
+				events = append(events, evt)
+			},
+		}
+		client := setupClient(options.Client().SetSessionMonitor(monitor))
+		assert.True(t, monitor == client.sessionMonitor, "expected session monitor %v, got %v", monitor,
+			client.sessionMonitor)
+		client.sessionPool = session.NewPool(nil)
+		client.sessionPool.SetMonitor(client.sessionMonitor)
+
+		sess, err := client.StartSession()
+		assert.Nil(t, err, "StartSession error: %v", err)
+		assert.Equal(t, 1, client.NumberSessionsInProgress(), "expected 1 session in progress, got %v",
+			client.NumberSessionsInProgress())
+		sess.EndSession(bgCtx)
+		// The pool has no session timeout because it does not receive topology updates, so the session expires when
+		// it is returned.
+		assert.Equal(t, 0, client.NumberSessionsInPool(), "expected 0 pooled sessions, got %v",
+			client.NumberSessionsInPool())
+
+		var types []string
+		for _, evt := range events {
+			types = append(types, evt.Type)
+		}
+		expected := []string{event.SessionCreated, event.SessionEnded, event.SessionExpired}
+		assert.Equal(t, expected, types, "expected events %v, got %v", expected, types)
+		assert.False(t, events[1].Implicit, "expected explicit session")
+	})
+	t.Run("reconnect", func(t *testing.T) {
+		client := setupClient()
+		err := client.Reconnect(bgCtx)
+		assert.NotNil(t, err, "expected Reconnect error for client that was never connected, got nil")
+
+		err = client.Connect(bgCtx)
+		assert.Nil(t, err, "Connect error: %v", err)
+		pool := client.sessionPool
+		err = client.Reconnect(bgCtx)
+		assert.NotNil(t, err, "expected Reconnect error for connected client, got nil")
+
+		err = client.Disconnect(bgCtx)
+		assert.Nil(t, err, "Disconnect error: %v", err)
+		err = client.Reconnect(bgCtx)
+		assert.Nil(t, err, "Reconnect error: %v", err)
+		assert.True(t, pool == client.sessionPool, "expected session pool to be reused")
+		err = client.Disconnect(bgCtx)
+		assert.Nil(t, err, "Disconnect error: %v", err)
+	})
+	t.Run("reset", func(t *testing.T) {
+		client := setupClient()
+		err := client.Reset(bgCtx)
+		assert.Equal(t, ErrClientDisconnected, err, "expected error %v, got %v", ErrClientDisconnected, err)
+
+		err = client.Connect(bgCtx)
+		assert.Nil(t, err, "Connect error: %v", err)
+		err = client.Reset(bgCtx)
+		assert.Nil(t, err, "Reset error: %v", err)
+		err = client.Disconnect(bgCtx)
+		assert.Nil(t, err, "Disconnect error: %v", err)
+		err = client.Reset(bgCtx)
+		assert.Equal(t, ErrClientDisconnected, err, "expected error %v, got %v", ErrClientDisconnected, err)
+
+		shared, err := NewClientFromDeployment(mockDeployment{})
+		assert.Nil(t, err, "NewClientFromDeployment error: %v", err)
+		err = shared.Reset(bgCtx)
+		assert.NotNil(t, err, "expected Reset error for client created from a deployment, got nil")
+	})
+	t.Run("session helpers", func(t *testing.T) {
+		client := setupClient()
+		client.sessionPool = session.NewPool(nil)
+
+		t.Run("snapshot and causal consistency conflict", func(t *testing.T) {
+			_, err := client.StartSession(options.Session().SetSnapshot(true).SetCausalConsistency(true))
+			assert.NotNil(t, err, "expected StartSession error, got nil")
+		})
+		t.Run("default causal consistency", func(t *testing.T) {
+			options.DefaultCausalConsistency = false
+			defer func() { options.DefaultCausalConsistency = true }()
+
+			sess, err := client.StartSession()
+			assert.Nil(t, err, "StartSession error: %v", err)
+			defer sess.EndSession(bgCtx)
+			cs := sess.(*sessionImpl).clientSession
+			assert.False(t, cs.Consistent, "expected session without causal consistency")
+
+			sess, err = client.StartSession(options.Session().SetCausalConsistency(true))
+			assert.Nil(t, err, "StartSession error: %v", err)
+			defer sess.EndSession(bgCtx)
+			cs = sess.(*sessionImpl).clientSession
+			assert.True(t, cs.Consistent, "expected causally consistent session")
+		})
+		t.Run("snapshot session retries SnapshotUnavailable", func(t *testing.T) {
+			var calls int
+			err := client.WithSnapshotSession(bgCtx, func(sc SessionContext) error {
+				calls++
+				cs := sc.(*sessionContext).Session.(*sessionImpl).clientSession
+				assert.True(t, cs.Snapshot, "expected snapshot session")
+				assert.False(t, cs.Consistent, "expected session without causal consistency")
+				if calls == 1 {
+					return CommandError{Code: 246, Name: "SnapshotUnavailable"}
+				}
+				return nil
+			})
+			assert.Nil(t, err, "WithSnapshotSession error: %v", err)
+			assert.Equal(t, 2, calls, "expected callback to be called 2 times, got %v", calls)
+		})
+		t.Run("snapshot session returns other errors", func(t *testing.T) {
+			callbackErr := errors.New("callback error")
+			var calls int
+			err := client.WithSnapshotSession(bgCtx, func(SessionContext) error {
+				calls++
+				return callbackErr
+			})
+			assert.Equal(t, callbackErr, err, "expected error %v, got %v", callbackErr, err)
+			assert.Equal(t, 1, calls, "expected callback to be called 1 time, got %v", calls)
+		})
+		t.Run("causal session", func(t *testing.T) {
+			err := client.WithCausalSession(bgCtx, func(sc SessionContext) error {
+				cs := sc.(*sessionContext).Session.(*sessionImpl).clientSession
+				assert.True(t, cs.Consistent, "expected causally consistent session")
+				return nil
+			}, options.Session().SetCausalConsistency(false))
+			assert.Nil(t, err, "WithCausalSession error: %v", err)
+		})
+	})
+	t.Run("facade", func(t *testing.T) {
+		t.Run("not connected", func(t *testing.T) {
+			_, err := setupClient().NewFacade()
+			assert.Equal(t, ErrClientDisconnected, err, "expected error %v, got %v", ErrClientDisconnected, err)
+		})
+		t.Run("options", func(t *testing.T) {
+			client := setupClient()
+			client.sessionPool = session.NewPool(nil)
+
+			rc := readconcern.Majority()
+			wc := writeconcern.New(writeconcern.WMajority())
+			rp := readpref.Secondary()
+			reg := bson.NewRegistryBuilder().Build()
+			facade, err := client.NewFacade(options.ClientFacade().SetReadConcern(rc).SetWriteConcern(wc).
+				SetReadPreference(rp).SetRegistry(reg))
+			assert.Nil(t, err, "NewFacade error: %v", err)
+
+			assert.Equal(t, rc, facade.readConcern, "expected read concern %v, got %v", rc, facade.readConcern)
+			assert.Equal(t, wc, facade.writeConcern, "expected write concern %v, got %v", wc, facade.writeConcern)
+			assert.Equal(t, rp, facade.readPreference, "expected read preference %v, got %v", rp, facade.readPreference)
+			assert.True(t, reg == facade.registry, "expected facade to use the given registry")
+			assert.NotEqual(t, rp, client.readPreference, "expected read preference of the client to be unchanged")
+
+			assert.True(t, client.deployment == facade.deployment, "expected facade to share the deployment")
+			assert.True(t, client.sessionPool == facade.sessionPool, "expected facade to share the session pool")
+		})
+		t.Run("shared sessions", func(t *testing.T) {
+			client := setupClient()
+			client.sessionPool = session.NewPool(nil)
+			facade, err := client.NewFacade()
+			assert.Nil(t, err, "NewFacade error: %v", err)
+
+			sess, err := client.StartSession()
+			assert.Nil(t, err, "StartSession error: %v", err)
+			defer sess.EndSession(bgCtx)
+
+			err = facade.validSession(sess.(*sessionImpl).clientSession)
+			assert.Nil(t, err, "expected session to be valid for the facade, got error %v", err)
+		})
+		t.Run("disconnect", func(t *testing.T) {
+			client := setupClient()
+			client.sessionPool = session.NewPool(nil)
+			facade, err := client.NewFacade()
+			assert.Nil(t, err, "NewFacade error: %v", err)
+
+			err = facade.Connect(bgCtx)
+			assert.Nil(t, err, "Connect error: %v", err)
+			err = facade.Disconnect(bgCtx)
+			assert.Nil(t, err, "Disconnect error: %v", err)
+		})
+	})
+	t.Run("DisconnectWithReport", func(t *testing.T) {
+		client := setupClient()
+		err := client.Connect(bgCtx)
+		assert.Nil(t, err, "Connect error: %v", err)
+
+		_, err = client.StartSession()
+		assert.Nil(t, err, "StartSession error: %v", err)
+
+		report, err := client.DisconnectWithReport(bgCtx)
+		assert.Nil(t, err, "DisconnectWithReport error: %v", err)
+		assert.Equal(t, 1, report.SessionsInProgress, "expected 1 session in progress, got %v", report.SessionsInProgress)
+		assert.Equal(t, 0, report.SessionsEnded, "expected 0 sessions ended, got %v", report.SessionsEnded)
+		assert.Equal(t, 0, report.InUseConnectionsClosed,
+			"expected 0 in use connections closed, got %v", report.InUseConnectionsClosed)
+	})
+	t.Run("ServerRTTStats", func(t *testing.T) {
+		client := setupClient()
+		err := client.Connect(bgCtx)
+		assert.Nil(t, err, "Connect error: %v", err)
+		defer func() { _ = client.Disconnect(bgCtx) }()
+
+		stats := client.ServerRTTStats()
+		_, ok := stats["localhost:27017"]
+		assert.True(t, ok, "expected stats for localhost:27017, got %v", stats)
+	})
+	t.Run("NewClientFromDeployment", func(t *testing.T) {
+		newTopology := func(t *testing.T) *topology.Topology {
+			t.Helper()
+
+			topo, err := topology.New(topology.WithSeedList(func(...string) []string { return []string{"localhost:27017"} }))
+			assert.Nil(t, err, "topology.New error: %v", err)
+			err = topo.Connect()
+			assert.Nil(t, err, "topology Connect error: %v", err)
+			return topo
+		}
+
+		t.Run("nil deployment", func(t *testing.T) {
+			_, err := NewClientFromDeployment(nil)
+			assert.NotNil(t, err, "expected NewClientFromDeployment error, got nil")
+		})
+		t.Run("topology options", func(t *testing.T) {
+			topo := newTopology(t)
+			defer func() { _ = topo.Disconnect(bgCtx) }()
+
+			_, err := NewClientFromDeployment(topo, options.Client().SetMaxPoolSize(10))
+			assert.NotNil(t, err, "expected NewClientFromDeployment error, got nil")
+		})
+		t.Run("shares deployment", func(t *testing.T) {
+			topo := newTopology(t)
+			defer func() { _ = topo.Disconnect(bgCtx) }()
+
+			wc := writeconcern.New(writeconcern.WMajority())
+			first, err := NewClientFromDeployment(topo, options.Client().SetWriteConcern(wc))
+			assert.Nil(t, err, "NewClientFromDeployment error: %v", err)
+			second, err := NewClientFromDeployment(topo)
+			assert.Nil(t, err, "NewClientFromDeployment error: %v", err)
+			assert.Equal(t, wc, first.writeConcern, "expected write concern %v, got %v", wc, first.writeConcern)
+
+			err = first.Connect(bgCtx)
+			assert.Nil(t, err, "Connect error: %v", err)
+			err = second.Connect(bgCtx)
+			assert.Nil(t, err, "Connect error: %v", err)
+			assert.True(t, first.deployment == second.deployment, "expected clients to share the deployment")
+			assert.True(t, first.sessionPool != second.sessionPool, "expected clients to have their own session pools")
+
+			err = first.Disconnect(bgCtx)
+			assert.Nil(t, err, "Disconnect error: %v", err)
+			err = topo.Disconnect(bgCtx)
+			assert.Nil(t, err, "expected deployment to remain connected, got Disconnect error %v", err)
+		})
+	})
 	t.Run("GetURI", func(t *testing.T) {
 		t.Run("ApplyURI not called", func(t *testing.T) {
 			opts := options.Client().SetHosts([]string{"localhost:27017"})
@@ -381,4 +720,49 @@ func TestClient(t *testing.T) {
 				"unexpected modification to serverAPI; expected %v, got %v", convertedAPIOptions, client.serverAPI)
 		})
 	})
+	t.Run("orphaned cursor targets", func(t *testing.T) {
+		selected := func(t *testing.T, target orphanedCursorTarget, topo description.Topology) []address.Address {
+			t.Helper()
+
+			servers, err := target.selector.SelectServer(topo, topo.Servers)
+			assert.Nil(t, err, "SelectServer error: %v", err)
+			var addrs []address.Address
+			for _, s := range servers {
+				addrs = append(addrs, s.Addr)
+			}
+			return addrs
+		}
+
+		t.Run("replica set", func(t *testing.T) {
+			topo := description.Topology{
+				Kind: description.ReplicaSetWithPrimary,
+				Servers: []description.Server{
+					{Addr: address.Address("a:27017"), Kind: description.RSPrimary},
+					{Addr: address.Address("b:27017"), Kind: description.RSSecondary},
+					{Addr: address.Address("c:27017"), Kind: description.RSArbiter},
+				},
+			}
+			targets := newOrphanedCursorTargets(topo.Servers)
+			assert.Equal(t, 2, len(targets), "expected 2 targets, got %v", len(targets))
+			for i, addr := range []address.Address{"a:27017", "b:27017"} {
+				got := selected(t, targets[i], topo)
+				assert.Equal(t, []address.Address{addr}, got, "expected server %v, got %v", addr, got)
+				assert.False(t, targets[i].localOps, "expected localOps to not be set for %v", addr)
+			}
+		})
+		t.Run("sharded cluster", func(t *testing.T) {
+			topo := description.Topology{
+				Kind: description.Sharded,
+				Servers: []description.Server{
+					{Addr: address.Address("mongos1:27017"), Kind: description.Mongos},
+					{Addr: address.Address("mongos2:27017"), Kind: description.Mongos},
+				},
+			}
+			targets := newOrphanedCursorTargets(topo.Servers)
+			assert.Equal(t, 2, len(targets), "expected 2 targets, got %v", len(targets))
+			got := selected(t, targets[1], topo)
+			assert.Equal(t, []address.Address{"mongos2:27017"}, got, "expected server mongos2:27017, got %v", got)
+			assert.True(t, targets[1].localOps, "expected localOps to be set for a mongos")
+		})
+	})
 }
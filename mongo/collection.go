@@ -10,12 +10,15 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"strconv"
 	"strings"
 	"time"
 
 	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/bson/bsoncodec"
 	"go.mongodb.org/mongo-driver/bson/bsontype"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo/address"
 	"go.mongodb.org/mongo-driver/mongo/description"
 	"go.mongodb.org/mongo-driver/mongo/options"
 	"go.mongodb.org/mongo-driver/mongo/readconcern"
@@ -38,6 +41,10 @@ type Collection struct {
 	readSelector   description.ServerSelector
 	writeSelector  description.ServerSelector
 	registry       *bsoncodec.Registry
+
+	filterPredicate func(context.Context) (interface{}, error)
+	ttlPolicy       *options.TTLPolicy
+	ttlFieldHandler func(error)
 }
 
 // aggregateParams is used to store information to configure an Aggregate operation.
@@ -55,6 +62,8 @@ type aggregateParams struct {
 	writeSelector  description.ServerSelector
 	readPreference *readpref.ReadPref
 	opts           []*options.AggregateOptions
+	// predicate is the filter predicate of the collection, which is added to the pipeline as a $match stage.
+	predicate bsoncore.Document
 }
 
 func closeImplicitSession(sess *session.Client) {
@@ -106,6 +115,10 @@ func newCollection(db *Database, name string, opts ...*options.CollectionOptions
 		readSelector:   readSelector,
 		writeSelector:  writeSelector,
 		registry:       reg,
+
+		filterPredicate: collOpt.FilterPredicate,
+		ttlPolicy:       collOpt.TTLPolicy,
+		ttlFieldHandler: collOpt.TTLFieldHandler,
 	}
 
 	return coll
@@ -122,6 +135,10 @@ func (coll *Collection) copy() *Collection {
 		readSelector:   coll.readSelector,
 		writeSelector:  coll.writeSelector,
 		registry:       coll.registry,
+
+		filterPredicate: coll.filterPredicate,
+		ttlPolicy:       coll.ttlPolicy,
+		ttlFieldHandler: coll.ttlFieldHandler,
 	}
 }
 
@@ -148,6 +165,18 @@ func (coll *Collection) Clone(opts ...*options.CollectionOptions) (*Collection,
 		copyColl.registry = optsColl.Registry
 	}
 
+	if optsColl.FilterPredicate != nil {
+		copyColl.filterPredicate = optsColl.FilterPredicate
+	}
+
+	if optsColl.TTLPolicy != nil {
+		copyColl.ttlPolicy = optsColl.TTLPolicy
+	}
+
+	if optsColl.TTLFieldHandler != nil {
+		copyColl.ttlFieldHandler = optsColl.TTLFieldHandler
+	}
+
 	copyColl.readSelector = description.CompositeSelector([]description.ServerSelector{
 		description.ReadPrefSelector(copyColl.readPreference),
 		description.LatencySelector(copyColl.client.localThreshold),
@@ -156,6 +185,95 @@ func (coll *Collection) Clone(opts ...*options.CollectionOptions) (*Collection,
 	return copyColl, nil
 }
 
+// transformFilter transforms filter into a document. If the collection has a filter predicate, the predicate returned
+// for ctx is combined with the filter using $and.
+func (coll *Collection) transformFilter(ctx context.Context, filter interface{}) (bsoncore.Document, error) {
+	f, err := transformBsoncoreDocument(coll.registry, filter, true, "filter")
+	if err != nil {
+		return nil, err
+	}
+	p, err := coll.transformFilterPredicate(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if p == nil {
+		return f, nil
+	}
+	if len(f) == bsoncore.EmptyDocumentLength {
+		return p, nil
+	}
+
+	aidx, arr := bsoncore.AppendArrayStart(nil)
+	arr = bsoncore.AppendDocumentElement(arr, "0", f)
+	arr = bsoncore.AppendDocumentElement(arr, "1", p)
+	arr, _ = bsoncore.AppendArrayEnd(arr, aidx)
+	return bsoncore.BuildDocument(nil, bsoncore.AppendArrayElement(nil, "$and", arr)), nil
+}
+
+// transformFilterPredicate returns the filter predicate of the collection for ctx as a document. It returns nil if the
+// collection has no filter predicate or the predicate for ctx is nil.
+func (coll *Collection) transformFilterPredicate(ctx context.Context) (bsoncore.Document, error) {
+	if coll.filterPredicate == nil {
+		return nil, nil
+	}
+
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	predicate, err := coll.filterPredicate(ctx)
+	if err != nil || predicate == nil {
+		return nil, err
+	}
+	return transformBsoncoreDocument(coll.registry, predicate, true, "filter predicate")
+}
+
+// addPredicateStage returns pipeline with a $match stage for predicate. The stage is added first so that the rest of
+// the pipeline only sees matching documents, unless the pipeline starts with a $geoNear, $search, or $vectorSearch
+// stage, which must be the first stage and is followed by the $match stage instead. Pipelines that start with a stage
+// that outputs metadata rather than the documents of the collection, such as $collStats, $indexStats, $changeStream,
+// or $listSearchIndexes, are returned unchanged, as are all pipelines if predicate is nil.
+func addPredicateStage(pipeline bsoncore.Document, predicate bsoncore.Document) (bsoncore.Document, error) {
+	if predicate == nil {
+		return pipeline, nil
+	}
+	values, err := pipeline.Values()
+	if err != nil {
+		return nil, err
+	}
+
+	matchStage := bsoncore.BuildDocument(nil, bsoncore.AppendDocumentElement(nil, "$match", predicate))
+	matchIdx := 0
+	if len(values) > 0 {
+		if first, ok := values[0].DocumentOK(); ok {
+			if elem, err := first.IndexErr(0); err == nil {
+				switch elem.Key() {
+				case "$geoNear", "$search", "$vectorSearch":
+					matchIdx = 1
+				case "$collStats", "$indexStats", "$changeStream", "$listSearchIndexes", "$planCacheStats",
+					"$searchMeta":
+					return pipeline, nil
+				}
+			}
+		}
+	}
+
+	aidx, arr := bsoncore.AppendArrayStart(nil)
+	var idx int
+	for i, val := range values {
+		if i == matchIdx {
+			arr = bsoncore.AppendDocumentElement(arr, strconv.Itoa(idx), matchStage)
+			idx++
+		}
+		arr = bsoncore.AppendValueElement(arr, strconv.Itoa(idx), val)
+		idx++
+	}
+	if matchIdx == len(values) {
+		arr = bsoncore.AppendDocumentElement(arr, strconv.Itoa(idx), matchStage)
+	}
+	arr, _ = bsoncore.AppendArrayEnd(arr, aidx)
+	return arr, nil
+}
+
 // Name returns the name of the collection.
 func (coll *Collection) Name() string {
 	return coll.name
@@ -166,6 +284,35 @@ func (coll *Collection) Database() *Database {
 	return coll.db
 }
 
+// ReadConcern returns the read concern used by the Collection. This is the read concern specified in the
+// CollectionOptions or, if none was specified, the read concern inherited from the Database.
+func (coll *Collection) ReadConcern() *readconcern.ReadConcern {
+	return coll.readConcern
+}
+
+// ReadPreference returns the read preference used by the Collection. This is the read preference specified in the
+// CollectionOptions or, if none was specified, the read preference inherited from the Database.
+func (coll *Collection) ReadPreference() *readpref.ReadPref {
+	return coll.readPreference
+}
+
+// WriteConcern returns the write concern used by the Collection. This is the write concern specified in the
+// CollectionOptions or, if none was specified, the write concern inherited from the Database.
+func (coll *Collection) WriteConcern() *writeconcern.WriteConcern {
+	return coll.writeConcern
+}
+
+// IsTimeSeries executes a listCollections command filtered by the collection's name and returns true if the
+// collection is a time series collection. If the collection does not exist, false is returned.
+func (coll *Collection) IsTimeSeries(ctx context.Context) (bool, error) {
+	specs, err := coll.db.ListCollectionSpecifications(ctx, bson.D{{"name", coll.name}})
+	if err != nil {
+		return false, err
+	}
+
+	return len(specs) > 0 && specs[0].Type == "timeseries", nil
+}
+
 // BulkWrite performs a bulk write operation (https://docs.mongodb.com/manual/core/bulk-write-operations/).
 //
 // The models parameter must be a slice of operations to be executed in this bulk write. It cannot be nil or empty.
@@ -220,6 +367,8 @@ func (coll *Collection) BulkWrite(ctx context.Context, models []WriteModel,
 	op := bulkWrite{
 		ordered:                  bwo.Ordered,
 		bypassDocumentValidation: bwo.BypassDocumentValidation,
+		comment:                  bwo.Comment,
+		let:                      bwo.Let,
 		models:                   models,
 		session:                  sess,
 		collection:               coll,
@@ -233,7 +382,7 @@ func (coll *Collection) BulkWrite(ctx context.Context, models []WriteModel,
 }
 
 func (coll *Collection) insert(ctx context.Context, documents []interface{},
-	opts ...*options.InsertManyOptions) ([]interface{}, error) {
+	opts ...*options.InsertManyOptions) ([]interface{}, bson.Raw, error) {
 
 	if ctx == nil {
 		ctx = context.Background()
@@ -246,23 +395,24 @@ func (coll *Collection) insert(ctx context.Context, documents []interface{},
 		var err error
 		docs[i], result[i], err = transformAndEnsureIDv2(coll.registry, doc)
 		if err != nil {
-			return nil, err
+			return nil, nil, err
 		}
 	}
+	coll.checkTTLFields(docs)
 
 	sess := sessionFromContext(ctx)
 	if sess == nil && coll.client.sessionPool != nil {
 		var err error
 		sess, err = session.NewClientSession(coll.client.sessionPool, coll.client.id, session.Implicit)
 		if err != nil {
-			return nil, err
+			return nil, nil, err
 		}
 		defer sess.EndSession()
 	}
 
 	err := coll.client.validSession(sess)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
 	wc := coll.writeConcern
@@ -285,6 +435,13 @@ func (coll *Collection) insert(ctx context.Context, documents []interface{},
 	if imo.BypassDocumentValidation != nil && *imo.BypassDocumentValidation {
 		op = op.BypassDocumentValidation(*imo.BypassDocumentValidation)
 	}
+	if imo.Comment != nil {
+		comment, err := transformValue(coll.registry, imo.Comment, true, "comment")
+		if err != nil {
+			return nil, nil, err
+		}
+		op = op.Comment(comment)
+	}
 	if imo.Ordered != nil {
 		op = op.Ordered(*imo.Ordered)
 	}
@@ -295,9 +452,10 @@ func (coll *Collection) insert(ctx context.Context, documents []interface{},
 	op = op.Retry(retry)
 
 	err = op.Execute(ctx)
+	raw := lastReply(op.Result().Replies)
 	wce, ok := err.(driver.WriteCommandError)
 	if !ok {
-		return result, err
+		return result, raw, err
 	}
 
 	// remove the ids that had writeErrors from result
@@ -312,7 +470,7 @@ func (coll *Collection) insert(ctx context.Context, documents []interface{},
 		result = append(result[:idIndex], result[idIndex+1:]...)
 	}
 
-	return result, err
+	return result, raw, err
 }
 
 // InsertOne executes an insert command to insert a single document into the collection.
@@ -333,13 +491,16 @@ func (coll *Collection) InsertOne(ctx context.Context, document interface{},
 	if ioOpts.BypassDocumentValidation != nil && *ioOpts.BypassDocumentValidation {
 		imOpts.SetBypassDocumentValidation(*ioOpts.BypassDocumentValidation)
 	}
-	res, err := coll.insert(ctx, []interface{}{document}, imOpts)
+	if ioOpts.Comment != nil {
+		imOpts.SetComment(ioOpts.Comment)
+	}
+	res, raw, err := coll.insert(ctx, []interface{}{document}, imOpts)
 
 	rr, err := processWriteError(err)
 	if rr&rrOne == 0 {
 		return nil, err
 	}
-	return &InsertOneResult{InsertedID: res[0]}, err
+	return &InsertOneResult{InsertedID: res[0], Raw: raw}, err
 }
 
 // InsertMany executes an insert command to insert multiple documents into the collection. If write errors occur
@@ -360,7 +521,7 @@ func (coll *Collection) InsertMany(ctx context.Context, documents []interface{},
 		return nil, ErrEmptySlice
 	}
 
-	result, err := coll.insert(ctx, documents, opts...)
+	result, _, err := coll.insert(ctx, documents, opts...)
 	rr, err := processWriteError(err)
 	if rr&rrMany == 0 {
 		return nil, err
@@ -399,7 +560,7 @@ func (coll *Collection) delete(ctx context.Context, filter interface{}, deleteOn
 		ctx = context.Background()
 	}
 
-	f, err := transformBsoncoreDocument(coll.registry, filter, true, "filter")
+	f, err := coll.transformFilter(ctx, filter)
 	if err != nil {
 		return nil, err
 	}
@@ -455,9 +616,23 @@ func (coll *Collection) delete(ctx context.Context, filter interface{}, deleteOn
 		Database(coll.db.name).Collection(coll.name).
 		Deployment(coll.client.deployment).Crypt(coll.client.cryptFLE).Ordered(true).
 		ServerAPI(coll.client.serverAPI)
+	if do.Comment != nil {
+		comment, err := transformValue(coll.registry, do.Comment, true, "comment")
+		if err != nil {
+			return nil, err
+		}
+		op = op.Comment(comment)
+	}
 	if do.Hint != nil {
 		op = op.Hint(true)
 	}
+	if do.Let != nil {
+		let, err := transformBsoncoreDocument(coll.registry, do.Let, true, "let")
+		if err != nil {
+			return nil, err
+		}
+		op = op.Let(let)
+	}
 
 	// deleteMany cannot be retried
 	retryMode := driver.RetryNone
@@ -469,7 +644,7 @@ func (coll *Collection) delete(ctx context.Context, filter interface{}, deleteOn
 	if rr&expectedRr == 0 {
 		return nil, err
 	}
-	return &DeleteResult{DeletedCount: int64(op.Result().N)}, err
+	return &DeleteResult{DeletedCount: int64(op.Result().N), Raw: lastReply(op.Result().Replies)}, err
 }
 
 // DeleteOne executes a delete command to delete at most one document from the collection.
@@ -556,6 +731,20 @@ func (coll *Collection) updateOrReplace(ctx context.Context, filter bsoncore.Doc
 	if uo.BypassDocumentValidation != nil && *uo.BypassDocumentValidation {
 		op = op.BypassDocumentValidation(*uo.BypassDocumentValidation)
 	}
+	if uo.Comment != nil {
+		comment, err := transformValue(coll.registry, uo.Comment, true, "comment")
+		if err != nil {
+			return nil, err
+		}
+		op = op.Comment(comment)
+	}
+	if uo.Let != nil {
+		let, err := transformBsoncoreDocument(coll.registry, uo.Let, true, "let")
+		if err != nil {
+			return nil, err
+		}
+		op = op.Let(let)
+	}
 	retry := driver.RetryNone
 	// retryable writes are only enabled updateOne/replaceOne operations
 	if !multi && coll.client.retryWrites {
@@ -574,6 +763,7 @@ func (coll *Collection) updateOrReplace(ctx context.Context, filter bsoncore.Doc
 		MatchedCount:  int64(opRes.N),
 		ModifiedCount: int64(opRes.NModified),
 		UpsertedCount: int64(len(opRes.Upserted)),
+		Raw:           lastReply(opRes.Replies),
 	}
 	if len(opRes.Upserted) > 0 {
 		res.UpsertedID = opRes.Upserted[0].ID
@@ -625,7 +815,7 @@ func (coll *Collection) UpdateOne(ctx context.Context, filter interface{}, updat
 		ctx = context.Background()
 	}
 
-	f, err := transformBsoncoreDocument(coll.registry, filter, true, "filter")
+	f, err := coll.transformFilter(ctx, filter)
 	if err != nil {
 		return nil, err
 	}
@@ -653,7 +843,7 @@ func (coll *Collection) UpdateMany(ctx context.Context, filter interface{}, upda
 		ctx = context.Background()
 	}
 
-	f, err := transformBsoncoreDocument(coll.registry, filter, true, "filter")
+	f, err := coll.transformFilter(ctx, filter)
 	if err != nil {
 		return nil, err
 	}
@@ -681,7 +871,7 @@ func (coll *Collection) ReplaceOne(ctx context.Context, filter interface{},
 		ctx = context.Background()
 	}
 
-	f, err := transformBsoncoreDocument(coll.registry, filter, true, "filter")
+	f, err := coll.transformFilter(ctx, filter)
 	if err != nil {
 		return nil, err
 	}
@@ -702,6 +892,8 @@ func (coll *Collection) ReplaceOne(ctx context.Context, filter interface{},
 		uOpts.Collation = opt.Collation
 		uOpts.Upsert = opt.Upsert
 		uOpts.Hint = opt.Hint
+		uOpts.Comment = opt.Comment
+		uOpts.Let = opt.Let
 		updateOptions = append(updateOptions, uOpts)
 	}
 
@@ -721,6 +913,10 @@ func (coll *Collection) ReplaceOne(ctx context.Context, filter interface{},
 // For more information about the command, see https://docs.mongodb.com/manual/reference/command/aggregate/.
 func (coll *Collection) Aggregate(ctx context.Context, pipeline interface{},
 	opts ...*options.AggregateOptions) (*Cursor, error) {
+	predicate, err := coll.transformFilterPredicate(ctx)
+	if err != nil {
+		return nil, err
+	}
 	a := aggregateParams{
 		ctx:            ctx,
 		pipeline:       pipeline,
@@ -735,10 +931,51 @@ func (coll *Collection) Aggregate(ctx context.Context, pipeline interface{},
 		writeSelector:  coll.writeSelector,
 		readPreference: coll.readPreference,
 		opts:           opts,
+		predicate:      predicate,
 	}
 	return aggregate(a)
 }
 
+// AggregateWithAccumulator executes an aggregate command that runs the given pipeline, groups the resulting documents
+// by the groupBy expression, and computes the outputField of each group with the custom JavaScript accumulator acc. It
+// returns a cursor over the groups, which each have an _id field containing the value of the groupBy expression.
+//
+// The pipeline parameter specifies the stages to run before the $group stage. It can be nil or any value accepted by
+// the Aggregate method. The groupBy parameter can be nil to compute a single group across all documents.
+//
+// The opts parameter can be used to specify options for the operation (see the options.AggregateOptions documentation.)
+//
+// This method requires MongoDB version >= 4.4. See the Accumulator documentation for more information.
+func (coll *Collection) AggregateWithAccumulator(ctx context.Context, pipeline interface{}, groupBy interface{},
+	outputField string, acc Accumulator, opts ...*options.AggregateOptions) (*Cursor, error) {
+
+	if outputField == "" || outputField == "_id" {
+		return nil, errors.New("outputField must be a non-empty field name other than _id")
+	}
+
+	var stages []interface{}
+	if pipeline != nil {
+		pipelineArr, _, err := transformAggregatePipelinev2(coll.registry, pipeline)
+		if err != nil {
+			return nil, err
+		}
+		values, err := pipelineArr.Values()
+		if err != nil {
+			return nil, err
+		}
+		for _, val := range values {
+			doc, ok := val.DocumentOK()
+			if !ok {
+				return nil, fmt.Errorf("pipeline stages must be documents, but got a BSON %s", val.Type)
+			}
+			stages = append(stages, bson.Raw(doc))
+		}
+	}
+	stages = append(stages, bson.D{{"$group", bson.D{{"_id", groupBy}, {outputField, acc}}}})
+
+	return coll.Aggregate(ctx, stages, opts...)
+}
+
 // aggreate is the helper method for Aggregate
 func aggregate(a aggregateParams) (*Cursor, error) {
 
@@ -750,6 +987,10 @@ func aggregate(a aggregateParams) (*Cursor, error) {
 	if err != nil {
 		return nil, err
 	}
+	pipelineArr, err = addPredicateStage(pipelineArr, a.predicate)
+	if err != nil {
+		return nil, err
+	}
 
 	sess := sessionFromContext(a.ctx)
 	if sess == nil && a.client.sessionPool != nil {
@@ -782,20 +1023,18 @@ func aggregate(a aggregateParams) (*Cursor, error) {
 	}
 
 	ao := options.MergeAggregateOptions(a.opts...)
-	cursorOpts := driver.CursorOptions{
-		CommandMonitor: a.client.monitor,
-		Crypt:          a.client.cryptFLE,
+	op, cursorOpts, err := aggregateOperation(a, pipelineArr, hasOutputStage, ao)
+	if err != nil {
+		closeImplicitSession(sess)
+		return nil, err
 	}
-
-	op := operation.NewAggregate(pipelineArr).
-		Session(sess).
+	op.Session(sess).
 		WriteConcern(wc).
 		ReadConcern(rc).
 		CommandMonitor(a.client.monitor).
 		ServerSelector(selector).
 		ClusterClock(a.client.clock).
 		Database(a.db).
-		Collection(a.col).
 		Deployment(a.client.deployment).
 		Crypt(a.client.cryptFLE).
 		ServerAPI(a.client.serverAPI)
@@ -806,6 +1045,63 @@ func aggregate(a aggregateParams) (*Cursor, error) {
 		op.ReadPreference(a.readPreference)
 	}
 
+	retry := driver.RetryNone
+	if a.retryRead && !hasOutputStage {
+		retry = driver.RetryOncePerCommand
+	}
+	op = op.Retry(retry)
+
+	var afterClusterTime *primitive.Timestamp
+	if !hasOutputStage {
+		afterClusterTime = staleReadOperationTime(ao.RetryStaleReadOnPrimary, sess, a.readPreference)
+	}
+	err = op.Execute(a.ctx)
+	if err != nil {
+		closeImplicitSession(sess)
+		if wce, ok := err.(driver.WriteCommandError); ok && wce.WriteConcernError != nil {
+			return nil, *convertDriverWriteConcernError(wce.WriteConcernError)
+		}
+		return nil, replaceErrors(err)
+	}
+
+	bc, err := op.Result(cursorOpts)
+	if err != nil {
+		closeImplicitSession(sess)
+		return nil, replaceErrors(err)
+	}
+	if isStaleRead(afterClusterTime, bc.OperationTime()) {
+		_ = bc.Close(a.ctx)
+		op.ReadPreference(readpref.Primary()).
+			ServerSelector(makeReadPrefSelector(sess, primaryReadSelector(a.client.localThreshold), a.client.localThreshold))
+		if err = op.Execute(a.ctx); err != nil {
+			closeImplicitSession(sess)
+			return nil, replaceErrors(err)
+		}
+		if bc, err = op.Result(cursorOpts); err != nil {
+			closeImplicitSession(sess)
+			return nil, replaceErrors(err)
+		}
+	}
+	cursor, err := newCursorWithSession(bc, a.registry, sess)
+	if err != nil {
+		return nil, replaceErrors(err)
+	}
+	a.client.trackCursor(cursor)
+	return cursor, nil
+}
+
+// aggregateOperation returns an Aggregate operation with the command for the aggregation described by a, along with
+// the options for its cursor. The caller sets the session, deployment, and other settings used to execute it.
+func aggregateOperation(a aggregateParams, pipelineArr bsoncore.Document, hasOutputStage bool,
+	ao *options.AggregateOptions) (*operation.Aggregate, driver.CursorOptions, error) {
+
+	op := operation.NewAggregate(pipelineArr).Collection(a.col)
+	cursorOpts := driver.CursorOptions{
+		CommandMonitor:       a.client.monitor,
+		Crypt:                a.client.cryptFLE,
+		PinnedCursorCounters: a.client.pinnedCursors,
+	}
+
 	if ao.AllowDiskUse != nil {
 		op.AllowDiskUse(*ao.AllowDiskUse)
 	}
@@ -827,39 +1123,23 @@ func aggregate(a aggregateParams) (*Cursor, error) {
 		cursorOpts.MaxTimeMS = int64(*ao.MaxAwaitTime / time.Millisecond)
 	}
 	if ao.Comment != nil {
-		op.Comment(*ao.Comment)
+		op.Comment(bsoncore.Value{Type: bsontype.String, Data: bsoncore.AppendString(nil, *ao.Comment)})
 	}
 	if ao.Hint != nil {
 		hintVal, err := transformValue(a.registry, ao.Hint, false, "hint")
 		if err != nil {
-			closeImplicitSession(sess)
-			return nil, err
+			return nil, cursorOpts, err
 		}
 		op.Hint(hintVal)
 	}
-
-	retry := driver.RetryNone
-	if a.retryRead && !hasOutputStage {
-		retry = driver.RetryOncePerCommand
-	}
-	op = op.Retry(retry)
-
-	err = op.Execute(a.ctx)
-	if err != nil {
-		closeImplicitSession(sess)
-		if wce, ok := err.(driver.WriteCommandError); ok && wce.WriteConcernError != nil {
-			return nil, *convertDriverWriteConcernError(wce.WriteConcernError)
+	if ao.Let != nil {
+		let, err := transformBsoncoreDocument(a.registry, ao.Let, true, "let")
+		if err != nil {
+			return nil, cursorOpts, err
 		}
-		return nil, replaceErrors(err)
-	}
-
-	bc, err := op.Result(cursorOpts)
-	if err != nil {
-		closeImplicitSession(sess)
-		return nil, replaceErrors(err)
+		op.Let(let)
 	}
-	cursor, err := newCursorWithSession(bc, a.registry, sess)
-	return cursor, replaceErrors(err)
+	return op, cursorOpts, nil
 }
 
 // CountDocuments returns the number of documents in the collection. For a fast count of the documents in the
@@ -867,33 +1147,50 @@ func aggregate(a aggregateParams) (*Cursor, error) {
 //
 // The filter parameter must be a document and can be used to select which documents contribute to the count. It
 // cannot be nil. An empty document (e.g. bson.D{}) should be used to count all documents in the collection. This will
-// result in a full collection scan.
+// result in a full collection scan unless the AllowFastCount option is set.
 //
 // The opts parameter can be used to specify options for the operation (see the options.CountOptions documentation).
 func (coll *Collection) CountDocuments(ctx context.Context, filter interface{},
 	opts ...*options.CountOptions) (int64, error) {
 
+	res, err := coll.CountDocumentsWithResult(ctx, filter, opts...)
+	if err != nil {
+		return 0, err
+	}
+	return res.Count, nil
+}
+
+// CountDocumentsWithResult is like CountDocuments, but returns a CountResult that also reports whether the count was
+// read from the collection metadata because of the AllowFastCount option.
+func (coll *Collection) CountDocumentsWithResult(ctx context.Context, filter interface{},
+	opts ...*options.CountOptions) (*CountResult, error) {
+
 	if ctx == nil {
 		ctx = context.Background()
 	}
 
 	countOpts := options.MergeCountOptions(opts...)
 
-	pipelineArr, err := countDocumentsAggregatePipeline(coll.registry, filter, countOpts)
+	f, err := coll.transformFilter(ctx, filter)
 	if err != nil {
-		return 0, err
+		return nil, err
+	}
+
+	pipelineArr, err := countDocumentsAggregatePipeline(coll.registry, f, countOpts)
+	if err != nil {
+		return nil, err
 	}
 
 	sess := sessionFromContext(ctx)
 	if sess == nil && coll.client.sessionPool != nil {
 		sess, err = session.NewClientSession(coll.client.sessionPool, coll.client.id, session.Implicit)
 		if err != nil {
-			return 0, err
+			return nil, err
 		}
 		defer sess.EndSession()
 	}
 	if err = coll.client.validSession(sess); err != nil {
-		return 0, err
+		return nil, err
 	}
 
 	rc := coll.readConcern
@@ -901,6 +1198,27 @@ func (coll *Collection) CountDocuments(ctx context.Context, filter interface{},
 		rc = nil
 	}
 
+	var comment bsoncore.Value
+	if countOpts.Comment != nil {
+		comment, err = transformValue(coll.registry, countOpts.Comment, true, "comment")
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	// $collStats cannot be run in a transaction.
+	if countOpts.AllowFastCount != nil && *countOpts.AllowFastCount && len(f) == bsoncore.EmptyDocumentLength &&
+		countOpts.Skip == nil && countOpts.Limit == nil && !sess.TransactionRunning() {
+
+		n, err := coll.collStatsCount(ctx, sess, rc, comment, countOpts.MaxTime)
+		if err == nil {
+			return &CountResult{Count: n, FastCount: true}, nil
+		}
+		if _, ok := err.(CommandError); !ok {
+			return nil, err
+		}
+	}
+
 	selector := makeReadPrefSelector(sess, coll.readSelector, coll.client.localThreshold)
 	op := operation.NewAggregate(pipelineArr).Session(sess).ReadConcern(rc).ReadPreference(coll.readPreference).
 		CommandMonitor(coll.client.monitor).ServerSelector(selector).ClusterClock(coll.client.clock).Database(coll.db.name).
@@ -908,13 +1226,16 @@ func (coll *Collection) CountDocuments(ctx context.Context, filter interface{},
 	if countOpts.Collation != nil {
 		op.Collation(bsoncore.Document(countOpts.Collation.ToDocument()))
 	}
+	if countOpts.Comment != nil {
+		op.Comment(comment)
+	}
 	if countOpts.MaxTime != nil {
 		op.MaxTimeMS(int64(*countOpts.MaxTime / time.Millisecond))
 	}
 	if countOpts.Hint != nil {
 		hintVal, err := transformValue(coll.registry, countOpts.Hint, false, "hint")
 		if err != nil {
-			return 0, err
+			return nil, err
 		}
 		op.Hint(hintVal)
 	}
@@ -926,9 +1247,62 @@ func (coll *Collection) CountDocuments(ctx context.Context, filter interface{},
 
 	err = op.Execute(ctx)
 	if err != nil {
+		return nil, replaceErrors(err)
+	}
+
+	n, err := aggregateCount(op)
+	if err != nil {
+		return nil, err
+	}
+	return &CountResult{Count: n}, nil
+}
+
+// collStatsCount returns the number of documents in the collection according to the collection metadata using a
+// $collStats aggregation. The comment and maxTime are passed to the aggregation if they are set.
+func (coll *Collection) collStatsCount(ctx context.Context, sess *session.Client, rc *readconcern.ReadConcern,
+	comment bsoncore.Value, maxTime *time.Duration) (int64, error) {
+
+	aidx, arr := bsoncore.AppendArrayStart(nil)
+	arr = bsoncore.AppendDocumentElement(arr, "0", bsoncore.BuildDocumentFromElements(nil,
+		bsoncore.AppendDocumentElement(nil, "$collStats", bsoncore.BuildDocumentFromElements(nil,
+			bsoncore.AppendDocumentElement(nil, "count", bsoncore.NewDocumentBuilder().Build()),
+		)),
+	))
+	arr = bsoncore.AppendDocumentElement(arr, "1", bsoncore.BuildDocumentFromElements(nil,
+		bsoncore.AppendDocumentElement(nil, "$group", bsoncore.BuildDocumentFromElements(nil,
+			bsoncore.AppendInt32Element(nil, "_id", 1),
+			bsoncore.AppendDocumentElement(nil, "n", bsoncore.BuildDocumentFromElements(nil,
+				bsoncore.AppendStringElement(nil, "$sum", "$count"),
+			)),
+		)),
+	))
+	arr, _ = bsoncore.AppendArrayEnd(arr, aidx)
+
+	selector := makeReadPrefSelector(sess, coll.readSelector, coll.client.localThreshold)
+	op := operation.NewAggregate(arr).Session(sess).ReadConcern(rc).ReadPreference(coll.readPreference).
+		CommandMonitor(coll.client.monitor).ServerSelector(selector).ClusterClock(coll.client.clock).Database(coll.db.name).
+		Collection(coll.name).Deployment(coll.client.deployment).Crypt(coll.client.cryptFLE).ServerAPI(coll.client.serverAPI)
+	if comment.Type != bsontype.Type(0) {
+		op.Comment(comment)
+	}
+	if maxTime != nil {
+		op.MaxTimeMS(int64(*maxTime / time.Millisecond))
+	}
+	retry := driver.RetryNone
+	if coll.client.retryReads {
+		retry = driver.RetryOncePerCommand
+	}
+	op = op.Retry(retry)
+
+	if err := op.Execute(ctx); err != nil {
 		return 0, replaceErrors(err)
 	}
+	return aggregateCount(op)
+}
 
+// aggregateCount returns the count in the response of an aggregation that ends with a {$group: {_id: 1, n: ...}}
+// stage. An empty response means that there are no documents.
+func aggregateCount(op *operation.Aggregate) (int64, error) {
 	batch := op.ResultCursorResponse().FirstBatch
 	if batch == nil {
 		return 0, errors.New("invalid response from server, no 'firstBatch' field")
@@ -948,7 +1322,8 @@ func (coll *Collection) CountDocuments(ctx context.Context, filter interface{},
 }
 
 // EstimatedDocumentCount executes a count command and returns an estimate of the number of documents in the collection
-// using collection metadata.
+// using collection metadata. If the client uses the strict stable API and the server does not allow the count command
+// with it, the estimate is read with a $collStats aggregation instead.
 //
 // The opts parameter can be used to specify options for the operation (see the options.EstimatedDocumentCountOptions
 // documentation).
@@ -989,6 +1364,14 @@ func (coll *Collection) EstimatedDocumentCount(ctx context.Context,
 		ServerSelector(selector).Crypt(coll.client.cryptFLE).ServerAPI(coll.client.serverAPI)
 
 	co := options.MergeEstimatedDocumentCountOptions(opts...)
+	var comment bsoncore.Value
+	if co.Comment != nil {
+		comment, err = transformValue(coll.registry, co.Comment, true, "comment")
+		if err != nil {
+			return 0, err
+		}
+		op = op.Comment(comment)
+	}
 	if co.MaxTime != nil {
 		op = op.MaxTimeMS(int64(*co.MaxTime / time.Millisecond))
 	}
@@ -999,6 +1382,11 @@ func (coll *Collection) EstimatedDocumentCount(ctx context.Context,
 	op.Retry(retry)
 
 	err = op.Execute(ctx)
+	if cerr, ok := replaceErrors(err).(CommandError); ok && cerr.Code == apiStrictErrorCode {
+		// Some server versions do not include the count command in the stable API, so the count is read with a
+		// $collStats aggregation instead.
+		return coll.collStatsCount(ctx, sess, rc, comment, co.MaxTime)
+	}
 
 	return op.Result().N, replaceErrors(err)
 }
@@ -1020,7 +1408,7 @@ func (coll *Collection) Distinct(ctx context.Context, fieldName string, filter i
 		ctx = context.Background()
 	}
 
-	f, err := transformBsoncoreDocument(coll.registry, filter, true, "filter")
+	f, err := coll.transformFilter(ctx, filter)
 	if err != nil {
 		return nil, err
 	}
@@ -1048,18 +1436,14 @@ func (coll *Collection) Distinct(ctx context.Context, fieldName string, filter i
 	selector := makeReadPrefSelector(sess, coll.readSelector, coll.client.localThreshold)
 	option := options.MergeDistinctOptions(opts...)
 
-	op := operation.NewDistinct(fieldName, bsoncore.Document(f)).
-		Session(sess).ClusterClock(coll.client.clock).
-		Database(coll.db.name).Collection(coll.name).CommandMonitor(coll.client.monitor).
+	op, err := coll.distinctOperation(fieldName, f, option)
+	if err != nil {
+		return nil, err
+	}
+	op.Session(sess).ClusterClock(coll.client.clock).
+		Database(coll.db.name).CommandMonitor(coll.client.monitor).
 		Deployment(coll.client.deployment).ReadConcern(rc).ReadPreference(coll.readPreference).
 		ServerSelector(selector).Crypt(coll.client.cryptFLE).ServerAPI(coll.client.serverAPI)
-
-	if option.Collation != nil {
-		op.Collation(bsoncore.Document(option.Collation.ToDocument()))
-	}
-	if option.MaxTime != nil {
-		op.MaxTimeMS(int64(*option.MaxTime / time.Millisecond))
-	}
 	retry := driver.RetryNone
 	if coll.client.retryReads {
 		retry = driver.RetryOncePerCommand
@@ -1094,6 +1478,35 @@ func (coll *Collection) Distinct(ctx context.Context, fieldName string, filter i
 	return retArray, replaceErrors(err)
 }
 
+// distinctOperation returns a Distinct operation with the command for a distinct with the given field name, filter,
+// and options. The caller sets the session, deployment, and other settings used to execute it.
+func (coll *Collection) distinctOperation(fieldName string, f bsoncore.Document,
+	option *options.DistinctOptions) (*operation.Distinct, error) {
+
+	op := operation.NewDistinct(fieldName, f).Collection(coll.name)
+	if option.Collation != nil {
+		op.Collation(bsoncore.Document(option.Collation.ToDocument()))
+	}
+	if option.Comment != nil {
+		comment, err := transformValue(coll.registry, option.Comment, true, "comment")
+		if err != nil {
+			return nil, err
+		}
+		op.Comment(comment)
+	}
+	if option.Hint != nil {
+		hint, err := transformValue(coll.registry, option.Hint, false, "hint")
+		if err != nil {
+			return nil, err
+		}
+		op.Hint(hint)
+	}
+	if option.MaxTime != nil {
+		op.MaxTimeMS(int64(*option.MaxTime / time.Millisecond))
+	}
+	return op, nil
+}
+
 // Find executes a find command and returns a Cursor over the matching documents in the collection.
 //
 // The filter parameter must be a document containing query operators and can be used to select which documents are
@@ -1109,7 +1522,7 @@ func (coll *Collection) Find(ctx context.Context, filter interface{},
 		ctx = context.Background()
 	}
 
-	f, err := transformBsoncoreDocument(coll.registry, filter, true, "filter")
+	f, err := coll.transformFilter(ctx, filter)
 	if err != nil {
 		return nil, err
 	}
@@ -1134,17 +1547,137 @@ func (coll *Collection) Find(ctx context.Context, filter interface{},
 		rc = nil
 	}
 
-	selector := makeReadPrefSelector(sess, coll.readSelector, coll.client.localThreshold)
-	op := operation.NewFind(f).
-		Session(sess).ReadConcern(rc).ReadPreference(coll.readPreference).
-		CommandMonitor(coll.client.monitor).ServerSelector(selector).
-		ClusterClock(coll.client.clock).Database(coll.db.name).Collection(coll.name).
-		Deployment(coll.client.deployment).Crypt(coll.client.cryptFLE).ServerAPI(coll.client.serverAPI)
-
 	fo := options.MergeFindOptions(opts...)
+	rp, selector, err := coll.findServerSelection(sess, fo)
+	if err != nil {
+		closeImplicitSession(sess)
+		return nil, err
+	}
+	op, cursorOpts, err := coll.findOperation(f, fo)
+	if err != nil {
+		closeImplicitSession(sess)
+		return nil, err
+	}
+	if err = coll.checkInMemorySort(ctx, sess, op, fo, rp, selector); err != nil {
+		closeImplicitSession(sess)
+		return nil, err
+	}
+	retry := driver.RetryNone
+	if coll.client.retryReads {
+		retry = driver.RetryOncePerCommand
+	}
+	configure := func(op *operation.Find) *operation.Find {
+		return op.Session(sess).ReadConcern(rc).ReadPreference(rp).
+			CommandMonitor(coll.client.monitor).ServerSelector(selector).
+			ClusterClock(coll.client.clock).Database(coll.db.name).
+			Deployment(coll.client.deployment).Crypt(coll.client.cryptFLE).ServerAPI(coll.client.serverAPI).
+			Retry(retry)
+	}
+	op = configure(op)
+
+	retryStaleRead := fo.RetryStaleReadOnPrimary
+	if fo.ServerAddress != nil {
+		// Retrying on the primary would send the operation to a different server than the one requested.
+		retryStaleRead = nil
+	}
+	afterClusterTime := staleReadOperationTime(retryStaleRead, sess, rp)
+	if err = op.Execute(ctx); err != nil {
+		closeImplicitSession(sess)
+		return nil, replaceErrors(err)
+	}
+
+	bc, err := op.Result(cursorOpts)
+	if err != nil {
+		closeImplicitSession(sess)
+		return nil, replaceErrors(err)
+	}
+	if isStaleRead(afterClusterTime, bc.OperationTime()) {
+		_ = bc.Close(ctx)
+		op.ReadPreference(readpref.Primary()).
+			ServerSelector(makeReadPrefSelector(sess, primaryReadSelector(coll.client.localThreshold), coll.client.localThreshold))
+		if err = op.Execute(ctx); err != nil {
+			closeImplicitSession(sess)
+			return nil, replaceErrors(err)
+		}
+		if bc, err = op.Result(cursorOpts); err != nil {
+			closeImplicitSession(sess)
+			return nil, replaceErrors(err)
+		}
+	}
+	var cursorBC batchCursor = bc
+	if coll.client.restartCursors && bc.ID() != 0 && !sess.TransactionRunning() &&
+		(fo.CursorType == nil || *fo.CursorType == options.NonTailable) {
+
+		restart := func(ctx context.Context, returned int64) (batchCursor, error) {
+			return coll.restartFind(ctx, f, fo, returned, configure)
+		}
+		cursorBC = newRestartBatchCursor(bc, restart, coll.client.pinnedCursors)
+	}
+	if shouldPrefetch(fo, sess) {
+		cursorBC = newPrefetchBatchCursor(cursorBC)
+	}
+	cursor, err := newCursorWithSession(cursorBC, coll.registry, sess)
+	if err != nil {
+		return nil, err
+	}
+	coll.client.trackCursor(cursor)
+	return cursor, nil
+}
+
+// restartFind runs the find described by f and fo again for a cursor that lost its pinned connection, skipping the
+// documents that have already been returned, and returns the batch cursor of the new query. The configure function
+// sets the session, deployment, and other settings of the original operation.
+func (coll *Collection) restartFind(ctx context.Context, f bsoncore.Document, fo *options.FindOptions, returned int64,
+	configure func(*operation.Find) *operation.Find) (batchCursor, error) {
+
+	restartOpts := *fo
+	skip := returned
+	if fo.Skip != nil {
+		skip += *fo.Skip
+	}
+	restartOpts.Skip = &skip
+	if fo.Limit != nil && *fo.Limit > 0 {
+		limit := *fo.Limit - returned
+		if limit <= 0 {
+			return driver.NewEmptyBatchCursor(), nil
+		}
+		restartOpts.Limit = &limit
+	}
+
+	op, cursorOpts, err := coll.findOperation(f, &restartOpts)
+	if err != nil {
+		return nil, err
+	}
+	op = configure(op)
+	if err = op.Execute(ctx); err != nil {
+		return nil, replaceErrors(err)
+	}
+	bc, err := op.Result(cursorOpts)
+	if err != nil {
+		return nil, replaceErrors(err)
+	}
+	return bc, nil
+}
+
+// findOperation returns a Find operation with the command for a find with the given filter and options, along with
+// the options for its cursor. The caller sets the session, deployment, and other settings used to execute it.
+func (coll *Collection) findOperation(f bsoncore.Document, fo *options.FindOptions) (*operation.Find,
+	driver.CursorOptions, error) {
+
+	op := operation.NewFind(f).Collection(coll.name)
+
+	if fo.Unbounded == nil || !*fo.Unbounded {
+		if fo.Limit == nil {
+			fo.Limit = coll.client.defaultFindLimit
+		}
+		if fo.MaxTime == nil {
+			fo.MaxTime = coll.client.defaultFindMaxTime
+		}
+	}
 	cursorOpts := driver.CursorOptions{
-		CommandMonitor: coll.client.monitor,
-		Crypt:          coll.client.cryptFLE,
+		CommandMonitor:       coll.client.monitor,
+		Crypt:                coll.client.cryptFLE,
+		PinnedCursorCounters: coll.client.pinnedCursors,
 	}
 
 	if fo.AllowDiskUse != nil {
@@ -1172,14 +1705,23 @@ func (coll *Collection) Find(ctx context.Context, filter interface{},
 			op.AwaitData(true)
 		}
 	}
+	if fo.Exhaust != nil {
+		op.Exhaust(*fo.Exhaust)
+	}
 	if fo.Hint != nil {
 		hint, err := transformValue(coll.registry, fo.Hint, false, "hint")
 		if err != nil {
-			closeImplicitSession(sess)
-			return nil, err
+			return nil, cursorOpts, err
 		}
 		op.Hint(hint)
 	}
+	if fo.Let != nil {
+		let, err := transformBsoncoreDocument(coll.registry, fo.Let, true, "let")
+		if err != nil {
+			return nil, cursorOpts, err
+		}
+		op.Let(let)
+	}
 	if fo.Limit != nil {
 		limit := *fo.Limit
 		if limit < 0 {
@@ -1192,8 +1734,7 @@ func (coll *Collection) Find(ctx context.Context, filter interface{},
 	if fo.Max != nil {
 		max, err := transformBsoncoreDocument(coll.registry, fo.Max, true, "max")
 		if err != nil {
-			closeImplicitSession(sess)
-			return nil, err
+			return nil, cursorOpts, err
 		}
 		op.Max(max)
 	}
@@ -1206,8 +1747,7 @@ func (coll *Collection) Find(ctx context.Context, filter interface{},
 	if fo.Min != nil {
 		min, err := transformBsoncoreDocument(coll.registry, fo.Min, true, "min")
 		if err != nil {
-			closeImplicitSession(sess)
-			return nil, err
+			return nil, cursorOpts, err
 		}
 		op.Min(min)
 	}
@@ -1220,8 +1760,7 @@ func (coll *Collection) Find(ctx context.Context, filter interface{},
 	if fo.Projection != nil {
 		proj, err := transformBsoncoreDocument(coll.registry, fo.Projection, true, "projection")
 		if err != nil {
-			closeImplicitSession(sess)
-			return nil, err
+			return nil, cursorOpts, err
 		}
 		op.Projection(proj)
 	}
@@ -1240,28 +1779,24 @@ func (coll *Collection) Find(ctx context.Context, filter interface{},
 	if fo.Sort != nil {
 		sort, err := transformBsoncoreDocument(coll.registry, fo.Sort, false, "sort")
 		if err != nil {
-			closeImplicitSession(sess)
-			return nil, err
+			return nil, cursorOpts, err
 		}
 		op.Sort(sort)
 	}
-	retry := driver.RetryNone
-	if coll.client.retryReads {
-		retry = driver.RetryOncePerCommand
-	}
-	op = op.Retry(retry)
+	return op, cursorOpts, nil
+}
 
-	if err = op.Execute(ctx); err != nil {
-		closeImplicitSession(sess)
-		return nil, replaceErrors(err)
+// shouldPrefetch returns true if the cursor for a find operation with the given options should prefetch batches.
+// Prefetching is not done for tailable cursors, which can wait for new documents indefinitely, or for explicit
+// sessions, which must not be used concurrently with other operations.
+func shouldPrefetch(fo *options.FindOptions, sess *session.Client) bool {
+	if fo.Prefetch == nil || !*fo.Prefetch {
+		return false
 	}
-
-	bc, err := op.Result(cursorOpts)
-	if err != nil {
-		closeImplicitSession(sess)
-		return nil, replaceErrors(err)
+	if fo.CursorType != nil && *fo.CursorType != options.NonTailable {
+		return false
 	}
-	return newCursorWithSession(bc, coll.registry, sess)
+	return sess == nil || sess.SessionType != session.Explicit
 }
 
 // FindOne executes a find command and returns a SingleResult for one document in the collection.
@@ -1283,24 +1818,29 @@ func (coll *Collection) FindOne(ctx context.Context, filter interface{},
 	findOpts := make([]*options.FindOptions, len(opts))
 	for i, opt := range opts {
 		findOpts[i] = &options.FindOptions{
-			AllowPartialResults: opt.AllowPartialResults,
-			BatchSize:           opt.BatchSize,
-			Collation:           opt.Collation,
-			Comment:             opt.Comment,
-			CursorType:          opt.CursorType,
-			Hint:                opt.Hint,
-			Max:                 opt.Max,
-			MaxAwaitTime:        opt.MaxAwaitTime,
-			MaxTime:             opt.MaxTime,
-			Min:                 opt.Min,
-			NoCursorTimeout:     opt.NoCursorTimeout,
-			OplogReplay:         opt.OplogReplay,
-			Projection:          opt.Projection,
-			ReturnKey:           opt.ReturnKey,
-			ShowRecordID:        opt.ShowRecordID,
-			Skip:                opt.Skip,
-			Snapshot:            opt.Snapshot,
-			Sort:                opt.Sort,
+			AllowPartialResults:     opt.AllowPartialResults,
+			BatchSize:               opt.BatchSize,
+			Collation:               opt.Collation,
+			Comment:                 opt.Comment,
+			CursorType:              opt.CursorType,
+			Hint:                    opt.Hint,
+			Let:                     opt.Let,
+			Max:                     opt.Max,
+			MaxAwaitTime:            opt.MaxAwaitTime,
+			MaxTime:                 opt.MaxTime,
+			Min:                     opt.Min,
+			NoCursorTimeout:         opt.NoCursorTimeout,
+			OplogReplay:             opt.OplogReplay,
+			Projection:              opt.Projection,
+			ReadPreference:          opt.ReadPreference,
+			RetryStaleReadOnPrimary: opt.RetryStaleReadOnPrimary,
+			ReturnKey:               opt.ReturnKey,
+			ServerAddress:           opt.ServerAddress,
+			ShowRecordID:            opt.ShowRecordID,
+			Skip:                    opt.Skip,
+			Snapshot:                opt.Snapshot,
+			Sort:                    opt.Sort,
+			Unbounded:               opt.Unbounded,
 		}
 	}
 	// Unconditionally send a limit to make sure only one document is returned and the cursor is not kept open
@@ -1379,7 +1919,7 @@ func (coll *Collection) findAndModify(ctx context.Context, op *operation.FindAnd
 func (coll *Collection) FindOneAndDelete(ctx context.Context, filter interface{},
 	opts ...*options.FindOneAndDeleteOptions) *SingleResult {
 
-	f, err := transformBsoncoreDocument(coll.registry, filter, true, "filter")
+	f, err := coll.transformFilter(ctx, filter)
 	if err != nil {
 		return &SingleResult{err: err}
 	}
@@ -1388,6 +1928,13 @@ func (coll *Collection) FindOneAndDelete(ctx context.Context, filter interface{}
 	if fod.Collation != nil {
 		op = op.Collation(bsoncore.Document(fod.Collation.ToDocument()))
 	}
+	if fod.Comment != nil {
+		comment, err := transformValue(coll.registry, fod.Comment, true, "comment")
+		if err != nil {
+			return &SingleResult{err: err}
+		}
+		op = op.Comment(comment)
+	}
 	if fod.MaxTime != nil {
 		op = op.MaxTimeMS(int64(*fod.MaxTime / time.Millisecond))
 	}
@@ -1412,6 +1959,13 @@ func (coll *Collection) FindOneAndDelete(ctx context.Context, filter interface{}
 		}
 		op = op.Hint(hint)
 	}
+	if fod.Let != nil {
+		let, err := transformBsoncoreDocument(coll.registry, fod.Let, true, "let")
+		if err != nil {
+			return &SingleResult{err: err}
+		}
+		op = op.Let(let)
+	}
 
 	return coll.findAndModify(ctx, op)
 }
@@ -1433,7 +1987,7 @@ func (coll *Collection) FindOneAndDelete(ctx context.Context, filter interface{}
 func (coll *Collection) FindOneAndReplace(ctx context.Context, filter interface{},
 	replacement interface{}, opts ...*options.FindOneAndReplaceOptions) *SingleResult {
 
-	f, err := transformBsoncoreDocument(coll.registry, filter, true, "filter")
+	f, err := coll.transformFilter(ctx, filter)
 	if err != nil {
 		return &SingleResult{err: err}
 	}
@@ -1454,6 +2008,13 @@ func (coll *Collection) FindOneAndReplace(ctx context.Context, filter interface{
 	if fo.Collation != nil {
 		op = op.Collation(bsoncore.Document(fo.Collation.ToDocument()))
 	}
+	if fo.Comment != nil {
+		comment, err := transformValue(coll.registry, fo.Comment, true, "comment")
+		if err != nil {
+			return &SingleResult{err: err}
+		}
+		op = op.Comment(comment)
+	}
 	if fo.MaxTime != nil {
 		op = op.MaxTimeMS(int64(*fo.MaxTime / time.Millisecond))
 	}
@@ -1484,6 +2045,13 @@ func (coll *Collection) FindOneAndReplace(ctx context.Context, filter interface{
 		}
 		op = op.Hint(hint)
 	}
+	if fo.Let != nil {
+		let, err := transformBsoncoreDocument(coll.registry, fo.Let, true, "let")
+		if err != nil {
+			return &SingleResult{err: err}
+		}
+		op = op.Let(let)
+	}
 
 	return coll.findAndModify(ctx, op)
 }
@@ -1510,7 +2078,7 @@ func (coll *Collection) FindOneAndUpdate(ctx context.Context, filter interface{}
 		ctx = context.Background()
 	}
 
-	f, err := transformBsoncoreDocument(coll.registry, filter, true, "filter")
+	f, err := coll.transformFilter(ctx, filter)
 	if err != nil {
 		return &SingleResult{err: err}
 	}
@@ -1537,6 +2105,13 @@ func (coll *Collection) FindOneAndUpdate(ctx context.Context, filter interface{}
 	if fo.Collation != nil {
 		op = op.Collation(bsoncore.Document(fo.Collation.ToDocument()))
 	}
+	if fo.Comment != nil {
+		comment, err := transformValue(coll.registry, fo.Comment, true, "comment")
+		if err != nil {
+			return &SingleResult{err: err}
+		}
+		op = op.Comment(comment)
+	}
 	if fo.MaxTime != nil {
 		op = op.MaxTimeMS(int64(*fo.MaxTime / time.Millisecond))
 	}
@@ -1567,6 +2142,13 @@ func (coll *Collection) FindOneAndUpdate(ctx context.Context, filter interface{}
 		}
 		op = op.Hint(hint)
 	}
+	if fo.Let != nil {
+		let, err := transformBsoncoreDocument(coll.registry, fo.Let, true, "let")
+		if err != nil {
+			return &SingleResult{err: err}
+		}
+		op = op.Let(let)
+	}
 
 	return coll.findAndModify(ctx, op)
 }
@@ -1605,6 +2187,12 @@ func (coll *Collection) Indexes() IndexView {
 	return IndexView{coll: coll}
 }
 
+// SearchIndexes returns a SearchIndexView instance that can be used to perform operations on the Atlas Search and
+// Vector Search indexes for the collection.
+func (coll *Collection) SearchIndexes() SearchIndexView {
+	return SearchIndexView{coll: coll}
+}
+
 // Drop drops the collection on the server. This method ignores "namespace not found" errors so it is safe to drop
 // a collection that does not exist on the server.
 func (coll *Collection) Drop(ctx context.Context) error {
@@ -1653,6 +2241,79 @@ func (coll *Collection) Drop(ctx context.Context) error {
 	return nil
 }
 
+// WithTransaction executes the fn callback in a transaction and returns its result. If ctx contains a Session, the
+// transaction is run in that Session using Session.WithTransaction. If a transaction is already running in that
+// Session, fn is called directly so its operations become part of the running transaction, which the caller remains
+// responsible for committing or aborting. Otherwise, a new Session is started for the duration of the call, using the
+// read concern, write concern, and read preference of the collection as the defaults for the transaction, and ended
+// before WithTransaction returns.
+//
+// The SessionContext passed to fn must be used as the Context parameter for any operations in fn that should be
+// executed in the transaction. The fn callback may be run multiple times and the behavior for retrying the callback
+// and the commit is the same as for Session.WithTransaction.
+//
+// The opts parameter can be used to specify options for the transaction (see the options.TransactionOptions
+// documentation).
+func (coll *Collection) WithTransaction(ctx context.Context, fn func(sessCtx SessionContext) (interface{}, error),
+	opts ...*options.TransactionOptions) (interface{}, error) {
+
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	if sess := SessionFromContext(ctx); sess != nil {
+		if si, ok := sess.(*sessionImpl); ok && si.clientSession.TransactionRunning() {
+			return fn(NewSessionContext(ctx, sess))
+		}
+		return sess.WithTransaction(ctx, fn, opts...)
+	}
+
+	sessOpts := options.Session().
+		SetDefaultReadConcern(coll.readConcern).
+		SetDefaultWriteConcern(coll.writeConcern).
+		SetDefaultReadPreference(coll.readPreference)
+	sess, err := coll.client.StartSession(sessOpts)
+	if err != nil {
+		return nil, err
+	}
+	defer sess.EndSession(ctx)
+
+	return sess.WithTransaction(ctx, fn, opts...)
+}
+
+// staleReadOperationTime returns the operation time of the session to compare the operation time of a read against to
+// determine whether it should be retried on the primary. This is the time that a causally consistent session sends as
+// afterClusterTime. The gossiped cluster time is not used because it advances with writes from other clients and
+// routinely runs ahead of the operation time of healthy secondaries. It returns nil if the read should not be retried
+// because the retry option is not set, the read preference already targets the primary, a transaction is running, or
+// the session has no operation time.
+func staleReadOperationTime(retryOnPrimary *bool, sess *session.Client, rp *readpref.ReadPref) *primitive.Timestamp {
+	if retryOnPrimary == nil || !*retryOnPrimary || sess == nil || sess.TransactionRunning() {
+		return nil
+	}
+	if rp == nil || rp.Mode() == readpref.PrimaryMode || sess.OperationTime == nil {
+		return nil
+	}
+	afterClusterTime := *sess.OperationTime
+	return &afterClusterTime
+}
+
+// isStaleRead returns true if operationTime is older than afterClusterTime, meaning that the node that executed the read
+// had not replicated all of the writes observed by the session when the read was started.
+func isStaleRead(afterClusterTime, operationTime *primitive.Timestamp) bool {
+	if afterClusterTime == nil || operationTime == nil {
+		return false
+	}
+	return primitive.CompareTimestamp(*operationTime, *afterClusterTime) < 0
+}
+
+func primaryReadSelector(localThreshold time.Duration) description.ServerSelector {
+	return description.CompositeSelector([]description.ServerSelector{
+		description.ReadPrefSelector(readpref.Primary()),
+		description.LatencySelector(localThreshold),
+	})
+}
+
 // makePinnedSelector makes a selector for a pinned session with a pinned server. Will attempt to do server selection on
 // the pinned server but if that fails it will go through a list of default selectors
 func makePinnedSelector(sess *session.Client, defaultSelector description.ServerSelector) description.ServerSelectorFunc {
@@ -1672,6 +2333,63 @@ func makePinnedSelector(sess *session.Client, defaultSelector description.Server
 	}
 }
 
+// findServerSelection returns the read preference and server selector for a find operation with the given options,
+// which can override the read preference of the collection and target a specific server.
+func (coll *Collection) findServerSelection(sess *session.Client, fo *options.FindOptions) (*readpref.ReadPref,
+	description.ServerSelector, error) {
+
+	if fo.ReadPreference == nil && fo.ServerAddress == nil {
+		return coll.readPreference, makeReadPrefSelector(sess, coll.readSelector, coll.client.localThreshold), nil
+	}
+	if sess.TransactionRunning() {
+		return nil, nil, errors.New("the ReadPreference and ServerAddress options cannot be used in a transaction")
+	}
+
+	rp := coll.readPreference
+	if fo.ReadPreference != nil {
+		rp = fo.ReadPreference
+	}
+	if fo.ServerAddress == nil {
+		selector := description.CompositeSelector([]description.ServerSelector{
+			description.ReadPrefSelector(rp),
+			description.LatencySelector(coll.client.localThreshold),
+		})
+		return rp, makePinnedSelector(sess, selector), nil
+	}
+
+	// The latency window is not applied because the server has been chosen explicitly.
+	addr := address.Address(*fo.ServerAddress).Canonicalize()
+	return rp, makePinnedSelector(sess, makeServerAddressSelector(addr, description.ReadPrefSelector(rp))), nil
+}
+
+// makeServerAddressSelector returns a selector that only selects the server with the given address if it is selected
+// by selector. Selection fails immediately if the topology has been discovered and does not contain the server, so
+// that operations targeting an unknown server do not wait for the server selection timeout.
+func makeServerAddressSelector(addr address.Address, selector description.ServerSelector) description.ServerSelectorFunc {
+	return func(t description.Topology, svrs []description.Server) ([]description.Server, error) {
+		if t.Kind != description.Unknown {
+			var found bool
+			for _, s := range t.Servers {
+				found = found || s.Addr == addr
+			}
+			if !found {
+				return nil, fmt.Errorf("server %s is not part of the deployment", addr)
+			}
+		}
+
+		suitable, err := selector.SelectServer(t, svrs)
+		if err != nil {
+			return nil, err
+		}
+		for _, candidate := range suitable {
+			if candidate.Addr == addr {
+				return []description.Server{candidate}, nil
+			}
+		}
+		return nil, nil
+	}
+}
+
 func makeReadPrefSelector(sess *session.Client, selector description.ServerSelector, localThreshold time.Duration) description.ServerSelectorFunc {
 	if sess != nil && sess.TransactionRunning() {
 		selector = description.CompositeSelector([]description.ServerSelector{
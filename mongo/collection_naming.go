@@ -0,0 +1,127 @@
+// Copyright (C) MongoDB, Inc. 2021-present.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
+
+package mongo
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"unicode"
+
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// CollectionNameProvider is implemented by model types that store their values in a collection with a fixed name. The
+// name overrides the CollectionNamer client option for the type. CollectionName is called on the zero value of the
+// model type, so it must not depend on the fields of the value.
+type CollectionNameProvider interface {
+	CollectionName() string
+}
+
+var tCollectionNameProvider = reflect.TypeOf((*CollectionNameProvider)(nil)).Elem()
+
+// CollectionFor gets a handle for the collection that stores values of the type of model. The model can be a value
+// of the type or a pointer, slice, or array of it, e.g. User{}, &User{}, or []User{} all resolve to the collection for
+// User.
+//
+// The collection name is determined as follows:
+//
+// 1. If the model type or a pointer to it implements CollectionNameProvider, its CollectionName is used.
+//
+// 2. If the CollectionNamer client option was set, it is called with the model type.
+//
+// 3. Otherwise, the name of the model type is used unchanged.
+//
+// An error is returned if the name is empty, e.g. because the model type is an unnamed struct type.
+//
+// The opts parameter can be used to specify options for the Collection (see the options.CollectionOptions
+// documentation).
+func (db *Database) CollectionFor(model interface{}, opts ...*options.CollectionOptions) (*Collection, error) {
+	name, err := db.client.collectionName(model)
+	if err != nil {
+		return nil, err
+	}
+	return db.Collection(name, opts...), nil
+}
+
+// collectionName returns the name of the collection for the type of model as described in the Database.CollectionFor
+// documentation.
+func (c *Client) collectionName(model interface{}) (string, error) {
+	if model == nil {
+		return "", ErrNilValue
+	}
+
+	t := reflect.TypeOf(model)
+	for t.Kind() == reflect.Ptr || t.Kind() == reflect.Slice || t.Kind() == reflect.Array {
+		t = t.Elem()
+	}
+	if t.Kind() == reflect.Interface {
+		return "", fmt.Errorf("cannot determine a collection name for interface type %v", t)
+	}
+
+	var name string
+	switch {
+	case t.Implements(tCollectionNameProvider):
+		name = reflect.Zero(t).Interface().(CollectionNameProvider).CollectionName()
+	case reflect.PtrTo(t).Implements(tCollectionNameProvider):
+		name = reflect.New(t).Interface().(CollectionNameProvider).CollectionName()
+	case c.collectionNamer != nil:
+		name = c.collectionNamer(t)
+	default:
+		name = t.Name()
+	}
+
+	if name == "" {
+		return "", fmt.Errorf("cannot determine a collection name for model type %v", t)
+	}
+	return name, nil
+}
+
+// SnakeCaseCollectionName is an options.CollectionNamer that converts the name of the model type to snake_case, e.g.
+// "UserProfile" to "user_profile". Acronyms are kept together, so "HTTPRequest" becomes "http_request".
+func SnakeCaseCollectionName(modelType reflect.Type) string {
+	return snakeCase(modelType.Name())
+}
+
+// PluralSnakeCaseCollectionName is an options.CollectionNamer that converts the name of the model type to snake_case
+// like SnakeCaseCollectionName and pluralizes the last word using common English rules, e.g. "UserProfile" to
+// "user_profiles" and "Category" to "categories". Irregular plurals are not handled, so types with irregular plural
+// names should implement CollectionNameProvider.
+func PluralSnakeCaseCollectionName(modelType reflect.Type) string {
+	return pluralize(snakeCase(modelType.Name()))
+}
+
+func snakeCase(name string) string {
+	runes := []rune(name)
+	var sb strings.Builder
+	for i, r := range runes {
+		if unicode.IsUpper(r) && i > 0 {
+			prev := runes[i-1]
+			// Start a new word after a lowercase letter or digit, or at the last capital of an acronym that is
+			// followed by a lowercase letter, e.g. the "R" in "HTTPRequest".
+			nextIsLower := i+1 < len(runes) && unicode.IsLower(runes[i+1])
+			if unicode.IsLower(prev) || unicode.IsDigit(prev) || (unicode.IsUpper(prev) && nextIsLower) {
+				sb.WriteByte('_')
+			}
+		}
+		sb.WriteRune(unicode.ToLower(r))
+	}
+	return sb.String()
+}
+
+func pluralize(word string) string {
+	switch {
+	case word == "":
+		return word
+	case strings.HasSuffix(word, "s"), strings.HasSuffix(word, "x"), strings.HasSuffix(word, "z"),
+		strings.HasSuffix(word, "ch"), strings.HasSuffix(word, "sh"):
+		return word + "es"
+	case strings.HasSuffix(word, "y") && len(word) > 1 && !strings.ContainsRune("aeiou", rune(word[len(word)-2])):
+		return word[:len(word)-1] + "ies"
+	}
+	return word + "s"
+}
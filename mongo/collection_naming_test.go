@@ -0,0 +1,106 @@
+// Copyright (C) MongoDB, Inc. 2021-present.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
+
+package mongo
+
+import (
+	"reflect"
+	"testing"
+
+	"go.mongodb.org/mongo-driver/internal/testutil/assert"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"go.mongodb.org/mongo-driver/x/mongo/driver/session"
+)
+
+type UserProfile struct{}
+
+type AuditEntry struct{}
+
+func (AuditEntry) CollectionName() string { return "audit_log" }
+
+type Person struct{}
+
+func (*Person) CollectionName() string { return "people" }
+
+func TestCollectionNaming(t *testing.T) {
+	t.Run("CollectionFor", func(t *testing.T) {
+		testCases := []struct {
+			name     string
+			namer    options.CollectionNamer
+			model    interface{}
+			expected string
+		}{
+			{"type name", nil, UserProfile{}, "UserProfile"},
+			{"pointer", nil, &UserProfile{}, "UserProfile"},
+			{"slice", nil, []*UserProfile{}, "UserProfile"},
+			{"namer", PluralSnakeCaseCollectionName, []UserProfile{}, "user_profiles"},
+			{"provider overrides namer", PluralSnakeCaseCollectionName, AuditEntry{}, "audit_log"},
+			{"pointer receiver provider", SnakeCaseCollectionName, Person{}, "people"},
+		}
+		for _, tc := range testCases {
+			t.Run(tc.name, func(t *testing.T) {
+				client := setupClient(options.Client().SetCollectionNamer(tc.namer))
+				coll, err := client.Database("db").CollectionFor(tc.model)
+				assert.Nil(t, err, "CollectionFor error: %v", err)
+				assert.Equal(t, tc.expected, coll.Name(), "expected collection name %q, got %q", tc.expected, coll.Name())
+			})
+		}
+	})
+	t.Run("CollectionFor errors", func(t *testing.T) {
+		db := setupClient().Database("db")
+		_, err := db.CollectionFor(nil)
+		assert.Equal(t, ErrNilValue, err, "expected error %v, got %v", ErrNilValue, err)
+		_, err = db.CollectionFor(struct{}{})
+		assert.NotNil(t, err, "expected CollectionFor error for unnamed type, got nil")
+		_, err = db.CollectionFor([]CollectionNameProvider{})
+		assert.NotNil(t, err, "expected CollectionFor error for interface type, got nil")
+	})
+	t.Run("facade keeps namer", func(t *testing.T) {
+		client := setupClient(options.Client().SetCollectionNamer(SnakeCaseCollectionName))
+		client.sessionPool = session.NewPool(nil)
+		facade, err := client.NewFacade()
+		assert.Nil(t, err, "NewFacade error: %v", err)
+
+		coll, err := facade.Database("db").CollectionFor(UserProfile{})
+		assert.Nil(t, err, "CollectionFor error: %v", err)
+		assert.Equal(t, "user_profile", coll.Name(), "expected collection name user_profile, got %q", coll.Name())
+	})
+	t.Run("snake case", func(t *testing.T) {
+		testCases := map[string]string{
+			"User":          "user",
+			"UserProfile":   "user_profile",
+			"HTTPRequest":   "http_request",
+			"UserID":        "user_id",
+			"Item2Tag":      "item2_tag",
+			"already_snake": "already_snake",
+		}
+		for name, expected := range testCases {
+			got := snakeCase(name)
+			assert.Equal(t, expected, got, "expected %q for %q, got %q", expected, name, got)
+		}
+	})
+	t.Run("pluralize", func(t *testing.T) {
+		testCases := map[string]string{
+			"user":     "users",
+			"address":  "addresses",
+			"box":      "boxes",
+			"match":    "matches",
+			"category": "categories",
+			"day":      "days",
+		}
+		for word, expected := range testCases {
+			got := pluralize(word)
+			assert.Equal(t, expected, got, "expected %q for %q, got %q", expected, word, got)
+		}
+	})
+	t.Run("namers use type name", func(t *testing.T) {
+		typ := reflect.TypeOf(UserProfile{})
+		assert.Equal(t, "user_profile", SnakeCaseCollectionName(typ), "expected user_profile, got %v",
+			SnakeCaseCollectionName(typ))
+		assert.Equal(t, "user_profiles", PluralSnakeCaseCollectionName(typ), "expected user_profiles, got %v",
+			PluralSnakeCaseCollectionName(typ))
+	})
+}
@@ -7,15 +7,23 @@
 package mongo
 
 import (
+	"context"
 	"errors"
 	"testing"
 
 	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
 	"go.mongodb.org/mongo-driver/internal/testutil/assert"
+	"go.mongodb.org/mongo-driver/mongo/address"
+	"go.mongodb.org/mongo-driver/mongo/description"
 	"go.mongodb.org/mongo-driver/mongo/options"
 	"go.mongodb.org/mongo-driver/mongo/readconcern"
 	"go.mongodb.org/mongo-driver/mongo/readpref"
 	"go.mongodb.org/mongo-driver/mongo/writeconcern"
+	"go.mongodb.org/mongo-driver/tag"
+	"go.mongodb.org/mongo-driver/x/bsonx/bsoncore"
+	"go.mongodb.org/mongo-driver/x/mongo/driver/session"
+	"go.mongodb.org/mongo-driver/x/mongo/driver/uuid"
 )
 
 const (
@@ -74,6 +82,11 @@ func TestCollection(t *testing.T) {
 			writeConcern:   wc1,
 		}
 		compareColls(t, expected, coll)
+
+		assert.Equal(t, rpPrimary, coll.ReadPreference(), "expected read preference %v, got %v", rpPrimary,
+			coll.ReadPreference())
+		assert.Equal(t, rcLocal, coll.ReadConcern(), "expected read concern %v, got %v", rcLocal, coll.ReadConcern())
+		assert.Equal(t, wc1, coll.WriteConcern(), "expected write concern %v, got %v", wc1, coll.WriteConcern())
 	})
 	t.Run("replace topology error", func(t *testing.T) {
 		coll := setupColl("foo")
@@ -213,4 +226,239 @@ func TestCollection(t *testing.T) {
 		_, err = coll.Watch(bgCtx, nil)
 		assert.Equal(t, aggErr, err, "expected error %v, got %v", aggErr, err)
 	})
+	t.Run("invalid let document", func(t *testing.T) {
+		coll := setupColl("foo")
+		doc := bson.D{}
+		update := bson.D{{"$set", bson.D{{"x", 1}}}}
+
+		err := coll.FindOneAndDelete(bgCtx, doc, options.FindOneAndDelete().SetLet("x")).Err()
+		_, ok := err.(MarshalError)
+		assert.True(t, ok, "expected error type %T, got %T", MarshalError{}, err)
+
+		err = coll.FindOneAndReplace(bgCtx, doc, doc, options.FindOneAndReplace().SetLet("x")).Err()
+		_, ok = err.(MarshalError)
+		assert.True(t, ok, "expected error type %T, got %T", MarshalError{}, err)
+
+		err = coll.FindOneAndUpdate(bgCtx, doc, update, options.FindOneAndUpdate().SetLet("x")).Err()
+		_, ok = err.(MarshalError)
+		assert.True(t, ok, "expected error type %T, got %T", MarshalError{}, err)
+	})
+	t.Run("filter predicate", func(t *testing.T) {
+		type tenantKey struct{}
+		predicate := func(ctx context.Context) (interface{}, error) {
+			tenant, ok := ctx.Value(tenantKey{}).(string)
+			if !ok {
+				return nil, errors.New("no tenant")
+			}
+			if tenant == "" {
+				return nil, nil
+			}
+			return bson.D{{"tenant", tenant}}, nil
+		}
+		coll := setupColl("foo", options.Collection().SetFilterPredicate(predicate))
+		tenantCtx := context.WithValue(bgCtx, tenantKey{}, "acme")
+
+		toRaw := func(val interface{}) bson.Raw {
+			b, err := bson.Marshal(val)
+			assert.Nil(t, err, "Marshal error: %v", err)
+			return b
+		}
+		testCases := []struct {
+			name     string
+			ctx      context.Context
+			filter   interface{}
+			expected bson.Raw
+		}{
+			{"combined", tenantCtx, bson.D{{"x", 1}},
+				toRaw(bson.D{{"$and", bson.A{bson.D{{"x", 1}}, bson.D{{"tenant", "acme"}}}}})},
+			{"empty filter", tenantCtx, bson.D{}, toRaw(bson.D{{"tenant", "acme"}})},
+			{"nil predicate", context.WithValue(bgCtx, tenantKey{}, ""), bson.D{{"x", 1}}, toRaw(bson.D{{"x", 1}})},
+		}
+		for _, tc := range testCases {
+			t.Run(tc.name, func(t *testing.T) {
+				got, err := coll.transformFilter(tc.ctx, tc.filter)
+				assert.Nil(t, err, "transformFilter error: %v", err)
+				assert.Equal(t, tc.expected, bson.Raw(got), "expected filter %v, got %v", tc.expected, bson.Raw(got))
+			})
+		}
+
+		t.Run("predicate error", func(t *testing.T) {
+			_, err := coll.DeleteOne(bgCtx, bson.D{})
+			assert.NotNil(t, err, "expected DeleteOne error, got nil")
+			assert.Equal(t, "no tenant", err.Error(), "expected error %q, got %q", "no tenant", err.Error())
+		})
+		t.Run("clone", func(t *testing.T) {
+			clone, err := coll.Clone()
+			assert.Nil(t, err, "Clone error: %v", err)
+			got, err := clone.transformFilter(tenantCtx, bson.D{})
+			assert.Nil(t, err, "transformFilter error: %v", err)
+			expected := toRaw(bson.D{{"tenant", "acme"}})
+			assert.Equal(t, expected, bson.Raw(got), "expected filter %v, got %v", expected, bson.Raw(got))
+		})
+	})
+	t.Run("with transaction", func(t *testing.T) {
+		coll := setupColl("foo", options.Collection().SetReadConcern(readconcern.Majority()))
+		coll.client.sessionPool = session.NewPool(nil)
+
+		t.Run("new session", func(t *testing.T) {
+			var sess Session
+			res, err := coll.WithTransaction(bgCtx, func(sc SessionContext) (interface{}, error) {
+				sess = SessionFromContext(sc)
+				cs := sess.(*sessionImpl).clientSession
+				assert.True(t, cs.TransactionStarting(), "expected transaction to be starting")
+				assert.Equal(t, readconcern.Majority(), cs.CurrentRc,
+					"expected read concern %v, got %v", readconcern.Majority(), cs.CurrentRc)
+				return "result", nil
+			})
+			assert.Nil(t, err, "WithTransaction error: %v", err)
+			assert.Equal(t, "result", res, "expected result %v, got %v", "result", res)
+			assert.True(t, sess.(*sessionImpl).clientSession.Terminated, "expected session to be ended")
+		})
+		t.Run("session from context", func(t *testing.T) {
+			sess, err := coll.client.StartSession()
+			assert.Nil(t, err, "StartSession error: %v", err)
+			defer sess.EndSession(bgCtx)
+
+			var got Session
+			_, err = coll.WithTransaction(NewSessionContext(bgCtx, sess), func(sc SessionContext) (interface{}, error) {
+				got = SessionFromContext(sc)
+				return nil, nil
+			})
+			assert.Nil(t, err, "WithTransaction error: %v", err)
+			assert.True(t, sess == got, "expected callback to be called with the session from the context")
+			assert.True(t, sess.(*sessionImpl).clientSession.TransactionCommitted(), "expected transaction to be committed")
+		})
+		t.Run("running transaction", func(t *testing.T) {
+			sess, err := coll.client.StartSession()
+			assert.Nil(t, err, "StartSession error: %v", err)
+			defer sess.EndSession(bgCtx)
+			err = sess.StartTransaction()
+			assert.Nil(t, err, "StartTransaction error: %v", err)
+
+			_, err = coll.WithTransaction(NewSessionContext(bgCtx, sess), func(SessionContext) (interface{}, error) {
+				return nil, nil
+			})
+			assert.Nil(t, err, "WithTransaction error: %v", err)
+			assert.True(t, sess.(*sessionImpl).clientSession.TransactionStarting(),
+				"expected transaction to still be running")
+		})
+	})
+	t.Run("stale reads", func(t *testing.T) {
+		afterClusterTime := &primitive.Timestamp{T: 10, I: 5}
+
+		testCases := []struct {
+			name             string
+			afterClusterTime *primitive.Timestamp
+			operationTime    *primitive.Timestamp
+			stale            bool
+		}{
+			{"older operation time", afterClusterTime, &primitive.Timestamp{T: 10, I: 4}, true},
+			{"equal operation time", afterClusterTime, &primitive.Timestamp{T: 10, I: 5}, false},
+			{"newer operation time", afterClusterTime, &primitive.Timestamp{T: 11, I: 0}, false},
+			{"no operation time", afterClusterTime, nil, false},
+			{"no session operation time", nil, &primitive.Timestamp{T: 10, I: 4}, false},
+		}
+		for _, tc := range testCases {
+			t.Run(tc.name, func(t *testing.T) {
+				got := isStaleRead(tc.afterClusterTime, tc.operationTime)
+				assert.Equal(t, tc.stale, got, "expected isStaleRead to return %v, got %v", tc.stale, got)
+			})
+		}
+
+		t.Run("session operation time", func(t *testing.T) {
+			id, _ := uuid.New()
+			sess, err := session.NewClientSession(session.NewPool(nil), id, session.Explicit)
+			assert.Nil(t, err, "NewClientSession error: %v", err)
+			retry := true
+
+			got := staleReadOperationTime(&retry, sess, readpref.Secondary())
+			assert.Nil(t, got, "expected no operation time before the session executed an operation, got %v", got)
+
+			err = sess.AdvanceOperationTime(afterClusterTime)
+			assert.Nil(t, err, "AdvanceOperationTime error: %v", err)
+			got = staleReadOperationTime(&retry, sess, readpref.Secondary())
+			assert.Equal(t, afterClusterTime, got, "expected operation time %v, got %v", afterClusterTime, got)
+			got = staleReadOperationTime(&retry, sess, readpref.Primary())
+			assert.Nil(t, got, "expected no operation time for primary reads, got %v", got)
+			got = staleReadOperationTime(nil, sess, readpref.Secondary())
+			assert.Nil(t, got, "expected no operation time when the option is not set, got %v", got)
+
+			err = sess.StartTransaction(nil)
+			assert.Nil(t, err, "StartTransaction error: %v", err)
+			got = staleReadOperationTime(&retry, sess, readpref.Secondary())
+			assert.Nil(t, got, "expected no operation time in a transaction, got %v", got)
+		})
+		t.Run("cluster time ahead of a causally consistent read", func(t *testing.T) {
+			id, _ := uuid.New()
+			sess, err := session.NewClientSession(session.NewPool(nil), id, session.Explicit)
+			assert.Nil(t, err, "NewClientSession error: %v", err)
+			err = sess.AdvanceOperationTime(afterClusterTime)
+			assert.Nil(t, err, "AdvanceOperationTime error: %v", err)
+			// Writes by other clients advance the gossiped cluster time past the session's operation time.
+			err = sess.AdvanceClusterTime(bson.Raw(bsoncore.BuildDocument(nil, bsoncore.AppendDocumentElement(nil,
+				"$clusterTime", bsoncore.BuildDocument(nil, bsoncore.AppendTimestampElement(nil, "clusterTime", 20, 0))))))
+			assert.Nil(t, err, "AdvanceClusterTime error: %v", err)
+			retry := true
+
+			after := staleReadOperationTime(&retry, sess, readpref.Secondary())
+			stale := isStaleRead(after, &primitive.Timestamp{T: 10, I: 6})
+			assert.False(t, stale, "expected read at an operation time after afterClusterTime to not be stale")
+		})
+	})
+	t.Run("server targeting", func(t *testing.T) {
+		analyticsTags := tag.Set{{Name: "nodeType", Value: "ANALYTICS"}}
+		topo := description.Topology{
+			Kind: description.ReplicaSetWithPrimary,
+			Servers: []description.Server{
+				{Addr: address.Address("a:27017"), Kind: description.RSPrimary},
+				{Addr: address.Address("b:27017"), Kind: description.RSSecondary, Tags: analyticsTags},
+				{Addr: address.Address("c:27017"), Kind: description.RSSecondary},
+			},
+		}
+		coll := setupColl("targeting")
+		selectAddrs := func(t *testing.T, fo *options.FindOptions) ([]address.Address, error) {
+			t.Helper()
+
+			_, selector, err := coll.findServerSelection(nil, fo)
+			assert.Nil(t, err, "findServerSelection error: %v", err)
+			selected, err := selector.SelectServer(topo, topo.Servers)
+			var addrs []address.Address
+			for _, s := range selected {
+				addrs = append(addrs, s.Addr)
+			}
+			return addrs, err
+		}
+
+		t.Run("read preference", func(t *testing.T) {
+			rp := readpref.Secondary(readpref.WithTagSets(analyticsTags))
+			addrs, err := selectAddrs(t, options.Find().SetReadPreference(rp))
+			assert.Nil(t, err, "SelectServer error: %v", err)
+			assert.Equal(t, []address.Address{"b:27017"}, addrs, "expected the analytics member, got %v", addrs)
+		})
+		t.Run("server address", func(t *testing.T) {
+			fo := options.Find().SetReadPreference(readpref.Secondary()).SetServerAddress("C:27017")
+			addrs, err := selectAddrs(t, fo)
+			assert.Nil(t, err, "SelectServer error: %v", err)
+			assert.Equal(t, []address.Address{"c:27017"}, addrs, "expected the requested member, got %v", addrs)
+
+			// The collection's primary read preference does not select the secondary.
+			addrs, err = selectAddrs(t, options.Find().SetServerAddress("c:27017"))
+			assert.Nil(t, err, "SelectServer error: %v", err)
+			assert.Equal(t, 0, len(addrs), "expected no servers, got %v", addrs)
+		})
+		t.Run("unknown server address", func(t *testing.T) {
+			_, err := selectAddrs(t, options.Find().SetServerAddress("d:27017"))
+			assert.NotNil(t, err, "expected SelectServer error, got nil")
+		})
+		t.Run("transaction", func(t *testing.T) {
+			id, _ := uuid.New()
+			sess, err := session.NewClientSession(session.NewPool(nil), id, session.Explicit)
+			assert.Nil(t, err, "NewClientSession error: %v", err)
+			err = sess.StartTransaction(nil)
+			assert.Nil(t, err, "StartTransaction error: %v", err)
+
+			_, _, err = coll.findServerSelection(sess, options.Find().SetServerAddress("a:27017"))
+			assert.NotNil(t, err, "expected findServerSelection error, got nil")
+		})
+	})
 }
@@ -0,0 +1,51 @@
+// Copyright (C) MongoDB, Inc. 2021-present.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
+
+package mongo_test
+
+import (
+	"context"
+	"testing"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/internal/testutil/assert"
+	"go.mongodb.org/mongo-driver/mongo/mongotest"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+func TestCommentAndLet(t *testing.T) {
+	d := mongotest.New()
+	client, err := d.NewClient()
+	assert.Nil(t, err, "NewClient error: %v", err)
+	defer func() { _ = client.Disconnect(context.Background()) }()
+	coll := client.Database("db").Collection("orders")
+
+	t.Run("insert one", func(t *testing.T) {
+		d.Reset()
+		d.AddReplies(mongotest.SuccessReply(bson.E{"n", 1}))
+		_, err := coll.InsertOne(context.Background(), bson.D{{"_id", 1}}, options.InsertOne().SetComment("hello"))
+		assert.Nil(t, err, "InsertOne error: %v", err)
+
+		cmds := d.Commands()
+		assert.Equal(t, 1, len(cmds), "expected 1 command, got %v", len(cmds))
+		comment, _ := cmds[0].Document.Lookup("comment").StringValueOK()
+		assert.Equal(t, "hello", comment, "expected comment %q, got %q", "hello", comment)
+	})
+	t.Run("replace one", func(t *testing.T) {
+		d.Reset()
+		d.AddReplies(mongotest.SuccessReply(bson.E{"n", 1}, bson.E{"nModified", 1}))
+		opts := options.Replace().SetComment("hello").SetLet(bson.D{{"x", 1}})
+		_, err := coll.ReplaceOne(context.Background(), bson.D{{"_id", 1}}, bson.D{{"y", 2}}, opts)
+		assert.Nil(t, err, "ReplaceOne error: %v", err)
+
+		cmds := d.Commands()
+		assert.Equal(t, 1, len(cmds), "expected 1 command, got %v", len(cmds))
+		comment, _ := cmds[0].Document.Lookup("comment").StringValueOK()
+		assert.Equal(t, "hello", comment, "expected comment %q, got %q", "hello", comment)
+		let, _ := cmds[0].Document.Lookup("let", "x").Int32OK()
+		assert.Equal(t, int32(1), let, "expected let.x 1, got %v", let)
+	})
+}
@@ -0,0 +1,30 @@
+// Copyright (C) MongoDB, Inc. 2021-present.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
+
+package mongo
+
+import (
+	"context"
+
+	"go.mongodb.org/mongo-driver/x/mongo/driver"
+)
+
+// WithCorrelationID returns a Context derived from ctx that carries the given correlation ID, e.g. the trace or request
+// ID of the application, replacing any correlation ID already carried by ctx.
+//
+// The command monitoring events, the connection checkout pool events, and the server selection events published for
+// operations executed with the returned Context have their CorrelationID field set to id. The events also have an
+// OperationID field that is generated by the driver, which is shared by all of the events of an operation, including
+// those of its retries, and can be used to join them in a tracing backend even if no correlation ID is set. The Cursor
+// returned by an operation executes each getMore as a separate operation.
+func WithCorrelationID(ctx context.Context, id string) context.Context {
+	return driver.WithCorrelationID(ctx, id)
+}
+
+// CorrelationID returns the correlation ID carried by ctx, or an empty string if ctx does not carry a correlation ID.
+func CorrelationID(ctx context.Context) string {
+	return driver.CorrelationID(ctx)
+}
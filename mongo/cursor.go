@@ -12,6 +12,7 @@ import (
 	"fmt"
 	"io"
 	"reflect"
+	"time"
 
 	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/bson/bsoncodec"
@@ -32,6 +33,8 @@ type Cursor struct {
 	batchLength   int
 	registry      *bsoncodec.Registry
 	clientSession *session.Client
+	firstBatch    []bson.Raw
+	leakTimer     *time.Timer
 
 	err error
 }
@@ -66,6 +69,57 @@ func newEmptyCursor() *Cursor {
 	return &Cursor{bc: driver.NewEmptyBatchCursor()}
 }
 
+// CursorStats contains statistics about the getMore commands sent to iterate a Cursor or ChangeStream. For a tailable
+// await cursor or a change stream, the duration of a getMore that returns no documents is approximately the time the
+// server awaited new documents, which is bounded by the max await time. These statistics can be used to tune the max
+// await time, trading the latency of new events against the number of getMore commands the server has to process.
+type CursorStats struct {
+	// The number of getMore commands that were sent.
+	GetMoreCount int64
+
+	// The number of getMore commands that succeeded but returned no documents, e.g. because the await time expired.
+	EmptyBatchCount int64
+
+	// The total, most recent, and maximum round trip time of the getMore commands, including the time the server
+	// awaited new documents.
+	TotalDuration time.Duration
+	LastDuration  time.Duration
+	MaxDuration   time.Duration
+}
+
+func newCursorStats(stats driver.GetMoreStats) CursorStats {
+	return CursorStats{
+		GetMoreCount:    stats.Count,
+		EmptyBatchCount: stats.EmptyBatches,
+		TotalDuration:   stats.TotalDuration,
+		LastDuration:    stats.LastDuration,
+		MaxDuration:     stats.MaxDuration,
+	}
+}
+
+// SetMaxAwaitTime sets the maximum amount of time the server waits for new documents in the getMore commands sent
+// after this call, overriding the MaxAwaitTime option the cursor was created with. This can only be used for
+// tailable await cursors, i.e. cursors created by Find with CursorType set to options.TailableAwait; the server returns
+// an error from the next getMore for other cursors. A duration of 0 means the server default is used.
+func (c *Cursor) SetMaxAwaitTime(d time.Duration) {
+	if bc, ok := c.bc.(awaitBatchCursor); ok {
+		bc.SetMaxTime(d)
+	}
+}
+
+// Stats returns statistics about the getMore commands sent by the cursor.
+func (c *Cursor) Stats() CursorStats {
+	if bc, ok := c.bc.(awaitBatchCursor); ok {
+		return newCursorStats(bc.GetMoreStats())
+	}
+	return CursorStats{}
+}
+
+// FirstBatch returns the documents in the first batch returned by the server for a cursor created by
+// Database.RunCommandCursor. It returns nil for other cursors. Unlike Current, the returned documents remain valid after
+// calls to Next and TryNext.
+func (c *Cursor) FirstBatch() []bson.Raw { return c.firstBatch }
+
 // ID returns the ID of this cursor, or 0 if the cursor has been closed or exhausted.
 func (c *Cursor) ID() int64 { return c.bc.ID() }
 
@@ -130,6 +184,7 @@ func (c *Cursor) next(ctx context.Context, nonBlocking bool) bool {
 			}
 			// Is the cursor ID zero?
 			if c.bc.ID() == 0 {
+				c.stopLeakTimer()
 				c.closeImplicitSession()
 				return false
 			}
@@ -143,6 +198,7 @@ func (c *Cursor) next(ctx context.Context, nonBlocking bool) bool {
 
 		// close the implicit session if this was the last getMore
 		if c.bc.ID() == 0 {
+			c.stopLeakTimer()
 			c.closeImplicitSession()
 		}
 
@@ -176,6 +232,7 @@ func (c *Cursor) Err() error { return c.err }
 // the first call, any subsequent calls will not change the state.
 func (c *Cursor) Close(ctx context.Context) error {
 	defer c.closeImplicitSession()
+	c.stopLeakTimer()
 	return replaceErrors(c.bc.Close(ctx))
 }
 
@@ -227,6 +284,93 @@ func (c *Cursor) All(ctx context.Context, results interface{}) error {
 	return nil
 }
 
+// CursorResult is a value received from the channel returned by Cursor.Stream. Exactly one of Document and Err is set.
+type CursorResult struct {
+	// A document returned by the cursor. Unlike Cursor.Current, it remains valid after the next value is received.
+	Document bson.Raw
+
+	// The error that stopped the iteration. It is the last value sent on the channel.
+	Err error
+}
+
+// Stream iterates the cursor in a new goroutine and sends each document on the returned channel. If an error occurs,
+// it is sent as the last value. The channel is closed once the cursor is exhausted, an error occurs, or ctx is done.
+//
+// The caller must either receive all values from the channel or cancel ctx to stop the iteration early. In both
+// cases, the cursor is closed before the channel is closed, which kills the cursor on the server if it is not
+// exhausted. The cursor is closed with a new Context so it is also killed after ctx has been cancelled.
+//
+// The cursor must not be used after calling Stream.
+func (c *Cursor) Stream(ctx context.Context) <-chan CursorResult {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	results := make(chan CursorResult)
+	go func() {
+		defer close(results)
+		defer c.closeDetached()
+
+		for c.Next(ctx) {
+			doc := make(bson.Raw, len(c.Current))
+			copy(doc, c.Current)
+			select {
+			case results <- CursorResult{Document: doc}:
+			case <-ctx.Done():
+				return
+			}
+		}
+		if err := c.Err(); err != nil {
+			select {
+			case results <- CursorResult{Err: err}:
+			case <-ctx.Done():
+			}
+		}
+	}()
+	return results
+}
+
+// Documents returns an iterator over the documents of the cursor that can be used with a range-over-func loop in Go
+// 1.23 or later:
+//
+//	for doc, err := range cursor.Documents(ctx) {
+//	    if err != nil {
+//	        return err
+//	    }
+//	    ...
+//	}
+//
+// The iterator yields each document with a nil error. If an error occurs, it is yielded with a nil document as the
+// last value. The yielded documents are only valid until the next iteration; a copy must be made to retain them. The
+// cursor is closed when the iteration ends, including when the loop is exited early, which kills the cursor on the
+// server if it is not exhausted.
+//
+// The cursor must not be used after the iteration has started.
+func (c *Cursor) Documents(ctx context.Context) func(yield func(bson.Raw, error) bool) {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	return func(yield func(bson.Raw, error) bool) {
+		defer c.closeDetached()
+
+		for c.Next(ctx) {
+			if !yield(c.Current, nil) {
+				return
+			}
+		}
+		if err := c.Err(); err != nil {
+			yield(nil, err)
+		}
+	}
+}
+
+// closeDetached closes the cursor with a new Context so the cursor is also killed on the server if the Context used
+// to iterate it is done.
+func (c *Cursor) closeDetached() {
+	_ = c.Close(context.Background())
+}
+
 // RemainingBatchLength returns the number of documents left in the current batch. If this returns zero, the subsequent
 // call to Next or TryNext will do a network request to fetch the next batch.
 func (c *Cursor) RemainingBatchLength() int {
@@ -268,6 +412,26 @@ func (c *Cursor) closeImplicitSession() {
 	}
 }
 
+// copyBatch returns a copy of the documents in batch that can be retained after the batch has been iterated.
+func copyBatch(batch *bsoncore.DocumentSequence) ([]bson.Raw, error) {
+	if batch == nil || len(batch.Data) == 0 {
+		return nil, nil
+	}
+
+	copied := &bsoncore.DocumentSequence{Style: batch.Style, Data: make([]byte, len(batch.Data))}
+	copy(copied.Data, batch.Data)
+	docs, err := copied.Documents()
+	if err != nil {
+		return nil, err
+	}
+
+	raws := make([]bson.Raw, 0, len(docs))
+	for _, doc := range docs {
+		raws = append(raws, bson.Raw(doc))
+	}
+	return raws, nil
+}
+
 // BatchCursorFromCursor returns a driver.BatchCursor for the given Cursor. If there is no underlying
 // driver.BatchCursor, nil is returned.
 //
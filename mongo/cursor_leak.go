@@ -0,0 +1,54 @@
+// Copyright (C) MongoDB, Inc. 2021-present.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
+
+package mongo
+
+import (
+	"runtime/debug"
+	"time"
+
+	"go.mongodb.org/mongo-driver/event"
+)
+
+// cursorLeakDetector reports cursors that are still open on the server after a timeout to the Leaked function of a
+// CursorMonitor. It is only configured if the CursorLeakTimeout client option is set and a CursorMonitor with a Leaked
+// function is specified, so no stack traces are captured if there is nothing to report them to.
+type cursorLeakDetector struct {
+	timeout time.Duration
+	monitor *event.CursorMonitor
+}
+
+// trackCursor starts tracking cursor if cursor leak detection is enabled for the Client. Cursors that have been
+// exhausted by the initial command do not hold server resources and are not tracked.
+func (c *Client) trackCursor(cursor *Cursor) {
+	if c.cursorLeaks == nil || cursor == nil || cursor.bc.ID() == 0 {
+		return
+	}
+
+	evt := &event.CursorLeakedEvent{
+		CursorID:  cursor.bc.ID(),
+		CreatedAt: time.Now(),
+		Timeout:   c.cursorLeaks.timeout,
+		Stack:     string(debug.Stack()),
+	}
+	cursor.leakTimer = time.AfterFunc(c.cursorLeaks.timeout, func() {
+		c.cursorLeaks.report(evt)
+	})
+}
+
+func (d *cursorLeakDetector) report(evt *event.CursorLeakedEvent) {
+	if d.monitor != nil && d.monitor.Leaked != nil {
+		d.monitor.Leaked(evt)
+	}
+}
+
+// stopLeakTimer stops the cursor leak detection timer for c, if there is one. It must be called when the cursor is
+// closed or exhausted.
+func (c *Cursor) stopLeakTimer() {
+	if c.leakTimer != nil {
+		c.leakTimer.Stop()
+	}
+}
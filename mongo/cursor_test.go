@@ -8,12 +8,19 @@ package mongo
 
 import (
 	"context"
+	"errors"
+	"strings"
 	"testing"
+	"time"
 
 	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/event"
 	"go.mongodb.org/mongo-driver/internal/testutil/assert"
+	"go.mongodb.org/mongo-driver/mongo/options"
 	"go.mongodb.org/mongo-driver/x/bsonx/bsoncore"
 	"go.mongodb.org/mongo-driver/x/mongo/driver"
+	"go.mongodb.org/mongo-driver/x/mongo/driver/session"
+	"go.mongodb.org/mongo-driver/x/mongo/driver/uuid"
 )
 
 type testBatchCursor struct {
@@ -85,6 +92,57 @@ func (tbc *testBatchCursor) Close(context.Context) error {
 	return nil
 }
 
+// lostBatchCursor is a testBatchCursor that loses its pinned connection after returning a number of batches.
+type lostBatchCursor struct {
+	*testBatchCursor
+	lostAfter int
+	lost      bool
+}
+
+func (lbc *lostBatchCursor) Next(ctx context.Context) bool {
+	if lbc.lostAfter == 0 {
+		lbc.lost = true
+		lbc.batches = nil
+		return false
+	}
+	lbc.lostAfter--
+	return lbc.testBatchCursor.Next(ctx)
+}
+
+func (lbc *lostBatchCursor) Err() error {
+	if lbc.lost {
+		return driver.Error{Code: 43, Message: "cursor not found"}
+	}
+	return nil
+}
+
+func (lbc *lostBatchCursor) LostPinnedConnection() bool {
+	return lbc.lost
+}
+
+// awaitTestBatchCursor is a testBatchCursor that implements awaitBatchCursor. Next blocks until a value is received
+// from release.
+type awaitTestBatchCursor struct {
+	*testBatchCursor
+	release chan struct{}
+	maxTime time.Duration
+	count   int64
+}
+
+func (abc *awaitTestBatchCursor) Next(ctx context.Context) bool {
+	<-abc.release
+	abc.count++
+	return abc.testBatchCursor.Next(ctx)
+}
+
+func (abc *awaitTestBatchCursor) SetMaxTime(d time.Duration) {
+	abc.maxTime = d
+}
+
+func (abc *awaitTestBatchCursor) GetMoreStats() driver.GetMoreStats {
+	return driver.GetMoreStats{Count: abc.count}
+}
+
 func TestCursor(t *testing.T) {
 	t.Run("loops until docs available", func(t *testing.T) {})
 	t.Run("returns false on context cancellation", func(t *testing.T) {})
@@ -178,4 +236,249 @@ func TestCursor(t *testing.T) {
 			assert.NotNil(t, err, "expected error, got: %v", err)
 		})
 	})
+	t.Run("Stream", func(t *testing.T) {
+		t.Run("sends all documents", func(t *testing.T) {
+			tbc := newTestBatchCursor(2, 3)
+			cursor, err := newCursor(tbc, nil)
+			assert.Nil(t, err, "newCursor error: %v", err)
+
+			var docs []bson.Raw
+			for res := range cursor.Stream(context.Background()) {
+				assert.Nil(t, res.Err, "Stream error: %v", res.Err)
+				docs = append(docs, res.Document)
+			}
+			assert.Equal(t, 6, len(docs), "expected 6 documents, got %v", len(docs))
+			for i, doc := range docs {
+				foo := doc.Lookup("foo").Int32()
+				assert.Equal(t, int32(i), foo, "expected foo to be %v, got %v", i, foo)
+			}
+			assert.True(t, tbc.closed, "expected batch cursor to be closed")
+		})
+		t.Run("closes cursor on cancellation", func(t *testing.T) {
+			tbc := newTestBatchCursor(2, 3)
+			cursor, err := newCursor(tbc, nil)
+			assert.Nil(t, err, "newCursor error: %v", err)
+
+			ctx, cancel := context.WithCancel(context.Background())
+			results := cursor.Stream(ctx)
+			res := <-results
+			assert.Nil(t, res.Err, "Stream error: %v", res.Err)
+			cancel()
+
+			// Drain the channel so the goroutine is known to have finished.
+			for range results {
+			}
+			assert.True(t, tbc.closed, "expected batch cursor to be closed")
+		})
+	})
+	t.Run("Documents", func(t *testing.T) {
+		t.Run("yields all documents", func(t *testing.T) {
+			tbc := newTestBatchCursor(2, 3)
+			cursor, err := newCursor(tbc, nil)
+			assert.Nil(t, err, "newCursor error: %v", err)
+
+			var count int
+			cursor.Documents(context.Background())(func(doc bson.Raw, err error) bool {
+				assert.Nil(t, err, "iteration error: %v", err)
+				count++
+				return true
+			})
+			assert.Equal(t, 6, count, "expected 6 documents, got %v", count)
+			assert.True(t, tbc.closed, "expected batch cursor to be closed")
+		})
+		t.Run("closes cursor when stopped early", func(t *testing.T) {
+			tbc := newTestBatchCursor(2, 3)
+			cursor, err := newCursor(tbc, nil)
+			assert.Nil(t, err, "newCursor error: %v", err)
+
+			var count int
+			cursor.Documents(context.Background())(func(doc bson.Raw, err error) bool {
+				count++
+				return false
+			})
+			assert.Equal(t, 1, count, "expected 1 document, got %v", count)
+			assert.True(t, tbc.closed, "expected batch cursor to be closed")
+		})
+	})
+	t.Run("prefetch", func(t *testing.T) {
+		t.Run("iterates all documents in order", func(t *testing.T) {
+			cursor, err := newCursor(newPrefetchBatchCursor(newTestBatchCursor(3, 2)), nil)
+			assert.Nil(t, err, "newCursor error: %v", err)
+
+			var count int32
+			for cursor.Next(context.Background()) {
+				foo := cursor.Current.Lookup("foo").Int32()
+				assert.Equal(t, count, foo, "expected foo to be %v, got %v", count, foo)
+				count++
+			}
+			assert.Nil(t, cursor.Err(), "cursor error: %v", cursor.Err())
+			assert.Equal(t, int32(6), count, "expected 6 documents, got %v", count)
+			assert.Equal(t, int64(0), cursor.ID(), "expected cursor ID 0, got %v", cursor.ID())
+		})
+		t.Run("fetches one batch ahead", func(t *testing.T) {
+			tbc := newTestBatchCursor(3, 2)
+			pc := newPrefetchBatchCursor(tbc)
+			assert.True(t, pc.Next(context.Background()), "expected Next to return true")
+			assert.Equal(t, 2, pc.Batch().DocumentCount(), "expected 2 documents, got %v", pc.Batch().DocumentCount())
+
+			// Close waits for the background fetch, so exactly one more batch has been consumed afterwards.
+			err := pc.Close(context.Background())
+			assert.Nil(t, err, "Close error: %v", err)
+			assert.True(t, tbc.closed, "expected batch cursor to be closed")
+			assert.Equal(t, 1, len(tbc.batches), "expected 1 remaining batch, got %v", len(tbc.batches))
+		})
+		t.Run("forwards max await time and stats", func(t *testing.T) {
+			abc := &awaitTestBatchCursor{testBatchCursor: newTestBatchCursor(3, 2), release: make(chan struct{}, 1)}
+			cursor, err := newCursor(newPrefetchBatchCursor(abc), nil)
+			assert.Nil(t, err, "newCursor error: %v", err)
+
+			abc.release <- struct{}{}
+			assert.True(t, cursor.Next(context.Background()), "expected Next to return true")
+			// The second batch is being fetched, so the max await time is applied once the fetch has finished.
+			cursor.SetMaxAwaitTime(time.Second)
+			assert.Equal(t, int64(1), cursor.Stats().GetMoreCount,
+				"expected 1 getMore, got %v", cursor.Stats().GetMoreCount)
+
+			abc.release <- struct{}{}
+			for i := 0; i < 2; i++ {
+				assert.True(t, cursor.Next(context.Background()), "expected Next to return true")
+			}
+			assert.Equal(t, time.Second, abc.maxTime, "expected max time 1s, got %v", abc.maxTime)
+			assert.Equal(t, int64(2), cursor.Stats().GetMoreCount,
+				"expected 2 getMores, got %v", cursor.Stats().GetMoreCount)
+
+			abc.release <- struct{}{}
+			err = cursor.Close(context.Background())
+			assert.Nil(t, err, "Close error: %v", err)
+		})
+		t.Run("close stops waiting on context cancellation", func(t *testing.T) {
+			abc := &awaitTestBatchCursor{testBatchCursor: newTestBatchCursor(3, 2), release: make(chan struct{}, 1)}
+			pc := newPrefetchBatchCursor(abc)
+			abc.release <- struct{}{}
+			assert.True(t, pc.Next(context.Background()), "expected Next to return true")
+
+			// The background fetch blocks until release receives a value.
+			ctx, cancel := context.WithCancel(context.Background())
+			cancel()
+			err := pc.Close(ctx)
+			assert.Equal(t, context.Canceled, err, "expected error %v, got %v", context.Canceled, err)
+			assert.Equal(t, int64(0), pc.ID(), "expected cursor ID 0, got %v", pc.ID())
+			abc.release <- struct{}{}
+		})
+		t.Run("ignored for tailable cursors and explicit sessions", func(t *testing.T) {
+			fo := options.Find().SetPrefetch(true)
+			assert.True(t, shouldPrefetch(fo, nil), "expected prefetching without a session")
+
+			sess, err := session.NewClientSession(session.NewPool(nil), uuid.UUID{}, session.Explicit)
+			assert.Nil(t, err, "NewClientSession error: %v", err)
+			assert.False(t, shouldPrefetch(fo, sess), "expected no prefetching for an explicit session")
+
+			fo.SetCursorType(options.TailableAwait)
+			assert.False(t, shouldPrefetch(fo, nil), "expected no prefetching for a tailable cursor")
+		})
+	})
+	t.Run("restart", func(t *testing.T) {
+		t.Run("restarts after the pinned connection is lost", func(t *testing.T) {
+			counters := &driver.PinnedCursorCounters{}
+			var skips []int64
+			restart := func(_ context.Context, returned int64) (batchCursor, error) {
+				skips = append(skips, returned)
+				return newTestBatchCursor(1, 3), nil
+			}
+			lbc := &lostBatchCursor{testBatchCursor: newTestBatchCursor(3, 2), lostAfter: 1}
+			cursor, err := newCursor(newRestartBatchCursor(lbc, restart, counters), nil)
+			assert.Nil(t, err, "newCursor error: %v", err)
+
+			var count int
+			for cursor.Next(context.Background()) {
+				count++
+			}
+			assert.Nil(t, cursor.Err(), "cursor error: %v", cursor.Err())
+			assert.Equal(t, 5, count, "expected 5 documents, got %v", count)
+			assert.Equal(t, []int64{2}, skips, "expected restart after 2 documents, got %v", skips)
+			assert.True(t, lbc.closed, "expected lost batch cursor to be closed")
+			expected := driver.PinnedCursorStats{Restarts: 1}
+			assert.Equal(t, expected, counters.Stats(), "expected stats %v, got %v", expected, counters.Stats())
+		})
+		t.Run("does not restart without progress", func(t *testing.T) {
+			counters := &driver.PinnedCursorCounters{}
+			restart := func(context.Context, int64) (batchCursor, error) {
+				return &lostBatchCursor{testBatchCursor: newTestBatchCursor(1, 1)}, nil
+			}
+			lbc := &lostBatchCursor{testBatchCursor: newTestBatchCursor(3, 2), lostAfter: 1}
+			cursor, err := newCursor(newRestartBatchCursor(lbc, restart, counters), nil)
+			assert.Nil(t, err, "newCursor error: %v", err)
+
+			var count int
+			for cursor.Next(context.Background()) {
+				count++
+			}
+			assert.Equal(t, 2, count, "expected 2 documents, got %v", count)
+			assert.NotNil(t, cursor.Err(), "expected cursor error, got nil")
+			assert.Equal(t, int64(1), counters.Stats().Restarts, "expected 1 restart, got %v", counters.Stats().Restarts)
+		})
+		t.Run("restart failure", func(t *testing.T) {
+			counters := &driver.PinnedCursorCounters{}
+			restartErr := errors.New("restart failed")
+			restart := func(context.Context, int64) (batchCursor, error) {
+				return nil, restartErr
+			}
+			lbc := &lostBatchCursor{testBatchCursor: newTestBatchCursor(3, 2), lostAfter: 0}
+			cursor, err := newCursor(newRestartBatchCursor(lbc, restart, counters), nil)
+			assert.Nil(t, err, "newCursor error: %v", err)
+
+			assert.False(t, cursor.Next(context.Background()), "expected Next to return false")
+			assert.Equal(t, restartErr, cursor.Err(), "expected error %v, got %v", restartErr, cursor.Err())
+			expected := driver.PinnedCursorStats{RestartFailures: 1}
+			assert.Equal(t, expected, counters.Stats(), "expected stats %v, got %v", expected, counters.Stats())
+		})
+	})
+	t.Run("leak detection", func(t *testing.T) {
+		newLeakClient := func(leaked chan *event.CursorLeakedEvent) *Client {
+			monitor := &event.CursorMonitor{
+				Leaked: func(evt *event.CursorLeakedEvent) {
+					leaked <- evt
+				},
+			}
+			return &Client{cursorLeaks: &cursorLeakDetector{timeout: 10 * time.Millisecond, monitor: monitor}}
+		}
+
+		t.Run("reports cursors that are not closed", func(t *testing.T) {
+			leaked := make(chan *event.CursorLeakedEvent, 1)
+			cursor, err := newCursor(newTestBatchCursor(2, 1), nil)
+			assert.Nil(t, err, "newCursor error: %v", err)
+			newLeakClient(leaked).trackCursor(cursor)
+
+			select {
+			case evt := <-leaked:
+				assert.Equal(t, int64(10), evt.CursorID, "expected cursor ID 10, got %v", evt.CursorID)
+				assert.True(t, strings.Contains(evt.Stack, "trackCursor"),
+					"expected stack to contain trackCursor, got %v", evt.Stack)
+			case <-time.After(time.Second):
+				t.Fatal("timed out waiting for cursor leaked event")
+			}
+		})
+		t.Run("does not report closed or exhausted cursors", func(t *testing.T) {
+			leaked := make(chan *event.CursorLeakedEvent, 2)
+			client := newLeakClient(leaked)
+
+			closed, err := newCursor(newTestBatchCursor(2, 1), nil)
+			assert.Nil(t, err, "newCursor error: %v", err)
+			client.trackCursor(closed)
+			err = closed.Close(context.Background())
+			assert.Nil(t, err, "Close error: %v", err)
+
+			exhausted, err := newCursor(newTestBatchCursor(2, 1), nil)
+			assert.Nil(t, err, "newCursor error: %v", err)
+			client.trackCursor(exhausted)
+			for exhausted.Next(context.Background()) {
+			}
+
+			select {
+			case evt := <-leaked:
+				t.Fatalf("expected no cursor leaked events, got %v", evt)
+			case <-time.After(50 * time.Millisecond):
+			}
+		})
+	})
 }
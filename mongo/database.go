@@ -10,9 +10,11 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"time"
 
 	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/bson/bsoncodec"
+	"go.mongodb.org/mongo-driver/bson/bsontype"
 	"go.mongodb.org/mongo-driver/mongo/description"
 	"go.mongodb.org/mongo-driver/mongo/options"
 	"go.mongodb.org/mongo-driver/mongo/readconcern"
@@ -132,7 +134,7 @@ func (db *Database) Aggregate(ctx context.Context, pipeline interface{},
 	return aggregate(a)
 }
 
-func (db *Database) processRunCommand(ctx context.Context, cmd interface{},
+func (db *Database) processRunCommand(ctx context.Context, cmd interface{}, cursorCommand bool,
 	opts ...*options.RunCmdOptions) (*operation.Command, *session.Client, error) {
 	sess := sessionFromContext(ctx)
 	if sess == nil && db.client.sessionPool != nil {
@@ -165,8 +167,28 @@ func (db *Database) processRunCommand(ctx context.Context, cmd interface{},
 		readSelect = makePinnedSelector(sess, readSelect)
 	}
 
-	return operation.NewCommand(runCmdDoc).
-		Session(sess).CommandMonitor(db.client.monitor).
+	var op *operation.Command
+	if cursorCommand {
+		cursorOpts := driver.CursorOptions{
+			CommandMonitor:       db.client.monitor,
+			Crypt:                db.client.cryptFLE,
+			PinnedCursorCounters: db.client.pinnedCursors,
+		}
+		if ro.BatchSize != nil {
+			cursorOpts.BatchSize = *ro.BatchSize
+		}
+		if ro.MaxAwaitTime != nil {
+			cursorOpts.MaxTimeMS = int64(*ro.MaxAwaitTime / time.Millisecond)
+		}
+		if ro.Comment != nil {
+			cursorOpts.Comment = bsoncore.Value{Type: bsontype.String, Data: bsoncore.AppendString(nil, *ro.Comment)}
+		}
+		op = operation.NewCursorCommand(runCmdDoc, cursorOpts)
+	} else {
+		op = operation.NewCommand(runCmdDoc)
+	}
+
+	return op.Session(sess).CommandMonitor(db.client.monitor).
 		ServerSelector(readSelect).ClusterClock(db.client.clock).
 		Database(db.name).Deployment(db.client.deployment).ReadConcern(db.readConcern).
 		Crypt(db.client.cryptFLE).ReadPreference(ro.ReadPreference).ServerAPI(db.client.serverAPI), sess, nil
@@ -187,7 +209,7 @@ func (db *Database) RunCommand(ctx context.Context, runCommand interface{}, opts
 		ctx = context.Background()
 	}
 
-	op, sess, err := db.processRunCommand(ctx, runCommand, opts...)
+	op, sess, err := db.processRunCommand(ctx, runCommand, false, opts...)
 	defer closeImplicitSession(sess)
 	if err != nil {
 		return &SingleResult{err: err}
@@ -212,13 +234,18 @@ func (db *Database) RunCommand(ctx context.Context, runCommand interface{}, opts
 // This must be an order-preserving type such as bson.D. Map types such as bson.M are not valid.
 // If the command document contains a session ID or any transaction-specific fields, the behavior is undefined.
 //
+// Commands that create tailable cursors, e.g. a find command with tailable and awaitData set to true, are supported.
+// The TryNext method of the returned Cursor can be used to poll such a cursor without blocking on an empty batch. The
+// documents in the first batch returned by the server are available via the Cursor.FirstBatch method.
+//
 // The opts parameter can be used to specify options for this operation (see the options.RunCmdOptions documentation).
+// The BatchSize, MaxAwaitTime, and Comment options are applied to the getMore commands used to iterate the cursor.
 func (db *Database) RunCommandCursor(ctx context.Context, runCommand interface{}, opts ...*options.RunCmdOptions) (*Cursor, error) {
 	if ctx == nil {
 		ctx = context.Background()
 	}
 
-	op, sess, err := db.processRunCommand(ctx, runCommand, opts...)
+	op, sess, err := db.processRunCommand(ctx, runCommand, true, opts...)
 	if err != nil {
 		closeImplicitSession(sess)
 		return nil, replaceErrors(err)
@@ -229,13 +256,21 @@ func (db *Database) RunCommandCursor(ctx context.Context, runCommand interface{}
 		return nil, replaceErrors(err)
 	}
 
-	bc, err := op.ResultCursor(driver.CursorOptions{})
+	bc, err := op.ResultCursor()
 	if err != nil {
 		closeImplicitSession(sess)
 		return nil, replaceErrors(err)
 	}
 	cursor, err := newCursorWithSession(bc, db.registry, sess)
-	return cursor, replaceErrors(err)
+	if err != nil {
+		return nil, replaceErrors(err)
+	}
+	if cursor.firstBatch, err = copyBatch(bc.Batch()); err != nil {
+		_ = cursor.Close(ctx)
+		return nil, err
+	}
+	db.client.trackCursor(cursor)
+	return cursor, nil
 }
 
 // Drop drops the database on the server. This method ignores "namespace not found" errors so it is safe to drop
@@ -391,7 +426,11 @@ func (db *Database) ListCollections(ctx context.Context, filter interface{}, opt
 		return nil, replaceErrors(err)
 	}
 	cursor, err := newCursorWithSession(bc, db.registry, sess)
-	return cursor, replaceErrors(err)
+	if err != nil {
+		return nil, replaceErrors(err)
+	}
+	db.client.trackCursor(cursor)
+	return cursor, nil
 }
 
 // ListCollectionNames executes a listCollections command and returns a slice containing the names of the collections
@@ -516,6 +555,9 @@ func (db *Database) CreateCollection(ctx context.Context, name string, opts ...*
 
 		op.IndexOptionDefaults(doc)
 	}
+	if cco.ExpireAfterSeconds != nil {
+		op.ExpireAfterSeconds(*cco.ExpireAfterSeconds)
+	}
 	if cco.MaxDocuments != nil {
 		op.Max(*cco.MaxDocuments)
 	}
@@ -529,6 +571,9 @@ func (db *Database) CreateCollection(ctx context.Context, name string, opts ...*
 		}
 		op.StorageEngine(storageEngine)
 	}
+	if cco.TimeSeriesOptions != nil {
+		op.Timeseries(timeSeriesDocument(cco.TimeSeriesOptions))
+	}
 	if cco.ValidationAction != nil {
 		op.ValidationAction(*cco.ValidationAction)
 	}
@@ -542,10 +587,64 @@ func (db *Database) CreateCollection(ctx context.Context, name string, opts ...*
 		}
 		op.Validator(validator)
 	}
+	if cco.EncryptedFields != nil {
+		encryptedFields, err := transformBsoncoreDocument(db.registry, cco.EncryptedFields, true, "encryptedFields")
+		if err != nil {
+			return err
+		}
+		op.EncryptedFields(encryptedFields)
+		return db.createEncryptedCollection(ctx, name, op, encryptedFields)
+	}
 
 	return db.executeCreateOperation(ctx, op)
 }
 
+// createEncryptedCollection creates the state collections used by Queryable Encryption for the collection, then the
+// collection itself with op and the index on its __safeContent__ field.
+func (db *Database) createEncryptedCollection(ctx context.Context, name string, op *operation.Create,
+	encryptedFields bsoncore.Document) error {
+
+	clusteredIndex := bsoncore.NewDocumentBuilder().
+		AppendDocument("key", bsoncore.NewDocumentBuilder().AppendInt32("_id", 1).Build()).
+		AppendBoolean("unique", true).
+		Build()
+	for _, suffix := range []string{"esc", "ecoc"} {
+		stateName, ok := encryptedFields.Lookup(suffix + "Collection").StringValueOK()
+		if !ok {
+			stateName = "enxcol_." + name + "." + suffix
+		}
+		stateOp := operation.NewCreate(stateName).ClusteredIndex(clusteredIndex).ServerAPI(db.client.serverAPI)
+		if err := db.executeCreateOperation(ctx, stateOp); err != nil {
+			return err
+		}
+	}
+
+	if err := db.executeCreateOperation(ctx, op); err != nil {
+		return err
+	}
+	_, err := db.Collection(name).Indexes().CreateOne(ctx, IndexModel{Keys: bson.D{{"__safeContent__", 1}}})
+	return err
+}
+
+func timeSeriesDocument(opts *options.TimeSeriesOptions) bsoncore.Document {
+	idx, doc := bsoncore.AppendDocumentStart(nil)
+	doc = bsoncore.AppendStringElement(doc, "timeField", opts.TimeField)
+	if opts.MetaField != nil {
+		doc = bsoncore.AppendStringElement(doc, "metaField", *opts.MetaField)
+	}
+	if opts.Granularity != nil {
+		doc = bsoncore.AppendStringElement(doc, "granularity", *opts.Granularity)
+	}
+	if opts.BucketMaxSpan != nil {
+		doc = bsoncore.AppendInt64Element(doc, "bucketMaxSpanSeconds", int64(*opts.BucketMaxSpan/time.Second))
+	}
+	if opts.BucketRounding != nil {
+		doc = bsoncore.AppendInt64Element(doc, "bucketRoundingSeconds", int64(*opts.BucketRounding/time.Second))
+	}
+	doc, _ = bsoncore.AppendDocumentEnd(doc, idx)
+	return doc
+}
+
 // CreateView executes a create command to explicitly create a view on the server. See
 // https://docs.mongodb.com/manual/core/views/ for more information about views. This method requires driver version >=
 // 1.4.0 and MongoDB version >= 3.4.
@@ -579,6 +678,107 @@ func (db *Database) CreateView(ctx context.Context, viewName, viewOn string, pip
 	return db.executeCreateOperation(ctx, op)
 }
 
+// ModifyCollection executes a collMod command to change the options of the collection with the specified name on the
+// server.
+//
+// The opts parameter can be used to specify the changes to make (see the options.ModifyCollectionOptions
+// documentation). Options that are not set are left unchanged on the server.
+//
+// For more information about the command, see https://docs.mongodb.com/manual/reference/command/collMod/.
+func (db *Database) ModifyCollection(ctx context.Context, name string, opts ...*options.ModifyCollectionOptions) error {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	mco := options.MergeModifyCollectionOptions(opts...)
+	op := operation.NewCollMod(name).ServerAPI(db.client.serverAPI)
+
+	if mco.ChangeStreamPreAndPostImages != nil {
+		doc := bsoncore.NewDocumentBuilder().AppendBoolean("enabled", *mco.ChangeStreamPreAndPostImages).Build()
+		op.ChangeStreamPreAndPostImages(doc)
+	}
+	if mco.Index != nil {
+		idx, err := db.modifyIndexDocument(mco.Index)
+		if err != nil {
+			return err
+		}
+		op.Index(idx)
+	}
+	if mco.ValidationAction != nil {
+		op.ValidationAction(*mco.ValidationAction)
+	}
+	if mco.ValidationLevel != nil {
+		op.ValidationLevel(*mco.ValidationLevel)
+	}
+	if mco.Validator != nil {
+		validator, err := transformBsoncoreDocument(db.registry, mco.Validator, true, "validator")
+		if err != nil {
+			return err
+		}
+		op.Validator(validator)
+	}
+
+	sess := sessionFromContext(ctx)
+	if sess == nil && db.client.sessionPool != nil {
+		var err error
+		sess, err = session.NewClientSession(db.client.sessionPool, db.client.id, session.Implicit)
+		if err != nil {
+			return err
+		}
+		defer sess.EndSession()
+	}
+
+	err := db.client.validSession(sess)
+	if err != nil {
+		return err
+	}
+
+	wc := db.writeConcern
+	if sess.TransactionRunning() {
+		wc = nil
+	}
+	if !writeconcern.AckWrite(wc) {
+		sess = nil
+	}
+
+	selector := makePinnedSelector(sess, db.writeSelector)
+	op = op.Session(sess).
+		WriteConcern(wc).
+		CommandMonitor(db.client.monitor).
+		ServerSelector(selector).
+		ClusterClock(db.client.clock).
+		Database(db.name).
+		Deployment(db.client.deployment).
+		Crypt(db.client.cryptFLE)
+
+	return replaceErrors(op.Execute(ctx))
+}
+
+func (db *Database) modifyIndexDocument(opts *options.ModifyIndexOptions) (bsoncore.Document, error) {
+	if (opts.Name == nil) == (opts.Keys == nil) {
+		return nil, errors.New("exactly one of the index name and keys must be specified to modify an index")
+	}
+
+	idx, doc := bsoncore.AppendDocumentStart(nil)
+	if opts.Name != nil {
+		doc = bsoncore.AppendStringElement(doc, "name", *opts.Name)
+	}
+	if opts.Keys != nil {
+		keys, err := transformBsoncoreDocument(db.registry, opts.Keys, false, "keys")
+		if err != nil {
+			return nil, err
+		}
+		doc = bsoncore.AppendDocumentElement(doc, "keyPattern", keys)
+	}
+	if opts.ExpireAfterSeconds != nil {
+		doc = bsoncore.AppendInt64Element(doc, "expireAfterSeconds", *opts.ExpireAfterSeconds)
+	}
+	if opts.Hidden != nil {
+		doc = bsoncore.AppendBooleanElement(doc, "hidden", *opts.Hidden)
+	}
+	return bsoncore.AppendDocumentEnd(doc, idx)
+}
+
 func (db *Database) executeCreateOperation(ctx context.Context, op *operation.Create) error {
 	sess := sessionFromContext(ctx)
 	if sess == nil && db.client.sessionPool != nil {
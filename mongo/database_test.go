@@ -10,6 +10,7 @@ import (
 	"context"
 	"errors"
 	"testing"
+	"time"
 
 	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/bson/bsoncodec"
@@ -18,6 +19,7 @@ import (
 	"go.mongodb.org/mongo-driver/mongo/readconcern"
 	"go.mongodb.org/mongo-driver/mongo/readpref"
 	"go.mongodb.org/mongo-driver/mongo/writeconcern"
+	"go.mongodb.org/mongo-driver/x/bsonx/bsoncore"
 )
 
 func setupDb(name string, opts ...*options.DatabaseOptions) *Database {
@@ -110,4 +112,37 @@ func TestDatabase(t *testing.T) {
 		_, err = db.ListCollectionNames(context.Background(), nil)
 		assert.Equal(t, ErrNilDocument, err, "expected error %v, got %v", ErrNilDocument, err)
 	})
+	t.Run("time series document", func(t *testing.T) {
+		tso := options.TimeSeries().
+			SetTimeField("ts").
+			SetMetaField("meta").
+			SetBucketMaxSpan(90 * time.Minute).
+			SetBucketRounding(90*time.Minute + 500*time.Millisecond)
+
+		got := timeSeriesDocument(tso)
+		expected := bsoncore.NewDocumentBuilder().
+			AppendString("timeField", "ts").
+			AppendString("metaField", "meta").
+			AppendInt64("bucketMaxSpanSeconds", 5400).
+			AppendInt64("bucketRoundingSeconds", 5400).
+			Build()
+		assert.Equal(t, expected, got, "expected document %v, got %v", expected, got)
+	})
+	t.Run("modify collection index error", func(t *testing.T) {
+		db := setupDb("foo")
+
+		testCases := []struct {
+			name  string
+			index *options.ModifyIndexOptions
+		}{
+			{"neither name nor keys", options.ModifyIndex().SetHidden(true)},
+			{"both name and keys", options.ModifyIndex().SetName("x_1").SetKeys(bson.D{{"x", 1}})},
+		}
+		for _, tc := range testCases {
+			t.Run(tc.name, func(t *testing.T) {
+				err := db.ModifyCollection(bgCtx, "bar", options.ModifyCollection().SetIndex(tc.index))
+				assert.NotNil(t, err, "expected ModifyCollection error, got nil")
+			})
+		}
+	})
 }
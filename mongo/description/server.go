@@ -47,6 +47,7 @@ type Server struct {
 	Passives              []string
 	Primary               address.Address
 	ReadOnly              bool
+	ServiceID             *primitive.ObjectID // Only set for servers that are deployed behind a load balancer.
 	SessionTimeoutMinutes uint32
 	SetName               string
 	SetVersion            uint32
@@ -225,6 +226,13 @@ func NewServer(addr address.Address, response bson.Raw) Server {
 				desc.LastError = fmt.Errorf("expected 'secondary' to be a boolean but it's a BSON %s", element.Value().Type)
 				return desc
 			}
+		case "serviceId":
+			oid, ok := element.Value().ObjectIDOK()
+			if !ok {
+				desc.LastError = fmt.Errorf("expected 'serviceId' to be an ObjectId but it's a BSON %s", element.Value().Type)
+				return desc
+			}
+			desc.ServiceID = &oid
 		case "setName":
 			desc.SetName, ok = element.Value().StringValueOK()
 			if !ok {
@@ -327,6 +335,11 @@ func (s Server) DataBearing() bool {
 		s.Kind == Standalone
 }
 
+// LoadBalanced returns true if the server is a mongos that is deployed behind a load balancer.
+func (s Server) LoadBalanced() bool {
+	return s.ServiceID != nil
+}
+
 // String implements the Stringer interface
 func (s Server) String() string {
 	str := fmt.Sprintf("Addr: %s, Type: %s",
@@ -11,13 +11,14 @@ type ServerKind uint32
 
 // These constants are the possible types of servers.
 const (
-	Standalone  ServerKind = 1
-	RSMember    ServerKind = 2
-	RSPrimary   ServerKind = 4 + RSMember
-	RSSecondary ServerKind = 8 + RSMember
-	RSArbiter   ServerKind = 16 + RSMember
-	RSGhost     ServerKind = 32 + RSMember
-	Mongos      ServerKind = 256
+	Standalone   ServerKind = 1
+	RSMember     ServerKind = 2
+	RSPrimary    ServerKind = 4 + RSMember
+	RSSecondary  ServerKind = 8 + RSMember
+	RSArbiter    ServerKind = 16 + RSMember
+	RSGhost      ServerKind = 32 + RSMember
+	Mongos       ServerKind = 256
+	LoadBalancer ServerKind = 512
 )
 
 // String returns a stringified version of the kind or "Unknown" if the kind is invalid.
@@ -37,6 +38,8 @@ func (kind ServerKind) String() string {
 		return "RSGhost"
 	case Mongos:
 		return "Mongos"
+	case LoadBalancer:
+		return "LoadBalancer"
 	}
 
 	return "Unknown"
@@ -109,7 +109,7 @@ func (ls *latencySelector) SelectServer(t Topology, candidates []Server) ([]Serv
 func WriteSelector() ServerSelector {
 	return ServerSelectorFunc(func(t Topology, candidates []Server) ([]Server, error) {
 		switch t.Kind {
-		case Single:
+		case Single, LoadBalanced:
 			return candidates, nil
 		default:
 			result := []Server{}
@@ -138,7 +138,7 @@ func ReadPrefSelector(rp *readpref.ReadPref) ServerSelector {
 		}
 
 		switch t.Kind {
-		case Single:
+		case Single, LoadBalanced:
 			return candidates, nil
 		case ReplicaSetNoPrimary, ReplicaSetWithPrimary:
 			return selectForReplicaSet(rp, t, candidates)
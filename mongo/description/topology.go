@@ -63,14 +63,14 @@ func (t Topology) Equal(other Topology) bool {
 
 // HasReadableServer returns true if the topology contains a server suitable for reading.
 //
-// If the Topology's kind is Single or Sharded, the mode parameter is ignored and the function contains true if any of
-// the servers in the Topology are of a known type.
+// If the Topology's kind is Single, Sharded, or LoadBalanced, the mode parameter is ignored and the function contains
+// true if any of the servers in the Topology are of a known type.
 //
 // For replica sets, the function returns true if the cluster contains a server that matches the provided read
 // preference mode.
 func (t Topology) HasReadableServer(mode readpref.Mode) bool {
 	switch t.Kind {
-	case Single, Sharded:
+	case Single, Sharded, LoadBalanced:
 		return hasAvailableServer(t.Servers, 0)
 	case ReplicaSetWithPrimary:
 		return hasAvailableServer(t.Servers, mode)
@@ -90,8 +90,8 @@ func (t Topology) HasReadableServer(mode readpref.Mode) bool {
 
 // HasWritableServer returns true if a topology has a server available for writing.
 //
-// If the Topology's kind is Single or Sharded, this function returns true if any of the servers in the Topology are of
-// a known type.
+// If the Topology's kind is Single, Sharded, or LoadBalanced, this function returns true if any of the servers in the
+// Topology are of a known type.
 //
 // For replica sets, the function returns true if the replica set contains a primary.
 func (t Topology) HasWritableServer() bool {
@@ -35,6 +35,34 @@ var ErrNilValue = errors.New("value is nil")
 // ErrEmptySlice is returned when an empty slice is passed to a CRUD method that requires a non-empty slice.
 var ErrEmptySlice = errors.New("must provide at least one element in input slice")
 
+// Error labels attached by the server or the driver to errors that belong to a category. The labels of an error can be
+// checked with HasErrorLabel or the ServerError.HasErrorLabel method.
+const (
+	// NetworkErrorLabel is the label of errors caused by a network error while communicating with the server.
+	NetworkErrorLabel = "NetworkError"
+
+	// RetryableWriteErrorLabel is the label of errors after which a write can safely be retried.
+	RetryableWriteErrorLabel = "RetryableWriteError"
+
+	// TransientTransactionErrorLabel is the label of errors after which the whole transaction can be retried.
+	TransientTransactionErrorLabel = "TransientTransactionError"
+
+	// UnknownTransactionCommitResultLabel is the label of errors returned by a commitTransaction command whose outcome
+	// is unknown. The commit can be retried.
+	UnknownTransactionCommitResultLabel = "UnknownTransactionCommitResult"
+
+	// NoWritesPerformedLabel is the label of errors returned by a retried write if no attempt of the write modified
+	// any data.
+	NoWritesPerformedLabel = "NoWritesPerformed"
+
+	// ResumableChangeStreamErrorLabel is the label of errors after which a change stream can be resumed.
+	ResumableChangeStreamErrorLabel = "ResumableChangeStreamError"
+)
+
+// apiStrictErrorCode is the code of the error returned for a command that is not in the stable API version used
+// with apiStrict.
+const apiStrictErrorCode int32 = 323
+
 // ErrMapForOrderedArgument is returned when a map with multiple keys is passed to a CRUD method for an ordered parameter
 type ErrMapForOrderedArgument struct {
 	ParamName string
@@ -129,9 +157,43 @@ func unwrap(err error) error {
 
 // IsNetworkError returns true if err is a network error
 func IsNetworkError(err error) bool {
+	return HasErrorLabel(err, NetworkErrorLabel)
+}
+
+// IsTransient returns true if err has the TransientTransactionError label, which means the transaction in which it
+// occurred can be retried from the start.
+func IsTransient(err error) bool {
+	return HasErrorLabel(err, TransientTransactionErrorLabel)
+}
+
+// IsRetryableWriteError returns true if err has the RetryableWriteError label, which means the write that returned it
+// can safely be retried.
+func IsRetryableWriteError(err error) bool {
+	return HasErrorLabel(err, RetryableWriteErrorLabel)
+}
+
+// IsUnknownTransactionCommitResult returns true if err has the UnknownTransactionCommitResult label, which means it is
+// unknown whether the transaction was committed and the commit can be retried.
+func IsUnknownTransactionCommitResult(err error) bool {
+	return HasErrorLabel(err, UnknownTransactionCommitResultLabel)
+}
+
+// HasErrorLabel returns true if err or an error it wraps is a ServerError with the specified label.
+func HasErrorLabel(err error, label string) bool {
+	for ; err != nil; err = unwrap(err) {
+		if e, ok := err.(ServerError); ok {
+			return e.HasErrorLabel(label)
+		}
+	}
+	return false
+}
+
+// isSnapshotUnavailableError returns true if err is a SnapshotUnavailable error, which is returned when the server
+// cannot satisfy a snapshot read at the requested point in time.
+func isSnapshotUnavailableError(err error) bool {
 	for ; err != nil; err = unwrap(err) {
 		if e, ok := err.(ServerError); ok {
-			return e.HasErrorLabel("NetworkError")
+			return e.HasErrorCode(246)
 		}
 	}
 	return false
@@ -300,6 +362,10 @@ type WriteConcernError struct {
 	Code    int
 	Message string
 	Details bson.Raw
+
+	// The labels included in the write concern error by the server. The labels are also included in the labels of the
+	// WriteException or BulkWriteException containing the write concern error.
+	Labels []string
 }
 
 // Error implements the error interface.
@@ -310,6 +376,16 @@ func (wce WriteConcernError) Error() string {
 	return wce.Message
 }
 
+// HasErrorLabel returns true if the write concern error contains the specified label.
+func (wce WriteConcernError) HasErrorLabel(label string) bool {
+	for _, l := range wce.Labels {
+		if l == label {
+			return true
+		}
+	}
+	return false
+}
+
 // WriteException is the error type returned by the InsertOne, DeleteOne, DeleteMany, UpdateOne, UpdateMany, and
 // ReplaceOne operations.
 type WriteException struct {
@@ -397,6 +473,7 @@ func convertDriverWriteConcernError(wce *driver.WriteConcernError) *WriteConcern
 		Code:    int(wce.Code),
 		Message: wce.Message,
 		Details: bson.Raw(wce.Details),
+		Labels:  wce.Labels,
 	}
 }
 
@@ -0,0 +1,223 @@
+// Copyright (C) MongoDB, Inc. 2021-present.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
+
+package mongo
+
+import (
+	"context"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo/description"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"go.mongodb.org/mongo-driver/mongo/readpref"
+	"go.mongodb.org/mongo-driver/x/mongo/driver/operation"
+	"go.mongodb.org/mongo-driver/x/mongo/driver/session"
+)
+
+// Explainable is an operation that can be explained with Collection.Explain. Explainable values are created with
+// ExplainFind, ExplainAggregate, and ExplainDistinct.
+type Explainable interface {
+	explainable(ctx context.Context, coll *Collection) (operation.Explainable, error)
+}
+
+type explainableFunc func(ctx context.Context, coll *Collection) (operation.Explainable, error)
+
+func (f explainableFunc) explainable(ctx context.Context, coll *Collection) (operation.Explainable, error) {
+	return f(ctx, coll)
+}
+
+// ExplainFind returns an Explainable for the find command that Collection.Find would run with the given filter and
+// options.
+func ExplainFind(filter interface{}, opts ...*options.FindOptions) Explainable {
+	return explainableFunc(func(ctx context.Context, coll *Collection) (operation.Explainable, error) {
+		f, err := coll.transformFilter(ctx, filter)
+		if err != nil {
+			return nil, err
+		}
+		op, _, err := coll.findOperation(f, options.MergeFindOptions(opts...))
+		if err != nil {
+			return nil, err
+		}
+		return op, nil
+	})
+}
+
+// ExplainAggregate returns an Explainable for the aggregate command that Collection.Aggregate would run with the
+// given pipeline and options. Explaining an aggregation with a $out or $merge stage does not write any documents.
+func ExplainAggregate(pipeline interface{}, opts ...*options.AggregateOptions) Explainable {
+	return explainableFunc(func(ctx context.Context, coll *Collection) (operation.Explainable, error) {
+		pipelineArr, hasOutputStage, err := transformAggregatePipelinev2(coll.registry, pipeline)
+		if err != nil {
+			return nil, err
+		}
+		predicate, err := coll.transformFilterPredicate(ctx)
+		if err != nil {
+			return nil, err
+		}
+		pipelineArr, err = addPredicateStage(pipelineArr, predicate)
+		if err != nil {
+			return nil, err
+		}
+		a := aggregateParams{
+			client:   coll.client,
+			registry: coll.registry,
+			col:      coll.name,
+		}
+		op, _, err := aggregateOperation(a, pipelineArr, hasOutputStage, options.MergeAggregateOptions(opts...))
+		if err != nil {
+			return nil, err
+		}
+		return op, nil
+	})
+}
+
+// ExplainDistinct returns an Explainable for the distinct command that Collection.Distinct would run with the given
+// field name, filter, and options.
+func ExplainDistinct(fieldName string, filter interface{}, opts ...*options.DistinctOptions) Explainable {
+	return explainableFunc(func(ctx context.Context, coll *Collection) (operation.Explainable, error) {
+		f, err := coll.transformFilter(ctx, filter)
+		if err != nil {
+			return nil, err
+		}
+		op, err := coll.distinctOperation(fieldName, f, options.MergeDistinctOptions(opts...))
+		if err != nil {
+			return nil, err
+		}
+		return op, nil
+	})
+}
+
+// ExplainResult is the result of an explain command.
+type ExplainResult struct {
+	// The plan selected by the query optimizer.
+	WinningPlan bson.Raw
+
+	// The plans that were considered and rejected by the query optimizer.
+	RejectedPlans []bson.Raw
+
+	// The execution statistics of the winning plan. This is nil if the verbosity of the explain was
+	// options.QueryPlanner.
+	ExecutionStats *ExplainExecutionStats
+
+	// The full explain output returned by the server. Its format depends on the explained command and the server
+	// version and topology.
+	Raw bson.Raw
+}
+
+// ExplainExecutionStats contains the execution statistics of an explained operation.
+type ExplainExecutionStats struct {
+	// The number of documents returned by the winning plan.
+	NReturned int64 `bson:"nReturned"`
+
+	// The time it took to select and run the winning plan.
+	ExecutionTimeMillis int64 `bson:"executionTimeMillis"`
+
+	// The number of index keys scanned.
+	TotalKeysExamined int64 `bson:"totalKeysExamined"`
+
+	// The number of documents scanned.
+	TotalDocsExamined int64 `bson:"totalDocsExamined"`
+
+	// The execution statistics of each stage of the winning plan, as a tree of stages.
+	ExecutionStages bson.Raw `bson:"executionStages"`
+}
+
+// explainOutput is the part of the explain output that is parsed into an ExplainResult. The output for an aggregation
+// whose first stages are executed by the query engine contains the query planner output in a $cursor stage.
+type explainOutput struct {
+	QueryPlanner *struct {
+		WinningPlan   bson.Raw   `bson:"winningPlan"`
+		RejectedPlans []bson.Raw `bson:"rejectedPlans"`
+	} `bson:"queryPlanner"`
+	ExecutionStats *ExplainExecutionStats `bson:"executionStats"`
+	Stages         []struct {
+		Cursor *explainOutput `bson:"$cursor"`
+	} `bson:"stages"`
+}
+
+func newExplainResult(raw bson.Raw) (*ExplainResult, error) {
+	var out explainOutput
+	if err := bson.Unmarshal(raw, &out); err != nil {
+		return nil, err
+	}
+	if out.QueryPlanner == nil && len(out.Stages) > 0 && out.Stages[0].Cursor != nil {
+		out = *out.Stages[0].Cursor
+	}
+
+	res := &ExplainResult{
+		ExecutionStats: out.ExecutionStats,
+		Raw:            raw,
+	}
+	if out.QueryPlanner != nil {
+		res.WinningPlan = out.QueryPlanner.WinningPlan
+		res.RejectedPlans = out.QueryPlanner.RejectedPlans
+	}
+	return res, nil
+}
+
+// Explain executes an explain command for the given operation and returns the query plan that the server selects for
+// it. Unlike running the explain command with RunCommand, the command is run with the read preference of the
+// collection and the session in ctx, if any. The explained operation is not executed, so a find or aggregate does not
+// return any documents.
+//
+// The op parameter specifies the operation to explain and is created with ExplainFind, ExplainAggregate, or
+// ExplainDistinct.
+//
+// The opts parameter can be used to specify options for the operation (see the options.ExplainOptions documentation).
+//
+// The explain command cannot be run in a transaction. For more information about the command, see
+// https://docs.mongodb.com/manual/reference/command/explain/.
+func (coll *Collection) Explain(ctx context.Context, op Explainable,
+	opts ...*options.ExplainOptions) (*ExplainResult, error) {
+
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	if op == nil {
+		return nil, ErrNilValue
+	}
+
+	explainable, err := op.explainable(ctx, coll)
+	if err != nil {
+		return nil, err
+	}
+
+	sess := sessionFromContext(ctx)
+	if sess == nil && coll.client.sessionPool != nil {
+		sess, err = session.NewClientSession(coll.client.sessionPool, coll.client.id, session.Implicit)
+		if err != nil {
+			return nil, err
+		}
+		defer sess.EndSession()
+	}
+
+	err = coll.client.validSession(sess)
+	if err != nil {
+		return nil, err
+	}
+
+	selector := makeReadPrefSelector(sess, coll.readSelector, coll.client.localThreshold)
+	return coll.explain(ctx, explainable, sess, coll.readPreference, selector, options.MergeExplainOptions(opts...))
+}
+
+// explain executes an explain command for explainable with the given session, read preference, and server selector.
+func (coll *Collection) explain(ctx context.Context, explainable operation.Explainable, sess *session.Client,
+	rp *readpref.ReadPref, selector description.ServerSelector, eo *options.ExplainOptions) (*ExplainResult, error) {
+
+	explain := operation.NewExplain(explainable).
+		Session(sess).ClusterClock(coll.client.clock).
+		Database(coll.db.name).CommandMonitor(coll.client.monitor).
+		Deployment(coll.client.deployment).ReadPreference(rp).
+		ServerSelector(selector).ServerAPI(coll.client.serverAPI)
+	if eo.Verbosity != nil {
+		explain.Verbosity(string(*eo.Verbosity))
+	}
+
+	if err := explain.Execute(ctx); err != nil {
+		return nil, replaceErrors(err)
+	}
+	return newExplainResult(bson.Raw(explain.Result()))
+}
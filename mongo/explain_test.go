@@ -0,0 +1,102 @@
+// Copyright (C) MongoDB, Inc. 2021-present.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
+
+package mongo
+
+import (
+	"reflect"
+	"testing"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/internal/testutil/assert"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"go.mongodb.org/mongo-driver/x/mongo/driver/operation"
+)
+
+func TestExplain(t *testing.T) {
+	marshal := func(t *testing.T, doc interface{}) bson.Raw {
+		t.Helper()
+
+		b, err := bson.Marshal(doc)
+		assert.Nil(t, err, "Marshal error: %v", err)
+		return b
+	}
+	winningPlan := bson.D{{"stage", "COLLSCAN"}}
+	rejectedPlan := bson.D{{"stage", "IXSCAN"}}
+	queryPlanner := bson.D{{"winningPlan", winningPlan}, {"rejectedPlans", bson.A{rejectedPlan}}}
+	executionStats := bson.D{
+		{"nReturned", int32(2)},
+		{"executionTimeMillis", int32(1)},
+		{"totalKeysExamined", int64(0)},
+		{"totalDocsExamined", int32(10)},
+		{"executionStages", bson.D{{"stage", "COLLSCAN"}}},
+	}
+
+	t.Run("result", func(t *testing.T) {
+		testCases := []struct {
+			name   string
+			output bson.D
+		}{
+			{"find", bson.D{{"queryPlanner", queryPlanner}, {"executionStats", executionStats}, {"ok", 1}}},
+			{"aggregate", bson.D{
+				{"stages", bson.A{
+					bson.D{{"$cursor", bson.D{{"queryPlanner", queryPlanner}, {"executionStats", executionStats}}}},
+					bson.D{{"$group", bson.D{{"_id", "$x"}}}},
+				}},
+				{"ok", 1},
+			}},
+		}
+		for _, tc := range testCases {
+			t.Run(tc.name, func(t *testing.T) {
+				raw := marshal(t, tc.output)
+				res, err := newExplainResult(raw)
+				assert.Nil(t, err, "newExplainResult error: %v", err)
+
+				assert.Equal(t, marshal(t, winningPlan), res.WinningPlan, "expected winning plan %v, got %v",
+					winningPlan, res.WinningPlan)
+				assert.Equal(t, 1, len(res.RejectedPlans), "expected 1 rejected plan, got %v", len(res.RejectedPlans))
+				assert.NotNil(t, res.ExecutionStats, "expected execution stats, got nil")
+				assert.Equal(t, int64(2), res.ExecutionStats.NReturned, "expected nReturned 2, got %v",
+					res.ExecutionStats.NReturned)
+				assert.Equal(t, int64(10), res.ExecutionStats.TotalDocsExamined, "expected totalDocsExamined 10, got %v",
+					res.ExecutionStats.TotalDocsExamined)
+				assert.Equal(t, raw, res.Raw, "expected raw output %v, got %v", raw, res.Raw)
+			})
+		}
+	})
+	t.Run("query planner verbosity", func(t *testing.T) {
+		res, err := newExplainResult(marshal(t, bson.D{{"queryPlanner", queryPlanner}, {"ok", 1}}))
+		assert.Nil(t, err, "newExplainResult error: %v", err)
+		assert.Nil(t, res.ExecutionStats, "expected no execution stats, got %v", res.ExecutionStats)
+	})
+	t.Run("explainables", func(t *testing.T) {
+		coll := setupColl("explain")
+		testCases := []struct {
+			name     string
+			op       Explainable
+			expected operation.Explainable
+		}{
+			{"find", ExplainFind(bson.D{}, options.Find().SetLimit(1)), &operation.Find{}},
+			{"aggregate", ExplainAggregate(Pipeline{}), &operation.Aggregate{}},
+			{"distinct", ExplainDistinct("x", bson.D{}), &operation.Distinct{}},
+		}
+		for _, tc := range testCases {
+			t.Run(tc.name, func(t *testing.T) {
+				op, err := tc.op.explainable(bgCtx, coll)
+				assert.Nil(t, err, "explainable error: %v", err)
+				assert.True(t, reflect.TypeOf(tc.expected) == reflect.TypeOf(op), "expected operation type %T, got %T",
+					tc.expected, op)
+			})
+		}
+
+		_, err := ExplainFind(nil).explainable(bgCtx, coll)
+		assert.Equal(t, ErrNilDocument, err, "expected error %v, got %v", ErrNilDocument, err)
+	})
+	t.Run("nil operation", func(t *testing.T) {
+		_, err := setupColl("explain").Explain(bgCtx, nil)
+		assert.Equal(t, ErrNilValue, err, "expected error %v, got %v", ErrNilValue, err)
+	})
+}
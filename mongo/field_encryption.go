@@ -0,0 +1,114 @@
+// Copyright (C) MongoDB, Inc. 2021-present.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
+
+package mongo
+
+import (
+	"fmt"
+	"reflect"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/bsoncodec"
+	"go.mongodb.org/mongo-driver/bson/bsonrw"
+	"go.mongodb.org/mongo-driver/bson/bsontype"
+	"go.mongodb.org/mongo-driver/x/bsonx/bsoncore"
+)
+
+// FieldCrypter encrypts and decrypts the values of individual fields for application-managed envelope encryption. An
+// implementation will typically use a data key that is itself encrypted by an external key management service.
+// Implementations must be safe for concurrent use.
+type FieldCrypter interface {
+	// EncryptField returns the ciphertext for the given plaintext.
+	EncryptField(plaintext []byte) ([]byte, error)
+
+	// DecryptField returns the plaintext for the given ciphertext.
+	DecryptField(ciphertext []byte) ([]byte, error)
+}
+
+// RegisterEncryptedType registers an encoder and decoder for t on rb that encrypt values of t when they are encoded and
+// decrypt them when they are decoded. This can be used by applications that cannot use client-side field level
+// encryption to encrypt designated fields by declaring them with a dedicated type, e.g. "type SSN string".
+//
+// Values are encoded with the bson.DefaultRegistry, encrypted by crypter, and stored as binary values with the
+// bsontype.BinaryUserDefined subtype. When decoding, values that are not stored in that form are decoded without
+// decryption so existing unencrypted documents can still be read. Nil pointers and interfaces are stored as null.
+func RegisterEncryptedType(rb *bsoncodec.RegistryBuilder, t reflect.Type, crypter FieldCrypter) *bsoncodec.RegistryBuilder {
+	fe := &fieldEncrypter{t: t, crypter: crypter}
+	return rb.RegisterTypeEncoder(t, bsoncodec.ValueEncoderFunc(fe.EncodeValue)).
+		RegisterTypeDecoder(t, bsoncodec.ValueDecoderFunc(fe.DecodeValue))
+}
+
+type fieldEncrypter struct {
+	t       reflect.Type
+	crypter FieldCrypter
+}
+
+func (fe *fieldEncrypter) EncodeValue(_ bsoncodec.EncodeContext, vw bsonrw.ValueWriter, val reflect.Value) error {
+	if !val.IsValid() || val.Type() != fe.t {
+		return bsoncodec.ValueEncoderError{Name: "EncryptedValueEncoder", Types: []reflect.Type{fe.t}, Received: val}
+	}
+	switch val.Kind() {
+	case reflect.Ptr, reflect.Interface, reflect.Map, reflect.Slice:
+		if val.IsNil() {
+			return vw.WriteNull()
+		}
+	}
+
+	bt, data, err := bson.MarshalValueWithRegistry(bson.DefaultRegistry, val.Interface())
+	if err != nil {
+		return err
+	}
+
+	// Prefix the value with its type so it can be decoded without knowing how it was encoded.
+	plaintext := make([]byte, 0, len(data)+1)
+	plaintext = append(plaintext, byte(bt))
+	plaintext = append(plaintext, data...)
+
+	ciphertext, err := fe.crypter.EncryptField(plaintext)
+	if err != nil {
+		return fmt.Errorf("error encrypting value of type %s: %v", fe.t, err)
+	}
+	return vw.WriteBinaryWithSubtype(ciphertext, bsontype.BinaryUserDefined)
+}
+
+func (fe *fieldEncrypter) DecodeValue(_ bsoncodec.DecodeContext, vr bsonrw.ValueReader, val reflect.Value) error {
+	if !val.CanSet() || val.Type() != fe.t {
+		return bsoncodec.ValueDecoderError{Name: "EncryptedValueDecoder", Types: []reflect.Type{fe.t}, Received: val}
+	}
+
+	dec, err := bson.DefaultRegistry.LookupDecoder(fe.t)
+	if err != nil {
+		return err
+	}
+	dc := bsoncodec.DecodeContext{Registry: bson.DefaultRegistry}
+
+	switch vr.Type() {
+	case bsontype.Null:
+		val.Set(reflect.Zero(fe.t))
+		return vr.ReadNull()
+	case bsontype.Binary:
+	default:
+		// The value was stored before encryption was enabled for this type.
+		return dec.DecodeValue(dc, vr, val)
+	}
+
+	data, subtype, err := vr.ReadBinary()
+	if err != nil {
+		return err
+	}
+	if subtype != bsontype.BinaryUserDefined {
+		return dec.DecodeValue(dc, bsonrw.NewBSONValueReader(bsontype.Binary, bsoncore.AppendBinary(nil, subtype, data)), val)
+	}
+
+	plaintext, err := fe.crypter.DecryptField(data)
+	if err != nil {
+		return fmt.Errorf("error decrypting value of type %s: %v", fe.t, err)
+	}
+	if len(plaintext) == 0 {
+		return fmt.Errorf("decrypted value of type %s is empty", fe.t)
+	}
+	return dec.DecodeValue(dc, bsonrw.NewBSONValueReader(bsontype.Type(plaintext[0]), plaintext[1:]), val)
+}
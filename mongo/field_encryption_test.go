@@ -0,0 +1,85 @@
+// Copyright (C) MongoDB, Inc. 2021-present.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
+
+package mongo
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/bsontype"
+	"go.mongodb.org/mongo-driver/internal/testutil/assert"
+)
+
+type encryptedSSN string
+
+type xorCrypter struct {
+	key byte
+	err error
+}
+
+func (x xorCrypter) xor(b []byte) ([]byte, error) {
+	if x.err != nil {
+		return nil, x.err
+	}
+	out := make([]byte, len(b))
+	for i := range b {
+		out[i] = b[i] ^ x.key
+	}
+	return out, nil
+}
+
+func (x xorCrypter) EncryptField(plaintext []byte) ([]byte, error) { return x.xor(plaintext) }
+
+func (x xorCrypter) DecryptField(ciphertext []byte) ([]byte, error) { return x.xor(ciphertext) }
+
+func TestFieldEncryption(t *testing.T) {
+	type person struct {
+		Name string
+		SSN  encryptedSSN
+	}
+	ssnType := reflect.TypeOf(encryptedSSN(""))
+	reg := RegisterEncryptedType(bson.NewRegistryBuilder(), ssnType, xorCrypter{key: 0x5a}).Build()
+
+	t.Run("round trip", func(t *testing.T) {
+		doc, err := bson.MarshalWithRegistry(reg, person{Name: "foo", SSN: "123-45-6789"})
+		assert.Nil(t, err, "MarshalWithRegistry error: %v", err)
+
+		subtype, data := bson.Raw(doc).Lookup("ssn").Binary()
+		assert.Equal(t, bsontype.BinaryUserDefined, subtype, "expected subtype %v, got %v",
+			bsontype.BinaryUserDefined, subtype)
+		assert.False(t, len(data) == 0, "expected encrypted data, got none")
+
+		var got person
+		err = bson.UnmarshalWithRegistry(reg, doc, &got)
+		assert.Nil(t, err, "UnmarshalWithRegistry error: %v", err)
+		assert.Equal(t, encryptedSSN("123-45-6789"), got.SSN, "expected SSN %q, got %q", "123-45-6789", got.SSN)
+	})
+	t.Run("unencrypted value", func(t *testing.T) {
+		doc, err := bson.Marshal(bson.D{{"name", "foo"}, {"ssn", "123-45-6789"}})
+		assert.Nil(t, err, "Marshal error: %v", err)
+
+		var got person
+		err = bson.UnmarshalWithRegistry(reg, doc, &got)
+		assert.Nil(t, err, "UnmarshalWithRegistry error: %v", err)
+		assert.Equal(t, encryptedSSN("123-45-6789"), got.SSN, "expected SSN %q, got %q", "123-45-6789", got.SSN)
+	})
+	t.Run("crypter errors", func(t *testing.T) {
+		crypterErr := errors.New("kms unavailable")
+		errReg := RegisterEncryptedType(bson.NewRegistryBuilder(), ssnType, xorCrypter{err: crypterErr}).Build()
+
+		_, err := bson.MarshalWithRegistry(errReg, person{SSN: "123-45-6789"})
+		assert.NotNil(t, err, "expected MarshalWithRegistry error, got nil")
+
+		doc, err := bson.MarshalWithRegistry(reg, person{SSN: "123-45-6789"})
+		assert.Nil(t, err, "MarshalWithRegistry error: %v", err)
+		var got person
+		err = bson.UnmarshalWithRegistry(errReg, doc, &got)
+		assert.NotNil(t, err, "expected UnmarshalWithRegistry error, got nil")
+	})
+}
@@ -0,0 +1,129 @@
+// Copyright (C) MongoDB, Inc. 2021-present.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
+
+package mongo_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/internal/testutil/assert"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/mongotest"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+func TestFilterPredicatePipelines(t *testing.T) {
+	type tenantKey struct{}
+	predicate := func(ctx context.Context) (interface{}, error) {
+		tenant, ok := ctx.Value(tenantKey{}).(string)
+		if !ok {
+			return nil, errors.New("no tenant")
+		}
+		return bson.D{{"tenant", tenant}}, nil
+	}
+	d := mongotest.New()
+	client, err := d.NewClient()
+	assert.Nil(t, err, "NewClient error: %v", err)
+	defer func() { _ = client.Disconnect(context.Background()) }()
+	coll := client.Database("db").Collection("orders", options.Collection().SetFilterPredicate(predicate))
+	tenantCtx := context.WithValue(context.Background(), tenantKey{}, "acme")
+
+	toRaw := func(val interface{}) bson.Raw {
+		b, err := bson.Marshal(bson.D{{"pipeline", val}})
+		assert.Nil(t, err, "Marshal error: %v", err)
+		return bson.Raw(b).Lookup("pipeline").Array()
+	}
+	match := bson.D{{"$match", bson.D{{"tenant", "acme"}}}}
+	group := bson.D{{"$group", bson.D{{"_id", "$status"}}}}
+	geoNear := bson.D{{"$geoNear", bson.D{{"near", bson.A{0, 0}}}}}
+
+	type testCase struct {
+		name     string
+		pipeline interface{}
+		expected bson.Raw
+	}
+	testCases := []testCase{
+		{"empty pipeline", mongo.Pipeline{}, toRaw(bson.A{match})},
+		{"predicate first", mongo.Pipeline{group}, toRaw(bson.A{match, group})},
+		{"after leading $geoNear", mongo.Pipeline{geoNear, group}, toRaw(bson.A{geoNear, match, group})},
+	}
+	for _, stage := range []string{"$collStats", "$indexStats", "$changeStream", "$listSearchIndexes"} {
+		metadata := bson.D{{stage, bson.D{}}}
+		testCases = append(testCases, testCase{
+			"not filtered after leading " + stage, mongo.Pipeline{metadata, group}, toRaw(bson.A{metadata, group}),
+		})
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			d.Reset()
+			d.AddReplies(mongotest.CursorReply("db.orders"))
+			cursor, err := coll.Aggregate(tenantCtx, tc.pipeline)
+			assert.Nil(t, err, "Aggregate error: %v", err)
+			_ = cursor.Close(tenantCtx)
+
+			cmds := d.Commands()
+			assert.Equal(t, 1, len(cmds), "expected 1 command, got %v", len(cmds))
+			got := cmds[0].Document.Lookup("pipeline").Array()
+			assert.Equal(t, tc.expected, got, "expected pipeline %v, got %v", tc.expected, got)
+		})
+	}
+	t.Run("search index list", func(t *testing.T) {
+		d.Reset()
+		d.AddReplies(mongotest.CursorReply("db.orders"))
+		cursor, err := coll.SearchIndexes().List(tenantCtx, nil)
+		assert.Nil(t, err, "List error: %v", err)
+		_ = cursor.Close(tenantCtx)
+
+		cmds := d.Commands()
+		assert.Equal(t, 1, len(cmds), "expected 1 command, got %v", len(cmds))
+		stages, err := cmds[0].Document.Lookup("pipeline").Array().Values()
+		assert.Nil(t, err, "Values error: %v", err)
+		assert.Equal(t, 1, len(stages), "expected only the $listSearchIndexes stage, got %v", stages)
+	})
+	t.Run("aggregate with accumulator", func(t *testing.T) {
+		d.Reset()
+		d.AddReplies(mongotest.CursorReply("db.orders"))
+		acc := mongo.Accumulator{
+			Init:       "function() { return 0; }",
+			Accumulate: "function(state) { return state + 1; }",
+			Merge:      "function(a, b) { return a + b; }",
+		}
+		cursor, err := coll.AggregateWithAccumulator(tenantCtx, nil, nil, "count", acc)
+		assert.Nil(t, err, "AggregateWithAccumulator error: %v", err)
+		_ = cursor.Close(tenantCtx)
+
+		cmds := d.Commands()
+		assert.Equal(t, 1, len(cmds), "expected 1 command, got %v", len(cmds))
+		stages, err := cmds[0].Document.Lookup("pipeline").Array().Values()
+		assert.Nil(t, err, "Values error: %v", err)
+		assert.Equal(t, 2, len(stages), "expected 2 stages, got %v", len(stages))
+		expected := toRaw(bson.A{match}).Index(0).Value().Document()
+		assert.Equal(t, expected, stages[0].Document(), "expected first stage %v, got %v", expected, stages[0])
+	})
+	t.Run("predicate error", func(t *testing.T) {
+		d.Reset()
+		_, err := coll.Aggregate(context.Background(), mongo.Pipeline{})
+		assert.NotNil(t, err, "expected Aggregate error, got nil")
+		assert.Equal(t, "no tenant", err.Error(), "expected error %q, got %q", "no tenant", err.Error())
+		assert.Equal(t, 0, len(d.Commands()), "expected no commands, got %v", len(d.Commands()))
+	})
+	t.Run("watch is not filtered", func(t *testing.T) {
+		d.Reset()
+		d.AddReplies(mongotest.CursorReply("db.orders"))
+		cs, err := coll.Watch(context.Background(), mongo.Pipeline{})
+		assert.Nil(t, err, "Watch error: %v", err)
+		_ = cs.Close(context.Background())
+
+		cmds := d.Commands()
+		assert.True(t, len(cmds) > 0, "expected an aggregate command")
+		stages, err := cmds[0].Document.Lookup("pipeline").Array().Values()
+		assert.Nil(t, err, "Values error: %v", err)
+		assert.Equal(t, 1, len(stages), "expected only the $changeStream stage, got %v", stages)
+	})
+}
@@ -0,0 +1,141 @@
+// Copyright (C) MongoDB, Inc. 2021-present.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
+
+package mongo
+
+import (
+	"context"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// Finder builds a find operation by chaining calls instead of filling in an options.FindOptions, e.g.
+//
+//	err := coll.Query(bson.D{{"age", bson.D{{"$gt", 30}}}}).Sort(bson.D{{"age", -1}}).Limit(10).All(ctx, &people)
+//
+// The methods that configure the operation modify and return the same Finder, so a Finder is not safe for concurrent
+// use. The operation is only run by Cursor, All, One, or Count, and a Finder can be run any number of times.
+type Finder struct {
+	coll   *Collection
+	filter interface{}
+	opts   *options.FindOptions
+}
+
+// Query returns a Finder for the documents in the collection that match filter. A nil filter matches all documents.
+func (coll *Collection) Query(filter interface{}) *Finder {
+	if filter == nil {
+		filter = bson.D{}
+	}
+	return &Finder{coll: coll, filter: filter, opts: options.Find()}
+}
+
+// Sort sets the order in which to return documents (see options.FindOptions.Sort).
+func (f *Finder) Sort(sort interface{}) *Finder {
+	f.opts.SetSort(sort)
+	return f
+}
+
+// Project sets the fields to include in the returned documents (see options.FindOptions.Projection).
+func (f *Finder) Project(projection interface{}) *Finder {
+	f.opts.SetProjection(projection)
+	return f
+}
+
+// Skip sets the number of documents to skip before returning documents.
+func (f *Finder) Skip(n int64) *Finder {
+	f.opts.SetSkip(n)
+	return f
+}
+
+// Limit sets the maximum number of documents to return (see options.FindOptions.Limit).
+func (f *Finder) Limit(n int64) *Finder {
+	f.opts.SetLimit(n)
+	return f
+}
+
+// BatchSize sets the maximum number of documents to include in each batch returned by the server.
+func (f *Finder) BatchSize(n int32) *Finder {
+	f.opts.SetBatchSize(n)
+	return f
+}
+
+// Hint sets the index to use for the operation (see options.FindOptions.Hint).
+func (f *Finder) Hint(hint interface{}) *Finder {
+	f.opts.SetHint(hint)
+	return f
+}
+
+// Collation sets the collation to use for string comparisons.
+func (f *Finder) Collation(collation *options.Collation) *Finder {
+	f.opts.SetCollation(collation)
+	return f
+}
+
+// Comment sets a comment to attach to the query to help trace it in the server logs and profiling data.
+func (f *Finder) Comment(comment string) *Finder {
+	f.opts.SetComment(comment)
+	return f
+}
+
+// MaxTime sets the maximum amount of time that the server should spend on the operation.
+func (f *Finder) MaxTime(d time.Duration) *Finder {
+	f.opts.SetMaxTime(d)
+	return f
+}
+
+// Options returns the options that the Finder passes to Collection.Find. Changes to the returned options affect the
+// Finder.
+func (f *Finder) Options() *options.FindOptions {
+	return f.opts
+}
+
+// Cursor runs the operation and returns a cursor over the matching documents.
+func (f *Finder) Cursor(ctx context.Context) (*Cursor, error) {
+	return f.coll.Find(ctx, f.filter, f.opts)
+}
+
+// All runs the operation and decodes all of the matching documents into results, which must be a pointer to a slice.
+func (f *Finder) All(ctx context.Context, results interface{}) error {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	cursor, err := f.Cursor(ctx)
+	if err != nil {
+		return err
+	}
+	return cursor.All(ctx, results)
+}
+
+// One runs the operation and returns a SingleResult for the first matching document, ignoring the limit and batch
+// size. If no document matches, the SingleResult has its error set to ErrNoDocuments.
+func (f *Finder) One(ctx context.Context) *SingleResult {
+	opts := f.opts
+	return f.coll.FindOne(ctx, f.filter, &options.FindOneOptions{
+		Collation:  opts.Collation,
+		Comment:    opts.Comment,
+		Hint:       opts.Hint,
+		MaxTime:    opts.MaxTime,
+		Projection: opts.Projection,
+		Skip:       opts.Skip,
+		Sort:       opts.Sort,
+	})
+}
+
+// Count returns the number of documents that match the filter, taking the skip and limit into account.
+func (f *Finder) Count(ctx context.Context) (int64, error) {
+	opts := options.Count()
+	opts.Collation = f.opts.Collation
+	if f.opts.Comment != nil {
+		opts.Comment = *f.opts.Comment
+	}
+	opts.Hint = f.opts.Hint
+	opts.Limit = f.opts.Limit
+	opts.MaxTime = f.opts.MaxTime
+	opts.Skip = f.opts.Skip
+	return f.coll.CountDocuments(ctx, f.filter, opts)
+}
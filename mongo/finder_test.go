@@ -0,0 +1,43 @@
+// Copyright (C) MongoDB, Inc. 2021-present.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
+
+package mongo
+
+import (
+	"testing"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/internal/testutil/assert"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+func TestFinder(t *testing.T) {
+	coll := setupColl("finder")
+
+	t.Run("options", func(t *testing.T) {
+		sort := bson.D{{"age", -1}}
+		projection := bson.D{{"name", 1}}
+		f := coll.Query(bson.D{{"x", 1}}).Sort(sort).Project(projection).Skip(5).Limit(10).BatchSize(2).
+			Hint("x_1").Comment("c").MaxTime(time.Second)
+
+		expected := options.Find().SetSort(sort).SetProjection(projection).SetSkip(5).SetLimit(10).SetBatchSize(2).
+			SetHint("x_1").SetComment("c").SetMaxTime(time.Second)
+		assert.Equal(t, expected, f.Options(), "expected options %v, got %v", expected, f.Options())
+	})
+	t.Run("nil filter matches all documents", func(t *testing.T) {
+		f := coll.Query(nil)
+		assert.Equal(t, bson.D{}, f.filter, "expected empty filter, got %v", f.filter)
+	})
+	t.Run("disconnected client", func(t *testing.T) {
+		err := coll.Query(nil).Limit(1).All(bgCtx, &[]bson.D{})
+		assert.Equal(t, ErrClientDisconnected, err, "expected error %v, got %v", ErrClientDisconnected, err)
+		err = coll.Query(nil).One(bgCtx).Err()
+		assert.Equal(t, ErrClientDisconnected, err, "expected error %v, got %v", ErrClientDisconnected, err)
+		_, err = coll.Query(nil).Count(bgCtx)
+		assert.Equal(t, ErrClientDisconnected, err, "expected error %v, got %v", ErrClientDisconnected, err)
+	})
+}
@@ -288,6 +288,12 @@ func (b *Bucket) Find(filter interface{}, opts ...*options.GridFSFindOptions) (*
 	if gfsOpts.BatchSize != nil {
 		find.SetBatchSize(*gfsOpts.BatchSize)
 	}
+	if gfsOpts.Collation != nil {
+		find.SetCollation(gfsOpts.Collation)
+	}
+	if gfsOpts.Hint != nil {
+		find.SetHint(gfsOpts.Hint)
+	}
 	if gfsOpts.Limit != nil {
 		find.SetLimit(int64(*gfsOpts.Limit))
 	}
@@ -0,0 +1,149 @@
+// Copyright (C) MongoDB, Inc. 2021-present.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
+
+package mongo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// hedgedAttempt is the outcome of one attempt of a hedged write.
+type hedgedAttempt struct {
+	res *UpdateResult
+	err error
+}
+
+// HedgedUpsert updates the document that matches filter, or inserts one if no document matches, like UpdateOne with
+// the Upsert option, but hedges against a slow primary: if an attempt has not returned after the hedge delay, another
+// attempt is started concurrently, which selects the primary again and so reaches the new primary if a failover
+// happened. An attempt that fails with a network or retryable write error is also followed by a new attempt. The
+// result of the first attempt that succeeds is returned and the other attempts are cancelled.
+//
+// The attempts can all be applied by the server, so the write must be idempotent: the update must only contain
+// operators such as $set whose effect does not depend on how many times they are applied, and the filter should match
+// at most one document, e.g. by having a unique index on the filter fields. The results are deduplicated: if an
+// attempt that finished earlier inserted the document that the returned attempt matched, the result reports the
+// upsert, and duplicate key errors caused by concurrent attempts inserting the same document are ignored as long as
+// another attempt succeeds.
+//
+// The attempts run concurrently, so they cannot share a session: an error is returned if ctx carries an explicit
+// session, e.g. inside a transaction, and each attempt uses its own implicit session.
+//
+// The opts parameter can be used to specify the hedge delay and the maximum number of attempts (see the
+// options.HedgedWriteOptions documentation).
+func (coll *Collection) HedgedUpsert(ctx context.Context, filter interface{}, update interface{},
+	opts ...*options.HedgedWriteOptions) (*UpdateResult, error) {
+
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	if sessionFromContext(ctx) != nil {
+		return nil, errors.New("a hedged write cannot be executed with an explicit session")
+	}
+
+	ho := options.MergeHedgedWriteOptions(opts...)
+	delay := options.DefaultHedgeDelay
+	if ho.Delay != nil {
+		delay = *ho.Delay
+	}
+	maxAttempts := options.DefaultHedgeMaxAttempts
+	if ho.MaxAttempts != nil {
+		maxAttempts = *ho.MaxAttempts
+	}
+	if delay <= 0 {
+		return nil, fmt.Errorf("hedge delay must be positive, got %v", delay)
+	}
+	if maxAttempts < 1 {
+		return nil, fmt.Errorf("max attempts must be at least 1, got %d", maxAttempts)
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	// The channel is buffered for all of the attempts so cancelled attempts can finish without being received.
+	attempts := make(chan hedgedAttempt, maxAttempts)
+	var started, inFlight int
+	start := func() {
+		started++
+		inFlight++
+		go func() {
+			res, err := coll.UpdateOne(ctx, filter, update, options.Update().SetUpsert(true))
+			attempts <- hedgedAttempt{res: res, err: err}
+		}()
+	}
+
+	start()
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+
+	var firstErr error
+	for {
+		select {
+		case <-timer.C:
+			if started < maxAttempts {
+				start()
+				timer.Reset(delay)
+			}
+		case attempt := <-attempts:
+			inFlight--
+			if attempt.err == nil {
+				return dedupHedgedResult(attempt.res, attempts), nil
+			}
+			// A duplicate key error is expected if another attempt inserted the document, so other errors are
+			// reported in preference.
+			if firstErr == nil || (IsDuplicateKeyError(firstErr) && !IsDuplicateKeyError(attempt.err)) {
+				firstErr = attempt.err
+			}
+			if inFlight > 0 {
+				continue
+			}
+			if started < maxAttempts && (IsNetworkError(attempt.err) || IsRetryableWriteError(attempt.err)) {
+				start()
+				if !timer.Stop() {
+					<-timer.C
+				}
+				timer.Reset(delay)
+				continue
+			}
+			return nil, firstErr
+		}
+	}
+}
+
+// dedupHedgedResult returns the result of a hedged write given the result of the attempt that succeeded first. If
+// that attempt matched a document inserted by another attempt that has also finished, the result reports the upsert
+// of the other attempt rather than a match.
+func dedupHedgedResult(res *UpdateResult, attempts <-chan hedgedAttempt) *UpdateResult {
+	if res.UpsertedID != nil {
+		return res
+	}
+	var upsertedID interface{}
+	for drained := false; !drained; {
+		select {
+		case attempt := <-attempts:
+			if attempt.err == nil && attempt.res.UpsertedID != nil {
+				upsertedID = attempt.res.UpsertedID
+			}
+		default:
+			drained = true
+		}
+	}
+	if upsertedID == nil || res.MatchedCount == 0 {
+		return res
+	}
+
+	deduped := *res
+	deduped.MatchedCount = 0
+	deduped.ModifiedCount = 0
+	deduped.UpsertedCount = 1
+	deduped.UpsertedID = upsertedID
+	return &deduped
+}
@@ -0,0 +1,68 @@
+// Copyright (C) MongoDB, Inc. 2021-present.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
+
+package mongo
+
+import (
+	"testing"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/internal/testutil/assert"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"go.mongodb.org/mongo-driver/x/mongo/driver/session"
+)
+
+func TestHedgedUpsert(t *testing.T) {
+	t.Run("invalid options", func(t *testing.T) {
+		coll := setupColl("hedged")
+		filter, update := bson.D{{"_id", 1}}, bson.D{{"$set", bson.D{{"x", 1}}}}
+
+		_, err := coll.HedgedUpsert(bgCtx, filter, update, options.HedgedWrite().SetDelay(0))
+		assert.NotNil(t, err, "expected error for zero delay, got nil")
+		_, err = coll.HedgedUpsert(bgCtx, filter, update, options.HedgedWrite().SetMaxAttempts(0))
+		assert.NotNil(t, err, "expected error for zero attempts, got nil")
+		_, err = coll.HedgedUpsert(bgCtx, filter, update, options.HedgedWrite().SetDelay(time.Millisecond))
+		assert.Equal(t, ErrClientDisconnected, err, "expected error %v, got %v", ErrClientDisconnected, err)
+	})
+	t.Run("explicit session", func(t *testing.T) {
+		coll := setupColl("hedged")
+		coll.client.sessionPool = session.NewPool(nil)
+		sess, err := coll.client.StartSession()
+		assert.Nil(t, err, "StartSession error: %v", err)
+		defer sess.EndSession(bgCtx)
+
+		filter, update := bson.D{{"_id", 1}}, bson.D{{"$set", bson.D{{"x", 1}}}}
+		_, err = coll.HedgedUpsert(NewSessionContext(bgCtx, sess), filter, update)
+		assert.NotNil(t, err, "expected error for explicit session, got nil")
+		assert.NotEqual(t, ErrClientDisconnected, err, "expected session error before server selection, got %v", err)
+	})
+	t.Run("dedup", func(t *testing.T) {
+		matched := &UpdateResult{MatchedCount: 1}
+		upserted := &UpdateResult{UpsertedCount: 1, UpsertedID: int32(1)}
+		testCases := []struct {
+			name     string
+			res      *UpdateResult
+			finished []hedgedAttempt
+			expected *UpdateResult
+		}{
+			{"upserted", upserted, []hedgedAttempt{{res: matched}}, upserted},
+			{"matched", matched, nil, matched},
+			{"matched after failed attempt", matched, []hedgedAttempt{{err: ErrClientDisconnected}}, matched},
+			{"matched after upsert", matched, []hedgedAttempt{{res: upserted}}, upserted},
+		}
+		for _, tc := range testCases {
+			t.Run(tc.name, func(t *testing.T) {
+				attempts := make(chan hedgedAttempt, len(tc.finished))
+				for _, attempt := range tc.finished {
+					attempts <- attempt
+				}
+				got := dedupHedgedResult(tc.res, attempts)
+				assert.Equal(t, tc.expected, got, "expected result %v, got %v", tc.expected, got)
+			})
+		}
+	})
+}
@@ -0,0 +1,102 @@
+// Copyright (C) MongoDB, Inc. 2021-present.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
+
+package mongo
+
+import (
+	"context"
+	"fmt"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/bsontype"
+	"go.mongodb.org/mongo-driver/mongo/description"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"go.mongodb.org/mongo-driver/mongo/readpref"
+	"go.mongodb.org/mongo-driver/x/mongo/driver/operation"
+	"go.mongodb.org/mongo-driver/x/mongo/driver/session"
+)
+
+// InMemorySortError is returned by Find and FindOne for a sorted query whose query plan sorts the documents in memory
+// if the client was created with the options.RejectInMemorySorts check (see options.ClientOptions.SetInMemorySortCheck).
+type InMemorySortError struct {
+	// The namespace of the query, in the form "<database>.<collection>".
+	Namespace string
+
+	// The sort of the query.
+	Sort bson.Raw
+
+	// The winning plan of the query, which has a SORT stage.
+	WinningPlan bson.Raw
+}
+
+// Error implements the error interface.
+func (e InMemorySortError) Error() string {
+	return fmt.Sprintf("the query plan of the find on %s with sort %v sorts the documents in memory, so an index that "+
+		"supports the sort may be missing", e.Namespace, e.Sort)
+}
+
+// checkInMemorySort explains the find operation op if the client checks sorted queries for in-memory sorts and the
+// query has a sort, and reports the query to the client's in-memory sort handler or returns an InMemorySortError if the
+// winning plan sorts in memory. The explain uses the read preference and server selector of the find so that the plan
+// is checked on the server that runs the query.
+func (coll *Collection) checkInMemorySort(ctx context.Context, sess *session.Client, op *operation.Find,
+	fo *options.FindOptions, rp *readpref.ReadPref, selector description.ServerSelector) error {
+
+	check := coll.client.inMemorySortCheck
+	if check == options.IgnoreInMemorySorts || fo.Sort == nil || sess.TransactionRunning() {
+		return nil
+	}
+	if check == options.WarnOnInMemorySorts && coll.client.inMemorySortFunc == nil {
+		return nil
+	}
+
+	sort, err := transformBsoncoreDocument(coll.registry, fo.Sort, false, "sort")
+	if err != nil {
+		return err
+	}
+	res, err := coll.explain(ctx, op, sess, rp, selector, options.Explain().SetVerbosity(options.QueryPlanner))
+	if err != nil {
+		return err
+	}
+	if !hasInMemorySort(res.WinningPlan) {
+		return nil
+	}
+
+	sortErr := InMemorySortError{
+		Namespace:   coll.db.name + "." + coll.name,
+		Sort:        bson.Raw(sort),
+		WinningPlan: res.WinningPlan,
+	}
+	if check == options.RejectInMemorySorts {
+		return sortErr
+	}
+	coll.client.inMemorySortFunc(sortErr)
+	return nil
+}
+
+// hasInMemorySort returns true if plan, or any of the stages nested in it, is a SORT stage. This covers the input
+// stages of classic plans, the plans of the shards of a sharded query, and the query plans of slot-based execution.
+func hasInMemorySort(plan bson.Raw) bool {
+	elems, err := plan.Elements()
+	if err != nil {
+		return false
+	}
+	for _, elem := range elems {
+		val := elem.Value()
+		switch val.Type {
+		case bsontype.String:
+			if elem.Key() == "stage" && val.StringValue() == "SORT" {
+				return true
+			}
+		case bsontype.EmbeddedDocument, bsontype.Array:
+			// Arrays have the same layout as documents, so their values are walked the same way.
+			if hasInMemorySort(bson.Raw(val.Value)) {
+				return true
+			}
+		}
+	}
+	return false
+}
@@ -0,0 +1,149 @@
+// Copyright (C) MongoDB, Inc. 2021-present.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
+
+package mongo_test
+
+import (
+	"context"
+	"testing"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/internal/testutil/assert"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/mongotest"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"go.mongodb.org/mongo-driver/mongo/readpref"
+)
+
+func TestInMemorySortCheck(t *testing.T) {
+	inMemoryPlan := bson.D{{"stage", "SORT"}, {"inputStage", bson.D{{"stage", "COLLSCAN"}}}}
+	shardedPlan := bson.D{
+		{"stage", "SINGLE_SHARD"},
+		{"shards", bson.A{bson.D{{"shardName", "shard0"}, {"winningPlan", inMemoryPlan}}}},
+	}
+	indexPlan := bson.D{{"stage", "FETCH"}, {"inputStage", bson.D{{"stage", "IXSCAN"}}}}
+	explainReply := func(plan bson.D) bson.D {
+		return mongotest.SuccessReply(bson.E{"queryPlanner", bson.D{{"winningPlan", plan}}})
+	}
+	sort := options.Find().SetSort(bson.D{{"createdAt", -1}})
+	filter := bson.D{{"status", "active"}}
+
+	newColl := func(t *testing.T, check options.InMemorySortCheck, opts ...*options.ClientOptions) (*mongotest.Deployment,
+		*mongo.Collection) {
+
+		d := mongotest.New()
+		client, err := d.NewClient(append(opts, options.Client().SetInMemorySortCheck(check))...)
+		assert.Nil(t, err, "NewClient error: %v", err)
+		return d, client.Database("db").Collection("orders")
+	}
+
+	t.Run("reject", func(t *testing.T) {
+		d, coll := newColl(t, options.RejectInMemorySorts)
+		defer func() { _ = coll.Database().Client().Disconnect(context.Background()) }()
+
+		for _, plan := range []bson.D{inMemoryPlan, shardedPlan} {
+			d.Reset()
+			d.AddReplies(explainReply(plan))
+			_, err := coll.Find(context.Background(), filter, sort)
+			sortErr, ok := err.(mongo.InMemorySortError)
+			assert.True(t, ok, "expected InMemorySortError, got %v", err)
+			assert.Equal(t, "db.orders", sortErr.Namespace, "expected namespace db.orders, got %v", sortErr.Namespace)
+			assert.Equal(t, int32(-1), sortErr.Sort.Lookup("createdAt").Int32(), "expected sort on createdAt, got %v",
+				sortErr.Sort)
+
+			cmds := d.Commands()
+			assert.Equal(t, 1, len(cmds), "expected only the explain to be sent, got %v commands", len(cmds))
+			assert.Equal(t, "explain", cmds[0].Name, "expected explain command, got %v", cmds[0].Name)
+			explained := cmds[0].Document.Lookup("explain").Document()
+			assert.Equal(t, "orders", explained.Lookup("find").StringValue(), "expected explained find, got %v",
+				explained)
+			verbosity := cmds[0].Document.Lookup("verbosity").StringValue()
+			assert.Equal(t, "queryPlanner", verbosity, "expected verbosity queryPlanner, got %v", verbosity)
+		}
+	})
+	t.Run("index supports the sort", func(t *testing.T) {
+		d, coll := newColl(t, options.RejectInMemorySorts)
+		defer func() { _ = coll.Database().Client().Disconnect(context.Background()) }()
+		d.AddReplies(explainReply(indexPlan), mongotest.CursorReply("db.orders", bson.D{{"_id", 1}}))
+
+		var docs []bson.D
+		cursor, err := coll.Find(context.Background(), filter, sort)
+		assert.Nil(t, err, "Find error: %v", err)
+		err = cursor.All(context.Background(), &docs)
+		assert.Nil(t, err, "All error: %v", err)
+		assert.Equal(t, 1, len(docs), "expected 1 document, got %v", len(docs))
+
+		cmds := d.Commands()
+		assert.Equal(t, 2, len(cmds), "expected 2 commands, got %v", len(cmds))
+		assert.Equal(t, "find", cmds[1].Name, "expected find command, got %v", cmds[1].Name)
+	})
+	t.Run("explain uses the read preference of the find", func(t *testing.T) {
+		d, coll := newColl(t, options.RejectInMemorySorts)
+		defer func() { _ = coll.Database().Client().Disconnect(context.Background()) }()
+		d.AddReplies(explainReply(indexPlan), mongotest.CursorReply("db.orders", bson.D{{"_id", 1}}))
+
+		opts := options.Find().SetSort(bson.D{{"createdAt", -1}}).SetReadPreference(readpref.Secondary())
+		cursor, err := coll.Find(context.Background(), filter, opts)
+		assert.Nil(t, err, "Find error: %v", err)
+		_ = cursor.Close(context.Background())
+
+		cmds := d.Commands()
+		assert.Equal(t, 2, len(cmds), "expected 2 commands, got %v", len(cmds))
+		for _, cmd := range cmds {
+			mode, _ := cmd.Document.Lookup("$readPreference", "mode").StringValueOK()
+			assert.Equal(t, "secondary", mode, "expected read preference secondary for %v, got %q", cmd.Name, mode)
+		}
+	})
+	t.Run("unsorted queries are not explained", func(t *testing.T) {
+		d, coll := newColl(t, options.RejectInMemorySorts)
+		defer func() { _ = coll.Database().Client().Disconnect(context.Background()) }()
+		d.AddReplies(mongotest.CursorReply("db.orders", bson.D{{"_id", 1}}))
+
+		err := coll.FindOne(context.Background(), filter).Err()
+		assert.Nil(t, err, "FindOne error: %v", err)
+		cmds := d.Commands()
+		assert.Equal(t, 1, len(cmds), "expected 1 command, got %v", len(cmds))
+		assert.Equal(t, "find", cmds[0].Name, "expected find command, got %v", cmds[0].Name)
+	})
+	t.Run("warn", func(t *testing.T) {
+		var reported []error
+		handler := options.Client().SetInMemorySortHandler(func(err error) { reported = append(reported, err) })
+		d, coll := newColl(t, options.WarnOnInMemorySorts, handler)
+		defer func() { _ = coll.Database().Client().Disconnect(context.Background()) }()
+		d.AddReplies(explainReply(inMemoryPlan), mongotest.CursorReply("db.orders", bson.D{{"_id", 1}}))
+
+		err := coll.FindOne(context.Background(), filter, options.FindOne().SetSort(bson.D{{"createdAt", -1}})).Err()
+		assert.Nil(t, err, "FindOne error: %v", err)
+		assert.Equal(t, 1, len(reported), "expected 1 reported query, got %v", len(reported))
+		sortErr, ok := reported[0].(mongo.InMemorySortError)
+		assert.True(t, ok, "expected InMemorySortError, got %v", reported[0])
+		assert.Equal(t, "db.orders", sortErr.Namespace, "expected namespace db.orders, got %v", sortErr.Namespace)
+		cmds := d.Commands()
+		assert.Equal(t, 2, len(cmds), "expected 2 commands, got %v", len(cmds))
+	})
+	t.Run("warn without a handler", func(t *testing.T) {
+		d, coll := newColl(t, options.WarnOnInMemorySorts)
+		defer func() { _ = coll.Database().Client().Disconnect(context.Background()) }()
+		d.AddReplies(mongotest.CursorReply("db.orders", bson.D{{"_id", 1}}))
+
+		_, err := coll.Find(context.Background(), filter, sort)
+		assert.Nil(t, err, "Find error: %v", err)
+		cmds := d.Commands()
+		assert.Equal(t, 1, len(cmds), "expected only the find to be sent, got %v commands", len(cmds))
+	})
+	t.Run("ignore by default", func(t *testing.T) {
+		d := mongotest.New()
+		client, err := d.NewClient()
+		assert.Nil(t, err, "NewClient error: %v", err)
+		defer func() { _ = client.Disconnect(context.Background()) }()
+		d.AddReplies(mongotest.CursorReply("db.orders", bson.D{{"_id", 1}}))
+
+		_, err = client.Database("db").Collection("orders").Find(context.Background(), filter, sort)
+		assert.Nil(t, err, "Find error: %v", err)
+		cmds := d.Commands()
+		assert.Equal(t, 1, len(cmds), "expected 1 command, got %v", len(cmds))
+	})
+}
@@ -0,0 +1,142 @@
+// Copyright (C) MongoDB, Inc. 2021-present.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
+
+package mongo
+
+import (
+	"context"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"go.mongodb.org/mongo-driver/mongo/readpref"
+)
+
+// IndexBuild is a handle to a createIndexes command started by IndexView.CreateManyAsync. It is safe for concurrent
+// use.
+type IndexBuild struct {
+	iv    IndexView
+	names []string
+	done  chan struct{}
+	err   error
+}
+
+// IndexBuildProgress describes the progress of an index build as reported by the $currentOp aggregation stage.
+type IndexBuildProgress struct {
+	// Whether the createIndexes command is still running. If false, the other fields are not set.
+	Active bool
+
+	// The current phase of the index build as reported by the server, e.g. "Index Build: scanning collection". This is
+	// empty if the build was not found in the output of $currentOp, e.g. because it is waiting for other index builds
+	// to finish.
+	Message string
+
+	// The amount of work done in the current phase and the total amount of work in the phase. Both are zero if the
+	// server does not report the progress of the current phase.
+	Done  int64
+	Total int64
+}
+
+// currentOpEntry is the subset of an operation reported by $currentOp that describes the progress of an index build.
+type currentOpEntry struct {
+	Msg      string `bson:"msg"`
+	Progress *struct {
+		Done  float64 `bson:"done"`
+		Total float64 `bson:"total"`
+	} `bson:"progress"`
+}
+
+// Names returns the names of the indexes being created.
+func (ib *IndexBuild) Names() []string {
+	return ib.names
+}
+
+// Done returns a channel that is closed when the createIndexes command finishes.
+func (ib *IndexBuild) Done() <-chan struct{} {
+	return ib.done
+}
+
+// Err returns the error returned by the createIndexes command. It returns nil if the command succeeded or has not
+// finished yet.
+func (ib *IndexBuild) Err() error {
+	select {
+	case <-ib.done:
+		return ib.err
+	default:
+		return nil
+	}
+}
+
+// Wait blocks until the createIndexes command finishes and returns the error returned by the command. If ctx expires
+// first, the context error is returned and the command keeps running.
+func (ib *IndexBuild) Wait(ctx context.Context) error {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	select {
+	case <-ib.done:
+		return ib.err
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// Progress runs a $currentOp aggregation against the admin database of the primary to report the progress of the
+// index build. It requires the inprog privilege if authentication is enabled. If the createIndexes command has
+// finished, the returned IndexBuildProgress has Active set to false and no command is run.
+func (ib *IndexBuild) Progress(ctx context.Context) (*IndexBuildProgress, error) {
+	select {
+	case <-ib.done:
+		return &IndexBuildProgress{}, nil
+	default:
+	}
+
+	coll := ib.iv.coll
+	ns := coll.db.name + "." + coll.name
+	pipeline := Pipeline{
+		{{"$currentOp", bson.D{{"allUsers", true}}}},
+		{{"$match", bson.D{
+			{"ns", bson.D{{"$in", bson.A{ns, coll.db.name + ".$cmd"}}}},
+			{"command.createIndexes", coll.name},
+			{"command.indexes.name", bson.D{{"$in", ib.names}}},
+		}}},
+	}
+	admin := coll.client.Database("admin", options.Database().SetReadPreference(readpref.Primary()))
+	cursor, err := admin.Aggregate(ctx, pipeline)
+	if err != nil {
+		return nil, err
+	}
+	var ops []bson.Raw
+	if err = cursor.All(ctx, &ops); err != nil {
+		return nil, err
+	}
+
+	return indexBuildProgress(ops)
+}
+
+// indexBuildProgress creates an IndexBuildProgress for an active index build from the operations reported by
+// $currentOp. Depending on the server version, the progress is reported by the createIndexes command or by a separate
+// operation for the index build, so the first operation that reports progress is used.
+func indexBuildProgress(ops []bson.Raw) (*IndexBuildProgress, error) {
+	progress := &IndexBuildProgress{Active: true}
+	for _, op := range ops {
+		var entry currentOpEntry
+		if err := bson.Unmarshal(op, &entry); err != nil {
+			return nil, err
+		}
+
+		if entry.Progress != nil {
+			progress.Message = entry.Msg
+			progress.Done = int64(entry.Progress.Done)
+			progress.Total = int64(entry.Progress.Total)
+			return progress, nil
+		}
+		if progress.Message == "" {
+			progress.Message = entry.Msg
+		}
+	}
+	return progress, nil
+}
@@ -0,0 +1,72 @@
+// Copyright (C) MongoDB, Inc. 2021-present.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
+
+package mongo
+
+import (
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// IndexModelBuilder builds an IndexModel one key at a time so the keys document is always an ordered document with
+// valid index types. An IndexModelBuilder can be created by a call to NewIndexModel.
+type IndexModelBuilder struct {
+	keys bson.D
+	opts *options.IndexOptions
+}
+
+// NewIndexModel creates a new IndexModelBuilder.
+func NewIndexModel() *IndexModelBuilder {
+	return &IndexModelBuilder{}
+}
+
+// Ascending adds an ascending key on the given field.
+func (imb *IndexModelBuilder) Ascending(field string) *IndexModelBuilder {
+	return imb.key(field, int32(1))
+}
+
+// Descending adds a descending key on the given field.
+func (imb *IndexModelBuilder) Descending(field string) *IndexModelBuilder {
+	return imb.key(field, int32(-1))
+}
+
+// Text adds a text key on the given field.
+func (imb *IndexModelBuilder) Text(field string) *IndexModelBuilder {
+	return imb.key(field, "text")
+}
+
+// Hashed adds a hashed key on the given field.
+func (imb *IndexModelBuilder) Hashed(field string) *IndexModelBuilder {
+	return imb.key(field, "hashed")
+}
+
+// Geo2D adds a 2d key on the given field.
+func (imb *IndexModelBuilder) Geo2D(field string) *IndexModelBuilder {
+	return imb.key(field, "2d")
+}
+
+// Geo2DSphere adds a 2dsphere key on the given field.
+func (imb *IndexModelBuilder) Geo2DSphere(field string) *IndexModelBuilder {
+	return imb.key(field, "2dsphere")
+}
+
+// Options sets the options to use to create the index.
+func (imb *IndexModelBuilder) Options(opts *options.IndexOptions) *IndexModelBuilder {
+	imb.opts = opts
+	return imb
+}
+
+// Build returns an IndexModel with the keys and options that have been added to the builder.
+func (imb *IndexModelBuilder) Build() IndexModel {
+	keys := make(bson.D, len(imb.keys))
+	copy(keys, imb.keys)
+	return IndexModel{Keys: keys, Options: imb.opts}
+}
+
+func (imb *IndexModelBuilder) key(field string, value interface{}) *IndexModelBuilder {
+	imb.keys = append(imb.keys, bson.E{Key: field, Value: value})
+	return imb
+}
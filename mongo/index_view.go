@@ -11,6 +11,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"math"
 	"strconv"
 	"time"
 
@@ -129,7 +130,11 @@ func (iv IndexView) List(ctx context.Context, opts ...*options.ListIndexesOption
 		return nil, replaceErrors(err)
 	}
 	cursor, err := newCursorWithSession(bc, iv.coll.registry, sess)
-	return cursor, replaceErrors(err)
+	if err != nil {
+		return nil, replaceErrors(err)
+	}
+	iv.coll.client.trackCursor(cursor)
+	return cursor, nil
 }
 
 // ListSpecifications executes a List command and returns a slice of returned IndexSpecifications
@@ -177,6 +182,46 @@ func (iv IndexView) CreateOne(ctx context.Context, model IndexModel, opts ...*op
 //
 // For more information about the command, see https://docs.mongodb.com/manual/reference/command/createIndexes/.
 func (iv IndexView) CreateMany(ctx context.Context, models []IndexModel, opts ...*options.CreateIndexesOptions) ([]string, error) {
+	names, indexes, err := iv.createIndexesDoc(models)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = iv.createIndexes(ctx, indexes, opts...); err != nil {
+		return nil, err
+	}
+	return names, nil
+}
+
+// CreateManyAsync starts a createIndexes command to create multiple indexes on the collection and returns an
+// IndexBuild that can be used to wait for the command to finish and to poll the progress of the index build. The
+// models are validated before the command is started, so an error is returned immediately if any of them is invalid.
+// See the IndexView.CreateMany documentation for more information about the models and opts parameters.
+//
+// The command runs with ctx in a separate goroutine. Cancelling ctx stops the driver from waiting for the command,
+// but the server may continue to build the indexes. If ctx holds a session, the session must not be used for other
+// operations until the index build is done.
+func (iv IndexView) CreateManyAsync(ctx context.Context, models []IndexModel, opts ...*options.CreateIndexesOptions) (*IndexBuild, error) {
+	names, indexes, err := iv.createIndexesDoc(models)
+	if err != nil {
+		return nil, err
+	}
+
+	ib := &IndexBuild{
+		iv:    iv,
+		names: names,
+		done:  make(chan struct{}),
+	}
+	go func() {
+		defer close(ib.done)
+		ib.err = iv.createIndexes(ctx, indexes, opts...)
+	}()
+	return ib, nil
+}
+
+// createIndexesDoc returns the names of the indexes described by models and the indexes array for a createIndexes
+// command.
+func (iv IndexView) createIndexesDoc(models []IndexModel) ([]string, bsoncore.Document, error) {
 	names := make([]string, 0, len(models))
 
 	var indexes bsoncore.Document
@@ -184,17 +229,17 @@ func (iv IndexView) CreateMany(ctx context.Context, models []IndexModel, opts ..
 
 	for i, model := range models {
 		if model.Keys == nil {
-			return nil, fmt.Errorf("index model keys cannot be nil")
+			return nil, nil, fmt.Errorf("index model keys cannot be nil")
 		}
 
 		keys, err := transformBsoncoreDocument(iv.coll.registry, model.Keys, false, "keys")
 		if err != nil {
-			return nil, err
+			return nil, nil, err
 		}
 
 		name, err := getOrGenerateIndexName(keys, model)
 		if err != nil {
-			return nil, err
+			return nil, nil, err
 		}
 
 		names = append(names, name)
@@ -210,35 +255,39 @@ func (iv IndexView) CreateMany(ctx context.Context, models []IndexModel, opts ..
 
 		optsDoc, err := iv.createOptionsDoc(model.Options)
 		if err != nil {
-			return nil, err
+			return nil, nil, err
 		}
 
 		indexes = bsoncore.AppendDocument(indexes, optsDoc)
 
 		indexes, err = bsoncore.AppendDocumentEnd(indexes, iidx)
 		if err != nil {
-			return nil, err
+			return nil, nil, err
 		}
 	}
 
 	indexes, err := bsoncore.AppendArrayEnd(indexes, aidx)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
+	return names, indexes, nil
+}
 
+// createIndexes executes a createIndexes command for the given indexes array.
+func (iv IndexView) createIndexes(ctx context.Context, indexes bsoncore.Document, opts ...*options.CreateIndexesOptions) error {
+	var err error
 	sess := sessionFromContext(ctx)
-
 	if sess == nil && iv.coll.client.sessionPool != nil {
 		sess, err = session.NewClientSession(iv.coll.client.sessionPool, iv.coll.client.id, session.Implicit)
 		if err != nil {
-			return nil, err
+			return err
 		}
 		defer sess.EndSession()
 	}
 
 	err = iv.coll.client.validSession(sess)
 	if err != nil {
-		return nil, err
+		return err
 	}
 
 	wc := iv.coll.writeConcern
@@ -264,7 +313,7 @@ func (iv IndexView) CreateMany(ctx context.Context, models []IndexModel, opts ..
 	if option.CommitQuorum != nil {
 		commitQuorum, err := transformValue(iv.coll.registry, option.CommitQuorum, true, "commitQuorum")
 		if err != nil {
-			return nil, err
+			return err
 		}
 
 		op.CommitQuorum(commitQuorum)
@@ -272,10 +321,10 @@ func (iv IndexView) CreateMany(ctx context.Context, models []IndexModel, opts ..
 
 	err = op.Execute(ctx)
 	if err != nil {
-		return nil, replaceErrors(err)
+		return replaceErrors(err)
 	}
 
-	return names, nil
+	return nil
 }
 
 func (iv IndexView) createOptionsDoc(opts *options.IndexOptions) (bsoncore.Document, error) {
@@ -447,6 +496,285 @@ func (iv IndexView) DropAll(ctx context.Context, opts ...*options.DropIndexesOpt
 	return iv.drop(ctx, "*", opts...)
 }
 
+// DiffIndexes compares the given models against the indexes that exist on the collection and returns an IndexPlan
+// describing the changes that EnsureIndexes would make. No indexes are created or dropped.
+//
+// Models are matched to existing indexes by name. If a model does not specify a name, it is generated from the Keys
+// document in the same way as CreateMany. The unique, sparse, hidden, expireAfterSeconds, partialFilterExpression,
+// collation, and wildcardProjection options of a matching index are compared with those of the model. Other options,
+// e.g. the weights of a text index, are not compared because the server reports defaults for them.
+//
+// The opts parameter can be used to specify options for this operation (see the options.EnsureIndexesOptions
+// documentation).
+func (iv IndexView) DiffIndexes(ctx context.Context, models []IndexModel, opts ...*options.EnsureIndexesOptions) (*IndexPlan, error) {
+	existing, err := iv.ListSpecifications(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return iv.diffIndexes(existing, models, options.MergeEnsureIndexesOptions(opts...))
+}
+
+// EnsureIndexes computes an IndexPlan as described in the IndexView.DiffIndexes documentation and applies it by
+// dropping and recreating the indexes in the plan's Replace list, creating the missing indexes, changing the indexes in
+// the plan's Modify list with the collMod command, and, if EnsureIndexesOptions.DropExtra is true, dropping the indexes
+// that are not in the list of models. Indexes in the plan's Conflicting list are left unchanged. The plan is returned
+// even if applying it fails so the caller can determine which changes were attempted.
+//
+// The opts parameter can be used to specify options for this operation (see the options.EnsureIndexesOptions
+// documentation).
+func (iv IndexView) EnsureIndexes(ctx context.Context, models []IndexModel, opts ...*options.EnsureIndexesOptions) (*IndexPlan, error) {
+	plan, err := iv.DiffIndexes(ctx, models, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, model := range plan.Replace {
+		if _, err = iv.DropOne(ctx, *model.Options.Name); err != nil {
+			return plan, err
+		}
+	}
+	if create := append(append([]IndexModel(nil), plan.Create...), plan.Replace...); len(create) > 0 {
+		if _, err = iv.CreateMany(ctx, create); err != nil {
+			return plan, err
+		}
+	}
+	for _, model := range plan.Modify {
+		index := options.ModifyIndex().SetName(*model.Options.Name)
+		if model.Options.ExpireAfterSeconds != nil {
+			index.SetExpireAfterSeconds(int64(*model.Options.ExpireAfterSeconds))
+		}
+		if model.Options.Hidden != nil {
+			index.SetHidden(*model.Options.Hidden)
+		}
+		err = iv.coll.db.ModifyCollection(ctx, iv.coll.name, options.ModifyCollection().SetIndex(index))
+		if err != nil {
+			return plan, err
+		}
+	}
+	for _, name := range plan.Drop {
+		if _, err = iv.DropOne(ctx, name); err != nil {
+			return plan, err
+		}
+	}
+
+	return plan, nil
+}
+
+func (iv IndexView) diffIndexes(existing []*IndexSpecification, models []IndexModel, opts *options.EnsureIndexesOptions) (*IndexPlan, error) {
+	byName := make(map[string]*IndexSpecification, len(existing))
+	for _, spec := range existing {
+		byName[spec.Name] = spec
+	}
+
+	plan := &IndexPlan{}
+	wanted := make(map[string]struct{}, len(models))
+	for _, model := range models {
+		if model.Keys == nil {
+			return nil, fmt.Errorf("index model keys cannot be nil")
+		}
+
+		keys, err := transformBsoncoreDocument(iv.coll.registry, model.Keys, false, "keys")
+		if err != nil {
+			return nil, err
+		}
+
+		name, err := getOrGenerateIndexName(keys, model)
+		if err != nil {
+			return nil, err
+		}
+		wanted[name] = struct{}{}
+
+		spec, ok := byName[name]
+		if !ok {
+			plan.Create = append(plan.Create, model)
+			continue
+		}
+		if !indexDocumentsEqual(keys, bsoncore.Document(spec.KeysDocument)) {
+			plan.Conflicting = append(plan.Conflicting, name)
+			continue
+		}
+
+		change, target, err := iv.compareIndexOptions(model, name, spec)
+		if err != nil {
+			return nil, err
+		}
+		switch change {
+		case indexOptionsReplace:
+			plan.Replace = append(plan.Replace, target)
+		case indexOptionsModify:
+			plan.Modify = append(plan.Modify, target)
+		default:
+			plan.Unchanged = append(plan.Unchanged, name)
+		}
+	}
+
+	if opts.DropExtra != nil && *opts.DropExtra {
+		for _, spec := range existing {
+			if _, ok := wanted[spec.Name]; ok || spec.Name == "_id_" {
+				continue
+			}
+			plan.Drop = append(plan.Drop, spec.Name)
+		}
+	}
+
+	return plan, nil
+}
+
+// indexOptionsChange describes how the options of an existing index differ from the options of an IndexModel.
+type indexOptionsChange int
+
+const (
+	indexOptionsEqual indexOptionsChange = iota
+	indexOptionsModify
+	indexOptionsReplace
+)
+
+// compareIndexOptions compares the options of model with those of the existing index spec, which has the same name and
+// keys. It returns the kind of change needed to make the index match the model and a copy of the model with the Name
+// option set to name. If only the hidden option differs and the model does not set it, the copy sets it to false so
+// the change can be applied with collMod.
+func (iv IndexView) compareIndexOptions(model IndexModel, name string, spec *IndexSpecification) (indexOptionsChange,
+	IndexModel, error) {
+
+	opts := options.Index()
+	if model.Options != nil {
+		copied := *model.Options
+		opts = &copied
+	}
+	opts.SetName(name)
+	target := IndexModel{Keys: model.Keys, Options: opts}
+
+	replace := !boolOptionsEqual(opts.Unique, spec.Unique) || !boolOptionsEqual(opts.Sparse, spec.Sparse) ||
+		!collationsEqual(opts.Collation, spec.Collation) ||
+		(opts.ExpireAfterSeconds == nil) != (spec.ExpireAfterSeconds == nil)
+	documents := []struct {
+		key      string
+		model    interface{}
+		existing bson.Raw
+	}{
+		{"partialFilterExpression", opts.PartialFilterExpression, spec.PartialFilterExpression},
+		{"wildcardProjection", opts.WildcardProjection, spec.WildcardProjection},
+	}
+	for _, doc := range documents {
+		if doc.model == nil {
+			replace = replace || len(doc.existing) != 0
+			continue
+		}
+		transformed, err := transformBsoncoreDocument(iv.coll.registry, doc.model, true, doc.key)
+		if err != nil {
+			return indexOptionsEqual, target, err
+		}
+		replace = replace || !indexDocumentsEqual(transformed, bsoncore.Document(doc.existing))
+	}
+	if replace {
+		return indexOptionsReplace, target, nil
+	}
+
+	change := indexOptionsEqual
+	if opts.ExpireAfterSeconds != nil && *opts.ExpireAfterSeconds != *spec.ExpireAfterSeconds {
+		change = indexOptionsModify
+	}
+	if !boolOptionsEqual(opts.Hidden, spec.Hidden) {
+		hidden := opts.Hidden != nil && *opts.Hidden
+		opts.Hidden = &hidden
+		change = indexOptionsModify
+	}
+	return change, target, nil
+}
+
+// boolOptionsEqual returns true if the two boolean index options are equal. An option that is not set is equal to
+// false because that is the server's default for all boolean index options.
+func boolOptionsEqual(a, b *bool) bool {
+	return (a != nil && *a) == (b != nil && *b)
+}
+
+// collationsEqual returns true if the collation of a model matches the collation reported by the server. The server
+// reports every field of a collation, so only the fields set in the model are compared. A missing collation is equal
+// to the simple collation.
+func collationsEqual(model *options.Collation, existing bson.Raw) bool {
+	isSimple := func(doc bsoncore.Document) bool {
+		if len(doc) == 0 {
+			return true
+		}
+		locale, ok := doc.Lookup("locale").StringValueOK()
+		return ok && locale == "simple"
+	}
+	if model == nil {
+		return isSimple(bsoncore.Document(existing))
+	}
+
+	modelDoc := bsoncore.Document(model.ToDocument())
+	if len(existing) == 0 {
+		return isSimple(modelDoc)
+	}
+	elems, err := modelDoc.Elements()
+	if err != nil {
+		return false
+	}
+	for _, elem := range elems {
+		val, err := bsoncore.Document(existing).LookupErr(elem.Key())
+		if err != nil || !indexValuesEqual(elem.Value(), val) {
+			return false
+		}
+	}
+	return true
+}
+
+// indexDocumentsEqual returns true if the two documents have the same fields in the same order. Integral values are
+// compared as integers because the server may report a key created with 1 as 1.0 or vice versa.
+func indexDocumentsEqual(a, b bsoncore.Document) bool {
+	aElems, err := a.Elements()
+	if err != nil {
+		return false
+	}
+	bElems, err := b.Elements()
+	if err != nil {
+		return false
+	}
+	if len(aElems) != len(bElems) {
+		return false
+	}
+
+	for i, aElem := range aElems {
+		bElem := bElems[i]
+		if aElem.Key() != bElem.Key() {
+			return false
+		}
+
+		if !indexValuesEqual(aElem.Value(), bElem.Value()) {
+			return false
+		}
+	}
+	return true
+}
+
+// indexValuesEqual returns true if the two values are equal. Integral numeric values are compared as integers, so 1 and
+// 1.0 are equal, but a non-integral double is only equal to the same double.
+func indexValuesEqual(a, b bsoncore.Value) bool {
+	aNum, aOK := integralValue(a)
+	bNum, bOK := integralValue(b)
+	if aOK && bOK {
+		return aNum == bNum
+	}
+	return a.Equal(b)
+}
+
+// integralValue returns the value of v as an int64 and true if v is an integer or a double with an integral value.
+func integralValue(v bsoncore.Value) (int64, bool) {
+	switch v.Type {
+	case bsontype.Int32, bsontype.Int64:
+		return v.AsInt64OK()
+	case bsontype.Double:
+		f := v.Double()
+		if f != math.Trunc(f) || f < math.MinInt64 || f >= math.MaxInt64 {
+			return 0, false
+		}
+		return int64(f), true
+	}
+	return 0, false
+}
+
 func getOrGenerateIndexName(keySpecDocument bsoncore.Document, model IndexModel) (string, error) {
 	if model.Options != nil && model.Options.Name != nil {
 		return *model.Options.Name, nil
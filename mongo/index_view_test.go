@@ -0,0 +1,245 @@
+// Copyright (C) MongoDB, Inc. 2021-present.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
+
+package mongo
+
+import (
+	"math"
+	"testing"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/internal/testutil/assert"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"go.mongodb.org/mongo-driver/x/bsonx/bsoncore"
+)
+
+func TestIndexView(t *testing.T) {
+	t.Run("model builder", func(t *testing.T) {
+		opts := options.Index().SetName("idx")
+		model := NewIndexModel().Ascending("a").Descending("b").Text("c").Hashed("d").
+			Geo2D("e").Geo2DSphere("f").Options(opts).Build()
+
+		expected := bson.D{
+			{"a", int32(1)},
+			{"b", int32(-1)},
+			{"c", "text"},
+			{"d", "hashed"},
+			{"e", "2d"},
+			{"f", "2dsphere"},
+		}
+		assert.Equal(t, expected, model.Keys, "expected keys %v, got %v", expected, model.Keys)
+		assert.True(t, opts == model.Options, "expected options %v, got %v", opts, model.Options)
+	})
+	t.Run("diff indexes", func(t *testing.T) {
+		iv := setupColl("diff").Indexes()
+		spec := func(name string, keys bson.D) *IndexSpecification {
+			doc, err := bson.Marshal(keys)
+			assert.Nil(t, err, "Marshal error: %v", err)
+			return &IndexSpecification{Name: name, KeysDocument: doc}
+		}
+		existing := []*IndexSpecification{
+			spec("_id_", bson.D{{"_id", int32(1)}}),
+			spec("a_1", bson.D{{"a", 1.0}}),
+			spec("b_1", bson.D{{"b", int32(1)}}),
+			spec("c_1", bson.D{{"c", int32(1)}}),
+		}
+		models := []IndexModel{
+			NewIndexModel().Ascending("a").Build(),
+			{Keys: bson.D{{"b", int32(1)}}, Options: options.Index().SetName("b_1")},
+			{Keys: bson.D{{"c", "hashed"}}, Options: options.Index().SetName("c_1")},
+			NewIndexModel().Descending("d").Build(),
+		}
+
+		testCases := []struct {
+			name      string
+			dropExtra bool
+		}{
+			{"drop extra false", false},
+			{"drop extra true", true},
+		}
+		for _, tc := range testCases {
+			t.Run(tc.name, func(t *testing.T) {
+				opts := options.EnsureIndexes().SetDropExtra(tc.dropExtra)
+				plan, err := iv.diffIndexes(existing, models, opts)
+				assert.Nil(t, err, "diffIndexes error: %v", err)
+
+				assert.Equal(t, 1, len(plan.Create), "expected 1 index to create, got %v", len(plan.Create))
+				assert.Equal(t, models[3].Keys, plan.Create[0].Keys,
+					"expected keys %v, got %v", models[3].Keys, plan.Create[0].Keys)
+				assert.Equal(t, []string{"a_1", "b_1"}, plan.Unchanged,
+					"expected unchanged indexes [a_1 b_1], got %v", plan.Unchanged)
+				assert.Equal(t, []string{"c_1"}, plan.Conflicting,
+					"expected conflicting indexes [c_1], got %v", plan.Conflicting)
+				assert.Equal(t, 0, len(plan.Drop), "expected no indexes to drop, got %v", plan.Drop)
+			})
+		}
+		t.Run("extra index dropped", func(t *testing.T) {
+			opts := options.EnsureIndexes().SetDropExtra(true)
+			plan, err := iv.diffIndexes(existing, models[:2], opts)
+			assert.Nil(t, err, "diffIndexes error: %v", err)
+
+			assert.Equal(t, []string{"c_1"}, plan.Drop, "expected indexes to drop [c_1], got %v", plan.Drop)
+			assert.Equal(t, 0, len(plan.Create), "expected no indexes to create, got %v", len(plan.Create))
+		})
+		t.Run("options changed", func(t *testing.T) {
+			raw := func(doc bson.D) bson.Raw {
+				b, err := bson.Marshal(doc)
+				assert.Nil(t, err, "Marshal error: %v", err)
+				return b
+			}
+			yes := true
+			ttl := int32(60)
+			existing := []*IndexSpecification{
+				spec("a_1", bson.D{{"a", int32(1)}}),
+				spec("b_1", bson.D{{"b", int32(1)}}),
+				spec("c_1", bson.D{{"c", int32(1)}}),
+				spec("d_1", bson.D{{"d", int32(1)}}),
+				spec("e_1", bson.D{{"e", int32(1)}}),
+				spec("f_1", bson.D{{"f", int32(1)}}),
+			}
+			existing[0].Unique = &yes
+			existing[1].ExpireAfterSeconds = &ttl
+			existing[2].Collation = raw(bson.D{{"locale", "en"}, {"caseLevel", false}, {"strength", int32(2)}})
+			existing[3].PartialFilterExpression = raw(bson.D{{"x", bson.D{{"$gt", int32(1)}}}})
+			existing[4].Hidden = &yes
+			existing[5].Unique = &yes
+			models := []IndexModel{
+				NewIndexModel().Ascending("a").Build(),
+				NewIndexModel().Ascending("b").Options(options.Index().SetExpireAfterSeconds(120)).Build(),
+				NewIndexModel().Ascending("c").Options(options.Index().SetCollation(&options.Collation{
+					Locale:   "en",
+					Strength: 2,
+				})).Build(),
+				NewIndexModel().Ascending("d").Options(options.Index().SetPartialFilterExpression(
+					bson.D{{"x", bson.D{{"$gt", int32(5)}}}})).Build(),
+				NewIndexModel().Ascending("e").Build(),
+				NewIndexModel().Ascending("f").Options(options.Index().SetUnique(true)).Build(),
+			}
+
+			plan, err := iv.diffIndexes(existing, models, options.EnsureIndexes())
+			assert.Nil(t, err, "diffIndexes error: %v", err)
+
+			assert.Equal(t, []string{"c_1", "f_1"}, plan.Unchanged,
+				"expected unchanged indexes [c_1 f_1], got %v", plan.Unchanged)
+			assert.Equal(t, 2, len(plan.Replace), "expected 2 indexes to replace, got %v", len(plan.Replace))
+			assert.Equal(t, "a_1", *plan.Replace[0].Options.Name, "expected a_1, got %v", *plan.Replace[0].Options.Name)
+			assert.Equal(t, "d_1", *plan.Replace[1].Options.Name, "expected d_1, got %v", *plan.Replace[1].Options.Name)
+			assert.Nil(t, models[0].Options, "expected model options to not be modified, got %v", models[0].Options)
+
+			assert.Equal(t, 2, len(plan.Modify), "expected 2 indexes to modify, got %v", len(plan.Modify))
+			ttlOpts, hiddenOpts := plan.Modify[0].Options, plan.Modify[1].Options
+			assert.Equal(t, "b_1", *ttlOpts.Name, "expected b_1, got %v", *ttlOpts.Name)
+			assert.Equal(t, int32(120), *ttlOpts.ExpireAfterSeconds,
+				"expected expireAfterSeconds 120, got %v", *ttlOpts.ExpireAfterSeconds)
+			assert.Equal(t, "e_1", *hiddenOpts.Name, "expected e_1, got %v", *hiddenOpts.Name)
+			assert.NotNil(t, hiddenOpts.Hidden, "expected hidden to be set")
+			assert.False(t, *hiddenOpts.Hidden, "expected hidden false, got true")
+			assert.Equal(t, 0, len(plan.Create), "expected no indexes to create, got %v", len(plan.Create))
+			assert.Equal(t, 0, len(plan.Conflicting), "expected no conflicting indexes, got %v", plan.Conflicting)
+		})
+		t.Run("index values", func(t *testing.T) {
+			value := func(v interface{}) bsoncore.Value {
+				typ, data, err := bson.MarshalValue(v)
+				assert.Nil(t, err, "MarshalValue error: %v", err)
+				return bsoncore.Value{Type: typ, Data: data}
+			}
+			testCases := []struct {
+				name  string
+				a, b  interface{}
+				equal bool
+			}{
+				{"int32 and double", int32(1), 1.0, true},
+				{"int32 and int64", int32(1), int64(1), true},
+				{"truncated double", 0.5, int32(0), false},
+				{"different doubles", 0.5, 0.25, false},
+				{"same double", 0.5, 0.5, true},
+				{"nested documents", bson.D{{"$gt", 0.5}}, bson.D{{"$gt", int32(0)}}, false},
+			}
+			for _, tc := range testCases {
+				equal := indexValuesEqual(value(tc.a), value(tc.b))
+				assert.Equal(t, tc.equal, equal, "%s: expected %v, got %v", tc.name, tc.equal, equal)
+			}
+		})
+		t.Run("nil keys", func(t *testing.T) {
+			_, err := iv.diffIndexes(existing, []IndexModel{{}}, options.EnsureIndexes())
+			assert.NotNil(t, err, "expected diffIndexes error, got nil")
+		})
+	})
+	t.Run("create many async", func(t *testing.T) {
+		iv := setupColl("async").Indexes()
+
+		t.Run("invalid model", func(t *testing.T) {
+			_, err := iv.CreateManyAsync(bgCtx, []IndexModel{{}})
+			assert.NotNil(t, err, "expected CreateManyAsync error, got nil")
+		})
+		t.Run("command error", func(t *testing.T) {
+			ib, err := iv.CreateManyAsync(bgCtx, []IndexModel{{Keys: bson.D{{"a", 1}}}})
+			assert.Nil(t, err, "CreateManyAsync error: %v", err)
+			assert.Equal(t, []string{"a_1"}, ib.Names(), "expected names [a_1], got %v", ib.Names())
+
+			err = ib.Wait(bgCtx)
+			assert.Equal(t, ErrClientDisconnected, err, "expected error %v, got %v", ErrClientDisconnected, err)
+			err = ib.Err()
+			assert.Equal(t, ErrClientDisconnected, err, "expected error %v, got %v", ErrClientDisconnected, err)
+
+			progress, err := ib.Progress(bgCtx)
+			assert.Nil(t, err, "Progress error: %v", err)
+			assert.False(t, progress.Active, "expected index build to be inactive")
+		})
+	})
+	t.Run("specification expireAfterSeconds", func(t *testing.T) {
+		i32 := func(v int32) *int32 { return &v }
+		testCases := []struct {
+			name     string
+			value    interface{}
+			expected *int32
+		}{
+			{"int32", int32(60), i32(60)},
+			{"int64", int64(60), i32(60)},
+			{"integral double", 60.0, i32(60)},
+			{"NaN", math.NaN(), nil},
+			{"fractional double", 1.5, nil},
+			{"out of range", int64(math.MaxInt32) + 1, nil},
+			{"string", "60", nil},
+		}
+		for _, tc := range testCases {
+			t.Run(tc.name, func(t *testing.T) {
+				doc, err := bson.Marshal(bson.D{{"name", "ttl"}, {"key", bson.D{{"a", 1}}}, {"expireAfterSeconds", tc.value}})
+				assert.Nil(t, err, "Marshal error: %v", err)
+
+				var spec IndexSpecification
+				err = bson.Unmarshal(doc, &spec)
+				assert.Nil(t, err, "Unmarshal error: %v", err)
+				assert.Equal(t, tc.expected, spec.ExpireAfterSeconds, "expected expireAfterSeconds %v, got %v",
+					tc.expected, spec.ExpireAfterSeconds)
+			})
+		}
+	})
+	t.Run("index build progress", func(t *testing.T) {
+		marshal := func(doc bson.D) bson.Raw {
+			b, err := bson.Marshal(doc)
+			assert.Nil(t, err, "Marshal error: %v", err)
+			return b
+		}
+
+		ops := []bson.Raw{
+			marshal(bson.D{{"msg", "waiting"}}),
+			marshal(bson.D{
+				{"msg", "Index Build: scanning collection"},
+				{"progress", bson.D{{"done", int32(40)}, {"total", int64(100)}}},
+			}),
+		}
+		progress, err := indexBuildProgress(ops)
+		assert.Nil(t, err, "indexBuildProgress error: %v", err)
+		expected := &IndexBuildProgress{Active: true, Message: "Index Build: scanning collection", Done: 40, Total: 100}
+		assert.Equal(t, expected, progress, "expected progress %v, got %v", expected, progress)
+
+		progress, err = indexBuildProgress(ops[:1])
+		assert.Nil(t, err, "indexBuildProgress error: %v", err)
+		expected = &IndexBuildProgress{Active: true, Message: "waiting"}
+		assert.Equal(t, expected, progress, "expected progress %v, got %v", expected, progress)
+	})
+}
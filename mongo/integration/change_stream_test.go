@@ -339,6 +339,29 @@ func TestChangeStream_ReplicaSet(t *testing.T) {
 		_, err = e.Command.LookupErr("maxTimeMS")
 		assert.Nil(mt, err, "field maxTimeMS not found in command %v", e.Command)
 	})
+	mt.Run("SetMaxAwaitTime", func(mt *mtest.T) {
+		// SetMaxAwaitTime should change the maxTimeMS of subsequent getMores and Stats should report them
+
+		opts := options.ChangeStream().SetMaxAwaitTime(100 * time.Millisecond)
+		cs, err := mt.Coll.Watch(mtest.Background, mongo.Pipeline{}, opts)
+		assert.Nil(mt, err, "Watch error: %v", err)
+		defer closeStream(cs)
+
+		cs.SetMaxAwaitTime(200 * time.Millisecond)
+		mt.ClearEvents()
+		assert.False(mt, cs.TryNext(mtest.Background), "unexpected event %v", cs.Current)
+
+		e := mt.GetStartedEvent()
+		assert.NotNil(mt, e, "expected getMore event, got nil")
+		maxTime := e.Command.Lookup("maxTimeMS").AsInt64()
+		assert.Equal(mt, int64(200), maxTime, "expected maxTimeMS 200, got %v", maxTime)
+
+		stats := cs.Stats()
+		assert.Equal(mt, int64(1), stats.GetMoreCount, "expected 1 getMore, got %v", stats.GetMoreCount)
+		assert.Equal(mt, int64(1), stats.EmptyBatchCount, "expected 1 empty batch, got %v", stats.EmptyBatchCount)
+		assert.True(mt, stats.LastDuration >= 200*time.Millisecond, "expected the getMore to await at least 200ms, got %v",
+			stats.LastDuration)
+	})
 	mt.RunOpts("resume token", noClientOpts, func(mt *mtest.T) {
 		// Prose tests to make assertions on resume tokens for change streams that have not done a getMore yet
 		mt.RunOpts("no getMore", noClientOpts, func(mt *mtest.T) {
@@ -10,6 +10,7 @@ import (
 	"fmt"
 	"path"
 	"reflect"
+	"runtime"
 	"strings"
 	"testing"
 	"time"
@@ -23,6 +24,7 @@ import (
 	"go.mongodb.org/mongo-driver/mongo/integration/mtest"
 	"go.mongodb.org/mongo-driver/mongo/options"
 	"go.mongodb.org/mongo-driver/mongo/readpref"
+	"go.mongodb.org/mongo-driver/version"
 	"go.mongodb.org/mongo-driver/x/bsonx/bsoncore"
 	"go.mongodb.org/mongo-driver/x/mongo/driver"
 	"go.mongodb.org/mongo-driver/x/mongo/driver/wiremessage"
@@ -429,6 +431,38 @@ func TestClient(t *testing.T) {
 		}
 	})
 
+	driverInfoClientOpts := options.Client().
+		SetDriverInfo("my-odm", "1.2.0", "aws-lambda")
+	driverInfoMtOpts := mtest.NewOptions().
+		ClientType(mtest.Proxy).
+		ClientOptions(driverInfoClientOpts).
+		Topologies(mtest.Single)
+	mt.RunOpts("driver info is appended", driverInfoMtOpts, func(mt *mtest.T) {
+		err := mt.Client.Ping(mtest.Background, mtest.PrimaryRp)
+		assert.Nil(mt, err, "Ping error: %v", err)
+
+		msgPairs := mt.GetProxiedMessages()
+		assert.True(mt, len(msgPairs) >= 2, "expected at least 2 events sent, got %v", len(msgPairs))
+
+		// The driver info should be appended in the handshakes for both the heartbeat and application connections.
+		expected := map[string]string{
+			"driver.name":    "mongo-go-driver|my-odm",
+			"driver.version": version.Driver + "|1.2.0",
+			"platform":       runtime.Version() + "|aws-lambda",
+		}
+		for idx, pair := range msgPairs[:2] {
+			assert.Equal(mt, pair.CommandName, "isMaster", "expected command name isMaster at index %d, got %s", idx,
+				pair.CommandName)
+
+			for path, want := range expected {
+				val, err := pair.Sent.Command.LookupErr(append([]string{"client"}, strings.Split(path, ".")...)...)
+				assert.Nil(mt, err, "expected command %s at index %d to contain %s", pair.Sent.Command, idx, path)
+				got := val.StringValue()
+				assert.Equal(mt, want, got, "expected %s %q at index %d, got %q", path, want, idx, got)
+			}
+		}
+	})
+
 	// Test that direct connections work as expected.
 	firstServerAddr := mtest.GlobalTopology().Description().Servers[0].Addr
 	directConnectionOpts := options.Client().
@@ -52,6 +52,13 @@ func TestCollection(t *testing.T) {
 			assert.Nil(mt, err, "InsertOne error: %v", err)
 			assert.Equal(mt, id, res.InsertedID, "expected inserted ID %v, got %v", id, res.InsertedID)
 		})
+		mt.Run("raw reply", func(mt *mtest.T) {
+			res, err := mt.Coll.InsertOne(mtest.Background, bson.D{{"x", 1}})
+			assert.Nil(mt, err, "InsertOne error: %v", err)
+			n, ok := res.Raw.Lookup("n").Int32OK()
+			assert.True(mt, ok, "expected n in raw reply %v", res.Raw)
+			assert.Equal(mt, int32(1), n, "expected n 1, got %v", n)
+		})
 		mt.Run("write error", func(mt *mtest.T) {
 			doc := bson.D{{"_id", 1}}
 			_, err := mt.Coll.InsertOne(mtest.Background, doc)
@@ -787,6 +794,25 @@ func TestCollection(t *testing.T) {
 			_, ok := err.(mongo.WriteConcernError)
 			assert.True(mt, ok, "expected error type %v, got %v", mongo.WriteConcernError{}, err)
 		})
+		mt.RunOpts("with accumulator", mtest.NewOptions().MinServerVersion("4.4"), func(mt *mtest.T) {
+			initCollection(mt, mt.Coll)
+			acc := mongo.Accumulator{
+				Init:           "function() { return 0; }",
+				Accumulate:     "function(state, x) { return state + x; }",
+				AccumulateArgs: []interface{}{"$x"},
+				Merge:          "function(a, b) { return a + b; }",
+				Finalize:       `function(state) { return "sum: " + state; }`,
+			}
+			pipeline := mongo.Pipeline{{{"$match", bson.D{{"x", bson.D{{"$gte", 2}}}}}}}
+			cursor, err := mt.Coll.AggregateWithAccumulator(mtest.Background, pipeline, nil, "total", acc)
+			assert.Nil(mt, err, "AggregateWithAccumulator error: %v", err)
+
+			var results []bson.M
+			err = cursor.All(mtest.Background, &results)
+			assert.Nil(mt, err, "All error: %v", err)
+			assert.Equal(mt, 1, len(results), "expected 1 result, got %v", len(results))
+			assert.Equal(mt, "sum: 14", results[0]["total"], "expected total 'sum: 14', got %v", results[0]["total"])
+		})
 	})
 	mt.RunOpts("count documents", noClientOpts, func(mt *mtest.T) {
 		mt.Run("success", func(mt *mtest.T) {
@@ -824,6 +850,43 @@ func TestCollection(t *testing.T) {
 			_, err := mt.Coll.CountDocuments(mtest.Background, bson.D{}, opts)
 			assert.Equal(mt, mongo.ErrMapForOrderedArgument{"hint"}, err, "expected error %v, got %v", mongo.ErrMapForOrderedArgument{"hint"}, err)
 		})
+		mt.RunOpts("fast count", mtest.NewOptions().MinServerVersion("3.6"), func(mt *mtest.T) {
+			testCases := []struct {
+				name      string
+				filter    bson.D
+				opts      *options.CountOptions
+				count     int64
+				fastCount bool
+			}{
+				{"no filter", bson.D{}, options.Count(), 5, true},
+				{"filter", bson.D{{"x", bson.D{{"$gt", 2}}}}, options.Count(), 3, false},
+				{"limit", bson.D{}, options.Count().SetLimit(3), 3, false},
+			}
+			for _, tc := range testCases {
+				mt.Run(tc.name, func(mt *mtest.T) {
+					initCollection(mt, mt.Coll)
+					mt.ClearEvents()
+					opts := tc.opts.SetAllowFastCount(true).SetComment("count").SetMaxTime(time.Second)
+					res, err := mt.Coll.CountDocumentsWithResult(mtest.Background, tc.filter, opts)
+					assert.Nil(mt, err, "CountDocumentsWithResult error: %v", err)
+					assert.Equal(mt, tc.count, res.Count, "expected count %v, got %v", tc.count, res.Count)
+					assert.Equal(mt, tc.fastCount, res.FastCount, "expected fast count %v, got %v", tc.fastCount,
+						res.FastCount)
+
+					evt := mt.GetStartedEvent()
+					stage := evt.Command.Lookup("pipeline", "0").Document().Index(0).Key()
+					expectedStage := "$match"
+					if tc.fastCount {
+						expectedStage = "$collStats"
+					}
+					assert.Equal(mt, expectedStage, stage, "expected first stage %v, got %v", expectedStage, stage)
+					_, err = evt.Command.LookupErr("maxTimeMS")
+					assert.Nil(mt, err, "expected maxTimeMS in command %v", evt.Command)
+					comment := evt.Command.Lookup("comment").StringValue()
+					assert.Equal(mt, "count", comment, "expected comment count, got %v", comment)
+				})
+			}
+		})
 	})
 	mt.RunOpts("estimated document count", noClientOpts, func(mt *mtest.T) {
 		testCases := []struct {
@@ -864,6 +927,215 @@ func TestCollection(t *testing.T) {
 				assert.Equal(mt, tc.expected, res, "expected result %v, got %v", tc.expected, res)
 			})
 		}
+		mt.RunOpts("hint", mtest.NewOptions().MinServerVersion("7.1"), func(mt *mtest.T) {
+			initCollection(mt, mt.Coll)
+			res, err := mt.Coll.Distinct(mtest.Background, "x", bson.D{}, options.Distinct().SetHint("_id_"))
+			assert.Nil(mt, err, "Distinct error: %v", err)
+			assert.Equal(mt, all, res, "expected result %v, got %v", all, res)
+		})
+	})
+	mt.RunOpts("checksum", noClientOpts, func(mt *mtest.T) {
+		initCollection(mt, mt.Coll)
+		target := mt.CreateCollection(mtest.Collection{Name: "checksum_target"}, true)
+		cursor, err := mt.Coll.Find(mtest.Background, bson.D{}, options.Find().SetSort(bson.D{{"x", -1}}))
+		assert.Nil(mt, err, "Find error: %v", err)
+		var docs []interface{}
+		err = cursor.All(mtest.Background, &docs)
+		assert.Nil(mt, err, "All error: %v", err)
+		_, err = target.InsertMany(mtest.Background, docs)
+		assert.Nil(mt, err, "InsertMany error: %v", err)
+
+		source, err := mt.Coll.Checksum(mtest.Background)
+		assert.Nil(mt, err, "Checksum error: %v", err)
+		copied, err := target.Checksum(mtest.Background)
+		assert.Nil(mt, err, "Checksum error: %v", err)
+		assert.Equal(mt, int64(5), source.Count, "expected count 5, got %v", source.Count)
+		assert.Equal(mt, source, copied, "expected checksum %v, got %v", source, copied)
+
+		filtered, err := target.Checksum(mtest.Background, options.Checksum().SetFilter(bson.D{{"x", bson.D{{"$gt", 3}}}}))
+		assert.Nil(mt, err, "Checksum error: %v", err)
+		assert.Equal(mt, int64(2), filtered.Count, "expected count 2, got %v", filtered.Count)
+
+		_, err = target.UpdateOne(mtest.Background, bson.D{{"x", 1}}, bson.D{{"$set", bson.D{{"x", 10}}}})
+		assert.Nil(mt, err, "UpdateOne error: %v", err)
+		_, err = target.DeleteOne(mtest.Background, bson.D{{"x", 2}})
+		assert.Nil(mt, err, "DeleteOne error: %v", err)
+		_, err = target.InsertOne(mtest.Background, bson.D{{"x", 6}})
+		assert.Nil(mt, err, "InsertOne error: %v", err)
+
+		diff, err := mongo.CompareCollections(mtest.Background, mt.Coll, target)
+		assert.Nil(mt, err, "CompareCollections error: %v", err)
+		assert.False(mt, diff.Equal(), "expected collections to differ")
+		assert.Equal(mt, 1, len(diff.Mismatched), "expected 1 mismatched document, got %v", len(diff.Mismatched))
+		assert.Equal(mt, 1, len(diff.MissingFromTarget), "expected 1 document missing from target, got %v",
+			len(diff.MissingFromTarget))
+		assert.Equal(mt, 1, len(diff.MissingFromSource), "expected 1 document missing from source, got %v",
+			len(diff.MissingFromSource))
+
+		diff, err = mongo.CompareCollections(mtest.Background, mt.Coll, target, options.Checksum().SetMaxDifferences(0))
+		assert.Nil(mt, err, "CompareCollections error: %v", err)
+		assert.False(mt, diff.Equal(), "expected collections to differ")
+		assert.Equal(mt, 0, len(diff.Mismatched), "expected no reported differences, got %v", diff.Mismatched)
+
+		_, err = mongo.CompareCollections(mtest.Background, mt.Coll, target,
+			options.Checksum().SetProjection(bson.D{{"_id", 0}}))
+		assert.Equal(mt, mongo.ErrMissingID, err, "expected error %v, got %v", mongo.ErrMissingID, err)
+	})
+	mt.RunOpts("sync documents", noClientOpts, func(mt *mtest.T) {
+		mt.Run("full", func(mt *mtest.T) {
+			_, err := mt.Coll.InsertMany(mtest.Background, []interface{}{
+				bson.D{{"_id", 1}, {"name", "a"}},
+				bson.D{{"_id", 2}, {"name", "b"}},
+				bson.D{{"_id", 3}, {"name", "c"}},
+			})
+			assert.Nil(mt, err, "InsertMany error: %v", err)
+
+			docs := []interface{}{
+				bson.D{{"_id", 1}, {"name", "a"}},
+				bson.D{{"_id", 2}, {"name", "B"}},
+				bson.D{{"_id", 4}, {"name", "d"}},
+			}
+			res, err := mt.Coll.SyncDocuments(mtest.Background, docs)
+			assert.Nil(mt, err, "SyncDocuments error: %v", err)
+			expected := &mongo.SyncResult{InsertedCount: 1, ReplacedCount: 1, DeletedCount: 1, UnchangedCount: 1}
+			res.BulkWrite = nil
+			assert.Equal(mt, expected, res, "expected result %v, got %v", expected, res)
+
+			cursor, err := mt.Coll.Find(mtest.Background, bson.D{}, options.Find().SetSort(bson.D{{"_id", 1}}))
+			assert.Nil(mt, err, "Find error: %v", err)
+			var got []bson.D
+			err = cursor.All(mtest.Background, &got)
+			assert.Nil(mt, err, "All error: %v", err)
+			assert.Equal(mt, 3, len(got), "expected 3 documents, got %v", len(got))
+			assert.Equal(mt, "B", got[1][1].Value, "expected name B, got %v", got[1][1].Value)
+
+			res, err = mt.Coll.SyncDocuments(mtest.Background, docs)
+			assert.Nil(mt, err, "SyncDocuments error: %v", err)
+			assert.Equal(mt, int64(3), res.UnchangedCount, "expected 3 unchanged documents, got %v", res.UnchangedCount)
+			assert.Nil(mt, res.BulkWrite, "expected no bulk write, got %v", res.BulkWrite)
+		})
+		mt.Run("scoped with key fields", func(mt *mtest.T) {
+			_, err := mt.Coll.InsertMany(mtest.Background, []interface{}{
+				bson.D{{"kind", "color"}, {"code", "red"}, {"hex", "f00"}},
+				bson.D{{"kind", "color"}, {"code", "blue"}, {"hex", "00e"}},
+				bson.D{{"kind", "size"}, {"code", "xl"}},
+			})
+			assert.Nil(mt, err, "InsertMany error: %v", err)
+
+			opts := options.Sync().SetKeyFields("kind", "code").SetScope(bson.D{{"kind", "color"}})
+			res, err := mt.Coll.SyncDocuments(mtest.Background, []interface{}{
+				bson.D{{"kind", "color"}, {"code", "red"}, {"hex", "f00"}},
+				bson.D{{"kind", "color"}, {"code", "blue"}, {"hex", "00f"}},
+			}, opts)
+			assert.Nil(mt, err, "SyncDocuments error: %v", err)
+			assert.Equal(mt, int64(1), res.UnchangedCount, "expected 1 unchanged document, got %v", res.UnchangedCount)
+			assert.Equal(mt, int64(1), res.ReplacedCount, "expected 1 replaced document, got %v", res.ReplacedCount)
+			assert.Equal(mt, int64(0), res.DeletedCount, "expected 0 deleted documents, got %v", res.DeletedCount)
+
+			count, err := mt.Coll.CountDocuments(mtest.Background, bson.D{{"kind", "size"}})
+			assert.Nil(mt, err, "CountDocuments error: %v", err)
+			assert.Equal(mt, int64(1), count, "expected document outside the scope to be kept, got count %v", count)
+		})
+		mt.Run("duplicate keys", func(mt *mtest.T) {
+			_, err := mt.Coll.SyncDocuments(mtest.Background, []interface{}{bson.D{{"_id", 1}}, bson.D{{"_id", 1}}})
+			assert.NotNil(mt, err, "expected SyncDocuments error, got nil")
+		})
+		mt.Run("partial failure", func(mt *mtest.T) {
+			_, err := mt.Coll.Indexes().CreateOne(mtest.Background, mongo.IndexModel{
+				Keys:    bson.D{{"code", 1}},
+				Options: options.Index().SetUnique(true),
+			})
+			assert.Nil(mt, err, "CreateOne error: %v", err)
+			_, err = mt.Coll.InsertMany(mtest.Background, []interface{}{
+				bson.D{{"_id", 1}, {"code", "a"}},
+				bson.D{{"_id", 9}, {"code", "z"}},
+			})
+			assert.Nil(mt, err, "InsertMany error: %v", err)
+
+			res, err := mt.Coll.SyncDocuments(mtest.Background, []interface{}{
+				bson.D{{"_id", 1}, {"code", "a"}},
+				bson.D{{"_id", 2}, {"code", "x"}},
+				bson.D{{"_id", 3}, {"code", "x"}},
+			})
+			_, ok := err.(mongo.BulkWriteException)
+			assert.True(mt, ok, "expected error type %T, got %T", mongo.BulkWriteException{}, err)
+			assert.NotNil(mt, res, "expected partial result, got nil")
+			expected := &mongo.SyncResult{InsertedCount: 1, DeletedCount: 1, UnchangedCount: 1}
+			assert.NotNil(mt, res.BulkWrite, "expected bulk write result, got nil")
+			res.BulkWrite = nil
+			assert.Equal(mt, expected, res, "expected result %v, got %v", expected, res)
+		})
+	})
+	mt.RunOpts("paginator", noClientOpts, func(mt *mtest.T) {
+		docs := []interface{}{
+			bson.D{{"_id", 1}, {"score", 10}},
+			bson.D{{"_id", 2}, {"score", 30}},
+			bson.D{{"_id", 3}, {"score", 20}},
+			bson.D{{"_id", 4}, {"score", 20}},
+			bson.D{{"_id", 5}, {"score", 20}},
+			bson.D{{"_id", 6}, {"score", 5}, {"hidden", true}},
+		}
+		_, err := mt.Coll.InsertMany(mtest.Background, docs)
+		assert.Nil(mt, err, "InsertMany error: %v", err)
+
+		opts := options.Paginator().SetSort(bson.D{{"score", -1}}).SetPageSize(2)
+		p, err := mongo.NewPaginator(mt.Coll, bson.D{{"hidden", bson.D{{"$exists", false}}}}, opts)
+		assert.Nil(mt, err, "NewPaginator error: %v", err)
+
+		var ids []int32
+		var token string
+		var pages int
+		for {
+			page, err := p.Page(mtest.Background, token)
+			assert.Nil(mt, err, "Page error: %v", err)
+			var results []struct {
+				ID int32 `bson:"_id"`
+			}
+			err = page.All(&results)
+			assert.Nil(mt, err, "All error: %v", err)
+			for _, res := range results {
+				ids = append(ids, res.ID)
+			}
+			pages++
+			if page.NextToken == "" {
+				break
+			}
+			token = page.NextToken
+		}
+		expected := []int32{2, 3, 4, 5, 1}
+		assert.Equal(mt, expected, ids, "expected ids %v, got %v", expected, ids)
+		assert.Equal(mt, 3, pages, "expected 3 pages, got %v", pages)
+	})
+	mt.RunOpts("explain", noClientOpts, func(mt *mtest.T) {
+		testCases := []struct {
+			name string
+			op   mongo.Explainable
+			cmd  string
+		}{
+			{"find", mongo.ExplainFind(bson.D{{"x", bson.D{{"$gt", 2}}}}), "find"},
+			{"aggregate", mongo.ExplainAggregate(mongo.Pipeline{{{"$match", bson.D{{"x", 1}}}}}), "aggregate"},
+			{"distinct", mongo.ExplainDistinct("x", bson.D{}), "distinct"},
+		}
+		for _, tc := range testCases {
+			mt.Run(tc.name, func(mt *mtest.T) {
+				initCollection(mt, mt.Coll)
+				mt.ClearEvents()
+				opts := options.Explain().SetVerbosity(options.ExecutionStats)
+				res, err := mt.Coll.Explain(mtest.Background, tc.op, opts)
+				assert.Nil(mt, err, "Explain error: %v", err)
+				assert.NotNil(mt, res.WinningPlan, "expected winning plan in explain output %v", res.Raw)
+				assert.NotNil(mt, res.ExecutionStats, "expected execution stats in explain output %v", res.Raw)
+
+				evt := mt.GetStartedEvent()
+				assert.Equal(mt, "explain", evt.CommandName, "expected command explain, got %v", evt.CommandName)
+				explained, ok := evt.Command.Lookup("explain").DocumentOK()
+				assert.True(mt, ok, "expected explain document in command %v", evt.Command)
+				name := explained.Index(0).Key()
+				assert.Equal(mt, tc.cmd, name, "expected explained command %v, got %v", tc.cmd, name)
+				verbosity := evt.Command.Lookup("verbosity").StringValue()
+				assert.Equal(mt, "executionStats", verbosity, "expected verbosity executionStats, got %v", verbosity)
+			})
+		}
 	})
 	mt.RunOpts("find", noClientOpts, func(mt *mtest.T) {
 		mt.Run("found", func(mt *mtest.T) {
@@ -1054,6 +1326,43 @@ func TestCollection(t *testing.T) {
 				})
 			}
 		})
+		defaultsOpts := mtest.NewOptions().ClientOptions(options.Client().
+			SetDefaultFindLimit(2).
+			SetDefaultFindMaxTime(time.Second))
+		mt.RunOpts("client defaults", defaultsOpts, func(mt *mtest.T) {
+			testCases := []struct {
+				name          string
+				opts          *options.FindOptions
+				expectedLimit interface{}
+				expectedMaxMS interface{}
+			}{
+				{"applied", options.Find(), int64(2), int64(1000)},
+				{"explicit values", options.Find().SetLimit(3).SetMaxTime(2 * time.Second), int64(3), int64(2000)},
+				{"unbounded", options.Find().SetUnbounded(true), nil, nil},
+			}
+			for _, tc := range testCases {
+				mt.Run(tc.name, func(mt *mtest.T) {
+					initCollection(mt, mt.Coll)
+					mt.ClearEvents()
+					cursor, err := mt.Coll.Find(mtest.Background, bson.D{}, tc.opts)
+					assert.Nil(mt, err, "Find error: %v", err)
+					_ = cursor.Close(mtest.Background)
+
+					started := mt.GetStartedEvent()
+					assert.NotNil(mt, started, "expected CommandStartedEvent, got nil")
+					for key, expected := range map[string]interface{}{"limit": tc.expectedLimit, "maxTimeMS": tc.expectedMaxMS} {
+						val, err := started.Command.LookupErr(key)
+						if expected == nil {
+							assert.NotNil(mt, err, "expected %v to be omitted, got %v", key, val)
+							continue
+						}
+						assert.Nil(mt, err, "%v not found in command", key)
+						got := val.AsInt64()
+						assert.Equal(mt, expected, got, "expected %v %v, got %v", key, expected, got)
+					}
+				})
+			}
+		})
 	})
 	mt.RunOpts("find one", noClientOpts, func(mt *mtest.T) {
 		mt.Run("limit", func(mt *mtest.T) {
@@ -1446,6 +1755,21 @@ func TestCollection(t *testing.T) {
 				})
 			}
 		})
+		mt.Run("raw replies", func(mt *mtest.T) {
+			models := []mongo.WriteModel{
+				mongo.NewInsertOneModel().SetDocument(bson.D{{"x", 1}}),
+				mongo.NewUpdateOneModel().SetFilter(bson.D{{"x", 1}}).SetUpdate(bson.D{{"$inc", bson.D{{"x", 1}}}}),
+				mongo.NewDeleteOneModel().SetFilter(bson.D{{"x", 2}}),
+			}
+			res, err := mt.Coll.BulkWrite(mtest.Background, models)
+			assert.Nil(mt, err, "BulkWrite error: %v", err)
+			assert.Equal(mt, 3, len(res.RawReplies), "expected 3 raw replies, got %v", len(res.RawReplies))
+			for _, reply := range res.RawReplies {
+				n, ok := reply.Lookup("n").Int32OK()
+				assert.True(mt, ok, "expected n in raw reply %v", reply)
+				assert.Equal(mt, int32(1), n, "expected n 1, got %v", n)
+			}
+		})
 
 		mt.RunOpts("insert write errors", noClientOpts, func(mt *mtest.T) {
 			doc1 := mongo.NewInsertOneModel().SetDocument(bson.D{{"_id", "x"}})
@@ -166,6 +166,82 @@ func TestCursor(t *testing.T) {
 			assertCursorBatchLength(mt, cursor, len(getMoreBatch)-1)
 		})
 	})
+	mt.RunOpts("exhaust", mtest.NewOptions().MinServerVersion("4.2").CollectionCreateOptions(cappedCollectionOpts), func(mt *mtest.T) {
+		// Only the first getMore of a tailable await exhaust cursor should be sent. The server streams the following
+		// batches on the pinned connection.
+
+		_, err := mt.Coll.InsertOne(mtest.Background, bson.D{{"x", 1}})
+		assert.Nil(mt, err, "InsertOne error: %v", err)
+
+		findOpts := options.Find().
+			SetCursorType(options.TailableAwait).
+			SetMaxAwaitTime(100 * time.Millisecond).
+			SetExhaust(true)
+		cursor, err := mt.Coll.Find(mtest.Background, bson.D{}, findOpts)
+		assert.Nil(mt, err, "Find error: %v", err)
+		defer cursor.Close(mtest.Background)
+		assert.True(mt, cursor.Next(mtest.Background), "expected Next to return true; cursor err: %v", cursor.Err())
+
+		mt.ClearEvents()
+		for i := 0; i < 3; i++ {
+			assert.False(mt, cursor.TryNext(mtest.Background), "unexpected document %v", cursor.Current)
+			assert.Nil(mt, cursor.Err(), "cursor error: %v", cursor.Err())
+		}
+		_, err = mt.Coll.InsertOne(mtest.Background, bson.D{{"x", 2}})
+		assert.Nil(mt, err, "InsertOne error: %v", err)
+		assert.True(mt, cursor.Next(mtest.Background), "expected Next to return true; cursor err: %v", cursor.Err())
+
+		var getMores int
+		for evt := mt.GetStartedEvent(); evt != nil; evt = mt.GetStartedEvent() {
+			if evt.CommandName == "getMore" {
+				getMores++
+			}
+		}
+		assert.Equal(mt, 1, getMores, "expected 1 getMore to be sent, got %v", getMores)
+		stats := cursor.Stats()
+		assert.True(mt, stats.GetMoreCount >= 4, "expected at least 4 batches, got %v", stats.GetMoreCount)
+	})
+	mt.RunOpts("max await time", mtest.NewOptions().ClientType(mtest.Mock), func(mt *mtest.T) {
+		cursorID := int64(50)
+		ns := mt.DB.Name() + "." + mt.Coll.Name()
+		find := mtest.CreateCursorResponse(cursorID, ns, mtest.FirstBatch)
+		emptyGetMore := mtest.CreateCursorResponse(cursorID, ns, mtest.NextBatch)
+		getMore := mtest.CreateCursorResponse(cursorID, ns, mtest.NextBatch, bson.D{{"x", 1}})
+		killCursors := mtest.CreateSuccessResponse()
+		mt.AddMockResponses(find, emptyGetMore, getMore, killCursors)
+
+		findOpts := options.Find().
+			SetCursorType(options.TailableAwait).
+			SetMaxAwaitTime(100 * time.Millisecond)
+		cursor, err := mt.Coll.Find(mtest.Background, bson.D{}, findOpts)
+		assert.Nil(mt, err, "Find error: %v", err)
+		defer cursor.Close(mtest.Background)
+
+		assertGetMoreMaxTime := func(expected int64) {
+			mt.Helper()
+
+			evt := mt.GetStartedEvent()
+			assert.Equal(mt, "getMore", evt.CommandName, "expected command %q, got %q", "getMore", evt.CommandName)
+			got := evt.Command.Lookup("maxTimeMS").AsInt64()
+			assert.Equal(mt, expected, got, "expected maxTimeMS %v, got %v", expected, got)
+		}
+
+		mt.ClearEvents()
+		assert.False(mt, cursor.TryNext(mtest.Background), "unexpected document %v", cursor.Current)
+		assertGetMoreMaxTime(100)
+
+		cursor.SetMaxAwaitTime(250 * time.Millisecond)
+		assert.True(mt, cursor.TryNext(mtest.Background), "expected TryNext to return true; cursor err: %v", cursor.Err())
+		assertGetMoreMaxTime(250)
+
+		stats := cursor.Stats()
+		assert.Equal(mt, int64(2), stats.GetMoreCount, "expected 2 getMores, got %v", stats.GetMoreCount)
+		assert.Equal(mt, int64(1), stats.EmptyBatchCount, "expected 1 empty batch, got %v", stats.EmptyBatchCount)
+		assert.True(mt, stats.MaxDuration >= stats.LastDuration, "expected max duration %v to be at least last duration %v",
+			stats.MaxDuration, stats.LastDuration)
+		assert.True(mt, stats.TotalDuration >= stats.MaxDuration, "expected total duration %v to be at least max duration %v",
+			stats.TotalDuration, stats.MaxDuration)
+	})
 	mt.RunOpts("all", noClientOpts, func(mt *mtest.T) {
 		failpointOpts := mtest.NewOptions().Topologies(mtest.ReplicaSet).MinServerVersion("4.0")
 		mt.RunOpts("getMore error", failpointOpts, func(mt *mtest.T) {
@@ -356,6 +356,40 @@ func TestDatabase(t *testing.T) {
 				assert.Equal(mt, tc.numExpected, count, "expected document count %v, got %v", tc.numExpected, count)
 			})
 		}
+
+		optionsTestOpts := mtest.NewOptions().CollectionName("runcommandcursor_opts").MinServerVersion("4.4")
+		mt.RunOpts("cursor options", optionsTestOpts, func(mt *mtest.T) {
+			_, err := mt.Coll.InsertMany(mtest.Background, data)
+			assert.Nil(mt, err, "InsertMany error: %v", err)
+
+			cmd := bson.D{{"find", mt.Coll.Name()}, {"batchSize", 2}}
+			opts := options.RunCmd().SetBatchSize(2).SetComment("runcommandcursor comment")
+			cursor, err := mt.DB.RunCommandCursor(mtest.Background, cmd, opts)
+			assert.Nil(mt, err, "RunCommandCursor error: %v", err)
+			defer cursor.Close(mtest.Background)
+
+			firstBatch := cursor.FirstBatch()
+			assert.Equal(mt, 2, len(firstBatch), "expected first batch length 2, got %v", len(firstBatch))
+
+			mt.ClearEvents()
+			var count int
+			for cursor.Next(mtest.Background) {
+				count++
+			}
+			assert.Nil(mt, cursor.Err(), "cursor error: %v", cursor.Err())
+			assert.Equal(mt, len(data), count, "expected document count %v, got %v", len(data), count)
+			assert.Equal(mt, 2, len(firstBatch), "expected first batch to be retained, got length %v", len(firstBatch))
+
+			evt := mt.GetStartedEvent()
+			assert.Equal(mt, "getMore", evt.CommandName, "expected command 'getMore', got '%v'", evt.CommandName)
+			batchSize, ok := evt.Command.Lookup("batchSize").Int32OK()
+			assert.True(mt, ok, "expected getMore batchSize to be an int32, got %v", evt.Command.Lookup("batchSize"))
+			assert.Equal(mt, int32(2), batchSize, "expected getMore batchSize 2, got %v", batchSize)
+			comment, ok := evt.Command.Lookup("comment").StringValueOK()
+			assert.True(mt, ok, "expected getMore comment to be a string, got %v", evt.Command.Lookup("comment"))
+			assert.Equal(mt, "runcommandcursor comment", comment, "expected comment %q, got %q",
+				"runcommandcursor comment", comment)
+		})
 	})
 
 	mt.RunOpts("create collection", noClientOpts, func(mt *mtest.T) {
@@ -453,6 +487,36 @@ func TestDatabase(t *testing.T) {
 			collation := collationVal.(bson.M)
 			assert.Equal(mt, locale, collation["locale"], "expected locale %v, got %v", locale, collation["locale"])
 		})
+		mt.RunOpts("time series", mtest.NewOptions().MinServerVersion("5.0"), func(mt *mtest.T) {
+			mt.CreateCollection(mtest.Collection{
+				Name: collectionName,
+			}, false)
+
+			tsOpts := options.TimeSeries().
+				SetTimeField("ts").
+				SetMetaField("meta").
+				SetGranularity("minutes")
+			createOpts := options.CreateCollection().
+				SetTimeSeriesOptions(tsOpts).
+				SetExpireAfterSeconds(3600)
+			err := mt.DB.CreateCollection(mtest.Background, collectionName, createOpts)
+			assert.Nil(mt, err, "CreateCollection error: %v", err)
+
+			actualOpts := getCollectionOptions(mt, collectionName)
+			timeseries, ok := actualOpts["timeseries"].(bson.M)
+			assert.True(mt, ok, "expected key 'timeseries' in collection options %v", actualOpts)
+			for key, expected := range map[string]string{"timeField": "ts", "metaField": "meta", "granularity": "minutes"} {
+				assert.Equal(mt, expected, timeseries[key], "expected %v to be %v, got %v", key, expected, timeseries[key])
+			}
+
+			isTimeSeries, err := mt.DB.Collection(collectionName).IsTimeSeries(mtest.Background)
+			assert.Nil(mt, err, "IsTimeSeries error: %v", err)
+			assert.True(mt, isTimeSeries, "expected collection %v to be a time series collection", collectionName)
+
+			isTimeSeries, err = mt.Coll.IsTimeSeries(mtest.Background)
+			assert.Nil(mt, err, "IsTimeSeries error: %v", err)
+			assert.False(mt, isTimeSeries, "expected collection %v not to be a time series collection", mt.Coll.Name())
+		})
 		mt.Run("write concern", func(mt *mtest.T) {
 			mt.CreateCollection(mtest.Collection{
 				Name: collectionName,
@@ -513,6 +577,51 @@ func TestDatabase(t *testing.T) {
 			assert.Equal(mt, locale, collation["locale"], "expected locale %v, got %v", locale, collation["locale"])
 		})
 	})
+	mt.RunOpts("modify collection", mtest.NewOptions().CreateClient(false).MinServerVersion("3.2"), func(mt *mtest.T) {
+		collectionName := "modify-collection-test"
+
+		mt.Run("validator", func(mt *mtest.T) {
+			mt.CreateCollection(mtest.Collection{
+				Name: collectionName,
+			}, true)
+
+			validator := bson.M{"x": bson.M{"$exists": true}}
+			modifyOpts := options.ModifyCollection().
+				SetValidator(validator).
+				SetValidationAction("warn").
+				SetValidationLevel("moderate")
+			err := mt.DB.ModifyCollection(mtest.Background, collectionName, modifyOpts)
+			assert.Nil(mt, err, "ModifyCollection error: %v", err)
+
+			expectedOpts := bson.M{
+				"validator":        validator,
+				"validationAction": "warn",
+				"validationLevel":  "moderate",
+			}
+			actualOpts := getCollectionOptions(mt, collectionName)
+			assert.Equal(mt, expectedOpts, actualOpts, "options mismatch; expected %v, got %v", expectedOpts,
+				actualOpts)
+		})
+		mt.RunOpts("hide index", mtest.NewOptions().MinServerVersion("4.4"), func(mt *mtest.T) {
+			mt.CreateCollection(mtest.Collection{
+				Name: collectionName,
+			}, true)
+
+			coll := mt.DB.Collection(collectionName)
+			name, err := coll.Indexes().CreateOne(mtest.Background, mongo.IndexModel{Keys: bson.D{{"x", 1}}})
+			assert.Nil(mt, err, "CreateOne error: %v", err)
+
+			mt.ClearEvents()
+			modifyOpts := options.ModifyCollection().SetIndex(options.ModifyIndex().SetName(name).SetHidden(true))
+			err = mt.DB.ModifyCollection(mtest.Background, collectionName, modifyOpts)
+			assert.Nil(mt, err, "ModifyCollection error: %v", err)
+
+			evt := mt.GetStartedEvent()
+			assert.Equal(mt, "collMod", evt.CommandName, "expected event for 'collMod', got '%v'", evt.CommandName)
+			hidden, ok := evt.Command.Lookup("index", "hidden").BooleanOK()
+			assert.True(mt, ok && hidden, "expected index.hidden to be true in command %v", evt.Command)
+		})
+	})
 }
 
 func getCollectionOptions(mt *mtest.T, collectionName string) bson.M {
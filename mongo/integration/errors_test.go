@@ -185,7 +185,7 @@ func TestErrors(t *testing.T) {
 			{
 				"WriteException all in writeConcernError",
 				mongo.WriteException{
-					&mongo.WriteConcernError{"name", matchCode, "foo", nil},
+					&mongo.WriteConcernError{"name", matchCode, "foo", nil, nil},
 					nil,
 					[]string{label},
 				},
@@ -214,7 +214,7 @@ func TestErrors(t *testing.T) {
 			{
 				"WriteException all false",
 				mongo.WriteException{
-					&mongo.WriteConcernError{"name", otherCode, "bar", nil},
+					&mongo.WriteConcernError{"name", otherCode, "bar", nil, nil},
 					mongo.WriteErrors{
 						mongo.WriteError{0, otherCode, "baz"},
 					},
@@ -229,7 +229,7 @@ func TestErrors(t *testing.T) {
 			{
 				"WriteException HasErrorCodeAndMessage false",
 				mongo.WriteException{
-					&mongo.WriteConcernError{"name", matchCode, "bar", nil},
+					&mongo.WriteConcernError{"name", matchCode, "bar", nil, nil},
 					mongo.WriteErrors{
 						mongo.WriteError{0, otherCode, "foo"},
 					},
@@ -244,7 +244,7 @@ func TestErrors(t *testing.T) {
 			{
 				"BulkWriteException all in writeConcernError",
 				mongo.BulkWriteException{
-					&mongo.WriteConcernError{"name", matchCode, "foo", nil},
+					&mongo.WriteConcernError{"name", matchCode, "foo", nil, nil},
 					nil,
 					[]string{label},
 				},
@@ -273,7 +273,7 @@ func TestErrors(t *testing.T) {
 			{
 				"BulkWriteException all false",
 				mongo.BulkWriteException{
-					&mongo.WriteConcernError{"name", otherCode, "bar", nil},
+					&mongo.WriteConcernError{"name", otherCode, "bar", nil, nil},
 					[]mongo.BulkWriteError{
 						{mongo.WriteError{0, otherCode, "baz"}, &mongo.InsertOneModel{}},
 					},
@@ -288,7 +288,7 @@ func TestErrors(t *testing.T) {
 			{
 				"BulkWriteException HasErrorCodeAndMessage false",
 				mongo.BulkWriteException{
-					&mongo.WriteConcernError{"name", matchCode, "bar", nil},
+					&mongo.WriteConcernError{"name", matchCode, "bar", nil, nil},
 					[]mongo.BulkWriteError{
 						{mongo.WriteError{0, otherCode, "foo"}, &mongo.InsertOneModel{}},
 					},
@@ -335,7 +335,7 @@ func TestErrors(t *testing.T) {
 				{
 					"WriteException true in writeConcernError",
 					mongo.WriteException{
-						&mongo.WriteConcernError{"name", 11001, "bar", nil},
+						&mongo.WriteConcernError{"name", 11001, "bar", nil, nil},
 						mongo.WriteErrors{
 							mongo.WriteError{0, 100, "baz"},
 						},
@@ -346,7 +346,7 @@ func TestErrors(t *testing.T) {
 				{
 					"WriteException true in writeErrors",
 					mongo.WriteException{
-						&mongo.WriteConcernError{"name", 100, "bar", nil},
+						&mongo.WriteConcernError{"name", 100, "bar", nil, nil},
 						mongo.WriteErrors{
 							mongo.WriteError{0, 12582, "baz"},
 						},
@@ -357,7 +357,7 @@ func TestErrors(t *testing.T) {
 				{
 					"WriteException false",
 					mongo.WriteException{
-						&mongo.WriteConcernError{"name", 16460, "bar", nil},
+						&mongo.WriteConcernError{"name", 16460, "bar", nil, nil},
 						mongo.WriteErrors{
 							mongo.WriteError{0, 100, "blah  E11000 blah"},
 						},
@@ -368,7 +368,7 @@ func TestErrors(t *testing.T) {
 				{
 					"BulkWriteException true",
 					mongo.BulkWriteException{
-						&mongo.WriteConcernError{"name", 100, "bar", nil},
+						&mongo.WriteConcernError{"name", 100, "bar", nil, nil},
 						[]mongo.BulkWriteError{
 							{mongo.WriteError{0, 16460, "blah  E11000 blah"}, &mongo.InsertOneModel{}},
 						},
@@ -379,7 +379,7 @@ func TestErrors(t *testing.T) {
 				{
 					"BulkWriteException false",
 					mongo.BulkWriteException{
-						&mongo.WriteConcernError{"name", 100, "bar", nil},
+						&mongo.WriteConcernError{"name", 100, "bar", nil, nil},
 						[]mongo.BulkWriteError{
 							{mongo.WriteError{0, 110, "blah"}, &mongo.InsertOneModel{}},
 						},
@@ -441,5 +441,36 @@ func TestErrors(t *testing.T) {
 				})
 			}
 		})
+		mt.Run("error labels", func(mt *mtest.T) {
+			labels := []string{mongo.TransientTransactionErrorLabel, mongo.RetryableWriteErrorLabel}
+			wce := &mongo.WriteConcernError{Name: "WriteConcernFailed", Code: 64, Labels: labels}
+			testCases := []struct {
+				name   string
+				err    error
+				result bool
+			}{
+				{"CommandError", mongo.CommandError{Labels: labels}, true},
+				{"WriteException", mongo.WriteException{WriteConcernError: wce, Labels: labels}, true},
+				{"BulkWriteException", mongo.BulkWriteException{WriteConcernError: wce, Labels: labels}, true},
+				{"wrapped error", wrappedError{mongo.CommandError{Labels: labels}}, true},
+				{"other labels", mongo.CommandError{Labels: []string{mongo.NetworkErrorLabel}}, false},
+				{"other error", errors.New("foo"), false},
+			}
+			for _, tc := range testCases {
+				mt.Run(tc.name, func(mt *mtest.T) {
+					res := mongo.IsTransient(tc.err)
+					assert.Equal(mt, tc.result, res, "expected IsTransient %v, got %v", tc.result, res)
+					res = mongo.IsRetryableWriteError(tc.err)
+					assert.Equal(mt, tc.result, res, "expected IsRetryableWriteError %v, got %v", tc.result, res)
+					res = mongo.IsUnknownTransactionCommitResult(tc.err)
+					assert.False(mt, res, "expected IsUnknownTransactionCommitResult false, got true")
+				})
+			}
+
+			assert.True(mt, wce.HasErrorLabel(mongo.TransientTransactionErrorLabel),
+				"expected write concern error to have label %q", mongo.TransientTransactionErrorLabel)
+			assert.False(mt, wce.HasErrorLabel(mongo.NoWritesPerformedLabel),
+				"expected write concern error not to have label %q", mongo.NoWritesPerformedLabel)
+		})
 	})
 }
@@ -518,6 +518,52 @@ func TestIndexView(t *testing.T) {
 		}
 		assert.Nil(mt, cursor.Err(), "cursor error: %v", cursor.Err())
 	})
+	mt.Run("ensure indexes", func(mt *mtest.T) {
+		iv := mt.Coll.Indexes()
+		_, err := iv.CreateOne(mtest.Background, mongo.IndexModel{Keys: bson.D{{"extra", 1}}})
+		assert.Nil(mt, err, "CreateOne error: %v", err)
+
+		models := []mongo.IndexModel{
+			mongo.NewIndexModel().Ascending("foo").Build(),
+			mongo.NewIndexModel().Ascending("bar").Descending("baz").Build(),
+		}
+		opts := options.EnsureIndexes().SetDropExtra(true)
+		plan, err := iv.EnsureIndexes(mtest.Background, models, opts)
+		assert.Nil(mt, err, "EnsureIndexes error: %v", err)
+		assert.Equal(mt, 2, len(plan.Create), "expected 2 indexes to create, got %v", len(plan.Create))
+		assert.Equal(mt, []string{"extra_1"}, plan.Drop, "expected indexes to drop [extra_1], got %v", plan.Drop)
+
+		specs, err := iv.ListSpecifications(mtest.Background)
+		assert.Nil(mt, err, "ListSpecifications error: %v", err)
+		var names []string
+		for _, spec := range specs {
+			names = append(names, spec.Name)
+		}
+		assert.Equal(mt, []string{"_id_", "foo_1", "bar_1_baz_-1"}, names,
+			"expected indexes [_id_ foo_1 bar_1_baz_-1], got %v", names)
+
+		plan, err = iv.EnsureIndexes(mtest.Background, models, opts)
+		assert.Nil(mt, err, "EnsureIndexes error: %v", err)
+		assert.Equal(mt, 0, len(plan.Create), "expected no indexes to create, got %v", len(plan.Create))
+		assert.Equal(mt, 0, len(plan.Drop), "expected no indexes to drop, got %v", len(plan.Drop))
+		assert.Equal(mt, []string{"foo_1", "bar_1_baz_-1"}, plan.Unchanged,
+			"expected unchanged indexes [foo_1 bar_1_baz_-1], got %v", plan.Unchanged)
+
+		models[0] = mongo.NewIndexModel().Ascending("foo").Options(options.Index().SetUnique(true)).Build()
+		plan, err = iv.EnsureIndexes(mtest.Background, models, opts)
+		assert.Nil(mt, err, "EnsureIndexes error: %v", err)
+		assert.Equal(mt, 1, len(plan.Replace), "expected 1 index to replace, got %v", len(plan.Replace))
+		assert.Equal(mt, []string{"bar_1_baz_-1"}, plan.Unchanged,
+			"expected unchanged indexes [bar_1_baz_-1], got %v", plan.Unchanged)
+
+		specs, err = iv.ListSpecifications(mtest.Background)
+		assert.Nil(mt, err, "ListSpecifications error: %v", err)
+		for _, spec := range specs {
+			if spec.Name == "foo_1" {
+				assert.True(mt, spec.Unique != nil && *spec.Unique, "expected foo_1 to be unique, got %v", spec.Unique)
+			}
+		}
+	})
 }
 
 func getIndexDoc(mt *mtest.T, iv mongo.IndexView, expectedKeyDoc bson.D) bson.D {
@@ -27,6 +27,13 @@ func DocumentToRawValue(doc bson.Raw) bson.RawValue {
 	}
 }
 
+func int64ToRawValue(n int64) bson.RawValue {
+	return bson.RawValue{
+		Type:  bsontype.Int64,
+		Value: bsoncore.AppendInt64(nil, n),
+	}
+}
+
 func RemoveFieldsFromDocument(doc bson.Raw, keys ...string) bson.Raw {
 	newDoc := bsoncore.NewDocumentBuilder()
 	elems, _ := doc.Elements()
@@ -11,6 +11,8 @@ import (
 
 	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/mongo/options"
+	"go.mongodb.org/mongo-driver/mongo/readconcern"
+	"go.mongodb.org/mongo-driver/mongo/writeconcern"
 )
 
 // GridFSBucketOptions is a wrapper for *options.BucketOptions. This type implements the bson.Unmarshaler interface to
@@ -23,12 +25,12 @@ var _ bson.Unmarshaler = (*GridFSBucketOptions)(nil)
 
 func (bo GridFSBucketOptions) UnmarshalBSON(data []byte) error {
 	var temp struct {
-		Name      *string                `bson:"name"`
-		ChunkSize *int32                 `bson:"chunkSizeBytes"`
-		RC        *readConcern           `bson:"readConcern"`
-		RP        *readPreference        `bson:"readPreference"`
-		WC        *writeConcern          `bson:"writeConcern"`
-		Extra     map[string]interface{} `bson:",inline"`
+		Name      *string                    `bson:"name"`
+		ChunkSize *int32                     `bson:"chunkSizeBytes"`
+		RC        *readconcern.ReadConcern   `bson:"readConcern"`
+		RP        *readPreference            `bson:"readPreference"`
+		WC        *writeconcern.WriteConcern `bson:"writeConcern"`
+		Extra     map[string]interface{}     `bson:",inline"`
 	}
 	if err := bson.Unmarshal(data, &temp); err != nil {
 		return fmt.Errorf("error unmarshalling to temporary GridFSBucketOptions object: %v", err)
@@ -45,7 +47,7 @@ func (bo GridFSBucketOptions) UnmarshalBSON(data []byte) error {
 		bo.SetChunkSizeBytes(*temp.ChunkSize)
 	}
 	if temp.RC != nil {
-		bo.SetReadConcern(temp.RC.toReadConcernOption())
+		bo.SetReadConcern(temp.RC)
 	}
 	if temp.RP != nil {
 		rp, err := temp.RP.toReadPrefOption()
@@ -55,11 +57,7 @@ func (bo GridFSBucketOptions) UnmarshalBSON(data []byte) error {
 		bo.SetReadPreference(rp)
 	}
 	if temp.WC != nil {
-		wc, err := temp.WC.toWriteConcernOption()
-		if err != nil {
-			return fmt.Errorf("error parsing write concern document: %v", err)
-		}
-		bo.SetWriteConcern(wc)
+		bo.SetWriteConcern(temp.WC)
 	}
 	return nil
 }
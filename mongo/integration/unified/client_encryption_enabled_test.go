@@ -0,0 +1,12 @@
+// Copyright (C) MongoDB, Inc. 2021-present.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
+
+// +build cse
+
+package unified
+
+// cseEnabled is true because the tests are built with the cse tag, which enables client-side encryption.
+const cseEnabled = true
@@ -0,0 +1,157 @@
+// Copyright (C) MongoDB, Inc. 2021-present.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
+
+package unified
+
+import (
+	"encoding/base64"
+	"fmt"
+	"os"
+
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+// localMasterKey is the base64-encoded 96-byte key used for the "local" KMS provider in the client-side encryption
+// specification tests.
+const localMasterKey = "Mng0NCt4ZHVUYUJCa1kxNkVyNUR1QURhZ2h2UzR2d2RrZzh0cFBwM3R6NmdWMDFBMUN3YkQ5aXRRMkhGRGdQV09wOGVNYUMxT2k3NjZKelhaQmRCZGJkTXVyZG9uSjFk"
+
+// kmsCredentialVariables maps each supported KMS provider to its credential fields and the environment variables used
+// to replace $$placeholder values for those fields.
+var kmsCredentialVariables = map[string]map[string]string{
+	"aws": {
+		"accessKeyId":     "AWS_ACCESS_KEY_ID",
+		"secretAccessKey": "AWS_SECRET_ACCESS_KEY",
+	},
+	"azure": {
+		"tenantId":     "AZURE_TENANT_ID",
+		"clientId":     "AZURE_CLIENT_ID",
+		"clientSecret": "AZURE_CLIENT_SECRET",
+	},
+	"gcp": {
+		"email":      "GCP_EMAIL",
+		"privateKey": "GCP_PRIVATE_KEY",
+	},
+	"local": {
+		"key": "",
+	},
+}
+
+// ClientEncryptionOptions represents the options used to create a clientEncryption entity. The key vault client is
+// referenced by entity ID, so it is resolved by the EntityMap when the entity is created.
+type ClientEncryptionOptions struct {
+	KeyVaultClientID  string                            `bson:"keyVaultClient"`
+	KeyVaultNamespace string                            `bson:"keyVaultNamespace"`
+	KmsProviders      map[string]map[string]interface{} `bson:"-"`
+}
+
+var _ bson.Unmarshaler = (*ClientEncryptionOptions)(nil)
+
+func (ceo *ClientEncryptionOptions) UnmarshalBSON(data []byte) error {
+	var temp struct {
+		KeyVaultClientID  string                 `bson:"keyVaultClient"`
+		KeyVaultNamespace string                 `bson:"keyVaultNamespace"`
+		KmsProviders      bson.Raw               `bson:"kmsProviders"`
+		Extra             map[string]interface{} `bson:",inline"`
+	}
+	if err := bson.Unmarshal(data, &temp); err != nil {
+		return fmt.Errorf("error unmarshalling to temporary ClientEncryptionOptions object: %v", err)
+	}
+	if len(temp.Extra) > 0 {
+		return fmt.Errorf("unrecognized fields for ClientEncryptionOptions: %v", MapKeys(temp.Extra))
+	}
+	if temp.KeyVaultClientID == "" {
+		return fmt.Errorf("keyVaultClient must be specified for ClientEncryptionOptions")
+	}
+	if temp.KeyVaultNamespace == "" {
+		return fmt.Errorf("keyVaultNamespace must be specified for ClientEncryptionOptions")
+	}
+
+	kmsProviders, err := createKmsProviders(temp.KmsProviders)
+	if err != nil {
+		return fmt.Errorf("error parsing kmsProviders document: %v", err)
+	}
+
+	ceo.KeyVaultClientID = temp.KeyVaultClientID
+	ceo.KeyVaultNamespace = temp.KeyVaultNamespace
+	ceo.KmsProviders = kmsProviders
+	return nil
+}
+
+// createKmsProviders converts a kmsProviders document to the map expected by options.ClientEncryptionOptions. Fields
+// set to {"$$placeholder": 1} are replaced with the credentials for the test environment.
+func createKmsProviders(doc bson.Raw) (map[string]map[string]interface{}, error) {
+	kmsProviders := make(map[string]map[string]interface{})
+
+	providers, _ := doc.Elements()
+	for _, provider := range providers {
+		name := provider.Key()
+		variables, ok := kmsCredentialVariables[name]
+		if !ok {
+			return nil, fmt.Errorf("unrecognized KMS provider %q", name)
+		}
+
+		providerDoc, ok := provider.Value().DocumentOK()
+		if !ok {
+			return nil, fmt.Errorf("expected document for KMS provider %q, got %s", name, provider.Value().Type)
+		}
+
+		credentials := make(map[string]interface{})
+		fields, _ := providerDoc.Elements()
+		for _, field := range fields {
+			key := field.Key()
+			val := field.Value()
+
+			variable, ok := variables[key]
+			if !ok {
+				return nil, fmt.Errorf("unrecognized field %q for KMS provider %q", key, name)
+			}
+			if !isPlaceholder(val) {
+				credentials[key] = val
+				continue
+			}
+
+			credential, err := kmsCredential(name, variable)
+			if err != nil {
+				return nil, err
+			}
+			credentials[key] = credential
+		}
+		kmsProviders[name] = credentials
+	}
+	return kmsProviders, nil
+}
+
+// kmsCredential returns the value used to replace a $$placeholder for a credential of the given KMS provider.
+func kmsCredential(provider, variable string) (interface{}, error) {
+	if provider == "local" {
+		return base64.StdEncoding.DecodeString(localMasterKey)
+	}
+
+	credential := os.Getenv(variable)
+	if credential == "" {
+		return nil, fmt.Errorf("environment variable %s must be set to use KMS provider %q", variable, provider)
+	}
+	return credential, nil
+}
+
+func isPlaceholder(val bson.RawValue) bool {
+	doc, ok := val.DocumentOK()
+	if !ok {
+		return false
+	}
+	_, err := doc.LookupErr("$$placeholder")
+	return err == nil
+}
+
+// createsClientEncryption returns true if any of the entities is a clientEncryption entity.
+func createsClientEncryption(entities []map[string]*EntityOptions) bool {
+	for _, entity := range entities {
+		if _, ok := entity["clientEncryption"]; ok {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,173 @@
+// Copyright (C) MongoDB, Inc. 2021-present.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
+
+package unified
+
+import (
+	"context"
+	"fmt"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/bsontype"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"go.mongodb.org/mongo-driver/x/bsonx/bsoncore"
+)
+
+// This file contains helpers to execute clientEncryption operations.
+
+func executeCreateDataKey(ctx context.Context, operation *Operation) (*OperationResult, error) {
+	ce, err := Entities(ctx).ClientEncryption(operation.Object)
+	if err != nil {
+		return nil, err
+	}
+
+	var kmsProvider string
+	opts := options.DataKey()
+
+	elems, _ := operation.Arguments.Elements()
+	for _, elem := range elems {
+		key := elem.Key()
+		val := elem.Value()
+
+		switch key {
+		case "kmsProvider":
+			kmsProvider = val.StringValue()
+		case "opts":
+			if err := setDataKeyOptions(opts, val.Document()); err != nil {
+				return nil, err
+			}
+		default:
+			return nil, fmt.Errorf("unrecognized createDataKey option %q", key)
+		}
+	}
+	if kmsProvider == "" {
+		return nil, newMissingArgumentError("kmsProvider")
+	}
+
+	id, err := ce.CreateDataKey(ctx, kmsProvider, opts)
+	if err != nil {
+		return NewErrorResult(err), nil
+	}
+	return NewValueResult(bsontype.Binary, bsoncore.AppendBinary(nil, id.Subtype, id.Data), nil), nil
+}
+
+func executeEncrypt(ctx context.Context, operation *Operation) (*OperationResult, error) {
+	ce, err := Entities(ctx).ClientEncryption(operation.Object)
+	if err != nil {
+		return nil, err
+	}
+
+	var value *bson.RawValue
+	opts := options.Encrypt()
+
+	elems, _ := operation.Arguments.Elements()
+	for _, elem := range elems {
+		key := elem.Key()
+		val := elem.Value()
+
+		switch key {
+		case "value":
+			value = &val
+		case "opts":
+			if err := setEncryptOptions(opts, val.Document()); err != nil {
+				return nil, err
+			}
+		default:
+			return nil, fmt.Errorf("unrecognized encrypt option %q", key)
+		}
+	}
+	if value == nil {
+		return nil, newMissingArgumentError("value")
+	}
+
+	encrypted, err := ce.Encrypt(ctx, *value, opts)
+	if err != nil {
+		return NewErrorResult(err), nil
+	}
+	return NewValueResult(bsontype.Binary, bsoncore.AppendBinary(nil, encrypted.Subtype, encrypted.Data), nil), nil
+}
+
+func executeDecrypt(ctx context.Context, operation *Operation) (*OperationResult, error) {
+	ce, err := Entities(ctx).ClientEncryption(operation.Object)
+	if err != nil {
+		return nil, err
+	}
+
+	var value *primitive.Binary
+	elems, _ := operation.Arguments.Elements()
+	for _, elem := range elems {
+		key := elem.Key()
+		val := elem.Value()
+
+		switch key {
+		case "value":
+			subtype, data, ok := val.BinaryOK()
+			if !ok {
+				return nil, fmt.Errorf("expected binary value for decrypt, got %s", val.Type)
+			}
+			value = &primitive.Binary{Subtype: subtype, Data: data}
+		default:
+			return nil, fmt.Errorf("unrecognized decrypt option %q", key)
+		}
+	}
+	if value == nil {
+		return nil, newMissingArgumentError("value")
+	}
+
+	decrypted, err := ce.Decrypt(ctx, *value)
+	if err != nil {
+		return NewErrorResult(err), nil
+	}
+	return NewValueResult(decrypted.Type, decrypted.Value, nil), nil
+}
+
+func setDataKeyOptions(opts *options.DataKeyOptions, doc bson.Raw) error {
+	elems, _ := doc.Elements()
+	for _, elem := range elems {
+		key := elem.Key()
+		val := elem.Value()
+
+		switch key {
+		case "masterKey":
+			opts.SetMasterKey(val.Document())
+		case "keyAltNames":
+			values, err := val.Array().Values()
+			if err != nil {
+				return fmt.Errorf("error parsing keyAltNames array: %v", err)
+			}
+			keyAltNames := make([]string, 0, len(values))
+			for _, v := range values {
+				keyAltNames = append(keyAltNames, v.StringValue())
+			}
+			opts.SetKeyAltNames(keyAltNames)
+		default:
+			return fmt.Errorf("unrecognized createDataKey opts field %q", key)
+		}
+	}
+	return nil
+}
+
+func setEncryptOptions(opts *options.EncryptOptions, doc bson.Raw) error {
+	elems, _ := doc.Elements()
+	for _, elem := range elems {
+		key := elem.Key()
+		val := elem.Value()
+
+		switch key {
+		case "keyId":
+			subtype, data := val.Binary()
+			opts.SetKeyID(primitive.Binary{Subtype: subtype, Data: data})
+		case "keyAltName":
+			opts.SetKeyAltName(val.StringValue())
+		case "algorithm":
+			opts.SetAlgorithm(val.StringValue())
+		default:
+			return fmt.Errorf("unrecognized encrypt opts field %q", key)
+		}
+	}
+	return nil
+}
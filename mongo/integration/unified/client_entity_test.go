@@ -13,12 +13,14 @@ import (
 	"time"
 
 	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/bsontype"
 	"go.mongodb.org/mongo-driver/event"
 	"go.mongodb.org/mongo-driver/internal/testutil"
 	"go.mongodb.org/mongo-driver/mongo"
 	"go.mongodb.org/mongo-driver/mongo/integration/mtest"
 	"go.mongodb.org/mongo-driver/mongo/options"
 	"go.mongodb.org/mongo-driver/mongo/readconcern"
+	"go.mongodb.org/mongo-driver/mongo/writeconcern"
 )
 
 // ClientEntity is a wrapper for a mongo.Client object that also holds additional information required during test
@@ -31,9 +33,11 @@ type ClientEntity struct {
 	succeeded       []*event.CommandSucceededEvent
 	failed          []*event.CommandFailedEvent
 	ignoredCommands map[string]struct{}
+	logLevels       map[string]int
+	logMessages     []*logMessage
 }
 
-func NewClientEntity(ctx context.Context, entityOptions *EntityOptions) (*ClientEntity, error) {
+func NewClientEntity(ctx context.Context, em *EntityMap, entityOptions *EntityOptions) (*ClientEntity, error) {
 	entity := &ClientEntity{
 		// The "configureFailPoint" command should always be ignored.
 		ignoredCommands: map[string]struct{}{
@@ -58,12 +62,11 @@ func NewClientEntity(ctx context.Context, entityOptions *EntityOptions) (*Client
 			return nil, err
 		}
 	}
+	monitor := &event.CommandMonitor{}
 	if entityOptions.ObserveEvents != nil {
 		// Configure a command monitor that listens for the specified event types. We don't take the IgnoredCommands
 		// option into account here because it can be overridden at the test level after the entity has already been
 		// created, so we store the events for now but account for it when iterating over them later.
-		monitor := &event.CommandMonitor{}
-
 		for _, eventType := range entityOptions.ObserveEvents {
 			switch eventType {
 			case "commandStartedEvent":
@@ -76,6 +79,22 @@ func NewClientEntity(ctx context.Context, entityOptions *EntityOptions) (*Client
 				return nil, fmt.Errorf("unrecognized event type %s", eventType)
 			}
 		}
+	}
+	if entityOptions.ObserveLogMessages != nil {
+		// Log messages are derived from the command monitoring events, so they are captured by the same monitor.
+		if err := entity.observeLogMessages(monitor, entityOptions.ObserveLogMessages); err != nil {
+			return nil, err
+		}
+	}
+	if entityOptions.StoreEventsAsEntities != nil {
+		poolMonitor := &event.PoolMonitor{}
+		if err := storeEventsAsEntities(em, monitor, poolMonitor, entityOptions.StoreEventsAsEntities); err != nil {
+			return nil, err
+		}
+		clientOpts.SetPoolMonitor(poolMonitor)
+	}
+	if entityOptions.ObserveEvents != nil || entityOptions.ObserveLogMessages != nil ||
+		entityOptions.StoreEventsAsEntities != nil {
 		clientOpts.SetMonitor(monitor)
 	}
 	if entityOptions.ServerAPIOptions != nil {
@@ -160,10 +179,9 @@ func (c *ClientEntity) getRecordEvents() bool {
 }
 
 func setClientOptionsFromURIOptions(clientOpts *options.ClientOptions, uriOpts bson.M) error {
-	// A write concern can be constructed across multiple URI options (e.g. "w", "j", and "wTimeoutMS") so we declare an
-	// empty writeConcern instance here that can be populated in the loop below.
-	var wc writeConcern
-	var wcSet bool
+	// A write concern can be constructed across multiple URI options (e.g. "w", "j", and "wTimeoutMS") so we collect
+	// the fields in a document here and decode it after the loop below.
+	var wc bson.D
 
 	for key, value := range uriOpts {
 		switch key {
@@ -176,16 +194,19 @@ func setClientOptionsFromURIOptions(clientOpts *options.ClientOptions, uriOpts b
 		case "retryWrites":
 			clientOpts.SetRetryWrites(value.(bool))
 		case "w":
-			wc.W = value
-			wcSet = true
+			wc = append(wc, bson.E{Key: key, Value: value})
 		default:
 			return fmt.Errorf("unrecognized URI option %s", key)
 		}
 	}
 
-	if wcSet {
-		converted, err := wc.toWriteConcernOption()
+	if len(wc) > 0 {
+		doc, err := bson.Marshal(wc)
 		if err != nil {
+			return fmt.Errorf("error marshalling write concern: %v", err)
+		}
+		converted := new(writeconcern.WriteConcern)
+		if err := converted.UnmarshalBSONValue(bsontype.EmbeddedDocument, doc); err != nil {
 			return fmt.Errorf("error creating write concern: %v", err)
 		}
 		clientOpts.SetWriteConcern(converted)
@@ -11,53 +11,12 @@ import (
 	"time"
 
 	"go.mongodb.org/mongo-driver/bson"
-	"go.mongodb.org/mongo-driver/mongo/readconcern"
 	"go.mongodb.org/mongo-driver/mongo/readpref"
-	"go.mongodb.org/mongo-driver/mongo/writeconcern"
 	"go.mongodb.org/mongo-driver/tag"
 )
 
-// This file defines helper types to convert BSON documents to ReadConcern, WriteConcern, and ReadPref objects.
-
-type readConcern struct {
-	Level string `bson:"level"`
-}
-
-func (rc *readConcern) toReadConcernOption() *readconcern.ReadConcern {
-	return readconcern.New(readconcern.Level(rc.Level))
-}
-
-type writeConcern struct {
-	Journal    *bool       `bson:"journal"`
-	W          interface{} `bson:"w"`
-	WTimeoutMS *int32      `bson:"wtimeoutMS"`
-}
-
-func (wc *writeConcern) toWriteConcernOption() (*writeconcern.WriteConcern, error) {
-	var wcOptions []writeconcern.Option
-	if wc.Journal != nil {
-		wcOptions = append(wcOptions, writeconcern.J(*wc.Journal))
-	}
-	if wc.W != nil {
-		switch converted := wc.W.(type) {
-		case string:
-			if converted != "majority" {
-				return nil, fmt.Errorf("invalid write concern 'w' string value %q", converted)
-			}
-			wcOptions = append(wcOptions, writeconcern.WMajority())
-		case int32:
-			wcOptions = append(wcOptions, writeconcern.W(int(converted)))
-		default:
-			return nil, fmt.Errorf("invalid type for write concern 'w' field %T", wc.W)
-		}
-	}
-	if wc.WTimeoutMS != nil {
-		wTimeout := time.Duration(*wc.WTimeoutMS) * time.Millisecond
-		wcOptions = append(wcOptions, writeconcern.WTimeout(wTimeout))
-	}
-
-	return writeconcern.New(wcOptions...), nil
-}
+// This file defines helper types to convert BSON documents to ReadPref objects. ReadConcern and WriteConcern objects
+// are decoded directly because they implement bson.ValueUnmarshaler.
 
 type readPreference struct {
 	Mode                string              `bson:"mode"`
@@ -23,6 +23,8 @@ const (
 	failPointsKey ctxKey = "test-failpoints"
 	// targetedFailPointsKey is used to store a map from a fail point name to the host on which the fail point is set.
 	targetedFailPointsKey ctxKey = "test-targeted-failpoints"
+	// loopDoneKey is used to store a channel that is closed when loop operations should stop.
+	loopDoneKey ctxKey = "test-loop-done"
 )
 
 // NewTestContext creates a new Context derived from ctx with values initialized to store the state required for test
@@ -34,6 +36,17 @@ func NewTestContext(ctx context.Context) context.Context {
 	return ctx
 }
 
+// WithLoopDone returns a Context derived from ctx in which loop operations stop once done is closed.
+func WithLoopDone(ctx context.Context, done <-chan struct{}) context.Context {
+	return context.WithValue(ctx, loopDoneKey, done)
+}
+
+// LoopDone returns the channel that stops loop operations, or nil if loop operations only stop when ctx is done.
+func LoopDone(ctx context.Context) <-chan struct{} {
+	done, _ := ctx.Value(loopDoneKey).(<-chan struct{})
+	return done
+}
+
 func AddFailPoint(ctx context.Context, failPoint string, client *mongo.Client) error {
 	failPoints := ctx.Value(failPointsKey).(map[string]*mongo.Client)
 	if _, ok := failPoints[failPoint]; ok {
@@ -9,6 +9,7 @@ package unified
 import (
 	"context"
 	"fmt"
+	"time"
 
 	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/bson/bsontype"
@@ -24,6 +25,7 @@ func executeCreateCollection(ctx context.Context, operation *Operation) (*Operat
 	}
 
 	var collName string
+	cco := options.CreateCollection()
 	elems, _ := operation.Arguments.Elements()
 	for _, elem := range elems {
 		key := elem.Key()
@@ -32,6 +34,14 @@ func executeCreateCollection(ctx context.Context, operation *Operation) (*Operat
 		switch key {
 		case "collection":
 			collName = val.StringValue()
+		case "expireAfterSeconds":
+			cco.SetExpireAfterSeconds(val.AsInt64())
+		case "timeseries":
+			tso, err := createTimeSeriesOptions(val.Document())
+			if err != nil {
+				return nil, err
+			}
+			cco.SetTimeSeriesOptions(tso)
 		default:
 			return nil, fmt.Errorf("unrecognized createCollection option %q", key)
 		}
@@ -40,10 +50,35 @@ func executeCreateCollection(ctx context.Context, operation *Operation) (*Operat
 		return nil, newMissingArgumentError("collName")
 	}
 
-	err = db.CreateCollection(ctx, collName)
+	err = db.CreateCollection(ctx, collName, cco)
 	return NewErrorResult(err), nil
 }
 
+func createTimeSeriesOptions(doc bson.Raw) (*options.TimeSeriesOptions, error) {
+	tso := options.TimeSeries()
+	elems, _ := doc.Elements()
+	for _, elem := range elems {
+		key := elem.Key()
+		val := elem.Value()
+
+		switch key {
+		case "timeField":
+			tso.SetTimeField(val.StringValue())
+		case "metaField":
+			tso.SetMetaField(val.StringValue())
+		case "granularity":
+			tso.SetGranularity(val.StringValue())
+		case "bucketMaxSpanSeconds":
+			tso.SetBucketMaxSpan(time.Duration(val.AsInt64()) * time.Second)
+		case "bucketRoundingSeconds":
+			tso.SetBucketRounding(time.Duration(val.AsInt64()) * time.Second)
+		default:
+			return nil, fmt.Errorf("unrecognized timeseries option %q", key)
+		}
+	}
+	return tso, nil
+}
+
 func executeDropCollection(ctx context.Context, operation *Operation) (*OperationResult, error) {
 	db, err := Entities(ctx).Database(operation.Object)
 	if err != nil {
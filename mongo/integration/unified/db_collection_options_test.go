@@ -11,6 +11,8 @@ import (
 
 	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/mongo/options"
+	"go.mongodb.org/mongo-driver/mongo/readconcern"
+	"go.mongodb.org/mongo-driver/mongo/writeconcern"
 )
 
 type DBOrCollectionOptions struct {
@@ -22,10 +24,10 @@ type DBOrCollectionOptions struct {
 // to their corresponding Go objects.
 func (d *DBOrCollectionOptions) UnmarshalBSON(data []byte) error {
 	var temp struct {
-		RC    *readConcern           `bson:"readConcern"`
-		RP    *readPreference        `bson:"readPreference"`
-		WC    *writeConcern          `bson:"writeConcern"`
-		Extra map[string]interface{} `bson:",inline"`
+		RC    *readconcern.ReadConcern   `bson:"readConcern"`
+		RP    *readPreference            `bson:"readPreference"`
+		WC    *writeconcern.WriteConcern `bson:"writeConcern"`
+		Extra map[string]interface{}     `bson:",inline"`
 	}
 	if err := bson.Unmarshal(data, &temp); err != nil {
 		return fmt.Errorf("error unmarshalling to temporary DBOrCollectionOptions object: %v", err)
@@ -37,9 +39,8 @@ func (d *DBOrCollectionOptions) UnmarshalBSON(data []byte) error {
 	d.DBOptions = options.Database()
 	d.CollectionOptions = options.Collection()
 	if temp.RC != nil {
-		rc := temp.RC.toReadConcernOption()
-		d.DBOptions.SetReadConcern(rc)
-		d.CollectionOptions.SetReadConcern(rc)
+		d.DBOptions.SetReadConcern(temp.RC)
+		d.CollectionOptions.SetReadConcern(temp.RC)
 	}
 	if temp.RP != nil {
 		rp, err := temp.RP.toReadPrefOption()
@@ -51,13 +52,8 @@ func (d *DBOrCollectionOptions) UnmarshalBSON(data []byte) error {
 		d.CollectionOptions.SetReadPreference(rp)
 	}
 	if temp.WC != nil {
-		wc, err := temp.WC.toWriteConcernOption()
-		if err != nil {
-			return fmt.Errorf("error parsing write concern document: %v", err)
-		}
-
-		d.DBOptions.SetWriteConcern(wc)
-		d.CollectionOptions.SetWriteConcern(wc)
+		d.DBOptions.SetWriteConcern(temp.WC)
+		d.CollectionOptions.SetWriteConcern(temp.WC)
 	}
 
 	return nil
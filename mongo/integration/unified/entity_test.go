@@ -9,6 +9,7 @@ package unified
 import (
 	"context"
 	"fmt"
+	"sync"
 
 	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/mongo"
@@ -23,11 +24,13 @@ type EntityOptions struct {
 	ID string `bson:"id"`
 
 	// Options for client entities.
-	URIOptions          bson.M            `bson:"uriOptions"`
-	UseMultipleMongoses *bool             `bson:"useMultipleMongoses"`
-	ObserveEvents       []string          `bson:"observeEvents"`
-	IgnoredCommands     []string          `bson:"ignoreCommandMonitoringEvents"`
-	ServerAPIOptions    *ServerAPIOptions `bson:"serverApi"`
+	URIOptions            bson.M                         `bson:"uriOptions"`
+	UseMultipleMongoses   *bool                          `bson:"useMultipleMongoses"`
+	ObserveEvents         []string                       `bson:"observeEvents"`
+	ObserveLogMessages    map[string]string              `bson:"observeLogMessages"`
+	IgnoredCommands       []string                       `bson:"ignoreCommandMonitoringEvents"`
+	ServerAPIOptions      *ServerAPIOptions              `bson:"serverApi"`
+	StoreEventsAsEntities []*StoreEventsAsEntitiesOption `bson:"storeEventsAsEntities"`
 
 	// Options for database entities.
 	DatabaseName    string                 `bson:"databaseName"`
@@ -43,6 +46,9 @@ type EntityOptions struct {
 	// Options for GridFS bucket entities.
 	GridFSBucketOptions *GridFSBucketOptions `bson:"bucketOptions"`
 
+	// Options for clientEncryption entities.
+	ClientEncryptionOptions *ClientEncryptionOptions `bson:"clientEncryptionOpts"`
+
 	// Options that reference other entities.
 	ClientID   string `bson:"client"`
 	DatabaseID string `bson:"database"`
@@ -52,26 +58,35 @@ type EntityOptions struct {
 // ID, even if they are of different types. It also enforces referential integrity so construction of an entity that
 // references another (e.g. a database entity references a client) will fail if the referenced entity does not exist.
 type EntityMap struct {
-	allEntities   map[string]struct{}
-	changeStreams map[string]*mongo.ChangeStream
-	clients       map[string]*ClientEntity
-	dbs           map[string]*mongo.Database
-	collections   map[string]*mongo.Collection
-	sessions      map[string]mongo.Session
-	gridfsBuckets map[string]*gridfs.Bucket
-	bsonValues    map[string]bson.RawValue
+	allEntities       map[string]struct{}
+	changeStreams     map[string]*mongo.ChangeStream
+	clients           map[string]*ClientEntity
+	dbs               map[string]*mongo.Database
+	collections       map[string]*mongo.Collection
+	sessions          map[string]mongo.Session
+	gridfsBuckets     map[string]*gridfs.Bucket
+	clientEncryptions map[string]*mongo.ClientEncryption
+	bsonValues        map[string]bson.RawValue
+
+	// documentLists holds the entities that are arrays of documents, such as the events stored for a client or the
+	// errors stored by a loop operation. Events are published by background goroutines, so the lists are guarded by
+	// documentListsLock.
+	documentLists     map[string][]bson.Raw
+	documentListsLock sync.Mutex
 }
 
 func NewEntityMap() *EntityMap {
 	return &EntityMap{
-		allEntities:   make(map[string]struct{}),
-		gridfsBuckets: make(map[string]*gridfs.Bucket),
-		bsonValues:    make(map[string]bson.RawValue),
-		changeStreams: make(map[string]*mongo.ChangeStream),
-		clients:       make(map[string]*ClientEntity),
-		collections:   make(map[string]*mongo.Collection),
-		dbs:           make(map[string]*mongo.Database),
-		sessions:      make(map[string]mongo.Session),
+		allEntities:       make(map[string]struct{}),
+		gridfsBuckets:     make(map[string]*gridfs.Bucket),
+		clientEncryptions: make(map[string]*mongo.ClientEncryption),
+		bsonValues:        make(map[string]bson.RawValue),
+		documentLists:     make(map[string][]bson.Raw),
+		changeStreams:     make(map[string]*mongo.ChangeStream),
+		clients:           make(map[string]*ClientEntity),
+		collections:       make(map[string]*mongo.Collection),
+		dbs:               make(map[string]*mongo.Database),
+		sessions:          make(map[string]mongo.Session),
 	}
 }
 
@@ -85,6 +100,34 @@ func (em *EntityMap) AddBSONEntity(id string, val bson.RawValue) error {
 	return nil
 }
 
+// AddDocumentListEntity creates an entity that is an empty array of documents. Documents can be added to it with
+// AppendDocument.
+func (em *EntityMap) AddDocumentListEntity(id string) error {
+	if err := em.verifyEntityDoesNotExist(id); err != nil {
+		return err
+	}
+
+	em.documentListsLock.Lock()
+	defer em.documentListsLock.Unlock()
+
+	em.allEntities[id] = struct{}{}
+	em.documentLists[id] = make([]bson.Raw, 0)
+	return nil
+}
+
+// AppendDocument appends doc to the array of documents entity with the given ID. It is safe to call concurrently.
+func (em *EntityMap) AppendDocument(id string, doc bson.Raw) error {
+	em.documentListsLock.Lock()
+	defer em.documentListsLock.Unlock()
+
+	docs, ok := em.documentLists[id]
+	if !ok {
+		return newEntityNotFoundError("document list", id)
+	}
+	em.documentLists[id] = append(docs, doc)
+	return nil
+}
+
 func (em *EntityMap) AddChangeStreamEntity(id string, stream *mongo.ChangeStream) error {
 	if err := em.verifyEntityDoesNotExist(id); err != nil {
 		return err
@@ -112,6 +155,8 @@ func (em *EntityMap) AddEntity(ctx context.Context, entityType string, entityOpt
 		err = em.addSessionEntity(entityOptions)
 	case "bucket":
 		err = em.addGridFSBucketEntity(entityOptions)
+	case "clientEncryption":
+		err = em.addClientEncryptionEntity(entityOptions)
 	default:
 		return fmt.Errorf("unrecognized entity type %q", entityType)
 	}
@@ -131,6 +176,14 @@ func (em *EntityMap) GridFSBucket(id string) (*gridfs.Bucket, error) {
 	return bucket, nil
 }
 
+func (em *EntityMap) ClientEncryption(id string) (*mongo.ClientEncryption, error) {
+	ce, ok := em.clientEncryptions[id]
+	if !ok {
+		return nil, newEntityNotFoundError("client encryption", id)
+	}
+	return ce, nil
+}
+
 func (em *EntityMap) BSONValue(id string) (bson.RawValue, error) {
 	val, ok := em.bsonValues[id]
 	if !ok {
@@ -139,6 +192,18 @@ func (em *EntityMap) BSONValue(id string) (bson.RawValue, error) {
 	return val, nil
 }
 
+// DocumentList returns a copy of the array of documents entity with the given ID.
+func (em *EntityMap) DocumentList(id string) ([]bson.Raw, error) {
+	em.documentListsLock.Lock()
+	defer em.documentListsLock.Unlock()
+
+	docs, ok := em.documentLists[id]
+	if !ok {
+		return nil, newEntityNotFoundError("document list", id)
+	}
+	return append([]bson.Raw(nil), docs...), nil
+}
+
 func (em *EntityMap) ChangeStream(id string) (*mongo.ChangeStream, error) {
 	client, ok := em.changeStreams[id]
 	if !ok {
@@ -199,12 +264,20 @@ func (em *EntityMap) Close(ctx context.Context) []error {
 			errs = append(errs, fmt.Errorf("error closing client with ID %q: %v", id, err))
 		}
 	}
+
+	// ClientEncryption.Close also disconnects the key vault client, which is a client entity that was already
+	// disconnected above, so ErrClientDisconnected is expected.
+	for id, ce := range em.clientEncryptions {
+		if err := ce.Close(ctx); err != nil && err != mongo.ErrClientDisconnected {
+			errs = append(errs, fmt.Errorf("error closing client encryption with ID %q: %v", id, err))
+		}
+	}
 	return errs
 }
 
 func (em *EntityMap) addClientEntity(ctx context.Context, EntityOptions *EntityOptions) error {
 	var client *ClientEntity
-	client, err := NewClientEntity(ctx, EntityOptions)
+	client, err := NewClientEntity(ctx, em, EntityOptions)
 	if err != nil {
 		return fmt.Errorf("error creating client entity: %v", err)
 	}
@@ -283,6 +356,29 @@ func (em *EntityMap) addGridFSBucketEntity(EntityOptions *EntityOptions) error {
 	return nil
 }
 
+func (em *EntityMap) addClientEncryptionEntity(EntityOptions *EntityOptions) error {
+	ceo := EntityOptions.ClientEncryptionOptions
+	if ceo == nil {
+		return newMissingArgumentError("clientEncryptionOpts")
+	}
+
+	client, ok := em.clients[ceo.KeyVaultClientID]
+	if !ok {
+		return newEntityNotFoundError("client", ceo.KeyVaultClientID)
+	}
+
+	opts := options.ClientEncryption().
+		SetKeyVaultNamespace(ceo.KeyVaultNamespace).
+		SetKmsProviders(ceo.KmsProviders)
+	ce, err := mongo.NewClientEncryption(client.Client, opts)
+	if err != nil {
+		return fmt.Errorf("error creating client encryption: %v", err)
+	}
+
+	em.clientEncryptions[EntityOptions.ID] = ce
+	return nil
+}
+
 func (em *EntityMap) verifyEntityDoesNotExist(id string) error {
 	if _, ok := em.allEntities[id]; ok {
 		return fmt.Errorf("entity with ID %q already exists", id)
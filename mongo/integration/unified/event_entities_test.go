@@ -0,0 +1,151 @@
+// Copyright (C) MongoDB, Inc. 2021-present.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
+
+package unified
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/event"
+)
+
+// poolEventTypes maps the names of the connection pool events that can be stored as entities to the event types used
+// by the driver.
+var poolEventTypes = map[string]string{
+	"PoolCreatedEvent":              event.PoolCreated,
+	"PoolClearedEvent":              event.PoolCleared,
+	"PoolClosedEvent":               event.PoolClosedEvent,
+	"ConnectionCreatedEvent":        event.ConnectionCreated,
+	"ConnectionReadyEvent":          event.ConnectionReady,
+	"ConnectionClosedEvent":         event.ConnectionClosed,
+	"ConnectionCheckOutFailedEvent": event.GetFailed,
+	"ConnectionCheckedOutEvent":     event.GetSucceeded,
+	"ConnectionCheckedInEvent":      event.ConnectionReturned,
+}
+
+// StoreEventsAsEntitiesOption specifies an entity in which a client entity stores the events of the given types.
+type StoreEventsAsEntitiesOption struct {
+	ID     string   `bson:"id"`
+	Events []string `bson:"events"`
+}
+
+// storeEventsAsEntities creates an array of documents entity in em for each of opts and configures monitor and
+// poolMonitor to append a document to it for every event of the types it stores.
+func storeEventsAsEntities(em *EntityMap, monitor *event.CommandMonitor, poolMonitor *event.PoolMonitor,
+	opts []*StoreEventsAsEntitiesOption) error {
+
+	commandEntities := make(map[string][]string)
+	poolEntities := make(map[string][]string)
+	for _, opt := range opts {
+		if err := em.AddDocumentListEntity(opt.ID); err != nil {
+			return err
+		}
+
+		for _, name := range opt.Events {
+			switch name {
+			case "CommandStartedEvent", "CommandSucceededEvent", "CommandFailedEvent":
+				commandEntities[name] = append(commandEntities[name], opt.ID)
+			default:
+				eventType, ok := poolEventTypes[name]
+				if !ok {
+					return fmt.Errorf("unsupported event type %q for storeEventsAsEntities", name)
+				}
+				poolEntities[eventType] = append(poolEntities[eventType], opt.ID)
+			}
+		}
+	}
+
+	storeEvent := func(ids []string, name string, fields bson.D) {
+		if len(ids) == 0 {
+			return
+		}
+
+		doc := append(bson.D{
+			{"name", name},
+			{"observedAt", secondsSinceEpoch(time.Now())},
+		}, fields...)
+		raw, err := bson.Marshal(doc)
+		if err != nil {
+			panic(fmt.Sprintf("error marshalling event document: %v", err))
+		}
+		for _, id := range ids {
+			_ = em.AppendDocument(id, raw)
+		}
+	}
+
+	started, succeeded, failed := monitor.Started, monitor.Succeeded, monitor.Failed
+	monitor.Started = func(ctx context.Context, evt *event.CommandStartedEvent) {
+		if started != nil {
+			started(ctx, evt)
+		}
+		storeEvent(commandEntities["CommandStartedEvent"], "CommandStartedEvent", bson.D{
+			{"commandName", evt.CommandName},
+			{"databaseName", evt.DatabaseName},
+			{"requestId", evt.RequestID},
+			{"connectionId", evt.ConnectionID},
+		})
+	}
+	monitor.Succeeded = func(ctx context.Context, evt *event.CommandSucceededEvent) {
+		if succeeded != nil {
+			succeeded(ctx, evt)
+		}
+		storeEvent(commandEntities["CommandSucceededEvent"], "CommandSucceededEvent", bson.D{
+			{"commandName", evt.CommandName},
+			{"duration", float64(evt.DurationNanos) / float64(time.Second)},
+			{"requestId", evt.RequestID},
+			{"connectionId", evt.ConnectionID},
+		})
+	}
+	monitor.Failed = func(ctx context.Context, evt *event.CommandFailedEvent) {
+		if failed != nil {
+			failed(ctx, evt)
+		}
+		storeEvent(commandEntities["CommandFailedEvent"], "CommandFailedEvent", bson.D{
+			{"commandName", evt.CommandName},
+			{"duration", float64(evt.DurationNanos) / float64(time.Second)},
+			{"failure", evt.Failure},
+			{"requestId", evt.RequestID},
+			{"connectionId", evt.ConnectionID},
+		})
+	}
+
+	poolEvent := poolMonitor.Event
+	poolMonitor.Event = func(evt *event.PoolEvent) {
+		if poolEvent != nil {
+			poolEvent(evt)
+		}
+
+		fields := bson.D{{"address", evt.Address}}
+		switch evt.Type {
+		case event.ConnectionCreated, event.ConnectionReady, event.GetSucceeded, event.ConnectionReturned:
+			fields = append(fields, bson.E{"connectionId", int64(evt.ConnectionID)})
+		case event.ConnectionClosed:
+			fields = append(fields, bson.E{"connectionId", int64(evt.ConnectionID)}, bson.E{"reason", evt.Reason})
+		case event.GetFailed:
+			fields = append(fields, bson.E{"reason", evt.Reason})
+		}
+		storeEvent(poolEntities[evt.Type], poolEventName(evt.Type), fields)
+	}
+	return nil
+}
+
+// poolEventName returns the name used by the unified test format for the driver's pool event type.
+func poolEventName(eventType string) string {
+	for name, t := range poolEventTypes {
+		if t == eventType {
+			return name
+		}
+	}
+	return eventType
+}
+
+// secondsSinceEpoch returns the number of seconds between the Unix epoch and t with sub-second precision.
+func secondsSinceEpoch(t time.Time) float64 {
+	return float64(t.UnixNano()) / float64(time.Second)
+}
@@ -0,0 +1,243 @@
+// Copyright (C) MongoDB, Inc. 2021-present.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
+
+package unified
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"net"
+	"strconv"
+	"strings"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/event"
+)
+
+// maxLoggedDocumentLength is the length at which documents in log messages are truncated, as required by the logging
+// specification.
+const maxLoggedDocumentLength = 1000
+
+var (
+	// logLevels maps the log levels from the logging specification to their severity. A message is captured if its
+	// severity is at most the severity of the level configured for its component.
+	logLevels = map[string]int{
+		"off":       0,
+		"emergency": 1,
+		"alert":     2,
+		"critical":  3,
+		"error":     4,
+		"warning":   5,
+		"notice":    6,
+		"info":      7,
+		"debug":     8,
+		"trace":     9,
+	}
+
+	// supportedLogComponents is the set of components for which log messages can be captured. Command log messages are
+	// derived from command monitoring events. The driver does not publish events for the other components.
+	supportedLogComponents = map[string]struct{}{
+		"command": {},
+	}
+)
+
+// logMessage is a log message captured for a client entity.
+type logMessage struct {
+	Level     string
+	Component string
+	Data      bson.Raw
+}
+
+// ExpectedLogMessage is a log message that a test expects a client to emit.
+type ExpectedLogMessage struct {
+	Level             string   `bson:"level"`
+	Component         string   `bson:"component"`
+	FailureIsRedacted *bool    `bson:"failureIsRedacted"`
+	Data              bson.Raw `bson:"data"`
+}
+
+// ExpectedLogMessagesForClient is the list of log messages that a test expects a client to emit.
+type ExpectedLogMessagesForClient struct {
+	ClientID            string                `bson:"client"`
+	Messages            []*ExpectedLogMessage `bson:"messages"`
+	IgnoreExtraMessages bool                  `bson:"ignoreExtraMessages"`
+}
+
+// unsupportedLogComponent returns the first component for which a client entity in entities observes log messages that
+// cannot be captured, or an empty string if there is no such component.
+func unsupportedLogComponent(entities []map[string]*EntityOptions) string {
+	for _, entity := range entities {
+		for entityType, entityOptions := range entity {
+			if entityType != "client" {
+				continue
+			}
+			for component := range entityOptions.ObserveLogMessages {
+				if _, ok := supportedLogComponents[component]; !ok {
+					return component
+				}
+			}
+		}
+	}
+	return ""
+}
+
+// observeLogMessages configures the client entity to capture log messages at the given levels, keyed by component,
+// from the events published to monitor.
+func (c *ClientEntity) observeLogMessages(monitor *event.CommandMonitor, levels map[string]string) error {
+	c.logLevels = make(map[string]int, len(levels))
+	for component, level := range levels {
+		if _, ok := supportedLogComponents[component]; !ok {
+			return fmt.Errorf("unsupported log component %q", component)
+		}
+		severity, ok := logLevels[level]
+		if !ok {
+			return fmt.Errorf("unrecognized log level %q for component %q", level, component)
+		}
+		c.logLevels[component] = severity
+	}
+
+	started, succeeded, failed := monitor.Started, monitor.Succeeded, monitor.Failed
+	monitor.Started = func(ctx context.Context, evt *event.CommandStartedEvent) {
+		if started != nil {
+			started(ctx, evt)
+		}
+		c.logCommand(evt.ConnectionID, bson.D{
+			{"message", "Command started"},
+			{"command", truncatedJSON(evt.Command)},
+			{"databaseName", evt.DatabaseName},
+			{"commandName", evt.CommandName},
+			{"requestId", evt.RequestID},
+			{"operationId", evt.RequestID},
+		})
+	}
+	monitor.Succeeded = func(ctx context.Context, evt *event.CommandSucceededEvent) {
+		if succeeded != nil {
+			succeeded(ctx, evt)
+		}
+		c.logCommand(evt.ConnectionID, bson.D{
+			{"message", "Command succeeded"},
+			{"durationMS", float64(evt.DurationNanos) / float64(time.Millisecond)},
+			{"reply", truncatedJSON(evt.Reply)},
+			{"commandName", evt.CommandName},
+			{"requestId", evt.RequestID},
+			{"operationId", evt.RequestID},
+		})
+	}
+	monitor.Failed = func(ctx context.Context, evt *event.CommandFailedEvent) {
+		if failed != nil {
+			failed(ctx, evt)
+		}
+		c.logCommand(evt.ConnectionID, bson.D{
+			{"message", "Command failed"},
+			{"durationMS", float64(evt.DurationNanos) / float64(time.Millisecond)},
+			{"failure", evt.Failure},
+			{"commandName", evt.CommandName},
+			{"requestId", evt.RequestID},
+			{"operationId", evt.RequestID},
+		})
+	}
+	return nil
+}
+
+// logCommand captures a debug log message for the command component if the client observes it. The host and port of
+// the server are added to data based on connectionID.
+func (c *ClientEntity) logCommand(connectionID string, data bson.D) {
+	severity, ok := c.logLevels["command"]
+	if !ok || severity < logLevels["debug"] || !c.getRecordEvents() {
+		return
+	}
+
+	// Connection IDs have the form "host:port[-id]".
+	addr := connectionID
+	if idx := strings.LastIndex(addr, "[-"); idx != -1 {
+		addr = addr[:idx]
+	}
+	if host, port, err := net.SplitHostPort(addr); err == nil {
+		data = append(data, bson.E{"serverHost", host})
+		if p, err := strconv.Atoi(port); err == nil {
+			data = append(data, bson.E{"serverPort", int32(p)})
+		}
+	}
+
+	raw, err := bson.Marshal(data)
+	if err != nil {
+		panic(fmt.Sprintf("error marshalling log message data: %v", err))
+	}
+	c.logMessages = append(c.logMessages, &logMessage{
+		Level:     "debug",
+		Component: "command",
+		Data:      raw,
+	})
+}
+
+// truncatedJSON returns the relaxed extended JSON representation of doc truncated to maxLoggedDocumentLength bytes.
+func truncatedJSON(doc bson.Raw) string {
+	str := doc.String()
+	if len(str) > maxLoggedDocumentLength {
+		str = str[:maxLoggedDocumentLength] + "..."
+	}
+	return str
+}
+
+// VerifyLogMessages verifies that the client referenced by expected emitted the expected log messages in order.
+func VerifyLogMessages(ctx context.Context, expected *ExpectedLogMessagesForClient) error {
+	client, err := Entities(ctx).Client(expected.ClientID)
+	if err != nil {
+		return err
+	}
+
+	actual := client.logMessages
+	for idx, expectedMsg := range expected.Messages {
+		if len(actual) == 0 {
+			return newLogVerificationError(idx, client, "no log message emitted")
+		}
+		actualMsg := actual[0]
+		actual = actual[1:]
+
+		if expectedMsg.FailureIsRedacted != nil {
+			return newLogVerificationError(idx, client, "failureIsRedacted is not supported")
+		}
+		if expectedMsg.Level != actualMsg.Level {
+			return newLogVerificationError(idx, client, "expected level %q, got %q", expectedMsg.Level, actualMsg.Level)
+		}
+		if expectedMsg.Component != actualMsg.Component {
+			return newLogVerificationError(idx, client, "expected component %q, got %q", expectedMsg.Component,
+				actualMsg.Component)
+		}
+		if expectedMsg.Data != nil {
+			expectedDoc := DocumentToRawValue(expectedMsg.Data)
+			actualDoc := DocumentToRawValue(actualMsg.Data)
+			if err := VerifyValuesMatch(ctx, expectedDoc, actualDoc, true); err != nil {
+				return newLogVerificationError(idx, client, "error comparing data documents: %v", err)
+			}
+		}
+	}
+
+	if len(actual) > 0 && !expected.IgnoreExtraMessages {
+		return fmt.Errorf("extra log messages emitted; all log messages for client: %s",
+			stringifyLogMessagesForClient(client))
+	}
+	return nil
+}
+
+func newLogVerificationError(idx int, client *ClientEntity, msg string, args ...interface{}) error {
+	fullMsg := fmt.Sprintf(msg, args...)
+	return fmt.Errorf("log message comparison failed at index %d: %s; all log messages found for client: %s", idx,
+		fullMsg, stringifyLogMessagesForClient(client))
+}
+
+func stringifyLogMessagesForClient(client *ClientEntity) string {
+	str := bytes.NewBuffer(nil)
+
+	str.WriteString("\n\n")
+	for _, msg := range client.logMessages {
+		str.WriteString(fmt.Sprintf("[%s] %s: %s\n", msg.Level, msg.Component, msg.Data))
+	}
+
+	return str.String()
+}
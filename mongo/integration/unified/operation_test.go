@@ -30,7 +30,11 @@ func (op *Operation) Execute(ctx context.Context) error {
 	if err != nil {
 		return fmt.Errorf("execution failed: %v", err)
 	}
+	return op.verify(ctx, res)
+}
 
+// verify verifies the result and/or error returned by the operation against the expected values.
+func (op *Operation) verify(ctx context.Context, res *OperationResult) error {
 	if err := VerifyOperationError(ctx, op.ExpectedError, res); err != nil {
 		return fmt.Errorf("error verification failed: %v", err)
 	}
@@ -139,6 +143,14 @@ func (op *Operation) run(ctx context.Context) (*OperationResult, error) {
 	case "upload":
 		return executeBucketUpload(ctx, op)
 
+	// ClientEncryption operations
+	case "createDataKey":
+		return executeCreateDataKey(ctx, op)
+	case "encrypt":
+		return executeEncrypt(ctx, op)
+	case "decrypt":
+		return executeDecrypt(ctx, op)
+
 	// Change Stream operations
 	case "iterateUntilDocumentOrError":
 		return executeIterateUntilDocumentOrError(ctx, op)
@@ -15,6 +15,8 @@ import (
 )
 
 var (
+	// expectLogMessages from schema version 1.13 is supported, but the features of versions 1.2 through 1.12 are not,
+	// so only version 1.1 is claimed.
 	supportedSchemaVersions = map[int]string{
 		1: "1.1",
 	}
@@ -12,6 +12,8 @@ import (
 
 	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/mongo/options"
+	"go.mongodb.org/mongo-driver/mongo/readconcern"
+	"go.mongodb.org/mongo-driver/mongo/writeconcern"
 )
 
 // TransactionOptions is a wrapper for *options.TransactionOptions. This type implements the bson.Unmarshaler interface
@@ -24,11 +26,11 @@ var _ bson.Unmarshaler = (*TransactionOptions)(nil)
 
 func (to *TransactionOptions) UnmarshalBSON(data []byte) error {
 	var temp struct {
-		RC              *readConcern           `bson:"readConcern"`
-		RP              *readPreference        `bson:"readPreference"`
-		WC              *writeConcern          `bson:"writeConcern"`
-		MaxCommitTimeMS *int64                 `bson:"maxCommitTimeMS"`
-		Extra           map[string]interface{} `bson:",inline"`
+		RC              *readconcern.ReadConcern   `bson:"readConcern"`
+		RP              *readPreference            `bson:"readPreference"`
+		WC              *writeconcern.WriteConcern `bson:"writeConcern"`
+		MaxCommitTimeMS *int64                     `bson:"maxCommitTimeMS"`
+		Extra           map[string]interface{}     `bson:",inline"`
 	}
 	if err := bson.Unmarshal(data, &temp); err != nil {
 		return fmt.Errorf("error unmarshalling to temporary TransactionOptions object: %v", err)
@@ -42,8 +44,8 @@ func (to *TransactionOptions) UnmarshalBSON(data []byte) error {
 		mctms := time.Duration(*temp.MaxCommitTimeMS) * time.Millisecond
 		to.SetMaxCommitTime(&mctms)
 	}
-	if rc := temp.RC; rc != nil {
-		to.SetReadConcern(rc.toReadConcernOption())
+	if temp.RC != nil {
+		to.SetReadConcern(temp.RC)
 	}
 	if rp := temp.RP; rp != nil {
 		converted, err := rp.toReadPrefOption()
@@ -52,12 +54,8 @@ func (to *TransactionOptions) UnmarshalBSON(data []byte) error {
 		}
 		to.SetReadPreference(converted)
 	}
-	if wc := temp.WC; wc != nil {
-		converted, err := wc.toWriteConcernOption()
-		if err != nil {
-			return fmt.Errorf("error parsing write concern document: %v", err)
-		}
-		to.SetWriteConcern(converted)
+	if temp.WC != nil {
+		to.SetWriteConcern(temp.WC)
 	}
 	return nil
 }
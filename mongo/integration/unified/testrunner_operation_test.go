@@ -9,6 +9,7 @@ package unified
 import (
 	"context"
 	"fmt"
+	"time"
 
 	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/mongo"
@@ -111,11 +112,139 @@ func executeTestRunnerOperation(ctx context.Context, operation *Operation) error
 		coll := LookupString(args, "collectionName")
 		index := LookupString(args, "indexName")
 		return verifyIndexExists(ctx, db, coll, index, false)
+	case "loop":
+		return executeLoop(ctx, args)
 	default:
 		return fmt.Errorf("unrecognized testRunner operation %q", operation.Name)
 	}
 }
 
+// executeLoop runs the operations in args repeatedly until the channel returned by LoopDone is closed or ctx is done.
+// Errors and failures of the operations are stored in entities if the corresponding arguments are specified.
+// Otherwise, the first error or failure stops the loop and is returned.
+func executeLoop(ctx context.Context, args bson.Raw) error {
+	var loopArgs struct {
+		Operations         []*Operation `bson:"operations"`
+		ErrorsEntityID     string       `bson:"storeErrorsAsEntity"`
+		FailuresEntityID   string       `bson:"storeFailuresAsEntity"`
+		SuccessesEntityID  string       `bson:"storeSuccessesAsEntity"`
+		IterationsEntityID string       `bson:"storeIterationsAsEntity"`
+	}
+	if err := bson.Unmarshal(args, &loopArgs); err != nil {
+		return fmt.Errorf("error unmarshalling loop arguments: %v", err)
+	}
+	for _, op := range loopArgs.Operations {
+		if op.Name == "loop" {
+			return fmt.Errorf("loop operations cannot be nested")
+		}
+	}
+
+	em := Entities(ctx)
+	errorsID := loopArgs.ErrorsEntityID
+	if errorsID != "" {
+		if err := em.AddDocumentListEntity(errorsID); err != nil {
+			return err
+		}
+	}
+	// Failures are stored with the errors if they don't have their own entity.
+	failuresID := loopArgs.FailuresEntityID
+	if failuresID == "" {
+		failuresID = errorsID
+	} else if failuresID != errorsID {
+		if err := em.AddDocumentListEntity(failuresID); err != nil {
+			return err
+		}
+	}
+	for _, id := range []string{loopArgs.SuccessesEntityID, loopArgs.IterationsEntityID} {
+		if id == "" {
+			continue
+		}
+		if err := em.verifyEntityDoesNotExist(id); err != nil {
+			return err
+		}
+	}
+
+	var successes, iterations int64
+	done := LoopDone(ctx)
+	for !isLoopDone(ctx, done) {
+		for idx, op := range loopArgs.Operations {
+			failure, err := executeLoopOperation(ctx, op)
+			if err == nil {
+				successes++
+				continue
+			}
+
+			id := errorsID
+			if failure {
+				id = failuresID
+			}
+			if id == "" {
+				return fmt.Errorf("error running operation %q at index %d in loop: %v", op.Name, idx, err)
+			}
+			if err := storeLoopError(em, id, err); err != nil {
+				return err
+			}
+			// The rest of the operations are skipped and the next iteration starts after an error or failure.
+			break
+		}
+		iterations++
+	}
+
+	if id := loopArgs.SuccessesEntityID; id != "" {
+		if err := em.AddBSONEntity(id, int64ToRawValue(successes)); err != nil {
+			return err
+		}
+	}
+	if id := loopArgs.IterationsEntityID; id != "" {
+		if err := em.AddBSONEntity(id, int64ToRawValue(iterations)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// executeLoopOperation runs an operation of a loop. The returned error is a failure if the operation ran as expected
+// but its result or error did not match the expected values.
+func executeLoopOperation(ctx context.Context, op *Operation) (bool, error) {
+	// Running an operation can remove the session from its arguments, so a copy is run to keep the original intact for
+	// the next iteration.
+	opCopy := *op
+	res, err := opCopy.run(ctx)
+	if err != nil {
+		return false, err
+	}
+	if res.Err != nil && opCopy.ExpectedError == nil {
+		return false, res.Err
+	}
+	if err := opCopy.verify(ctx, res); err != nil {
+		return true, err
+	}
+	return false, nil
+}
+
+// storeLoopError appends a document describing err to the array of documents entity with the given ID.
+func storeLoopError(em *EntityMap, id string, err error) error {
+	doc, marshalErr := bson.Marshal(bson.D{
+		{"error", err.Error()},
+		{"time", secondsSinceEpoch(time.Now())},
+	})
+	if marshalErr != nil {
+		return fmt.Errorf("error marshalling error document: %v", marshalErr)
+	}
+	return em.AppendDocument(id, doc)
+}
+
+func isLoopDone(ctx context.Context, done <-chan struct{}) bool {
+	select {
+	case <-done:
+		return true
+	case <-ctx.Done():
+		return true
+	default:
+		return false
+	}
+}
+
 func extractClientSession(sess mongo.Session) *session.Client {
 	return sess.(mongo.XSession).ClientSession()
 }
@@ -10,6 +10,8 @@ import (
 	"context"
 	"fmt"
 	"io/ioutil"
+	"os"
+	"os/signal"
 	"path"
 	"testing"
 
@@ -45,12 +47,13 @@ const (
 )
 
 type TestCase struct {
-	Description       string             `bson:"description"`
-	RunOnRequirements []mtest.RunOnBlock `bson:"runOnRequirements"`
-	SkipReason        *string            `bson:"skipReason"`
-	Operations        []*Operation       `bson:"operations"`
-	ExpectedEvents    []*ExpectedEvents  `bson:"expectEvents"`
-	Outcome           []*CollectionData  `bson:"outcome"`
+	Description       string                          `bson:"description"`
+	RunOnRequirements []mtest.RunOnBlock              `bson:"runOnRequirements"`
+	SkipReason        *string                         `bson:"skipReason"`
+	Operations        []*Operation                    `bson:"operations"`
+	ExpectedEvents    []*ExpectedEvents               `bson:"expectEvents"`
+	ExpectedLogs      []*ExpectedLogMessagesForClient `bson:"expectLogMessages"`
+	Outcome           []*CollectionData               `bson:"outcome"`
 }
 
 func (t *TestCase) PerformsDistinct() bool {
@@ -136,8 +139,38 @@ func runTestCase(mt *mtest.T, testFile TestFile, testCase *TestCase) {
 	if _, ok := skippedTestDescriptions[testCase.Description]; ok {
 		mt.Skip("skipping due to known failure")
 	}
+	if component := unsupportedLogComponent(testFile.CreateEntities); component != "" {
+		mt.Skipf("skipping because log messages for component %q cannot be captured", component)
+	}
+	if !cseEnabled && createsClientEncryption(testFile.CreateEntities) {
+		mt.Skip("skipping because client-side encryption requires the cse build tag")
+	}
+	// ClientEncryption does not support rewrapping data keys.
+	if testCase.performsOperation("rewrapManyDataKey") {
+		mt.Skip("skipping because rewrapManyDataKey is not supported")
+	}
 
 	testCtx := NewTestContext(mtest.Background)
+	if testCase.performsOperation("loop") {
+		// Loop operations run until the test is interrupted, e.g. by a workload executor that stops the test after
+		// the cluster has been changed.
+		done := make(chan struct{})
+		stopped := make(chan struct{})
+		interrupted := make(chan os.Signal, 1)
+		signal.Notify(interrupted, os.Interrupt)
+		defer func() {
+			signal.Stop(interrupted)
+			close(stopped)
+		}()
+		go func() {
+			select {
+			case <-interrupted:
+				close(done)
+			case <-stopped:
+			}
+		}()
+		testCtx = WithLoopDone(testCtx, done)
+	}
 
 	defer func() {
 		// If anything fails while doing test cleanup, we only log the error because the actual test may have already
@@ -212,6 +245,11 @@ func runTestCase(mt *mtest.T, testFile TestFile, testCase *TestCase) {
 		assert.Nil(mt, err, "events verification failed at index %d: %v", idx, err)
 	}
 
+	for idx, expectedLogs := range testCase.ExpectedLogs {
+		err := VerifyLogMessages(testCtx, expectedLogs)
+		assert.Nil(mt, err, "log messages verification failed at index %d: %v", idx, err)
+	}
+
 	for idx, collData := range testCase.Outcome {
 		err := collData.VerifyContents(testCtx)
 		assert.Nil(mt, err, "error verifying outcome for collection %q at index %d: %v",
@@ -0,0 +1,223 @@
+// Copyright (C) MongoDB, Inc. 2021-present.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
+
+package mgocompat
+
+import (
+	"fmt"
+	"strings"
+
+	"go.mongodb.org/mongo-driver/bson"
+	bsonmgocompat "go.mongodb.org/mongo-driver/bson/mgocompat"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+var emptyDoc = bson.D{}
+
+// ChangeInfo is the equivalent of mgo's ChangeInfo. It holds details about the outcome of an update or remove
+// operation.
+type ChangeInfo struct {
+	// The number of documents that were updated. For compatibility with mgo, this includes documents that matched but
+	// were not modified.
+	Updated int
+
+	// The number of documents that were removed.
+	Removed int
+
+	// The number of documents that matched the selector.
+	Matched int
+
+	// The _id of the document that was inserted by an upsert, or nil.
+	UpsertedId interface{}
+}
+
+// Collection is the equivalent of mgo's Collection.
+type Collection struct {
+	Database *Database
+	Name     string
+	FullName string
+
+	coll *mongo.Collection
+}
+
+// Collection returns the mongo.Collection that the collection wraps.
+func (c *Collection) Collection() *mongo.Collection { return c.coll }
+
+// Find returns a Query for the documents that match query, which can be nil to match all documents.
+func (c *Collection) Find(query interface{}) *Query {
+	if query == nil {
+		query = emptyDoc
+	}
+	return &Query{coll: c, filter: query}
+}
+
+// FindId returns a Query for the document with the given _id.
+func (c *Collection) FindId(id interface{}) *Query { return c.Find(bson.D{{"_id", id}}) }
+
+// Count returns the number of documents in the collection.
+func (c *Collection) Count() (int, error) { return c.Find(nil).Count() }
+
+// Insert inserts the given documents. The documents are inserted in order and the insert stops at the first error.
+func (c *Collection) Insert(docs ...interface{}) error {
+	_, err := c.coll.InsertMany(c.Database.Session.ctx, docs)
+	return err
+}
+
+// Update updates the first document that matches selector with update, which can be a document with update operators
+// or a replacement document. ErrNotFound is returned if no document matched.
+func (c *Collection) Update(selector interface{}, update interface{}) error {
+	info, err := c.update(selector, update, false, false)
+	if err != nil {
+		return err
+	}
+	if info.Matched == 0 {
+		return ErrNotFound
+	}
+	return nil
+}
+
+// UpdateId updates the document with the given _id like Update.
+func (c *Collection) UpdateId(id interface{}, update interface{}) error {
+	return c.Update(bson.D{{"_id", id}}, update)
+}
+
+// UpdateAll updates all of the documents that match selector with update, which must be a document with update
+// operators.
+func (c *Collection) UpdateAll(selector interface{}, update interface{}) (*ChangeInfo, error) {
+	return c.update(selector, update, true, false)
+}
+
+// Upsert updates the first document that matches selector with update like Update, or inserts a document if no
+// document matched.
+func (c *Collection) Upsert(selector interface{}, update interface{}) (*ChangeInfo, error) {
+	return c.update(selector, update, false, true)
+}
+
+// UpsertId upserts the document with the given _id like Upsert.
+func (c *Collection) UpsertId(id interface{}, update interface{}) (*ChangeInfo, error) {
+	return c.Upsert(bson.D{{"_id", id}}, update)
+}
+
+func (c *Collection) update(selector, update interface{}, multi, upsert bool) (*ChangeInfo, error) {
+	ctx := c.Database.Session.ctx
+	if selector == nil {
+		selector = emptyDoc
+	}
+	operators, err := hasUpdateOperators(update)
+	if err != nil {
+		return nil, err
+	}
+
+	var res *mongo.UpdateResult
+	switch {
+	case multi:
+		res, err = c.coll.UpdateMany(ctx, selector, update)
+	case operators:
+		res, err = c.coll.UpdateOne(ctx, selector, update, options.Update().SetUpsert(upsert))
+	default:
+		res, err = c.coll.ReplaceOne(ctx, selector, update, options.Replace().SetUpsert(upsert))
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &ChangeInfo{
+		Updated:    int(res.MatchedCount),
+		Matched:    int(res.MatchedCount),
+		UpsertedId: res.UpsertedID,
+	}, nil
+}
+
+// hasUpdateOperators returns true if the first key of update is an update operator, i.e. starts with a $.
+func hasUpdateOperators(update interface{}) (bool, error) {
+	doc, err := bson.MarshalWithRegistry(bsonmgocompat.Registry, update)
+	if err != nil {
+		return false, err
+	}
+	elem, err := bson.Raw(doc).IndexErr(0)
+	if err != nil {
+		return false, fmt.Errorf("update document must not be empty")
+	}
+	return strings.HasPrefix(elem.Key(), "$"), nil
+}
+
+// Remove removes the first document that matches selector. ErrNotFound is returned if no document matched.
+func (c *Collection) Remove(selector interface{}) error {
+	if selector == nil {
+		selector = emptyDoc
+	}
+	res, err := c.coll.DeleteOne(c.Database.Session.ctx, selector)
+	if err != nil {
+		return err
+	}
+	if res.DeletedCount == 0 {
+		return ErrNotFound
+	}
+	return nil
+}
+
+// RemoveId removes the document with the given _id like Remove.
+func (c *Collection) RemoveId(id interface{}) error { return c.Remove(bson.D{{"_id", id}}) }
+
+// RemoveAll removes all of the documents that match selector, which can be nil to remove all documents.
+func (c *Collection) RemoveAll(selector interface{}) (*ChangeInfo, error) {
+	if selector == nil {
+		selector = emptyDoc
+	}
+	res, err := c.coll.DeleteMany(c.Database.Session.ctx, selector)
+	if err != nil {
+		return nil, err
+	}
+	return &ChangeInfo{Removed: int(res.DeletedCount), Matched: int(res.DeletedCount)}, nil
+}
+
+// EnsureIndexKey creates an index on the given keys if it does not exist. Each key is a field name, optionally
+// prefixed with "-" for a descending index or "+" for an ascending index, as accepted by Query.Sort.
+func (c *Collection) EnsureIndexKey(key ...string) error {
+	keys, err := sortDocument(key)
+	if err != nil {
+		return err
+	}
+	_, err = c.coll.Indexes().CreateOne(c.Database.Session.ctx, mongo.IndexModel{Keys: keys})
+	return err
+}
+
+// DropIndex drops the index on the given keys, which are specified like for EnsureIndexKey.
+func (c *Collection) DropIndex(key ...string) error {
+	keys, err := sortDocument(key)
+	if err != nil {
+		return err
+	}
+	parts := make([]string, 0, 2*len(keys))
+	for _, k := range keys {
+		parts = append(parts, k.Key, fmt.Sprint(k.Value))
+	}
+	_, err = c.coll.Indexes().DropOne(c.Database.Session.ctx, strings.Join(parts, "_"))
+	return err
+}
+
+// DropCollection drops the collection.
+func (c *Collection) DropCollection() error { return c.coll.Drop(c.Database.Session.ctx) }
+
+// sortDocument converts mgo-style sort or index keys, e.g. []string{"-age", "name"}, into a document such as
+// {age: -1, name: 1}.
+func sortDocument(fields []string) (bson.D, error) {
+	doc := make(bson.D, 0, len(fields))
+	for _, field := range fields {
+		dir := 1
+		switch {
+		case strings.HasPrefix(field, "-"):
+			dir, field = -1, field[1:]
+		case strings.HasPrefix(field, "+"):
+			field = field[1:]
+		}
+		if field == "" {
+			return nil, fmt.Errorf("sort key must not be empty")
+		}
+		doc = append(doc, bson.E{Key: field, Value: dir})
+	}
+	return doc, nil
+}
@@ -0,0 +1,136 @@
+// Copyright (C) MongoDB, Inc. 2021-present.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
+
+package mgocompat
+
+import (
+	"context"
+	"testing"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/internal/testutil/assert"
+	"go.mongodb.org/mongo-driver/mongo/mongotest"
+)
+
+func TestFacade(t *testing.T) {
+	newSession := func(t *testing.T) (*mongotest.Deployment, *Session) {
+		t.Helper()
+
+		d := mongotest.New()
+		client, err := d.NewClient()
+		assert.Nil(t, err, "NewClient error: %v", err)
+		return d, NewSession(client)
+	}
+	type person struct {
+		Name string
+		Age  int
+	}
+
+	t.Run("find with sort and limit", func(t *testing.T) {
+		d, session := newSession(t)
+		defer disconnect(session)
+		d.AddReplies(mongotest.CursorReply("test.people", bson.D{{"name", "alice"}, {"age", 40}}))
+
+		var people []person
+		err := session.DB("test").C("people").Find(bson.M{"age": bson.M{"$gt": 30}}).
+			Sort("-age", "+name").Skip(5).Limit(10).All(&people)
+		assert.Nil(t, err, "All error: %v", err)
+		assert.Equal(t, []person{{"alice", 40}}, people, "unexpected results %v", people)
+
+		cmd := d.Commands()[0].Document
+		assert.Equal(t, "people", cmd.Lookup("find").StringValue(), "unexpected command %v", cmd)
+		wantSort := bson.Raw(mustMarshal(t, bson.D{{"age", -1}, {"name", 1}}))
+		assert.Equal(t, wantSort, cmd.Lookup("sort").Document(), "expected sort %v, got %v", wantSort,
+			cmd.Lookup("sort"))
+		assert.Equal(t, int64(5), cmd.Lookup("skip").Int64(), "expected skip 5, got %v", cmd.Lookup("skip"))
+		assert.Equal(t, int64(10), cmd.Lookup("limit").Int64(), "expected limit 10, got %v", cmd.Lookup("limit"))
+	})
+	t.Run("One returns ErrNotFound", func(t *testing.T) {
+		d, session := newSession(t)
+		defer disconnect(session)
+		d.AddReplies(mongotest.CursorReply("test.people"))
+
+		var p person
+		err := session.DB("test").C("people").FindId(1).One(&p)
+		assert.Equal(t, ErrNotFound, err, "expected error %v, got %v", ErrNotFound, err)
+	})
+	t.Run("Update", func(t *testing.T) {
+		testCases := []struct {
+			name    string
+			update  interface{}
+			matched int32
+			wantErr error
+			wantOp  bool
+		}{
+			{"operators", bson.M{"$set": bson.M{"age": 41}}, 1, nil, true},
+			{"replacement", person{"alice", 41}, 1, nil, false},
+			{"not found", bson.M{"$set": bson.M{"age": 41}}, 0, ErrNotFound, true},
+		}
+		for _, tc := range testCases {
+			t.Run(tc.name, func(t *testing.T) {
+				d, session := newSession(t)
+				defer disconnect(session)
+				d.AddReplies(mongotest.SuccessReply(bson.E{"n", tc.matched}, bson.E{"nModified", tc.matched}))
+
+				err := session.DB("test").C("people").Update(bson.M{"name": "alice"}, tc.update)
+				assert.Equal(t, tc.wantErr, err, "expected error %v, got %v", tc.wantErr, err)
+
+				update := d.Commands()[0].Document.Lookup("updates").Array().Index(0).Value().Document().Lookup("u")
+				elems, _ := update.Document().Elements()
+				isOp := elems[0].Key()[0] == '$'
+				assert.Equal(t, tc.wantOp, isOp, "expected operator update %v, got %v", tc.wantOp, update)
+			})
+		}
+	})
+	t.Run("RemoveAll", func(t *testing.T) {
+		d, session := newSession(t)
+		defer disconnect(session)
+		d.AddReplies(mongotest.SuccessReply(bson.E{"n", 3}))
+
+		info, err := session.DB("test").C("people").RemoveAll(nil)
+		assert.Nil(t, err, "RemoveAll error: %v", err)
+		assert.Equal(t, 3, info.Removed, "expected 3 documents removed, got %v", info.Removed)
+	})
+	t.Run("Iter", func(t *testing.T) {
+		d, session := newSession(t)
+		defer disconnect(session)
+		d.AddReplies(mongotest.CursorReply("test.people", bson.D{{"name", "alice"}}, bson.D{{"name", "bob"}}))
+
+		iter := session.DB("test").C("people").Find(nil).Iter()
+		var names []string
+		var p person
+		for iter.Next(&p) {
+			names = append(names, p.Name)
+		}
+		err := iter.Close()
+		assert.Nil(t, err, "Close error: %v", err)
+		assert.Equal(t, []string{"alice", "bob"}, names, "unexpected names %v", names)
+	})
+	t.Run("invalid sort key", func(t *testing.T) {
+		_, session := newSession(t)
+		defer disconnect(session)
+		err := session.DB("test").C("people").Find(nil).Sort("-").All(&[]person{})
+		assert.NotNil(t, err, "expected error for empty sort key, got nil")
+	})
+	t.Run("distinct with invalid hint key", func(t *testing.T) {
+		d, session := newSession(t)
+		defer disconnect(session)
+		var names []string
+		err := session.DB("test").C("people").Find(nil).Hint("-").Distinct("name", &names)
+		assert.NotNil(t, err, "expected error for empty hint key, got nil")
+		assert.Equal(t, 0, len(d.Commands()), "expected no commands to be sent, got %v", len(d.Commands()))
+	})
+}
+
+func disconnect(session *Session) { _ = session.Client().Disconnect(context.Background()) }
+
+func mustMarshal(t *testing.T, val interface{}) []byte {
+	t.Helper()
+
+	b, err := bson.Marshal(val)
+	assert.Nil(t, err, "Marshal error: %v", err)
+	return b
+}
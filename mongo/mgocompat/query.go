@@ -0,0 +1,235 @@
+// Copyright (C) MongoDB, Inc. 2021-present.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
+
+package mgocompat
+
+import (
+	"context"
+
+	"go.mongodb.org/mongo-driver/bson"
+	bsonmgocompat "go.mongodb.org/mongo-driver/bson/mgocompat"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// Query is the equivalent of mgo's Query. It is created by Collection.Find and configured by chaining calls, e.g.
+// Find(query).Sort("-age").Skip(10).Limit(10).All(&results). A Query is not safe for concurrent use.
+type Query struct {
+	coll       *Collection
+	filter     interface{}
+	sort       []string
+	projection interface{}
+	hint       interface{}
+	skip       int64
+	limit      int64
+	batchSize  int32
+	err        error
+}
+
+// Sort sets the sort order of the results. Each field is a field name, optionally prefixed with "-" for descending
+// order or "+" for ascending order, e.g. Sort("-age", "name").
+func (q *Query) Sort(fields ...string) *Query {
+	q.sort = fields
+	return q
+}
+
+// Select sets the projection of the results, e.g. Select(bson.M{"name": 1}).
+func (q *Query) Select(selector interface{}) *Query {
+	q.projection = selector
+	return q
+}
+
+// Skip sets the number of documents to skip.
+func (q *Query) Skip(n int) *Query {
+	q.skip = int64(n)
+	return q
+}
+
+// Limit sets the maximum number of documents to return. A limit of 0 means no limit.
+func (q *Query) Limit(n int) *Query {
+	q.limit = int64(n)
+	return q
+}
+
+// Batch sets the number of documents returned in each batch.
+func (q *Query) Batch(n int) *Query {
+	q.batchSize = int32(n)
+	return q
+}
+
+// Hint sets the index to use, specified like the fields of Sort.
+func (q *Query) Hint(indexKey ...string) *Query {
+	q.hint, q.err = sortDocument(indexKey)
+	return q
+}
+
+// One decodes the first document of the results into result, which can be nil to only check that a document exists.
+// ErrNotFound is returned if there are no results.
+func (q *Query) One(result interface{}) error {
+	opts, err := q.findOneOptions()
+	if err != nil {
+		return err
+	}
+	res := q.coll.coll.FindOne(q.ctx(), q.filter, opts)
+	if res.Err() == mongo.ErrNoDocuments {
+		return ErrNotFound
+	}
+	if result == nil {
+		return res.Err()
+	}
+	return res.Decode(result)
+}
+
+// All decodes all of the results into result, which must be a pointer to a slice.
+func (q *Query) All(result interface{}) error {
+	cursor, err := q.cursor()
+	if err != nil {
+		return err
+	}
+	return cursor.All(q.ctx(), result)
+}
+
+// Count returns the number of documents that match the query, taking Skip and Limit into account.
+func (q *Query) Count() (int, error) {
+	if q.err != nil {
+		return 0, q.err
+	}
+	opts := options.Count()
+	if q.skip != 0 {
+		opts.SetSkip(q.skip)
+	}
+	if q.limit != 0 {
+		opts.SetLimit(q.limit)
+	}
+	if q.hint != nil {
+		opts.SetHint(q.hint)
+	}
+	n, err := q.coll.coll.CountDocuments(q.ctx(), q.filter, opts)
+	return int(n), err
+}
+
+// Distinct decodes the distinct values of key in the results into result, which must be a pointer to a slice.
+func (q *Query) Distinct(key string, result interface{}) error {
+	if q.err != nil {
+		return q.err
+	}
+	values, err := q.coll.coll.Distinct(q.ctx(), key, q.filter)
+	if err != nil {
+		return err
+	}
+	doc, err := bson.MarshalWithRegistry(bsonmgocompat.Registry, bson.D{{"values", values}})
+	if err != nil {
+		return err
+	}
+	return bson.Raw(doc).Lookup("values").UnmarshalWithRegistry(bsonmgocompat.Registry, result)
+}
+
+// Iter returns an iterator over the results. The iterator must be closed with Close.
+func (q *Query) Iter() *Iter {
+	cursor, err := q.cursor()
+	return &Iter{ctx: q.ctx(), cursor: cursor, err: err}
+}
+
+func (q *Query) ctx() context.Context { return q.coll.Database.Session.ctx }
+
+func (q *Query) cursor() (*mongo.Cursor, error) {
+	if q.err != nil {
+		return nil, q.err
+	}
+	opts := options.Find()
+	if len(q.sort) > 0 {
+		sort, err := sortDocument(q.sort)
+		if err != nil {
+			return nil, err
+		}
+		opts.SetSort(sort)
+	}
+	if q.projection != nil {
+		opts.SetProjection(q.projection)
+	}
+	if q.hint != nil {
+		opts.SetHint(q.hint)
+	}
+	if q.skip != 0 {
+		opts.SetSkip(q.skip)
+	}
+	if q.limit != 0 {
+		opts.SetLimit(q.limit)
+	}
+	if q.batchSize != 0 {
+		opts.SetBatchSize(q.batchSize)
+	}
+	return q.coll.coll.Find(q.ctx(), q.filter, opts)
+}
+
+func (q *Query) findOneOptions() (*options.FindOneOptions, error) {
+	if q.err != nil {
+		return nil, q.err
+	}
+	opts := options.FindOne()
+	if len(q.sort) > 0 {
+		sort, err := sortDocument(q.sort)
+		if err != nil {
+			return nil, err
+		}
+		opts.SetSort(sort)
+	}
+	if q.projection != nil {
+		opts.SetProjection(q.projection)
+	}
+	if q.hint != nil {
+		opts.SetHint(q.hint)
+	}
+	if q.skip != 0 {
+		opts.SetSkip(q.skip)
+	}
+	return opts, nil
+}
+
+// Iter is the equivalent of mgo's Iter. It iterates over the results of a Query.
+type Iter struct {
+	ctx    context.Context
+	cursor *mongo.Cursor
+	err    error
+}
+
+// Next decodes the next document into result and returns true, or returns false if there are no more documents or an
+// error occurred, in which case Err returns the error.
+func (it *Iter) Next(result interface{}) bool {
+	if it.err != nil || it.cursor == nil {
+		return false
+	}
+	if !it.cursor.Next(it.ctx) {
+		it.err = it.cursor.Err()
+		return false
+	}
+	if err := it.cursor.Decode(result); err != nil {
+		it.err = err
+		return false
+	}
+	return true
+}
+
+// All decodes the remaining documents into result, which must be a pointer to a slice, and closes the iterator.
+func (it *Iter) All(result interface{}) error {
+	if it.err != nil {
+		return it.err
+	}
+	return it.cursor.All(it.ctx, result)
+}
+
+// Err returns the error that stopped the iteration, if any.
+func (it *Iter) Err() error { return it.err }
+
+// Close closes the iterator and returns the error that stopped the iteration, if any.
+func (it *Iter) Close() error {
+	if it.cursor != nil {
+		if err := it.cursor.Close(it.ctx); err != nil && it.err == nil {
+			it.err = err
+		}
+	}
+	return it.err
+}
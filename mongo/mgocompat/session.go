@@ -0,0 +1,128 @@
+// Copyright (C) MongoDB, Inc. 2021-present.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
+
+// Package mgocompat provides a facade over a mongo.Client with the session, database, collection, and query API of
+// globalsign/mgo, to ease the migration of code written for mgo. The common mgo calls are mapped onto the operations
+// of the driver, e.g.
+//
+//	session := mgocompat.NewSession(client)
+//	var people []Person
+//	err := session.DB("test").C("people").Find(bson.M{"age": bson.M{"$gt": 30}}).Sort("-age", "name").Limit(10).All(&people)
+//
+// is executed as a find with the sort {age: -1, name: 1} and a limit of 10.
+//
+// Documents are encoded and decoded with the Registry of the bson/mgocompat package, so types written for mgo's bson
+// package behave the same way. The mgo API does not take a context, so operations use the context of the Session,
+// which can be set with WithContext.
+//
+// The facade is a migration aid: it does not cover all of the mgo API, and code should be moved to the driver's API
+// over time. A Session is safe for concurrent use, but the Query and Iter types are not.
+package mgocompat
+
+import (
+	"context"
+	"errors"
+
+	bsonmgocompat "go.mongodb.org/mongo-driver/bson/mgocompat"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"go.mongodb.org/mongo-driver/mongo/readpref"
+)
+
+// ErrNotFound is returned by operations that expect to find a document, such as Query.One and Collection.Update, if
+// no document matched. It is equivalent to mgo's ErrNotFound.
+var ErrNotFound = errors.New("not found")
+
+// Session is the equivalent of mgo's Session. It wraps a mongo.Client, which manages the connections, so copying and
+// closing sessions does not open or close any connections.
+type Session struct {
+	client *mongo.Client
+	ctx    context.Context
+}
+
+// NewSession creates a Session for client. The client must be connected.
+func NewSession(client *mongo.Client) *Session {
+	return &Session{client: client, ctx: context.Background()}
+}
+
+// WithContext returns a copy of the session whose operations use ctx.
+func (s *Session) WithContext(ctx context.Context) *Session {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	return &Session{client: s.client, ctx: ctx}
+}
+
+// Client returns the mongo.Client of the session.
+func (s *Session) Client() *mongo.Client { return s.client }
+
+// Copy returns a copy of the session. It exists for compatibility with mgo code that copies a session per request.
+func (s *Session) Copy() *Session { return s.WithContext(s.ctx) }
+
+// Clone is the same as Copy.
+func (s *Session) Clone() *Session { return s.Copy() }
+
+// Close does nothing. The connections are owned by the client, which must be disconnected by the application.
+func (s *Session) Close() {}
+
+// Ping runs a ping command against the primary.
+func (s *Session) Ping() error { return s.client.Ping(s.ctx, readpref.Primary()) }
+
+// DatabaseNames returns the names of the databases on the server.
+func (s *Session) DatabaseNames() ([]string, error) {
+	return s.client.ListDatabaseNames(s.ctx, emptyDoc)
+}
+
+// DB returns the database with the given name.
+func (s *Session) DB(name string) *Database {
+	return &Database{
+		Name:    name,
+		Session: s,
+		db:      s.client.Database(name, options.Database().SetRegistry(bsonmgocompat.Registry)),
+	}
+}
+
+// Database is the equivalent of mgo's Database.
+type Database struct {
+	Name    string
+	Session *Session
+
+	db *mongo.Database
+}
+
+// Database returns the mongo.Database that the database wraps.
+func (d *Database) Database() *mongo.Database { return d.db }
+
+// C returns the collection with the given name.
+func (d *Database) C(name string) *Collection {
+	return &Collection{
+		Database: d,
+		Name:     name,
+		FullName: d.Name + "." + name,
+		coll:     d.db.Collection(name),
+	}
+}
+
+// Run runs cmd against the database and decodes the reply into result, which can be nil. If cmd is a string, it is
+// run as the command {cmd: 1}.
+func (d *Database) Run(cmd interface{}, result interface{}) error {
+	if name, ok := cmd.(string); ok {
+		cmd = map[string]interface{}{name: 1}
+	}
+	res := d.db.RunCommand(d.Session.ctx, cmd)
+	if result == nil {
+		return res.Err()
+	}
+	return res.Decode(result)
+}
+
+// CollectionNames returns the names of the collections in the database.
+func (d *Database) CollectionNames() ([]string, error) {
+	return d.db.ListCollectionNames(d.Session.ctx, emptyDoc)
+}
+
+// DropDatabase drops the database.
+func (d *Database) DropDatabase() error { return d.db.Drop(d.Session.ctx) }
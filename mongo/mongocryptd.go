@@ -8,8 +8,10 @@ package mongo
 
 import (
 	"context"
+	"fmt"
 	"os/exec"
 	"strings"
+	"sync"
 	"time"
 
 	"go.mongodb.org/mongo-driver/mongo/options"
@@ -30,9 +32,14 @@ var databaseOpts = options.Database().SetReadConcern(readconcern.New()).SetReadP
 
 type mcryptClient struct {
 	bypassSpawn bool
-	client      *Client
+	healthCheck bool
 	path        string
 	spawnArgs   []string
+
+	// clients contains one Client per mongocryptd URI. The Client at index current is used to mark commands.
+	clients []*Client
+	mu      sync.Mutex
+	current int
 }
 
 func newMcryptClient(opts *options.AutoEncryptionOptions) (*mcryptClient, error) {
@@ -46,10 +53,13 @@ func newMcryptClient(opts *options.AutoEncryptionOptions) (*mcryptClient, error)
 		bypassAutoEncryption = *opts.BypassAutoEncryption
 	}
 
+	// a pool of mongocryptd URIs refers to processes that were spawned in advance
+	uris := opts.MongocryptdURIs
 	mc := &mcryptClient{
-		// mongocryptd should not be spawned if mongocryptdBypassSpawn is passed or if bypassAutoEncryption is
-		// specified because it is not used during decryption
-		bypassSpawn: bypassSpawn || bypassAutoEncryption,
+		// mongocryptd should not be spawned if mongocryptdBypassSpawn is passed, if bypassAutoEncryption is
+		// specified because it is not used during decryption, or if a pool of mongocryptd URIs is specified
+		bypassSpawn: bypassSpawn || bypassAutoEncryption || len(uris) > 0,
+		healthCheck: opts.MongocryptdHealthCheck != nil && *opts.MongocryptdHealthCheck,
 	}
 
 	if !mc.bypassSpawn {
@@ -59,25 +69,66 @@ func newMcryptClient(opts *options.AutoEncryptionOptions) (*mcryptClient, error)
 		}
 	}
 
-	// get connection string
-	uri := defaultURI
-	if u, ok := opts.ExtraOptions["mongocryptdURI"]; ok {
-		uri = u.(string)
+	// get connection strings
+	if len(uris) == 0 {
+		uri := defaultURI
+		if u, ok := opts.ExtraOptions["mongocryptdURI"]; ok {
+			uri = u.(string)
+		}
+		uris = []string{uri}
 	}
 
-	// create client
-	client, err := NewClient(options.Client().ApplyURI(uri).SetServerSelectionTimeout(defaultServerSelectionTimeout))
-	if err != nil {
-		return nil, err
+	// create clients
+	for _, uri := range uris {
+		client, err := NewClient(options.Client().ApplyURI(uri).SetServerSelectionTimeout(defaultServerSelectionTimeout))
+		if err != nil {
+			return nil, err
+		}
+		mc.clients = append(mc.clients, client)
 	}
-	mc.client = client
 
 	return mc, nil
 }
 
+// client returns the Client that is currently used to mark commands.
+func (mc *mcryptClient) client() *Client {
+	mc.mu.Lock()
+	defer mc.mu.Unlock()
+
+	return mc.clients[mc.current]
+}
+
+// failover switches to the next healthy mongocryptd of the pool after the current one, other than failed, could not
+// be reached. It returns false if there is no other mongocryptd or none of them is healthy.
+func (mc *mcryptClient) failover(ctx context.Context, failed *Client) bool {
+	mc.mu.Lock()
+	start := mc.current
+	mc.mu.Unlock()
+
+	for i := 1; i < len(mc.clients); i++ {
+		idx := (start + i) % len(mc.clients)
+		if mc.clients[idx] == failed || mc.ping(ctx, mc.clients[idx]) != nil {
+			continue
+		}
+
+		mc.mu.Lock()
+		mc.current = idx
+		mc.mu.Unlock()
+		return true
+	}
+	return false
+}
+
+// ping checks whether the mongocryptd that client is connected to is reachable.
+func (mc *mcryptClient) ping(ctx context.Context, client *Client) error {
+	return client.Database("admin", databaseOpts).RunCommand(ctx, bsoncore.NewDocumentBuilder().
+		AppendInt32("ping", 1).Build()).Err()
+}
+
 // markCommand executes the given command on mongocryptd.
 func (mc *mcryptClient) markCommand(ctx context.Context, dbName string, cmd bsoncore.Document) (bsoncore.Document, error) {
-	db := mc.client.Database(dbName, databaseOpts)
+	client := mc.client()
+	db := client.Database(dbName, databaseOpts)
 
 	res, err := db.RunCommand(ctx, cmd).DecodeBytes()
 	// propagate original result
@@ -85,13 +136,21 @@ func (mc *mcryptClient) markCommand(ctx context.Context, dbName string, cmd bson
 		return bsoncore.Document(res), nil
 	}
 	// wrap original error
-	if mc.bypassSpawn || !strings.Contains(err.Error(), serverSelectionTimeoutStr) {
+	if !strings.Contains(err.Error(), serverSelectionTimeoutStr) {
 		return nil, MongocryptdError{Wrapped: err}
 	}
 
-	// re-spawn and retry
-	if err = mc.spawnProcess(); err != nil {
-		return nil, err
+	switch {
+	case mc.failover(ctx, client):
+		// retry on another mongocryptd of the pool
+		db = mc.client().Database(dbName, databaseOpts)
+	case mc.bypassSpawn:
+		return nil, MongocryptdError{Wrapped: err}
+	default:
+		// re-spawn and retry
+		if err = mc.spawnProcess(); err != nil {
+			return nil, err
+		}
 	}
 	res, err = db.RunCommand(ctx, cmd).DecodeBytes()
 	if err != nil {
@@ -100,14 +159,42 @@ func (mc *mcryptClient) markCommand(ctx context.Context, dbName string, cmd bson
 	return bsoncore.Document(res), nil
 }
 
-// connect connects the underlying Client instance. This must be called before performing any mark operations.
+// connect connects the underlying Client instances. This must be called before performing any mark operations. If
+// health checks are enabled, the first mongocryptd that responds to a ping is used and an error is returned if none of
+// them respond.
 func (mc *mcryptClient) connect(ctx context.Context) error {
-	return mc.client.Connect(ctx)
+	for _, client := range mc.clients {
+		if err := client.Connect(ctx); err != nil {
+			return err
+		}
+	}
+	if !mc.healthCheck {
+		return nil
+	}
+
+	var errs []string
+	for idx, client := range mc.clients {
+		err := mc.ping(ctx, client)
+		if err == nil {
+			mc.mu.Lock()
+			mc.current = idx
+			mc.mu.Unlock()
+			return nil
+		}
+		errs = append(errs, err.Error())
+	}
+	return MongocryptdError{Wrapped: fmt.Errorf("mongocryptd health check failed: %s", strings.Join(errs, "; "))}
 }
 
-// disconnect disconnects the underlying Client instance. This should be called after all operations have completed.
+// disconnect disconnects the underlying Client instances. This should be called after all operations have completed.
 func (mc *mcryptClient) disconnect(ctx context.Context) error {
-	return mc.client.Disconnect(ctx)
+	var err error
+	for _, client := range mc.clients {
+		if derr := client.Disconnect(ctx); derr != nil && err == nil {
+			err = derr
+		}
+	}
+	return err
 }
 
 func (mc *mcryptClient) spawnProcess() error {
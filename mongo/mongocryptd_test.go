@@ -0,0 +1,49 @@
+// Copyright (C) MongoDB, Inc. 2021-present.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
+
+package mongo
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"go.mongodb.org/mongo-driver/internal/testutil/assert"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+func TestMongocryptd(t *testing.T) {
+	// Nothing listens on these ports, so the health checks fail.
+	uris := []string{"mongodb://localhost:27099", "mongodb://localhost:27098"}
+
+	t.Run("URI pool", func(t *testing.T) {
+		mc, err := newMcryptClient(options.AutoEncryption().SetMongocryptdURIs(uris...))
+		assert.Nil(t, err, "newMcryptClient error: %v", err)
+		assert.True(t, mc.bypassSpawn, "expected spawning to be bypassed for a URI pool")
+		assert.False(t, mc.healthCheck, "expected health check to be disabled by default")
+		assert.Equal(t, len(uris), len(mc.clients), "expected %v clients, got %v", len(uris), len(mc.clients))
+		assert.True(t, mc.client() == mc.clients[0], "expected the first client to be used")
+	})
+	t.Run("default URI", func(t *testing.T) {
+		opts := options.AutoEncryption().SetExtraOptions(map[string]interface{}{"mongocryptdBypassSpawn": true})
+		mc, err := newMcryptClient(opts)
+		assert.Nil(t, err, "newMcryptClient error: %v", err)
+		assert.Equal(t, 1, len(mc.clients), "expected 1 client, got %v", len(mc.clients))
+	})
+	t.Run("health check failure", func(t *testing.T) {
+		opts := options.AutoEncryption().SetMongocryptdURIs(uris...).SetMongocryptdHealthCheck(true)
+		mc, err := newMcryptClient(opts)
+		assert.Nil(t, err, "newMcryptClient error: %v", err)
+
+		ctx, cancel := context.WithTimeout(context.Background(), 200*time.Millisecond)
+		defer cancel()
+		err = mc.connect(ctx)
+		_, ok := err.(MongocryptdError)
+		assert.True(t, ok, "expected error type %T, got %v", MongocryptdError{}, err)
+		assert.False(t, mc.failover(ctx, mc.client()), "expected failover to fail without a healthy mongocryptd")
+		_ = mc.disconnect(context.Background())
+	})
+}
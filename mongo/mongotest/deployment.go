@@ -0,0 +1,387 @@
+// Copyright (C) MongoDB, Inc. 2021-present.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
+
+// Package mongotest provides an in-memory deployment that can be used to unit test code that uses a mongo.Client
+// without a running server. The deployment records every command it receives and replies with canned responses, so
+// tests can assert on the commands sent by the code under test and control the results it sees.
+//
+// A typical test creates a Deployment, queues the replies the code under test needs, and inspects the recorded
+// commands afterwards:
+//
+//	d := mongotest.New()
+//	client, err := d.NewClient()
+//	if err != nil {
+//		t.Fatal(err)
+//	}
+//	defer client.Disconnect(context.Background())
+//
+//	d.AddReplies(mongotest.CursorReply("db.users", bson.D{{"name", "alice"}}))
+//	name, err := lookupName(client, "alice") // code under test
+//	...
+//	cmd := d.Commands()[0] // cmd.Name == "find"
+//
+// The deployment does not interpret commands, so replies must be queued in the order the commands are sent or computed
+// by a handler set with SetHandler. Replies are never sent for unacknowledged writes.
+//
+// The package also provides a FaultDialer that injects network errors, dropped, delayed, and duplicated responses into
+// the connections of a client that uses a real deployment, which can be used to test retry and timeout handling.
+package mongotest
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"sync"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/address"
+	"go.mongodb.org/mongo-driver/mongo/description"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"go.mongodb.org/mongo-driver/x/bsonx/bsoncore"
+	"go.mongodb.org/mongo-driver/x/mongo/driver"
+	"go.mongodb.org/mongo-driver/x/mongo/driver/topology"
+	"go.mongodb.org/mongo-driver/x/mongo/driver/wiremessage"
+)
+
+const (
+	serverAddress                = address.Address("localhost:27017")
+	sessionTimeoutMinutes uint32 = 30
+)
+
+// serverDescription is the description of every connection to the deployment. It describes a replica set primary
+// so sessions and transactions can be used.
+var serverDescription = description.Server{
+	Addr:                  serverAddress,
+	CanonicalAddr:         serverAddress,
+	MaxDocumentSize:       16777216,
+	MaxMessageSize:        48000000,
+	MaxBatchCount:         100000,
+	SessionTimeoutMinutes: sessionTimeoutMinutes,
+	Kind:                  description.RSPrimary,
+	WireVersion: &description.VersionRange{
+		Max: topology.SupportedWireVersions.Max,
+	},
+}
+
+// Command is a command received by a Deployment.
+type Command struct {
+	// The name of the command, e.g. "find" or "insert".
+	Name string
+
+	// The database the command was run against.
+	Database string
+
+	// The command document. Documents sent in document sequences, such as the documents of an insert command, are
+	// included as arrays so the document looks like the command in the MongoDB documentation.
+	Document bson.Raw
+}
+
+// Handler computes the reply to a command. It is used for commands that arrive when no replies are queued. If it
+// returns nil, the command fails with an error.
+type Handler func(cmd Command) bson.D
+
+// Deployment is an in-memory driver.Deployment with a single server. It is safe for concurrent use.
+type Deployment struct {
+	mu       sync.Mutex
+	replies  []bson.D
+	handler  Handler
+	commands []Command
+	updates  []chan description.Topology
+}
+
+var _ driver.Deployment = &Deployment{}
+var _ driver.Server = &Deployment{}
+var _ driver.Connector = &Deployment{}
+var _ driver.Disconnector = &Deployment{}
+var _ driver.Subscriber = &Deployment{}
+
+// New creates a new Deployment without any queued replies.
+func New() *Deployment {
+	return &Deployment{}
+}
+
+// NewClient creates and connects a mongo.Client that sends all commands to the deployment. The opts parameter can be
+// used to configure the client, but options that configure the topology, e.g. the hosts to connect to, cannot be used.
+func (d *Deployment) NewClient(opts ...*options.ClientOptions) (*mongo.Client, error) {
+	opts = append(opts, &options.ClientOptions{Deployment: d})
+	client, err := mongo.NewClient(opts...)
+	if err != nil {
+		return nil, err
+	}
+	if err = client.Connect(context.Background()); err != nil {
+		return nil, err
+	}
+	return client, nil
+}
+
+// AddReplies queues replies to be sent for the next commands, in order.
+func (d *Deployment) AddReplies(replies ...bson.D) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	d.replies = append(d.replies, replies...)
+}
+
+// SetHandler sets the handler used to reply to commands that arrive when no replies are queued.
+func (d *Deployment) SetHandler(h Handler) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	d.handler = h
+}
+
+// Commands returns the commands received by the deployment, in the order they were received.
+func (d *Deployment) Commands() []Command {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	return append([]Command(nil), d.commands...)
+}
+
+// Reset removes all queued replies and recorded commands.
+func (d *Deployment) Reset() {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	d.replies = nil
+	d.commands = nil
+}
+
+// SelectServer implements the driver.Deployment interface. The deployment has a single server, which is selected for
+// every operation.
+func (d *Deployment) SelectServer(context.Context, description.ServerSelector) (driver.Server, error) {
+	return d, nil
+}
+
+// Kind implements the driver.Deployment interface. It always returns description.Single.
+func (d *Deployment) Kind() description.TopologyKind {
+	return description.Single
+}
+
+// Connection implements the driver.Server interface.
+func (d *Deployment) Connection(context.Context) (driver.Connection, error) {
+	return &connection{deployment: d}, nil
+}
+
+// Connect is a no-op method which implements the driver.Connector interface.
+func (d *Deployment) Connect() error {
+	return nil
+}
+
+// Disconnect implements the driver.Disconnector interface. It closes the subscriptions to the deployment.
+func (d *Deployment) Disconnect(context.Context) error {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	for _, updates := range d.updates {
+		close(updates)
+	}
+	d.updates = nil
+	return nil
+}
+
+// Subscribe implements the driver.Subscriber interface. The subscription receives a single topology description that
+// enables sessions.
+func (d *Deployment) Subscribe() (*driver.Subscription, error) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	updates := make(chan description.Topology, 1)
+	updates <- description.Topology{
+		Kind:                  description.Single,
+		Servers:               []description.Server{serverDescription},
+		SessionTimeoutMinutes: sessionTimeoutMinutes,
+	}
+	d.updates = append(d.updates, updates)
+	return &driver.Subscription{Updates: updates}, nil
+}
+
+// Unsubscribe is a no-op method which implements the driver.Subscriber interface.
+func (d *Deployment) Unsubscribe(*driver.Subscription) error {
+	return nil
+}
+
+// roundTrip records cmd and returns the reply to send for it.
+func (d *Deployment) roundTrip(cmd Command, expectReply bool) bson.D {
+	d.mu.Lock()
+	d.commands = append(d.commands, cmd)
+	if !expectReply {
+		d.mu.Unlock()
+		return nil
+	}
+	if len(d.replies) > 0 {
+		reply := d.replies[0]
+		d.replies = d.replies[1:]
+		d.mu.Unlock()
+		return reply
+	}
+	handler := d.handler
+	d.mu.Unlock()
+
+	// The handler is called without holding the lock so it can use the Deployment.
+	var reply bson.D
+	if handler != nil {
+		reply = handler(cmd)
+	}
+	if reply == nil {
+		reply = ErrorReply(0, fmt.Sprintf("mongotest: no reply for command %q", cmd.Name))
+	}
+	return reply
+}
+
+// connection implements the driver.Connection interface by passing each command to a Deployment and returning the
+// reply computed by the Deployment.
+type connection struct {
+	deployment *Deployment
+	reply      []byte
+}
+
+var _ driver.Connection = &connection{}
+
+// WriteWireMessage implements the driver.Connection interface. Only OP_MSG wire messages are supported.
+func (c *connection) WriteWireMessage(_ context.Context, wm []byte) error {
+	_, requestID, _, opcode, rem, ok := wiremessage.ReadHeader(wm)
+	if !ok {
+		return errors.New("mongotest: malformed wire message header")
+	}
+	if opcode != wiremessage.OpMsg {
+		return fmt.Errorf("mongotest: unsupported wire message opcode %v", opcode)
+	}
+
+	flags, rem, ok := wiremessage.ReadMsgFlags(rem)
+	if !ok {
+		return errors.New("mongotest: malformed OP_MSG flags")
+	}
+	cmd, err := readCommand(rem, flags&wiremessage.ChecksumPresent == wiremessage.ChecksumPresent)
+	if err != nil {
+		return err
+	}
+
+	// The server does not reply to a message with the moreToCome flag set, which is used for unacknowledged writes.
+	moreToCome := flags&wiremessage.MoreToCome == wiremessage.MoreToCome
+	replyDoc := c.deployment.roundTrip(cmd, !moreToCome)
+	if moreToCome {
+		return nil
+	}
+	reply, err := bson.Marshal(replyDoc)
+	if err != nil {
+		return fmt.Errorf("mongotest: cannot marshal reply to command %q: %v", cmd.Name, err)
+	}
+
+	idx, dst := wiremessage.AppendHeaderStart(nil, wiremessage.NextRequestID(), requestID, wiremessage.OpMsg)
+	dst = wiremessage.AppendMsgFlags(dst, 0)
+	dst = wiremessage.AppendMsgSectionType(dst, wiremessage.SingleDocument)
+	dst = append(dst, reply...)
+	c.reply = bsoncore.UpdateLength(dst, idx, int32(len(dst[idx:])))
+	return nil
+}
+
+// ReadWireMessage implements the driver.Connection interface. It returns the reply to the last command written to the
+// connection.
+func (c *connection) ReadWireMessage(_ context.Context, dst []byte) ([]byte, error) {
+	if c.reply == nil {
+		return dst, errors.New("mongotest: no reply to read")
+	}
+	dst = append(dst, c.reply...)
+	c.reply = nil
+	return dst, nil
+}
+
+// Description returns a fixed server description for the connection.
+func (*connection) Description() description.Server {
+	return serverDescription
+}
+
+// Close is a no-op method which implements the driver.Connection interface.
+func (*connection) Close() error {
+	return nil
+}
+
+// ID returns a fixed identifier for the connection.
+func (*connection) ID() string {
+	return "<mongotest_connection>"
+}
+
+// Address returns a fixed address for the connection.
+func (*connection) Address() address.Address {
+	return serverAddress
+}
+
+// Stale returns if the connection is stale. It always returns false.
+func (*connection) Stale() bool {
+	return false
+}
+
+// readCommand reads the sections of an OP_MSG wire message into a Command.
+func readCommand(sections []byte, checksum bool) (Command, error) {
+	if checksum {
+		if len(sections) < 4 {
+			return Command{}, errors.New("mongotest: malformed OP_MSG checksum")
+		}
+		sections = sections[:len(sections)-4]
+	}
+
+	var body bsoncore.Document
+	var identifiers []string
+	var sequences [][]bsoncore.Document
+	for len(sections) > 0 {
+		stype, rem, ok := wiremessage.ReadMsgSectionType(sections)
+		if !ok {
+			return Command{}, errors.New("mongotest: malformed OP_MSG section")
+		}
+
+		switch stype {
+		case wiremessage.SingleDocument:
+			body, rem, ok = wiremessage.ReadMsgSectionSingleDocument(rem)
+		case wiremessage.DocumentSequence:
+			var identifier string
+			var docs []bsoncore.Document
+			identifier, docs, rem, ok = wiremessage.ReadMsgSectionDocumentSequence(rem)
+			identifiers = append(identifiers, identifier)
+			sequences = append(sequences, docs)
+		default:
+			return Command{}, fmt.Errorf("mongotest: unsupported OP_MSG section type %v", stype)
+		}
+		if !ok {
+			return Command{}, errors.New("mongotest: malformed OP_MSG section")
+		}
+		sections = rem
+	}
+	if body == nil {
+		return Command{}, errors.New("mongotest: OP_MSG has no command document")
+	}
+
+	elems, err := body.Elements()
+	if err != nil {
+		return Command{}, fmt.Errorf("mongotest: malformed command document: %v", err)
+	}
+	if len(elems) == 0 {
+		return Command{}, errors.New("mongotest: empty command document")
+	}
+
+	idx, doc := bsoncore.AppendDocumentStart(nil)
+	for _, elem := range elems {
+		doc = append(doc, elem...)
+	}
+	for i, identifier := range identifiers {
+		var aidx int32
+		aidx, doc = bsoncore.AppendArrayElementStart(doc, identifier)
+		for j, seqDoc := range sequences[i] {
+			doc = bsoncore.AppendDocumentElement(doc, strconv.Itoa(j), seqDoc)
+		}
+		doc, _ = bsoncore.AppendArrayEnd(doc, aidx)
+	}
+	doc, _ = bsoncore.AppendDocumentEnd(doc, idx)
+
+	db, _ := body.Lookup("$db").StringValueOK()
+	return Command{
+		Name:     elems[0].Key(),
+		Database: db,
+		Document: bson.Raw(doc),
+	}, nil
+}
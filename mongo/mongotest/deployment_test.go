@@ -0,0 +1,128 @@
+// Copyright (C) MongoDB, Inc. 2021-present.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
+
+package mongotest
+
+import (
+	"context"
+	"testing"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/internal/testutil/assert"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"go.mongodb.org/mongo-driver/mongo/writeconcern"
+)
+
+func TestDeployment(t *testing.T) {
+	ctx := context.Background()
+	newClient := func(t *testing.T, opts ...*options.ClientOptions) (*Deployment, *mongo.Client) {
+		t.Helper()
+
+		d := New()
+		client, err := d.NewClient(opts...)
+		assert.Nil(t, err, "NewClient error: %v", err)
+		return d, client
+	}
+
+	t.Run("records commands", func(t *testing.T) {
+		d, client := newClient(t)
+		defer func() { _ = client.Disconnect(ctx) }()
+
+		d.AddReplies(SuccessReply(bson.E{"n", 2}))
+		coll := client.Database("db").Collection("coll")
+		res, err := coll.InsertMany(ctx, []interface{}{bson.D{{"_id", 1}}, bson.D{{"_id", 2}}})
+		assert.Nil(t, err, "InsertMany error: %v", err)
+		assert.Equal(t, 2, len(res.InsertedIDs), "expected 2 inserted IDs, got %v", len(res.InsertedIDs))
+
+		cmds := d.Commands()
+		assert.Equal(t, 1, len(cmds), "expected 1 command, got %v", len(cmds))
+		assert.Equal(t, "insert", cmds[0].Name, "expected command insert, got %v", cmds[0].Name)
+		assert.Equal(t, "db", cmds[0].Database, "expected database db, got %v", cmds[0].Database)
+
+		docs, ok := cmds[0].Document.Lookup("documents").ArrayOK()
+		assert.True(t, ok, "expected documents array in command %v", cmds[0].Document)
+		values, err := docs.Values()
+		assert.Nil(t, err, "Values error: %v", err)
+		assert.Equal(t, 2, len(values), "expected 2 documents, got %v", len(values))
+	})
+	t.Run("cursor replies", func(t *testing.T) {
+		d, client := newClient(t)
+		defer func() { _ = client.Disconnect(ctx) }()
+
+		d.AddReplies(
+			CursorReplyWithID(42, "db.coll", bson.D{{"x", 1}}),
+			GetMoreReply(0, "db.coll", bson.D{{"x", 2}}),
+		)
+		cursor, err := client.Database("db").Collection("coll").Find(ctx, bson.D{})
+		assert.Nil(t, err, "Find error: %v", err)
+
+		var docs []bson.D
+		err = cursor.All(ctx, &docs)
+		assert.Nil(t, err, "All error: %v", err)
+		assert.Equal(t, 2, len(docs), "expected 2 documents, got %v", len(docs))
+
+		cmds := d.Commands()
+		assert.Equal(t, 2, len(cmds), "expected 2 commands, got %v", len(cmds))
+		assert.Equal(t, "getMore", cmds[1].Name, "expected command getMore, got %v", cmds[1].Name)
+	})
+	t.Run("handler", func(t *testing.T) {
+		d, client := newClient(t)
+		defer func() { _ = client.Disconnect(ctx) }()
+
+		d.SetHandler(func(cmd Command) bson.D {
+			if cmd.Name == "count" {
+				return SuccessReply(bson.E{"n", 7})
+			}
+			return nil
+		})
+		coll := client.Database("db").Collection("coll")
+		n, err := coll.EstimatedDocumentCount(ctx)
+		assert.Nil(t, err, "EstimatedDocumentCount error: %v", err)
+		assert.Equal(t, int64(7), n, "expected count 7, got %v", n)
+
+		_, err = coll.DeleteOne(ctx, bson.D{})
+		_, ok := err.(mongo.CommandError)
+		assert.True(t, ok, "expected error type %T, got %T", mongo.CommandError{}, err)
+	})
+	t.Run("error reply", func(t *testing.T) {
+		d, client := newClient(t)
+		defer func() { _ = client.Disconnect(ctx) }()
+
+		d.AddReplies(ErrorReply(11000, "duplicate key"))
+		_, err := client.Database("db").Collection("coll").InsertOne(ctx, bson.D{{"_id", 1}})
+		ce, ok := err.(mongo.CommandError)
+		assert.True(t, ok, "expected error type %T, got %T", mongo.CommandError{}, err)
+		assert.Equal(t, int32(11000), ce.Code, "expected code 11000, got %v", ce.Code)
+	})
+	t.Run("unacknowledged writes", func(t *testing.T) {
+		d, client := newClient(t, options.Client().SetWriteConcern(writeconcern.New(writeconcern.W(0))))
+		defer func() { _ = client.Disconnect(ctx) }()
+
+		d.AddReplies(SuccessReply(bson.E{"n", 1}))
+		coll := client.Database("db").Collection("coll")
+		_, err := coll.InsertOne(ctx, bson.D{{"_id", 1}})
+		assert.Equal(t, mongo.ErrUnacknowledgedWrite, err, "expected error %v, got %v", mongo.ErrUnacknowledgedWrite, err)
+
+		n, err := coll.EstimatedDocumentCount(ctx)
+		assert.Nil(t, err, "EstimatedDocumentCount error: %v", err)
+		assert.Equal(t, int64(1), n, "expected reply to be sent for count, got count %v", n)
+	})
+	t.Run("reset", func(t *testing.T) {
+		d, client := newClient(t)
+		defer func() { _ = client.Disconnect(ctx) }()
+
+		d.AddReplies(SuccessReply())
+		err := client.Ping(ctx, nil)
+		assert.Nil(t, err, "Ping error: %v", err)
+
+		d.AddReplies(SuccessReply())
+		d.Reset()
+		assert.Equal(t, 0, len(d.Commands()), "expected no commands, got %v", len(d.Commands()))
+		err = client.Ping(ctx, nil)
+		assert.NotNil(t, err, "expected Ping error, got nil")
+	})
+}
@@ -0,0 +1,403 @@
+// Copyright (C) MongoDB, Inc. 2021-present.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
+
+package mongotest
+
+import (
+	"context"
+	"encoding/binary"
+	"errors"
+	"io"
+	"math/rand"
+	"net"
+	"sync"
+	"time"
+
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"go.mongodb.org/mongo-driver/x/mongo/driver/wiremessage"
+)
+
+// ErrInjectedNetworkError is the error wrapped by the errors returned for injected network errors.
+var ErrInjectedNetworkError = errors.New("mongotest: injected network error")
+
+// handshakeCommands are the commands that are never subject to faults. They are used to establish connections and to
+// monitor the deployment, so faulting them would mark servers as unknown instead of exercising the error handling of
+// the operations under test.
+var handshakeCommands = map[string]struct{}{
+	"isMaster": {},
+	"ismaster": {},
+	"hello":    {},
+}
+
+// Faults configures the faults injected by a FaultDialer. Each probability is a number between 0 and 1 that is applied
+// independently to every request sent over a connection, and at most one fault is injected per request. The sum of
+// the probabilities should not exceed 1.
+type Faults struct {
+	// The probability that writing a request fails with a network error. The connection is closed and the request is
+	// not sent to the server.
+	NetworkError float64
+
+	// The probability that the response to a request is dropped. Reading the response blocks until the read deadline
+	// of the connection expires, so operations fail with a timeout if a socket timeout or context deadline is set and
+	// block until the connection is closed otherwise.
+	Drop float64
+
+	// The probability that the response to a request is delayed by DelayDuration.
+	Delay float64
+
+	// The duration by which delayed responses are delayed.
+	DelayDuration time.Duration
+
+	// The probability that the response to a request is delivered twice. The duplicate is read as the response to the
+	// next request on the connection, which usually fails because the response does not match the request.
+	Duplicate float64
+}
+
+// FaultStats contains the number of faults injected by a FaultDialer.
+type FaultStats struct {
+	// The number of requests that were subject to faults.
+	Requests int64
+
+	NetworkErrors int64
+	Drops         int64
+	Delays        int64
+	Duplicates    int64
+}
+
+type fault int
+
+const (
+	noFault fault = iota
+	networkErrorFault
+	dropFault
+	delayFault
+	duplicateFault
+)
+
+// FaultDialer is a dialer that injects faults into the connections it creates. It can be used to verify the retry and
+// timeout handling of an application against a real deployment without an external proxy:
+//
+//	dialer := mongotest.NewFaultDialer(nil)
+//	dialer.SetFaults(mongotest.Faults{NetworkError: 0.1, Delay: 0.1, DelayDuration: time.Second})
+//	client, err := mongo.NewClient(options.Client().ApplyURI(uri).SetDialer(dialer))
+//
+// Faults apply to every request sent by the client, including authentication and session commands, except for
+// handshakes and heartbeats. Compressed requests cannot be inspected and are always subject to faults. A FaultDialer is
+// safe for concurrent use by multiple goroutines and the faults can be changed while the client is in use.
+type FaultDialer struct {
+	dialer options.ContextDialer
+
+	mu     sync.Mutex
+	faults Faults
+	rand   *rand.Rand
+	stats  FaultStats
+}
+
+// NewFaultDialer creates a FaultDialer that creates connections using dialer. If dialer is nil, a net.Dialer is used.
+// No faults are injected until SetFaults is called.
+func NewFaultDialer(dialer options.ContextDialer) *FaultDialer {
+	if dialer == nil {
+		dialer = &net.Dialer{}
+	}
+	return &FaultDialer{
+		dialer: dialer,
+		rand:   rand.New(rand.NewSource(time.Now().UnixNano())),
+	}
+}
+
+// SetFaults sets the faults injected into subsequent requests on all connections created by the dialer.
+func (d *FaultDialer) SetFaults(faults Faults) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	d.faults = faults
+}
+
+// SetSeed seeds the random number generator used to decide which requests faults are injected into. Requests sent
+// concurrently may still be faulted in a different order between runs.
+func (d *FaultDialer) SetSeed(seed int64) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	d.rand = rand.New(rand.NewSource(seed))
+}
+
+// Stats returns the number of faults injected so far.
+func (d *FaultDialer) Stats() FaultStats {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	return d.stats
+}
+
+// DialContext implements the options.ContextDialer interface.
+func (d *FaultDialer) DialContext(ctx context.Context, network, address string) (net.Conn, error) {
+	conn, err := d.dialer.DialContext(ctx, network, address)
+	if err != nil {
+		return nil, err
+	}
+	return &faultConn{Conn: conn, dialer: d, done: make(chan struct{})}, nil
+}
+
+// next decides which fault to inject into a request.
+func (d *FaultDialer) next() (fault, time.Duration) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	d.stats.Requests++
+	p := d.rand.Float64()
+	switch {
+	case p < d.faults.NetworkError:
+		d.stats.NetworkErrors++
+		return networkErrorFault, 0
+	case p < d.faults.NetworkError+d.faults.Drop:
+		d.stats.Drops++
+		return dropFault, 0
+	case p < d.faults.NetworkError+d.faults.Drop+d.faults.Delay:
+		d.stats.Delays++
+		return delayFault, d.faults.DelayDuration
+	case p < d.faults.NetworkError+d.faults.Drop+d.faults.Delay+d.faults.Duplicate:
+		d.stats.Duplicates++
+		return duplicateFault, 0
+	}
+	return noFault, 0
+}
+
+type responseFault struct {
+	fault fault
+	delay time.Duration
+}
+
+// faultConn is a net.Conn that injects faults into the requests written to it and the responses read from it. The
+// driver writes each request with a single call to Write, so every call is treated as a complete wire message.
+type faultConn struct {
+	net.Conn
+	dialer *FaultDialer
+
+	mu           sync.Mutex
+	pending      []responseFault
+	readDeadline time.Time
+	buf          []byte
+
+	closeOnce sync.Once
+	done      chan struct{}
+}
+
+// Write implements the net.Conn interface.
+func (c *faultConn) Write(b []byte) (int, error) {
+	if name, ok := commandName(b); ok {
+		if _, ok := handshakeCommands[name]; ok {
+			c.expectResponse(b, responseFault{})
+			return c.Conn.Write(b)
+		}
+	}
+
+	f, delay := c.dialer.next()
+	if f == networkErrorFault {
+		_ = c.Close()
+		return 0, &net.OpError{Op: "write", Net: "tcp", Addr: c.RemoteAddr(), Err: ErrInjectedNetworkError}
+	}
+	c.expectResponse(b, responseFault{fault: f, delay: delay})
+	return c.Conn.Write(b)
+}
+
+// expectResponse queues the fault for the response to wm unless the server does not reply to it.
+func (c *faultConn) expectResponse(wm []byte, rf responseFault) {
+	if wiremessage.IsMsgMoreToCome(wm) {
+		return
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.pending = append(c.pending, rf)
+}
+
+// Read implements the net.Conn interface.
+func (c *faultConn) Read(b []byte) (int, error) {
+	for len(c.buf) == 0 {
+		msg, err := c.readMessage()
+		if err != nil {
+			return 0, err
+		}
+
+		rf := c.nextResponseFault()
+		switch rf.fault {
+		case dropFault:
+			continue
+		case delayFault:
+			if err = c.sleep(rf.delay); err != nil {
+				return 0, err
+			}
+		case duplicateFault:
+			msg = append(msg, msg...)
+		}
+		c.buf = msg
+	}
+
+	n := copy(b, c.buf)
+	c.buf = c.buf[n:]
+	return n, nil
+}
+
+// readMessage reads a complete wire message from the underlying connection.
+func (c *faultConn) readMessage() ([]byte, error) {
+	var size [4]byte
+	if _, err := io.ReadFull(c.Conn, size[:]); err != nil {
+		return nil, err
+	}
+	length := int(binary.LittleEndian.Uint32(size[:]))
+	if length < 4 {
+		return nil, errors.New("mongotest: malformed wire message length")
+	}
+
+	msg := make([]byte, length)
+	copy(msg, size[:])
+	if _, err := io.ReadFull(c.Conn, msg[4:]); err != nil {
+		return nil, err
+	}
+	return msg, nil
+}
+
+// nextResponseFault returns the fault for the next response. Responses without a pending request, such as streamed
+// heartbeat responses, are never faulted.
+func (c *faultConn) nextResponseFault() responseFault {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if len(c.pending) == 0 {
+		return responseFault{}
+	}
+	rf := c.pending[0]
+	c.pending = c.pending[1:]
+	return rf
+}
+
+// sleep waits for d, returning a timeout error if the read deadline expires first or an error if the connection is
+// closed while waiting.
+func (c *faultConn) sleep(d time.Duration) error {
+	c.mu.Lock()
+	deadline := c.readDeadline
+	c.mu.Unlock()
+
+	var timedOut bool
+	if !deadline.IsZero() {
+		if untilDeadline := time.Until(deadline); untilDeadline < d {
+			d, timedOut = untilDeadline, true
+		}
+	}
+
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-timer.C:
+		if timedOut {
+			return &net.OpError{Op: "read", Net: "tcp", Addr: c.RemoteAddr(), Err: timeoutError{}}
+		}
+		return nil
+	case <-c.done:
+		return &net.OpError{Op: "read", Net: "tcp", Addr: c.RemoteAddr(), Err: errors.New("use of closed connection")}
+	}
+}
+
+// SetDeadline implements the net.Conn interface.
+func (c *faultConn) SetDeadline(t time.Time) error {
+	c.setReadDeadline(t)
+	return c.Conn.SetDeadline(t)
+}
+
+// SetReadDeadline implements the net.Conn interface.
+func (c *faultConn) SetReadDeadline(t time.Time) error {
+	c.setReadDeadline(t)
+	return c.Conn.SetReadDeadline(t)
+}
+
+func (c *faultConn) setReadDeadline(t time.Time) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.readDeadline = t
+}
+
+// Close implements the net.Conn interface.
+func (c *faultConn) Close() error {
+	c.closeOnce.Do(func() { close(c.done) })
+	return c.Conn.Close()
+}
+
+// timeoutError is the error returned when the read deadline expires while a response is delayed.
+type timeoutError struct{}
+
+func (timeoutError) Error() string   { return "i/o timeout" }
+func (timeoutError) Timeout() bool   { return true }
+func (timeoutError) Temporary() bool { return true }
+
+// commandName returns the name of the command in an OP_MSG or OP_QUERY wire message. It returns false if the message
+// cannot be inspected, e.g. because it is compressed.
+func commandName(wm []byte) (string, bool) {
+	_, _, _, opcode, rem, ok := wiremessage.ReadHeader(wm)
+	if !ok {
+		return "", false
+	}
+
+	switch opcode {
+	case wiremessage.OpMsg:
+		var flags wiremessage.MsgFlag
+		if flags, rem, ok = wiremessage.ReadMsgFlags(rem); !ok {
+			return "", false
+		}
+		if flags&wiremessage.ChecksumPresent == wiremessage.ChecksumPresent {
+			if len(rem) < 4 {
+				return "", false
+			}
+			rem = rem[:len(rem)-4]
+		}
+		for len(rem) > 0 {
+			var stype wiremessage.SectionType
+			if stype, rem, ok = wiremessage.ReadMsgSectionType(rem); !ok {
+				return "", false
+			}
+			if stype == wiremessage.SingleDocument {
+				doc, _, ok := wiremessage.ReadMsgSectionSingleDocument(rem)
+				if !ok {
+					return "", false
+				}
+				elem, err := doc.IndexErr(0)
+				if err != nil {
+					return "", false
+				}
+				return elem.Key(), true
+			}
+			if _, _, rem, ok = wiremessage.ReadMsgSectionRawDocumentSequence(rem); !ok {
+				return "", false
+			}
+		}
+	case wiremessage.OpQuery:
+		if _, rem, ok = wiremessage.ReadQueryFlags(rem); !ok {
+			return "", false
+		}
+		if _, rem, ok = wiremessage.ReadQueryFullCollectionName(rem); !ok {
+			return "", false
+		}
+		if _, rem, ok = wiremessage.ReadQueryNumberToSkip(rem); !ok {
+			return "", false
+		}
+		if _, rem, ok = wiremessage.ReadQueryNumberToReturn(rem); !ok {
+			return "", false
+		}
+		query, _, ok := wiremessage.ReadQueryQuery(rem)
+		if !ok {
+			return "", false
+		}
+		elem, err := query.IndexErr(0)
+		if err != nil {
+			return "", false
+		}
+		return elem.Key(), true
+	}
+	return "", false
+}
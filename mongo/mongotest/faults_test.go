@@ -0,0 +1,169 @@
+// Copyright (C) MongoDB, Inc. 2021-present.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
+
+package mongotest
+
+import (
+	"context"
+	"io"
+	"net"
+	"testing"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/internal/testutil/assert"
+	"go.mongodb.org/mongo-driver/x/bsonx/bsoncore"
+	"go.mongodb.org/mongo-driver/x/mongo/driver/wiremessage"
+)
+
+type pipeDialer struct {
+	server func(net.Conn)
+}
+
+func (p pipeDialer) DialContext(context.Context, string, string) (net.Conn, error) {
+	client, server := net.Pipe()
+	go p.server(server)
+	return client, nil
+}
+
+// echoServer replies to every wire message it reads by sending the message back.
+func echoServer(conn net.Conn) {
+	defer func() { _ = conn.Close() }()
+
+	fc := &faultConn{Conn: conn, dialer: NewFaultDialer(nil), done: make(chan struct{})}
+	for {
+		msg, err := fc.readMessage()
+		if err != nil {
+			return
+		}
+		if _, err = conn.Write(msg); err != nil {
+			return
+		}
+	}
+}
+
+func opMsg(t *testing.T, cmd bson.D) []byte {
+	t.Helper()
+
+	doc, err := bson.Marshal(cmd)
+	assert.Nil(t, err, "Marshal error: %v", err)
+
+	idx, wm := wiremessage.AppendHeaderStart(nil, 1, 0, wiremessage.OpMsg)
+	wm = wiremessage.AppendMsgFlags(wm, 0)
+	wm = wiremessage.AppendMsgSectionType(wm, wiremessage.SingleDocument)
+	wm = append(wm, doc...)
+	return bsoncore.UpdateLength(wm, idx, int32(len(wm)))
+}
+
+func TestFaultDialer(t *testing.T) {
+	ping := opMsg(t, bson.D{{"ping", 1}, {"$db", "admin"}})
+	dial := func(t *testing.T, faults Faults) (*FaultDialer, net.Conn) {
+		t.Helper()
+
+		d := NewFaultDialer(pipeDialer{server: echoServer})
+		d.SetFaults(faults)
+		conn, err := d.DialContext(context.Background(), "tcp", "localhost:27017")
+		assert.Nil(t, err, "DialContext error: %v", err)
+		return d, conn
+	}
+	roundTrip := func(t *testing.T, conn net.Conn, wm []byte) ([]byte, error) {
+		t.Helper()
+
+		if _, err := conn.Write(wm); err != nil {
+			return nil, err
+		}
+		reply := make([]byte, len(wm))
+		_, err := io.ReadFull(conn, reply)
+		return reply, err
+	}
+
+	t.Run("no faults", func(t *testing.T) {
+		d, conn := dial(t, Faults{})
+		defer func() { _ = conn.Close() }()
+
+		reply, err := roundTrip(t, conn, ping)
+		assert.Nil(t, err, "round trip error: %v", err)
+		assert.Equal(t, ping, reply, "expected reply %v, got %v", ping, reply)
+		assert.Equal(t, FaultStats{Requests: 1}, d.Stats(), "expected 1 request, got %+v", d.Stats())
+	})
+	t.Run("network error", func(t *testing.T) {
+		d, conn := dial(t, Faults{NetworkError: 1})
+		defer func() { _ = conn.Close() }()
+
+		_, err := conn.Write(ping)
+		opErr, ok := err.(*net.OpError)
+		assert.True(t, ok, "expected error type %T, got %v", &net.OpError{}, err)
+		assert.Equal(t, ErrInjectedNetworkError, opErr.Err, "expected error %v, got %v", ErrInjectedNetworkError,
+			opErr.Err)
+		assert.Equal(t, int64(1), d.Stats().NetworkErrors, "expected 1 network error, got %v", d.Stats().NetworkErrors)
+	})
+	t.Run("handshakes are not faulted", func(t *testing.T) {
+		d, conn := dial(t, Faults{NetworkError: 1})
+		defer func() { _ = conn.Close() }()
+
+		for _, name := range []string{"isMaster", "hello"} {
+			wm := opMsg(t, bson.D{{name, 1}, {"$db", "admin"}})
+			_, err := roundTrip(t, conn, wm)
+			assert.Nil(t, err, "round trip error for %v: %v", name, err)
+		}
+		assert.Equal(t, FaultStats{}, d.Stats(), "expected no requests to be faulted, got %+v", d.Stats())
+	})
+	t.Run("drop", func(t *testing.T) {
+		d, conn := dial(t, Faults{Drop: 1})
+		defer func() { _ = conn.Close() }()
+
+		_ = conn.SetReadDeadline(time.Now().Add(50 * time.Millisecond))
+		_, err := roundTrip(t, conn, ping)
+		netErr, ok := err.(net.Error)
+		assert.True(t, ok && netErr.Timeout(), "expected timeout error, got %v", err)
+		assert.Equal(t, int64(1), d.Stats().Drops, "expected 1 drop, got %v", d.Stats().Drops)
+	})
+	t.Run("delay", func(t *testing.T) {
+		_, conn := dial(t, Faults{Delay: 1, DelayDuration: 20 * time.Millisecond})
+		defer func() { _ = conn.Close() }()
+
+		start := time.Now()
+		_, err := roundTrip(t, conn, ping)
+		assert.Nil(t, err, "round trip error: %v", err)
+		elapsed := time.Since(start)
+		assert.True(t, elapsed >= 20*time.Millisecond, "expected reply to be delayed by 20ms, took %v", elapsed)
+
+		_ = conn.SetReadDeadline(time.Now().Add(5 * time.Millisecond))
+		_, err = roundTrip(t, conn, ping)
+		netErr, ok := err.(net.Error)
+		assert.True(t, ok && netErr.Timeout(), "expected timeout error, got %v", err)
+	})
+	t.Run("delay interrupted by close", func(t *testing.T) {
+		_, conn := dial(t, Faults{Delay: 1, DelayDuration: time.Minute})
+
+		go func() {
+			time.Sleep(10 * time.Millisecond)
+			_ = conn.Close()
+		}()
+		_, err := roundTrip(t, conn, ping)
+		assert.NotNil(t, err, "expected error after closing the connection, got nil")
+	})
+	t.Run("duplicate", func(t *testing.T) {
+		d, conn := dial(t, Faults{Duplicate: 1})
+		defer func() { _ = conn.Close() }()
+
+		_, err := roundTrip(t, conn, ping)
+		assert.Nil(t, err, "round trip error: %v", err)
+		d.SetFaults(Faults{})
+		dup := make([]byte, len(ping))
+		_, err = io.ReadFull(conn, dup)
+		assert.Nil(t, err, "read error: %v", err)
+		assert.Equal(t, ping, dup, "expected duplicate reply %v, got %v", ping, dup)
+	})
+	t.Run("command name", func(t *testing.T) {
+		name, ok := commandName(ping)
+		assert.True(t, ok, "expected command name to be found")
+		assert.Equal(t, "ping", name, "expected command name ping, got %v", name)
+
+		_, ok = commandName([]byte{0x01})
+		assert.False(t, ok, "expected no command name for a malformed message")
+	})
+}
@@ -0,0 +1,64 @@
+// Copyright (C) MongoDB, Inc. 2021-present.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
+
+package mongotest
+
+import (
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+// SuccessReply creates a reply for a successful command with the given elements, e.g. SuccessReply(bson.E{"n", 1})
+// for a write command that modified one document.
+func SuccessReply(elems ...bson.E) bson.D {
+	reply := bson.D{
+		{"ok", 1},
+	}
+	return append(reply, elems...)
+}
+
+// CursorReply creates a reply for a command that returns a cursor, such as find or aggregate, with the given documents
+// in the first batch. The ns parameter is the namespace of the cursor in the form "database.collection". The cursor is
+// exhausted, so the driver does not send a getMore command for it.
+func CursorReply(ns string, batch ...bson.D) bson.D {
+	return cursorReply(0, ns, "firstBatch", batch)
+}
+
+// CursorReplyWithID is like CursorReply, but creates a cursor with the given ID so the driver sends getMore commands
+// for it. The replies to the getMore commands can be created with GetMoreReply.
+func CursorReplyWithID(id int64, ns string, batch ...bson.D) bson.D {
+	return cursorReply(id, ns, "firstBatch", batch)
+}
+
+// GetMoreReply creates a reply for a getMore command with the given documents in the next batch. An id of 0 indicates
+// that the cursor is exhausted.
+func GetMoreReply(id int64, ns string, batch ...bson.D) bson.D {
+	return cursorReply(id, ns, "nextBatch", batch)
+}
+
+// ErrorReply creates a reply for a command that failed with the given error code and message.
+func ErrorReply(code int32, message string) bson.D {
+	return bson.D{
+		{"ok", 0},
+		{"code", code},
+		{"errmsg", message},
+	}
+}
+
+func cursorReply(id int64, ns, identifier string, batch []bson.D) bson.D {
+	batchArr := bson.A{}
+	for _, doc := range batch {
+		batchArr = append(batchArr, doc)
+	}
+
+	return bson.D{
+		{"ok", 1},
+		{"cursor", bson.D{
+			{"id", id},
+			{"ns", ns},
+			{identifier, batchArr},
+		}},
+	}
+}
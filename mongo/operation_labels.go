@@ -0,0 +1,34 @@
+// Copyright (C) MongoDB, Inc. 2021-present.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
+
+package mongo
+
+import (
+	"context"
+
+	"go.mongodb.org/mongo-driver/x/mongo/driver"
+)
+
+// WithOperationLabels returns a Context derived from ctx that carries the given labels, e.g. the team, endpoint, or
+// job on whose behalf operations are executed. The labels are added to any labels already carried by ctx, replacing
+// labels with the same keys.
+//
+// Every command sent with the returned Context includes the labels in its comment as a document of the form
+// {labels: {<key>: <value>, ...}}, so they are recorded in the server logs, the database profiler, and the output of
+// $currentOp and can be used to attribute the cost of operations on a shared cluster. The comment is also included
+// in the command documents reported by command monitoring events.
+//
+// The labels are only sent to servers running MongoDB 4.4 or later because older servers do not accept a comment for
+// all commands. If an operation has a comment set through its options, that comment is sent instead of the labels.
+func WithOperationLabels(ctx context.Context, labels map[string]string) context.Context {
+	return driver.WithOperationLabels(ctx, labels)
+}
+
+// OperationLabels returns the labels carried by ctx, or nil if ctx does not carry any labels. The returned map must not
+// be modified.
+func OperationLabels(ctx context.Context) map[string]string {
+	return driver.OperationLabels(ctx)
+}
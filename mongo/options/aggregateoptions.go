@@ -28,6 +28,13 @@ type AggregateOptions struct {
 	// default value is nil, which means the default collation of the collection will be used.
 	Collation *Collation
 
+	// Specifies parameters for the aggregate expression. This option is only valid for MongoDB versions >= 5.0. Older
+	// servers will report an error for using this option. This must be a document mapping parameter names to values. Values
+	// must be constant or closed expressions that do not reference document fields. Parameters can then be accessed as
+	// variables in an aggregate expression context (e.g. "$$var"). The default value is nil, which means that no let
+	// document will be sent.
+	Let interface{}
+
 	// The maximum amount of time that the query can run on the server. The default value is nil, meaning that there
 	// is no time limit for query execution.
 	MaxTime *time.Duration
@@ -44,6 +51,14 @@ type AggregateOptions struct {
 	// as a document. The hint does not apply to $lookup and $graphLookup aggregation stages. The driver will return an
 	// error if the hint parameter is a multi-key map. The default value is nil, which means that no hint will be sent.
 	Hint interface{}
+
+	// If true and the operation is sent to a non-primary member, the operation will be retried once on the primary if
+	// the operation time of the response is older than the operation time of the session when the operation was
+	// started, which indicates that the result may not reflect the writes observed by the session. This is the time
+	// sent as afterClusterTime by causally consistent sessions. Implicit sessions and explicit sessions that have not
+	// executed an operation yet have no operation time, so their reads are never retried. This option is ignored for
+	// aggregations with a $out or $merge stage and for operations in a transaction. The default value is false.
+	RetryStaleReadOnPrimary *bool
 }
 
 // Aggregate creates a new AggregateOptions instance.
@@ -75,6 +90,12 @@ func (ao *AggregateOptions) SetCollation(c *Collation) *AggregateOptions {
 	return ao
 }
 
+// SetLet sets the value for the Let field.
+func (ao *AggregateOptions) SetLet(let interface{}) *AggregateOptions {
+	ao.Let = let
+	return ao
+}
+
 // SetMaxTime sets the value for the MaxTime field.
 func (ao *AggregateOptions) SetMaxTime(d time.Duration) *AggregateOptions {
 	ao.MaxTime = &d
@@ -99,6 +120,12 @@ func (ao *AggregateOptions) SetHint(h interface{}) *AggregateOptions {
 	return ao
 }
 
+// SetRetryStaleReadOnPrimary sets the value for the RetryStaleReadOnPrimary field.
+func (ao *AggregateOptions) SetRetryStaleReadOnPrimary(b bool) *AggregateOptions {
+	ao.RetryStaleReadOnPrimary = &b
+	return ao
+}
+
 // MergeAggregateOptions combines the given AggregateOptions instances into a single AggregateOptions in a last-one-wins
 // fashion.
 func MergeAggregateOptions(opts ...*AggregateOptions) *AggregateOptions {
@@ -119,6 +146,9 @@ func MergeAggregateOptions(opts ...*AggregateOptions) *AggregateOptions {
 		if ao.Collation != nil {
 			aggOpts.Collation = ao.Collation
 		}
+		if ao.Let != nil {
+			aggOpts.Let = ao.Let
+		}
 		if ao.MaxTime != nil {
 			aggOpts.MaxTime = ao.MaxTime
 		}
@@ -131,6 +161,9 @@ func MergeAggregateOptions(opts ...*AggregateOptions) *AggregateOptions {
 		if ao.Hint != nil {
 			aggOpts.Hint = ao.Hint
 		}
+		if ao.RetryStaleReadOnPrimary != nil {
+			aggOpts.RetryStaleReadOnPrimary = ao.RetryStaleReadOnPrimary
+		}
 	}
 
 	return aggOpts
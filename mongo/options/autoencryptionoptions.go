@@ -21,12 +21,14 @@ package options
 // Enabling Client Side Encryption reduces the maximum document and message size (using a maxBsonObjectSize of 2MiB and
 // maxMessageSizeBytes of 6MB) and may have a negative performance impact.
 type AutoEncryptionOptions struct {
-	KeyVaultClientOptions *ClientOptions
-	KeyVaultNamespace     string
-	KmsProviders          map[string]map[string]interface{}
-	SchemaMap             map[string]interface{}
-	BypassAutoEncryption  *bool
-	ExtraOptions          map[string]interface{}
+	KeyVaultClientOptions  *ClientOptions
+	KeyVaultNamespace      string
+	KmsProviders           map[string]map[string]interface{}
+	SchemaMap              map[string]interface{}
+	BypassAutoEncryption   *bool
+	ExtraOptions           map[string]interface{}
+	MongocryptdURIs        []string
+	MongocryptdHealthCheck *bool
 }
 
 // AutoEncryption creates a new AutoEncryptionOptions configured with default values.
@@ -91,6 +93,25 @@ func (a *AutoEncryptionOptions) SetExtraOptions(extraOpts map[string]interface{}
 	return a
 }
 
+// SetMongocryptdURIs specifies the URIs of a pool of mongocryptd processes that were spawned in advance, e.g. by a
+// process manager. If this is set, the driver does not spawn mongocryptd and the mongocryptdURI and
+// mongocryptdBypassSpawn extra options are ignored. The first URI is used to mark commands. If the mongocryptd process
+// it refers to cannot be reached, the driver switches to the next URI whose process responds to a ping.
+func (a *AutoEncryptionOptions) SetMongocryptdURIs(uris ...string) *AutoEncryptionOptions {
+	a.MongocryptdURIs = uris
+	return a
+}
+
+// SetMongocryptdHealthCheck specifies whether the driver should ping mongocryptd when the mongo.Client is connected.
+// If this is true, mongo.Client.Connect returns an error if no mongocryptd process can be reached, so a missing or
+// misconfigured mongocryptd is reported at startup rather than at the first operation that needs to be encrypted. If a
+// pool of mongocryptd URIs is specified, the first URI whose process responds to the ping is used. The default is
+// false.
+func (a *AutoEncryptionOptions) SetMongocryptdHealthCheck(check bool) *AutoEncryptionOptions {
+	a.MongocryptdHealthCheck = &check
+	return a
+}
+
 // MergeAutoEncryptionOptions combines the argued AutoEncryptionOptions in a last-one wins fashion.
 func MergeAutoEncryptionOptions(opts ...*AutoEncryptionOptions) *AutoEncryptionOptions {
 	aeo := AutoEncryption()
@@ -117,6 +138,12 @@ func MergeAutoEncryptionOptions(opts ...*AutoEncryptionOptions) *AutoEncryptionO
 		if opt.ExtraOptions != nil {
 			aeo.ExtraOptions = opt.ExtraOptions
 		}
+		if opt.MongocryptdURIs != nil {
+			aeo.MongocryptdURIs = opt.MongocryptdURIs
+		}
+		if opt.MongocryptdHealthCheck != nil {
+			aeo.MongocryptdHealthCheck = opt.MongocryptdHealthCheck
+		}
 	}
 
 	return aeo
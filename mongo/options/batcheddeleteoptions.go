@@ -0,0 +1,87 @@
+// Copyright (C) MongoDB, Inc. 2021-present.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
+
+package options
+
+import "time"
+
+// DefaultDeleteBatchSize is the maximum number of documents deleted per batch by Collection.DeleteManyInBatches if no
+// batch size is specified.
+const DefaultDeleteBatchSize int32 = 1000
+
+// BatchedDeleteOptions represents options that can be used to configure a DeleteManyInBatches operation.
+type BatchedDeleteOptions struct {
+	// The maximum number of documents deleted by each delete command. The default value is DefaultDeleteBatchSize.
+	BatchSize *int32
+
+	// The time to wait after each batch before deleting the next one, which gives secondaries time to replicate the
+	// deletes. The default value is 0, which means batches are deleted back to back.
+	Pause *time.Duration
+
+	// A function that is called after each batch with the total number of documents deleted so far. The default
+	// value is nil, which means progress is not reported.
+	Progress func(deleted int64)
+
+	// The index to use for the queries that find the documents of each batch. This should either be the index name as
+	// a string or the index specification as a document. The default value is nil, which means that no hint will be
+	// sent.
+	Hint interface{}
+}
+
+// BatchedDelete creates a new BatchedDeleteOptions instance.
+func BatchedDelete() *BatchedDeleteOptions {
+	return &BatchedDeleteOptions{}
+}
+
+// SetBatchSize sets the value for the BatchSize field.
+func (bo *BatchedDeleteOptions) SetBatchSize(i int32) *BatchedDeleteOptions {
+	bo.BatchSize = &i
+	return bo
+}
+
+// SetPause sets the value for the Pause field.
+func (bo *BatchedDeleteOptions) SetPause(d time.Duration) *BatchedDeleteOptions {
+	bo.Pause = &d
+	return bo
+}
+
+// SetProgress sets the value for the Progress field.
+func (bo *BatchedDeleteOptions) SetProgress(fn func(deleted int64)) *BatchedDeleteOptions {
+	bo.Progress = fn
+	return bo
+}
+
+// SetHint sets the value for the Hint field.
+func (bo *BatchedDeleteOptions) SetHint(hint interface{}) *BatchedDeleteOptions {
+	bo.Hint = hint
+	return bo
+}
+
+// MergeBatchedDeleteOptions combines the given BatchedDeleteOptions instances into a single BatchedDeleteOptions in a
+// last-one-wins fashion.
+func MergeBatchedDeleteOptions(opts ...*BatchedDeleteOptions) *BatchedDeleteOptions {
+	bo := BatchedDelete()
+	for _, opt := range opts {
+		if opt == nil {
+			continue
+		}
+
+		if opt.BatchSize != nil {
+			bo.BatchSize = opt.BatchSize
+		}
+		if opt.Pause != nil {
+			bo.Pause = opt.Pause
+		}
+		if opt.Progress != nil {
+			bo.Progress = opt.Progress
+		}
+		if opt.Hint != nil {
+			bo.Hint = opt.Hint
+		}
+	}
+
+	return bo
+}
@@ -17,6 +17,17 @@ type BulkWriteOptions struct {
 	// validation.
 	BypassDocumentValidation *bool
 
+	// A string or document that will be included in server logs, profiling logs, and currentOp queries to help trace
+	// the operation. The default value is nil, which means that no comment will be included in the logs.
+	Comment interface{}
+
+	// Specifies parameters for all update and delete commands in the BulkWrite. This option is only valid for MongoDB
+	// versions >= 5.0. Older servers will report an error for using this option. This must be a document mapping parameter
+	// names to values. Values must be constant or closed expressions that do not reference document fields. Parameters can
+	// then be accessed as variables in an aggregate expression context (e.g. "$$var"). The default value is nil, which
+	// means that no let document will be sent.
+	Let interface{}
+
 	// If true, no writes will be executed after one fails. The default value is true.
 	Ordered *bool
 }
@@ -28,6 +39,18 @@ func BulkWrite() *BulkWriteOptions {
 	}
 }
 
+// SetComment sets the value for the Comment field.
+func (b *BulkWriteOptions) SetComment(comment interface{}) *BulkWriteOptions {
+	b.Comment = comment
+	return b
+}
+
+// SetLet sets the value for the Let field.
+func (b *BulkWriteOptions) SetLet(let interface{}) *BulkWriteOptions {
+	b.Let = let
+	return b
+}
+
 // SetOrdered sets the value for the Ordered field.
 func (b *BulkWriteOptions) SetOrdered(ordered bool) *BulkWriteOptions {
 	b.Ordered = &ordered
@@ -48,6 +71,12 @@ func MergeBulkWriteOptions(opts ...*BulkWriteOptions) *BulkWriteOptions {
 		if opt == nil {
 			continue
 		}
+		if opt.Comment != nil {
+			b.Comment = opt.Comment
+		}
+		if opt.Let != nil {
+			b.Let = opt.Let
+		}
 		if opt.Ordered != nil {
 			b.Ordered = opt.Ordered
 		}
@@ -0,0 +1,81 @@
+// Copyright (C) MongoDB, Inc. 2021-present.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
+
+package options
+
+// ChecksumOptions represents options that can be used to configure a Checksum or CompareCollections operation.
+type ChecksumOptions struct {
+	// A filter that limits the documents that are included in the checksum. The default value is nil, which means all
+	// documents in the collection are included.
+	Filter interface{}
+
+	// A document describing which fields are included in the checksum, e.g. to exclude fields that are expected to
+	// differ between the collections. The _id field must not be excluded for CompareCollections. The default value is
+	// nil, which means whole documents are included.
+	Projection interface{}
+
+	// The maximum number of documents to be included in each batch returned by the server.
+	BatchSize *int32
+
+	// The maximum number of document IDs reported in each list of differences by CompareCollections. The default
+	// value is nil, which means all differences are reported.
+	MaxDifferences *int
+}
+
+// Checksum creates a new ChecksumOptions instance.
+func Checksum() *ChecksumOptions {
+	return &ChecksumOptions{}
+}
+
+// SetFilter sets the value for the Filter field.
+func (co *ChecksumOptions) SetFilter(filter interface{}) *ChecksumOptions {
+	co.Filter = filter
+	return co
+}
+
+// SetProjection sets the value for the Projection field.
+func (co *ChecksumOptions) SetProjection(projection interface{}) *ChecksumOptions {
+	co.Projection = projection
+	return co
+}
+
+// SetBatchSize sets the value for the BatchSize field.
+func (co *ChecksumOptions) SetBatchSize(i int32) *ChecksumOptions {
+	co.BatchSize = &i
+	return co
+}
+
+// SetMaxDifferences sets the value for the MaxDifferences field.
+func (co *ChecksumOptions) SetMaxDifferences(i int) *ChecksumOptions {
+	co.MaxDifferences = &i
+	return co
+}
+
+// MergeChecksumOptions combines the given ChecksumOptions instances into a single ChecksumOptions in a last-one-wins
+// fashion.
+func MergeChecksumOptions(opts ...*ChecksumOptions) *ChecksumOptions {
+	co := Checksum()
+	for _, opt := range opts {
+		if opt == nil {
+			continue
+		}
+
+		if opt.Filter != nil {
+			co.Filter = opt.Filter
+		}
+		if opt.Projection != nil {
+			co.Projection = opt.Projection
+		}
+		if opt.BatchSize != nil {
+			co.BatchSize = opt.BatchSize
+		}
+		if opt.MaxDifferences != nil {
+			co.MaxDifferences = opt.MaxDifferences
+		}
+	}
+
+	return co
+}
@@ -0,0 +1,88 @@
+// Copyright (C) MongoDB, Inc. 2021-present.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
+
+package options
+
+import (
+	"go.mongodb.org/mongo-driver/bson/bsoncodec"
+	"go.mongodb.org/mongo-driver/mongo/readconcern"
+	"go.mongodb.org/mongo-driver/mongo/readpref"
+	"go.mongodb.org/mongo-driver/mongo/writeconcern"
+)
+
+// ClientFacadeOptions represents options that can be used to configure a Client created by Client.NewFacade.
+type ClientFacadeOptions struct {
+	// The read concern to use for operations executed on the facade. The default value is nil, which means that the
+	// read concern of the Client used to create the facade will be used.
+	ReadConcern *readconcern.ReadConcern
+
+	// The write concern to use for operations executed on the facade. The default value is nil, which means that the
+	// write concern of the Client used to create the facade will be used.
+	WriteConcern *writeconcern.WriteConcern
+
+	// The read preference to use for operations executed on the facade. The default value is nil, which means that the
+	// read preference of the Client used to create the facade will be used.
+	ReadPreference *readpref.ReadPref
+
+	// The BSON registry to marshal and unmarshal documents for operations executed on the facade. The default value is
+	// nil, which means that the registry of the Client used to create the facade will be used.
+	Registry *bsoncodec.Registry
+}
+
+// ClientFacade creates a new ClientFacadeOptions instance.
+func ClientFacade() *ClientFacadeOptions {
+	return &ClientFacadeOptions{}
+}
+
+// SetReadConcern sets the value for the ReadConcern field.
+func (c *ClientFacadeOptions) SetReadConcern(rc *readconcern.ReadConcern) *ClientFacadeOptions {
+	c.ReadConcern = rc
+	return c
+}
+
+// SetWriteConcern sets the value for the WriteConcern field.
+func (c *ClientFacadeOptions) SetWriteConcern(wc *writeconcern.WriteConcern) *ClientFacadeOptions {
+	c.WriteConcern = wc
+	return c
+}
+
+// SetReadPreference sets the value for the ReadPreference field.
+func (c *ClientFacadeOptions) SetReadPreference(rp *readpref.ReadPref) *ClientFacadeOptions {
+	c.ReadPreference = rp
+	return c
+}
+
+// SetRegistry sets the value for the Registry field.
+func (c *ClientFacadeOptions) SetRegistry(r *bsoncodec.Registry) *ClientFacadeOptions {
+	c.Registry = r
+	return c
+}
+
+// MergeClientFacadeOptions combines the given ClientFacadeOptions instances into a single ClientFacadeOptions in a
+// last-one-wins fashion.
+func MergeClientFacadeOptions(opts ...*ClientFacadeOptions) *ClientFacadeOptions {
+	c := ClientFacade()
+
+	for _, opt := range opts {
+		if opt == nil {
+			continue
+		}
+		if opt.ReadConcern != nil {
+			c.ReadConcern = opt.ReadConcern
+		}
+		if opt.WriteConcern != nil {
+			c.WriteConcern = opt.WriteConcern
+		}
+		if opt.ReadPreference != nil {
+			c.ReadPreference = opt.ReadPreference
+		}
+		if opt.Registry != nil {
+			c.Registry = opt.Registry
+		}
+	}
+
+	return c
+}
@@ -16,10 +16,12 @@ import (
 	"fmt"
 	"io/ioutil"
 	"net"
+	"reflect"
 	"strings"
 	"time"
 
 	"github.com/youmark/pkcs8"
+	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/bson/bsoncodec"
 	"go.mongodb.org/mongo-driver/event"
 	"go.mongodb.org/mongo-driver/mongo/readconcern"
@@ -28,6 +30,7 @@ import (
 	"go.mongodb.org/mongo-driver/tag"
 	"go.mongodb.org/mongo-driver/x/mongo/driver"
 	"go.mongodb.org/mongo-driver/x/mongo/driver/connstring"
+	"go.mongodb.org/mongo-driver/x/mongo/driver/dns"
 	"go.mongodb.org/mongo-driver/x/mongo/driver/wiremessage"
 )
 
@@ -39,6 +42,25 @@ type ContextDialer interface {
 	DialContext(ctx context.Context, network, address string) (net.Conn, error)
 }
 
+// Seedlist is the result of resolving the SRV host name of a "mongodb+srv" URI: the hosts from the SRV records and the
+// connection options from the TXT record, e.g. "replicaSet=rs0" or "authSource=admin".
+type Seedlist = dns.Seedlist
+
+// SeedlistCache is an interface that can be implemented by types that persist the seedlists resolved from an SRV URI.
+// It should be used to provide a bootstrap fallback when configuring a Client with a "mongodb+srv" URI.
+//
+// LoadSeedlist should return the last seedlist stored for the given SRV host name, or nil if there is none.
+// StoreSeedlist should record the given seedlist for the SRV host name. Both methods must be safe for concurrent use.
+type SeedlistCache interface {
+	LoadSeedlist(host string) (*Seedlist, error)
+	StoreSeedlist(host string, seedlist *Seedlist) error
+}
+
+// NewFileSeedlistCache creates a SeedlistCache that persists seedlists as JSON to the file at path.
+func NewFileSeedlistCache(path string) SeedlistCache {
+	return dns.NewFileSeedlistCache(path)
+}
+
 // Credential can be used to provide authentication options when configuring a Client.
 //
 // AuthMechanism: the mechanism to use for authentication. Supported values include "SCRAM-SHA-256", "SCRAM-SHA-1",
@@ -90,22 +112,73 @@ type Credential struct {
 	PasswordSet             bool
 }
 
+// CollectionNamer maps a model type to the name of the collection that stores values of the type. The type is never a
+// pointer, slice, or array type because those are dereferenced to their element type first.
+type CollectionNamer func(modelType reflect.Type) string
+
+// DriverInfo contains information about a library that wraps the driver, such as an ODM or framework. It is sent to
+// the server in the client metadata of the connection handshake so the library shows up in server logs. Each non-empty
+// field is appended to the corresponding driver field, separated by a "|".
+//
+// Name: the name of the library (e.g. "my-odm").
+//
+// Version: the version of the library (e.g. "1.2.0").
+//
+// Platform: information about the platform the library runs on (e.g. "aws-lambda").
+type DriverInfo struct {
+	Name     string
+	Version  string
+	Platform string
+}
+
+// InMemorySortCheck specifies how sorted Find and FindOne operations are checked for query plans that sort the
+// documents in memory instead of using an index. See ClientOptions.SetInMemorySortCheck.
+type InMemorySortCheck int
+
+// These constants specify the valid values for InMemorySortCheck.
+const (
+	// IgnoreInMemorySorts disables the check.
+	IgnoreInMemorySorts InMemorySortCheck = iota
+
+	// WarnOnInMemorySorts reports each sorted query that is sorted in memory to the handler specified through
+	// ClientOptions.SetInMemorySortHandler without failing the query.
+	WarnOnInMemorySorts
+
+	// RejectInMemorySorts causes sorted queries that are sorted in memory to fail with a mongo.InMemorySortError
+	// without executing them.
+	RejectInMemorySorts
+)
+
 // ClientOptions contains options to configure a Client instance. Each option can be set through setter functions. See
 // documentation for each setter function for an explanation of the option.
 type ClientOptions struct {
 	AppName                  *string
 	Auth                     *Credential
 	AutoEncryptionOptions    *AutoEncryptionOptions
+	CollectionNamer          CollectionNamer
+	CommandMonitorRedactor   func(cmd bson.Raw) bson.Raw
 	ConnectTimeout           *time.Duration
 	Compressors              []string
+	CursorLeakTimeout        *time.Duration
+	CursorMonitor            *event.CursorMonitor
+	DefaultDatabase          *string
+	DefaultFindLimit         *int64
+	DefaultFindMaxTime       *time.Duration
 	Dialer                   ContextDialer
 	Direct                   *bool
+	DisableLocalCompression  *bool
 	DisableOCSPEndpointCheck *bool
+	DriverInfo               *DriverInfo
 	HeartbeatInterval        *time.Duration
 	Hosts                    []string
+	InMemorySortCheck        *InMemorySortCheck
+	InMemorySortHandler      func(err error)
+	LoadBalanced             *bool
 	LocalThreshold           *time.Duration
 	MaxConnIdleTime          *time.Duration
+	MaxConnecting            *uint64
 	MaxPoolSize              *uint64
+	MinHeartbeatInterval     *time.Duration
 	MinPoolSize              *uint64
 	PoolMonitor              *event.PoolMonitor
 	Monitor                  *event.CommandMonitor
@@ -114,10 +187,15 @@ type ClientOptions struct {
 	ReadPreference           *readpref.ReadPref
 	Registry                 *bsoncodec.Registry
 	ReplicaSet               *string
+	RestartPinnedCursors     *bool
 	RetryReads               *bool
 	RetryWrites              *bool
+	SeedlistCache            SeedlistCache
 	ServerAPIOptions         *ServerAPIOptions
+	ServerFailureBackoff     *time.Duration
 	ServerSelectionTimeout   *time.Duration
+	SessionLeakThreshold     *uint64
+	SessionMonitor           *event.SessionMonitor
 	SocketTimeout            *time.Duration
 	TLSConfig                *tls.Config
 	WriteConcern             *writeconcern.WriteConcern
@@ -169,6 +247,22 @@ func (c *ClientOptions) validateAndSetError() {
 		}
 	}
 
+	// Load balanced mode requires a single host and cannot be combined with options that configure topology discovery.
+	if c.LoadBalanced != nil && *c.LoadBalanced {
+		if len(c.Hosts) > 1 {
+			c.err = errors.New("loadBalanced cannot be set to true if multiple hosts are specified")
+			return
+		}
+		if c.ReplicaSet != nil {
+			c.err = errors.New("loadBalanced cannot be set to true if a replica set name is specified")
+			return
+		}
+		if c.Direct != nil {
+			c.err = errors.New("loadBalanced cannot be set to true if the direct connection option is specified")
+			return
+		}
+	}
+
 	// verify server API version if ServerAPIOptions are passed in.
 	if c.ServerAPIOptions != nil {
 		c.err = c.ServerAPIOptions.ServerAPIVersion.Validate()
@@ -201,7 +295,7 @@ func (c *ClientOptions) ApplyURI(uri string) *ClientOptions {
 	}
 
 	c.uri = uri
-	cs, err := connstring.ParseAndValidate(uri)
+	cs, err := connstring.ParseAndValidateWithSeedlistCache(uri, c.SeedlistCache)
 	if err != nil {
 		c.err = err
 		return c
@@ -212,6 +306,10 @@ func (c *ClientOptions) ApplyURI(uri string) *ClientOptions {
 		c.AppName = &cs.AppName
 	}
 
+	if cs.Database != "" {
+		c.DefaultDatabase = &cs.Database
+	}
+
 	// Only create a Credential if there is a request for authentication via non-empty credentials in the URI.
 	if cs.HasAuthParameters() {
 		c.Auth = &Credential{
@@ -255,6 +353,10 @@ func (c *ClientOptions) ApplyURI(uri string) *ClientOptions {
 
 	c.Hosts = cs.Hosts
 
+	if cs.LoadBalancedSet {
+		c.LoadBalanced = &cs.LoadBalanced
+	}
+
 	if cs.LocalThresholdSet {
 		c.LocalThreshold = &cs.LocalThreshold
 	}
@@ -263,6 +365,10 @@ func (c *ClientOptions) ApplyURI(uri string) *ClientOptions {
 		c.MaxConnIdleTime = &cs.MaxConnIdleTime
 	}
 
+	if cs.MaxConnectingSet {
+		c.MaxConnecting = &cs.MaxConnecting
+	}
+
 	if cs.MaxPoolSizeSet {
 		c.MaxPoolSize = &cs.MaxPoolSize
 	}
@@ -410,6 +516,15 @@ func (c *ClientOptions) SetAuth(auth Credential) *ClientOptions {
 	return c
 }
 
+// SetCollectionNamer specifies a function that maps model types to collection names, which is used by
+// Database.CollectionFor to enforce a naming convention, e.g. mongo.PluralSnakeCaseCollectionName. Model types that
+// implement mongo.CollectionNameProvider override the namer. The default is nil, meaning the name of the model type is
+// used unchanged.
+func (c *ClientOptions) SetCollectionNamer(namer CollectionNamer) *ClientOptions {
+	c.CollectionNamer = namer
+	return c
+}
+
 // SetCompressors sets the compressors that can be used when communicating with a server. Valid values are:
 //
 // 1. "snappy" - requires server version >= 3.4
@@ -419,8 +534,9 @@ func (c *ClientOptions) SetAuth(auth Credential) *ClientOptions {
 // 3. "zstd" - requires server version >= 4.2, and driver version >= 1.2.0 with cgo support enabled or driver version >= 1.3.0
 //    without cgo
 //
-// If this option is specified, the driver will perform a negotiation with the server to determine a common list of of
-// compressors and will use the first one in that list when performing operations. See
+// If this option is specified, the driver will perform a negotiation with the server when creating a connection and
+// will use the first compressor in comps that is also supported by the server, so comps is in order of preference. The
+// compressor negotiated with each server can be retrieved with Client.ServerCompressors. See
 // https://docs.mongodb.com/manual/reference/program/mongod/#cmdoption-mongod-networkmessagecompressors for more
 // information about configuring compression on the server and the server-side defaults.
 //
@@ -441,6 +557,69 @@ func (c *ClientOptions) SetConnectTimeout(d time.Duration) *ClientOptions {
 	return c
 }
 
+// SetCursorLeakTimeout enables cursor leak detection. Cursors that have not been closed or exhausted within the
+// given duration of being created are reported to the Leaked function of the CursorMonitor specified through
+// SetCursorMonitor. Detection is disabled if no such function is specified. The report includes the stack trace of the
+// goroutine that created the cursor. Capturing the stack trace adds overhead to every operation that creates a
+// cursor, so this option is intended for debugging. The default is nil, meaning cursor leaks will not be detected.
+func (c *ClientOptions) SetCursorLeakTimeout(d time.Duration) *ClientOptions {
+	c.CursorLeakTimeout = &d
+	return c
+}
+
+// SetCursorMonitor specifies a CursorMonitor to receive cursor leak events. Events are only published if cursor leak
+// detection is enabled through SetCursorLeakTimeout.
+func (c *ClientOptions) SetCursorMonitor(m *event.CursorMonitor) *ClientOptions {
+	c.CursorMonitor = m
+	return c
+}
+
+// SetDefaultDatabase specifies the database that Client.Database returns when it is called with an empty name. This can
+// also be set through the database in the path of a URI (e.g. "mongodb://localhost/test"), which is also the default
+// authentication database. The default is nil, meaning there is no default database.
+func (c *ClientOptions) SetDefaultDatabase(name string) *ClientOptions {
+	c.DefaultDatabase = &name
+	return c
+}
+
+// SetDefaultFindLimit specifies a limit that is applied to Find operations that do not specify a limit of their own.
+// This is a guardrail against accidentally unbounded queries, e.g. in user-facing APIs. Operations that need to scan
+// all matching documents must opt out by setting FindOptions.Unbounded to true. The default is nil, meaning no
+// default limit will be applied.
+func (c *ClientOptions) SetDefaultFindLimit(i int64) *ClientOptions {
+	c.DefaultFindLimit = &i
+	return c
+}
+
+// SetDefaultFindMaxTime specifies a maximum execution time that is applied to Find and FindOne operations that do not
+// specify a MaxTime of their own. Operations can opt out by setting FindOptions.Unbounded or FindOneOptions.Unbounded
+// to true. The default is nil, meaning no default time limit will be applied.
+func (c *ClientOptions) SetDefaultFindMaxTime(d time.Duration) *ClientOptions {
+	c.DefaultFindMaxTime = &d
+	return c
+}
+
+// SetInMemorySortCheck specifies whether sorted Find and FindOne operations are checked for a missing index by
+// explaining them before they are executed. If the winning plan of the query has a SORT stage, which means that the
+// documents are sorted in memory because no index supports the sort, a mongo.InMemorySortError is reported to the
+// handler specified through SetInMemorySortHandler or the operation fails, depending on check. This is meant to be enabled in development and in CI integration tests to catch queries that lack
+// an index before they reach production, because the explain doubles the number of commands sent for sorted queries.
+// The check is skipped for operations in a transaction, in which explain cannot be run, and an error running the explain
+// is returned by the operation. The default is IgnoreInMemorySorts.
+func (c *ClientOptions) SetInMemorySortCheck(check InMemorySortCheck) *ClientOptions {
+	c.InMemorySortCheck = &check
+	return c
+}
+
+// SetInMemorySortHandler specifies a function that is called with a mongo.InMemorySortError for each sorted query that
+// is sorted in memory if the WarnOnInMemorySorts check is enabled through SetInMemorySortCheck. The handler is called
+// synchronously before the query is executed. The default is nil, in which case the WarnOnInMemorySorts check is
+// skipped because there is nothing to report to.
+func (c *ClientOptions) SetInMemorySortHandler(handler func(err error)) *ClientOptions {
+	c.InMemorySortHandler = handler
+	return c
+}
+
 // SetDialer specifies a custom ContextDialer to be used to create new connections to the server. The default is a
 // net.Dialer with the Timeout field set to ConnectTimeout. See https://golang.org/pkg/net/#Dialer for more information
 // about the net.Dialer type.
@@ -469,6 +648,28 @@ func (c *ClientOptions) SetDirect(b bool) *ClientOptions {
 	return c
 }
 
+// SetDisableLocalCompression specifies whether compression should be disabled for connections to servers on the
+// same host, i.e. servers with a localhost or loopback address or a Unix domain socket path, for which compression
+// costs CPU time without reducing network latency. The compressors specified through SetCompressors are still used for
+// connections to other servers. The default is false.
+func (c *ClientOptions) SetDisableLocalCompression(b bool) *ClientOptions {
+	c.DisableLocalCompression = &b
+	return c
+}
+
+// SetDriverInfo specifies information about a library that wraps the driver, such as an ODM or framework. The name,
+// version, and platform are appended to the driver information sent to the server when creating new connections, so
+// the library shows up in server logs. Empty values are not appended. The default is nil, meaning only the driver's own
+// information will be sent.
+func (c *ClientOptions) SetDriverInfo(name, version, platform string) *ClientOptions {
+	c.DriverInfo = &DriverInfo{
+		Name:     name,
+		Version:  version,
+		Platform: platform,
+	}
+	return c
+}
+
 // SetHeartbeatInterval specifies the amount of time to wait between periodic background server checks. This can also be
 // set through the "heartbeatIntervalMS" URI option (e.g. "heartbeatIntervalMS=10000"). The default is 10 seconds.
 func (c *ClientOptions) SetHeartbeatInterval(d time.Duration) *ClientOptions {
@@ -486,6 +687,24 @@ func (c *ClientOptions) SetHosts(s []string) *ClientOptions {
 	return c
 }
 
+// SetLoadBalanced specifies whether or not the MongoDB deployment is hosted behind a load balancer. This can also be
+// set through the "loadBalanced" URI option. The driver will error during Client configuration if this option is set
+// to true and one of these conditions are met:
+//
+// 1. Multiple hosts are specified, either via the ApplyURI or SetHosts methods. This includes the case where an SRV
+// URI is used and the SRV record resolves to multiple hostnames.
+//
+// 2. A replica set name is specified, either via the URI or the SetReplicaSet method.
+//
+// 3. The options specify whether or not a direct connection should be made, either via the URI or the SetDirect method.
+//
+// In load balanced mode, the driver does not monitor the deployment and cursors and transactions are pinned to the
+// connection used to start them. The default value is false.
+func (c *ClientOptions) SetLoadBalanced(lb bool) *ClientOptions {
+	c.LoadBalanced = &lb
+	return c
+}
+
 // SetLocalThreshold specifies the width of the 'latency window': when choosing between multiple suitable servers for an
 // operation, this is the acceptable non-negative delta between shortest and longest average round-trip times. A server
 // within the latency window is selected randomly. This can also be set through the "localThresholdMS" URI option (e.g.
@@ -503,6 +722,15 @@ func (c *ClientOptions) SetMaxConnIdleTime(d time.Duration) *ClientOptions {
 	return c
 }
 
+// SetMaxConnecting specifies the maximum number of connections to each server that the driver establishes at the same
+// time. Limiting the number of concurrent connection handshakes prevents a burst of new connections from overloading
+// the server when many requests arrive at once. This can also be set through the "maxConnecting" URI option (e.g.
+// "maxConnecting=2"). The default is 2. If this is 0, the default will be used.
+func (c *ClientOptions) SetMaxConnecting(u uint64) *ClientOptions {
+	c.MaxConnecting = &u
+	return c
+}
+
 // SetMaxPoolSize specifies that maximum number of connections allowed in the driver's connection pool to each server.
 // Requests to a server will block if this maximum is reached. This can also be set through the "maxPoolSize" URI option
 // (e.g. "maxPoolSize=100"). The default is 100. If this is 0, it will be set to math.MaxInt64.
@@ -511,6 +739,14 @@ func (c *ClientOptions) SetMaxPoolSize(u uint64) *ClientOptions {
 	return c
 }
 
+// SetMinHeartbeatInterval specifies the minimum amount of time to wait between two checks of a server, which limits
+// how often a server is checked when operations request immediate checks because they could not select a server or
+// got a network error. It must be positive. The default is 500 milliseconds.
+func (c *ClientOptions) SetMinHeartbeatInterval(d time.Duration) *ClientOptions {
+	c.MinHeartbeatInterval = &d
+	return c
+}
+
 // SetMinPoolSize specifies the minimum number of connections allowed in the driver's connection pool to each server. If
 // this is non-zero, each server's pool will be maintained in the background to ensure that the size does not fall below
 // the minimum. This can also be set through the "minPoolSize" URI option (e.g. "minPoolSize=100"). The default is 0.
@@ -526,6 +762,17 @@ func (c *ClientOptions) SetPoolMonitor(m *event.PoolMonitor) *ClientOptions {
 	return c
 }
 
+// SetCommandMonitorRedactor specifies a function that is called with the command of each CommandStartedEvent before
+// the event is published to the CommandMonitor specified through SetMonitor. The command in the event is replaced with
+// the document returned by the function, so it can be used to remove or mask sensitive fields, e.g. personal data in
+// inserted documents, before they reach logs or APM tools. The command passed to the function is a copy, so it can be
+// modified in place. Commands that are already redacted by the driver, such as authentication commands, are not passed
+// to the function. The default is nil, meaning commands are published unchanged.
+func (c *ClientOptions) SetCommandMonitorRedactor(redactor func(cmd bson.Raw) bson.Raw) *ClientOptions {
+	c.CommandMonitorRedactor = redactor
+	return c
+}
+
 // SetMonitor specifies a CommandMonitor to receive command events. See the event.CommandMonitor documentation for more
 // information about the structure of the monitor and events that can be received.
 func (c *ClientOptions) SetMonitor(m *event.CommandMonitor) *ClientOptions {
@@ -584,6 +831,19 @@ func (c *ClientOptions) SetReplicaSet(s string) *ClientOptions {
 	return c
 }
 
+// SetRestartPinnedCursors specifies whether Find cursors that lose the connection they are pinned to in load balanced
+// mode are transparently restarted. A cursor loses its connection if a getMore fails with a network error or is routed
+// by the load balancer to a server that does not have the cursor. If this option is true, the find is sent again with
+// its skip increased and its limit decreased by the number of documents already returned, and the iteration continues
+// with the new cursor. Documents can be returned twice or missed if the results are not sorted on a unique key or if
+// the collection is modified during the iteration, so this should only be enabled for such queries or when duplicates
+// are acceptable. Tailable cursors and cursors created in a transaction are never restarted. The number of lost
+// connections and restarts is reported by Client.PinnedCursorStats. The default is false.
+func (c *ClientOptions) SetRestartPinnedCursors(b bool) *ClientOptions {
+	c.RestartPinnedCursors = &b
+	return c
+}
+
 // SetRetryWrites specifies whether supported write operations should be retried once on certain errors, such as network
 // errors.
 //
@@ -614,6 +874,20 @@ func (c *ClientOptions) SetRetryReads(b bool) *ClientOptions {
 	return c
 }
 
+// SetSeedlistCache specifies a SeedlistCache used to persist the hosts and TXT options resolved from a "mongodb+srv"
+// URI. Every successful SRV lookup, both when the URI is applied and during SRV polling, is stored in the cache. If the
+// SRV lookup fails when the URI is applied, the most recently stored hosts and TXT options are used to bootstrap the
+// Client instead of returning an error.
+//
+// The lookup happens in ApplyURI, so this option must be set before ApplyURI is called, e.g.
+// options.Client().SetSeedlistCache(cache).ApplyURI(uri). If it is set afterwards, only the hosts found by SRV polling
+// are stored and a failed lookup in ApplyURI is still returned by Validate. The default is nil, meaning no seedlists
+// will be persisted.
+func (c *ClientOptions) SetSeedlistCache(cache SeedlistCache) *ClientOptions {
+	c.SeedlistCache = cache
+	return c
+}
+
 // SetServerSelectionTimeout specifies how long the driver will wait to find an available, suitable server to execute an
 // operation. This can also be set through the "serverSelectionTimeoutMS" URI option (e.g.
 // "serverSelectionTimeoutMS=30000"). The default value is 30 seconds.
@@ -622,6 +896,25 @@ func (c *ClientOptions) SetServerSelectionTimeout(d time.Duration) *ClientOption
 	return c
 }
 
+// SetSessionLeakThreshold enables session leak detection. Explicit sessions are tracked with the stack trace of the
+// goroutine that started them until EndSession is called. Each time the number of server sessions checked out of the
+// Client's session pool grows beyond the given threshold, the outstanding explicit sessions are reported to the
+// ThresholdExceeded function of the SessionMonitor specified through SetSessionMonitor. Detection is disabled if no
+// such function is specified. Capturing the stack trace adds overhead to every call to StartSession, so this option
+// is intended for debugging. The default is nil, meaning session leaks will not be detected.
+func (c *ClientOptions) SetSessionLeakThreshold(threshold uint64) *ClientOptions {
+	c.SessionLeakThreshold = &threshold
+	return c
+}
+
+// SetSessionMonitor specifies a SessionMonitor to receive session events. Server session lifecycle events are always
+// published to the Event function, while ThresholdExceeded events are only published if session leak detection is
+// enabled through SetSessionLeakThreshold.
+func (c *ClientOptions) SetSessionMonitor(m *event.SessionMonitor) *ClientOptions {
+	c.SessionMonitor = m
+	return c
+}
+
 // SetSocketTimeout specifies how long the driver will wait for a socket read or write to return before returning a
 // network error. This can also be set through the "socketTimeoutMS" URI option (e.g. "socketTimeoutMS=1000"). The
 // default value is 0, meaning no timeout is used and socket operations can block indefinitely.
@@ -726,6 +1019,17 @@ func (c *ClientOptions) SetServerAPIOptions(opts *ServerAPIOptions) *ClientOptio
 	return c
 }
 
+// SetServerFailureBackoff specifies the maximum amount of time to wait between the checks of a server that keeps
+// failing, e.g. a decommissioned host that is still in the seed list. After the second consecutive failed check, the
+// wait starts at twice the heartbeat interval and doubles after each failed check up to this maximum, with random
+// jitter so that servers that failed at the same time are not checked together. Requests for immediate checks are
+// ignored while a server is backing off. The backoff ends with the first successful check. The default is 0, meaning
+// failing servers are checked at the heartbeat interval.
+func (c *ClientOptions) SetServerFailureBackoff(d time.Duration) *ClientOptions {
+	c.ServerFailureBackoff = &d
+	return c
+}
+
 // MergeClientOptions combines the given *ClientOptions into a single *ClientOptions in a last one wins fashion.
 // The specified options are merged with the existing options on the client, with the specified options taking
 // precedence.
@@ -749,27 +1053,63 @@ func MergeClientOptions(opts ...*ClientOptions) *ClientOptions {
 		if opt.AuthenticateToAnything != nil {
 			c.AuthenticateToAnything = opt.AuthenticateToAnything
 		}
+		if opt.CollectionNamer != nil {
+			c.CollectionNamer = opt.CollectionNamer
+		}
+		if opt.CommandMonitorRedactor != nil {
+			c.CommandMonitorRedactor = opt.CommandMonitorRedactor
+		}
 		if opt.Compressors != nil {
 			c.Compressors = opt.Compressors
 		}
 		if opt.ConnectTimeout != nil {
 			c.ConnectTimeout = opt.ConnectTimeout
 		}
+		if opt.CursorLeakTimeout != nil {
+			c.CursorLeakTimeout = opt.CursorLeakTimeout
+		}
+		if opt.CursorMonitor != nil {
+			c.CursorMonitor = opt.CursorMonitor
+		}
+		if opt.DefaultDatabase != nil {
+			c.DefaultDatabase = opt.DefaultDatabase
+		}
+		if opt.DefaultFindLimit != nil {
+			c.DefaultFindLimit = opt.DefaultFindLimit
+		}
+		if opt.DefaultFindMaxTime != nil {
+			c.DefaultFindMaxTime = opt.DefaultFindMaxTime
+		}
 		if opt.HeartbeatInterval != nil {
 			c.HeartbeatInterval = opt.HeartbeatInterval
 		}
 		if len(opt.Hosts) > 0 {
 			c.Hosts = opt.Hosts
 		}
+		if opt.InMemorySortCheck != nil {
+			c.InMemorySortCheck = opt.InMemorySortCheck
+		}
+		if opt.InMemorySortHandler != nil {
+			c.InMemorySortHandler = opt.InMemorySortHandler
+		}
+		if opt.LoadBalanced != nil {
+			c.LoadBalanced = opt.LoadBalanced
+		}
 		if opt.LocalThreshold != nil {
 			c.LocalThreshold = opt.LocalThreshold
 		}
 		if opt.MaxConnIdleTime != nil {
 			c.MaxConnIdleTime = opt.MaxConnIdleTime
 		}
+		if opt.MaxConnecting != nil {
+			c.MaxConnecting = opt.MaxConnecting
+		}
 		if opt.MaxPoolSize != nil {
 			c.MaxPoolSize = opt.MaxPoolSize
 		}
+		if opt.MinHeartbeatInterval != nil {
+			c.MinHeartbeatInterval = opt.MinHeartbeatInterval
+		}
 		if opt.MinPoolSize != nil {
 			c.MinPoolSize = opt.MinPoolSize
 		}
@@ -782,6 +1122,9 @@ func MergeClientOptions(opts ...*ClientOptions) *ClientOptions {
 		if opt.ServerAPIOptions != nil {
 			c.ServerAPIOptions = opt.ServerAPIOptions
 		}
+		if opt.ServerFailureBackoff != nil {
+			c.ServerFailureBackoff = opt.ServerFailureBackoff
+		}
 		if opt.ServerMonitor != nil {
 			c.ServerMonitor = opt.ServerMonitor
 		}
@@ -797,18 +1140,33 @@ func MergeClientOptions(opts ...*ClientOptions) *ClientOptions {
 		if opt.ReplicaSet != nil {
 			c.ReplicaSet = opt.ReplicaSet
 		}
+		if opt.RestartPinnedCursors != nil {
+			c.RestartPinnedCursors = opt.RestartPinnedCursors
+		}
 		if opt.RetryWrites != nil {
 			c.RetryWrites = opt.RetryWrites
 		}
 		if opt.RetryReads != nil {
 			c.RetryReads = opt.RetryReads
 		}
+		if opt.SeedlistCache != nil {
+			c.SeedlistCache = opt.SeedlistCache
+		}
 		if opt.ServerSelectionTimeout != nil {
 			c.ServerSelectionTimeout = opt.ServerSelectionTimeout
 		}
+		if opt.SessionLeakThreshold != nil {
+			c.SessionLeakThreshold = opt.SessionLeakThreshold
+		}
+		if opt.SessionMonitor != nil {
+			c.SessionMonitor = opt.SessionMonitor
+		}
 		if opt.Direct != nil {
 			c.Direct = opt.Direct
 		}
+		if opt.DriverInfo != nil {
+			c.DriverInfo = opt.DriverInfo
+		}
 		if opt.SocketTimeout != nil {
 			c.SocketTimeout = opt.SocketTimeout
 		}
@@ -830,6 +1188,9 @@ func MergeClientOptions(opts ...*ClientOptions) *ClientOptions {
 		if opt.Deployment != nil {
 			c.Deployment = opt.Deployment
 		}
+		if opt.DisableLocalCompression != nil {
+			c.DisableLocalCompression = opt.DisableLocalCompression
+		}
 		if opt.DisableOCSPEndpointCheck != nil {
 			c.DisableOCSPEndpointCheck = opt.DisableOCSPEndpointCheck
 		}
@@ -26,6 +26,7 @@ import (
 	"go.mongodb.org/mongo-driver/mongo/readpref"
 	"go.mongodb.org/mongo-driver/mongo/writeconcern"
 	"go.mongodb.org/mongo-driver/x/mongo/driver/connstring"
+	"go.mongodb.org/mongo-driver/x/mongo/driver/dns"
 )
 
 var tClientOptions = reflect.TypeOf(&ClientOptions{})
@@ -62,12 +63,18 @@ func TestClientOptions(t *testing.T) {
 			{"Auth", (*ClientOptions).SetAuth, Credential{Username: "foo", Password: "bar"}, "Auth", true},
 			{"Compressors", (*ClientOptions).SetCompressors, []string{"zstd", "snappy", "zlib"}, "Compressors", true},
 			{"ConnectTimeout", (*ClientOptions).SetConnectTimeout, 5 * time.Second, "ConnectTimeout", true},
+			{"DefaultDatabase", (*ClientOptions).SetDefaultDatabase, "test", "DefaultDatabase", true},
+			{"DefaultFindLimit", (*ClientOptions).SetDefaultFindLimit, int64(100), "DefaultFindLimit", true},
+			{"DefaultFindMaxTime", (*ClientOptions).SetDefaultFindMaxTime, 5 * time.Second, "DefaultFindMaxTime", true},
 			{"Dialer", (*ClientOptions).SetDialer, testDialer{Num: 12345}, "Dialer", true},
 			{"HeartbeatInterval", (*ClientOptions).SetHeartbeatInterval, 5 * time.Second, "HeartbeatInterval", true},
 			{"Hosts", (*ClientOptions).SetHosts, []string{"localhost:27017", "localhost:27018", "localhost:27019"}, "Hosts", true},
+			{"InMemorySortCheck", (*ClientOptions).SetInMemorySortCheck, RejectInMemorySorts, "InMemorySortCheck", true},
 			{"LocalThreshold", (*ClientOptions).SetLocalThreshold, 5 * time.Second, "LocalThreshold", true},
 			{"MaxConnIdleTime", (*ClientOptions).SetMaxConnIdleTime, 5 * time.Second, "MaxConnIdleTime", true},
+			{"MaxConnecting", (*ClientOptions).SetMaxConnecting, uint64(5), "MaxConnecting", true},
 			{"MaxPoolSize", (*ClientOptions).SetMaxPoolSize, uint64(250), "MaxPoolSize", true},
+			{"MinHeartbeatInterval", (*ClientOptions).SetMinHeartbeatInterval, time.Second, "MinHeartbeatInterval", true},
 			{"MinPoolSize", (*ClientOptions).SetMinPoolSize, uint64(10), "MinPoolSize", true},
 			{"PoolMonitor", (*ClientOptions).SetPoolMonitor, &event.PoolMonitor{}, "PoolMonitor", false},
 			{"Monitor", (*ClientOptions).SetMonitor, &event.CommandMonitor{}, "Monitor", false},
@@ -75,7 +82,9 @@ func TestClientOptions(t *testing.T) {
 			{"ReadPreference", (*ClientOptions).SetReadPreference, readpref.SecondaryPreferred(), "ReadPreference", false},
 			{"Registry", (*ClientOptions).SetRegistry, bson.NewRegistryBuilder().Build(), "Registry", false},
 			{"ReplicaSet", (*ClientOptions).SetReplicaSet, "example-replicaset", "ReplicaSet", true},
+			{"RestartPinnedCursors", (*ClientOptions).SetRestartPinnedCursors, true, "RestartPinnedCursors", true},
 			{"RetryWrites", (*ClientOptions).SetRetryWrites, true, "RetryWrites", true},
+			{"SeedlistCache", (*ClientOptions).SetSeedlistCache, testSeedlistCache{Num: 12345}, "SeedlistCache", true},
 			{"ServerSelectionTimeout", (*ClientOptions).SetServerSelectionTimeout, 5 * time.Second, "ServerSelectionTimeout", true},
 			{"Direct", (*ClientOptions).SetDirect, true, "Direct", true},
 			{"SocketTimeout", (*ClientOptions).SetSocketTimeout, 5 * time.Second, "SocketTimeout", true},
@@ -295,12 +304,13 @@ func TestClientOptions(t *testing.T) {
 			{
 				"DatabaseNoAuth",
 				"mongodb://localhost/example-database",
-				baseClient(),
+				baseClient().SetDefaultDatabase("example-database"),
 			},
 			{
 				"DatabaseAsDefault",
 				"mongodb://foo@localhost/example-database",
-				baseClient().SetAuth(Credential{AuthSource: "example-database", Username: "foo"}),
+				baseClient().SetAuth(Credential{AuthSource: "example-database", Username: "foo"}).
+					SetDefaultDatabase("example-database"),
 			},
 			{
 				"HeartbeatInterval",
@@ -322,6 +332,11 @@ func TestClientOptions(t *testing.T) {
 				"mongodb://localhost/?maxIdleTimeMS=300000",
 				baseClient().SetMaxConnIdleTime(5 * time.Minute),
 			},
+			{
+				"MaxConnecting",
+				"mongodb://localhost/?maxConnecting=5",
+				baseClient().SetMaxConnecting(5),
+			},
 			{
 				"MaxPoolSize",
 				"mongodb://localhost/?maxPoolSize=256",
@@ -521,6 +536,44 @@ func TestClientOptions(t *testing.T) {
 			})
 		}
 	})
+	t.Run("SetDriverInfo", func(t *testing.T) {
+		opts := Client().SetDriverInfo("my-odm", "1.2.0", "aws-lambda")
+		expected := &DriverInfo{Name: "my-odm", Version: "1.2.0", Platform: "aws-lambda"}
+		assert.Equal(t, expected, opts.DriverInfo, "expected DriverInfo %v, got %v", expected, opts.DriverInfo)
+
+		merged := MergeClientOptions(opts, Client().SetAppName("foo"))
+		assert.Equal(t, expected, merged.DriverInfo, "expected merged DriverInfo %v, got %v", expected, merged.DriverInfo)
+	})
+	t.Run("load balanced validation", func(t *testing.T) {
+		testCases := []struct {
+			name string
+			opts *ClientOptions
+			err  error
+		}{
+			{"multiple hosts in URI", Client().ApplyURI("mongodb://foo,bar"), errors.New("loadBalanced cannot be set to true if multiple hosts are specified")},
+			{"multiple hosts in options", Client().SetHosts([]string{"foo", "bar"}), errors.New("loadBalanced cannot be set to true if multiple hosts are specified")},
+			{"replica set name", Client().SetReplicaSet("foo"), errors.New("loadBalanced cannot be set to true if a replica set name is specified")},
+			{"directConnection=true", Client().SetDirect(true), errors.New("loadBalanced cannot be set to true if the direct connection option is specified")},
+			{"directConnection=false", Client().SetDirect(false), errors.New("loadBalanced cannot be set to true if the direct connection option is specified")},
+			{"single host", Client().ApplyURI("mongodb://foo"), nil},
+		}
+		for _, tc := range testCases {
+			t.Run(tc.name, func(t *testing.T) {
+				err := tc.opts.SetLoadBalanced(true).Validate()
+				if tc.err == nil {
+					assert.Nil(t, err, "Validate error: %v", err)
+					return
+				}
+				assert.NotNil(t, err, "expected error %v, got nil", tc.err)
+				assert.Equal(t, tc.err.Error(), err.Error(), "expected error %v, got %v", tc.err, err)
+			})
+		}
+
+		opts := Client().ApplyURI("mongodb://foo/?loadBalanced=true")
+		assert.Nil(t, opts.Validate(), "Validate error: %v", opts.Validate())
+		assert.NotNil(t, opts.LoadBalanced, "expected LoadBalanced to be set by the URI")
+		assert.True(t, *opts.LoadBalanced, "expected LoadBalanced to be true, got false")
+	})
 	t.Run("direct connection validation", func(t *testing.T) {
 		t.Run("multiple hosts", func(t *testing.T) {
 			expectedErr := errors.New("a direct connection cannot be made if multiple hosts are specified")
@@ -551,6 +604,35 @@ func TestClientOptions(t *testing.T) {
 			assert.Equal(t, expectedErr.Error(), err.Error(), "expected error %v, got %v", expectedErr, err)
 		})
 	})
+	t.Run("seedlist cache", func(t *testing.T) {
+		origResolver := dns.DefaultResolver
+		defer func() { dns.DefaultResolver = origResolver }()
+		dns.DefaultResolver = &dns.Resolver{
+			LookupSRV: func(string, string, string) (string, []*net.SRV, error) {
+				return "", nil, errors.New("dns outage")
+			},
+			LookupTXT: func(string) ([]string, error) {
+				return nil, errors.New("dns outage")
+			},
+		}
+		uri := "mongodb+srv://test.example.com/"
+		cache := memorySeedlistCache{
+			"test.example.com": {Hosts: []string{"host1.example.com:27017"}, Options: []string{"replicaSet=rs0"}},
+		}
+
+		t.Run("set before ApplyURI", func(t *testing.T) {
+			opts := Client().SetSeedlistCache(cache).ApplyURI(uri)
+			err := opts.Validate()
+			assert.Nil(t, err, "Validate error: %v", err)
+			assert.Equal(t, []string{"host1.example.com:27017"}, opts.Hosts, "expected cached hosts, got %v", opts.Hosts)
+			assert.NotNil(t, opts.ReplicaSet, "expected cached replica set, got nil")
+			assert.Equal(t, "rs0", *opts.ReplicaSet, "expected replica set rs0, got %v", *opts.ReplicaSet)
+		})
+		t.Run("set after ApplyURI", func(t *testing.T) {
+			err := Client().ApplyURI(uri).SetSeedlistCache(cache).Validate()
+			assert.NotNil(t, err, "expected SRV lookup error, got nil")
+		})
+	})
 }
 
 func createCertPool(t *testing.T, paths ...string) *x509.CertPool {
@@ -587,6 +669,29 @@ func (testDialer) DialContext(ctx context.Context, network, address string) (net
 	return nil, nil
 }
 
+type testSeedlistCache struct {
+	Num int
+}
+
+func (testSeedlistCache) LoadSeedlist(string) (*Seedlist, error) {
+	return nil, nil
+}
+
+func (testSeedlistCache) StoreSeedlist(string, *Seedlist) error {
+	return nil
+}
+
+type memorySeedlistCache map[string]*Seedlist
+
+func (c memorySeedlistCache) LoadSeedlist(host string) (*Seedlist, error) {
+	return c[host], nil
+}
+
+func (c memorySeedlistCache) StoreSeedlist(host string, seedlist *Seedlist) error {
+	c[host] = seedlist
+	return nil
+}
+
 func compareTLSConfig(cfg1, cfg2 *tls.Config) bool {
 	if cfg1 == nil && cfg2 == nil {
 		return true
@@ -7,6 +7,9 @@
 package options
 
 import (
+	"context"
+	"time"
+
 	"go.mongodb.org/mongo-driver/bson/bsoncodec"
 	"go.mongodb.org/mongo-driver/mongo/readconcern"
 	"go.mongodb.org/mongo-driver/mongo/readpref"
@@ -30,6 +33,42 @@ type CollectionOptions struct {
 	// The BSON registry to marshal and unmarshal documents for operations executed on the Collection. The default value
 	// is nil, which means that the registry of the database used to configure the Collection will be used.
 	Registry *bsoncodec.Registry
+
+	// A function that returns a predicate document for the Context of an operation, e.g. to restrict the operation to
+	// the documents of the tenant stored in the Context. The predicate is combined with the filter of every find,
+	// count, distinct, update, replace, and delete operation executed on the Collection, including those in a bulk
+	// write, using $and so that only documents matching both can be read or modified. Aggregations, including
+	// AggregateWithAccumulator, get a $match stage with the predicate at the start of the pipeline, or right after a
+	// leading $geoNear, $search, or $vectorSearch stage. Aggregations that start with a stage that outputs metadata,
+	// such as $collStats, $indexStats, $changeStream, or $listSearchIndexes, are not filtered, so they also work for
+	// SearchIndexView.List. If the function returns a nil predicate, the filter is used unchanged. If it returns an
+	// error, the operation is not executed and the error is returned. The predicate is not applied to Watch, because
+	// change events don't have the shape of the documents the predicate matches, or to EstimatedDocumentCount, which
+	// doesn't take a filter. The default value is nil, which means that filters will not be modified.
+	FilterPredicate func(ctx context.Context) (interface{}, error)
+
+	// The TTL policy of the Collection. The policy is used by Collection.EnsureTTLIndex to create or update the TTL
+	// index, and documents inserted through the Collection are checked to contain a date in the policy's field if
+	// TTLFieldHandler is set. The default value is nil, which means that the Collection does not have a TTL policy.
+	TTLPolicy *TTLPolicy
+
+	// A function that is called with a mongo.TTLFieldError for each document inserted through the Collection that will
+	// never expire under the TTL policy because the policy's field does not contain a date. The function is called
+	// before the documents are sent to the server and the insert is not affected. The default value is nil, which means
+	// that inserted documents will not be checked.
+	TTLFieldHandler func(err error)
+}
+
+// TTLPolicy specifies that the documents in a collection expire after a duration, based on the date stored in a field.
+//
+// Field: the name of the field that stores the date, which can use dot notation for embedded fields. The field must
+// contain a BSON date or an array of dates for a document to expire. Documents in which the field has a different type,
+// e.g. a string, are never removed by the server.
+//
+// ExpireAfter: the duration after the date in Field after which a document expires. This is truncated to seconds.
+type TTLPolicy struct {
+	Field       string
+	ExpireAfter time.Duration
 }
 
 // Collection creates a new CollectionOptions instance.
@@ -61,6 +100,27 @@ func (c *CollectionOptions) SetRegistry(r *bsoncodec.Registry) *CollectionOption
 	return c
 }
 
+// SetFilterPredicate sets the value for the FilterPredicate field.
+func (c *CollectionOptions) SetFilterPredicate(fn func(ctx context.Context) (interface{}, error)) *CollectionOptions {
+	c.FilterPredicate = fn
+	return c
+}
+
+// SetTTLPolicy sets the value for the TTLPolicy field.
+func (c *CollectionOptions) SetTTLPolicy(field string, expireAfter time.Duration) *CollectionOptions {
+	c.TTLPolicy = &TTLPolicy{
+		Field:       field,
+		ExpireAfter: expireAfter,
+	}
+	return c
+}
+
+// SetTTLFieldHandler sets the value for the TTLFieldHandler field.
+func (c *CollectionOptions) SetTTLFieldHandler(handler func(err error)) *CollectionOptions {
+	c.TTLFieldHandler = handler
+	return c
+}
+
 // MergeCollectionOptions combines the given CollectionOptions instances into a single *CollectionOptions in a
 // last-one-wins fashion.
 func MergeCollectionOptions(opts ...*CollectionOptions) *CollectionOptions {
@@ -82,6 +142,15 @@ func MergeCollectionOptions(opts ...*CollectionOptions) *CollectionOptions {
 		if opt.Registry != nil {
 			c.Registry = opt.Registry
 		}
+		if opt.FilterPredicate != nil {
+			c.FilterPredicate = opt.FilterPredicate
+		}
+		if opt.TTLPolicy != nil {
+			c.TTLPolicy = opt.TTLPolicy
+		}
+		if opt.TTLFieldHandler != nil {
+			c.TTLFieldHandler = opt.TTLFieldHandler
+		}
 	}
 
 	return c
@@ -10,11 +10,21 @@ import "time"
 
 // CountOptions represents options that can be used to configure a CountDocuments operation.
 type CountOptions struct {
+	// If true, a count without a filter, skip, or limit is read from the collection metadata with a $collStats
+	// aggregation instead of scanning the collection. The metadata count can be inaccurate after an unclean shutdown
+	// and can include orphaned documents in sharded clusters. If the $collStats aggregation fails, e.g. because the
+	// collection is a view, the documents are counted as usual. The default value is false.
+	AllowFastCount *bool
+
 	// Specifies a collation to use for string comparisons during the operation. This option is only valid for MongoDB
 	// versions >= 3.4. For previous server versions, the driver will return an error if this option is used. The
 	// default value is nil, which means the default collation of the collection will be used.
 	Collation *Collation
 
+	// A string or document that will be included in server logs, profiling logs, and currentOp queries to help trace
+	// the operation. The default value is nil, which means that no comment will be included in the logs.
+	Comment interface{}
+
 	// The index to use for the aggregation. This should either be the index name as a string or the index specification
 	// as a document. The driver will return an error if the hint parameter is a multi-key map. The default value is nil,
 	// which means that no hint will be sent.
@@ -37,12 +47,24 @@ func Count() *CountOptions {
 	return &CountOptions{}
 }
 
+// SetAllowFastCount sets the value for the AllowFastCount field.
+func (co *CountOptions) SetAllowFastCount(b bool) *CountOptions {
+	co.AllowFastCount = &b
+	return co
+}
+
 // SetCollation sets the value for the Collation field.
 func (co *CountOptions) SetCollation(c *Collation) *CountOptions {
 	co.Collation = c
 	return co
 }
 
+// SetComment sets the value for the Comment field.
+func (co *CountOptions) SetComment(comment interface{}) *CountOptions {
+	co.Comment = comment
+	return co
+}
+
 // SetHint sets the value for the Hint field.
 func (co *CountOptions) SetHint(h interface{}) *CountOptions {
 	co.Hint = h
@@ -74,9 +96,15 @@ func MergeCountOptions(opts ...*CountOptions) *CountOptions {
 		if co == nil {
 			continue
 		}
+		if co.AllowFastCount != nil {
+			countOpts.AllowFastCount = co.AllowFastCount
+		}
 		if co.Collation != nil {
 			countOpts.Collation = co.Collation
 		}
+		if co.Comment != nil {
+			countOpts.Comment = co.Comment
+		}
 		if co.Hint != nil {
 			countOpts.Hint = co.Hint
 		}
@@ -6,6 +6,10 @@
 
 package options
 
+import (
+	"time"
+)
+
 // DefaultIndexOptions represents the default options for a collection to apply on new indexes. This type can be used
 // when creating a new collection through the CreateCollectionOptions.SetDefaultIndexOptions method.
 type DefaultIndexOptions struct {
@@ -26,6 +30,67 @@ func (d *DefaultIndexOptions) SetStorageEngine(storageEngine interface{}) *Defau
 	return d
 }
 
+// TimeSeriesOptions specifies the options for a time series collection. This type can be used when creating a new
+// collection through the CreateCollectionOptions.SetTimeSeriesOptions method.
+type TimeSeriesOptions struct {
+	// The name of the top-level field to be used for time. Inserted documents must have this field, and the field
+	// must be of the BSON datetime type.
+	TimeField string
+
+	// The name of the top-level field describing the series. This field is used to group related data and may be of
+	// any BSON type except array. The default value is nil, meaning documents are not grouped by a meta field.
+	MetaField *string
+
+	// The expected interval between subsequent measurements for a time series. Valid values are "seconds", "minutes",
+	// and "hours". The default value is nil, meaning the server default of "seconds" will be used.
+	Granularity *string
+
+	// The maximum time span covered by a single bucket. This option must be specified together with BucketRounding
+	// and cannot be combined with Granularity. It is rounded down to a whole number of seconds and is only valid for
+	// MongoDB versions >= 6.3.
+	BucketMaxSpan *time.Duration
+
+	// The duration used to round down the timestamp of the first document in a bucket. This option must be specified
+	// together with BucketMaxSpan and cannot be combined with Granularity. It is rounded down to a whole number of
+	// seconds and is only valid for MongoDB versions >= 6.3.
+	BucketRounding *time.Duration
+}
+
+// TimeSeries creates a new TimeSeriesOptions instance.
+func TimeSeries() *TimeSeriesOptions {
+	return &TimeSeriesOptions{}
+}
+
+// SetTimeField sets the value for the TimeField field.
+func (tso *TimeSeriesOptions) SetTimeField(timeField string) *TimeSeriesOptions {
+	tso.TimeField = timeField
+	return tso
+}
+
+// SetMetaField sets the value for the MetaField field.
+func (tso *TimeSeriesOptions) SetMetaField(metaField string) *TimeSeriesOptions {
+	tso.MetaField = &metaField
+	return tso
+}
+
+// SetGranularity sets the value for the Granularity field.
+func (tso *TimeSeriesOptions) SetGranularity(granularity string) *TimeSeriesOptions {
+	tso.Granularity = &granularity
+	return tso
+}
+
+// SetBucketMaxSpan sets the value for the BucketMaxSpan field.
+func (tso *TimeSeriesOptions) SetBucketMaxSpan(dur time.Duration) *TimeSeriesOptions {
+	tso.BucketMaxSpan = &dur
+	return tso
+}
+
+// SetBucketRounding sets the value for the BucketRounding field.
+func (tso *TimeSeriesOptions) SetBucketRounding(dur time.Duration) *TimeSeriesOptions {
+	tso.BucketRounding = &dur
+	return tso
+}
+
 // CreateCollectionOptions represents options that can be used to configure a CreateCollection operation.
 type CreateCollectionOptions struct {
 	// Specifies if the collection is capped (see https://docs.mongodb.com/manual/core/capped-collections/). If true,
@@ -40,6 +105,18 @@ type CreateCollectionOptions struct {
 	// >= 3.4. The default value is nil, meaning indexes will be configured using server defaults.
 	DefaultIndexOptions *DefaultIndexOptions
 
+	// Specifies the fields of the collection that are encrypted with Queryable Encryption. The value must be a document
+	// in the form {fields: [{path: <field>, bsonType: <type>, keyId: <data key id>, queries: <queries>}, ...]}. If set,
+	// the state collections used by Queryable Encryption and an index on the __safeContent__ field are also created.
+	// This option is only valid for MongoDB versions >= 7.0. The default value is nil, meaning the collection will not
+	// be encrypted.
+	EncryptedFields interface{}
+
+	// Specifies the number of seconds after which documents in a time series collection are deleted. This option is
+	// only valid for time series collections and MongoDB versions >= 5.0. The default value is nil, meaning documents
+	// are never deleted automatically.
+	ExpireAfterSeconds *int64
+
 	// Specifies the maximum number of documents allowed in a capped collection. The limit specified by the SizeInBytes
 	// option takes precedence over this option. If a capped collection reaches its size limit, old documents will be
 	// removed, regardless of the number of documents in the collection. The default value is 0, meaning the maximum
@@ -54,6 +131,11 @@ type CreateCollectionOptions struct {
 	// will be used.
 	StorageEngine interface{}
 
+	// Specifies the options for a time series collection. If set, the collection will be created as a time series
+	// collection. This option is only valid for MongoDB versions >= 5.0. The default value is nil, meaning a regular
+	// collection will be created.
+	TimeSeriesOptions *TimeSeriesOptions
+
 	// Specifies what should happen if a document being inserted does not pass validation. Valid values are "error" and
 	// "warn". See https://docs.mongodb.com/manual/core/schema-validation/#accept-or-reject-invalid-documents for more
 	// information. This option is only valid for MongoDB versions >= 3.2. The default value is "error".
@@ -95,6 +177,18 @@ func (c *CreateCollectionOptions) SetDefaultIndexOptions(opts *DefaultIndexOptio
 	return c
 }
 
+// SetEncryptedFields sets the value for the EncryptedFields field.
+func (c *CreateCollectionOptions) SetEncryptedFields(encryptedFields interface{}) *CreateCollectionOptions {
+	c.EncryptedFields = encryptedFields
+	return c
+}
+
+// SetExpireAfterSeconds sets the value for the ExpireAfterSeconds field.
+func (c *CreateCollectionOptions) SetExpireAfterSeconds(eas int64) *CreateCollectionOptions {
+	c.ExpireAfterSeconds = &eas
+	return c
+}
+
 // SetMaxDocuments sets the value for the MaxDocuments field.
 func (c *CreateCollectionOptions) SetMaxDocuments(max int64) *CreateCollectionOptions {
 	c.MaxDocuments = &max
@@ -113,6 +207,12 @@ func (c *CreateCollectionOptions) SetStorageEngine(storageEngine interface{}) *C
 	return c
 }
 
+// SetTimeSeriesOptions sets the value for the TimeSeriesOptions field.
+func (c *CreateCollectionOptions) SetTimeSeriesOptions(timeSeriesOpts *TimeSeriesOptions) *CreateCollectionOptions {
+	c.TimeSeriesOptions = timeSeriesOpts
+	return c
+}
+
 // SetValidationAction sets the value for the ValidationAction field.
 func (c *CreateCollectionOptions) SetValidationAction(action string) *CreateCollectionOptions {
 	c.ValidationAction = &action
@@ -150,6 +250,12 @@ func MergeCreateCollectionOptions(opts ...*CreateCollectionOptions) *CreateColle
 		if opt.DefaultIndexOptions != nil {
 			cc.DefaultIndexOptions = opt.DefaultIndexOptions
 		}
+		if opt.EncryptedFields != nil {
+			cc.EncryptedFields = opt.EncryptedFields
+		}
+		if opt.ExpireAfterSeconds != nil {
+			cc.ExpireAfterSeconds = opt.ExpireAfterSeconds
+		}
 		if opt.MaxDocuments != nil {
 			cc.MaxDocuments = opt.MaxDocuments
 		}
@@ -159,6 +265,9 @@ func MergeCreateCollectionOptions(opts ...*CreateCollectionOptions) *CreateColle
 		if opt.StorageEngine != nil {
 			cc.StorageEngine = opt.StorageEngine
 		}
+		if opt.TimeSeriesOptions != nil {
+			cc.TimeSeriesOptions = opt.TimeSeriesOptions
+		}
 		if opt.ValidationAction != nil {
 			cc.ValidationAction = opt.ValidationAction
 		}
@@ -13,6 +13,10 @@ type DeleteOptions struct {
 	// default value is nil, which means the default collation of the collection will be used.
 	Collation *Collation
 
+	// A string or document that will be included in server logs, profiling logs, and currentOp queries to help trace
+	// the operation. The default value is nil, which means that no comment will be included in the logs.
+	Comment interface{}
+
 	// The index to use for the operation. This should either be the index name as a string or the index specification
 	// as a document. This option is only valid for MongoDB versions >= 4.4. Server versions >= 3.4 will return an error
 	// if this option is specified. For server versions < 3.4, the driver will return a client-side error if this option
@@ -20,6 +24,13 @@ type DeleteOptions struct {
 	// operation. The driver will return an error if the hint parameter is a multi-key map. The default value is nil,
 	// which means that no hint will be sent.
 	Hint interface{}
+
+	// Specifies parameters for the delete expression. This option is only valid for MongoDB versions >= 5.0. Older servers
+	// will report an error for using this option. This must be a document mapping parameter names to values. Values must be
+	// constant or closed expressions that do not reference document fields. Parameters can then be accessed as variables in
+	// an aggregate expression context (e.g. "$$var"). The default value is nil, which means that no let document will be
+	// sent.
+	Let interface{}
 }
 
 // Delete creates a new DeleteOptions instance.
@@ -33,12 +44,24 @@ func (do *DeleteOptions) SetCollation(c *Collation) *DeleteOptions {
 	return do
 }
 
+// SetComment sets the value for the Comment field.
+func (do *DeleteOptions) SetComment(comment interface{}) *DeleteOptions {
+	do.Comment = comment
+	return do
+}
+
 // SetHint sets the value for the Hint field.
 func (do *DeleteOptions) SetHint(hint interface{}) *DeleteOptions {
 	do.Hint = hint
 	return do
 }
 
+// SetLet sets the value for the Let field.
+func (do *DeleteOptions) SetLet(let interface{}) *DeleteOptions {
+	do.Let = let
+	return do
+}
+
 // MergeDeleteOptions combines the given DeleteOptions instances into a single DeleteOptions in a last-one-wins fashion.
 func MergeDeleteOptions(opts ...*DeleteOptions) *DeleteOptions {
 	dOpts := Delete()
@@ -49,9 +72,15 @@ func MergeDeleteOptions(opts ...*DeleteOptions) *DeleteOptions {
 		if do.Collation != nil {
 			dOpts.Collation = do.Collation
 		}
+		if do.Comment != nil {
+			dOpts.Comment = do.Comment
+		}
 		if do.Hint != nil {
 			dOpts.Hint = do.Hint
 		}
+		if do.Let != nil {
+			dOpts.Let = do.Let
+		}
 	}
 
 	return dOpts
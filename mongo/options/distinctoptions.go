@@ -15,6 +15,15 @@ type DistinctOptions struct {
 	// default value is nil, which means the default collation of the collection will be used.
 	Collation *Collation
 
+	// A string or document that will be included in server logs, profiling logs, and currentOp queries to help trace
+	// the operation. The default value is nil, which means that no comment will be included in the logs.
+	Comment interface{}
+
+	// The index to use for the operation. This should either be the index name as a string or the index specification
+	// as a document. This option is only valid for MongoDB versions >= 7.1. The driver will return an error if the hint
+	// parameter is a multi-key map. The default value is nil, which means that no hint will be sent.
+	Hint interface{}
+
 	// The maximum amount of time that the query can run on the server. The default value is nil, meaning that there
 	// is no time limit for query execution.
 	MaxTime *time.Duration
@@ -31,6 +40,18 @@ func (do *DistinctOptions) SetCollation(c *Collation) *DistinctOptions {
 	return do
 }
 
+// SetComment sets the value for the Comment field.
+func (do *DistinctOptions) SetComment(comment interface{}) *DistinctOptions {
+	do.Comment = comment
+	return do
+}
+
+// SetHint sets the value for the Hint field.
+func (do *DistinctOptions) SetHint(hint interface{}) *DistinctOptions {
+	do.Hint = hint
+	return do
+}
+
 // SetMaxTime sets the value for the MaxTime field.
 func (do *DistinctOptions) SetMaxTime(d time.Duration) *DistinctOptions {
 	do.MaxTime = &d
@@ -48,6 +69,12 @@ func MergeDistinctOptions(opts ...*DistinctOptions) *DistinctOptions {
 		if do.Collation != nil {
 			distinctOpts.Collation = do.Collation
 		}
+		if do.Comment != nil {
+			distinctOpts.Comment = do.Comment
+		}
+		if do.Hint != nil {
+			distinctOpts.Hint = do.Hint
+		}
 		if do.MaxTime != nil {
 			distinctOpts.MaxTime = do.MaxTime
 		}
@@ -0,0 +1,55 @@
+// Copyright (C) MongoDB, Inc. 2021-present.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
+
+package options
+
+// ExplainVerbosity specifies the amount of information returned by an explain command.
+type ExplainVerbosity string
+
+const (
+	// QueryPlanner returns the plan selected by the query optimizer without running it.
+	QueryPlanner ExplainVerbosity = "queryPlanner"
+	// ExecutionStats runs the selected plan and returns its execution statistics.
+	ExecutionStats ExplainVerbosity = "executionStats"
+	// AllPlansExecution runs the selected plan and returns its execution statistics along with partial statistics
+	// for the rejected plans that were evaluated during plan selection.
+	AllPlansExecution ExplainVerbosity = "allPlansExecution"
+)
+
+// ExplainOptions represents options that can be used to configure an Explain operation.
+type ExplainOptions struct {
+	// The verbosity of the explain output. The default value is nil, which means the server default of
+	// AllPlansExecution will be used.
+	Verbosity *ExplainVerbosity
+}
+
+// Explain creates a new ExplainOptions instance.
+func Explain() *ExplainOptions {
+	return &ExplainOptions{}
+}
+
+// SetVerbosity sets the value for the Verbosity field.
+func (eo *ExplainOptions) SetVerbosity(verbosity ExplainVerbosity) *ExplainOptions {
+	eo.Verbosity = &verbosity
+	return eo
+}
+
+// MergeExplainOptions combines the given ExplainOptions instances into a single ExplainOptions in a last-one-wins
+// fashion.
+func MergeExplainOptions(opts ...*ExplainOptions) *ExplainOptions {
+	eo := Explain()
+	for _, opt := range opts {
+		if opt == nil {
+			continue
+		}
+
+		if opt.Verbosity != nil {
+			eo.Verbosity = opt.Verbosity
+		}
+	}
+
+	return eo
+}
@@ -8,6 +8,8 @@ package options
 
 import (
 	"time"
+
+	"go.mongodb.org/mongo-driver/mongo/readpref"
 )
 
 // FindOptions represents options that can be used to configure a Find operation.
@@ -38,11 +40,25 @@ type FindOptions struct {
 	// that the cursor will be closed by the server when the last batch of documents is retrieved.
 	CursorType *CursorType
 
+	// If true, the server can stream the batches of the cursor created by the operation over the connection used to
+	// create it without waiting for a getMore command for each batch, which removes a round trip per batch, e.g. when
+	// tailing a capped collection with a tailable await cursor. The cursor is pinned to the connection until it is
+	// exhausted or closed, and closing the cursor while batches are being streamed closes the connection. The getMore
+	// commands for the cursor are sent as usual if the server version is older than 4.2. The default value is false.
+	Exhaust *bool
+
 	// The index to use for the operation. This should either be the index name as a string or the index specification
 	// as a document. The driver will return an error if the hint parameter is a multi-key map. The default value is nil,
 	// which means that no hint will be sent.
 	Hint interface{}
 
+	// Specifies parameters for the find expression. This option is only valid for MongoDB versions >= 5.0. Older servers
+	// will report an error for using this option. This must be a document mapping parameter names to values. Values must be
+	// constant or closed expressions that do not reference document fields. Parameters can then be accessed as variables in
+	// an aggregate expression context (e.g. "$$var"). The default value is nil, which means that no let document will be
+	// sent.
+	Let interface{}
+
 	// The maximum number of documents to return. The default value is 0, which means that all documents matching the
 	// filter will be returned. A negative limit specifies that the resulting documents should be returned in a single
 	// batch. The default value is 0.
@@ -75,14 +91,40 @@ type FindOptions struct {
 	// set.
 	OplogReplay *bool
 
+	// If true, the cursor created by the operation fetches the next batch of documents in the background while the
+	// current batch is iterated, which hides the latency of the getMore round trips for large scans. At most one batch
+	// is fetched ahead of the batch being iterated. This option is ignored for tailable cursors and for operations
+	// executed with an explicit session because sessions must not be used concurrently. The default value is false.
+	Prefetch *bool
+
 	// A document describing which fields will be included in the documents returned by the operation. The default value
 	// is nil, which means all fields will be included.
 	Projection interface{}
 
+	// The read preference used to select a server for the operation, e.g. readpref.Secondary(readpref.WithTags(
+	// "nodeType", "ANALYTICS")) to target members tagged for analytics workloads. This option cannot be used in a
+	// transaction. The default value is nil, which means the read preference of the collection will be used.
+	ReadPreference *readpref.ReadPref
+
+	// If true and the operation is sent to a non-primary member, the operation will be retried once on the primary if
+	// the operation time of the response is older than the operation time of the session when the operation was
+	// started, which indicates that the result may not reflect the writes observed by the session. This is the time
+	// sent as afterClusterTime by causally consistent sessions. Implicit sessions and explicit sessions that have not
+	// executed an operation yet have no operation time, so their reads are never retried. This option is ignored for
+	// operations in a transaction. The default value is false.
+	RetryStaleReadOnPrimary *bool
+
 	// If true, the documents returned by the operation will only contain fields corresponding to the index used. The
 	// default value is false.
 	ReturnKey *bool
 
+	// The address of the server, in "host:port" form, that the operation must be sent to, e.g. to debug queries on a
+	// specific replica set member or mongos. The server must also match the read preference of the operation. The
+	// operation fails without waiting for the server selection timeout if the deployment has been discovered and does
+	// not contain the server. The RetryStaleReadOnPrimary option is ignored if this option is set. This option cannot
+	// be used in a transaction. The default value is nil, which means any suitable server can be selected.
+	ServerAddress *string
+
 	// If true, a $recordId field with a record identifier will be included in the documents returned by the operation.
 	// The default value is false.
 	ShowRecordID *bool
@@ -99,6 +141,10 @@ type FindOptions struct {
 	// A document specifying the order in which documents should be returned.  The driver will return an error if the
 	// sort parameter is a multi-key map.
 	Sort interface{}
+
+	// If true, the DefaultFindLimit and DefaultFindMaxTime client options will not be applied to the operation, which
+	// allows it to return all matching documents without a time limit. The default value is false.
+	Unbounded *bool
 }
 
 // Find creates a new FindOptions instance.
@@ -142,12 +188,24 @@ func (f *FindOptions) SetCursorType(ct CursorType) *FindOptions {
 	return f
 }
 
+// SetExhaust sets the value for the Exhaust field.
+func (f *FindOptions) SetExhaust(b bool) *FindOptions {
+	f.Exhaust = &b
+	return f
+}
+
 // SetHint sets the value for the Hint field.
 func (f *FindOptions) SetHint(hint interface{}) *FindOptions {
 	f.Hint = hint
 	return f
 }
 
+// SetLet sets the value for the Let field.
+func (f *FindOptions) SetLet(let interface{}) *FindOptions {
+	f.Let = let
+	return f
+}
+
 // SetLimit sets the value for the Limit field.
 func (f *FindOptions) SetLimit(i int64) *FindOptions {
 	f.Limit = &i
@@ -192,18 +250,42 @@ func (f *FindOptions) SetOplogReplay(b bool) *FindOptions {
 	return f
 }
 
+// SetPrefetch sets the value for the Prefetch field.
+func (f *FindOptions) SetPrefetch(b bool) *FindOptions {
+	f.Prefetch = &b
+	return f
+}
+
 // SetProjection sets the value for the Projection field.
 func (f *FindOptions) SetProjection(projection interface{}) *FindOptions {
 	f.Projection = projection
 	return f
 }
 
+// SetReadPreference sets the value for the ReadPreference field.
+func (f *FindOptions) SetReadPreference(rp *readpref.ReadPref) *FindOptions {
+	f.ReadPreference = rp
+	return f
+}
+
+// SetRetryStaleReadOnPrimary sets the value for the RetryStaleReadOnPrimary field.
+func (f *FindOptions) SetRetryStaleReadOnPrimary(b bool) *FindOptions {
+	f.RetryStaleReadOnPrimary = &b
+	return f
+}
+
 // SetReturnKey sets the value for the ReturnKey field.
 func (f *FindOptions) SetReturnKey(b bool) *FindOptions {
 	f.ReturnKey = &b
 	return f
 }
 
+// SetServerAddress sets the value for the ServerAddress field.
+func (f *FindOptions) SetServerAddress(addr string) *FindOptions {
+	f.ServerAddress = &addr
+	return f
+}
+
 // SetShowRecordID sets the value for the ShowRecordID field.
 func (f *FindOptions) SetShowRecordID(b bool) *FindOptions {
 	f.ShowRecordID = &b
@@ -230,6 +312,12 @@ func (f *FindOptions) SetSort(sort interface{}) *FindOptions {
 	return f
 }
 
+// SetUnbounded sets the value for the Unbounded field.
+func (f *FindOptions) SetUnbounded(b bool) *FindOptions {
+	f.Unbounded = &b
+	return f
+}
+
 // MergeFindOptions combines the given FindOptions instances into a single FindOptions in a last-one-wins fashion.
 func MergeFindOptions(opts ...*FindOptions) *FindOptions {
 	fo := Find()
@@ -255,9 +343,15 @@ func MergeFindOptions(opts ...*FindOptions) *FindOptions {
 		if opt.CursorType != nil {
 			fo.CursorType = opt.CursorType
 		}
+		if opt.Exhaust != nil {
+			fo.Exhaust = opt.Exhaust
+		}
 		if opt.Hint != nil {
 			fo.Hint = opt.Hint
 		}
+		if opt.Let != nil {
+			fo.Let = opt.Let
+		}
 		if opt.Limit != nil {
 			fo.Limit = opt.Limit
 		}
@@ -279,12 +373,24 @@ func MergeFindOptions(opts ...*FindOptions) *FindOptions {
 		if opt.OplogReplay != nil {
 			fo.OplogReplay = opt.OplogReplay
 		}
+		if opt.Prefetch != nil {
+			fo.Prefetch = opt.Prefetch
+		}
 		if opt.Projection != nil {
 			fo.Projection = opt.Projection
 		}
+		if opt.ReadPreference != nil {
+			fo.ReadPreference = opt.ReadPreference
+		}
+		if opt.RetryStaleReadOnPrimary != nil {
+			fo.RetryStaleReadOnPrimary = opt.RetryStaleReadOnPrimary
+		}
 		if opt.ReturnKey != nil {
 			fo.ReturnKey = opt.ReturnKey
 		}
+		if opt.ServerAddress != nil {
+			fo.ServerAddress = opt.ServerAddress
+		}
 		if opt.ShowRecordID != nil {
 			fo.ShowRecordID = opt.ShowRecordID
 		}
@@ -297,6 +403,9 @@ func MergeFindOptions(opts ...*FindOptions) *FindOptions {
 		if opt.Sort != nil {
 			fo.Sort = opt.Sort
 		}
+		if opt.Unbounded != nil {
+			fo.Unbounded = opt.Unbounded
+		}
 	}
 
 	return fo
@@ -333,6 +442,13 @@ type FindOneOptions struct {
 	// which means that no hint will be sent.
 	Hint interface{}
 
+	// Specifies parameters for the find expression. This option is only valid for MongoDB versions >= 5.0. Older servers
+	// will report an error for using this option. This must be a document mapping parameter names to values. Values must be
+	// constant or closed expressions that do not reference document fields. Parameters can then be accessed as variables in
+	// an aggregate expression context (e.g. "$$var"). The default value is nil, which means that no let document will be
+	// sent.
+	Let interface{}
+
 	// A document specifying the exclusive upper bound for a specific index. The default value is nil, which means that
 	// there is no maximum value.
 	Max interface{}
@@ -368,10 +484,30 @@ type FindOneOptions struct {
 	// is nil, which means all fields will be included.
 	Projection interface{}
 
+	// The read preference used to select a server for the operation, e.g. readpref.Secondary(readpref.WithTags(
+	// "nodeType", "ANALYTICS")) to target members tagged for analytics workloads. This option cannot be used in a
+	// transaction. The default value is nil, which means the read preference of the collection will be used.
+	ReadPreference *readpref.ReadPref
+
+	// If true and the operation is sent to a non-primary member, the operation will be retried once on the primary if
+	// the operation time of the response is older than the operation time of the session when the operation was
+	// started, which indicates that the result may not reflect the writes observed by the session. This is the time
+	// sent as afterClusterTime by causally consistent sessions. Implicit sessions and explicit sessions that have not
+	// executed an operation yet have no operation time, so their reads are never retried. This option is ignored for
+	// operations in a transaction. The default value is false.
+	RetryStaleReadOnPrimary *bool
+
 	// If true, the document returned by the operation will only contain fields corresponding to the index used. The
 	// default value is false.
 	ReturnKey *bool
 
+	// The address of the server, in "host:port" form, that the operation must be sent to, e.g. to debug queries on a
+	// specific replica set member or mongos. The server must also match the read preference of the operation. The
+	// operation fails without waiting for the server selection timeout if the deployment has been discovered and does
+	// not contain the server. The RetryStaleReadOnPrimary option is ignored if this option is set. This option cannot
+	// be used in a transaction. The default value is nil, which means any suitable server can be selected.
+	ServerAddress *string
+
 	// If true, a $recordId field with a record identifier will be included in the document returned by the operation.
 	// The default value is false.
 	ShowRecordID *bool
@@ -388,6 +524,9 @@ type FindOneOptions struct {
 	// A document specifying the sort order to apply to the query. The first document in the sorted order will be
 	// returned. The driver will return an error if the sort parameter is a multi-key map.
 	Sort interface{}
+
+	// If true, the DefaultFindMaxTime client option will not be applied to the operation. The default value is false.
+	Unbounded *bool
 }
 
 // FindOne creates a new FindOneOptions instance.
@@ -435,6 +574,12 @@ func (f *FindOneOptions) SetHint(hint interface{}) *FindOneOptions {
 	return f
 }
 
+// SetLet sets the value for the Let field.
+func (f *FindOneOptions) SetLet(let interface{}) *FindOneOptions {
+	f.Let = let
+	return f
+}
+
 // SetMax sets the value for the Max field.
 func (f *FindOneOptions) SetMax(max interface{}) *FindOneOptions {
 	f.Max = max
@@ -484,12 +629,30 @@ func (f *FindOneOptions) SetProjection(projection interface{}) *FindOneOptions {
 	return f
 }
 
+// SetReadPreference sets the value for the ReadPreference field.
+func (f *FindOneOptions) SetReadPreference(rp *readpref.ReadPref) *FindOneOptions {
+	f.ReadPreference = rp
+	return f
+}
+
+// SetRetryStaleReadOnPrimary sets the value for the RetryStaleReadOnPrimary field.
+func (f *FindOneOptions) SetRetryStaleReadOnPrimary(b bool) *FindOneOptions {
+	f.RetryStaleReadOnPrimary = &b
+	return f
+}
+
 // SetReturnKey sets the value for the ReturnKey field.
 func (f *FindOneOptions) SetReturnKey(b bool) *FindOneOptions {
 	f.ReturnKey = &b
 	return f
 }
 
+// SetServerAddress sets the value for the ServerAddress field.
+func (f *FindOneOptions) SetServerAddress(addr string) *FindOneOptions {
+	f.ServerAddress = &addr
+	return f
+}
+
 // SetShowRecordID sets the value for the ShowRecordID field.
 func (f *FindOneOptions) SetShowRecordID(b bool) *FindOneOptions {
 	f.ShowRecordID = &b
@@ -516,6 +679,12 @@ func (f *FindOneOptions) SetSort(sort interface{}) *FindOneOptions {
 	return f
 }
 
+// SetUnbounded sets the value for the Unbounded field.
+func (f *FindOneOptions) SetUnbounded(b bool) *FindOneOptions {
+	f.Unbounded = &b
+	return f
+}
+
 // MergeFindOneOptions combines the given FindOneOptions instances into a single FindOneOptions in a last-one-wins
 // fashion.
 func MergeFindOneOptions(opts ...*FindOneOptions) *FindOneOptions {
@@ -542,6 +711,9 @@ func MergeFindOneOptions(opts ...*FindOneOptions) *FindOneOptions {
 		if opt.Hint != nil {
 			fo.Hint = opt.Hint
 		}
+		if opt.Let != nil {
+			fo.Let = opt.Let
+		}
 		if opt.Max != nil {
 			fo.Max = opt.Max
 		}
@@ -563,9 +735,18 @@ func MergeFindOneOptions(opts ...*FindOneOptions) *FindOneOptions {
 		if opt.Projection != nil {
 			fo.Projection = opt.Projection
 		}
+		if opt.ReadPreference != nil {
+			fo.ReadPreference = opt.ReadPreference
+		}
+		if opt.RetryStaleReadOnPrimary != nil {
+			fo.RetryStaleReadOnPrimary = opt.RetryStaleReadOnPrimary
+		}
 		if opt.ReturnKey != nil {
 			fo.ReturnKey = opt.ReturnKey
 		}
+		if opt.ServerAddress != nil {
+			fo.ServerAddress = opt.ServerAddress
+		}
 		if opt.ShowRecordID != nil {
 			fo.ShowRecordID = opt.ShowRecordID
 		}
@@ -578,6 +759,9 @@ func MergeFindOneOptions(opts ...*FindOneOptions) *FindOneOptions {
 		if opt.Sort != nil {
 			fo.Sort = opt.Sort
 		}
+		if opt.Unbounded != nil {
+			fo.Unbounded = opt.Unbounded
+		}
 	}
 
 	return fo
@@ -596,6 +780,17 @@ type FindOneAndReplaceOptions struct {
 	// default value is nil, which means the default collation of the collection will be used.
 	Collation *Collation
 
+	// A string or document that will be included in server logs, profiling logs, and currentOp queries to help trace
+	// the operation. The default value is nil, which means that no comment will be included in the logs.
+	Comment interface{}
+
+	// Specifies parameters for the find expression. This option is only valid for MongoDB versions >= 5.0. Older servers
+	// will report an error for using this option. This must be a document mapping parameter names to values. Values must be
+	// constant or closed expressions that do not reference document fields. Parameters can then be accessed as variables in
+	// an aggregate expression context (e.g. "$$var"). The default value is nil, which means that no let document will be
+	// sent.
+	Let interface{}
+
 	// The maximum amount of time that the query can run on the server. The default value is nil, meaning that there
 	// is no time limit for query execution.
 	MaxTime *time.Duration
@@ -643,6 +838,18 @@ func (f *FindOneAndReplaceOptions) SetCollation(collation *Collation) *FindOneAn
 	return f
 }
 
+// SetComment sets the value for the Comment field.
+func (f *FindOneAndReplaceOptions) SetComment(comment interface{}) *FindOneAndReplaceOptions {
+	f.Comment = comment
+	return f
+}
+
+// SetLet sets the value for the Let field.
+func (f *FindOneAndReplaceOptions) SetLet(let interface{}) *FindOneAndReplaceOptions {
+	f.Let = let
+	return f
+}
+
 // SetMaxTime sets the value for the MaxTime field.
 func (f *FindOneAndReplaceOptions) SetMaxTime(d time.Duration) *FindOneAndReplaceOptions {
 	f.MaxTime = &d
@@ -693,6 +900,12 @@ func MergeFindOneAndReplaceOptions(opts ...*FindOneAndReplaceOptions) *FindOneAn
 		if opt.Collation != nil {
 			fo.Collation = opt.Collation
 		}
+		if opt.Comment != nil {
+			fo.Comment = opt.Comment
+		}
+		if opt.Let != nil {
+			fo.Let = opt.Let
+		}
 		if opt.MaxTime != nil {
 			fo.MaxTime = opt.MaxTime
 		}
@@ -734,6 +947,17 @@ type FindOneAndUpdateOptions struct {
 	// default value is nil, which means the default collation of the collection will be used.
 	Collation *Collation
 
+	// A string or document that will be included in server logs, profiling logs, and currentOp queries to help trace
+	// the operation. The default value is nil, which means that no comment will be included in the logs.
+	Comment interface{}
+
+	// Specifies parameters for the find expression. This option is only valid for MongoDB versions >= 5.0. Older servers
+	// will report an error for using this option. This must be a document mapping parameter names to values. Values must be
+	// constant or closed expressions that do not reference document fields. Parameters can then be accessed as variables in
+	// an aggregate expression context (e.g. "$$var"). The default value is nil, which means that no let document will be
+	// sent.
+	Let interface{}
+
 	// The maximum amount of time that the query can run on the server. The default value is nil, meaning that there
 	// is no time limit for query execution.
 	MaxTime *time.Duration
@@ -787,6 +1011,18 @@ func (f *FindOneAndUpdateOptions) SetCollation(collation *Collation) *FindOneAnd
 	return f
 }
 
+// SetComment sets the value for the Comment field.
+func (f *FindOneAndUpdateOptions) SetComment(comment interface{}) *FindOneAndUpdateOptions {
+	f.Comment = comment
+	return f
+}
+
+// SetLet sets the value for the Let field.
+func (f *FindOneAndUpdateOptions) SetLet(let interface{}) *FindOneAndUpdateOptions {
+	f.Let = let
+	return f
+}
+
 // SetMaxTime sets the value for the MaxTime field.
 func (f *FindOneAndUpdateOptions) SetMaxTime(d time.Duration) *FindOneAndUpdateOptions {
 	f.MaxTime = &d
@@ -840,6 +1076,12 @@ func MergeFindOneAndUpdateOptions(opts ...*FindOneAndUpdateOptions) *FindOneAndU
 		if opt.Collation != nil {
 			fo.Collation = opt.Collation
 		}
+		if opt.Comment != nil {
+			fo.Comment = opt.Comment
+		}
+		if opt.Let != nil {
+			fo.Let = opt.Let
+		}
 		if opt.MaxTime != nil {
 			fo.MaxTime = opt.MaxTime
 		}
@@ -870,6 +1112,17 @@ type FindOneAndDeleteOptions struct {
 	// default value is nil, which means the default collation of the collection will be used.
 	Collation *Collation
 
+	// A string or document that will be included in server logs, profiling logs, and currentOp queries to help trace
+	// the operation. The default value is nil, which means that no comment will be included in the logs.
+	Comment interface{}
+
+	// Specifies parameters for the find expression. This option is only valid for MongoDB versions >= 5.0. Older servers
+	// will report an error for using this option. This must be a document mapping parameter names to values. Values must be
+	// constant or closed expressions that do not reference document fields. Parameters can then be accessed as variables in
+	// an aggregate expression context (e.g. "$$var"). The default value is nil, which means that no let document will be
+	// sent.
+	Let interface{}
+
 	// The maximum amount of time that the query can run on the server. The default value is nil, meaning that there
 	// is no time limit for query execution.
 	MaxTime *time.Duration
@@ -903,6 +1156,18 @@ func (f *FindOneAndDeleteOptions) SetCollation(collation *Collation) *FindOneAnd
 	return f
 }
 
+// SetComment sets the value for the Comment field.
+func (f *FindOneAndDeleteOptions) SetComment(comment interface{}) *FindOneAndDeleteOptions {
+	f.Comment = comment
+	return f
+}
+
+// SetLet sets the value for the Let field.
+func (f *FindOneAndDeleteOptions) SetLet(let interface{}) *FindOneAndDeleteOptions {
+	f.Let = let
+	return f
+}
+
 // SetMaxTime sets the value for the MaxTime field.
 func (f *FindOneAndDeleteOptions) SetMaxTime(d time.Duration) *FindOneAndDeleteOptions {
 	f.MaxTime = &d
@@ -938,6 +1203,12 @@ func MergeFindOneAndDeleteOptions(opts ...*FindOneAndDeleteOptions) *FindOneAndD
 		if opt.Collation != nil {
 			fo.Collation = opt.Collation
 		}
+		if opt.Comment != nil {
+			fo.Comment = opt.Comment
+		}
+		if opt.Let != nil {
+			fo.Let = opt.Let
+		}
 		if opt.MaxTime != nil {
 			fo.MaxTime = opt.MaxTime
 		}
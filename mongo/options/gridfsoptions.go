@@ -218,6 +218,16 @@ type GridFSFindOptions struct {
 	// The maximum number of documents to be included in each batch returned by the server.
 	BatchSize *int32
 
+	// Specifies a collation to use for string comparisons during the operation. This option is only valid for MongoDB
+	// versions >= 3.4. For previous server versions, the driver will return an error if this option is used. The
+	// default value is nil, which means the default collation of the files collection will be used.
+	Collation *Collation
+
+	// The index of the files collection to use for the operation. This should either be the index name as a string or
+	// the index specification as a document. The driver will return an error if the hint parameter is a multi-key map.
+	// The default value is nil, which means that no hint will be sent.
+	Hint interface{}
+
 	// The maximum number of documents to return. The default value is 0, which means that all documents matching the
 	// filter will be returned. A negative limit specifies that the resulting documents should be returned in a single
 	// batch. The default value is 0.
@@ -256,6 +266,18 @@ func (f *GridFSFindOptions) SetBatchSize(i int32) *GridFSFindOptions {
 	return f
 }
 
+// SetCollation sets the value for the Collation field.
+func (f *GridFSFindOptions) SetCollation(c *Collation) *GridFSFindOptions {
+	f.Collation = c
+	return f
+}
+
+// SetHint sets the value for the Hint field.
+func (f *GridFSFindOptions) SetHint(hint interface{}) *GridFSFindOptions {
+	f.Hint = hint
+	return f
+}
+
 // SetLimit sets the value for the Limit field.
 func (f *GridFSFindOptions) SetLimit(i int32) *GridFSFindOptions {
 	f.Limit = &i
@@ -300,6 +322,12 @@ func MergeGridFSFindOptions(opts ...*GridFSFindOptions) *GridFSFindOptions {
 		if opt.BatchSize != nil {
 			fo.BatchSize = opt.BatchSize
 		}
+		if opt.Collation != nil {
+			fo.Collation = opt.Collation
+		}
+		if opt.Hint != nil {
+			fo.Hint = opt.Hint
+		}
 		if opt.Limit != nil {
 			fo.Limit = opt.Limit
 		}
@@ -0,0 +1,63 @@
+// Copyright (C) MongoDB, Inc. 2021-present.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
+
+package options
+
+import "time"
+
+// DefaultHedgeDelay is the time a hedged write waits for an attempt before starting the next one if no delay is
+// specified.
+const DefaultHedgeDelay = 500 * time.Millisecond
+
+// DefaultHedgeMaxAttempts is the maximum number of attempts of a hedged write if no maximum is specified.
+const DefaultHedgeMaxAttempts = 2
+
+// HedgedWriteOptions represents options that can be used to configure a hedged write such as Collection.HedgedUpsert.
+type HedgedWriteOptions struct {
+	// The time to wait for the response to an attempt before starting the next attempt. This should be set to a high
+	// percentile of the normal latency of the write. The default value is DefaultHedgeDelay.
+	Delay *time.Duration
+
+	// The maximum number of attempts, including the first one. The default value is DefaultHedgeMaxAttempts.
+	MaxAttempts *int
+}
+
+// HedgedWrite creates a new HedgedWriteOptions instance.
+func HedgedWrite() *HedgedWriteOptions {
+	return &HedgedWriteOptions{}
+}
+
+// SetDelay sets the value for the Delay field.
+func (ho *HedgedWriteOptions) SetDelay(d time.Duration) *HedgedWriteOptions {
+	ho.Delay = &d
+	return ho
+}
+
+// SetMaxAttempts sets the value for the MaxAttempts field.
+func (ho *HedgedWriteOptions) SetMaxAttempts(i int) *HedgedWriteOptions {
+	ho.MaxAttempts = &i
+	return ho
+}
+
+// MergeHedgedWriteOptions combines the given HedgedWriteOptions instances into a single HedgedWriteOptions in a
+// last-one-wins fashion.
+func MergeHedgedWriteOptions(opts ...*HedgedWriteOptions) *HedgedWriteOptions {
+	ho := HedgedWrite()
+	for _, opt := range opts {
+		if opt == nil {
+			continue
+		}
+
+		if opt.Delay != nil {
+			ho.Delay = opt.Delay
+		}
+		if opt.MaxAttempts != nil {
+			ho.MaxAttempts = opt.MaxAttempts
+		}
+	}
+
+	return ho
+}
@@ -121,6 +121,41 @@ func MergeDropIndexesOptions(opts ...*DropIndexesOptions) *DropIndexesOptions {
 	return c
 }
 
+// EnsureIndexesOptions represents options that can be used to configure IndexView.DiffIndexes and
+// IndexView.EnsureIndexes operations.
+type EnsureIndexesOptions struct {
+	// If true, indexes that exist on the collection but are not in the list of models will be dropped. The _id index
+	// is never dropped. The default value is false.
+	DropExtra *bool
+}
+
+// EnsureIndexes creates a new EnsureIndexesOptions instance.
+func EnsureIndexes() *EnsureIndexesOptions {
+	return &EnsureIndexesOptions{}
+}
+
+// SetDropExtra sets the value for the DropExtra field.
+func (e *EnsureIndexesOptions) SetDropExtra(b bool) *EnsureIndexesOptions {
+	e.DropExtra = &b
+	return e
+}
+
+// MergeEnsureIndexesOptions combines the given EnsureIndexesOptions into a single EnsureIndexesOptions in a
+// last-one-wins fashion.
+func MergeEnsureIndexesOptions(opts ...*EnsureIndexesOptions) *EnsureIndexesOptions {
+	e := EnsureIndexes()
+	for _, opt := range opts {
+		if opt == nil {
+			continue
+		}
+		if opt.DropExtra != nil {
+			e.DropExtra = opt.DropExtra
+		}
+	}
+
+	return e
+}
+
 // ListIndexesOptions represents options that can be used to configure an IndexView.List operation.
 type ListIndexesOptions struct {
 	// The maximum number of documents to be included in each batch returned by the server.
@@ -13,6 +13,10 @@ type InsertOneOptions struct {
 	// false. See https://docs.mongodb.com/manual/core/schema-validation/ for more information about document
 	// validation.
 	BypassDocumentValidation *bool
+
+	// A string or document that will be included in server logs, profiling logs, and currentOp queries to help trace
+	// the operation. The default value is nil, which means that no comment will be included in the logs.
+	Comment interface{}
 }
 
 // InsertOne creates a new InsertOneOptions instance.
@@ -26,6 +30,12 @@ func (ioo *InsertOneOptions) SetBypassDocumentValidation(b bool) *InsertOneOptio
 	return ioo
 }
 
+// SetComment sets the value for the Comment field.
+func (ioo *InsertOneOptions) SetComment(comment interface{}) *InsertOneOptions {
+	ioo.Comment = comment
+	return ioo
+}
+
 // MergeInsertOneOptions combines the given InsertOneOptions instances into a single InsertOneOptions in a last-one-wins
 // fashion.
 func MergeInsertOneOptions(opts ...*InsertOneOptions) *InsertOneOptions {
@@ -37,6 +47,9 @@ func MergeInsertOneOptions(opts ...*InsertOneOptions) *InsertOneOptions {
 		if ioo.BypassDocumentValidation != nil {
 			ioOpts.BypassDocumentValidation = ioo.BypassDocumentValidation
 		}
+		if ioo.Comment != nil {
+			ioOpts.Comment = ioo.Comment
+		}
 	}
 
 	return ioOpts
@@ -50,6 +63,10 @@ type InsertManyOptions struct {
 	// validation.
 	BypassDocumentValidation *bool
 
+	// A string or document that will be included in server logs, profiling logs, and currentOp queries to help trace
+	// the operation. The default value is nil, which means that no comment will be included in the logs.
+	Comment interface{}
+
 	// If true, no writes will be executed after one fails. The default value is true.
 	Ordered *bool
 }
@@ -67,6 +84,12 @@ func (imo *InsertManyOptions) SetBypassDocumentValidation(b bool) *InsertManyOpt
 	return imo
 }
 
+// SetComment sets the value for the Comment field.
+func (imo *InsertManyOptions) SetComment(comment interface{}) *InsertManyOptions {
+	imo.Comment = comment
+	return imo
+}
+
 // SetOrdered sets the value for the Ordered field.
 func (imo *InsertManyOptions) SetOrdered(b bool) *InsertManyOptions {
 	imo.Ordered = &b
@@ -84,6 +107,9 @@ func MergeInsertManyOptions(opts ...*InsertManyOptions) *InsertManyOptions {
 		if imo.BypassDocumentValidation != nil {
 			imOpts.BypassDocumentValidation = imo.BypassDocumentValidation
 		}
+		if imo.Comment != nil {
+			imOpts.Comment = imo.Comment
+		}
 		if imo.Ordered != nil {
 			imOpts.Ordered = imo.Ordered
 		}
@@ -0,0 +1,147 @@
+// Copyright (C) MongoDB, Inc. 2021-present.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
+
+package options
+
+// ModifyIndexOptions represents changes to an existing index. This type can be used when modifying a collection
+// through the ModifyCollectionOptions.SetIndex method. The index to modify must be identified by exactly one of the
+// Name and Keys fields.
+type ModifyIndexOptions struct {
+	// The name of the index to modify.
+	Name *string
+
+	// The keys document of the index to modify. This must be an order-preserving type such as bson.D. Map types such
+	// as bson.M are not valid.
+	Keys interface{}
+
+	// Specifies the new number of seconds after which documents in a TTL index expire.
+	ExpireAfterSeconds *int64
+
+	// Specifies whether the index is hidden from the query planner. This option is only valid for MongoDB versions
+	// >= 4.4.
+	Hidden *bool
+}
+
+// ModifyIndex creates a new ModifyIndexOptions instance.
+func ModifyIndex() *ModifyIndexOptions {
+	return &ModifyIndexOptions{}
+}
+
+// SetName sets the value for the Name field.
+func (m *ModifyIndexOptions) SetName(name string) *ModifyIndexOptions {
+	m.Name = &name
+	return m
+}
+
+// SetKeys sets the value for the Keys field.
+func (m *ModifyIndexOptions) SetKeys(keys interface{}) *ModifyIndexOptions {
+	m.Keys = keys
+	return m
+}
+
+// SetExpireAfterSeconds sets the value for the ExpireAfterSeconds field.
+func (m *ModifyIndexOptions) SetExpireAfterSeconds(seconds int64) *ModifyIndexOptions {
+	m.ExpireAfterSeconds = &seconds
+	return m
+}
+
+// SetHidden sets the value for the Hidden field.
+func (m *ModifyIndexOptions) SetHidden(hidden bool) *ModifyIndexOptions {
+	m.Hidden = &hidden
+	return m
+}
+
+// ModifyCollectionOptions represents options that can be used to configure a ModifyCollection operation.
+type ModifyCollectionOptions struct {
+	// Specifies whether change streams opened on the collection include the pre- and post-images of modified
+	// documents. This option is only valid for MongoDB versions >= 6.0. The default value is nil, meaning the
+	// setting is not changed.
+	ChangeStreamPreAndPostImages *bool
+
+	// Specifies changes to an existing index on the collection. The default value is nil, meaning no index is modified.
+	Index *ModifyIndexOptions
+
+	// Specifies what should happen if a document being inserted does not pass validation. Valid values are "error" and
+	// "warn". See https://docs.mongodb.com/manual/core/schema-validation/#accept-or-reject-invalid-documents for more
+	// information. The default value is nil, meaning the setting is not changed.
+	ValidationAction *string
+
+	// Specifies how strictly the server applies validation rules to existing documents in the collection during update
+	// operations. Valid values are "off", "strict", and "moderate". See
+	// https://docs.mongodb.com/manual/core/schema-validation/#existing-documents for more information. The default
+	// value is nil, meaning the setting is not changed.
+	ValidationLevel *string
+
+	// A document specifying the new validation rules for the collection. See
+	// https://docs.mongodb.com/manual/core/schema-validation/ for more information about schema validation. The default
+	// value is nil, meaning the validator is not changed.
+	Validator interface{}
+}
+
+// ModifyCollection creates a new ModifyCollectionOptions instance.
+func ModifyCollection() *ModifyCollectionOptions {
+	return &ModifyCollectionOptions{}
+}
+
+// SetChangeStreamPreAndPostImages sets the value for the ChangeStreamPreAndPostImages field.
+func (m *ModifyCollectionOptions) SetChangeStreamPreAndPostImages(enabled bool) *ModifyCollectionOptions {
+	m.ChangeStreamPreAndPostImages = &enabled
+	return m
+}
+
+// SetIndex sets the value for the Index field.
+func (m *ModifyCollectionOptions) SetIndex(index *ModifyIndexOptions) *ModifyCollectionOptions {
+	m.Index = index
+	return m
+}
+
+// SetValidationAction sets the value for the ValidationAction field.
+func (m *ModifyCollectionOptions) SetValidationAction(action string) *ModifyCollectionOptions {
+	m.ValidationAction = &action
+	return m
+}
+
+// SetValidationLevel sets the value for the ValidationLevel field.
+func (m *ModifyCollectionOptions) SetValidationLevel(level string) *ModifyCollectionOptions {
+	m.ValidationLevel = &level
+	return m
+}
+
+// SetValidator sets the value for the Validator field.
+func (m *ModifyCollectionOptions) SetValidator(validator interface{}) *ModifyCollectionOptions {
+	m.Validator = validator
+	return m
+}
+
+// MergeModifyCollectionOptions combines the given ModifyCollectionOptions instances into a single
+// ModifyCollectionOptions in a last-one-wins fashion.
+func MergeModifyCollectionOptions(opts ...*ModifyCollectionOptions) *ModifyCollectionOptions {
+	mc := ModifyCollection()
+
+	for _, opt := range opts {
+		if opt == nil {
+			continue
+		}
+
+		if opt.ChangeStreamPreAndPostImages != nil {
+			mc.ChangeStreamPreAndPostImages = opt.ChangeStreamPreAndPostImages
+		}
+		if opt.Index != nil {
+			mc.Index = opt.Index
+		}
+		if opt.ValidationAction != nil {
+			mc.ValidationAction = opt.ValidationAction
+		}
+		if opt.ValidationLevel != nil {
+			mc.ValidationLevel = opt.ValidationLevel
+		}
+		if opt.Validator != nil {
+			mc.Validator = opt.Validator
+		}
+	}
+
+	return mc
+}
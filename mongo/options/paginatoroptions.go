@@ -0,0 +1,86 @@
+// Copyright (C) MongoDB, Inc. 2021-present.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
+
+package options
+
+// DefaultPageSize is the number of documents returned per page by a Paginator if no page size is specified.
+const DefaultPageSize int64 = 100
+
+// PaginatorOptions represents options that can be used to configure a Paginator.
+type PaginatorOptions struct {
+	// A document specifying the order in which documents are paginated, e.g. {createdAt: -1}. Each value must be 1 for
+	// ascending or -1 for descending order. The _id field is appended in ascending order if it is not included, so the
+	// order is always unique. This option must be a document with a single key or an ordered type such as bson.D. The
+	// default value is nil, which means documents are paginated in ascending order of _id.
+	Sort interface{}
+
+	// The maximum number of documents in each page. The default value is DefaultPageSize.
+	PageSize *int64
+
+	// A document describing which fields will be included in the documents returned. The sort fields must be included.
+	// The default value is nil, which means all fields will be included.
+	Projection interface{}
+
+	// The index to use for the queries. This should be an index on the sort fields. This should either be the index
+	// name as a string or the index specification as a document. The default value is nil, which means that no hint
+	// will be sent.
+	Hint interface{}
+}
+
+// Paginator creates a new PaginatorOptions instance.
+func Paginator() *PaginatorOptions {
+	return &PaginatorOptions{}
+}
+
+// SetSort sets the value for the Sort field.
+func (po *PaginatorOptions) SetSort(sort interface{}) *PaginatorOptions {
+	po.Sort = sort
+	return po
+}
+
+// SetPageSize sets the value for the PageSize field.
+func (po *PaginatorOptions) SetPageSize(i int64) *PaginatorOptions {
+	po.PageSize = &i
+	return po
+}
+
+// SetProjection sets the value for the Projection field.
+func (po *PaginatorOptions) SetProjection(projection interface{}) *PaginatorOptions {
+	po.Projection = projection
+	return po
+}
+
+// SetHint sets the value for the Hint field.
+func (po *PaginatorOptions) SetHint(hint interface{}) *PaginatorOptions {
+	po.Hint = hint
+	return po
+}
+
+// MergePaginatorOptions combines the given PaginatorOptions instances into a single PaginatorOptions in a
+// last-one-wins fashion.
+func MergePaginatorOptions(opts ...*PaginatorOptions) *PaginatorOptions {
+	po := Paginator()
+	for _, opt := range opts {
+		if opt == nil {
+			continue
+		}
+
+		if opt.Sort != nil {
+			po.Sort = opt.Sort
+		}
+		if opt.PageSize != nil {
+			po.PageSize = opt.PageSize
+		}
+		if opt.Projection != nil {
+			po.Projection = opt.Projection
+		}
+		if opt.Hint != nil {
+			po.Hint = opt.Hint
+		}
+	}
+
+	return po
+}
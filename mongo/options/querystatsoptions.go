@@ -0,0 +1,90 @@
+// Copyright (C) MongoDB, Inc. 2021-present.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
+
+package options
+
+// QueryStatsOptions represents options that can be used to configure a Client.QueryStats operation.
+type QueryStatsOptions struct {
+	// The key used to transform the field names and string literals of the query shapes with the HMAC-SHA-256
+	// algorithm, so that the statistics can be collected without reading the names of the fields and collections. The
+	// server requires the key to be at least 32 bytes long. By default, the identifiers are not transformed.
+	HMACKey []byte
+
+	// The maximum number of entries to include in each batch returned by the server.
+	BatchSize *int32
+}
+
+// QueryStats creates a new QueryStatsOptions instance.
+func QueryStats() *QueryStatsOptions {
+	return &QueryStatsOptions{}
+}
+
+// SetHMACKey sets the value for the HMACKey field.
+func (qo *QueryStatsOptions) SetHMACKey(key []byte) *QueryStatsOptions {
+	qo.HMACKey = key
+	return qo
+}
+
+// SetBatchSize sets the value for the BatchSize field.
+func (qo *QueryStatsOptions) SetBatchSize(i int32) *QueryStatsOptions {
+	qo.BatchSize = &i
+	return qo
+}
+
+// MergeQueryStatsOptions combines the given QueryStatsOptions instances into a single QueryStatsOptions in a
+// last-one-wins fashion.
+func MergeQueryStatsOptions(opts ...*QueryStatsOptions) *QueryStatsOptions {
+	qo := QueryStats()
+	for _, opt := range opts {
+		if opt == nil {
+			continue
+		}
+
+		if opt.HMACKey != nil {
+			qo.HMACKey = opt.HMACKey
+		}
+		if opt.BatchSize != nil {
+			qo.BatchSize = opt.BatchSize
+		}
+	}
+
+	return qo
+}
+
+// OperationMetricsOptions represents options that can be used to configure a Client.OperationMetrics operation.
+type OperationMetricsOptions struct {
+	// If true, the server resets the metrics after returning them, so that each call returns the resources consumed
+	// since the previous one. The default value is false.
+	ClearMetrics *bool
+}
+
+// OperationMetrics creates a new OperationMetricsOptions instance.
+func OperationMetrics() *OperationMetricsOptions {
+	return &OperationMetricsOptions{}
+}
+
+// SetClearMetrics sets the value for the ClearMetrics field.
+func (oo *OperationMetricsOptions) SetClearMetrics(b bool) *OperationMetricsOptions {
+	oo.ClearMetrics = &b
+	return oo
+}
+
+// MergeOperationMetricsOptions combines the given OperationMetricsOptions instances into a single
+// OperationMetricsOptions in a last-one-wins fashion.
+func MergeOperationMetricsOptions(opts ...*OperationMetricsOptions) *OperationMetricsOptions {
+	oo := OperationMetrics()
+	for _, opt := range opts {
+		if opt == nil {
+			continue
+		}
+
+		if opt.ClearMetrics != nil {
+			oo.ClearMetrics = opt.ClearMetrics
+		}
+	}
+
+	return oo
+}
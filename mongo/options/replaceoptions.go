@@ -19,6 +19,10 @@ type ReplaceOptions struct {
 	// default value is nil, which means the default collation of the collection will be used.
 	Collation *Collation
 
+	// A string or document that will be included in server logs, profiling logs, and currentOp queries to help trace
+	// the operation. The default value is nil, which means that no comment will be included in the logs.
+	Comment interface{}
+
 	// The index to use for the operation. This should either be the index name as a string or the index specification
 	// as a document. This option is only valid for MongoDB versions >= 4.2. Server versions >= 3.4 will return an error
 	// if this option is specified. For server versions < 3.4, the driver will return a client-side error if this option
@@ -27,6 +31,13 @@ type ReplaceOptions struct {
 	// which means that no hint will be sent.
 	Hint interface{}
 
+	// Specifies parameters for the update expression. This option is only valid for MongoDB versions >= 5.0. Older servers
+	// will report an error for using this option. This must be a document mapping parameter names to values. Values must be
+	// constant or closed expressions that do not reference document fields. Parameters can then be accessed as variables in
+	// an aggregate expression context (e.g. "$$var"). The default value is nil, which means that no let document will be
+	// sent.
+	Let interface{}
+
 	// If true, a new document will be inserted if the filter does not match any documents in the collection. The
 	// default value is false.
 	Upsert *bool
@@ -49,12 +60,24 @@ func (ro *ReplaceOptions) SetCollation(c *Collation) *ReplaceOptions {
 	return ro
 }
 
+// SetComment sets the value for the Comment field.
+func (ro *ReplaceOptions) SetComment(comment interface{}) *ReplaceOptions {
+	ro.Comment = comment
+	return ro
+}
+
 // SetHint sets the value for the Hint field.
 func (ro *ReplaceOptions) SetHint(h interface{}) *ReplaceOptions {
 	ro.Hint = h
 	return ro
 }
 
+// SetLet sets the value for the Let field.
+func (ro *ReplaceOptions) SetLet(let interface{}) *ReplaceOptions {
+	ro.Let = let
+	return ro
+}
+
 // SetUpsert sets the value for the Upsert field.
 func (ro *ReplaceOptions) SetUpsert(b bool) *ReplaceOptions {
 	ro.Upsert = &b
@@ -75,9 +98,15 @@ func MergeReplaceOptions(opts ...*ReplaceOptions) *ReplaceOptions {
 		if ro.Collation != nil {
 			rOpts.Collation = ro.Collation
 		}
+		if ro.Comment != nil {
+			rOpts.Comment = ro.Comment
+		}
 		if ro.Hint != nil {
 			rOpts.Hint = ro.Hint
 		}
+		if ro.Let != nil {
+			rOpts.Let = ro.Let
+		}
 		if ro.Upsert != nil {
 			rOpts.Upsert = ro.Upsert
 		}
@@ -6,13 +6,33 @@
 
 package options
 
-import "go.mongodb.org/mongo-driver/mongo/readpref"
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/mongo/readpref"
+)
 
 // RunCmdOptions represents options that can be used to configure a RunCommand operation.
 type RunCmdOptions struct {
 	// The read preference to use for the operation. The default value is nil, which means that the primary read
 	// preference will be used.
 	ReadPreference *readpref.ReadPref
+
+	// The maximum number of documents to be included in each batch returned by the server for getMore commands. This
+	// option is only used by Database.RunCommandCursor. The default value is nil, which means that the server will
+	// choose the batch size.
+	BatchSize *int32
+
+	// The maximum amount of time that the server should wait for new documents to satisfy a getMore command on a
+	// tailable await cursor. This option is only used by Database.RunCommandCursor and is only valid for commands
+	// that create a tailable cursor with awaitData set, e.g. a find command with tailable and awaitData set to true on
+	// a capped collection. The default value is nil, which means the server default will be used.
+	MaxAwaitTime *time.Duration
+
+	// A string that will be included with getMore commands to help trace the operation through the database profiler,
+	// currentOp, and logs. This option is only used by Database.RunCommandCursor and is only valid for MongoDB
+	// versions >= 4.4. The default value is nil, which means that no comment will be included.
+	Comment *string
 }
 
 // RunCmd creates a new RunCmdOptions instance.
@@ -26,6 +46,24 @@ func (rc *RunCmdOptions) SetReadPreference(rp *readpref.ReadPref) *RunCmdOptions
 	return rc
 }
 
+// SetBatchSize sets value for the BatchSize field.
+func (rc *RunCmdOptions) SetBatchSize(size int32) *RunCmdOptions {
+	rc.BatchSize = &size
+	return rc
+}
+
+// SetMaxAwaitTime sets value for the MaxAwaitTime field.
+func (rc *RunCmdOptions) SetMaxAwaitTime(d time.Duration) *RunCmdOptions {
+	rc.MaxAwaitTime = &d
+	return rc
+}
+
+// SetComment sets value for the Comment field.
+func (rc *RunCmdOptions) SetComment(comment string) *RunCmdOptions {
+	rc.Comment = &comment
+	return rc
+}
+
 // MergeRunCmdOptions combines the given RunCmdOptions instances into one *RunCmdOptions in a last-one-wins fashion.
 func MergeRunCmdOptions(opts ...*RunCmdOptions) *RunCmdOptions {
 	rc := RunCmd()
@@ -36,6 +74,15 @@ func MergeRunCmdOptions(opts ...*RunCmdOptions) *RunCmdOptions {
 		if opt.ReadPreference != nil {
 			rc.ReadPreference = opt.ReadPreference
 		}
+		if opt.BatchSize != nil {
+			rc.BatchSize = opt.BatchSize
+		}
+		if opt.MaxAwaitTime != nil {
+			rc.MaxAwaitTime = opt.MaxAwaitTime
+		}
+		if opt.Comment != nil {
+			rc.Comment = opt.Comment
+		}
 	}
 
 	return rc
@@ -0,0 +1,81 @@
+// Copyright (C) MongoDB, Inc. 2021-present.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
+
+package options
+
+// SearchIndexesOptions represents options that can be used to configure a SearchIndexView.
+type SearchIndexesOptions struct {
+	// The name of the search index. For SearchIndexView.CreateOne and SearchIndexView.CreateMany, the default value is
+	// nil, meaning the server will name the index "default". For SearchIndexView.List, the default value is nil,
+	// meaning all search indexes will be listed.
+	Name *string
+
+	// The type of the search index. Valid values are "search" and "vectorSearch". The default value is nil, meaning
+	// the server default of "search" will be used.
+	Type *string
+}
+
+// SearchIndexes creates a new SearchIndexesOptions instance.
+func SearchIndexes() *SearchIndexesOptions {
+	return &SearchIndexesOptions{}
+}
+
+// SetName sets the value for the Name field.
+func (sio *SearchIndexesOptions) SetName(name string) *SearchIndexesOptions {
+	sio.Name = &name
+	return sio
+}
+
+// SetType sets the value for the Type field.
+func (sio *SearchIndexesOptions) SetType(typ string) *SearchIndexesOptions {
+	sio.Type = &typ
+	return sio
+}
+
+// CreateSearchIndexesOptions represents options that can be used to configure a SearchIndexView.CreateOne or
+// SearchIndexView.CreateMany operation.
+type CreateSearchIndexesOptions struct {
+}
+
+// CreateSearchIndexes creates a new CreateSearchIndexesOptions instance.
+func CreateSearchIndexes() *CreateSearchIndexesOptions {
+	return &CreateSearchIndexesOptions{}
+}
+
+// ListSearchIndexesOptions represents options that can be used to configure a SearchIndexView.List operation.
+type ListSearchIndexesOptions struct {
+	// The options to use for the aggregate command that lists the search indexes.
+	AggregateOpts *AggregateOptions
+}
+
+// ListSearchIndexes creates a new ListSearchIndexesOptions instance.
+func ListSearchIndexes() *ListSearchIndexesOptions {
+	return &ListSearchIndexesOptions{}
+}
+
+// SetAggregateOpts sets the value for the AggregateOpts field.
+func (l *ListSearchIndexesOptions) SetAggregateOpts(opts *AggregateOptions) *ListSearchIndexesOptions {
+	l.AggregateOpts = opts
+	return l
+}
+
+// DropSearchIndexOptions represents options that can be used to configure a SearchIndexView.DropOne operation.
+type DropSearchIndexOptions struct {
+}
+
+// DropSearchIndex creates a new DropSearchIndexOptions instance.
+func DropSearchIndex() *DropSearchIndexOptions {
+	return &DropSearchIndexOptions{}
+}
+
+// UpdateSearchIndexOptions represents options that can be used to configure a SearchIndexView.UpdateOne operation.
+type UpdateSearchIndexOptions struct {
+}
+
+// UpdateSearchIndex creates a new UpdateSearchIndexOptions instance.
+func UpdateSearchIndex() *UpdateSearchIndexOptions {
+	return &UpdateSearchIndexOptions{}
+}
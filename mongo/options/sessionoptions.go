@@ -14,12 +14,14 @@ import (
 	"go.mongodb.org/mongo-driver/mongo/writeconcern"
 )
 
-// DefaultCausalConsistency is the default value for the CausalConsistency option.
+// DefaultCausalConsistency is the default value for the CausalConsistency option. It is not used for snapshot
+// sessions, which are not causally consistent unless CausalConsistency is set.
 var DefaultCausalConsistency = true
 
 // SessionOptions represents options that can be used to configure a Session.
 type SessionOptions struct {
-	// If true, causal consistency will be enabled for the session. The default value is true. See
+	// If true, causal consistency will be enabled for the session. This option cannot be set to true if Snapshot is
+	// set to true. The default value is true unless Snapshot is set to true. See
 	// https://docs.mongodb.com/manual/core/read-isolation-consistency-recency/#sessions for more information.
 	CausalConsistency *bool
 
@@ -38,13 +40,17 @@ type SessionOptions struct {
 	// The default maximum amount of time that a CommitTransaction operation executed in the session can run on the
 	// server. The default value is nil, which means that that there is no time limit for execution.
 	DefaultMaxCommitTime *time.Duration
+
+	// If true, all read operations performed with the session will read from the same snapshot. The snapshot is
+	// established by the first read operation and reused by later ones. This option cannot be set to true if
+	// CausalConsistency is set to true, and transactions cannot be started in snapshot sessions. This option is only
+	// valid for MongoDB versions >= 5.0. The default value is false.
+	Snapshot *bool
 }
 
 // Session creates a new SessionOptions instance.
 func Session() *SessionOptions {
-	return &SessionOptions{
-		CausalConsistency: &DefaultCausalConsistency,
-	}
+	return &SessionOptions{}
 }
 
 // SetCausalConsistency sets the value for the CausalConsistency field.
@@ -77,6 +83,12 @@ func (s *SessionOptions) SetDefaultMaxCommitTime(mct *time.Duration) *SessionOpt
 	return s
 }
 
+// SetSnapshot sets the value for the Snapshot field.
+func (s *SessionOptions) SetSnapshot(b bool) *SessionOptions {
+	s.Snapshot = &b
+	return s
+}
+
 // MergeSessionOptions combines the given SessionOptions instances into a single SessionOptions in a last-one-wins
 // fashion.
 func MergeSessionOptions(opts ...*SessionOptions) *SessionOptions {
@@ -100,6 +112,9 @@ func MergeSessionOptions(opts ...*SessionOptions) *SessionOptions {
 		if opt.DefaultMaxCommitTime != nil {
 			s.DefaultMaxCommitTime = opt.DefaultMaxCommitTime
 		}
+		if opt.Snapshot != nil {
+			s.Snapshot = opt.Snapshot
+		}
 	}
 
 	return s
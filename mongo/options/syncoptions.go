@@ -0,0 +1,81 @@
+// Copyright (C) MongoDB, Inc. 2021-present.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
+
+package options
+
+// SyncOptions represents options that can be used to configure a SyncDocuments operation.
+type SyncOptions struct {
+	// The fields that uniquely identify a document. Fields in embedded documents can be specified with dot notation.
+	// The default value is nil, which means documents are identified by their _id field.
+	KeyFields []string
+
+	// A filter that limits the documents in the collection that are synchronized. Documents that do not match the
+	// filter are not updated or deleted. The default value is nil, which means the whole collection is synchronized.
+	Scope interface{}
+
+	// If true, documents in the scope whose keys are not in the given documents are deleted. The default value is
+	// true.
+	Delete *bool
+
+	// If true, writes executed as part of the operation will opt out of document-level validation on the server. The
+	// default value is false. See https://docs.mongodb.com/manual/core/schema-validation/ for more information about
+	// document validation.
+	BypassDocumentValidation *bool
+}
+
+// Sync creates a new SyncOptions instance.
+func Sync() *SyncOptions {
+	return &SyncOptions{}
+}
+
+// SetKeyFields sets the value for the KeyFields field.
+func (so *SyncOptions) SetKeyFields(fields ...string) *SyncOptions {
+	so.KeyFields = fields
+	return so
+}
+
+// SetScope sets the value for the Scope field.
+func (so *SyncOptions) SetScope(scope interface{}) *SyncOptions {
+	so.Scope = scope
+	return so
+}
+
+// SetDelete sets the value for the Delete field.
+func (so *SyncOptions) SetDelete(b bool) *SyncOptions {
+	so.Delete = &b
+	return so
+}
+
+// SetBypassDocumentValidation sets the value for the BypassDocumentValidation field.
+func (so *SyncOptions) SetBypassDocumentValidation(b bool) *SyncOptions {
+	so.BypassDocumentValidation = &b
+	return so
+}
+
+// MergeSyncOptions combines the given SyncOptions instances into a single SyncOptions in a last-one-wins fashion.
+func MergeSyncOptions(opts ...*SyncOptions) *SyncOptions {
+	so := Sync()
+	for _, opt := range opts {
+		if opt == nil {
+			continue
+		}
+
+		if opt.KeyFields != nil {
+			so.KeyFields = opt.KeyFields
+		}
+		if opt.Scope != nil {
+			so.Scope = opt.Scope
+		}
+		if opt.Delete != nil {
+			so.Delete = opt.Delete
+		}
+		if opt.BypassDocumentValidation != nil {
+			so.BypassDocumentValidation = opt.BypassDocumentValidation
+		}
+	}
+
+	return so
+}
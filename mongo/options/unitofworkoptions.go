@@ -0,0 +1,76 @@
+// Copyright (C) MongoDB, Inc. 2021-present.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
+
+package options
+
+import "context"
+
+// UnitOfWorkHook is a function called when a unit of work is committed with the domain events raised in it, in the
+// order in which they were raised.
+type UnitOfWorkHook func(ctx context.Context, events []interface{}) error
+
+// UnitOfWorkOptions represents options that can be used to configure a unit of work created by Client.NewUnitOfWork.
+type UnitOfWorkOptions struct {
+	// The options for the transaction in which the writes are committed. The default value is nil, which means that
+	// the transaction options of the session are used.
+	TransactionOptions *TransactionOptions
+
+	// A hook called after the writes have been sent and before the transaction is committed. It is called with the
+	// context of the transaction, so writes done with that context, e.g. to an outbox collection, are committed or
+	// aborted along with the writes of the unit of work. If it returns an error, the transaction is aborted. The hook
+	// can be called more than once if the transaction is retried.
+	BeforeCommit UnitOfWorkHook
+
+	// A hook called once after the writes have been committed, e.g. to publish the domain events. If it returns an
+	// error, the error is returned by Commit, but the writes stay committed.
+	AfterCommit UnitOfWorkHook
+}
+
+// UnitOfWork creates a new UnitOfWorkOptions instance.
+func UnitOfWork() *UnitOfWorkOptions {
+	return &UnitOfWorkOptions{}
+}
+
+// SetTransactionOptions sets the value for the TransactionOptions field.
+func (uo *UnitOfWorkOptions) SetTransactionOptions(opts *TransactionOptions) *UnitOfWorkOptions {
+	uo.TransactionOptions = opts
+	return uo
+}
+
+// SetBeforeCommit sets the value for the BeforeCommit field.
+func (uo *UnitOfWorkOptions) SetBeforeCommit(hook UnitOfWorkHook) *UnitOfWorkOptions {
+	uo.BeforeCommit = hook
+	return uo
+}
+
+// SetAfterCommit sets the value for the AfterCommit field.
+func (uo *UnitOfWorkOptions) SetAfterCommit(hook UnitOfWorkHook) *UnitOfWorkOptions {
+	uo.AfterCommit = hook
+	return uo
+}
+
+// MergeUnitOfWorkOptions combines the given UnitOfWorkOptions instances into a single UnitOfWorkOptions in a
+// last-one-wins fashion.
+func MergeUnitOfWorkOptions(opts ...*UnitOfWorkOptions) *UnitOfWorkOptions {
+	uo := UnitOfWork()
+	for _, opt := range opts {
+		if opt == nil {
+			continue
+		}
+
+		if opt.TransactionOptions != nil {
+			uo.TransactionOptions = opt.TransactionOptions
+		}
+		if opt.BeforeCommit != nil {
+			uo.BeforeCommit = opt.BeforeCommit
+		}
+		if opt.AfterCommit != nil {
+			uo.AfterCommit = opt.AfterCommit
+		}
+	}
+
+	return uo
+}
@@ -0,0 +1,69 @@
+// Copyright (C) MongoDB, Inc. 2021-present.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
+
+package options
+
+import "go.mongodb.org/mongo-driver/bson/bsoncodec"
+
+// UpdateDiffOptions represents options that can be used to configure the update created by mongo.NewUpdateFromDiff.
+type UpdateDiffOptions struct {
+	// If true, fields that exist in the old document but not in the new document are left unchanged. Otherwise, they are
+	// removed with $unset. The default value is false.
+	KeepMissingFields *bool
+
+	// The registry used to marshal the old and new documents. The default value is nil, which means that
+	// bson.DefaultRegistry will be used.
+	Registry *bsoncodec.Registry
+
+	// If true, a new document will be inserted if no document has the _id of the new document. The default value is
+	// nil, which means that no new document will be inserted.
+	Upsert *bool
+}
+
+// UpdateDiff creates a new UpdateDiffOptions instance.
+func UpdateDiff() *UpdateDiffOptions {
+	return &UpdateDiffOptions{}
+}
+
+// SetKeepMissingFields sets the value for the KeepMissingFields field.
+func (udo *UpdateDiffOptions) SetKeepMissingFields(b bool) *UpdateDiffOptions {
+	udo.KeepMissingFields = &b
+	return udo
+}
+
+// SetRegistry sets the value for the Registry field.
+func (udo *UpdateDiffOptions) SetRegistry(r *bsoncodec.Registry) *UpdateDiffOptions {
+	udo.Registry = r
+	return udo
+}
+
+// SetUpsert sets the value for the Upsert field.
+func (udo *UpdateDiffOptions) SetUpsert(b bool) *UpdateDiffOptions {
+	udo.Upsert = &b
+	return udo
+}
+
+// MergeUpdateDiffOptions combines the given UpdateDiffOptions instances into a single UpdateDiffOptions in a
+// last-one-wins fashion.
+func MergeUpdateDiffOptions(opts ...*UpdateDiffOptions) *UpdateDiffOptions {
+	udOpts := UpdateDiff()
+	for _, udo := range opts {
+		if udo == nil {
+			continue
+		}
+		if udo.KeepMissingFields != nil {
+			udOpts.KeepMissingFields = udo.KeepMissingFields
+		}
+		if udo.Registry != nil {
+			udOpts.Registry = udo.Registry
+		}
+		if udo.Upsert != nil {
+			udOpts.Upsert = udo.Upsert
+		}
+	}
+
+	return udOpts
+}
@@ -24,6 +24,10 @@ type UpdateOptions struct {
 	// default value is nil, which means the default collation of the collection will be used.
 	Collation *Collation
 
+	// A string or document that will be included in server logs, profiling logs, and currentOp queries to help trace
+	// the operation. The default value is nil, which means that no comment will be included in the logs.
+	Comment interface{}
+
 	// The index to use for the operation. This should either be the index name as a string or the index specification
 	// as a document. This option is only valid for MongoDB versions >= 4.2. Server versions >= 3.4 will return an error
 	// if this option is specified. For server versions < 3.4, the driver will return a client-side error if this option
@@ -32,6 +36,13 @@ type UpdateOptions struct {
 	// which means that no hint will be sent.
 	Hint interface{}
 
+	// Specifies parameters for the update expression. This option is only valid for MongoDB versions >= 5.0. Older servers
+	// will report an error for using this option. This must be a document mapping parameter names to values. Values must be
+	// constant or closed expressions that do not reference document fields. Parameters can then be accessed as variables in
+	// an aggregate expression context (e.g. "$$var"). The default value is nil, which means that no let document will be
+	// sent.
+	Let interface{}
+
 	// If true, a new document will be inserted if the filter does not match any documents in the collection. The
 	// default value is false.
 	Upsert *bool
@@ -60,12 +71,24 @@ func (uo *UpdateOptions) SetCollation(c *Collation) *UpdateOptions {
 	return uo
 }
 
+// SetComment sets the value for the Comment field.
+func (uo *UpdateOptions) SetComment(comment interface{}) *UpdateOptions {
+	uo.Comment = comment
+	return uo
+}
+
 // SetHint sets the value for the Hint field.
 func (uo *UpdateOptions) SetHint(h interface{}) *UpdateOptions {
 	uo.Hint = h
 	return uo
 }
 
+// SetLet sets the value for the Let field.
+func (uo *UpdateOptions) SetLet(let interface{}) *UpdateOptions {
+	uo.Let = let
+	return uo
+}
+
 // SetUpsert sets the value for the Upsert field.
 func (uo *UpdateOptions) SetUpsert(b bool) *UpdateOptions {
 	uo.Upsert = &b
@@ -88,9 +111,15 @@ func MergeUpdateOptions(opts ...*UpdateOptions) *UpdateOptions {
 		if uo.Collation != nil {
 			uOpts.Collation = uo.Collation
 		}
+		if uo.Comment != nil {
+			uOpts.Comment = uo.Comment
+		}
 		if uo.Hint != nil {
 			uOpts.Hint = uo.Hint
 		}
+		if uo.Let != nil {
+			uOpts.Let = uo.Let
+		}
 		if uo.Upsert != nil {
 			uOpts.Upsert = uo.Upsert
 		}
@@ -0,0 +1,204 @@
+// Copyright (C) MongoDB, Inc. 2021-present.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
+
+package mongo
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"strings"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo/description"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"go.mongodb.org/mongo-driver/mongo/readpref"
+	"go.mongodb.org/mongo-driver/x/mongo/driver/topology"
+)
+
+// ErrEmptyAppName is returned by Client.KillOrphanedCursors if the app name is empty.
+var ErrEmptyAppName = errors.New("app name must not be empty")
+
+// KillOrphanedCursors kills the idle cursors that were opened by clients with the given app name (see
+// options.ClientOptions.SetAppName) and returns the number of cursors that were killed. It is meant to be called on
+// startup to clean up the cursors left open by previous instances of the application that crashed, which otherwise
+// hold server resources until they time out, or forever if they were created with the NoCursorTimeout option.
+//
+// The idle cursors are found with the $currentOp aggregation stage, which requires MongoDB 4.2+, and are killed with
+// the killCursors command. $currentOp only reports the cursors of the server it runs on, so it is run on every
+// data-bearing member of a replica set and on every mongos of a sharded cluster, with the localOps option so that each
+// mongos reports its own cursors, and each cursor is killed on the server that owns it. The servers behind a load
+// balancer cannot be targeted individually, so only the cursors of the server the load balancer selects are found.
+//
+// Only the cursors of the authenticated user are considered. All of the idle cursors with the app name are killed,
+// including those of other instances that are still running, so the app name should identify a single instance, e.g.
+// by including the host name, and this method should be called before the client creates any cursor.
+func (c *Client) KillOrphanedCursors(ctx context.Context, appName string) (int, error) {
+	if appName == "" {
+		return 0, ErrEmptyAppName
+	}
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	targets, err := c.orphanedCursorTargets(ctx)
+	if err != nil {
+		return 0, err
+	}
+	var killed int
+	for _, target := range targets {
+		n, err := c.killOrphanedCursorsOn(ctx, appName, target)
+		killed += n
+		if err != nil {
+			return killed, err
+		}
+	}
+	return killed, nil
+}
+
+// orphanedCursorTarget is a server that KillOrphanedCursors looks for idle cursors on. A nil selector means that the
+// server is selected with the default read preference of RunCommand.
+type orphanedCursorTarget struct {
+	selector description.ServerSelector
+	localOps bool
+}
+
+// orphanedCursorTargets returns a target for each data-bearing server of the deployment, or a single target without a
+// selector if the servers cannot be targeted individually.
+func (c *Client) orphanedCursorTargets(ctx context.Context) ([]orphanedCursorTarget, error) {
+	t, ok := c.deployment.(*topology.Topology)
+	if !ok || t.Kind() == description.LoadBalanced {
+		return []orphanedCursorTarget{{}}, nil
+	}
+
+	// Wait until a data-bearing server has been discovered.
+	if _, err := t.SelectServer(ctx, description.ReadPrefSelector(readpref.Nearest())); err != nil {
+		return nil, replaceErrors(err)
+	}
+	return newOrphanedCursorTargets(t.Description().Servers), nil
+}
+
+// newOrphanedCursorTargets returns a target for each of the data-bearing servers in servers. The cursors of a mongos
+// are only reported by $currentOp with the localOps option, which also excludes the operations of the shards.
+func newOrphanedCursorTargets(servers []description.Server) []orphanedCursorTarget {
+	var targets []orphanedCursorTarget
+	for _, s := range servers {
+		if !s.DataBearing() {
+			continue
+		}
+		targets = append(targets, orphanedCursorTarget{
+			selector: makeServerAddressSelector(s.Addr, description.ReadPrefSelector(readpref.Nearest())),
+			localOps: s.Kind == description.Mongos,
+		})
+	}
+	return targets
+}
+
+// killOrphanedCursorsOn finds the idle cursors with the app name on the target server and kills them on that server.
+func (c *Client) killOrphanedCursorsOn(ctx context.Context, appName string, target orphanedCursorTarget) (int, error) {
+	currentOp := bson.D{{"idleCursors", true}}
+	if target.localOps {
+		currentOp = append(currentOp, bson.E{"localOps", true})
+	}
+	pipeline := Pipeline{
+		{{"$currentOp", currentOp}},
+		{{"$match", bson.D{
+			{"type", "idleCursor"},
+			{"$or", bson.A{
+				bson.D{{"appName", appName}},
+				bson.D{{"cursor.originatingCommand.$client.application.name", appName}},
+			}},
+		}}},
+		{{"$project", bson.D{{"_id", 0}, {"ns", 1}, {"cursorId", "$cursor.cursorId"}}}},
+	}
+	cmd := bson.D{{"aggregate", 1}, {"pipeline", pipeline}, {"cursor", bson.D{}}}
+	cursor, err := c.Database("admin").runCommandCursorOn(ctx, cmd, target.selector)
+	if err != nil {
+		return 0, err
+	}
+	var orphans []struct {
+		NS       string `bson:"ns"`
+		CursorID int64  `bson:"cursorId"`
+	}
+	if err = cursor.All(ctx, &orphans); err != nil {
+		return 0, err
+	}
+
+	ids := make(map[string][]int64)
+	for _, orphan := range orphans {
+		ids[orphan.NS] = append(ids[orphan.NS], orphan.CursorID)
+	}
+	namespaces := make([]string, 0, len(ids))
+	for ns := range ids {
+		namespaces = append(namespaces, ns)
+	}
+	sort.Strings(namespaces)
+
+	var killed int
+	for _, ns := range namespaces {
+		idx := strings.IndexByte(ns, '.')
+		if idx == -1 {
+			continue
+		}
+		var res struct {
+			CursorsKilled []int64 `bson:"cursorsKilled"`
+		}
+		cmd := bson.D{{"killCursors", ns[idx+1:]}, {"cursors", ids[ns]}}
+		if err = c.Database(ns[:idx]).runCommandOn(ctx, cmd, target.selector).Decode(&res); err != nil {
+			return killed, err
+		}
+		killed += len(res.CursorsKilled)
+	}
+	return killed, nil
+}
+
+// runCommandOn behaves like RunCommand but sends the command to the server selected by selector, with a read
+// preference that allows it to run on a secondary. A nil selector is equivalent to calling RunCommand.
+func (db *Database) runCommandOn(ctx context.Context, cmd interface{}, selector description.ServerSelector) *SingleResult {
+	if selector == nil {
+		return db.RunCommand(ctx, cmd)
+	}
+
+	op, sess, err := db.processRunCommand(ctx, cmd, false, options.RunCmd().SetReadPreference(readpref.Nearest()))
+	defer closeImplicitSession(sess)
+	if err != nil {
+		return &SingleResult{err: err}
+	}
+
+	err = op.ServerSelector(selector).Execute(ctx)
+	_, convErr := processWriteError(err)
+	return &SingleResult{
+		err: convErr,
+		rdr: bson.Raw(op.Result()),
+		reg: db.registry,
+	}
+}
+
+// runCommandCursorOn behaves like RunCommandCursor but sends the command to the server selected by selector, with a
+// read preference that allows it to run on a secondary. A nil selector is equivalent to calling RunCommandCursor.
+func (db *Database) runCommandCursorOn(ctx context.Context, cmd interface{},
+	selector description.ServerSelector) (*Cursor, error) {
+
+	if selector == nil {
+		return db.RunCommandCursor(ctx, cmd)
+	}
+
+	op, sess, err := db.processRunCommand(ctx, cmd, true, options.RunCmd().SetReadPreference(readpref.Nearest()))
+	if err != nil {
+		closeImplicitSession(sess)
+		return nil, replaceErrors(err)
+	}
+	if err = op.ServerSelector(selector).Execute(ctx); err != nil {
+		closeImplicitSession(sess)
+		return nil, replaceErrors(err)
+	}
+	bc, err := op.ResultCursor()
+	if err != nil {
+		closeImplicitSession(sess)
+		return nil, replaceErrors(err)
+	}
+	return newCursorWithSession(bc, db.registry, sess)
+}
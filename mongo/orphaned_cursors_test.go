@@ -0,0 +1,73 @@
+// Copyright (C) MongoDB, Inc. 2021-present.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
+
+package mongo_test
+
+import (
+	"context"
+	"testing"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/internal/testutil/assert"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/mongotest"
+)
+
+func TestKillOrphanedCursors(t *testing.T) {
+	d := mongotest.New()
+	client, err := d.NewClient()
+	assert.Nil(t, err, "NewClient error: %v", err)
+	defer func() { _ = client.Disconnect(context.Background()) }()
+
+	t.Run("kills cursors per namespace", func(t *testing.T) {
+		d.Reset()
+		d.AddReplies(
+			mongotest.CursorReply("admin.$cmd.aggregate",
+				bson.D{{"ns", "shop.orders"}, {"cursorId", int64(11)}},
+				bson.D{{"ns", "app.logs"}, {"cursorId", int64(21)}},
+				bson.D{{"ns", "shop.orders"}, {"cursorId", int64(12)}},
+			),
+			mongotest.SuccessReply(bson.E{"cursorsKilled", bson.A{int64(21)}}),
+			mongotest.SuccessReply(bson.E{"cursorsKilled", bson.A{int64(11), int64(12)}}),
+		)
+
+		killed, err := client.KillOrphanedCursors(context.Background(), "worker-1")
+		assert.Nil(t, err, "KillOrphanedCursors error: %v", err)
+		assert.Equal(t, 3, killed, "expected 3 cursors killed, got %v", killed)
+
+		cmds := d.Commands()
+		assert.Equal(t, 3, len(cmds), "expected 3 commands, got %v", len(cmds))
+		assert.Equal(t, "aggregate", cmds[0].Name, "expected aggregate, got %v", cmds[0].Name)
+		assert.Equal(t, "admin", cmds[0].Database, "expected database admin, got %v", cmds[0].Database)
+
+		type killCursors struct {
+			Database string
+			Coll     string  `bson:"killCursors"`
+			Cursors  []int64 `bson:"cursors"`
+		}
+		expected := []killCursors{{"app", "logs", []int64{21}}, {"shop", "orders", []int64{11, 12}}}
+		for i, cmd := range cmds[1:] {
+			var got killCursors
+			err = bson.Unmarshal(cmd.Document, &got)
+			assert.Nil(t, err, "Unmarshal error: %v", err)
+			got.Database = cmd.Database
+			assert.Equal(t, expected[i], got, "expected command %v, got %v", expected[i], got)
+		}
+	})
+	t.Run("no orphaned cursors", func(t *testing.T) {
+		d.Reset()
+		d.AddReplies(mongotest.CursorReply("admin.$cmd.aggregate"))
+
+		killed, err := client.KillOrphanedCursors(context.Background(), "worker-1")
+		assert.Nil(t, err, "KillOrphanedCursors error: %v", err)
+		assert.Equal(t, 0, killed, "expected no cursors killed, got %v", killed)
+		assert.Equal(t, 1, len(d.Commands()), "expected 1 command, got %v", len(d.Commands()))
+	})
+	t.Run("empty app name", func(t *testing.T) {
+		_, err := client.KillOrphanedCursors(context.Background(), "")
+		assert.Equal(t, mongo.ErrEmptyAppName, err, "expected error %v, got %v", mongo.ErrEmptyAppName, err)
+	})
+}
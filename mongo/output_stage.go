@@ -0,0 +1,210 @@
+// Copyright (C) MongoDB, Inc. 2021-present.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
+
+package mongo
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+// Values for the MergeStage.WhenMatched field.
+const (
+	MergeWhenMatchedReplace      = "replace"
+	MergeWhenMatchedKeepExisting = "keepExisting"
+	MergeWhenMatchedMerge        = "merge"
+	MergeWhenMatchedFail         = "fail"
+)
+
+// Values for the MergeStage.WhenNotMatched field.
+const (
+	MergeWhenNotMatchedInsert  = "insert"
+	MergeWhenNotMatchedDiscard = "discard"
+	MergeWhenNotMatchedFail    = "fail"
+)
+
+// mergeWhenMatchedPipelineStages are the stages that can be used in the MergeStage.WhenMatchedPipeline pipeline.
+var mergeWhenMatchedPipelineStages = map[string]struct{}{
+	"$addFields":   {},
+	"$set":         {},
+	"$project":     {},
+	"$unset":       {},
+	"$replaceRoot": {},
+	"$replaceWith": {},
+}
+
+// MergeStage represents a $merge aggregation stage, which writes the results of an aggregation to a collection. The
+// options are validated when the stage is converted to a document, so mistakes are reported before the aggregation is
+// sent to the server. A MergeStage can be used as the last stage of a pipeline of type []interface{} because it
+// marshals to a {"$merge": {...}} document, or it can be converted to a bson.D for a Pipeline using the Stage method.
+// This stage is only valid for MongoDB versions >= 4.2. See
+// https://docs.mongodb.com/manual/reference/operator/aggregation/merge/ for more information.
+type MergeStage struct {
+	// The database of the output collection. The default value is "", meaning the database of the aggregation is used.
+	Database string
+
+	// The name of the output collection. It is required.
+	Collection string
+
+	// The fields used to match result documents to documents in the output collection. The output collection must
+	// have a unique index on these fields. The default value is nil, meaning the documents are matched by _id.
+	On []string
+
+	// Variables that can be referenced in WhenMatchedPipeline. The variable $$new always refers to the result
+	// document. This must be nil unless WhenMatchedPipeline is set.
+	Let interface{}
+
+	// The action to take if a result document matches a document in the output collection. This must be one of the
+	// MergeWhenMatched constants and cannot be set together with WhenMatchedPipeline. The default value is "", meaning
+	// the server default of "merge" is used.
+	WhenMatched string
+
+	// A pipeline that updates the matching document in the output collection. It can only contain $addFields, $set,
+	// $project, $unset, $replaceRoot, and $replaceWith stages and cannot be set together with WhenMatched.
+	WhenMatchedPipeline Pipeline
+
+	// The action to take if a result document does not match a document in the output collection. This must be one of
+	// the MergeWhenNotMatched constants. The default value is "", meaning the server default of "insert" is used.
+	WhenNotMatched string
+}
+
+// Stage validates the options of ms and returns the $merge stage document.
+func (ms MergeStage) Stage() (bson.D, error) {
+	if err := validateOutputNamespace(ms.Database, ms.Collection); err != nil {
+		return nil, fmt.Errorf("invalid $merge stage: %v", err)
+	}
+
+	var into interface{} = ms.Collection
+	if ms.Database != "" {
+		into = bson.D{{"db", ms.Database}, {"coll", ms.Collection}}
+	}
+	merge := bson.D{{"into", into}}
+
+	if len(ms.On) > 0 {
+		seen := make(map[string]struct{}, len(ms.On))
+		for _, field := range ms.On {
+			if field == "" {
+				return nil, errors.New("invalid $merge stage: the On fields cannot be empty")
+			}
+			if _, ok := seen[field]; ok {
+				return nil, fmt.Errorf("invalid $merge stage: the On field %q is specified more than once", field)
+			}
+			seen[field] = struct{}{}
+		}
+
+		if len(ms.On) == 1 {
+			merge = append(merge, bson.E{"on", ms.On[0]})
+		} else {
+			merge = append(merge, bson.E{"on", ms.On})
+		}
+	}
+
+	if ms.Let != nil {
+		if ms.WhenMatchedPipeline == nil {
+			return nil, errors.New("invalid $merge stage: Let can only be set if WhenMatchedPipeline is set")
+		}
+		merge = append(merge, bson.E{"let", ms.Let})
+	}
+
+	switch {
+	case ms.WhenMatched != "" && ms.WhenMatchedPipeline != nil:
+		return nil, errors.New("invalid $merge stage: WhenMatched and WhenMatchedPipeline cannot both be set")
+	case ms.WhenMatchedPipeline != nil:
+		if len(ms.WhenMatchedPipeline) == 0 {
+			return nil, errors.New("invalid $merge stage: WhenMatchedPipeline cannot be empty")
+		}
+		for i, stage := range ms.WhenMatchedPipeline {
+			if len(stage) == 0 {
+				return nil, fmt.Errorf("invalid $merge stage: WhenMatchedPipeline stage %d is empty", i)
+			}
+			if _, ok := mergeWhenMatchedPipelineStages[stage[0].Key]; !ok {
+				return nil, fmt.Errorf("invalid $merge stage: %s cannot be used in WhenMatchedPipeline", stage[0].Key)
+			}
+		}
+		merge = append(merge, bson.E{"whenMatched", ms.WhenMatchedPipeline})
+	case ms.WhenMatched != "":
+		switch ms.WhenMatched {
+		case MergeWhenMatchedReplace, MergeWhenMatchedKeepExisting, MergeWhenMatchedMerge, MergeWhenMatchedFail:
+		default:
+			return nil, fmt.Errorf("invalid $merge stage: unknown WhenMatched action %q", ms.WhenMatched)
+		}
+		merge = append(merge, bson.E{"whenMatched", ms.WhenMatched})
+	}
+
+	if ms.WhenNotMatched != "" {
+		switch ms.WhenNotMatched {
+		case MergeWhenNotMatchedInsert, MergeWhenNotMatchedDiscard, MergeWhenNotMatchedFail:
+		default:
+			return nil, fmt.Errorf("invalid $merge stage: unknown WhenNotMatched action %q", ms.WhenNotMatched)
+		}
+		merge = append(merge, bson.E{"whenNotMatched", ms.WhenNotMatched})
+	}
+
+	return bson.D{{"$merge", merge}}, nil
+}
+
+// MarshalBSON implements the bson.Marshaler interface.
+func (ms MergeStage) MarshalBSON() ([]byte, error) {
+	stage, err := ms.Stage()
+	if err != nil {
+		return nil, err
+	}
+	return bson.Marshal(stage)
+}
+
+// OutStage represents an $out aggregation stage, which replaces a collection with the results of an aggregation. An
+// OutStage can be used as the last stage of a pipeline of type []interface{} because it marshals to a {"$out": ...}
+// document, or it can be converted to a bson.D for a Pipeline using the Stage method. Writing to a different database
+// is only valid for MongoDB versions >= 4.4. See https://docs.mongodb.com/manual/reference/operator/aggregation/out/
+// for more information.
+type OutStage struct {
+	// The database of the output collection. The default value is "", meaning the database of the aggregation is used.
+	Database string
+
+	// The name of the output collection. It is required.
+	Collection string
+}
+
+// Stage validates the options of o and returns the $out stage document.
+func (o OutStage) Stage() (bson.D, error) {
+	if err := validateOutputNamespace(o.Database, o.Collection); err != nil {
+		return nil, fmt.Errorf("invalid $out stage: %v", err)
+	}
+
+	if o.Database == "" {
+		return bson.D{{"$out", o.Collection}}, nil
+	}
+	return bson.D{{"$out", bson.D{{"db", o.Database}, {"coll", o.Collection}}}}, nil
+}
+
+// MarshalBSON implements the bson.Marshaler interface.
+func (o OutStage) MarshalBSON() ([]byte, error) {
+	stage, err := o.Stage()
+	if err != nil {
+		return nil, err
+	}
+	return bson.Marshal(stage)
+}
+
+// validateOutputNamespace checks the database and collection names of an output stage against the naming restrictions
+// of the server. The database is optional.
+func validateOutputNamespace(db, coll string) error {
+	if strings.ContainsAny(db, "/\\. \"$\x00") {
+		return fmt.Errorf("database name %q contains a character that is not allowed", db)
+	}
+	switch {
+	case coll == "":
+		return errors.New("the collection name is required")
+	case strings.ContainsAny(coll, "$\x00"):
+		return fmt.Errorf("collection name %q contains a character that is not allowed", coll)
+	case strings.HasPrefix(coll, "system."):
+		return fmt.Errorf("collection name %q is reserved for internal use", coll)
+	}
+	return nil
+}
@@ -0,0 +1,132 @@
+// Copyright (C) MongoDB, Inc. 2021-present.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
+
+package mongo
+
+import (
+	"testing"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/internal/testutil/assert"
+)
+
+func TestOutputStages(t *testing.T) {
+	t.Run("merge stage", func(t *testing.T) {
+		testCases := []struct {
+			name     string
+			stage    MergeStage
+			expected bson.D
+		}{
+			{
+				"collection only",
+				MergeStage{Collection: "out"},
+				bson.D{{"$merge", bson.D{{"into", "out"}}}},
+			},
+			{
+				"other database with actions",
+				MergeStage{
+					Database:       "reporting",
+					Collection:     "out",
+					On:             []string{"region", "day"},
+					WhenMatched:    MergeWhenMatchedReplace,
+					WhenNotMatched: MergeWhenNotMatchedDiscard,
+				},
+				bson.D{{"$merge", bson.D{
+					{"into", bson.D{{"db", "reporting"}, {"coll", "out"}}},
+					{"on", []string{"region", "day"}},
+					{"whenMatched", "replace"},
+					{"whenNotMatched", "discard"},
+				}}},
+			},
+			{
+				"pipeline with let",
+				MergeStage{
+					Collection:          "out",
+					On:                  []string{"region"},
+					Let:                 bson.D{{"total", "$total"}},
+					WhenMatchedPipeline: Pipeline{{{"$set", bson.D{{"total", "$$total"}}}}},
+				},
+				bson.D{{"$merge", bson.D{
+					{"into", "out"},
+					{"on", "region"},
+					{"let", bson.D{{"total", "$total"}}},
+					{"whenMatched", Pipeline{{{"$set", bson.D{{"total", "$$total"}}}}}},
+				}}},
+			},
+		}
+		for _, tc := range testCases {
+			t.Run(tc.name, func(t *testing.T) {
+				stage, err := tc.stage.Stage()
+				assert.Nil(t, err, "Stage error: %v", err)
+				assert.Equal(t, tc.expected, stage, "expected stage %v, got %v", tc.expected, stage)
+
+				got, err := bson.Marshal(tc.stage)
+				assert.Nil(t, err, "Marshal error: %v", err)
+				expected, err := bson.Marshal(tc.expected)
+				assert.Nil(t, err, "Marshal error: %v", err)
+				assert.Equal(t, bson.Raw(expected), bson.Raw(got), "expected %v, got %v", bson.Raw(expected), bson.Raw(got))
+			})
+		}
+	})
+	t.Run("merge stage validation", func(t *testing.T) {
+		testCases := []struct {
+			name  string
+			stage MergeStage
+		}{
+			{"missing collection", MergeStage{Database: "db"}},
+			{"invalid database", MergeStage{Database: "a.b", Collection: "out"}},
+			{"system collection", MergeStage{Collection: "system.views"}},
+			{"empty on field", MergeStage{Collection: "out", On: []string{""}}},
+			{"duplicate on field", MergeStage{Collection: "out", On: []string{"a", "a"}}},
+			{"let without pipeline", MergeStage{Collection: "out", Let: bson.D{{"a", 1}}}},
+			{"unknown when matched", MergeStage{Collection: "out", WhenMatched: "update"}},
+			{"unknown when not matched", MergeStage{Collection: "out", WhenNotMatched: "ignore"}},
+			{"when matched and pipeline",
+				MergeStage{Collection: "out", WhenMatched: MergeWhenMatchedMerge,
+					WhenMatchedPipeline: Pipeline{{{"$set", bson.D{{"a", 1}}}}}}},
+			{"empty pipeline", MergeStage{Collection: "out", WhenMatchedPipeline: Pipeline{}}},
+			{"invalid pipeline stage",
+				MergeStage{Collection: "out", WhenMatchedPipeline: Pipeline{{{"$match", bson.D{{"a", 1}}}}}}},
+		}
+		for _, tc := range testCases {
+			t.Run(tc.name, func(t *testing.T) {
+				_, err := tc.stage.Stage()
+				assert.NotNil(t, err, "expected Stage error, got nil")
+				_, err = bson.Marshal(tc.stage)
+				assert.NotNil(t, err, "expected Marshal error, got nil")
+			})
+		}
+	})
+	t.Run("out stage", func(t *testing.T) {
+		stage, err := OutStage{Collection: "out"}.Stage()
+		assert.Nil(t, err, "Stage error: %v", err)
+		expected := bson.D{{"$out", "out"}}
+		assert.Equal(t, expected, stage, "expected stage %v, got %v", expected, stage)
+
+		stage, err = OutStage{Database: "reporting", Collection: "out"}.Stage()
+		assert.Nil(t, err, "Stage error: %v", err)
+		expected = bson.D{{"$out", bson.D{{"db", "reporting"}, {"coll", "out"}}}}
+		assert.Equal(t, expected, stage, "expected stage %v, got %v", expected, stage)
+
+		_, err = OutStage{Database: "reporting"}.Stage()
+		assert.NotNil(t, err, "expected Stage error for missing collection, got nil")
+		_, err = OutStage{Database: "a$b", Collection: "out"}.Stage()
+		assert.NotNil(t, err, "expected Stage error for invalid database, got nil")
+	})
+	t.Run("output stage detected in pipeline", func(t *testing.T) {
+		pipeline := []interface{}{
+			bson.D{{"$match", bson.D{{"a", 1}}}},
+			MergeStage{Database: "reporting", Collection: "out"},
+		}
+		_, hasOutputStage, err := transformAggregatePipelinev2(bson.DefaultRegistry, pipeline)
+		assert.Nil(t, err, "transformAggregatePipelinev2 error: %v", err)
+		assert.True(t, hasOutputStage, "expected $merge stage to be detected as an output stage")
+
+		_, hasOutputStage, err = transformAggregatePipelinev2(bson.DefaultRegistry, []interface{}{OutStage{Collection: "out"}})
+		assert.Nil(t, err, "transformAggregatePipelinev2 error: %v", err)
+		assert.True(t, hasOutputStage, "expected $out stage to be detected as an output stage")
+	})
+}
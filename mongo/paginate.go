@@ -0,0 +1,70 @@
+// Copyright (C) MongoDB, Inc. 2021-present.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
+
+//go:build go1.23
+// +build go1.23
+
+package mongo
+
+import (
+	"context"
+	"iter"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// Paginate returns an iterator over the documents in coll that match filter, decoded into values of type T. The
+// documents are queried in pages of pageSize documents in ascending order of _id using keyset pagination (see the
+// Paginator documentation), so the iteration can scan a large collection without holding a cursor open between pages
+// and without the cost of a page growing with its position:
+//
+//	for order, err := range mongo.Paginate[Order](ctx, coll, bson.D{{"status", "open"}}, 500) {
+//		if err != nil {
+//			return err
+//		}
+//		process(order)
+//	}
+//
+// If creating the Paginator, querying a page, or decoding a document fails, the error is yielded with the zero value
+// of T and the iteration stops. A page is only queried when the documents of the previous page have been consumed, and
+// breaking out of the loop stops the iteration without querying further pages. Use NewPaginator directly to page in a
+// different sort order or with a projection.
+//
+// Paginate requires Go 1.23 or later.
+func Paginate[T any](ctx context.Context, coll *Collection, filter interface{}, pageSize int64) iter.Seq2[T, error] {
+	return func(yield func(T, error) bool) {
+		var zero T
+		p, err := NewPaginator(coll, filter, options.Paginator().SetPageSize(pageSize))
+		if err != nil {
+			yield(zero, err)
+			return
+		}
+
+		token := ""
+		for {
+			page, err := p.Page(ctx, token)
+			if err != nil {
+				yield(zero, err)
+				return
+			}
+			for _, doc := range page.Documents {
+				var val T
+				if err = bson.UnmarshalWithRegistry(coll.registry, doc, &val); err != nil {
+					yield(zero, err)
+					return
+				}
+				if !yield(val, nil) {
+					return
+				}
+			}
+			if page.NextToken == "" {
+				return
+			}
+			token = page.NextToken
+		}
+	}
+}
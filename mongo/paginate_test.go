@@ -0,0 +1,94 @@
+// Copyright (C) MongoDB, Inc. 2021-present.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
+
+//go:build go1.23
+// +build go1.23
+
+package mongo_test
+
+import (
+	"context"
+	"testing"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/internal/testutil/assert"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/mongotest"
+)
+
+func TestPaginate(t *testing.T) {
+	type order struct {
+		ID     int32  `bson:"_id"`
+		Status string `bson:"status"`
+	}
+	d := mongotest.New()
+	client, err := d.NewClient()
+	assert.Nil(t, err, "NewClient error: %v", err)
+	defer func() { _ = client.Disconnect(context.Background()) }()
+	coll := client.Database("db").Collection("orders")
+	filter := bson.D{{"status", "open"}}
+
+	t.Run("pages", func(t *testing.T) {
+		d.Reset()
+		d.AddReplies(
+			mongotest.CursorReply("db.orders",
+				bson.D{{"_id", 1}, {"status", "open"}},
+				bson.D{{"_id", 2}, {"status", "open"}},
+				bson.D{{"_id", 3}, {"status", "open"}},
+			),
+			mongotest.CursorReply("db.orders", bson.D{{"_id", 3}, {"status", "open"}}),
+		)
+
+		var ids []int32
+		for o, err := range mongo.Paginate[order](context.Background(), coll, filter, 2) {
+			assert.Nil(t, err, "Paginate error: %v", err)
+			ids = append(ids, o.ID)
+		}
+		assert.Equal(t, []int32{1, 2, 3}, ids, "expected orders [1 2 3], got %v", ids)
+
+		cmds := d.Commands()
+		assert.Equal(t, 2, len(cmds), "expected 2 finds, got %v", len(cmds))
+		limit := cmds[0].Document.Lookup("limit").Int64()
+		assert.Equal(t, int64(3), limit, "expected limit 3, got %v", limit)
+		_, err := cmds[1].Document.Lookup("filter").Document().LookupErr("$and")
+		assert.Nil(t, err, "expected second page to start after the first, got filter %v",
+			cmds[1].Document.Lookup("filter"))
+	})
+	t.Run("break stops querying", func(t *testing.T) {
+		d.Reset()
+		d.AddReplies(mongotest.CursorReply("db.orders",
+			bson.D{{"_id", 1}, {"status", "open"}},
+			bson.D{{"_id", 2}, {"status", "open"}},
+			bson.D{{"_id", 3}, {"status", "open"}},
+		))
+
+		for o, err := range mongo.Paginate[order](context.Background(), coll, filter, 2) {
+			assert.Nil(t, err, "Paginate error: %v", err)
+			assert.Equal(t, int32(1), o.ID, "expected order 1, got %v", o.ID)
+			break
+		}
+		cmds := d.Commands()
+		assert.Equal(t, 1, len(cmds), "expected 1 find, got %v", len(cmds))
+	})
+	t.Run("errors stop the iteration", func(t *testing.T) {
+		d.Reset()
+		d.AddReplies(mongotest.CursorReply("db.orders", bson.D{{"_id", "not an int"}}))
+
+		var errs []error
+		for _, err := range mongo.Paginate[order](context.Background(), coll, filter, 2) {
+			errs = append(errs, err)
+		}
+		assert.Equal(t, 1, len(errs), "expected 1 error, got %v", errs)
+		assert.NotNil(t, errs[0], "expected decode error, got nil")
+
+		errs = nil
+		for _, err := range mongo.Paginate[order](context.Background(), coll, filter, 0) {
+			errs = append(errs, err)
+		}
+		assert.Equal(t, 1, len(errs), "expected 1 error, got %v", errs)
+		assert.NotNil(t, errs[0], "expected page size error, got nil")
+	})
+}
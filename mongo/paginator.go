@@ -0,0 +1,255 @@
+// Copyright (C) MongoDB, Inc. 2021-present.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
+
+package mongo
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/bsoncodec"
+	"go.mongodb.org/mongo-driver/bson/bsontype"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"go.mongodb.org/mongo-driver/x/bsonx/bsoncore"
+)
+
+// ErrInvalidPageToken is returned by Paginator.Page if the page token was not returned by a Paginator with the same
+// sort order.
+var ErrInvalidPageToken = errors.New("invalid page token")
+
+// Paginator pages through the documents in a collection that match a filter using keyset pagination: each page is
+// queried with a filter on the sort fields that starts after the last document of the previous page, rather than by
+// skipping the documents of the previous pages. The cost of a page therefore does not grow with its position if there
+// is an index on the sort fields, and pages do not skip or repeat documents when documents before them are inserted
+// or deleted.
+//
+// The sort fields should be present with values of the same BSON type in all documents, because the comparison query
+// operators used to start a page only match values of the same type. Numeric types are compared with each other.
+//
+// A Paginator is safe for concurrent use.
+type Paginator struct {
+	coll       *Collection
+	filter     bsoncore.Document
+	sort       bsoncore.Document
+	fields     []string
+	ascending  []bool
+	pageSize   int64
+	projection interface{}
+	hint       interface{}
+}
+
+// Page is a page of documents returned by Paginator.Page.
+type Page struct {
+	// The documents in the page.
+	Documents []bson.Raw
+
+	// The token to pass to Paginator.Page to get the next page. This is empty if this is the last page.
+	NextToken string
+
+	registry *bsoncodec.Registry
+}
+
+// All decodes the documents in the page into results, which must be a pointer to a slice.
+func (p *Page) All(results interface{}) error {
+	resultsVal := reflect.ValueOf(results)
+	if resultsVal.Kind() != reflect.Ptr || resultsVal.Elem().Kind() != reflect.Slice {
+		return fmt.Errorf("results argument must be a pointer to a slice, but was a %s", resultsVal.Type())
+	}
+
+	sliceVal := reflect.MakeSlice(resultsVal.Elem().Type(), len(p.Documents), len(p.Documents))
+	for i, doc := range p.Documents {
+		if err := bson.UnmarshalWithRegistry(p.registry, doc, sliceVal.Index(i).Addr().Interface()); err != nil {
+			return err
+		}
+	}
+	resultsVal.Elem().Set(sliceVal)
+	return nil
+}
+
+// NewPaginator creates a Paginator for the documents in coll that match filter.
+//
+// The opts parameter can be used to specify the sort order and page size (see the options.PaginatorOptions
+// documentation).
+func NewPaginator(coll *Collection, filter interface{}, opts ...*options.PaginatorOptions) (*Paginator, error) {
+	po := options.MergePaginatorOptions(opts...)
+	p := &Paginator{
+		coll:       coll,
+		pageSize:   options.DefaultPageSize,
+		projection: po.Projection,
+		hint:       po.Hint,
+	}
+	if po.PageSize != nil {
+		if *po.PageSize <= 0 {
+			return nil, fmt.Errorf("page size must be positive, got %d", *po.PageSize)
+		}
+		p.pageSize = *po.PageSize
+	}
+
+	var err error
+	p.filter, err = transformBsoncoreDocument(coll.registry, filter, true, "filter")
+	if err != nil {
+		return nil, err
+	}
+
+	sort := po.Sort
+	if sort == nil {
+		sort = bson.D{}
+	}
+	sortDoc, err := transformBsoncoreDocument(coll.registry, sort, false, "sort")
+	if err != nil {
+		return nil, err
+	}
+	elems, err := sortDoc.Elements()
+	if err != nil {
+		return nil, err
+	}
+
+	idx, sortDoc := bsoncore.AppendDocumentStart(nil)
+	for _, elem := range elems {
+		dir, ok := elem.Value().AsInt64OK()
+		if !ok || (dir != 1 && dir != -1) {
+			return nil, fmt.Errorf("sort direction of field %q must be 1 or -1, got %v", elem.Key(), elem.Value())
+		}
+		p.fields = append(p.fields, elem.Key())
+		p.ascending = append(p.ascending, dir == 1)
+		sortDoc = bsoncore.AppendInt32Element(sortDoc, elem.Key(), int32(dir))
+		// _id is unique, so any field after it would not change the order.
+		if elem.Key() == "_id" {
+			break
+		}
+	}
+	if len(p.fields) == 0 || p.fields[len(p.fields)-1] != "_id" {
+		p.fields = append(p.fields, "_id")
+		p.ascending = append(p.ascending, true)
+		sortDoc = bsoncore.AppendInt32Element(sortDoc, "_id", 1)
+	}
+	p.sort, _ = bsoncore.AppendDocumentEnd(sortDoc, idx)
+	return p, nil
+}
+
+// Page returns the page of documents after the page that returned token, or the first page if token is empty.
+func (p *Paginator) Page(ctx context.Context, token string) (*Page, error) {
+	filter := bson.Raw(p.filter)
+	if token != "" {
+		values, err := p.decodeToken(token)
+		if err != nil {
+			return nil, err
+		}
+		filter, err = bson.MarshalWithRegistry(p.coll.registry, bson.D{{"$and", bson.A{filter, p.after(values)}}})
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	fo := options.Find().SetSort(bson.Raw(p.sort)).SetLimit(p.pageSize + 1)
+	if p.projection != nil {
+		fo.SetProjection(p.projection)
+	}
+	if p.hint != nil {
+		fo.SetHint(p.hint)
+	}
+	cursor, err := p.coll.Find(ctx, filter, fo)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	page := &Page{registry: p.coll.registry}
+	for cursor.Next(ctx) {
+		page.Documents = append(page.Documents, append(bson.Raw(nil), cursor.Current...))
+	}
+	if err = cursor.Err(); err != nil {
+		return nil, err
+	}
+
+	if int64(len(page.Documents)) > p.pageSize {
+		page.Documents = page.Documents[:p.pageSize]
+		page.NextToken, err = p.encodeToken(page.Documents[len(page.Documents)-1])
+		if err != nil {
+			return nil, err
+		}
+	}
+	return page, nil
+}
+
+// after returns a filter for the documents that come after the document with the given values of the sort fields:
+//
+//	{$or: [{f1: {$gt: v1}}, {f1: v1, f2: {$gt: v2}}, ...]}
+func (p *Paginator) after(values []bson.RawValue) bson.D {
+	clauses := make(bson.A, 0, len(p.fields))
+	for i, field := range p.fields {
+		clause := make(bson.D, 0, i+1)
+		for j := 0; j < i; j++ {
+			clause = append(clause, bson.E{Key: p.fields[j], Value: values[j]})
+		}
+		op := "$lt"
+		if p.ascending[i] {
+			op = "$gt"
+		}
+		clauses = append(clauses, append(clause, bson.E{Key: field, Value: bson.D{{op, values[i]}}}))
+	}
+	return bson.D{{"$or", clauses}}
+}
+
+// encodeToken returns a page token with the sort order and the values of the sort fields of doc.
+func (p *Paginator) encodeToken(doc bson.Raw) (string, error) {
+	aidx, values := bsoncore.AppendArrayStart(nil)
+	for i, field := range p.fields {
+		val, err := doc.LookupErr(strings.Split(field, ".")...)
+		if err != nil {
+			return "", fmt.Errorf("document does not have sort field %q", field)
+		}
+		values = bsoncore.AppendValueElement(values, strconv.Itoa(i), bsoncore.Value{Type: val.Type, Data: val.Value})
+	}
+	values, _ = bsoncore.AppendArrayEnd(values, aidx)
+
+	token := bsoncore.NewDocumentBuilder().
+		AppendDocument("s", p.sort).
+		AppendArray("v", values).
+		Build()
+	return base64.RawURLEncoding.EncodeToString(token), nil
+}
+
+// decodeToken returns the values of the sort fields in a page token.
+func (p *Paginator) decodeToken(token string) ([]bson.RawValue, error) {
+	b, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return nil, ErrInvalidPageToken
+	}
+	doc := bsoncore.Document(b)
+	if doc.Validate() != nil {
+		return nil, ErrInvalidPageToken
+	}
+	sort, ok := doc.Lookup("s").DocumentOK()
+	if !ok || !bytes.Equal(sort, p.sort) {
+		return nil, ErrInvalidPageToken
+	}
+	arr, ok := doc.Lookup("v").ArrayOK()
+	if !ok {
+		return nil, ErrInvalidPageToken
+	}
+	elems, err := arr.Elements()
+	if err != nil || len(elems) != len(p.fields) {
+		return nil, ErrInvalidPageToken
+	}
+
+	values := make([]bson.RawValue, 0, len(elems))
+	for _, elem := range elems {
+		val := elem.Value()
+		if val.Type == bsontype.Type(0) {
+			return nil, ErrInvalidPageToken
+		}
+		values = append(values, bson.RawValue{Type: val.Type, Value: val.Data})
+	}
+	return values, nil
+}
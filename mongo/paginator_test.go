@@ -0,0 +1,108 @@
+// Copyright (C) MongoDB, Inc. 2021-present.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
+
+package mongo
+
+import (
+	"testing"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/internal/testutil/assert"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+func TestPaginator(t *testing.T) {
+	coll := setupColl("paginator")
+	marshal := func(t *testing.T, doc interface{}) bson.Raw {
+		t.Helper()
+
+		b, err := bson.Marshal(doc)
+		assert.Nil(t, err, "Marshal error: %v", err)
+		return b
+	}
+
+	t.Run("sort", func(t *testing.T) {
+		testCases := []struct {
+			name     string
+			sort     interface{}
+			expected bson.D
+		}{
+			{"default", nil, bson.D{{"_id", int32(1)}}},
+			{"id appended", bson.D{{"createdAt", -1}}, bson.D{{"createdAt", int32(-1)}, {"_id", int32(1)}}},
+			{"fields after id ignored", bson.D{{"_id", -1}, {"x", 1}}, bson.D{{"_id", int32(-1)}}},
+			{"single key map", bson.M{"x": int64(1)}, bson.D{{"x", int32(1)}, {"_id", int32(1)}}},
+		}
+		for _, tc := range testCases {
+			t.Run(tc.name, func(t *testing.T) {
+				p, err := NewPaginator(coll, bson.D{}, options.Paginator().SetSort(tc.sort))
+				assert.Nil(t, err, "NewPaginator error: %v", err)
+				expected := marshal(t, tc.expected)
+				assert.Equal(t, expected, bson.Raw(p.sort), "expected sort %v, got %v", expected, bson.Raw(p.sort))
+			})
+		}
+	})
+	t.Run("invalid options", func(t *testing.T) {
+		_, err := NewPaginator(coll, bson.D{}, options.Paginator().SetSort(bson.D{{"x", 2}}))
+		assert.NotNil(t, err, "expected error for invalid sort direction, got nil")
+		_, err = NewPaginator(coll, bson.D{}, options.Paginator().SetSort(bson.D{{"x", "asc"}}))
+		assert.NotNil(t, err, "expected error for invalid sort direction, got nil")
+		_, err = NewPaginator(coll, bson.D{}, options.Paginator().SetPageSize(0))
+		assert.NotNil(t, err, "expected error for invalid page size, got nil")
+		_, err = NewPaginator(coll, nil)
+		assert.Equal(t, ErrNilDocument, err, "expected error %v, got %v", ErrNilDocument, err)
+	})
+	t.Run("after", func(t *testing.T) {
+		p, err := NewPaginator(coll, bson.D{}, options.Paginator().SetSort(bson.D{{"createdAt", -1}}))
+		assert.Nil(t, err, "NewPaginator error: %v", err)
+
+		createdAt := primitive.NewDateTimeFromTime(time.Unix(1600000000, 0))
+		id := primitive.NewObjectID()
+		token, err := p.encodeToken(marshal(t, bson.D{{"_id", id}, {"x", 1}, {"createdAt", createdAt}}))
+		assert.Nil(t, err, "encodeToken error: %v", err)
+		values, err := p.decodeToken(token)
+		assert.Nil(t, err, "decodeToken error: %v", err)
+
+		expected := marshal(t, bson.D{{"$or", bson.A{
+			bson.D{{"createdAt", bson.D{{"$lt", createdAt}}}},
+			bson.D{{"createdAt", createdAt}, {"_id", bson.D{{"$gt", id}}}},
+		}}})
+		got := marshal(t, p.after(values))
+		assert.Equal(t, expected, got, "expected filter %v, got %v", expected, got)
+	})
+	t.Run("invalid tokens", func(t *testing.T) {
+		p, err := NewPaginator(coll, bson.D{}, options.Paginator().SetSort(bson.D{{"x", 1}}))
+		assert.Nil(t, err, "NewPaginator error: %v", err)
+		other, err := NewPaginator(coll, bson.D{}, options.Paginator().SetSort(bson.D{{"x", -1}}))
+		assert.Nil(t, err, "NewPaginator error: %v", err)
+
+		_, err = p.encodeToken(marshal(t, bson.D{{"_id", 1}}))
+		assert.NotNil(t, err, "expected error for missing sort field, got nil")
+
+		otherToken, err := other.encodeToken(marshal(t, bson.D{{"_id", 1}, {"x", 1}}))
+		assert.Nil(t, err, "encodeToken error: %v", err)
+		for _, token := range []string{"not base64!", "AAAA", otherToken} {
+			_, err = p.Page(bgCtx, token)
+			assert.Equal(t, ErrInvalidPageToken, err, "expected error %v for token %q, got %v", ErrInvalidPageToken,
+				token, err)
+		}
+	})
+	t.Run("page decode", func(t *testing.T) {
+		page := &Page{
+			Documents: []bson.Raw{marshal(t, bson.D{{"x", 1}}), marshal(t, bson.D{{"x", 2}})},
+			registry:  bson.DefaultRegistry,
+		}
+		var results []struct{ X int }
+		err := page.All(&results)
+		assert.Nil(t, err, "All error: %v", err)
+		assert.Equal(t, 2, len(results), "expected 2 results, got %v", len(results))
+		assert.Equal(t, 2, results[1].X, "expected x 2, got %v", results[1].X)
+
+		err = page.All(results)
+		assert.NotNil(t, err, "expected error for non-pointer results, got nil")
+	})
+}
@@ -0,0 +1,175 @@
+// Copyright (C) MongoDB, Inc. 2021-present.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
+
+package mongo
+
+import (
+	"context"
+	"time"
+
+	"go.mongodb.org/mongo-driver/x/bsonx/bsoncore"
+	"go.mongodb.org/mongo-driver/x/mongo/driver"
+)
+
+// prefetchBatchCursor is a batchCursor that fetches the next batch from the wrapped batchCursor in the background
+// while the current batch is iterated. At most one batch is fetched ahead: the following batch is only requested once
+// the prefetched batch has been returned by Next.
+//
+// The wrapped batchCursor is not safe for concurrent use, so its state is copied whenever a batch is returned and it
+// is only accessed again after the background fetch has finished. For the same reason, a max await time set while a
+// fetch is in progress is applied once the fetch has finished, and the getMore statistics do not include the fetch
+// that is in progress.
+type prefetchBatchCursor struct {
+	bc batchCursor
+
+	// The state of bc when the current batch was returned by Next.
+	id     int64
+	batch  *bsoncore.DocumentSequence
+	server driver.Server
+	err    error
+	stats  driver.GetMoreStats
+
+	// maxTime is the max await time set by SetMaxTime while a fetch was in progress. It is nil if there is none.
+	maxTime *time.Duration
+
+	// pending receives the result of the background call to bc.Next. It is nil if no fetch is in progress.
+	pending chan bool
+}
+
+var _ batchCursor = (*prefetchBatchCursor)(nil)
+var _ awaitBatchCursor = (*prefetchBatchCursor)(nil)
+
+func newPrefetchBatchCursor(bc batchCursor) *prefetchBatchCursor {
+	pc := &prefetchBatchCursor{bc: bc}
+	pc.copyState()
+	return pc
+}
+
+func (pc *prefetchBatchCursor) ID() int64 {
+	return pc.id
+}
+
+func (pc *prefetchBatchCursor) Next(ctx context.Context) bool {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	var ok bool
+	if pc.pending == nil {
+		// There is no prefetched batch, e.g. because this is the first batch, so get it in the calling goroutine.
+		ok = pc.bc.Next(ctx)
+	} else {
+		select {
+		case ok = <-pc.pending:
+			pc.pending = nil
+			pc.applyMaxTime()
+		case <-ctx.Done():
+			pc.err = ctx.Err()
+			return false
+		}
+	}
+
+	pc.copyState()
+	if ok && pc.id != 0 {
+		pc.prefetch()
+	}
+	return ok
+}
+
+func (pc *prefetchBatchCursor) Batch() *bsoncore.DocumentSequence {
+	return pc.batch
+}
+
+func (pc *prefetchBatchCursor) Server() driver.Server {
+	return pc.server
+}
+
+func (pc *prefetchBatchCursor) Err() error {
+	return pc.err
+}
+
+func (pc *prefetchBatchCursor) Close(ctx context.Context) error {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	// Wait for the background fetch so the wrapped cursor is not used concurrently.
+	if pending := pc.pending; pending != nil {
+		pc.pending = nil
+		select {
+		case <-pending:
+		case <-ctx.Done():
+			// Close the wrapped cursor once the fetch has finished so it is not left open on the server.
+			pc.id = 0
+			go func() {
+				<-pending
+				_ = pc.bc.Close(context.Background())
+			}()
+			return ctx.Err()
+		}
+	}
+
+	err := pc.bc.Close(ctx)
+	pc.copyState()
+	return err
+}
+
+// SetMaxTime implements the awaitBatchCursor interface. It has no effect if the wrapped cursor does not implement
+// awaitBatchCursor.
+func (pc *prefetchBatchCursor) SetMaxTime(d time.Duration) {
+	if pc.pending != nil {
+		pc.maxTime = &d
+		return
+	}
+	if bc, ok := pc.bc.(awaitBatchCursor); ok {
+		bc.SetMaxTime(d)
+	}
+}
+
+// GetMoreStats implements the awaitBatchCursor interface.
+func (pc *prefetchBatchCursor) GetMoreStats() driver.GetMoreStats {
+	return pc.stats
+}
+
+// applyMaxTime sets the max await time that was set while a fetch was in progress on the wrapped cursor. It must only
+// be called when no fetch is in progress.
+func (pc *prefetchBatchCursor) applyMaxTime() {
+	if pc.maxTime == nil {
+		return
+	}
+	if bc, ok := pc.bc.(awaitBatchCursor); ok {
+		bc.SetMaxTime(*pc.maxTime)
+	}
+	pc.maxTime = nil
+}
+
+// prefetch starts fetching the next batch in the background. The fetch is not bound to the context of a call to Next
+// because it outlives the call. Its duration is limited by the MaxTime of the operation and the timeouts of the
+// connection.
+func (pc *prefetchBatchCursor) prefetch() {
+	pending := make(chan bool, 1)
+	pc.pending = pending
+	go func() {
+		pending <- pc.bc.Next(context.Background())
+	}()
+}
+
+// copyState copies the state of the wrapped cursor. The batch is copied because the wrapped cursor reuses its
+// DocumentSequence for the next batch.
+func (pc *prefetchBatchCursor) copyState() {
+	pc.id = pc.bc.ID()
+	pc.server = pc.bc.Server()
+	pc.err = pc.bc.Err()
+	if bc, ok := pc.bc.(awaitBatchCursor); ok {
+		pc.stats = bc.GetMoreStats()
+	}
+
+	pc.batch = new(bsoncore.DocumentSequence)
+	if batch := pc.bc.Batch(); batch != nil {
+		pc.batch.Style = batch.Style
+		pc.batch.Data = batch.Data
+	}
+}
@@ -0,0 +1,295 @@
+// Copyright (C) MongoDB, Inc. 2021-present.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
+
+package mongo
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/bsontype"
+	"go.mongodb.org/mongo-driver/x/bsonx/bsoncore"
+)
+
+// placeholderPrefix is the prefix of the strings that stand in for placeholders while a template is parsed. It starts
+// with a NUL byte so it cannot collide with a string written in a template by accident.
+const placeholderPrefix = "\x00:"
+
+// queryParam is a placeholder in a parsed QueryTemplate.
+type queryParam string
+
+// QueryTemplate is a query document with named placeholders. A QueryTemplate is parsed once and can be bound to
+// different parameters any number of times. It is safe for concurrent use.
+type QueryTemplate struct {
+	doc    bson.D
+	params map[string]struct{}
+	err    error
+}
+
+// ParseQuery parses a query template. The template is written in the syntax accepted by bson.ParseShell, and a
+// placeholder is written as a colon followed by a name in place of a value:
+//
+//	{'status': :status, 'age': {'$gt': :minAge}, 'tags': {'$in': [:tag, 'default']}}
+//
+// A placeholder name consists of letters, digits, and underscores and does not start with a digit. Placeholders can
+// be used as the values of a document or the elements of an array, but not as keys or as the arguments of a shell
+// helper such as ObjectId.
+func ParseQuery(template string) (*QueryTemplate, error) {
+	src, names, err := replacePlaceholders(template)
+	if err != nil {
+		return nil, err
+	}
+	doc, err := bson.ParseShell(src)
+	if err != nil {
+		return nil, err
+	}
+
+	q := &QueryTemplate{params: make(map[string]struct{}, len(names))}
+	for _, name := range names {
+		q.params[name] = struct{}{}
+	}
+	val, err := q.parse(doc)
+	if err != nil {
+		return nil, err
+	}
+	q.doc = val.(bson.D)
+	return q, nil
+}
+
+// Query is like ParseQuery but returns an error from Bind if the template cannot be parsed. This allows a query to be
+// declared and bound in a single expression:
+//
+//	filter, err := mongo.Query("{'status': :status}").Bind(bson.M{"status": "active"})
+func Query(template string) *QueryTemplate {
+	q, err := ParseQuery(template)
+	if err != nil {
+		return &QueryTemplate{err: err}
+	}
+	return q
+}
+
+// Err returns the error that occurred while parsing the template, if any.
+func (q *QueryTemplate) Err() error {
+	return q.err
+}
+
+// Params returns the names of the placeholders in the template in sorted order.
+func (q *QueryTemplate) Params() []string {
+	names := make([]string, 0, len(q.params))
+	for name := range q.params {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Bind returns the query document with each placeholder replaced by the parameter with the same name. Every
+// placeholder must be given a parameter, and every parameter must be used by a placeholder.
+//
+// To prevent operator injection, a parameter is always bound as a single value and cannot contain a document with a
+// key that starts with "$". For example, binding {"$ne": null} to a placeholder for a string returns an error rather
+// than a query that matches every document.
+func (q *QueryTemplate) Bind(params map[string]interface{}) (bson.D, error) {
+	if q.err != nil {
+		return nil, q.err
+	}
+	for name := range params {
+		if _, ok := q.params[name]; !ok {
+			return nil, fmt.Errorf("parameter %q is not used by the query", name)
+		}
+	}
+
+	values := make(map[string]bson.RawValue, len(params))
+	for _, name := range q.Params() {
+		param, ok := params[name]
+		if !ok {
+			return nil, fmt.Errorf("missing parameter %q", name)
+		}
+		if param == nil {
+			values[name] = bson.RawValue{Type: bsontype.Null}
+			continue
+		}
+		t, data, err := bson.MarshalValue(param)
+		if err != nil {
+			return nil, fmt.Errorf("error marshalling parameter %q: %v", name, err)
+		}
+		if err = checkOperators(t, data); err != nil {
+			return nil, fmt.Errorf("invalid parameter %q: %v", name, err)
+		}
+		values[name] = bson.RawValue{Type: t, Value: data}
+	}
+	return bind(q.doc, values).(bson.D), nil
+}
+
+// parse replaces the placeholder strings in a document returned by bson.ParseShell with queryParam values.
+func (q *QueryTemplate) parse(val interface{}) (interface{}, error) {
+	switch v := val.(type) {
+	case bson.D:
+		doc := make(bson.D, 0, len(v))
+		for _, e := range v {
+			if name, ok := q.placeholder(e.Key); ok {
+				return nil, fmt.Errorf("placeholder %q cannot be used as a key", ":"+name)
+			}
+			elemVal, err := q.parse(e.Value)
+			if err != nil {
+				return nil, err
+			}
+			doc = append(doc, bson.E{Key: e.Key, Value: elemVal})
+		}
+		return doc, nil
+	case bson.A:
+		arr := make(bson.A, 0, len(v))
+		for _, elem := range v {
+			elemVal, err := q.parse(elem)
+			if err != nil {
+				return nil, err
+			}
+			arr = append(arr, elemVal)
+		}
+		return arr, nil
+	case string:
+		if name, ok := q.placeholder(v); ok {
+			return queryParam(name), nil
+		}
+	}
+	return val, nil
+}
+
+func (q *QueryTemplate) placeholder(s string) (string, bool) {
+	if !strings.HasPrefix(s, placeholderPrefix) {
+		return "", false
+	}
+	name := s[len(placeholderPrefix):]
+	_, ok := q.params[name]
+	return name, ok
+}
+
+// bind returns a copy of val with each queryParam replaced by its value.
+func bind(val interface{}, values map[string]bson.RawValue) interface{} {
+	switch v := val.(type) {
+	case bson.D:
+		doc := make(bson.D, 0, len(v))
+		for _, e := range v {
+			doc = append(doc, bson.E{Key: e.Key, Value: bind(e.Value, values)})
+		}
+		return doc
+	case bson.A:
+		arr := make(bson.A, 0, len(v))
+		for _, elem := range v {
+			arr = append(arr, bind(elem, values))
+		}
+		return arr
+	case queryParam:
+		return values[string(v)]
+	}
+	return val
+}
+
+// checkOperators returns an error if a marshalled value contains a document with a key that starts with "$".
+func checkOperators(t bsontype.Type, data []byte) error {
+	if t != bsontype.EmbeddedDocument && t != bsontype.Array {
+		return nil
+	}
+	elems, err := bsoncore.Document(data).Elements()
+	if err != nil {
+		return err
+	}
+	for _, elem := range elems {
+		if t == bsontype.EmbeddedDocument && strings.HasPrefix(elem.Key(), "$") {
+			return fmt.Errorf("operator %q is not allowed in a parameter", elem.Key())
+		}
+		val := elem.Value()
+		if err = checkOperators(val.Type, val.Data); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// replacePlaceholders replaces each placeholder in a template with a string literal that bson.ParseShell can parse and
+// returns the names of the placeholders. A colon starts a placeholder if it is followed by a name and appears where a
+// value or key is expected, i.e. after '{', '[', ',' or the colon that separates a key from its value. Colons in
+// strings, comments, and regular expression literals are ignored.
+func replacePlaceholders(template string) (string, []string, error) {
+	var sb strings.Builder
+	var names []string
+	var prev byte
+	for i := 0; i < len(template); {
+		c := template[i]
+		switch {
+		case c == '"' || c == '\'' || c == '/':
+			comment := strings.HasPrefix(template[i:], "//") || strings.HasPrefix(template[i:], "/*")
+			end, err := skipLiteral(template, i)
+			if err != nil {
+				return "", nil, err
+			}
+			sb.WriteString(template[i:end])
+			i = end
+			if !comment {
+				prev = c
+			}
+			continue
+		case c == ' ' || c == '\t' || c == '\n' || c == '\r':
+			sb.WriteByte(c)
+			i++
+			continue
+		case c == ':' && (prev == '{' || prev == '[' || prev == ',' || prev == ':'):
+			end := i + 1
+			for end < len(template) && isParamChar(template[end], end == i+1) {
+				end++
+			}
+			if end == i+1 {
+				return "", nil, fmt.Errorf("expected placeholder name at offset %d", i+1)
+			}
+			name := template[i+1 : end]
+			names = append(names, name)
+			sb.WriteString(`"` + placeholderPrefix + name + `"`)
+			i = end
+			prev = '"'
+			continue
+		}
+		sb.WriteByte(c)
+		prev = c
+		i++
+	}
+	return sb.String(), names, nil
+}
+
+// skipLiteral returns the offset after the string, comment, or regular expression literal that starts at offset i of
+// s. The literal is returned unchanged for bson.ParseShell to validate.
+func skipLiteral(s string, i int) (int, error) {
+	switch {
+	case strings.HasPrefix(s[i:], "//"):
+		end := strings.IndexByte(s[i:], '\n')
+		if end < 0 {
+			return len(s), nil
+		}
+		return i + end + 1, nil
+	case strings.HasPrefix(s[i:], "/*"):
+		end := strings.Index(s[i+2:], "*/")
+		if end < 0 {
+			return len(s), nil
+		}
+		return i + end + 4, nil
+	}
+
+	quote := s[i]
+	for j := i + 1; j < len(s); j++ {
+		switch s[j] {
+		case '\\':
+			j++
+		case quote:
+			return j + 1, nil
+		}
+	}
+	return 0, fmt.Errorf("unterminated literal at offset %d", i)
+}
+
+func isParamChar(c byte, first bool) bool {
+	return c == '_' || (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z') || (!first && c >= '0' && c <= '9')
+}
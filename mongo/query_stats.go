@@ -0,0 +1,232 @@
+// Copyright (C) MongoDB, Inc. 2021-present.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
+
+package mongo
+
+import (
+	"context"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// sensitiveBinarySubtype is the binary subtype of values that the server must not log, such as the HMAC key of a
+// $queryStats stage.
+const sensitiveBinarySubtype byte = 0x08
+
+// QueryStatsEntry is the statistics of a query shape returned by Client.QueryStats.
+type QueryStatsEntry struct {
+	// The query shape, which is the query with its literal values removed, along with the options and client metadata
+	// that distinguish it from the other entries.
+	Key bson.Raw `bson:"key"`
+
+	// The hash of Key, which identifies the entry.
+	KeyHash string `bson:"keyHash"`
+
+	// The hash of the query shape only, which is shared by the entries of the same query sent with different options
+	// or by different clients. This is only set by MongoDB 8.0 or later.
+	QueryShapeHash string `bson:"queryShapeHash"`
+
+	// The statistics of the executions of the query shape.
+	Metrics QueryStatsMetrics `bson:"metrics"`
+
+	// The time at which the server read the statistics of the entry.
+	AsOf primitive.Timestamp `bson:"asOf"`
+
+	// The entry as it was returned by the server, which includes statistics that do not have a field.
+	Raw bson.Raw `bson:"-"`
+}
+
+// QueryStatsMetrics is the statistics of the executions of a query shape.
+type QueryStatsMetrics struct {
+	// The number of times the query shape was executed.
+	ExecCount int64 `bson:"execCount"`
+
+	// The execution time of the last execution, in microseconds.
+	LastExecutionMicros int64 `bson:"lastExecutionMicros"`
+
+	// The total execution time of the executions, including the time spent in getMore commands, in microseconds.
+	TotalExecMicros QueryStatsAggregate `bson:"totalExecMicros"`
+
+	// The time spent until the first batch of results was returned, in microseconds.
+	FirstResponseExecMicros QueryStatsAggregate `bson:"firstResponseExecMicros"`
+
+	// The number of documents returned by the executions.
+	DocsReturned QueryStatsAggregate `bson:"docsReturned"`
+
+	// The number of index keys examined by the executions. This is only set by MongoDB 8.0 or later.
+	KeysExamined QueryStatsAggregate `bson:"keysExamined"`
+
+	// The number of documents examined by the executions. This is only set by MongoDB 8.0 or later.
+	DocsExamined QueryStatsAggregate `bson:"docsExamined"`
+
+	// The time of the first execution since the entry was added.
+	FirstSeenTimestamp time.Time `bson:"firstSeenTimestamp"`
+
+	// The time of the last execution.
+	LatestSeenTimestamp time.Time `bson:"latestSeenTimestamp"`
+}
+
+// QueryStatsAggregate is a metric aggregated over the executions of a query shape. The mean is Sum divided by the
+// ExecCount of the metrics, and the variance can be derived from SumOfSquares.
+type QueryStatsAggregate struct {
+	Sum int64 `bson:"sum"`
+	Max int64 `bson:"max"`
+	Min int64 `bson:"min"`
+
+	// The sum of the squares of the values. The server reports it as a Decimal128 because it can overflow an int64.
+	SumOfSquares primitive.Decimal128 `bson:"sumOfSquares"`
+}
+
+// QueryStats retrieves the statistics that the server collects for each query shape with the $queryStats aggregation
+// stage, which requires MongoDB 7.1 or later, or MongoDB Atlas on older versions. The statistics are an alternative to
+// the profiler and slow query logs that cover all of the queries at a low cost, which makes them suitable for being
+// collected periodically by monitoring agents.
+//
+// The stage is run against the admin database and requires the queryStatsRead privilege, or the
+// queryStatsReadTransformed privilege if the identifiers are transformed. The opts parameter can be used to specify a
+// key to transform the identifiers of the query shapes (see the options.QueryStatsOptions documentation).
+//
+// For more information about the stage, see https://docs.mongodb.com/manual/reference/operator/aggregation/queryStats/.
+func (c *Client) QueryStats(ctx context.Context, opts ...*options.QueryStatsOptions) ([]QueryStatsEntry, error) {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	qo := options.MergeQueryStatsOptions(opts...)
+	stage := bson.D{}
+	if qo.HMACKey != nil {
+		stage = append(stage, bson.E{"transformIdentifiers", bson.D{
+			{"algorithm", "hmac-sha-256"},
+			{"hmacKey", primitive.Binary{Subtype: sensitiveBinarySubtype, Data: qo.HMACKey}},
+		}})
+	}
+	aggOpts := options.Aggregate()
+	if qo.BatchSize != nil {
+		aggOpts.SetBatchSize(*qo.BatchSize)
+	}
+
+	cursor, err := c.Database("admin").Aggregate(ctx, Pipeline{{{"$queryStats", stage}}}, aggOpts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var entries []QueryStatsEntry
+	for cursor.Next(ctx) {
+		var entry QueryStatsEntry
+		if err = cursor.Decode(&entry); err != nil {
+			return nil, err
+		}
+		entry.Raw = append(bson.Raw(nil), cursor.Current...)
+		entries = append(entries, entry)
+	}
+	return entries, cursor.Err()
+}
+
+// OperationMetricsEntry is the resources consumed by the operations on a database, returned by
+// Client.OperationMetrics.
+type OperationMetricsEntry struct {
+	// The name of the database.
+	DB string `bson:"db"`
+
+	// The time on the server when the metrics were read.
+	LocalTime time.Time `bson:"localTime"`
+
+	// The resources consumed by the reads while the server was a primary.
+	PrimaryMetrics OperationReadMetrics `bson:"primaryMetrics"`
+
+	// The resources consumed by the reads while the server was a secondary.
+	SecondaryMetrics OperationReadMetrics `bson:"secondaryMetrics"`
+
+	// The CPU time spent by the operations, in nanoseconds. This is only reported on Linux.
+	CPUNanos int64 `bson:"cpuNanos"`
+
+	// The number of bytes of documents written.
+	DocBytesWritten int64 `bson:"docBytesWritten"`
+
+	// The number of document units written, where a unit is 128 bytes of a document.
+	DocUnitsWritten int64 `bson:"docUnitsWritten"`
+
+	// The number of bytes of index entries written.
+	IdxEntryBytesWritten int64 `bson:"idxEntryBytesWritten"`
+
+	// The number of index entry units written, where a unit is 16 bytes of an entry.
+	IdxEntryUnitsWritten int64 `bson:"idxEntryUnitsWritten"`
+
+	// The number of units written, combining the document and index entry units.
+	TotalUnitsWritten int64 `bson:"totalUnitsWritten"`
+
+	// The entry as it was returned by the server.
+	Raw bson.Raw `bson:"-"`
+}
+
+// OperationReadMetrics is the resources consumed by the reads on a database.
+type OperationReadMetrics struct {
+	// The number of bytes of documents read.
+	DocBytesRead int64 `bson:"docBytesRead"`
+
+	// The number of document units read, where a unit is 4 KB of a document.
+	DocUnitsRead int64 `bson:"docUnitsRead"`
+
+	// The number of bytes of index entries read.
+	IdxEntryBytesRead int64 `bson:"idxEntryBytesRead"`
+
+	// The number of index entry units read, where a unit is 256 bytes of an entry.
+	IdxEntryUnitsRead int64 `bson:"idxEntryUnitsRead"`
+
+	// The number of keys sorted.
+	KeysSorted int64 `bson:"keysSorted"`
+
+	// The number of times a sort spilled to disk.
+	SorterSpills int64 `bson:"sorterSpills"`
+
+	// The number of document units returned, where a unit is 128 bytes of a document.
+	DocUnitsReturned int64 `bson:"docUnitsReturned"`
+
+	// The number of cursor seeks in the storage engine.
+	CursorSeeks int64 `bson:"cursorSeeks"`
+}
+
+// OperationMetrics retrieves the resources consumed by the operations on each database of the server with the
+// $operationMetrics aggregation stage. The server only collects the metrics if it runs with the
+// profileOperationResourceConsumptionMetrics parameter enabled. The metrics are accumulated since the server started
+// or since they were last cleared.
+//
+// The stage is run against the admin database. The opts parameter can be used to clear the metrics after reading them
+// (see the options.OperationMetricsOptions documentation).
+func (c *Client) OperationMetrics(ctx context.Context,
+	opts ...*options.OperationMetricsOptions) ([]OperationMetricsEntry, error) {
+
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	oo := options.MergeOperationMetricsOptions(opts...)
+	stage := bson.D{}
+	if oo.ClearMetrics != nil {
+		stage = append(stage, bson.E{"clearMetrics", *oo.ClearMetrics})
+	}
+
+	cursor, err := c.Database("admin").Aggregate(ctx, Pipeline{{{"$operationMetrics", stage}}})
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var entries []OperationMetricsEntry
+	for cursor.Next(ctx) {
+		var entry OperationMetricsEntry
+		if err = cursor.Decode(&entry); err != nil {
+			return nil, err
+		}
+		entry.Raw = append(bson.Raw(nil), cursor.Current...)
+		entries = append(entries, entry)
+	}
+	return entries, cursor.Err()
+}
@@ -0,0 +1,165 @@
+// Copyright (C) MongoDB, Inc. 2021-present.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
+
+package mongo_test
+
+import (
+	"bytes"
+	"context"
+	"testing"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/internal/testutil/assert"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/mongotest"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+func TestQueryStats(t *testing.T) {
+	newClient := func(t *testing.T) (*mongotest.Deployment, *mongo.Client) {
+		d := mongotest.New()
+		client, err := d.NewClient()
+		assert.Nil(t, err, "NewClient error: %v", err)
+		return d, client
+	}
+	stage := func(t *testing.T, cmd mongotest.Command, name string) bson.Raw {
+		assert.Equal(t, "aggregate", cmd.Name, "expected aggregate command, got %v", cmd.Name)
+		db := cmd.Document.Lookup("$db").StringValue()
+		assert.Equal(t, "admin", db, "expected admin database, got %v", db)
+		first, err := cmd.Document.Lookup("pipeline").Array().IndexErr(0)
+		assert.Nil(t, err, "expected a pipeline stage in %v", cmd.Document)
+		doc, ok := first.Value().Document().Lookup(name).DocumentOK()
+		assert.True(t, ok, "expected %v stage, got %v", name, first.Value())
+		return doc
+	}
+
+	t.Run("query stats", func(t *testing.T) {
+		d, client := newClient(t)
+		defer func() { _ = client.Disconnect(context.Background()) }()
+
+		seen := time.Date(2024, time.March, 1, 12, 0, 0, 0, time.UTC)
+		sumOfSquares, err := primitive.ParseDecimal128("282500")
+		assert.Nil(t, err, "ParseDecimal128 error: %v", err)
+		d.AddReplies(mongotest.CursorReply("admin.$cmd.aggregate", bson.D{
+			{"key", bson.D{{"queryShape", bson.D{{"cmdNs", bson.D{{"db", "shop"}, {"coll", "orders"}}}}}}},
+			{"keyHash", "LpD7Jq"},
+			{"queryShapeHash", "E5B4C2"},
+			{"metrics", bson.D{
+				{"lastExecutionMicros", int64(250)},
+				{"execCount", int64(3)},
+				{"totalExecMicros", bson.D{
+					{"sum", int64(900)}, {"max", int64(400)}, {"min", int64(250)}, {"sumOfSquares", sumOfSquares},
+				}},
+				{"docsReturned", bson.D{{"sum", int64(12)}, {"max", int64(5)}, {"min", int64(3)}}},
+				{"hasSortStage", false},
+				{"firstSeenTimestamp", seen},
+				{"latestSeenTimestamp", seen.Add(time.Minute)},
+			}},
+			{"asOf", primitive.Timestamp{T: 1709294460, I: 1}},
+		}))
+
+		key := bytes.Repeat([]byte{0x2a}, 32)
+		entries, err := client.QueryStats(context.Background(), options.QueryStats().SetHMACKey(key))
+		assert.Nil(t, err, "QueryStats error: %v", err)
+		assert.Equal(t, 1, len(entries), "expected 1 entry, got %v", len(entries))
+
+		entry := entries[0]
+		assert.Equal(t, "LpD7Jq", entry.KeyHash, "expected key hash LpD7Jq, got %v", entry.KeyHash)
+		assert.Equal(t, "E5B4C2", entry.QueryShapeHash, "expected query shape hash E5B4C2, got %v",
+			entry.QueryShapeHash)
+		coll := entry.Key.Lookup("queryShape", "cmdNs", "coll").StringValue()
+		assert.Equal(t, "orders", coll, "expected collection orders in key, got %v", coll)
+		assert.Equal(t, int64(3), entry.Metrics.ExecCount, "expected 3 executions, got %v", entry.Metrics.ExecCount)
+		exec := entry.Metrics.TotalExecMicros
+		assert.Equal(t, int64(900), exec.Sum, "expected total exec micros sum 900, got %v", exec.Sum)
+		assert.Equal(t, int64(400), exec.Max, "expected total exec micros max 400, got %v", exec.Max)
+		assert.Equal(t, int64(250), exec.Min, "expected total exec micros min 250, got %v", exec.Min)
+		assert.Equal(t, "282500", exec.SumOfSquares.String(), "expected sum of squares 282500, got %v",
+			exec.SumOfSquares)
+		assert.Equal(t, int64(12), entry.Metrics.DocsReturned.Sum, "expected 12 documents returned, got %v",
+			entry.Metrics.DocsReturned.Sum)
+		assert.True(t, seen.Equal(entry.Metrics.FirstSeenTimestamp), "expected first seen %v, got %v", seen,
+			entry.Metrics.FirstSeenTimestamp)
+		assert.Equal(t, primitive.Timestamp{T: 1709294460, I: 1}, entry.AsOf, "expected asOf timestamp, got %v",
+			entry.AsOf)
+		_, err = entry.Raw.LookupErr("metrics", "hasSortStage")
+		assert.Nil(t, err, "expected raw entry to keep unmapped metrics, got %v", entry.Raw)
+
+		cmds := d.Commands()
+		assert.Equal(t, 1, len(cmds), "expected 1 command, got %v", len(cmds))
+		transform := stage(t, cmds[0], "$queryStats").Lookup("transformIdentifiers").Document()
+		algorithm := transform.Lookup("algorithm").StringValue()
+		assert.Equal(t, "hmac-sha-256", algorithm, "expected algorithm hmac-sha-256, got %v", algorithm)
+		subtype, data := transform.Lookup("hmacKey").Binary()
+		assert.Equal(t, byte(0x08), subtype, "expected sensitive binary subtype, got %v", subtype)
+		assert.Equal(t, key, data, "expected HMAC key %v, got %v", key, data)
+	})
+	t.Run("query stats without transformation", func(t *testing.T) {
+		d, client := newClient(t)
+		defer func() { _ = client.Disconnect(context.Background()) }()
+		d.AddReplies(mongotest.CursorReply("admin.$cmd.aggregate"))
+
+		entries, err := client.QueryStats(context.Background())
+		assert.Nil(t, err, "QueryStats error: %v", err)
+		assert.Equal(t, 0, len(entries), "expected no entries, got %v", len(entries))
+
+		cmds := d.Commands()
+		assert.Equal(t, 1, len(cmds), "expected 1 command, got %v", len(cmds))
+		spec := stage(t, cmds[0], "$queryStats")
+		elems, err := spec.Elements()
+		assert.Nil(t, err, "Elements error: %v", err)
+		assert.Equal(t, 0, len(elems), "expected empty $queryStats stage, got %v", spec)
+	})
+	t.Run("query stats error", func(t *testing.T) {
+		d, client := newClient(t)
+		defer func() { _ = client.Disconnect(context.Background()) }()
+		d.AddReplies(mongotest.ErrorReply(40324, "Unrecognized pipeline stage name: '$queryStats'"))
+
+		_, err := client.QueryStats(context.Background())
+		cmdErr, ok := err.(mongo.CommandError)
+		assert.True(t, ok, "expected CommandError, got %v", err)
+		assert.Equal(t, int32(40324), cmdErr.Code, "expected code 40324, got %v", cmdErr.Code)
+	})
+	t.Run("operation metrics", func(t *testing.T) {
+		d, client := newClient(t)
+		defer func() { _ = client.Disconnect(context.Background()) }()
+		d.AddReplies(mongotest.CursorReply("admin.$cmd.aggregate", bson.D{
+			{"db", "shop"},
+			{"localTime", time.Date(2024, time.March, 1, 12, 0, 0, 0, time.UTC)},
+			{"primaryMetrics", bson.D{
+				{"docBytesRead", int64(4096)},
+				{"docUnitsRead", int64(1)},
+				{"keysSorted", int64(10)},
+				{"cursorSeeks", int64(2)},
+			}},
+			{"secondaryMetrics", bson.D{{"docBytesRead", int64(512)}}},
+			{"cpuNanos", int64(120000)},
+			{"docBytesWritten", int64(256)},
+			{"totalUnitsWritten", int64(3)},
+		}))
+
+		entries, err := client.OperationMetrics(context.Background(), options.OperationMetrics().SetClearMetrics(true))
+		assert.Nil(t, err, "OperationMetrics error: %v", err)
+		assert.Equal(t, 1, len(entries), "expected 1 entry, got %v", len(entries))
+
+		entry := entries[0]
+		assert.Equal(t, "shop", entry.DB, "expected database shop, got %v", entry.DB)
+		expectedPrimary := mongo.OperationReadMetrics{DocBytesRead: 4096, DocUnitsRead: 1, KeysSorted: 10, CursorSeeks: 2}
+		assert.Equal(t, expectedPrimary, entry.PrimaryMetrics, "expected primary metrics %v, got %v", expectedPrimary,
+			entry.PrimaryMetrics)
+		assert.Equal(t, int64(512), entry.SecondaryMetrics.DocBytesRead, "expected 512 bytes read on secondary, got %v",
+			entry.SecondaryMetrics.DocBytesRead)
+		assert.Equal(t, int64(120000), entry.CPUNanos, "expected 120000 CPU nanos, got %v", entry.CPUNanos)
+		assert.Equal(t, int64(3), entry.TotalUnitsWritten, "expected 3 units written, got %v", entry.TotalUnitsWritten)
+
+		cmds := d.Commands()
+		assert.Equal(t, 1, len(cmds), "expected 1 command, got %v", len(cmds))
+		clear := stage(t, cmds[0], "$operationMetrics").Lookup("clearMetrics").Boolean()
+		assert.True(t, clear, "expected clearMetrics to be true")
+	})
+}
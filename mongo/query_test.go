@@ -0,0 +1,117 @@
+// Copyright (C) MongoDB, Inc. 2021-present.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
+
+package mongo
+
+import (
+	"testing"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/internal/testutil/assert"
+)
+
+func TestQueryTemplate(t *testing.T) {
+	marshal := func(t *testing.T, doc interface{}) bson.Raw {
+		t.Helper()
+
+		b, err := bson.Marshal(doc)
+		assert.Nil(t, err, "Marshal error: %v", err)
+		return b
+	}
+
+	t.Run("bind", func(t *testing.T) {
+		testCases := []struct {
+			name     string
+			template string
+			params   bson.M
+			expected bson.D
+		}{
+			{
+				"values",
+				"{'status': :status, 'age': {'$gt': :minAge}}",
+				bson.M{"status": "active", "minAge": 21},
+				bson.D{{"status", "active"}, {"age", bson.D{{"$gt", int32(21)}}}},
+			},
+			{
+				"arrays and repeated placeholders",
+				"{tags: {$in: [:tag, 'default']}, $or: [{a: :tag}, {b::tag}]}",
+				bson.M{"tag": "x"},
+				bson.D{
+					{"tags", bson.D{{"$in", bson.A{"x", "default"}}}},
+					{"$or", bson.A{bson.D{{"a", "x"}}, bson.D{{"b", "x"}}}},
+				},
+			},
+			{
+				"colons in literals",
+				"{a: 'x: :y', // :z\n b: /:w/, c: :v}",
+				bson.M{"v": nil},
+				bson.D{{"a", "x: :y"}, {"b", primitive.Regex{Pattern: ":w"}}, {"c", nil}},
+			},
+			{
+				"document and array parameters",
+				"{a: :doc, b: {$in: :list}}",
+				bson.M{"doc": bson.D{{"x", bson.A{1}}}, "list": []string{"p", "q"}},
+				bson.D{{"a", bson.D{{"x", bson.A{int32(1)}}}}, {"b", bson.D{{"$in", bson.A{"p", "q"}}}}},
+			},
+		}
+		for _, tc := range testCases {
+			t.Run(tc.name, func(t *testing.T) {
+				doc, err := Query(tc.template).Bind(tc.params)
+				assert.Nil(t, err, "Bind error: %v", err)
+				expected := marshal(t, tc.expected)
+				got := marshal(t, doc)
+				assert.Equal(t, expected, got, "expected %v, got %v", expected, got)
+			})
+		}
+	})
+	t.Run("reuse", func(t *testing.T) {
+		q, err := ParseQuery("{a: :a}")
+		assert.Nil(t, err, "ParseQuery error: %v", err)
+		assert.Equal(t, []string{"a"}, q.Params(), "expected params [a], got %v", q.Params())
+
+		for _, val := range []string{"x", "y"} {
+			doc, err := q.Bind(bson.M{"a": val})
+			assert.Nil(t, err, "Bind error: %v", err)
+			assert.Equal(t, val, doc[0].Value.(bson.RawValue).StringValue(), "expected %v, got %v", val, doc[0].Value)
+		}
+	})
+	t.Run("parse errors", func(t *testing.T) {
+		templates := []string{
+			"{a: :}",
+			"{a: :1x}",
+			"{:a: 1}",
+			"{a: 1, :b: 2}",
+			"{a: ObjectId(:id)}",
+			"{a: 'unterminated}",
+			"{a: :a",
+		}
+		for _, template := range templates {
+			q := Query(template)
+			assert.NotNil(t, q.Err(), "expected error for %q, got nil", template)
+			_, err := q.Bind(nil)
+			assert.Equal(t, q.Err(), err, "expected error %v, got %v", q.Err(), err)
+		}
+	})
+	t.Run("bind errors", func(t *testing.T) {
+		testCases := []struct {
+			name   string
+			params bson.M
+		}{
+			{"missing parameter", bson.M{}},
+			{"unused parameter", bson.M{"status": "x", "other": 1}},
+			{"operator", bson.M{"status": bson.M{"$ne": nil}}},
+			{"nested operator", bson.M{"status": bson.A{bson.D{{"a", bson.D{{"$where", "true"}}}}}}},
+			{"unmarshallable", bson.M{"status": make(chan int)}},
+		}
+		for _, tc := range testCases {
+			t.Run(tc.name, func(t *testing.T) {
+				_, err := Query("{status: :status}").Bind(tc.params)
+				assert.NotNil(t, err, "expected Bind error, got nil")
+			})
+		}
+	})
+}
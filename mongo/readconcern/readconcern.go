@@ -7,6 +7,9 @@
 package readconcern // import "go.mongodb.org/mongo-driver/mongo/readconcern"
 
 import (
+	"fmt"
+	"net/url"
+
 	"go.mongodb.org/mongo-driver/bson/bsontype"
 	"go.mongodb.org/mongo-driver/x/bsonx/bsoncore"
 )
@@ -76,6 +79,50 @@ func (rc *ReadConcern) MarshalBSONValue() (bsontype.Type, []byte, error) {
 	return bsontype.EmbeddedDocument, bsoncore.BuildDocument(nil, elems), nil
 }
 
+// UnmarshalBSONValue implements the bson.ValueUnmarshaler interface. The value must be a document with an optional
+// "level" field. A BSON null is decoded to an empty ReadConcern.
+func (rc *ReadConcern) UnmarshalBSONValue(t bsontype.Type, data []byte) error {
+	if t == bsontype.Null {
+		*rc = ReadConcern{}
+		return nil
+	}
+	doc, ok := bsoncore.Value{Type: t, Data: data}.DocumentOK()
+	if !ok {
+		return fmt.Errorf("cannot decode BSON %s into a ReadConcern", t)
+	}
+	elems, err := doc.Elements()
+	if err != nil {
+		return err
+	}
+
+	var decoded ReadConcern
+	for _, elem := range elems {
+		switch key := elem.Key(); key {
+		case "level":
+			level, ok := elem.Value().StringValueOK()
+			if !ok {
+				return fmt.Errorf("read concern `level` field must be a string, got BSON %s", elem.Value().Type)
+			}
+			decoded.level = level
+		default:
+			return fmt.Errorf("unrecognized read concern field %q", key)
+		}
+	}
+
+	*rc = decoded
+	return nil
+}
+
+// URIOptions returns the read concern formatted as connection string options, e.g. "readConcernLevel=majority". The
+// result can be appended to the query of a connection string and parsed with options.ClientOptions.ApplyURI. It is
+// empty if the level is not set.
+func (rc *ReadConcern) URIOptions() string {
+	if rc == nil || rc.level == "" {
+		return ""
+	}
+	return "readConcernLevel=" + url.QueryEscape(rc.level)
+}
+
 // GetLevel returns the read concern level.
 func (rc *ReadConcern) GetLevel() string {
 	return rc.level
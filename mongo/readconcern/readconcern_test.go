@@ -0,0 +1,52 @@
+// Copyright (C) MongoDB, Inc. 2021-present.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
+
+package readconcern_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/bsontype"
+	"go.mongodb.org/mongo-driver/mongo/readconcern"
+	"go.mongodb.org/mongo-driver/x/mongo/driver/connstring"
+)
+
+func TestReadConcernBSON(t *testing.T) {
+	t.Run("round trip", func(t *testing.T) {
+		for _, rc := range []*readconcern.ReadConcern{readconcern.New(), readconcern.Majority()} {
+			doc, err := bson.Marshal(bson.M{"readConcern": rc})
+			require.NoError(t, err)
+
+			var decoded struct {
+				ReadConcern *readconcern.ReadConcern `bson:"readConcern"`
+			}
+			require.NoError(t, bson.Unmarshal(doc, &decoded))
+			require.Equal(t, rc, decoded.ReadConcern)
+		}
+	})
+	t.Run("errors for invalid documents", func(t *testing.T) {
+		for _, tc := range []bson.D{{{"level", 1}}, {{"unknown", "local"}}} {
+			doc, err := bson.Marshal(tc)
+			require.NoError(t, err)
+
+			rc := new(readconcern.ReadConcern)
+			require.Error(t, rc.UnmarshalBSONValue(bsontype.EmbeddedDocument, doc), "expected error for %v", tc)
+		}
+	})
+}
+
+func TestReadConcernURIOptions(t *testing.T) {
+	require.Equal(t, "", readconcern.New().URIOptions())
+
+	uriOpts := readconcern.Snapshot().URIOptions()
+	require.Equal(t, "readConcernLevel=snapshot", uriOpts)
+
+	cs, err := connstring.ParseAndValidate("mongodb://localhost/?" + uriOpts)
+	require.NoError(t, err)
+	require.Equal(t, "snapshot", cs.ReadConcernLevel)
+}
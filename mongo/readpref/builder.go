@@ -0,0 +1,95 @@
+// Copyright (C) MongoDB, Inc. 2021-present.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
+
+package readpref
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/tag"
+)
+
+// Builder is used to compose a read preference one server selection option at a time. Unlike the Option functions,
+// which replace each other when they configure the same setting, tag sets added to a Builder are accumulated so
+// configuration from several sources can be combined. A Builder is not safe for concurrent use.
+type Builder struct {
+	mode         Mode
+	maxStaleness *time.Duration
+	tagSets      []tag.Set
+	hedgeEnabled *bool
+}
+
+// NewBuilder creates a new Builder for a read preference with the given mode.
+func NewBuilder(mode Mode) *Builder {
+	return &Builder{mode: mode}
+}
+
+// NewBuilderFrom creates a new Builder initialized with the mode and options of rp, which can be used to derive a
+// read preference from an existing one.
+func NewBuilderFrom(rp *ReadPref) *Builder {
+	b := NewBuilder(rp.mode)
+	if rp.maxStalenessSet {
+		b.MaxStaleness(rp.maxStaleness)
+	}
+	b.tagSets = append(b.tagSets, rp.tagSets...)
+	if rp.hedgeEnabled != nil {
+		b.HedgeEnabled(*rp.hedgeEnabled)
+	}
+	return b
+}
+
+// Mode sets the mode of the read preference.
+func (b *Builder) Mode(mode Mode) *Builder {
+	b.mode = mode
+	return b
+}
+
+// MaxStaleness sets the maximum staleness a server is allowed.
+func (b *Builder) MaxStaleness(ms time.Duration) *Builder {
+	b.maxStaleness = &ms
+	return b
+}
+
+// AddTagSet adds a tag set used to match a server. Tag sets are tried in the order they were added.
+func (b *Builder) AddTagSet(tagSet tag.Set) *Builder {
+	b.tagSets = append(b.tagSets, tagSet)
+	return b
+}
+
+// TagSets replaces all tag sets added to the Builder with tagSets.
+func (b *Builder) TagSets(tagSets ...tag.Set) *Builder {
+	b.tagSets = append([]tag.Set(nil), tagSets...)
+	return b
+}
+
+// HedgeEnabled specifies whether or not hedged reads should be enabled in the server. See WithHedgeEnabled for more
+// information.
+func (b *Builder) HedgeEnabled(hedgeEnabled bool) *Builder {
+	b.hedgeEnabled = &hedgeEnabled
+	return b
+}
+
+// Build creates a read preference from the Builder. An error is returned if options were set for a read preference
+// with mode primary or if the mode is not valid.
+func (b *Builder) Build() (*ReadPref, error) {
+	if !b.mode.IsValid() {
+		return nil, errInvalidMode
+	}
+
+	var opts []Option
+	if b.maxStaleness != nil {
+		opts = append(opts, WithMaxStaleness(*b.maxStaleness))
+	}
+	if len(b.tagSets) > 0 {
+		// Copy the tag sets so tag sets added to the Builder later do not modify the returned read preference.
+		opts = append(opts, WithTagSets(append([]tag.Set(nil), b.tagSets...)...))
+	}
+	if b.hedgeEnabled != nil {
+		opts = append(opts, WithHedgeEnabled(*b.hedgeEnabled))
+	}
+
+	return New(b.mode, opts...)
+}
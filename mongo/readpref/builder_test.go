@@ -0,0 +1,65 @@
+// Copyright (C) MongoDB, Inc. 2021-present.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
+
+package readpref
+
+import (
+	"testing"
+	"time"
+
+	"go.mongodb.org/mongo-driver/internal/testutil/assert"
+	"go.mongodb.org/mongo-driver/tag"
+)
+
+func TestBuilder(t *testing.T) {
+	t.Run("all options", func(t *testing.T) {
+		rp, err := NewBuilder(SecondaryMode).
+			MaxStaleness(120 * time.Second).
+			AddTagSet(tag.Set{{"dc", "ny"}}).
+			AddTagSet(tag.Set{}).
+			HedgeEnabled(true).
+			Build()
+		assert.Nil(t, err, "Build error: %v", err)
+
+		expected := "secondary(maxStaleness=2m0s tagSet=dc=ny tagSet= hedgeEnabled=true)"
+		assert.Equal(t, expected, rp.String(), "expected %q, got %q", expected, rp.String())
+	})
+	t.Run("TagSets replaces added tag sets", func(t *testing.T) {
+		rp, err := NewBuilder(NearestMode).
+			AddTagSet(tag.Set{{"dc", "ny"}}).
+			TagSets(tag.Set{{"dc", "sf"}}).
+			Build()
+		assert.Nil(t, err, "Build error: %v", err)
+
+		expected := []tag.Set{{{"dc", "sf"}}}
+		assert.Equal(t, expected, rp.TagSets(), "expected tag sets %v, got %v", expected, rp.TagSets())
+	})
+	t.Run("result is not modified by the builder", func(t *testing.T) {
+		b := NewBuilder(NearestMode).AddTagSet(tag.Set{{"dc", "ny"}})
+		rp, err := b.Build()
+		assert.Nil(t, err, "Build error: %v", err)
+		b.AddTagSet(tag.Set{{"dc", "sf"}})
+
+		assert.Equal(t, 1, len(rp.TagSets()), "expected 1 tag set, got %v", rp.TagSets())
+	})
+	t.Run("NewBuilderFrom", func(t *testing.T) {
+		orig := Nearest(WithMaxStaleness(90*time.Second), WithTags("dc", "ny"), WithHedgeEnabled(false))
+		rp, err := NewBuilderFrom(orig).Mode(SecondaryPreferredMode).AddTagSet(tag.Set{}).Build()
+		assert.Nil(t, err, "Build error: %v", err)
+
+		expected := "secondaryPreferred(maxStaleness=1m30s tagSet=dc=ny tagSet= hedgeEnabled=false)"
+		assert.Equal(t, expected, rp.String(), "expected %q, got %q", expected, rp.String())
+		assert.Equal(t, 1, len(orig.TagSets()), "expected original to have 1 tag set, got %v", orig.TagSets())
+	})
+	t.Run("primary with options", func(t *testing.T) {
+		_, err := NewBuilder(PrimaryMode).HedgeEnabled(true).Build()
+		assert.Equal(t, errInvalidReadPreference, err, "expected error %v, got %v", errInvalidReadPreference, err)
+	})
+	t.Run("invalid mode", func(t *testing.T) {
+		_, err := NewBuilder(Mode(0)).Build()
+		assert.Equal(t, errInvalidMode, err, "expected error %v, got %v", errInvalidMode, err)
+	})
+}
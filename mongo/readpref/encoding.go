@@ -0,0 +1,278 @@
+// Copyright (C) MongoDB, Inc. 2021-present.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
+
+package readpref
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"go.mongodb.org/mongo-driver/tag"
+)
+
+// URIOptions returns the read preference as connection string options, e.g.
+// "readPreference=secondary&maxStalenessSeconds=120&readPreferenceTags=dc:ny". Tag sets are written as
+// readPreferenceTags options in order and hedged reads are written as the hedgeEnabled option, which is only understood
+// by ParseURIOptions. The maximum staleness is truncated to whole seconds. The result can be appended to the query of a
+// connection string or parsed with ParseURIOptions. Tag names and values are escaped, so tags that contain "," or ":"
+// round-trip through ParseURIOptions, but connection strings split the tags after unescaping them and cannot contain
+// such tags.
+func (r *ReadPref) URIOptions() string {
+	var b bytes.Buffer
+	b.WriteString("readPreference=")
+	b.WriteString(r.mode.String())
+	if r.maxStalenessSet {
+		fmt.Fprintf(&b, "&maxStalenessSeconds=%d", int64(r.maxStaleness/time.Second))
+	}
+	for _, tagSet := range r.tagSets {
+		b.WriteString("&readPreferenceTags=")
+		for i, t := range tagSet {
+			if i > 0 {
+				b.WriteString(",")
+			}
+			b.WriteString(url.QueryEscape(t.Name))
+			b.WriteString(":")
+			b.WriteString(url.QueryEscape(t.Value))
+		}
+	}
+	if r.hedgeEnabled != nil {
+		fmt.Fprintf(&b, "&hedgeEnabled=%v", *r.hedgeEnabled)
+	}
+	return b.String()
+}
+
+// ParseURIOptions creates a read preference from connection string options in the format returned by URIOptions. The
+// options can start with "?" and option names are case insensitive. Options that do not configure a read preference
+// are ignored, so the query of a full connection string can be parsed. An error is returned if s does not contain a
+// readPreference option.
+func ParseURIOptions(s string) (*ReadPref, error) {
+	values, err := parseRawQuery(strings.TrimPrefix(s, "?"))
+	if err != nil {
+		return nil, err
+	}
+
+	var b *Builder
+	var tagSets []string
+	var maxStaleness, hedgeEnabled string
+	for key, vals := range values {
+		lowerKey := strings.ToLower(key)
+		if lowerKey == "readpreferencetags" {
+			// Tag sets are unescaped by parseURITagSet after splitting them, so escaped separators are kept.
+			tagSets = append(tagSets, vals...)
+			continue
+		}
+		val, err := url.QueryUnescape(vals[len(vals)-1])
+		if err != nil {
+			return nil, fmt.Errorf("invalid value for %s: %v", key, err)
+		}
+		switch lowerKey {
+		case "readpreference":
+			mode, err := ModeFromString(val)
+			if err != nil {
+				return nil, err
+			}
+			b = NewBuilder(mode)
+		case "maxstalenessseconds":
+			maxStaleness = val
+		case "hedgeenabled":
+			hedgeEnabled = val
+		}
+	}
+	if b == nil {
+		return nil, errors.New("readPreference option is required")
+	}
+
+	// A value of -1 is the same as not specifying a maximum staleness.
+	if maxStaleness != "" && maxStaleness != "-1" {
+		n, err := strconv.Atoi(maxStaleness)
+		if err != nil || n < 0 {
+			return nil, fmt.Errorf("invalid value for maxStalenessSeconds: %s", maxStaleness)
+		}
+		b.MaxStaleness(time.Duration(n) * time.Second)
+	}
+	for _, val := range tagSets {
+		tagSet, err := parseURITagSet(val)
+		if err != nil {
+			return nil, err
+		}
+		b.AddTagSet(tagSet)
+	}
+	if hedgeEnabled != "" {
+		enabled, err := strconv.ParseBool(hedgeEnabled)
+		if err != nil {
+			return nil, fmt.Errorf("invalid value for hedgeEnabled: %s", hedgeEnabled)
+		}
+		b.HedgeEnabled(enabled)
+	}
+
+	return b.Build()
+}
+
+// parseRawQuery parses the options of a query like url.ParseQuery, but only unescapes the option names so that values
+// can be split before they are unescaped.
+func parseRawQuery(query string) (map[string][]string, error) {
+	values := make(map[string][]string)
+	for _, pair := range strings.Split(query, "&") {
+		if pair == "" {
+			continue
+		}
+		kv := strings.SplitN(pair, "=", 2)
+		key, err := url.QueryUnescape(kv[0])
+		if err != nil {
+			return nil, err
+		}
+		var val string
+		if len(kv) == 2 {
+			val = kv[1]
+		}
+		values[key] = append(values[key], val)
+	}
+	return values, nil
+}
+
+// parseURITagSet parses the still escaped value of a readPreferenceTags option. The tag names and values are unescaped
+// after the value is split, so they can contain escaped "," and ":" characters. An empty value is an empty tag set,
+// which matches any server.
+func parseURITagSet(val string) (tag.Set, error) {
+	tagSet := tag.Set{}
+	if val == "" {
+		return tagSet, nil
+	}
+
+	for _, item := range strings.Split(val, ",") {
+		parts := strings.Split(item, ":")
+		if len(parts) != 2 {
+			return nil, fmt.Errorf("invalid value for readPreferenceTags: %s", val)
+		}
+		name, err := url.QueryUnescape(parts[0])
+		if err != nil {
+			return nil, fmt.Errorf("invalid value for readPreferenceTags: %s", val)
+		}
+		value, err := url.QueryUnescape(parts[1])
+		if err != nil {
+			return nil, fmt.Errorf("invalid value for readPreferenceTags: %s", val)
+		}
+		tagSet = append(tagSet, tag.Tag{Name: name, Value: value})
+	}
+	return tagSet, nil
+}
+
+// readPrefJSON is the JSON representation of a ReadPref. It has the same shape as the $readPreference document sent
+// to the server.
+type readPrefJSON struct {
+	Mode                string         `json:"mode"`
+	MaxStalenessSeconds *int64         `json:"maxStalenessSeconds,omitempty"`
+	Tags                []jsonTagSet   `json:"tags,omitempty"`
+	Hedge               *readPrefHedge `json:"hedge,omitempty"`
+}
+
+type readPrefHedge struct {
+	Enabled bool `json:"enabled"`
+}
+
+// jsonTagSet is a tag.Set that is represented as a JSON object whose keys are in the order of the tags.
+type jsonTagSet tag.Set
+
+// MarshalJSON implements the json.Marshaler interface.
+func (ts jsonTagSet) MarshalJSON() ([]byte, error) {
+	var b bytes.Buffer
+	b.WriteString("{")
+	for i, t := range ts {
+		if i > 0 {
+			b.WriteString(",")
+		}
+		name, err := json.Marshal(t.Name)
+		if err != nil {
+			return nil, err
+		}
+		value, err := json.Marshal(t.Value)
+		if err != nil {
+			return nil, err
+		}
+		b.Write(name)
+		b.WriteString(":")
+		b.Write(value)
+	}
+	b.WriteString("}")
+	return b.Bytes(), nil
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface.
+func (ts *jsonTagSet) UnmarshalJSON(data []byte) error {
+	dec := json.NewDecoder(bytes.NewReader(data))
+	if tok, err := dec.Token(); err != nil || tok != json.Delim('{') {
+		return fmt.Errorf("tag set must be a JSON object: %s", data)
+	}
+
+	*ts = jsonTagSet{}
+	for dec.More() {
+		tok, err := dec.Token()
+		if err != nil {
+			return err
+		}
+		var value string
+		if err = dec.Decode(&value); err != nil {
+			return fmt.Errorf("invalid value for tag %q: %v", tok, err)
+		}
+		*ts = append(*ts, tag.Tag{Name: tok.(string), Value: value})
+	}
+	return nil
+}
+
+// MarshalJSON implements the json.Marshaler interface. The read preference is represented as a JSON object with the
+// same fields as the $readPreference document sent to the server, e.g.
+// {"mode":"secondary","maxStalenessSeconds":120,"tags":[{"dc":"ny"}],"hedge":{"enabled":true}}. The maximum staleness
+// is truncated to whole seconds.
+func (r *ReadPref) MarshalJSON() ([]byte, error) {
+	rpj := readPrefJSON{Mode: r.mode.String()}
+	if r.maxStalenessSet {
+		seconds := int64(r.maxStaleness / time.Second)
+		rpj.MaxStalenessSeconds = &seconds
+	}
+	for _, tagSet := range r.tagSets {
+		rpj.Tags = append(rpj.Tags, jsonTagSet(tagSet))
+	}
+	if r.hedgeEnabled != nil {
+		rpj.Hedge = &readPrefHedge{Enabled: *r.hedgeEnabled}
+	}
+	return json.Marshal(rpj)
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface. It accepts the format returned by MarshalJSON.
+func (r *ReadPref) UnmarshalJSON(data []byte) error {
+	var rpj readPrefJSON
+	if err := json.Unmarshal(data, &rpj); err != nil {
+		return err
+	}
+
+	mode, err := ModeFromString(rpj.Mode)
+	if err != nil {
+		return err
+	}
+	b := NewBuilder(mode)
+	if rpj.MaxStalenessSeconds != nil && *rpj.MaxStalenessSeconds != -1 {
+		b.MaxStaleness(time.Duration(*rpj.MaxStalenessSeconds) * time.Second)
+	}
+	for _, tagSet := range rpj.Tags {
+		b.AddTagSet(tag.Set(tagSet))
+	}
+	if rpj.Hedge != nil {
+		b.HedgeEnabled(rpj.Hedge.Enabled)
+	}
+
+	rp, err := b.Build()
+	if err != nil {
+		return err
+	}
+	*r = *rp
+	return nil
+}
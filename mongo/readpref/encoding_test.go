@@ -0,0 +1,122 @@
+// Copyright (C) MongoDB, Inc. 2021-present.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
+
+package readpref
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"go.mongodb.org/mongo-driver/internal/testutil/assert"
+	"go.mongodb.org/mongo-driver/tag"
+)
+
+func TestURIOptions(t *testing.T) {
+	testCases := []struct {
+		name     string
+		rp       *ReadPref
+		expected string
+	}{
+		{"primary", Primary(), "readPreference=primary"},
+		{
+			"all options",
+			Nearest(
+				WithMaxStaleness(120*time.Second),
+				WithTagSets(tag.Set{{"dc", "ny"}, {"rack", "1"}}, tag.Set{}),
+				WithHedgeEnabled(true),
+			),
+			"readPreference=nearest&maxStalenessSeconds=120&readPreferenceTags=dc:ny,rack:1&readPreferenceTags=&hedgeEnabled=true",
+		},
+		{
+			"escaped tags",
+			Secondary(WithTagSets(tag.Set{{"a,b:c", "x y"}, {"dc", "n:y"}})),
+			"readPreference=secondary&readPreferenceTags=a%2Cb%3Ac:x+y,dc:n%3Ay",
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			got := tc.rp.URIOptions()
+			assert.Equal(t, tc.expected, got, "expected %q, got %q", tc.expected, got)
+
+			rp, err := ParseURIOptions(got)
+			assert.Nil(t, err, "ParseURIOptions error: %v", err)
+			assert.Equal(t, tc.rp.String(), rp.String(), "expected %v, got %v", tc.rp, rp)
+			assert.Equal(t, tc.rp.TagSets(), rp.TagSets(), "expected tag sets %v, got %v", tc.rp.TagSets(), rp.TagSets())
+		})
+	}
+}
+
+func TestParseURIOptions(t *testing.T) {
+	t.Run("connection string query", func(t *testing.T) {
+		rp, err := ParseURIOptions("?readpreference=secondary&MaxStalenessSeconds=-1&w=majority&readPreferenceTags=dc:ny")
+		assert.Nil(t, err, "ParseURIOptions error: %v", err)
+
+		expected := "secondary(tagSet=dc=ny)"
+		assert.Equal(t, expected, rp.String(), "expected %q, got %q", expected, rp.String())
+	})
+
+	errorCases := []struct {
+		name    string
+		options string
+	}{
+		{"missing mode", "maxStalenessSeconds=120"},
+		{"invalid mode", "readPreference=foo"},
+		{"invalid max staleness", "readPreference=nearest&maxStalenessSeconds=foo"},
+		{"invalid tags", "readPreference=nearest&readPreferenceTags=dc"},
+		{"invalid hedge", "readPreference=nearest&hedgeEnabled=foo"},
+		{"options with primary", "readPreference=primary&hedgeEnabled=true"},
+	}
+	for _, tc := range errorCases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := ParseURIOptions(tc.options)
+			assert.NotNil(t, err, "expected error, got nil")
+		})
+	}
+}
+
+func TestReadPref_JSON(t *testing.T) {
+	testCases := []struct {
+		name     string
+		rp       *ReadPref
+		expected string
+	}{
+		{"primary", Primary(), `{"mode":"primary"}`},
+		{
+			"all options",
+			Secondary(
+				WithMaxStaleness(120*time.Second),
+				WithTagSets(tag.Set{{"rack", "1"}, {"dc", "ny"}}, tag.Set{}),
+				WithHedgeEnabled(false),
+			),
+			`{"mode":"secondary","maxStalenessSeconds":120,"tags":[{"rack":"1","dc":"ny"},{}],"hedge":{"enabled":false}}`,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			b, err := json.Marshal(tc.rp)
+			assert.Nil(t, err, "Marshal error: %v", err)
+			assert.Equal(t, tc.expected, string(b), "expected %s, got %s", tc.expected, b)
+
+			var rp ReadPref
+			err = json.Unmarshal(b, &rp)
+			assert.Nil(t, err, "Unmarshal error: %v", err)
+			assert.Equal(t, tc.rp.String(), rp.String(), "expected %v, got %v", tc.rp, &rp)
+		})
+	}
+	t.Run("invalid", func(t *testing.T) {
+		for _, data := range []string{
+			`{"mode":"foo"}`,
+			`{"mode":"nearest","tags":[["dc","ny"]]}`,
+			`{"mode":"nearest","tags":[{"dc":1}]}`,
+			`{"mode":"primary","maxStalenessSeconds":120}`,
+		} {
+			var rp ReadPref
+			err := json.Unmarshal([]byte(data), &rp)
+			assert.NotNil(t, err, "expected error for %s, got nil", data)
+		}
+	})
+}
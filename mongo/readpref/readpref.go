@@ -17,6 +17,7 @@ import (
 
 var (
 	errInvalidReadPreference = errors.New("can not specify tags, max staleness, or hedge with mode primary")
+	errInvalidMode           = errors.New("invalid read preference mode")
 )
 
 var primary = ReadPref{mode: PrimaryMode}
@@ -0,0 +1,179 @@
+// Copyright (C) MongoDB, Inc. 2021-present.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
+
+// Package replay provides a Recorder that captures the commands sent by a Client and a Replay function that
+// re-executes a recording against another deployment. This can be used to validate a migration by replaying production
+// traffic against the new cluster or to generate realistic load for a load test.
+//
+// A recording is a stream of BSON documents, one per Record, in the order in which the commands finished. Commands
+// that cannot be replayed against another deployment, such as handshakes, authentication, getMore, and transaction
+// commands, are not recorded.
+//
+// Recordings contain the full command documents, including inserted documents and query filters. Sensitive fields
+// should be removed with the redact function of NewRecorder or with the CommandMonitorRedactor client option.
+package replay // import "go.mongodb.org/mongo-driver/mongo/replay"
+
+import (
+	"context"
+	"io"
+	"sync"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/event"
+)
+
+// unreplayableCommands are the commands that are not recorded because they are tied to the connection, session, or
+// cursor state of the recorded deployment.
+var unreplayableCommands = map[string]struct{}{
+	"isMaster":          {},
+	"ismaster":          {},
+	"hello":             {},
+	"authenticate":      {},
+	"saslStart":         {},
+	"saslContinue":      {},
+	"getnonce":          {},
+	"getMore":           {},
+	"killCursors":       {},
+	"endSessions":       {},
+	"commitTransaction": {},
+	"abortTransaction":  {},
+}
+
+// Record is a command captured by a Recorder.
+type Record struct {
+	// The database the command was run against.
+	Database string `bson:"db"`
+
+	// The name of the command, e.g. "find".
+	CommandName string `bson:"commandName"`
+
+	// The command document as sent to the server, after redaction.
+	Command bson.Raw `bson:"command"`
+
+	// The time between the start of the recording and the start of the command.
+	Offset time.Duration `bson:"offset"`
+
+	// The time it took for the command to finish.
+	Duration time.Duration `bson:"duration"`
+
+	// Whether the command failed. Commands that returned write errors are not considered failed.
+	Failed bool `bson:"failed"`
+}
+
+// ReadRecord reads the next Record of a recording from r. It returns io.EOF if there are no more records.
+func ReadRecord(r io.Reader) (Record, error) {
+	doc, err := bson.NewFromIOReader(r)
+	if err != nil {
+		return Record{}, err
+	}
+
+	var rec Record
+	err = bson.Unmarshal(doc, &rec)
+	return rec, err
+}
+
+type requestKey struct {
+	connectionID string
+	requestID    int64
+}
+
+// Recorder writes the commands sent by a Client to a recording. A Recorder is attached to a Client by passing the
+// CommandMonitor returned by Monitor to the SetMonitor client option. It is safe for concurrent use by multiple
+// goroutines.
+type Recorder struct {
+	redact func(bson.Raw) bson.Raw
+	start  time.Time
+
+	mu      sync.Mutex
+	w       io.Writer
+	pending map[requestKey]Record
+	err     error
+}
+
+// NewRecorder creates a Recorder that writes a recording to w. The recording starts when NewRecorder is called. If
+// redact is not nil, it is called with a copy of each recorded command and the returned document is recorded instead,
+// so it can be used to remove sensitive fields from the recording.
+func NewRecorder(w io.Writer, redact func(cmd bson.Raw) bson.Raw) *Recorder {
+	return &Recorder{
+		redact:  redact,
+		start:   time.Now(),
+		w:       w,
+		pending: make(map[requestKey]Record),
+	}
+}
+
+// Monitor returns a CommandMonitor that records the commands it receives events for.
+func (r *Recorder) Monitor() *event.CommandMonitor {
+	return &event.CommandMonitor{
+		Started:   r.started,
+		Succeeded: r.succeeded,
+		Failed:    r.failed,
+	}
+}
+
+// Err returns the first error that occurred while writing the recording, or nil if no error occurred. Commands that
+// finish after an error are not recorded.
+func (r *Recorder) Err() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return r.err
+}
+
+func (r *Recorder) started(_ context.Context, evt *event.CommandStartedEvent) {
+	if _, ok := unreplayableCommands[evt.CommandName]; ok || len(evt.Command) == 0 {
+		return
+	}
+
+	cmd := make(bson.Raw, len(evt.Command))
+	copy(cmd, evt.Command)
+	if r.redact != nil {
+		cmd = r.redact(cmd)
+	}
+	rec := Record{
+		Database:    evt.DatabaseName,
+		CommandName: evt.CommandName,
+		Command:     cmd,
+		Offset:      time.Since(r.start),
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.pending[requestKey{evt.ConnectionID, evt.RequestID}] = rec
+}
+
+func (r *Recorder) succeeded(_ context.Context, evt *event.CommandSucceededEvent) {
+	r.finish(evt.CommandFinishedEvent, false)
+}
+
+func (r *Recorder) failed(_ context.Context, evt *event.CommandFailedEvent) {
+	r.finish(evt.CommandFinishedEvent, true)
+}
+
+func (r *Recorder) finish(evt event.CommandFinishedEvent, failed bool) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	key := requestKey{evt.ConnectionID, evt.RequestID}
+	rec, ok := r.pending[key]
+	if !ok {
+		return
+	}
+	delete(r.pending, key)
+	if r.err != nil {
+		return
+	}
+
+	rec.Duration = time.Duration(evt.DurationNanos)
+	rec.Failed = failed
+	doc, err := bson.Marshal(rec)
+	if err == nil {
+		_, err = r.w.Write(doc)
+	}
+	r.err = err
+}
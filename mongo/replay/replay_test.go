@@ -0,0 +1,168 @@
+// Copyright (C) MongoDB, Inc. 2021-present.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
+
+package replay
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"testing"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/event"
+	"go.mongodb.org/mongo-driver/internal/testutil/assert"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+func marshal(t *testing.T, doc bson.D) bson.Raw {
+	t.Helper()
+
+	b, err := bson.Marshal(doc)
+	assert.Nil(t, err, "Marshal error: %v", err)
+	return b
+}
+
+type errWriter struct{}
+
+func (errWriter) Write([]byte) (int, error) { return 0, errors.New("write error") }
+
+func TestReplay(t *testing.T) {
+	t.Run("recorder", func(t *testing.T) {
+		var buf bytes.Buffer
+		redact := func(cmd bson.Raw) bson.Raw {
+			coll, ok := cmd.Lookup("find").StringValueOK()
+			if !ok {
+				return cmd
+			}
+			return marshal(t, bson.D{{"find", coll}, {"filter", bson.D{}}})
+		}
+		monitor := NewRecorder(&buf, redact).Monitor()
+
+		find := marshal(t, bson.D{{"find", "users"}, {"filter", bson.D{{"ssn", "123-45-6789"}}}})
+		monitor.Started(context.Background(), &event.CommandStartedEvent{
+			Command: find, DatabaseName: "db", CommandName: "find", RequestID: 1, ConnectionID: "conn1",
+		})
+		monitor.Started(context.Background(), &event.CommandStartedEvent{
+			Command: marshal(t, bson.D{{"getMore", int64(1)}}), DatabaseName: "db", CommandName: "getMore",
+			RequestID: 2, ConnectionID: "conn1",
+		})
+		monitor.Started(context.Background(), &event.CommandStartedEvent{
+			Command: bson.Raw{}, DatabaseName: "admin", CommandName: "createUser", RequestID: 3, ConnectionID: "conn1",
+		})
+		monitor.Started(context.Background(), &event.CommandStartedEvent{
+			Command: marshal(t, bson.D{{"drop", "users"}}), DatabaseName: "db", CommandName: "drop", RequestID: 1,
+			ConnectionID: "conn2",
+		})
+
+		monitor.Failed(context.Background(), &event.CommandFailedEvent{
+			CommandFinishedEvent: event.CommandFinishedEvent{CommandName: "drop", RequestID: 1, ConnectionID: "conn2"},
+		})
+		monitor.Succeeded(context.Background(), &event.CommandSucceededEvent{
+			CommandFinishedEvent: event.CommandFinishedEvent{
+				DurationNanos: int64(time.Millisecond), CommandName: "find", RequestID: 1, ConnectionID: "conn1",
+			},
+		})
+		monitor.Succeeded(context.Background(), &event.CommandSucceededEvent{
+			CommandFinishedEvent: event.CommandFinishedEvent{CommandName: "getMore", RequestID: 2, ConnectionID: "conn1"},
+		})
+
+		drop, err := ReadRecord(&buf)
+		assert.Nil(t, err, "ReadRecord error: %v", err)
+		assert.Equal(t, "drop", drop.CommandName, "expected command drop, got %v", drop.CommandName)
+		assert.True(t, drop.Failed, "expected drop record to be failed")
+
+		rec, err := ReadRecord(&buf)
+		assert.Nil(t, err, "ReadRecord error: %v", err)
+		assert.Equal(t, "db", rec.Database, "expected database db, got %v", rec.Database)
+		assert.Equal(t, "find", rec.CommandName, "expected command find, got %v", rec.CommandName)
+		expected := marshal(t, bson.D{{"find", "users"}, {"filter", bson.D{}}})
+		assert.Equal(t, expected, rec.Command, "expected command %v, got %v", expected, rec.Command)
+		assert.Equal(t, time.Millisecond, rec.Duration, "expected duration %v, got %v", time.Millisecond, rec.Duration)
+		assert.False(t, rec.Failed, "expected find record to not be failed")
+		assert.True(t, rec.Offset <= drop.Offset, "expected find offset to be at most %v, got %v", drop.Offset,
+			rec.Offset)
+		assert.Equal(t, "123-45-6789", find.Lookup("filter", "ssn").StringValue(),
+			"expected the command in the event to not be modified")
+
+		_, err = ReadRecord(&buf)
+		assert.Equal(t, io.EOF, err, "expected error %v, got %v", io.EOF, err)
+	})
+	t.Run("recorder write error", func(t *testing.T) {
+		rec := NewRecorder(errWriter{}, nil)
+		monitor := rec.Monitor()
+		monitor.Started(context.Background(), &event.CommandStartedEvent{
+			Command: marshal(t, bson.D{{"ping", 1}}), DatabaseName: "admin", CommandName: "ping", RequestID: 1,
+		})
+		monitor.Succeeded(context.Background(), &event.CommandSucceededEvent{
+			CommandFinishedEvent: event.CommandFinishedEvent{CommandName: "ping", RequestID: 1},
+		})
+		assert.NotNil(t, rec.Err(), "expected recorder error, got nil")
+	})
+	t.Run("replay command", func(t *testing.T) {
+		cmd := marshal(t, bson.D{
+			{"find", "users"},
+			{"filter", bson.D{{"x", 1}}},
+			{"readConcern", bson.D{{"level", "majority"}, {"afterClusterTime", primitive.Timestamp{T: 1, I: 1}}}},
+			{"lsid", bson.D{{"id", primitive.Binary{Subtype: 4, Data: make([]byte, 16)}}}},
+			{"txnNumber", int64(1)},
+			{"$clusterTime", bson.D{{"clusterTime", primitive.Timestamp{T: 1, I: 1}}}},
+			{"$db", "db"},
+			{"$readPreference", bson.D{{"mode", "secondary"}}},
+		})
+		got, err := replayCommand(cmd)
+		assert.Nil(t, err, "replayCommand error: %v", err)
+		expected := marshal(t, bson.D{
+			{"find", "users"},
+			{"filter", bson.D{{"x", 1}}},
+			{"readConcern", bson.D{{"level", "majority"}}},
+		})
+		assert.Equal(t, expected, got, "expected command %v, got %v", expected, got)
+
+		cmd = marshal(t, bson.D{{"find", "users"}, {"readConcern", bson.D{{"afterClusterTime", primitive.Timestamp{}}}}})
+		got, err = replayCommand(cmd)
+		assert.Nil(t, err, "replayCommand error: %v", err)
+		expected = marshal(t, bson.D{{"find", "users"}})
+		assert.Equal(t, expected, got, "expected command %v, got %v", expected, got)
+	})
+	t.Run("report", func(t *testing.T) {
+		report := &Report{}
+		report.add(Record{CommandName: "find"}, nil)
+		report.add(Record{CommandName: "insert", Failed: true}, errors.New("duplicate key"))
+		report.add(Record{CommandName: "drop", Failed: true}, nil)
+		report.add(Record{CommandName: "update"}, errors.New("timeout"))
+
+		assert.Equal(t, 4, report.Commands, "expected 4 commands, got %v", report.Commands)
+		assert.Equal(t, 2, report.Failed, "expected 2 failed commands, got %v", report.Failed)
+		assert.Equal(t, 2, report.Mismatched, "expected 2 mismatched commands, got %v", report.Mismatched)
+		assert.Equal(t, 2, len(report.Errors), "expected 2 errors, got %v", len(report.Errors))
+	})
+	t.Run("replay", func(t *testing.T) {
+		client, err := mongo.NewClient(options.Client())
+		assert.Nil(t, err, "NewClient error: %v", err)
+
+		report, err := Replay(context.Background(), client, &bytes.Buffer{}, 1)
+		assert.Nil(t, err, "Replay error: %v", err)
+		assert.Equal(t, 0, report.Commands, "expected 0 commands, got %v", report.Commands)
+
+		var buf bytes.Buffer
+		doc, err := bson.Marshal(Record{Database: "db", CommandName: "ping", Command: marshal(t, bson.D{{"ping", 1}})})
+		assert.Nil(t, err, "Marshal error: %v", err)
+		buf.Write(doc)
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		report, err = Replay(ctx, client, &buf, 0)
+		assert.Equal(t, context.Canceled, err, "expected error %v, got %v", context.Canceled, err)
+		assert.Equal(t, 0, report.Commands, "expected 0 commands, got %v", report.Commands)
+
+		_, err = Replay(context.Background(), client, bytes.NewReader([]byte{0x01}), 0)
+		assert.NotNil(t, err, "expected error for a truncated recording, got nil")
+	})
+}
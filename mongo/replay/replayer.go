@@ -0,0 +1,199 @@
+// Copyright (C) MongoDB, Inc. 2021-present.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
+
+package replay
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"sync"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/x/bsonx/bsoncore"
+)
+
+// maxReportErrors is the maximum number of errors collected in a Report.
+const maxReportErrors = 100
+
+// removedCommandFields are the top-level command fields that are added by the driver for the recorded deployment and
+// are removed before a command is replayed. The client used for the replay adds its own values where needed.
+var removedCommandFields = map[string]struct{}{
+	"$db":                  {},
+	"$clusterTime":         {},
+	"$readPreference":      {},
+	"lsid":                 {},
+	"txnNumber":            {},
+	"autocommit":           {},
+	"startTransaction":     {},
+	"apiVersion":           {},
+	"apiStrict":            {},
+	"apiDeprecationErrors": {},
+}
+
+// Report summarizes the result of a Replay.
+type Report struct {
+	// The number of commands that were replayed.
+	Commands int
+
+	// The number of commands that failed during the replay.
+	Failed int
+
+	// The number of commands whose outcome differed from the recording, i.e. commands that failed during the replay
+	// but succeeded when they were recorded or vice versa.
+	Mismatched int
+
+	// The time it took to replay the recording.
+	Elapsed time.Duration
+
+	// The errors of the commands that failed, up to a maximum of 100 errors.
+	Errors []error
+}
+
+func (r *Report) add(rec Record, err error) {
+	r.Commands++
+	if err != nil {
+		r.Failed++
+		if len(r.Errors) < maxReportErrors {
+			r.Errors = append(r.Errors, fmt.Errorf("replaying %s command on database %q: %v", rec.CommandName,
+				rec.Database, err))
+		}
+	}
+	if (err != nil) != rec.Failed {
+		r.Mismatched++
+	}
+}
+
+// Replay reads the recording from r and executes its commands using client. The speed parameter controls the pace of
+// the replay: with a speed of 1, each command is started at the same offset from the start of the replay as it was
+// from the start of the recording, and commands run concurrently as they did when they were recorded. A speed of 2
+// replays the recording twice as fast. With a speed of 0 or less, the commands are run one after another as fast as
+// possible.
+//
+// Session, transaction, cluster time, read preference, and API version fields are removed from the commands before
+// they are replayed, as is the afterClusterTime read concern option. The commands are run on the primary.
+//
+// Replay returns once all commands finished. If ctx is cancelled, no further commands are started and the context
+// error is returned along with the report of the commands that were replayed. An error is also returned if the
+// recording cannot be read. A failing command does not stop the replay; failures are counted in the report.
+func Replay(ctx context.Context, client *mongo.Client, r io.Reader, speed float64) (*Report, error) {
+	report := &Report{}
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+
+	run := func(rec Record) {
+		err := runRecord(ctx, client, rec)
+
+		mu.Lock()
+		defer mu.Unlock()
+		report.add(rec, err)
+	}
+
+	start := time.Now()
+	var err error
+	for {
+		var rec Record
+		rec, err = ReadRecord(r)
+		if err != nil {
+			if err == io.EOF {
+				err = nil
+			}
+			break
+		}
+
+		if speed <= 0 {
+			if err = ctx.Err(); err != nil {
+				break
+			}
+			run(rec)
+			continue
+		}
+
+		wait := time.Until(start.Add(time.Duration(float64(rec.Offset) / speed)))
+		if err = sleep(ctx, wait); err != nil {
+			break
+		}
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			run(rec)
+		}()
+	}
+	wg.Wait()
+
+	report.Elapsed = time.Since(start)
+	return report, err
+}
+
+func runRecord(ctx context.Context, client *mongo.Client, rec Record) error {
+	cmd, err := replayCommand(rec.Command)
+	if err != nil {
+		return err
+	}
+	return client.Database(rec.Database).RunCommand(ctx, cmd).Err()
+}
+
+// replayCommand returns a copy of cmd without the fields in removedCommandFields and the afterClusterTime read concern
+// option.
+func replayCommand(cmd bson.Raw) (bson.Raw, error) {
+	elems, err := bsoncore.Document(cmd).Elements()
+	if err != nil {
+		return nil, err
+	}
+
+	idx, doc := bsoncore.AppendDocumentStart(nil)
+	for _, elem := range elems {
+		key := elem.Key()
+		if _, ok := removedCommandFields[key]; ok {
+			continue
+		}
+		if key != "readConcern" {
+			doc = append(doc, elem...)
+			continue
+		}
+
+		rc, ok := elem.Value().DocumentOK()
+		if !ok {
+			doc = append(doc, elem...)
+			continue
+		}
+		rcElems, err := rc.Elements()
+		if err != nil {
+			return nil, err
+		}
+		rcIdx, rcDoc := bsoncore.AppendDocumentStart(nil)
+		for _, rcElem := range rcElems {
+			if rcElem.Key() != "afterClusterTime" {
+				rcDoc = append(rcDoc, rcElem...)
+			}
+		}
+		rcDoc, _ = bsoncore.AppendDocumentEnd(rcDoc, rcIdx)
+		if len(rcDoc) > 5 {
+			doc = bsoncore.AppendDocumentElement(doc, key, rcDoc)
+		}
+	}
+	doc, err = bsoncore.AppendDocumentEnd(doc, idx)
+	return bson.Raw(doc), err
+}
+
+// sleep waits for d or until ctx is done, whichever happens first. It returns the context error in the latter case.
+func sleep(ctx context.Context, d time.Duration) error {
+	if d <= 0 {
+		return ctx.Err()
+	}
+
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
@@ -0,0 +1,148 @@
+// Copyright (C) MongoDB, Inc. 2021-present.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
+
+package mongo
+
+import (
+	"context"
+	"time"
+
+	"go.mongodb.org/mongo-driver/x/bsonx/bsoncore"
+	"go.mongodb.org/mongo-driver/x/mongo/driver"
+)
+
+// PinnedCursorStats contains counts of the errors of cursors pinned to connections in load balanced mode and of the
+// restarts of those cursors. See ClientOptions.SetRestartPinnedCursors.
+type PinnedCursorStats struct {
+	// The number of getMore commands that failed because the connection the cursor was pinned to was closed or was
+	// routed to a server that does not have the cursor.
+	WrongConnectionErrors int64
+
+	// The number of cursors that were restarted after losing their pinned connection.
+	Restarts int64
+
+	// The number of attempts to restart a cursor that failed.
+	RestartFailures int64
+}
+
+// PinnedCursorStats returns the counts of the errors of the cursors of the client that lost their pinned connection in
+// load balanced mode and of their restarts. The counts are always zero for deployments that are not load balanced.
+func (c *Client) PinnedCursorStats() PinnedCursorStats {
+	stats := c.pinnedCursors.Stats()
+	return PinnedCursorStats{
+		WrongConnectionErrors: stats.WrongConnectionErrors,
+		Restarts:              stats.Restarts,
+		RestartFailures:       stats.RestartFailures,
+	}
+}
+
+// pinnedBatchCursor is implemented by batch cursors that report whether they lost their pinned connection.
+type pinnedBatchCursor interface {
+	LostPinnedConnection() bool
+}
+
+// restartFunc creates a new batch cursor for the query of a restarted cursor, skipping the given number of documents
+// that have already been returned.
+type restartFunc func(ctx context.Context, returned int64) (batchCursor, error)
+
+// restartBatchCursor is a batchCursor that restarts the wrapped batchCursor with a new query when it loses the
+// connection it is pinned to in load balanced mode.
+type restartBatchCursor struct {
+	bc       batchCursor
+	restart  restartFunc
+	counters *driver.PinnedCursorCounters
+
+	// The number of documents returned by all of the cursors, and by the previous cursors when bc was created.
+	returned          int64
+	returnedAtRestart int64
+	restarted         bool
+
+	// The getMore statistics of the previous cursors.
+	stats driver.GetMoreStats
+	err   error
+}
+
+var _ batchCursor = (*restartBatchCursor)(nil)
+var _ awaitBatchCursor = (*restartBatchCursor)(nil)
+
+func newRestartBatchCursor(bc batchCursor, restart restartFunc,
+	counters *driver.PinnedCursorCounters) *restartBatchCursor {
+
+	return &restartBatchCursor{bc: bc, restart: restart, counters: counters}
+}
+
+func (rc *restartBatchCursor) ID() int64 {
+	return rc.bc.ID()
+}
+
+func (rc *restartBatchCursor) Next(ctx context.Context) bool {
+	if rc.err != nil {
+		return false
+	}
+
+	for {
+		if rc.bc.Next(ctx) {
+			rc.returned += int64(rc.bc.Batch().DocumentCount())
+			return true
+		}
+
+		pc, ok := rc.bc.(pinnedBatchCursor)
+		if !ok || !pc.LostPinnedConnection() {
+			return false
+		}
+		// A cursor that loses its connection again before returning any document is not restarted, so a load
+		// balancer that keeps misrouting getMores cannot cause a restart loop.
+		if rc.restarted && rc.returned == rc.returnedAtRestart {
+			return false
+		}
+
+		if abc, ok := rc.bc.(awaitBatchCursor); ok {
+			rc.stats = rc.stats.Add(abc.GetMoreStats())
+		}
+		_ = rc.bc.Close(ctx)
+		bc, err := rc.restart(ctx, rc.returned)
+		rc.counters.AddRestart(err)
+		if err != nil {
+			rc.err = err
+			return false
+		}
+		rc.bc = bc
+		rc.restarted = true
+		rc.returnedAtRestart = rc.returned
+	}
+}
+
+func (rc *restartBatchCursor) Batch() *bsoncore.DocumentSequence {
+	return rc.bc.Batch()
+}
+
+func (rc *restartBatchCursor) Server() driver.Server {
+	return rc.bc.Server()
+}
+
+func (rc *restartBatchCursor) Err() error {
+	if rc.err != nil {
+		return rc.err
+	}
+	return rc.bc.Err()
+}
+
+func (rc *restartBatchCursor) Close(ctx context.Context) error {
+	return rc.bc.Close(ctx)
+}
+
+func (rc *restartBatchCursor) SetMaxTime(d time.Duration) {
+	if abc, ok := rc.bc.(awaitBatchCursor); ok {
+		abc.SetMaxTime(d)
+	}
+}
+
+func (rc *restartBatchCursor) GetMoreStats() driver.GetMoreStats {
+	if abc, ok := rc.bc.(awaitBatchCursor); ok {
+		return rc.stats.Add(abc.GetMoreStats())
+	}
+	return rc.stats
+}
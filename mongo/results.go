@@ -8,9 +8,11 @@ package mongo
 
 import (
 	"fmt"
+	"math"
 
 	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/x/bsonx/bsoncore"
 	"go.mongodb.org/mongo-driver/x/mongo/driver/operation"
 )
 
@@ -33,12 +35,19 @@ type BulkWriteResult struct {
 
 	// A map of operation index to the _id of each upserted document.
 	UpsertedIDs map[int64]interface{}
+
+	// The raw server replies to the write commands executed for the operation, in the order in which the commands were
+	// sent. This is empty for unacknowledged writes.
+	RawReplies []bson.Raw
 }
 
 // InsertOneResult is the result type returned by an InsertOne operation.
 type InsertOneResult struct {
 	// The _id of the inserted document. A value generated by the driver will be of type primitive.ObjectID.
 	InsertedID interface{}
+
+	// The raw server reply to the insert command. This is nil for unacknowledged writes.
+	Raw bson.Raw
 }
 
 // InsertManyResult is a result type returned by an InsertMany operation.
@@ -49,7 +58,36 @@ type InsertManyResult struct {
 
 // DeleteResult is the result type returned by DeleteOne and DeleteMany operations.
 type DeleteResult struct {
-	DeletedCount int64 `bson:"n"` // The number of documents deleted.
+	DeletedCount int64    `bson:"n"` // The number of documents deleted.
+	Raw          bson.Raw `bson:"-"` // The raw server reply to the delete command, or nil for unacknowledged writes.
+}
+
+// CountResult is the result type returned by a CountDocumentsWithResult operation.
+type CountResult struct {
+	// The number of documents.
+	Count int64
+
+	// Whether the count was read from the collection metadata rather than computed by scanning the documents. See the
+	// options.CountOptions.AllowFastCount documentation.
+	FastCount bool
+}
+
+// SyncResult is the result type returned by a SyncDocuments operation.
+type SyncResult struct {
+	// The number of documents inserted because no document with the same key was in the collection.
+	InsertedCount int64
+
+	// The number of documents replaced because the document with the same key in the collection was different.
+	ReplacedCount int64
+
+	// The number of documents deleted because their keys were not in the given documents.
+	DeletedCount int64
+
+	// The number of documents that were already up to date and were not written.
+	UnchangedCount int64
+
+	// The result of the bulk write that applied the changes. This is nil if no changes were needed.
+	BulkWrite *BulkWriteResult
 }
 
 // ListDatabasesResult is a result of a ListDatabases operation.
@@ -62,6 +100,15 @@ type ListDatabasesResult struct {
 	TotalSize int64
 }
 
+// lastReply returns the last of the server replies collected by a write operation as a bson.Raw, or nil if there are
+// none.
+func lastReply(replies []bsoncore.Document) bson.Raw {
+	if len(replies) == 0 {
+		return nil
+	}
+	return bson.Raw(replies[len(replies)-1])
+}
+
 func newListDatabasesResultFromOperation(res operation.ListDatabasesResult) ListDatabasesResult {
 	var ldr ListDatabasesResult
 	ldr.Databases = make([]DatabaseSpecification, 0, len(res.Databases))
@@ -88,6 +135,7 @@ type UpdateResult struct {
 	ModifiedCount int64       // The number of documents modified by the operation.
 	UpsertedCount int64       // The number of documents upserted by the operation.
 	UpsertedID    interface{} // The _id field of the upserted document, or nil if no upsert was done.
+	Raw           bson.Raw    // The raw server reply to the update command, or nil for unacknowledged writes.
 }
 
 // UnmarshalBSON implements the bson.Unmarshaler interface.
@@ -158,15 +206,45 @@ type IndexSpecification struct {
 
 	// The index version.
 	Version int32
+
+	// The length of time, in seconds, after which documents expire. This is nil if the index is not a TTL index or if
+	// its expireAfterSeconds option is not an integral value that fits in an int32, e.g. NaN, which older server
+	// versions accepted.
+	ExpireAfterSeconds *int32
+
+	// Whether the index is unique. This is nil if the server did not report the option.
+	Unique *bool
+
+	// Whether the index is sparse. This is nil if the server did not report the option.
+	Sparse *bool
+
+	// Whether the index is hidden from the query planner. This is nil if the server did not report the option.
+	Hidden *bool
+
+	// The filter of a partial index. This is nil if the index is not a partial index.
+	PartialFilterExpression bson.Raw
+
+	// The collation of the index. This is nil if the index uses the simple binary comparison.
+	Collation bson.Raw
+
+	// The projection of a wildcard index. This is nil if the index does not have a wildcard projection.
+	WildcardProjection bson.Raw
 }
 
 var _ bson.Unmarshaler = (*IndexSpecification)(nil)
 
 type unmarshalIndexSpecification struct {
-	Name         string   `bson:"name"`
-	Namespace    string   `bson:"ns"`
-	KeysDocument bson.Raw `bson:"key"`
-	Version      int32    `bson:"v"`
+	Name               string        `bson:"name"`
+	Namespace          string        `bson:"ns"`
+	KeysDocument       bson.Raw      `bson:"key"`
+	Version            int32         `bson:"v"`
+	ExpireAfterSeconds bson.RawValue `bson:"expireAfterSeconds"`
+	Unique             *bool         `bson:"unique"`
+	Sparse             *bool         `bson:"sparse"`
+	Hidden             *bool         `bson:"hidden"`
+	PartialFilter      bson.Raw      `bson:"partialFilterExpression"`
+	Collation          bson.Raw      `bson:"collation"`
+	WildcardProjection bson.Raw      `bson:"wildcardProjection"`
 }
 
 // UnmarshalBSON implements the bson.Unmarshaler interface.
@@ -180,16 +258,55 @@ func (i *IndexSpecification) UnmarshalBSON(data []byte) error {
 	i.Namespace = temp.Namespace
 	i.KeysDocument = temp.KeysDocument
 	i.Version = temp.Version
+	i.ExpireAfterSeconds = nil
+	expireAfterSeconds := bsoncore.Value{Type: temp.ExpireAfterSeconds.Type, Data: temp.ExpireAfterSeconds.Value}
+	if eas, ok := integralValue(expireAfterSeconds); ok && eas >= math.MinInt32 && eas <= math.MaxInt32 {
+		eas32 := int32(eas)
+		i.ExpireAfterSeconds = &eas32
+	}
+	i.Unique = temp.Unique
+	i.Sparse = temp.Sparse
+	i.Hidden = temp.Hidden
+	i.PartialFilterExpression = temp.PartialFilter
+	i.Collation = temp.Collation
+	i.WildcardProjection = temp.WildcardProjection
 	return nil
 }
 
+// IndexPlan describes the changes needed to make the indexes on a collection match a list of IndexModels. This type is
+// returned by the IndexView.DiffIndexes and IndexView.EnsureIndexes functions.
+type IndexPlan struct {
+	// The models for indexes that do not exist on the collection.
+	Create []IndexModel
+
+	// The names of indexes that exist on the collection but are not in the list of models. This is only populated if
+	// EnsureIndexesOptions.DropExtra is true and never contains the _id index.
+	Drop []string
+
+	// The models for indexes that exist on the collection with the same name and keys but different options, e.g. a
+	// different unique or partialFilterExpression option, which can only be changed by dropping and recreating the
+	// index. The models have their Name option set to the name of the existing index.
+	Replace []IndexModel
+
+	// The models for indexes that exist on the collection with the same name and keys and only differ in the
+	// expireAfterSeconds or hidden option, which can be changed with the collMod command. The models have their Name
+	// option set to the name of the existing index.
+	Modify []IndexModel
+
+	// The names of indexes that exist on the collection with the same name, keys, and options as a model.
+	Unchanged []string
+
+	// The names of indexes that exist on the collection with the same name as a model but a different keys document.
+	Conflicting []string
+}
+
 // CollectionSpecification represents a collection in a database. This type is returned by the
 // Database.ListCollectionSpecifications function.
 type CollectionSpecification struct {
 	// The collection name.
 	Name string
 
-	// The type of the collection. This will either be "collection" or "view".
+	// The type of the collection. This will be "collection", "view", or "timeseries".
 	Type string
 
 	// Whether or not the collection is readOnly. This will be false for MongoDB versions < 3.4.
@@ -0,0 +1,155 @@
+// Copyright (C) MongoDB, Inc. 2021-present.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
+
+package mongo
+
+import (
+	"context"
+	"errors"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// ErrInvalidSampleSize is returned if a sampling helper is called with a sample size that is not valid.
+var ErrInvalidSampleSize = errors.New("sample size must be positive")
+
+// FieldCoverage describes how often a set of fields is present in the documents of a collection and which types the
+// values of the fields have. It is returned by Collection.FieldCoverage.
+type FieldCoverage struct {
+	// The number of documents examined.
+	Documents int64
+
+	// The statistics for each of the requested fields, keyed by the field path.
+	Fields map[string]FieldStats
+}
+
+// FieldStats describes the values of a single field in the documents examined by Collection.FieldCoverage.
+type FieldStats struct {
+	// The number of examined documents in which the field is present. A field with a null value is present.
+	Present int64
+
+	// The number of examined documents in which the field has each type, keyed by the type alias used by the $type
+	// aggregation operator, e.g. "string" or "int". Documents in which the field is missing are not counted.
+	Types map[string]int64
+}
+
+// Coverage returns the fraction of examined documents in which field is present, between 0 and 1. It returns 0 if no
+// documents were examined or field was not requested.
+func (fc *FieldCoverage) Coverage(field string) float64 {
+	if fc.Documents == 0 {
+		return 0
+	}
+	return float64(fc.Fields[field].Present) / float64(fc.Documents)
+}
+
+// fieldTypeCount is a document returned by the aggregation run by Collection.FieldCoverage.
+type fieldTypeCount struct {
+	ID struct {
+		Field int64  `bson:"field"`
+		Type  string `bson:"type"`
+	} `bson:"_id"`
+	Count int64 `bson:"count"`
+}
+
+// Sample executes an aggregate command with a $sample stage to select n pseudo-random documents from the collection. A
+// Cursor over the sampled documents is returned. ErrInvalidSampleSize is returned if n is not positive.
+//
+// The opts parameter can be used to specify options for the operation (see the options.AggregateOptions documentation).
+//
+// For more information about the stage, see https://docs.mongodb.com/manual/reference/operator/aggregation/sample/.
+func (coll *Collection) Sample(ctx context.Context, n int64, opts ...*options.AggregateOptions) (*Cursor, error) {
+	if n <= 0 {
+		return nil, ErrInvalidSampleSize
+	}
+
+	return coll.Aggregate(ctx, Pipeline{{{"$sample", bson.D{{"size", n}}}}}, opts...)
+}
+
+// FieldCoverage reports how often each of the given fields is present and which types its values have in a sample of
+// the documents in the collection. This can be used to audit the data in a collection before adding a schema validator
+// or an index. Fields can be given as dotted paths into embedded documents, e.g. "address.city".
+//
+// The sample parameter specifies how many pseudo-random documents are examined using a $sample stage. If it is 0, all
+// documents in the collection are examined. ErrInvalidSampleSize is returned if it is negative.
+//
+// The statistics are computed by the server with an aggregation that uses the $type operator, which requires MongoDB
+// 3.4 or later.
+func (coll *Collection) FieldCoverage(ctx context.Context, fields []string, sample int64) (*FieldCoverage, error) {
+	if len(fields) == 0 {
+		return nil, ErrEmptySlice
+	}
+	if sample < 0 {
+		return nil, ErrInvalidSampleSize
+	}
+
+	// Duplicate fields are removed so each field is counted once per document.
+	unique := make([]string, 0, len(fields))
+	seen := make(map[string]bool, len(fields))
+	types := make(bson.A, 0, len(fields))
+	for _, field := range fields {
+		if seen[field] {
+			continue
+		}
+		seen[field] = true
+		unique = append(unique, field)
+		types = append(types, bson.D{{"$type", "$" + field}})
+	}
+
+	var pipeline Pipeline
+	if sample > 0 {
+		pipeline = append(pipeline, bson.D{{"$sample", bson.D{{"size", sample}}}})
+	}
+	pipeline = append(pipeline,
+		bson.D{{"$project", bson.D{{"_id", 0}, {"types", types}}}},
+		bson.D{{"$unwind", bson.D{{"path", "$types"}, {"includeArrayIndex", "field"}}}},
+		bson.D{{"$group", bson.D{
+			{"_id", bson.D{{"field", "$field"}, {"type", "$types"}}},
+			{"count", bson.D{{"$sum", 1}}},
+		}}},
+	)
+
+	cursor, err := coll.Aggregate(ctx, pipeline)
+	if err != nil {
+		return nil, err
+	}
+	var counts []fieldTypeCount
+	if err = cursor.All(ctx, &counts); err != nil {
+		return nil, err
+	}
+
+	return newFieldCoverage(unique, counts), nil
+}
+
+// newFieldCoverage creates a FieldCoverage from the number of documents in which each field has each type. The field
+// of a count is the index of the field in fields.
+func newFieldCoverage(fields []string, counts []fieldTypeCount) *FieldCoverage {
+	fc := &FieldCoverage{Fields: make(map[string]FieldStats, len(fields))}
+	for _, field := range fields {
+		fc.Fields[field] = FieldStats{Types: make(map[string]int64)}
+	}
+
+	for _, count := range counts {
+		if count.ID.Field < 0 || count.ID.Field >= int64(len(fields)) {
+			continue
+		}
+
+		// Every document has a type for every field, so the counts for any one field add up to the number of
+		// documents examined.
+		if count.ID.Field == 0 {
+			fc.Documents += count.Count
+		}
+		if count.ID.Type == "missing" {
+			continue
+		}
+
+		stats := fc.Fields[fields[count.ID.Field]]
+		stats.Present += count.Count
+		stats.Types[count.ID.Type] += count.Count
+		fc.Fields[fields[count.ID.Field]] = stats
+	}
+	return fc
+}
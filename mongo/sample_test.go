@@ -0,0 +1,58 @@
+// Copyright (C) MongoDB, Inc. 2021-present.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
+
+package mongo
+
+import (
+	"testing"
+
+	"go.mongodb.org/mongo-driver/internal/testutil/assert"
+)
+
+func TestSample(t *testing.T) {
+	t.Run("invalid sample size", func(t *testing.T) {
+		coll := setupColl("sample")
+
+		_, err := coll.Sample(bgCtx, 0)
+		assert.Equal(t, ErrInvalidSampleSize, err, "expected error %v, got %v", ErrInvalidSampleSize, err)
+
+		_, err = coll.FieldCoverage(bgCtx, []string{"a"}, -1)
+		assert.Equal(t, ErrInvalidSampleSize, err, "expected error %v, got %v", ErrInvalidSampleSize, err)
+	})
+	t.Run("no fields", func(t *testing.T) {
+		_, err := setupColl("sample").FieldCoverage(bgCtx, nil, 10)
+		assert.Equal(t, ErrEmptySlice, err, "expected error %v, got %v", ErrEmptySlice, err)
+	})
+	t.Run("field coverage", func(t *testing.T) {
+		newCount := func(field int64, typ string, count int64) fieldTypeCount {
+			var c fieldTypeCount
+			c.ID.Field = field
+			c.ID.Type = typ
+			c.Count = count
+			return c
+		}
+
+		fields := []string{"a", "b.c"}
+		counts := []fieldTypeCount{
+			newCount(0, "string", 6),
+			newCount(0, "int", 3),
+			newCount(0, "missing", 1),
+			newCount(1, "null", 2),
+			newCount(1, "missing", 8),
+		}
+		fc := newFieldCoverage(fields, counts)
+
+		assert.Equal(t, int64(10), fc.Documents, "expected 10 documents, got %v", fc.Documents)
+		expected := FieldStats{Present: 9, Types: map[string]int64{"string": 6, "int": 3}}
+		assert.Equal(t, expected, fc.Fields["a"], "expected stats %v, got %v", expected, fc.Fields["a"])
+		expected = FieldStats{Present: 2, Types: map[string]int64{"null": 2}}
+		assert.Equal(t, expected, fc.Fields["b.c"], "expected stats %v, got %v", expected, fc.Fields["b.c"])
+
+		assert.Equal(t, 0.9, fc.Coverage("a"), "expected coverage 0.9, got %v", fc.Coverage("a"))
+		assert.Equal(t, 0.2, fc.Coverage("b.c"), "expected coverage 0.2, got %v", fc.Coverage("b.c"))
+		assert.Equal(t, 0.0, fc.Coverage("d"), "expected coverage 0, got %v", fc.Coverage("d"))
+	})
+}
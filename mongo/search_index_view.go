@@ -0,0 +1,254 @@
+// Copyright (C) MongoDB, Inc. 2021-present.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
+
+package mongo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/bsontype"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"go.mongodb.org/mongo-driver/x/bsonx/bsoncore"
+	"go.mongodb.org/mongo-driver/x/mongo/driver/operation"
+	"go.mongodb.org/mongo-driver/x/mongo/driver/session"
+)
+
+// SearchIndexView is a type that can be used to create, drop, list, and update Atlas Search and Vector Search indexes
+// on a collection. A SearchIndexView for a collection can be created by a call to Collection.SearchIndexes().
+type SearchIndexView struct {
+	coll *Collection
+}
+
+// SearchIndexModel represents a new search index to be created.
+type SearchIndexModel struct {
+	// A document describing the definition for the search index. It cannot be nil. See
+	// https://www.mongodb.com/docs/atlas/atlas-search/create-index/ for reference.
+	Definition interface{}
+
+	// The search index options.
+	Options *options.SearchIndexesOptions
+}
+
+// List executes a $listSearchIndexes aggregation and returns a cursor over the search indexes in the collection.
+//
+// The searchIdxOpts parameter can be used to only list the search index with a specific name (see the
+// options.SearchIndexesOptions documentation).
+//
+// The opts parameter can be used to specify options for this operation (see the options.ListSearchIndexesOptions
+// documentation).
+func (siv SearchIndexView) List(ctx context.Context, searchIdxOpts *options.SearchIndexesOptions,
+	opts ...*options.ListSearchIndexesOptions) (*Cursor, error) {
+
+	stage := bson.D{}
+	if searchIdxOpts != nil && searchIdxOpts.Name != nil {
+		stage = append(stage, bson.E{Key: "name", Value: *searchIdxOpts.Name})
+	}
+
+	var aggregateOpts []*options.AggregateOptions
+	for _, opt := range opts {
+		if opt != nil && opt.AggregateOpts != nil {
+			aggregateOpts = append(aggregateOpts, opt.AggregateOpts)
+		}
+	}
+
+	return siv.coll.Aggregate(ctx, Pipeline{{{"$listSearchIndexes", stage}}}, aggregateOpts...)
+}
+
+// CreateOne executes a createSearchIndexes command to create a search index on the collection and returns the name of
+// the new search index. See the SearchIndexView.CreateMany documentation for more information and an example.
+func (siv SearchIndexView) CreateOne(ctx context.Context, model SearchIndexModel,
+	opts ...*options.CreateSearchIndexesOptions) (string, error) {
+
+	names, err := siv.CreateMany(ctx, []SearchIndexModel{model}, opts...)
+	if err != nil {
+		return "", err
+	}
+
+	return names[0], nil
+}
+
+// CreateMany executes a createSearchIndexes command to create multiple search indexes on the collection and returns
+// the names of the new search indexes. Search indexes are built asynchronously, so an index may not be queryable
+// until the List method reports it as ready.
+//
+// For each SearchIndexModel in the models parameter, the index name can be specified via the Options field. If a name
+// is not given, the server will name the index "default".
+//
+// The opts parameter can be used to specify options for this operation (see the options.CreateSearchIndexesOptions
+// documentation).
+func (siv SearchIndexView) CreateMany(ctx context.Context, models []SearchIndexModel,
+	_ ...*options.CreateSearchIndexesOptions) ([]string, error) {
+
+	var indexes bsoncore.Document
+	aidx, indexes := bsoncore.AppendArrayStart(indexes)
+
+	for i, model := range models {
+		if model.Definition == nil {
+			return nil, errors.New("search index model definition cannot be nil")
+		}
+
+		definition, err := transformBsoncoreDocument(siv.coll.registry, model.Definition, true, "definition")
+		if err != nil {
+			return nil, err
+		}
+
+		var iidx int32
+		iidx, indexes = bsoncore.AppendDocumentElementStart(indexes, strconv.Itoa(i))
+		if model.Options != nil && model.Options.Name != nil {
+			indexes = bsoncore.AppendStringElement(indexes, "name", *model.Options.Name)
+		}
+		if model.Options != nil && model.Options.Type != nil {
+			indexes = bsoncore.AppendStringElement(indexes, "type", *model.Options.Type)
+		}
+		indexes = bsoncore.AppendDocumentElement(indexes, "definition", definition)
+
+		indexes, err = bsoncore.AppendDocumentEnd(indexes, iidx)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	indexes, err := bsoncore.AppendArrayEnd(indexes, aidx)
+	if err != nil {
+		return nil, err
+	}
+
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	sess, err := siv.startSession(ctx)
+	defer closeImplicitSession(sess)
+	if err != nil {
+		return nil, err
+	}
+
+	op := operation.NewCreateSearchIndexes(indexes).
+		Session(sess).CommandMonitor(siv.coll.client.monitor).
+		ServerSelector(makePinnedSelector(sess, siv.coll.writeSelector)).ClusterClock(siv.coll.client.clock).
+		Database(siv.coll.db.name).Collection(siv.coll.name).
+		Deployment(siv.coll.client.deployment).ServerAPI(siv.coll.client.serverAPI)
+	if err = op.Execute(ctx); err != nil {
+		return nil, replaceErrors(err)
+	}
+
+	return searchIndexNames(op.Result().IndexesCreated)
+}
+
+// DropOne executes a dropSearchIndex command to drop the search index with the given name from the collection. This
+// method ignores "namespace not found" errors so it is safe to call it for a collection that does not exist.
+//
+// The opts parameter can be used to specify options for this operation (see the options.DropSearchIndexOptions
+// documentation).
+func (siv SearchIndexView) DropOne(ctx context.Context, name string, _ ...*options.DropSearchIndexOptions) error {
+	if name == "" {
+		return errors.New("search index name cannot be empty")
+	}
+
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	sess, err := siv.startSession(ctx)
+	defer closeImplicitSession(sess)
+	if err != nil {
+		return err
+	}
+
+	op := operation.NewDropSearchIndex(name).
+		Session(sess).CommandMonitor(siv.coll.client.monitor).
+		ServerSelector(makePinnedSelector(sess, siv.coll.writeSelector)).ClusterClock(siv.coll.client.clock).
+		Database(siv.coll.db.name).Collection(siv.coll.name).
+		Deployment(siv.coll.client.deployment).ServerAPI(siv.coll.client.serverAPI)
+	err = op.Execute(ctx)
+	if err != nil && !isNamespaceNotFoundError(err) {
+		return replaceErrors(err)
+	}
+	return nil
+}
+
+// UpdateOne executes an updateSearchIndex command to replace the definition of the search index with the given name.
+//
+// The opts parameter can be used to specify options for this operation (see the options.UpdateSearchIndexOptions
+// documentation).
+func (siv SearchIndexView) UpdateOne(ctx context.Context, name string, definition interface{},
+	_ ...*options.UpdateSearchIndexOptions) error {
+
+	if name == "" {
+		return errors.New("search index name cannot be empty")
+	}
+	if definition == nil {
+		return errors.New("search index definition cannot be nil")
+	}
+
+	def, err := transformBsoncoreDocument(siv.coll.registry, definition, true, "definition")
+	if err != nil {
+		return err
+	}
+
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	sess, err := siv.startSession(ctx)
+	defer closeImplicitSession(sess)
+	if err != nil {
+		return err
+	}
+
+	op := operation.NewUpdateSearchIndex(name, def).
+		Session(sess).CommandMonitor(siv.coll.client.monitor).
+		ServerSelector(makePinnedSelector(sess, siv.coll.writeSelector)).ClusterClock(siv.coll.client.clock).
+		Database(siv.coll.db.name).Collection(siv.coll.name).
+		Deployment(siv.coll.client.deployment).ServerAPI(siv.coll.client.serverAPI)
+	return replaceErrors(op.Execute(ctx))
+}
+
+// startSession returns the session from ctx or, if there is none, a new implicit session. The returned session must be
+// closed with closeImplicitSession.
+func (siv SearchIndexView) startSession(ctx context.Context) (*session.Client, error) {
+	sess := sessionFromContext(ctx)
+	if sess == nil && siv.coll.client.sessionPool != nil {
+		var err error
+		sess, err = session.NewClientSession(siv.coll.client.sessionPool, siv.coll.client.id, session.Implicit)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return sess, siv.coll.client.validSession(sess)
+}
+
+// searchIndexNames returns the names in the indexesCreated array of a createSearchIndexes response.
+func searchIndexNames(indexesCreated bsoncore.Value) ([]string, error) {
+	arr, ok := indexesCreated.ArrayOK()
+	if !ok {
+		return nil, fmt.Errorf("expected indexesCreated to be an array, got BSON type %s", indexesCreated.Type)
+	}
+
+	vals, err := arr.Values()
+	if err != nil {
+		return nil, err
+	}
+
+	names := make([]string, 0, len(vals))
+	for _, val := range vals {
+		doc, ok := val.DocumentOK()
+		if !ok {
+			return nil, fmt.Errorf("expected indexesCreated element to be a document, got BSON type %s", val.Type)
+		}
+		nameVal, err := doc.LookupErr("name")
+		if err != nil || nameVal.Type != bsontype.String {
+			return nil, fmt.Errorf("expected indexesCreated element %v to contain a string name", doc)
+		}
+		names = append(names, nameVal.StringValue())
+	}
+	return names, nil
+}
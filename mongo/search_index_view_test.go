@@ -0,0 +1,52 @@
+// Copyright (C) MongoDB, Inc. 2021-present.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
+
+package mongo
+
+import (
+	"context"
+	"testing"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/bsontype"
+	"go.mongodb.org/mongo-driver/internal/testutil/assert"
+	"go.mongodb.org/mongo-driver/x/bsonx/bsoncore"
+)
+
+func TestSearchIndexView(t *testing.T) {
+	siv := setupColl("search").SearchIndexes()
+
+	t.Run("nil definition", func(t *testing.T) {
+		_, err := siv.CreateOne(context.Background(), SearchIndexModel{})
+		assert.NotNil(t, err, "expected CreateOne error, got nil")
+
+		err = siv.UpdateOne(context.Background(), "idx", nil)
+		assert.NotNil(t, err, "expected UpdateOne error, got nil")
+	})
+	t.Run("empty name", func(t *testing.T) {
+		err := siv.DropOne(context.Background(), "")
+		assert.NotNil(t, err, "expected DropOne error, got nil")
+
+		err = siv.UpdateOne(context.Background(), "", bson.D{})
+		assert.NotNil(t, err, "expected UpdateOne error, got nil")
+	})
+	t.Run("search index names", func(t *testing.T) {
+		doc := func(name string) []byte {
+			return bsoncore.BuildDocument(nil, bsoncore.AppendStringElement(nil, "name", name))
+		}
+		arr := bsoncore.BuildArray(nil,
+			bsoncore.Value{Type: bsontype.EmbeddedDocument, Data: doc("a")},
+			bsoncore.Value{Type: bsontype.EmbeddedDocument, Data: doc("b")},
+		)
+		names, err := searchIndexNames(bsoncore.Value{Type: bsontype.Array, Data: arr})
+		assert.Nil(t, err, "searchIndexNames error: %v", err)
+		assert.Equal(t, []string{"a", "b"}, names, "expected names [a b], got %v", names)
+
+		_, err = searchIndexNames(bsoncore.Value{Type: bsontype.Array, Data: bsoncore.BuildArray(nil,
+			bsoncore.Value{Type: bsontype.EmbeddedDocument, Data: bsoncore.BuildDocument(nil)})})
+		assert.NotNil(t, err, "expected searchIndexNames error for missing name, got nil")
+	})
+}
@@ -0,0 +1,145 @@
+// Copyright (C) MongoDB, Inc. 2021-present.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
+
+package mongo
+
+import (
+	"context"
+
+	"go.mongodb.org/mongo-driver/mongo/description"
+	"go.mongodb.org/mongo-driver/mongo/readpref"
+	"go.mongodb.org/mongo-driver/x/mongo/driver/topology"
+)
+
+// ServerFeatures describes the wire version and the features supported by the servers a Client is connected to. A
+// feature is only reported as supported if all of the data-bearing servers known to the client support it, so the
+// result does not depend on which server an operation is sent to.
+type ServerFeatures struct {
+	// The range of wire versions supported by all of the data-bearing servers known to the client. The maximum is the
+	// lowest maximum wire version of the servers.
+	WireVersion description.VersionRange
+
+	// Whether collations can be specified for operations (MongoDB 3.4+).
+	SupportsCollation bool
+
+	// Whether sessions are supported (MongoDB 3.6+ deployments whose servers report a logical session timeout).
+	SupportsSessions bool
+
+	// Whether array filters can be specified for updates (MongoDB 3.6+).
+	SupportsArrayFilters bool
+
+	// Whether change streams can be opened (MongoDB 3.6+ replica sets and sharded clusters).
+	SupportsChangeStreams bool
+
+	// Whether writes can be retried (MongoDB 3.6+ replica sets and sharded clusters).
+	SupportsRetryableWrites bool
+
+	// Whether transactions can be started (MongoDB 4.0+ replica sets and 4.2+ sharded clusters).
+	SupportsTransactions bool
+
+	// Whether exhaust cursors can stream getMore batches (MongoDB 4.2+).
+	SupportsExhaustCursors bool
+
+	// Whether the let option can be specified for updates, deletes and findAndModify (MongoDB 5.0+).
+	SupportsLetInUpdate bool
+
+	// Whether snapshot reads can be started outside of transactions (MongoDB 5.0+ replica sets and sharded clusters).
+	SupportsSnapshotReads bool
+
+	// Whether time series collections can be created (MongoDB 5.0+).
+	SupportsTimeSeries bool
+
+	// Whether collections can be created with Queryable Encryption encrypted fields (MongoDB 7.0+ replica sets and
+	// sharded clusters).
+	SupportsQueryableEncryption bool
+}
+
+// ServerFeatures returns the wire version and the features supported by the servers the client is connected to. It
+// waits until at least one data-bearing server has been discovered or the context expires. See the ServerFeatures
+// documentation for more information.
+//
+// The features are derived from the server descriptions of the last heartbeats, so they can change if servers are
+// upgraded or added to the deployment. An error is returned if the client is disconnected.
+func (c *Client) ServerFeatures(ctx context.Context) (*ServerFeatures, error) {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	if c.sessionPool == nil {
+		return nil, ErrClientDisconnected
+	}
+
+	selector := description.ReadPrefSelector(readpref.Nearest())
+	server, err := c.deployment.SelectServer(ctx, selector)
+	if err != nil {
+		return nil, replaceErrors(err)
+	}
+
+	// The servers behind a load balancer are not monitored, so their descriptions come from the handshake of a
+	// connection.
+	t, ok := c.deployment.(*topology.Topology)
+	if ok && t.Kind() != description.LoadBalanced {
+		return newServerFeatures(t.Description().Servers), nil
+	}
+	conn, err := server.Connection(ctx)
+	if err != nil {
+		return nil, replaceErrors(err)
+	}
+	defer conn.Close()
+	return newServerFeatures([]description.Server{conn.Description()}), nil
+}
+
+// newServerFeatures returns the features supported by all of the data-bearing servers in servers.
+func newServerFeatures(servers []description.Server) *ServerFeatures {
+	var wire *description.VersionRange
+	standalone, sharded := true, false
+	sessionTimeout := uint32(0)
+	for _, s := range servers {
+		if (!s.DataBearing() && s.Kind != description.LoadBalancer) || s.WireVersion == nil {
+			continue
+		}
+
+		if wire == nil {
+			wire = &description.VersionRange{Min: s.WireVersion.Min, Max: s.WireVersion.Max}
+			sessionTimeout = s.SessionTimeoutMinutes
+		}
+		if s.WireVersion.Min > wire.Min {
+			wire.Min = s.WireVersion.Min
+		}
+		if s.WireVersion.Max < wire.Max {
+			wire.Max = s.WireVersion.Max
+		}
+		if s.SessionTimeoutMinutes < sessionTimeout {
+			sessionTimeout = s.SessionTimeoutMinutes
+		}
+		standalone = standalone && s.Kind == description.Standalone
+		sharded = sharded || s.Kind == description.Mongos || s.Kind == description.LoadBalancer
+	}
+	if wire == nil {
+		return &ServerFeatures{}
+	}
+
+	max := wire.Max
+	replicated := !standalone
+	sessions := max >= 6 && sessionTimeout != 0
+	transactionWire := int32(7)
+	if sharded {
+		transactionWire = 8
+	}
+	return &ServerFeatures{
+		WireVersion:                 *wire,
+		SupportsCollation:           max >= 5,
+		SupportsSessions:            sessions,
+		SupportsArrayFilters:        max >= 6,
+		SupportsChangeStreams:       max >= 6 && replicated,
+		SupportsRetryableWrites:     sessions && replicated,
+		SupportsTransactions:        sessions && replicated && max >= transactionWire,
+		SupportsExhaustCursors:      max >= 8,
+		SupportsLetInUpdate:         max >= 13,
+		SupportsSnapshotReads:       max >= 13 && replicated,
+		SupportsTimeSeries:          max >= 13,
+		SupportsQueryableEncryption: max >= 21 && replicated,
+	}
+}
@@ -0,0 +1,68 @@
+// Copyright (C) MongoDB, Inc. 2021-present.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
+
+package mongo
+
+import (
+	"testing"
+
+	"go.mongodb.org/mongo-driver/internal/testutil/assert"
+	"go.mongodb.org/mongo-driver/mongo/description"
+)
+
+func TestServerFeatures(t *testing.T) {
+	server := func(kind description.ServerKind, maxWire int32) description.Server {
+		return description.Server{
+			Kind:                  kind,
+			WireVersion:           &description.VersionRange{Min: 0, Max: maxWire},
+			SessionTimeoutMinutes: 30,
+		}
+	}
+
+	t.Run("replica set uses lowest wire version", func(t *testing.T) {
+		features := newServerFeatures([]description.Server{
+			server(description.RSPrimary, 13),
+			server(description.RSSecondary, 8),
+			{Kind: description.Unknown},
+		})
+		assert.Equal(t, int32(8), features.WireVersion.Max, "expected max wire version 8, got %v",
+			features.WireVersion.Max)
+		assert.True(t, features.SupportsTransactions, "expected transactions to be supported")
+		assert.True(t, features.SupportsExhaustCursors, "expected exhaust cursors to be supported")
+		assert.False(t, features.SupportsSnapshotReads, "expected snapshot reads not to be supported")
+		assert.False(t, features.SupportsLetInUpdate, "expected let not to be supported")
+	})
+	t.Run("standalone", func(t *testing.T) {
+		features := newServerFeatures([]description.Server{server(description.Standalone, 13)})
+		assert.True(t, features.SupportsSessions, "expected sessions to be supported")
+		assert.True(t, features.SupportsLetInUpdate, "expected let to be supported")
+		assert.False(t, features.SupportsChangeStreams, "expected change streams not to be supported")
+		assert.False(t, features.SupportsRetryableWrites, "expected retryable writes not to be supported")
+		assert.False(t, features.SupportsTransactions, "expected transactions not to be supported")
+		assert.False(t, features.SupportsSnapshotReads, "expected snapshot reads not to be supported")
+	})
+	t.Run("sharded transactions require wire version 8", func(t *testing.T) {
+		features := newServerFeatures([]description.Server{server(description.Mongos, 7)})
+		assert.False(t, features.SupportsTransactions, "expected transactions not to be supported")
+		features = newServerFeatures([]description.Server{server(description.LoadBalancer, 8)})
+		assert.True(t, features.SupportsTransactions, "expected transactions to be supported")
+	})
+	t.Run("sessions require session timeout", func(t *testing.T) {
+		noTimeout := server(description.RSSecondary, 13)
+		noTimeout.SessionTimeoutMinutes = 0
+		features := newServerFeatures([]description.Server{server(description.RSPrimary, 13), noTimeout})
+		assert.False(t, features.SupportsSessions, "expected sessions not to be supported")
+		assert.False(t, features.SupportsTransactions, "expected transactions not to be supported")
+	})
+	t.Run("no data-bearing servers", func(t *testing.T) {
+		features := newServerFeatures([]description.Server{{Kind: description.RSArbiter}})
+		assert.Equal(t, ServerFeatures{}, *features, "expected no features, got %v", features)
+	})
+	t.Run("disconnected client", func(t *testing.T) {
+		_, err := setupClient().ServerFeatures(bgCtx)
+		assert.Equal(t, ErrClientDisconnected, err, "expected error %v, got %v", ErrClientDisconnected, err)
+	})
+}
@@ -0,0 +1,123 @@
+// Copyright (C) MongoDB, Inc. 2021-present.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
+
+package mongo
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// ServerLog is the result of a getLog command. It is returned by Client.GetServerLog.
+type ServerLog struct {
+	// The total number of lines written to the log since the server started. The server only keeps the most recent
+	// lines, so this can be larger than the number of entries.
+	TotalLinesWritten int64
+
+	// The log entries kept by the server, from oldest to newest.
+	Entries []ServerLogEntry
+}
+
+// ServerLogEntry is a single line of a server log. Servers running MongoDB 4.4 or later write structured log lines in
+// JSON format, which are parsed into the fields of the entry. For older servers and lines that cannot be parsed, only
+// the Raw field is set.
+type ServerLogEntry struct {
+	// The time at which the entry was written.
+	Time time.Time
+
+	// The severity of the entry, e.g. "I" for informational or "W" for warning messages.
+	Severity string
+
+	// The component that wrote the entry, e.g. "NETWORK" or "COMMAND".
+	Component string
+
+	// The unique identifier of the log statement that wrote the entry.
+	ID int64
+
+	// The name of the thread that wrote the entry, e.g. "conn12".
+	Context string
+
+	// The message of the entry. Attribute values referenced by the message are stored in Attributes.
+	Message string
+
+	// The attributes of the entry, such as the duration of a slow operation or the address of a client. This is nil
+	// if the entry has no attributes.
+	Attributes bson.Raw
+
+	// The tags of the entry, e.g. "startupWarnings".
+	Tags []string
+
+	// The line as it was returned by the server.
+	Raw string
+}
+
+// structuredLogLine is the JSON format of a log line written by MongoDB 4.4 or later.
+type structuredLogLine struct {
+	Time       time.Time `bson:"t"`
+	Severity   string    `bson:"s"`
+	Component  string    `bson:"c"`
+	ID         int64     `bson:"id"`
+	Context    string    `bson:"ctx"`
+	Message    string    `bson:"msg"`
+	Attributes bson.Raw  `bson:"attr"`
+	Tags       []string  `bson:"tags"`
+}
+
+// GetServerLog executes a getLog command to retrieve the most recent entries of a log kept in memory by the server
+// and parses them into ServerLogEntry values. The name parameter specifies the log to retrieve and must be "global"
+// for the combined log or "startupWarnings" for the warnings logged when the server started.
+//
+// The command is run against the admin database. The opts parameter can be used to specify options for the command,
+// e.g. a read preference to select the server whose log is retrieved (see the options.RunCmdOptions documentation).
+//
+// For more information about the command, see https://docs.mongodb.com/manual/reference/command/getLog/.
+func (c *Client) GetServerLog(ctx context.Context, name string, opts ...*options.RunCmdOptions) (*ServerLog, error) {
+	var res struct {
+		TotalLinesWritten int64    `bson:"totalLinesWritten"`
+		Log               []string `bson:"log"`
+	}
+	err := c.Database("admin").RunCommand(ctx, bson.D{{"getLog", name}}, opts...).Decode(&res)
+	if err != nil {
+		return nil, err
+	}
+
+	log := &ServerLog{
+		TotalLinesWritten: res.TotalLinesWritten,
+		Entries:           make([]ServerLogEntry, 0, len(res.Log)),
+	}
+	for _, line := range res.Log {
+		log.Entries = append(log.Entries, parseServerLogLine(line))
+	}
+	return log, nil
+}
+
+// parseServerLogLine parses a structured log line. If line is not a structured log line, an entry with only the Raw
+// field set is returned.
+func parseServerLogLine(line string) ServerLogEntry {
+	entry := ServerLogEntry{Raw: line}
+	if !strings.HasPrefix(strings.TrimSpace(line), "{") {
+		return entry
+	}
+
+	var parsed structuredLogLine
+	if err := bson.UnmarshalExtJSON([]byte(line), false, &parsed); err != nil {
+		return entry
+	}
+
+	entry.Time = parsed.Time
+	entry.Severity = parsed.Severity
+	entry.Component = parsed.Component
+	entry.ID = parsed.ID
+	entry.Context = parsed.Context
+	entry.Message = parsed.Message
+	entry.Attributes = parsed.Attributes
+	entry.Tags = parsed.Tags
+	return entry
+}
@@ -0,0 +1,47 @@
+// Copyright (C) MongoDB, Inc. 2021-present.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
+
+package mongo
+
+import (
+	"testing"
+	"time"
+
+	"go.mongodb.org/mongo-driver/internal/testutil/assert"
+)
+
+func TestServerLog(t *testing.T) {
+	t.Run("structured line", func(t *testing.T) {
+		line := `{"t":{"$date":"2021-06-01T12:30:00.123+00:00"},"s":"I","c":"NETWORK","id":22943,"ctx":"listener",` +
+			`"msg":"Connection accepted","attr":{"remote":"127.0.0.1:54321","connectionCount":3},"tags":["startupWarnings"]}`
+		entry := parseServerLogLine(line)
+
+		expectedTime := time.Date(2021, time.June, 1, 12, 30, 0, 123000000, time.UTC)
+		assert.True(t, expectedTime.Equal(entry.Time), "expected time %v, got %v", expectedTime, entry.Time)
+		assert.Equal(t, "I", entry.Severity, "expected severity I, got %v", entry.Severity)
+		assert.Equal(t, "NETWORK", entry.Component, "expected component NETWORK, got %v", entry.Component)
+		assert.Equal(t, int64(22943), entry.ID, "expected ID 22943, got %v", entry.ID)
+		assert.Equal(t, "listener", entry.Context, "expected context listener, got %v", entry.Context)
+		assert.Equal(t, "Connection accepted", entry.Message, "expected message 'Connection accepted', got %v",
+			entry.Message)
+		assert.Equal(t, []string{"startupWarnings"}, entry.Tags, "expected tags [startupWarnings], got %v", entry.Tags)
+		assert.Equal(t, line, entry.Raw, "expected raw line %v, got %v", line, entry.Raw)
+
+		remote, ok := entry.Attributes.Lookup("remote").StringValueOK()
+		assert.True(t, ok, "expected remote attribute in %v", entry.Attributes)
+		assert.Equal(t, "127.0.0.1:54321", remote, "expected remote 127.0.0.1:54321, got %v", remote)
+	})
+	t.Run("legacy line", func(t *testing.T) {
+		line := "2019-06-01T12:30:00.123+0000 I NETWORK  [listener] connection accepted from 127.0.0.1:54321"
+		entry := parseServerLogLine(line)
+		assert.Equal(t, ServerLogEntry{Raw: line}, entry, "expected only the raw line to be set, got %v", entry)
+	})
+	t.Run("malformed line", func(t *testing.T) {
+		line := `{"t":`
+		entry := parseServerLogLine(line)
+		assert.Equal(t, ServerLogEntry{Raw: line}, entry, "expected only the raw line to be set, got %v", entry)
+	})
+}
@@ -165,6 +165,7 @@ func (s *sessionImpl) EndSession(ctx context.Context) {
 		_ = s.AbortTransaction(ctx)
 	}
 	s.clientSession.EndSession()
+	s.client.untrackSession(s.clientSession)
 }
 
 // WithTransaction implements the Session interface.
@@ -194,7 +195,7 @@ func (s *sessionImpl) WithTransaction(ctx context.Context, fn func(sessCtx Sessi
 			}
 
 			if cerr, ok := err.(CommandError); ok {
-				if cerr.HasErrorLabel(driver.TransientTransactionError) {
+				if cerr.HasErrorLabel(TransientTransactionErrorLabel) {
 					continue
 				}
 			}
@@ -220,10 +221,10 @@ func (s *sessionImpl) WithTransaction(ctx context.Context, fn func(sessCtx Sessi
 			}
 
 			if cerr, ok := err.(CommandError); ok {
-				if cerr.HasErrorLabel(driver.UnknownTransactionCommitResult) && !cerr.IsMaxTimeMSExpiredError() {
+				if cerr.HasErrorLabel(UnknownTransactionCommitResultLabel) && !cerr.IsMaxTimeMSExpiredError() {
 					continue
 				}
-				if cerr.HasErrorLabel(driver.TransientTransactionError) {
+				if cerr.HasErrorLabel(TransientTransactionErrorLabel) {
 					break CommitLoop
 				}
 			}
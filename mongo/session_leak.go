@@ -0,0 +1,93 @@
+// Copyright (C) MongoDB, Inc. 2021-present.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
+
+package mongo
+
+import (
+	"runtime/debug"
+	"sort"
+	"sync"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/event"
+	"go.mongodb.org/mongo-driver/x/mongo/driver/session"
+)
+
+// sessionLeakDetector tracks the explicit sessions of a Client and reports them when the number of checked out server
+// sessions grows beyond a threshold to the ThresholdExceeded function of a SessionMonitor. It is only configured if the
+// SessionLeakThreshold client option is set and a SessionMonitor with a ThresholdExceeded function is specified.
+type sessionLeakDetector struct {
+	threshold int
+	monitor   *event.SessionMonitor
+
+	mu       sync.Mutex
+	sessions map[*session.Client]event.OutstandingSession
+}
+
+func newSessionLeakDetector(threshold int, monitor *event.SessionMonitor) *sessionLeakDetector {
+	return &sessionLeakDetector{
+		threshold: threshold,
+		monitor:   monitor,
+		sessions:  make(map[*session.Client]event.OutstandingSession),
+	}
+}
+
+// trackSession starts tracking the explicit session sess if session leak detection is enabled for the Client.
+func (c *Client) trackSession(sess *session.Client) {
+	if c == nil || c.sessionLeaks == nil {
+		return
+	}
+
+	outstanding := event.OutstandingSession{
+		StartedAt: time.Now(),
+		Stack:     string(debug.Stack()),
+	}
+	if sess.Server != nil {
+		outstanding.SessionID = bson.Raw(sess.SessionID)
+	}
+
+	d := c.sessionLeaks
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.sessions[sess] = outstanding
+}
+
+// untrackSession stops tracking the explicit session sess. It is safe to call for sessions that are not tracked.
+func (c *Client) untrackSession(sess *session.Client) {
+	if c == nil || c.sessionLeaks == nil {
+		return
+	}
+
+	d := c.sessionLeaks
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	delete(d.sessions, sess)
+}
+
+// thresholdExceeded is called by the session pool when the number of checked out server sessions grows beyond the
+// threshold. If the threshold is exceeded by StartSession, the session being started is not tracked yet, so it is not
+// included in the report.
+func (d *sessionLeakDetector) thresholdExceeded(checkedOut int) {
+	d.mu.Lock()
+	sessions := make([]event.OutstandingSession, 0, len(d.sessions))
+	for _, outstanding := range d.sessions {
+		sessions = append(sessions, outstanding)
+	}
+	d.mu.Unlock()
+
+	sort.Slice(sessions, func(i, j int) bool {
+		return sessions[i].StartedAt.Before(sessions[j].StartedAt)
+	})
+	evt := &event.SessionThresholdExceededEvent{
+		CheckedOut: checkedOut,
+		Threshold:  d.threshold,
+		Sessions:   sessions,
+	}
+	if d.monitor != nil && d.monitor.ThresholdExceeded != nil {
+		d.monitor.ThresholdExceeded(evt)
+	}
+}
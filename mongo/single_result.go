@@ -9,15 +9,30 @@ package mongo
 import (
 	"context"
 	"errors"
+	"reflect"
+	"strings"
 
 	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/bson/bsoncodec"
+	"go.mongodb.org/mongo-driver/bson/bsontype"
 )
 
 // ErrNoDocuments is returned by SingleResult methods when the operation that created the SingleResult did not return
 // any documents.
 var ErrNoDocuments = errors.New("mongo: no documents in result")
 
+// MissingFieldsError is returned by SingleResult.DecodeInto if the document did not contain a value for one or more
+// struct fields that are not marked omitempty.
+type MissingFieldsError struct {
+	// Fields contains the keys of the missing fields. Fields of embedded documents are given as dotted paths.
+	Fields []string
+}
+
+// Error implements the error interface.
+func (mfe MissingFieldsError) Error() string {
+	return "mongo: document is missing fields: " + strings.Join(mfe.Fields, ", ")
+}
+
 // SingleResult represents a single document returned from an operation. If the operation resulted in an error, all
 // SingleResult methods will return that error. If the operation did not return any documents, all SingleResult methods
 // will return ErrNoDocuments.
@@ -48,6 +63,79 @@ func (sr *SingleResult) Decode(v interface{}) error {
 	return bson.UnmarshalWithRegistry(sr.reg, sr.rdr, v)
 }
 
+// DecodeInto behaves like Decode, but also validates that the document contained a value for every field of the struct
+// v points to that is not marked omitempty. If any are missing, v is still populated with the fields that were present
+// and a MissingFieldsError listing the missing fields is returned. This can be used to detect projections or schemas
+// that have drifted from the struct definition, which would otherwise result in silent zero values.
+//
+// Fields of inline structs are validated as fields of the enclosing struct and fields of embedded documents are
+// validated recursively. Keys are determined using bsoncodec.DefaultStructTagParser. If v does not point to a struct,
+// DecodeInto is equivalent to Decode.
+func (sr *SingleResult) DecodeInto(v interface{}) error {
+	if err := sr.Decode(v); err != nil {
+		return err
+	}
+
+	missing, err := missingStructFields(sr.rdr, reflect.TypeOf(v), "")
+	if err != nil {
+		return err
+	}
+	if len(missing) > 0 {
+		return MissingFieldsError{Fields: missing}
+	}
+	return nil
+}
+
+// missingStructFields returns the keys of the fields of t that are not marked omitempty and are not present in doc.
+func missingStructFields(doc bson.Raw, t reflect.Type, prefix string) ([]string, error) {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	if t.Kind() != reflect.Struct {
+		return nil, nil
+	}
+
+	var missing []string
+	for i := 0; i < t.NumField(); i++ {
+		sf := t.Field(i)
+		if sf.PkgPath != "" {
+			continue
+		}
+
+		stags, err := bsoncodec.DefaultStructTagParser.ParseStructTags(sf)
+		if err != nil {
+			return nil, err
+		}
+		if stags.Skip {
+			continue
+		}
+		if stags.Inline {
+			inlined, err := missingStructFields(doc, sf.Type, prefix)
+			if err != nil {
+				return nil, err
+			}
+			missing = append(missing, inlined...)
+			continue
+		}
+
+		val, err := doc.LookupErr(stags.Name)
+		if err != nil {
+			if !stags.OmitEmpty {
+				missing = append(missing, prefix+stags.Name)
+			}
+			continue
+		}
+		if val.Type == bsontype.EmbeddedDocument {
+			nested, err := missingStructFields(val.Document(), sf.Type, prefix+stags.Name+".")
+			if err != nil {
+				return nil, err
+			}
+			missing = append(missing, nested...)
+		}
+	}
+	return missing, nil
+}
+
 // DecodeBytes will return the document represented by this SingleResult as a bson.Raw. If there was an error from the
 // operation that created this SingleResult, both the result and that error will be returned. If the operation returned
 // no documents, this will return (nil, ErrNoDocuments).
@@ -44,6 +44,56 @@ func TestSingleResult(t *testing.T) {
 		})
 	})
 
+	t.Run("DecodeInto", func(t *testing.T) {
+		type address struct {
+			City string
+			Zip  string `bson:"zip,omitempty"`
+		}
+		type Base struct {
+			ID int `bson:"_id"`
+		}
+		type person struct {
+			Base    `bson:",inline"`
+			Name    string
+			Age     int      `bson:"age,omitempty"`
+			Address *address `bson:"addr"`
+			Ignored string   `bson:"-"`
+		}
+
+		t.Run("all fields present", func(t *testing.T) {
+			doc, err := bson.Marshal(bson.D{{"_id", 1}, {"name", "foo"}, {"addr", bson.D{{"city", "bar"}}}})
+			assert.Nil(t, err, "Marshal error: %v", err)
+
+			var p person
+			sr := &SingleResult{rdr: doc, reg: bson.DefaultRegistry}
+			err = sr.DecodeInto(&p)
+			assert.Nil(t, err, "DecodeInto error: %v", err)
+			assert.Equal(t, "bar", p.Address.City, "expected city %q, got %q", "bar", p.Address.City)
+		})
+		t.Run("missing fields", func(t *testing.T) {
+			doc, err := bson.Marshal(bson.D{{"name", "foo"}, {"addr", bson.D{{"zip", "12345"}}}})
+			assert.Nil(t, err, "Marshal error: %v", err)
+
+			var p person
+			sr := &SingleResult{rdr: doc, reg: bson.DefaultRegistry}
+			err = sr.DecodeInto(&p)
+			mfe, ok := err.(MissingFieldsError)
+			assert.True(t, ok, "expected error type %T, got %T", MissingFieldsError{}, err)
+			expected := []string{"_id", "addr.city"}
+			assert.Equal(t, expected, mfe.Fields, "expected missing fields %v, got %v", expected, mfe.Fields)
+			assert.Equal(t, "foo", p.Name, "expected name %q, got %q", "foo", p.Name)
+		})
+		t.Run("non-struct", func(t *testing.T) {
+			doc, err := bson.Marshal(bson.D{{"x", 1}})
+			assert.Nil(t, err, "Marshal error: %v", err)
+
+			var m bson.M
+			sr := &SingleResult{rdr: doc, reg: bson.DefaultRegistry}
+			err = sr.DecodeInto(&m)
+			assert.Nil(t, err, "DecodeInto error: %v", err)
+		})
+	})
+
 	t.Run("Err", func(t *testing.T) {
 		sr := &SingleResult{}
 		assert.Equal(t, ErrNoDocuments, sr.Err(), "expected error %v, got %v", ErrNoDocuments, sr.Err())
@@ -0,0 +1,54 @@
+// Copyright (C) MongoDB, Inc. 2021-present.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
+
+package mongo_test
+
+import (
+	"context"
+	"testing"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/internal/testutil/assert"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/mongotest"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+func TestStaleRoutingRetry(t *testing.T) {
+	staleConfig := mongotest.ErrorReply(13388, "stale config")
+	newColl := func(t *testing.T, opts ...*options.ClientOptions) (*mongotest.Deployment, *mongo.Collection) {
+		t.Helper()
+
+		d := mongotest.New()
+		client, err := d.NewClient(opts...)
+		assert.Nil(t, err, "NewClient error: %v", err)
+		return d, client.Database("db").Collection("orders")
+	}
+
+	t.Run("aggregate is retried", func(t *testing.T) {
+		d, coll := newColl(t)
+		d.AddReplies(staleConfig, mongotest.CursorReply("db.orders"))
+		cursor, err := coll.Aggregate(context.Background(), mongo.Pipeline{})
+		assert.Nil(t, err, "Aggregate error: %v", err)
+		_ = cursor.Close(context.Background())
+		assert.Equal(t, 2, len(d.Commands()), "expected 2 attempts, got %v", len(d.Commands()))
+	})
+	t.Run("aggregate with $merge is not retried", func(t *testing.T) {
+		d, coll := newColl(t)
+		d.AddReplies(staleConfig, mongotest.CursorReply("db.orders"))
+		pipeline := mongo.Pipeline{{{"$merge", bson.D{{"into", "totals"}}}}}
+		_, err := coll.Aggregate(context.Background(), pipeline)
+		assert.NotNil(t, err, "expected Aggregate error, got nil")
+		assert.Equal(t, 1, len(d.Commands()), "expected 1 attempt, got %v", len(d.Commands()))
+	})
+	t.Run("find is not retried with retryReads=false", func(t *testing.T) {
+		d, coll := newColl(t, options.Client().SetRetryReads(false))
+		d.AddReplies(staleConfig, mongotest.CursorReply("db.orders"))
+		_, err := coll.Find(context.Background(), bson.D{})
+		assert.NotNil(t, err, "expected Find error, got nil")
+		assert.Equal(t, 1, len(d.Commands()), "expected 1 attempt, got %v", len(d.Commands()))
+	})
+}
@@ -0,0 +1,185 @@
+// Copyright (C) MongoDB, Inc. 2021-present.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
+
+package mongo
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"strings"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// SyncDocuments makes the documents in the collection match the given documents with the minimal number of writes,
+// e.g. to load reference data. The documents are matched to the documents in the collection by their key fields,
+// which must be unique in the given documents. Documents that are not in the collection are inserted, documents that
+// differ from the document in the collection with the same key replace it, and documents in the collection whose keys
+// are not in the given documents are deleted. Documents that are already up to date are not written.
+//
+// If a given document does not have an _id field, it is compared with the document in the collection without the _id
+// field and the _id of the document in the collection is kept when it is replaced. Documents in the collection that do
+// not have all of the key fields are deleted.
+//
+// The writes are executed as a single ordered bulk write in which deletes come before replacements and replacements
+// come before inserts, so changes to the keys of documents do not conflict with unique indexes. If the bulk write
+// fails, the writes before the failed write have been applied and the result is returned along with the error. Its
+// InsertedCount, ReplacedCount, and DeletedCount fields are then the counts of the writes that were applied. The
+// documents in the scope are held in memory while the changes are computed.
+//
+// The opts parameter can be used to specify options for the operation (see the options.SyncOptions documentation).
+func (coll *Collection) SyncDocuments(ctx context.Context, documents []interface{},
+	opts ...*options.SyncOptions) (*SyncResult, error) {
+
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	so := options.MergeSyncOptions(opts...)
+	keyFields := so.KeyFields
+	if len(keyFields) == 0 {
+		keyFields = []string{"_id"}
+	}
+
+	desired := make(map[string]bson.Raw, len(documents))
+	keys := make([]string, 0, len(documents))
+	for i, document := range documents {
+		doc, err := transformBsoncoreDocument(coll.registry, document, true, "document")
+		if err != nil {
+			return nil, err
+		}
+		key, ok := syncKey(bson.Raw(doc), keyFields)
+		if !ok {
+			return nil, fmt.Errorf("document at index %d does not have all key fields %v", i, keyFields)
+		}
+		if _, ok := desired[key]; ok {
+			return nil, fmt.Errorf("document at index %d has the same key as a previous document", i)
+		}
+		desired[key] = bson.Raw(doc)
+		keys = append(keys, key)
+	}
+
+	scope := so.Scope
+	if scope == nil {
+		scope = bson.D{}
+	}
+	cursor, err := coll.Find(ctx, scope, options.Find().SetUnbounded(true))
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	res := &SyncResult{}
+	var deletes, replaces, inserts []WriteModel
+	deleteExtra := so.Delete == nil || *so.Delete
+	matched := make(map[string]bool, len(documents))
+	for cursor.Next(ctx) {
+		existing := cursor.Current
+		id, err := documentID(existing)
+		if err != nil {
+			return nil, err
+		}
+		filter := bson.D{{"_id", id}}
+
+		key, ok := syncKey(existing, keyFields)
+		doc, found := desired[key]
+		if !ok || !found || matched[key] {
+			if deleteExtra {
+				deletes = append(deletes, NewDeleteOneModel().SetFilter(filter))
+				res.DeletedCount++
+			}
+			continue
+		}
+		matched[key] = true
+
+		docID, err := doc.LookupErr("_id")
+		hasID := err == nil
+		switch {
+		case hasID && bytes.Equal(existing, doc), !hasID && equalWithoutID(existing, doc):
+			res.UnchangedCount++
+		case hasID && !docID.Equal(id):
+			// The _id of a document cannot be changed, so the document is deleted and inserted instead.
+			deletes = append(deletes, NewDeleteOneModel().SetFilter(filter))
+			inserts = append(inserts, NewInsertOneModel().SetDocument(doc))
+			res.DeletedCount++
+			res.InsertedCount++
+		default:
+			replaces = append(replaces, NewReplaceOneModel().SetFilter(filter).SetReplacement(doc))
+			res.ReplacedCount++
+		}
+	}
+	if err = cursor.Err(); err != nil {
+		return nil, err
+	}
+
+	for _, key := range keys {
+		if !matched[key] {
+			inserts = append(inserts, NewInsertOneModel().SetDocument(desired[key]))
+			res.InsertedCount++
+		}
+	}
+
+	models := append(append(deletes, replaces...), inserts...)
+	if len(models) == 0 {
+		return res, nil
+	}
+	bwo := options.BulkWrite().SetOrdered(true)
+	if so.BypassDocumentValidation != nil {
+		bwo.SetBypassDocumentValidation(*so.BypassDocumentValidation)
+	}
+	res.BulkWrite, err = coll.BulkWrite(ctx, models, bwo)
+	if err != nil {
+		if res.BulkWrite == nil {
+			return nil, err
+		}
+		res.InsertedCount = res.BulkWrite.InsertedCount
+		res.ReplacedCount = res.BulkWrite.MatchedCount
+		res.DeletedCount = res.BulkWrite.DeletedCount
+		return res, err
+	}
+	return res, nil
+}
+
+// syncKey returns a map key for the values of the key fields of doc and whether doc has all of the key fields.
+func syncKey(doc bson.Raw, keyFields []string) (string, bool) {
+	var sb strings.Builder
+	for _, field := range keyFields {
+		val, err := doc.LookupErr(strings.Split(field, ".")...)
+		if err != nil {
+			return "", false
+		}
+		// BSON values are self-delimiting given their type, so the key is unambiguous.
+		sb.WriteByte(byte(val.Type))
+		sb.Write(val.Value)
+	}
+	return sb.String(), true
+}
+
+// equalWithoutID returns true if existing without its _id field is equal to doc, which does not have an _id field.
+func equalWithoutID(existing, doc bson.Raw) bool {
+	existingElems, err := existing.Elements()
+	if err != nil {
+		return false
+	}
+	docElems, err := doc.Elements()
+	if err != nil {
+		return false
+	}
+
+	i := 0
+	for _, elem := range existingElems {
+		if elem.Key() == "_id" {
+			continue
+		}
+		if i >= len(docElems) || !bytes.Equal(elem, docElems[i]) {
+			return false
+		}
+		i++
+	}
+	return i == len(docElems)
+}
@@ -0,0 +1,60 @@
+// Copyright (C) MongoDB, Inc. 2021-present.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
+
+package mongo
+
+import (
+	"testing"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/internal/testutil/assert"
+)
+
+func TestSyncDocuments(t *testing.T) {
+	marshal := func(t *testing.T, doc interface{}) bson.Raw {
+		t.Helper()
+
+		b, err := bson.Marshal(doc)
+		assert.Nil(t, err, "Marshal error: %v", err)
+		return b
+	}
+
+	t.Run("syncKey", func(t *testing.T) {
+		fields := []string{"a", "b.c"}
+		key, ok := syncKey(marshal(t, bson.D{{"a", 1}, {"b", bson.D{{"c", "x"}}}}), fields)
+		assert.True(t, ok, "expected document to have key fields")
+		other, ok := syncKey(marshal(t, bson.D{{"b", bson.D{{"c", "x"}}}, {"z", 2}, {"a", 1}}), fields)
+		assert.True(t, ok, "expected document to have key fields")
+		assert.Equal(t, key, other, "expected keys to be equal")
+
+		int64Key, _ := syncKey(marshal(t, bson.D{{"a", int64(1)}, {"b", bson.D{{"c", "x"}}}}), fields)
+		assert.NotEqual(t, key, int64Key, "expected keys of different types to differ")
+
+		_, ok = syncKey(marshal(t, bson.D{{"a", 1}}), fields)
+		assert.False(t, ok, "expected document to be missing key fields")
+	})
+	t.Run("equalWithoutID", func(t *testing.T) {
+		doc := marshal(t, bson.D{{"x", 1}, {"y", "z"}})
+		testCases := []struct {
+			name     string
+			existing bson.D
+			equal    bool
+		}{
+			{"equal", bson.D{{"_id", 1}, {"x", 1}, {"y", "z"}}, true},
+			{"id in the middle", bson.D{{"x", 1}, {"_id", 1}, {"y", "z"}}, true},
+			{"different value", bson.D{{"_id", 1}, {"x", 2}, {"y", "z"}}, false},
+			{"different order", bson.D{{"_id", 1}, {"y", "z"}, {"x", 1}}, false},
+			{"extra field", bson.D{{"_id", 1}, {"x", 1}, {"y", "z"}, {"w", 1}}, false},
+			{"missing field", bson.D{{"_id", 1}, {"x", 1}}, false},
+		}
+		for _, tc := range testCases {
+			t.Run(tc.name, func(t *testing.T) {
+				equal := equalWithoutID(marshal(t, tc.existing), doc)
+				assert.Equal(t, tc.equal, equal, "expected equalWithoutID to return %v, got %v", tc.equal, equal)
+			})
+		}
+	})
+}
@@ -0,0 +1,151 @@
+// Copyright (C) MongoDB, Inc. 2021-present.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
+
+package mongo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/bsontype"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"go.mongodb.org/mongo-driver/x/bsonx/bsoncore"
+)
+
+// TTLFieldError is reported to the TTLFieldHandler of a collection (see the options.CollectionOptions.TTLFieldHandler
+// documentation) for an inserted document that will never expire because the field of the collection's TTL policy does
+// not contain a date.
+type TTLFieldError struct {
+	// The namespace of the insert, in the form "<database>.<collection>".
+	Namespace string
+
+	// The _id of the document, which is the zero RawValue if the document does not have an _id.
+	DocumentID bson.RawValue
+
+	// The field of the TTL policy.
+	Field string
+
+	// The BSON type of the field in the document.
+	Type bsontype.Type
+}
+
+// Error implements the error interface.
+func (e TTLFieldError) Error() string {
+	return fmt.Sprintf("the TTL field %q of the document with _id %v inserted into %s has BSON type %v instead of a "+
+		"date, so the document will never expire", e.Field, e.DocumentID, e.Namespace, e.Type)
+}
+
+// EnsureTTLIndex creates or updates the TTL index for the TTL policy of the collection (see the
+// options.CollectionOptions.TTLPolicy documentation) and returns the name of the index.
+//
+// If the collection does not have an index on the policy's field, an ascending index is created with the policy's
+// expiry. If a single-field index on the field exists with a different expiry, the expiry is changed using the collMod
+// command. An error is returned if the collection does not have a TTL policy or if an index on the field exists that
+// is not a TTL index, because the index must be dropped before a TTL index on the field can be created.
+func (coll *Collection) EnsureTTLIndex(ctx context.Context) (string, error) {
+	policy := coll.ttlPolicy
+	if policy == nil {
+		return "", errors.New("the collection does not have a TTL policy")
+	}
+	if policy.Field == "" {
+		return "", errors.New("the field of the TTL policy cannot be empty")
+	}
+	expireAfterSeconds := int32(policy.ExpireAfter / time.Second)
+
+	existing, err := coll.Indexes().ListSpecifications(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	spec := findTTLIndex(existing, policy.Field)
+	switch {
+	case spec == nil:
+		model := IndexModel{
+			Keys:    bson.D{{policy.Field, 1}},
+			Options: options.Index().SetExpireAfterSeconds(expireAfterSeconds),
+		}
+		return coll.Indexes().CreateOne(ctx, model)
+	case spec.ExpireAfterSeconds == nil:
+		return "", fmt.Errorf("index %q on field %q is not a TTL index", spec.Name, policy.Field)
+	case *spec.ExpireAfterSeconds == expireAfterSeconds:
+		return spec.Name, nil
+	}
+
+	index := options.ModifyIndex().SetName(spec.Name).SetExpireAfterSeconds(int64(expireAfterSeconds))
+	err = coll.db.ModifyCollection(ctx, coll.name, options.ModifyCollection().SetIndex(index))
+	if err != nil {
+		return "", err
+	}
+	return spec.Name, nil
+}
+
+// findTTLIndex returns the single-field index on field in existing, or nil if there is none. Compound indexes are
+// ignored because they cannot be TTL indexes.
+func findTTLIndex(existing []*IndexSpecification, field string) *IndexSpecification {
+	for _, spec := range existing {
+		elems, err := bsoncore.Document(spec.KeysDocument).Elements()
+		if err != nil || len(elems) != 1 {
+			continue
+		}
+		if elems[0].Key() == field {
+			return spec
+		}
+	}
+	return nil
+}
+
+// checkTTLFields reports a TTLFieldError to the TTL field handler of the collection for each of the documents that will never expire under the TTL policy of the
+// collection because the policy's field does not contain a date. Documents without the field or with a null value are
+// assumed to not expire intentionally.
+func (coll *Collection) checkTTLFields(docs []bsoncore.Document) {
+	if coll.ttlPolicy == nil || coll.ttlFieldHandler == nil {
+		return
+	}
+
+	for _, doc := range docs {
+		t, ok := invalidTTLFieldType(doc, coll.ttlPolicy.Field)
+		if !ok {
+			continue
+		}
+
+		id, _ := doc.LookupErr("_id")
+		coll.ttlFieldHandler(TTLFieldError{
+			Namespace:  coll.db.name + "." + coll.name,
+			DocumentID: bson.RawValue{Type: id.Type, Value: id.Data},
+			Field:      coll.ttlPolicy.Field,
+			Type:       t,
+		})
+	}
+}
+
+// invalidTTLFieldType returns the type of field in doc and true if the field exists but will not cause the document to
+// expire. A field is valid if it is a date, an array containing at least one date, or null.
+func invalidTTLFieldType(doc bsoncore.Document, field string) (bsontype.Type, bool) {
+	val, err := doc.LookupErr(strings.Split(field, ".")...)
+	if err != nil {
+		return 0, false
+	}
+
+	switch val.Type {
+	case bsontype.DateTime, bsontype.Null:
+		return 0, false
+	case bsontype.Array:
+		values, err := val.Array().Values()
+		if err != nil {
+			return 0, false
+		}
+		for _, v := range values {
+			if v.Type == bsontype.DateTime {
+				return 0, false
+			}
+		}
+	}
+	return val.Type, true
+}
@@ -0,0 +1,103 @@
+// Copyright (C) MongoDB, Inc. 2021-present.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
+
+package mongo
+
+import (
+	"testing"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/bsontype"
+	"go.mongodb.org/mongo-driver/internal/testutil/assert"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"go.mongodb.org/mongo-driver/x/bsonx/bsoncore"
+)
+
+func TestTTL(t *testing.T) {
+	t.Run("collection options", func(t *testing.T) {
+		coll := setupColl("ttl", options.Collection().SetTTLPolicy("createdAt", time.Hour))
+		expected := &options.TTLPolicy{Field: "createdAt", ExpireAfter: time.Hour}
+		assert.Equal(t, expected, coll.ttlPolicy, "expected TTL policy %v, got %v", expected, coll.ttlPolicy)
+
+		clone, err := coll.Clone()
+		assert.Nil(t, err, "Clone error: %v", err)
+		assert.Equal(t, expected, clone.ttlPolicy, "expected cloned TTL policy %v, got %v", expected, clone.ttlPolicy)
+	})
+	t.Run("EnsureTTLIndex without policy", func(t *testing.T) {
+		_, err := setupColl("ttl").EnsureTTLIndex(bgCtx)
+		assert.NotNil(t, err, "expected EnsureTTLIndex error, got nil")
+	})
+	t.Run("find TTL index", func(t *testing.T) {
+		spec := func(name string, keys bson.D) *IndexSpecification {
+			doc, err := bson.Marshal(keys)
+			assert.Nil(t, err, "Marshal error: %v", err)
+			return &IndexSpecification{Name: name, KeysDocument: doc}
+		}
+		existing := []*IndexSpecification{
+			spec("_id_", bson.D{{"_id", int32(1)}}),
+			spec("createdAt_1_a_1", bson.D{{"createdAt", int32(1)}, {"a", int32(1)}}),
+			spec("createdAt_-1", bson.D{{"createdAt", int32(-1)}}),
+		}
+
+		found := findTTLIndex(existing, "createdAt")
+		assert.NotNil(t, found, "expected index to be found")
+		assert.Equal(t, "createdAt_-1", found.Name, "expected index createdAt_-1, got %v", found.Name)
+		found = findTTLIndex(existing, "updatedAt")
+		assert.Nil(t, found, "expected no index to be found, got %v", found)
+	})
+	t.Run("invalid TTL field type", func(t *testing.T) {
+		now := time.Now()
+		testCases := []struct {
+			name    string
+			field   string
+			doc     bson.D
+			invalid bool
+			t       bsontype.Type
+		}{
+			{"date", "createdAt", bson.D{{"createdAt", now}}, false, 0},
+			{"missing", "createdAt", bson.D{{"a", 1}}, false, 0},
+			{"null", "createdAt", bson.D{{"createdAt", nil}}, false, 0},
+			{"array with date", "createdAt", bson.D{{"createdAt", bson.A{"x", now}}}, false, 0},
+			{"string", "createdAt", bson.D{{"createdAt", now.Format(time.RFC3339)}}, true, bsontype.String},
+			{"array without date", "createdAt", bson.D{{"createdAt", bson.A{"x"}}}, true, bsontype.Array},
+			{"embedded string", "meta.createdAt", bson.D{{"meta", bson.D{{"createdAt", "yesterday"}}}}, true, bsontype.String},
+		}
+		for _, tc := range testCases {
+			t.Run(tc.name, func(t *testing.T) {
+				doc, err := bson.Marshal(tc.doc)
+				assert.Nil(t, err, "Marshal error: %v", err)
+
+				got, invalid := invalidTTLFieldType(doc, tc.field)
+				assert.Equal(t, tc.invalid, invalid, "expected invalid %v, got %v", tc.invalid, invalid)
+				assert.Equal(t, tc.t, got, "expected type %v, got %v", tc.t, got)
+			})
+		}
+	})
+	t.Run("TTL field handler", func(t *testing.T) {
+		var reported []error
+		coll := setupColl("ttl", options.Collection().SetTTLPolicy("createdAt", time.Hour))
+		valid, err := bson.Marshal(bson.D{{"_id", 1}, {"createdAt", time.Now()}})
+		assert.Nil(t, err, "Marshal error: %v", err)
+		invalid, err := bson.Marshal(bson.D{{"_id", 2}, {"createdAt", "yesterday"}})
+		assert.Nil(t, err, "Marshal error: %v", err)
+		docs := []bsoncore.Document{valid, invalid}
+
+		coll.checkTTLFields(docs)
+		coll, err = coll.Clone(options.Collection().SetTTLFieldHandler(func(err error) {
+			reported = append(reported, err)
+		}))
+		assert.Nil(t, err, "Clone error: %v", err)
+		coll.checkTTLFields(docs)
+
+		assert.Equal(t, 1, len(reported), "expected 1 reported document, got %v", len(reported))
+		fieldErr, ok := reported[0].(TTLFieldError)
+		assert.True(t, ok, "expected TTLFieldError, got %v", reported[0])
+		assert.Equal(t, int32(2), fieldErr.DocumentID.Int32(), "expected _id 2, got %v", fieldErr.DocumentID)
+		assert.Equal(t, bsontype.String, fieldErr.Type, "expected type string, got %v", fieldErr.Type)
+		assert.Equal(t, "createdAt", fieldErr.Field, "expected field createdAt, got %v", fieldErr.Field)
+	})
+}
@@ -0,0 +1,204 @@
+// Copyright (C) MongoDB, Inc. 2021-present.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
+
+package mongo
+
+import (
+	"context"
+	"sync"
+
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// UnitOfWork collects the writes of a business operation across several collections, along with the domain events
+// that the operation raises, and applies them together when Commit is called. This replaces the session and
+// transaction handling that services otherwise write for each operation:
+//
+//	uow := client.NewUnitOfWork(options.UnitOfWork().SetAfterCommit(publish))
+//	uow.Register(orders, mongo.NewInsertOneModel().SetDocument(order))
+//	uow.Register(inventory, mongo.NewUpdateOneModel().
+//		SetFilter(bson.D{{"sku", order.SKU}}).
+//		SetUpdate(bson.D{{"$inc", bson.D{{"stock", -order.Quantity}}}}))
+//	uow.Raise(OrderPlaced{ID: order.ID})
+//	_, err := uow.Commit(ctx)
+//
+// The writes are committed in a single transaction if the deployment supports transactions (see
+// ServerFeatures.SupportsTransactions). Otherwise, e.g. on a standalone server, the writes of each collection are sent
+// as an ordered bulk write, so the writes are not atomic and a failure can leave the writes of the previous
+// collections applied.
+//
+// A UnitOfWork is safe for concurrent use.
+type UnitOfWork struct {
+	client *Client
+	opts   *options.UnitOfWorkOptions
+
+	mu     sync.Mutex
+	writes []*pendingWrites
+	events []interface{}
+}
+
+// pendingWrites is the writes registered in a unit of work for a namespace.
+type pendingWrites struct {
+	ns     string
+	coll   *Collection
+	models []WriteModel
+}
+
+// UnitOfWorkResult is the result of UnitOfWork.Commit.
+type UnitOfWorkResult struct {
+	// The results of the bulk writes sent for each namespace, keyed by namespace in the form
+	// "<database>.<collection>". If Commit returns an error, this only contains the namespaces whose writes succeeded.
+	Results map[string]*BulkWriteResult
+
+	// Whether the writes were committed in a transaction.
+	Transactional bool
+}
+
+// NewUnitOfWork creates an empty UnitOfWork whose writes are sent with the client. The opts parameter can be used to
+// specify the transaction options and the hooks called on commit (see the options.UnitOfWorkOptions documentation).
+func (c *Client) NewUnitOfWork(opts ...*options.UnitOfWorkOptions) *UnitOfWork {
+	return &UnitOfWork{
+		client: c,
+		opts:   options.MergeUnitOfWorkOptions(opts...),
+	}
+}
+
+// Register adds models to the writes of coll. The writes of a namespace are sent in the order in which they were
+// registered, using the collection with which the first write of the namespace was registered.
+func (u *UnitOfWork) Register(coll *Collection, models ...WriteModel) {
+	if len(models) == 0 {
+		return
+	}
+	ns := coll.db.name + "." + coll.name
+
+	u.mu.Lock()
+	defer u.mu.Unlock()
+
+	for _, w := range u.writes {
+		if w.ns == ns {
+			w.models = append(w.models, models...)
+			return
+		}
+	}
+	u.writes = append(u.writes, &pendingWrites{ns: ns, coll: coll, models: models})
+}
+
+// Raise records domain events that are passed to the hooks when the unit of work is committed.
+func (u *UnitOfWork) Raise(events ...interface{}) {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+
+	u.events = append(u.events, events...)
+}
+
+// Pending returns the number of writes that have been registered and not yet committed or discarded.
+func (u *UnitOfWork) Pending() int {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+
+	var n int
+	for _, w := range u.writes {
+		n += len(w.models)
+	}
+	return n
+}
+
+// Discard removes the registered writes and the raised events without sending them.
+func (u *UnitOfWork) Discard() {
+	u.take()
+}
+
+// Commit sends the registered writes and calls the hooks. The unit of work is emptied when Commit is called, whether
+// or not it succeeds, so it can be reused for the next operation. If there are no writes and no events, Commit does
+// nothing.
+//
+// In a transaction, the BeforeCommit hook is called after the writes have been sent, and the transaction is retried
+// as described in the Session.WithTransaction documentation. Without transactions, the BeforeCommit hook is called
+// after the writes have been applied. The AfterCommit hook is only called if the writes and the BeforeCommit hook
+// succeed.
+func (u *UnitOfWork) Commit(ctx context.Context) (*UnitOfWorkResult, error) {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	writes, events := u.take()
+	res := &UnitOfWorkResult{Results: make(map[string]*BulkWriteResult, len(writes))}
+	if len(writes) == 0 && len(events) == 0 {
+		return res, nil
+	}
+
+	features, err := u.client.ServerFeatures(ctx)
+	if err != nil {
+		return res, err
+	}
+	if features.SupportsTransactions {
+		res.Transactional = true
+		err = u.commitTransaction(ctx, writes, events, res)
+	} else {
+		err = u.apply(ctx, writes, events, res)
+	}
+	if err != nil {
+		return res, err
+	}
+
+	if u.opts.AfterCommit != nil {
+		if err = u.opts.AfterCommit(ctx, events); err != nil {
+			return res, err
+		}
+	}
+	return res, nil
+}
+
+// commitTransaction applies writes and calls the BeforeCommit hook in a transaction.
+func (u *UnitOfWork) commitTransaction(ctx context.Context, writes []*pendingWrites, events []interface{},
+	res *UnitOfWorkResult) error {
+
+	sess, err := u.client.StartSession()
+	if err != nil {
+		return err
+	}
+	defer sess.EndSession(ctx)
+
+	_, err = sess.WithTransaction(ctx, func(sc SessionContext) (interface{}, error) {
+		// The results of an aborted attempt are discarded because none of its writes were committed.
+		for ns := range res.Results {
+			delete(res.Results, ns)
+		}
+		return nil, u.apply(sc, writes, events, res)
+	}, u.opts.TransactionOptions)
+	if err != nil {
+		// Nothing is committed if the transaction fails, so the results of the writes are not reported.
+		res.Results = make(map[string]*BulkWriteResult)
+	}
+	return err
+}
+
+// apply sends the writes of each namespace as an ordered bulk write and calls the BeforeCommit hook.
+func (u *UnitOfWork) apply(ctx context.Context, writes []*pendingWrites, events []interface{},
+	res *UnitOfWorkResult) error {
+
+	for _, w := range writes {
+		bwr, err := w.coll.BulkWrite(ctx, w.models)
+		if err != nil {
+			return err
+		}
+		res.Results[w.ns] = bwr
+	}
+	if u.opts.BeforeCommit != nil {
+		return u.opts.BeforeCommit(ctx, events)
+	}
+	return nil
+}
+
+// take empties the unit of work and returns its writes and events.
+func (u *UnitOfWork) take() ([]*pendingWrites, []interface{}) {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+
+	writes, events := u.writes, u.events
+	u.writes, u.events = nil, nil
+	return writes, events
+}
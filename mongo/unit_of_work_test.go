@@ -0,0 +1,145 @@
+// Copyright (C) MongoDB, Inc. 2021-present.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
+
+package mongo_test
+
+import (
+	"context"
+	"testing"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/internal/testutil/assert"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/mongotest"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+func TestUnitOfWork(t *testing.T) {
+	type orderPlaced struct {
+		ID int
+	}
+	newClient := func(t *testing.T) (*mongotest.Deployment, *mongo.Client) {
+		d := mongotest.New()
+		client, err := d.NewClient()
+		assert.Nil(t, err, "NewClient error: %v", err)
+		return d, client
+	}
+	commandNames := func(cmds []mongotest.Command) []string {
+		names := make([]string, 0, len(cmds))
+		for _, cmd := range cmds {
+			names = append(names, cmd.Name)
+		}
+		return names
+	}
+
+	t.Run("commit", func(t *testing.T) {
+		d, client := newClient(t)
+		defer func() { _ = client.Disconnect(context.Background()) }()
+		d.AddReplies(
+			mongotest.SuccessReply(bson.E{"n", 2}),
+			mongotest.SuccessReply(bson.E{"n", 1}, bson.E{"nModified", 1}),
+			mongotest.SuccessReply(bson.E{"n", 1}),
+			mongotest.SuccessReply(),
+		)
+
+		db := client.Database("shop")
+		var afterEvents []interface{}
+		uow := client.NewUnitOfWork(options.UnitOfWork().
+			SetBeforeCommit(func(ctx context.Context, events []interface{}) error {
+				_, err := db.Collection("outbox").InsertOne(ctx, bson.D{{"events", len(events)}})
+				return err
+			}).
+			SetAfterCommit(func(_ context.Context, events []interface{}) error {
+				afterEvents = events
+				return nil
+			}))
+		uow.Register(db.Collection("orders"), mongo.NewInsertOneModel().SetDocument(bson.D{{"_id", 1}}))
+		uow.Register(db.Collection("inventory"), mongo.NewUpdateOneModel().
+			SetFilter(bson.D{{"sku", "a"}}).
+			SetUpdate(bson.D{{"$inc", bson.D{{"stock", -1}}}}))
+		uow.Register(db.Collection("orders"), mongo.NewInsertOneModel().SetDocument(bson.D{{"_id", 2}}))
+		uow.Raise(orderPlaced{ID: 1}, orderPlaced{ID: 2})
+		assert.Equal(t, 3, uow.Pending(), "expected 3 pending writes, got %v", uow.Pending())
+
+		res, err := uow.Commit(context.Background())
+		assert.Nil(t, err, "Commit error: %v", err)
+		assert.True(t, res.Transactional, "expected the writes to be committed in a transaction")
+		assert.Equal(t, int64(2), res.Results["shop.orders"].InsertedCount, "expected 2 inserted orders, got %v",
+			res.Results["shop.orders"].InsertedCount)
+		assert.Equal(t, int64(1), res.Results["shop.inventory"].ModifiedCount, "expected 1 modified item, got %v",
+			res.Results["shop.inventory"].ModifiedCount)
+		assert.Equal(t, []interface{}{orderPlaced{ID: 1}, orderPlaced{ID: 2}}, afterEvents,
+			"expected events passed to AfterCommit, got %v", afterEvents)
+		assert.Equal(t, 0, uow.Pending(), "expected no pending writes after commit, got %v", uow.Pending())
+
+		cmds := d.Commands()
+		expected := []string{"insert", "update", "insert", "commitTransaction"}
+		assert.Equal(t, expected, commandNames(cmds), "expected commands %v, got %v", expected, commandNames(cmds))
+		assert.Equal(t, "orders", cmds[0].Document.Lookup("insert").StringValue(), "expected insert into orders, got %v",
+			cmds[0].Document)
+		docs := cmds[0].Document.Lookup("documents").Array()
+		values, err := docs.Values()
+		assert.Nil(t, err, "Values error: %v", err)
+		assert.Equal(t, 2, len(values), "expected both orders in one insert, got %v", docs)
+		started := cmds[0].Document.Lookup("startTransaction").Boolean()
+		assert.True(t, started, "expected the first write to start the transaction, got %v", cmds[0].Document)
+		assert.Equal(t, "outbox", cmds[2].Document.Lookup("insert").StringValue(),
+			"expected BeforeCommit insert into outbox, got %v", cmds[2].Document)
+		for _, cmd := range cmds {
+			autocommit, ok := cmd.Document.Lookup("autocommit").BooleanOK()
+			assert.True(t, ok && !autocommit, "expected %v to be part of the transaction, got %v", cmd.Name,
+				cmd.Document)
+		}
+	})
+	t.Run("write error aborts", func(t *testing.T) {
+		d, client := newClient(t)
+		defer func() { _ = client.Disconnect(context.Background()) }()
+		d.AddReplies(
+			mongotest.SuccessReply(bson.E{"n", 1}),
+			mongotest.ErrorReply(2, "bad update"),
+			mongotest.SuccessReply(),
+		)
+
+		var afterCalled bool
+		uow := client.NewUnitOfWork(options.UnitOfWork().
+			SetAfterCommit(func(context.Context, []interface{}) error {
+				afterCalled = true
+				return nil
+			}))
+		db := client.Database("shop")
+		uow.Register(db.Collection("orders"), mongo.NewInsertOneModel().SetDocument(bson.D{{"_id", 1}}))
+		uow.Register(db.Collection("inventory"), mongo.NewUpdateOneModel().
+			SetFilter(bson.D{{"sku", "a"}}).
+			SetUpdate(bson.D{{"$inc", bson.D{{"stock", -1}}}}))
+
+		res, err := uow.Commit(context.Background())
+		assert.NotNil(t, err, "expected Commit error, got nil")
+		assert.Equal(t, 0, len(res.Results), "expected no results for an aborted transaction, got %v", res.Results)
+		assert.False(t, afterCalled, "expected AfterCommit not to be called")
+
+		expected := []string{"insert", "update", "abortTransaction"}
+		names := commandNames(d.Commands())
+		assert.Equal(t, expected, names, "expected commands %v, got %v", expected, names)
+	})
+	t.Run("empty", func(t *testing.T) {
+		d, client := newClient(t)
+		defer func() { _ = client.Disconnect(context.Background()) }()
+
+		res, err := client.NewUnitOfWork().Commit(context.Background())
+		assert.Nil(t, err, "Commit error: %v", err)
+		assert.Equal(t, 0, len(res.Results), "expected no results, got %v", res.Results)
+		assert.Equal(t, 0, len(d.Commands()), "expected no commands, got %v", len(d.Commands()))
+	})
+	t.Run("discard", func(t *testing.T) {
+		_, client := newClient(t)
+		defer func() { _ = client.Disconnect(context.Background()) }()
+
+		uow := client.NewUnitOfWork()
+		uow.Register(client.Database("shop").Collection("orders"), mongo.NewDeleteOneModel().SetFilter(bson.D{}))
+		uow.Discard()
+		assert.Equal(t, 0, uow.Pending(), "expected no pending writes after discard, got %v", uow.Pending())
+	})
+}
@@ -0,0 +1,159 @@
+// Copyright (C) MongoDB, Inc. 2021-present.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
+
+package mongo
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/bsontype"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"go.mongodb.org/mongo-driver/x/bsonx/bsoncore"
+)
+
+// NewUpdateFromDiff creates an UpdateOneModel that changes oldDoc into newDoc, which can be used to mirror changes made
+// to a document outside of the database. Both documents are marshalled with the registry in the options and must not
+// be nil. The filter of the model matches the _id of newDoc, which is required. If oldDoc has an _id, it must be equal
+// to the _id of newDoc.
+//
+// The update contains a $set for each field of newDoc that is not in oldDoc or has a different value, and an $unset for
+// each field of oldDoc that is not in newDoc unless the KeepMissingFields option is set. Embedded documents that exist
+// in both documents are compared field by field so only the changed fields are updated, e.g. {$set: {"a.b": 1}}.
+// Arrays and values of different BSON types are always replaced as a whole, so 1 and int64(1) differ.
+//
+// If the documents do not differ, a nil model and a nil error are returned. An error is returned if a changed
+// top-level field name is empty, starts with "$", or contains ".", because such fields cannot be updated with $set or
+// $unset.
+func NewUpdateFromDiff(oldDoc, newDoc interface{}, opts ...*options.UpdateDiffOptions) (*UpdateOneModel, error) {
+	udOpts := options.MergeUpdateDiffOptions(opts...)
+
+	oldRaw, err := transformBsoncoreDocument(udOpts.Registry, oldDoc, true, "oldDoc")
+	if err != nil {
+		return nil, err
+	}
+	newRaw, err := transformBsoncoreDocument(udOpts.Registry, newDoc, true, "newDoc")
+	if err != nil {
+		return nil, err
+	}
+
+	id, err := newRaw.LookupErr("_id")
+	if err != nil {
+		return nil, errors.New("the new document must have an _id field")
+	}
+	if oldID, err := oldRaw.LookupErr("_id"); err == nil && !oldID.Equal(id) {
+		return nil, errors.New("the _id of the old document must be equal to the _id of the new document")
+	}
+
+	d := documentDiff{keepMissing: udOpts.KeepMissingFields != nil && *udOpts.KeepMissingFields}
+	if err = d.diff("", oldRaw, newRaw); err != nil {
+		return nil, err
+	}
+	if len(d.set) == 0 && len(d.unset) == 0 {
+		return nil, nil
+	}
+
+	update := bsoncore.NewDocumentBuilder()
+	if len(d.set) > 0 {
+		update.AppendDocument("$set", bsoncore.BuildDocument(nil, d.set...))
+	}
+	if len(d.unset) > 0 {
+		update.AppendDocument("$unset", bsoncore.BuildDocument(nil, d.unset...))
+	}
+	filter := bsoncore.BuildDocument(nil, bsoncore.AppendValueElement(nil, "_id", id))
+
+	model := NewUpdateOneModel().SetFilter(bson.Raw(filter)).SetUpdate(bson.Raw(update.Build()))
+	if udOpts.Upsert != nil {
+		model.SetUpsert(*udOpts.Upsert)
+	}
+	return model, nil
+}
+
+// documentDiff collects the $set and $unset elements needed to change one document into another.
+type documentDiff struct {
+	keepMissing bool
+	set         [][]byte
+	unset       [][]byte
+}
+
+// diff compares the fields of oldDoc and newDoc, which are embedded at the dotted path prefix. The prefix is empty for
+// the top-level documents.
+func (d *documentDiff) diff(prefix string, oldDoc, newDoc bsoncore.Document) error {
+	newElems, err := newDoc.Elements()
+	if err != nil {
+		return err
+	}
+	for _, elem := range newElems {
+		key := elem.Key()
+		if prefix == "" && key == "_id" {
+			continue
+		}
+
+		newVal := elem.Value()
+		oldVal, err := oldDoc.LookupErr(key)
+		if err == nil && oldVal.Equal(newVal) {
+			continue
+		}
+		if !isUpdatablePath(key) {
+			return newFieldNotUpdatableError(key)
+		}
+
+		path := prefix + key
+		if err == nil && oldVal.Type == bsontype.EmbeddedDocument && newVal.Type == bsontype.EmbeddedDocument &&
+			hasUpdatableKeys(oldVal.Document()) && hasUpdatableKeys(newVal.Document()) {
+			if err = d.diff(path+".", oldVal.Document(), newVal.Document()); err != nil {
+				return err
+			}
+			continue
+		}
+		d.set = append(d.set, bsoncore.AppendValueElement(nil, path, newVal))
+	}
+
+	if d.keepMissing {
+		return nil
+	}
+	oldElems, err := oldDoc.Elements()
+	if err != nil {
+		return err
+	}
+	for _, elem := range oldElems {
+		key := elem.Key()
+		if _, err = newDoc.LookupErr(key); err == nil {
+			continue
+		}
+		if !isUpdatablePath(key) {
+			return newFieldNotUpdatableError(key)
+		}
+		d.unset = append(d.unset, bsoncore.AppendStringElement(nil, prefix+key, ""))
+	}
+	return nil
+}
+
+// isUpdatablePath returns true if key can be used as a component of a dotted update path.
+func isUpdatablePath(key string) bool {
+	return key != "" && !strings.HasPrefix(key, "$") && !strings.Contains(key, ".")
+}
+
+func newFieldNotUpdatableError(key string) error {
+	return fmt.Errorf("cannot create an update for field %q: field names in update paths must not be empty, start with '$', or contain '.'", key)
+}
+
+// hasUpdatableKeys returns true if all keys of doc can be used in dotted update paths. Embedded documents with other
+// keys are replaced as a whole instead of being compared field by field.
+func hasUpdatableKeys(doc bsoncore.Document) bool {
+	elems, err := doc.Elements()
+	if err != nil {
+		return false
+	}
+	for _, elem := range elems {
+		if !isUpdatablePath(elem.Key()) {
+			return false
+		}
+	}
+	return true
+}
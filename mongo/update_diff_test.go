@@ -0,0 +1,152 @@
+// Copyright (C) MongoDB, Inc. 2021-present.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
+
+package mongo
+
+import (
+	"testing"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/internal/testutil/assert"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+func TestNewUpdateFromDiff(t *testing.T) {
+	type address struct {
+		City string `bson:"city"`
+		Zip  string `bson:"zip"`
+	}
+	type person struct {
+		ID      int      `bson:"_id"`
+		Name    string   `bson:"name"`
+		Age     int      `bson:"age,omitempty"`
+		Address address  `bson:"address"`
+		Tags    []string `bson:"tags"`
+	}
+
+	t.Run("updates", func(t *testing.T) {
+		testCases := []struct {
+			name     string
+			oldDoc   interface{}
+			newDoc   interface{}
+			opts     *options.UpdateDiffOptions
+			expected bson.D
+		}{
+			{
+				"changed fields",
+				person{ID: 1, Name: "foo", Age: 30, Tags: []string{"a"}},
+				person{ID: 1, Name: "bar", Age: 31, Tags: []string{"a"}},
+				nil,
+				bson.D{{"$set", bson.D{{"name", "bar"}, {"age", int32(31)}}}},
+			},
+			{
+				"embedded document fields",
+				person{ID: 1, Name: "foo", Address: address{City: "NYC", Zip: "10001"}},
+				person{ID: 1, Name: "foo", Address: address{City: "NYC", Zip: "10002"}},
+				nil,
+				bson.D{{"$set", bson.D{{"address.zip", "10002"}}}},
+			},
+			{
+				"arrays are replaced",
+				person{ID: 1, Tags: []string{"a", "b"}},
+				person{ID: 1, Tags: []string{"a", "c"}},
+				nil,
+				bson.D{{"$set", bson.D{{"tags", bson.A{"a", "c"}}}}},
+			},
+			{
+				"removed fields",
+				person{ID: 1, Name: "foo", Age: 30},
+				person{ID: 1, Name: "foo"},
+				nil,
+				bson.D{{"$unset", bson.D{{"age", ""}}}},
+			},
+			{
+				"removed fields are kept",
+				person{ID: 1, Name: "foo", Age: 30},
+				person{ID: 1, Name: "bar"},
+				options.UpdateDiff().SetKeepMissingFields(true),
+				bson.D{{"$set", bson.D{{"name", "bar"}}}},
+			},
+			{
+				"set and unset",
+				bson.D{{"_id", 1}, {"a", bson.D{{"b", 1}, {"c", 2}}}},
+				bson.D{{"_id", 1}, {"a", bson.D{{"b", 2}}}, {"d", true}},
+				nil,
+				bson.D{{"$set", bson.D{{"a.b", int32(2)}, {"d", true}}}, {"$unset", bson.D{{"a.c", ""}}}},
+			},
+			{
+				"changed type",
+				bson.D{{"_id", 1}, {"a", bson.D{{"b", 1}}}},
+				bson.D{{"_id", 1}, {"a", int32(1)}},
+				nil,
+				bson.D{{"$set", bson.D{{"a", int32(1)}}}},
+			},
+			{
+				"embedded document with dotted keys is replaced",
+				bson.D{{"_id", 1}, {"a", bson.D{{"b.c", 1}}}},
+				bson.D{{"_id", 1}, {"a", bson.D{{"b.c", 2}}}},
+				nil,
+				bson.D{{"$set", bson.D{{"a", bson.D{{"b.c", int32(2)}}}}}},
+			},
+			{
+				"old document without _id",
+				bson.D{{"a", 1}},
+				bson.D{{"_id", 1}, {"a", 2}},
+				nil,
+				bson.D{{"$set", bson.D{{"a", int32(2)}}}},
+			},
+		}
+		for _, tc := range testCases {
+			t.Run(tc.name, func(t *testing.T) {
+				model, err := NewUpdateFromDiff(tc.oldDoc, tc.newDoc, tc.opts)
+				assert.Nil(t, err, "NewUpdateFromDiff error: %v", err)
+				assert.NotNil(t, model, "expected model, got nil")
+
+				expected, err := bson.Marshal(tc.expected)
+				assert.Nil(t, err, "Marshal error: %v", err)
+				assert.Equal(t, bson.Raw(expected), model.Update, "expected update %v, got %v", bson.Raw(expected), model.Update)
+
+				expectedFilter, err := bson.Marshal(bson.D{{"_id", int32(1)}})
+				assert.Nil(t, err, "Marshal error: %v", err)
+				assert.Equal(t, bson.Raw(expectedFilter), model.Filter,
+					"expected filter %v, got %v", bson.Raw(expectedFilter), model.Filter)
+				assert.Nil(t, model.Upsert, "expected upsert to not be set, got %v", model.Upsert)
+			})
+		}
+	})
+	t.Run("no changes", func(t *testing.T) {
+		doc := person{ID: 1, Name: "foo", Tags: []string{"a"}}
+		model, err := NewUpdateFromDiff(doc, doc)
+		assert.Nil(t, err, "NewUpdateFromDiff error: %v", err)
+		assert.Nil(t, model, "expected nil model, got %v", model)
+	})
+	t.Run("upsert", func(t *testing.T) {
+		model, err := NewUpdateFromDiff(bson.D{}, bson.D{{"_id", 1}, {"a", 1}}, options.UpdateDiff().SetUpsert(true))
+		assert.Nil(t, err, "NewUpdateFromDiff error: %v", err)
+		assert.NotNil(t, model.Upsert, "expected upsert to be set, got nil")
+		assert.True(t, *model.Upsert, "expected upsert to be true, got false")
+	})
+	t.Run("errors", func(t *testing.T) {
+		testCases := []struct {
+			name   string
+			oldDoc interface{}
+			newDoc interface{}
+		}{
+			{"nil old document", nil, bson.D{{"_id", 1}}},
+			{"nil new document", bson.D{{"_id", 1}}, nil},
+			{"missing _id", bson.D{{"a", 1}}, bson.D{{"a", 2}}},
+			{"different _id", bson.D{{"_id", 1}}, bson.D{{"_id", 2}}},
+			{"dollar key", bson.D{{"_id", 1}}, bson.D{{"_id", 1}, {"$a", 1}}},
+			{"dotted key", bson.D{{"_id", 1}, {"a.b", 1}}, bson.D{{"_id", 1}}},
+		}
+		for _, tc := range testCases {
+			t.Run(tc.name, func(t *testing.T) {
+				_, err := NewUpdateFromDiff(tc.oldDoc, tc.newDoc)
+				assert.NotNil(t, err, "expected NewUpdateFromDiff error, got nil")
+			})
+		}
+	})
+}
@@ -0,0 +1,146 @@
+// Copyright (C) MongoDB, Inc. 2021-present.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
+
+package mongo
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"go.mongodb.org/mongo-driver/mongo/readpref"
+	"go.mongodb.org/mongo-driver/x/bsonx/bsoncore"
+)
+
+// WriteCorrelation identifies the documents inserted by Collection.InsertManyCorrelated with a correlation ID, so
+// that their delivery can be verified by querying for them even if the write was unacknowledged.
+type WriteCorrelation struct {
+	// The field that holds the correlation ID in the inserted documents.
+	Field string
+
+	// The correlation ID, which is the same for all of the documents of the write.
+	ID primitive.ObjectID
+
+	// The number of documents that were sent to the server.
+	Count int64
+
+	coll *Collection
+}
+
+// DeliveryResult is the outcome of WriteCorrelation.Await.
+type DeliveryResult struct {
+	// The number of documents of the write that were found.
+	Delivered int64
+
+	// The error that stopped the check, or nil if all of the documents were found.
+	Err error
+}
+
+// InsertManyCorrelated inserts documents like InsertMany after adding field to each of them with a correlation ID
+// generated by the client, and returns a WriteCorrelation that can be used to check later which of the documents were
+// actually inserted. This allows best-effort delivery verification of fire-and-forget writes, such as telemetry sent
+// with an unacknowledged write concern (w:0), without giving up the latency of unacknowledged writes. The
+// ErrUnacknowledgedWrite error is not returned for unacknowledged writes.
+//
+// The documents must not already have field, which must be a top-level field name: it must not be empty, start with a
+// $, or contain a dot. The checks query the collection for the correlation ID, so there should be an index on field to
+// keep them cheap.
+//
+// The opts parameter can be used to specify options for the insert (see the options.InsertManyOptions documentation).
+func (coll *Collection) InsertManyCorrelated(ctx context.Context, documents []interface{}, field string,
+	opts ...*options.InsertManyOptions) (*WriteCorrelation, error) {
+
+	if field == "" || strings.HasPrefix(field, "$") || strings.Contains(field, ".") {
+		return nil, fmt.Errorf("invalid correlation field %q", field)
+	}
+	if len(documents) == 0 {
+		return nil, ErrEmptySlice
+	}
+
+	wc := &WriteCorrelation{Field: field, ID: primitive.NewObjectID(), Count: int64(len(documents)), coll: coll}
+	correlated := make([]interface{}, len(documents))
+	for i, document := range documents {
+		doc, err := transformBsoncoreDocument(coll.registry, document, true, "document")
+		if err != nil {
+			return nil, err
+		}
+		if _, err := doc.LookupErr(field); err == nil {
+			return nil, fmt.Errorf("document at index %d already has the correlation field %q", i, field)
+		}
+
+		idx, dst := bsoncore.AppendDocumentStart(nil)
+		dst = append(dst, doc[4:len(doc)-1]...)
+		dst = bsoncore.AppendObjectIDElement(dst, field, wc.ID)
+		dst, _ = bsoncore.AppendDocumentEnd(dst, idx)
+		correlated[i] = bson.Raw(dst)
+	}
+
+	_, err := coll.InsertMany(ctx, correlated, opts...)
+	if err == ErrUnacknowledgedWrite {
+		err = nil
+	}
+	return wc, err
+}
+
+// Check returns the number of documents of the write that are in the collection. The query is run on the primary so
+// that documents written by the unacknowledged write are visible as soon as the primary has applied them. Documents
+// that are deleted or lose the correlation field after being inserted are not counted.
+func (wc *WriteCorrelation) Check(ctx context.Context) (int64, error) {
+	coll, err := wc.coll.Clone(options.Collection().SetReadPreference(readpref.Primary()))
+	if err != nil {
+		return 0, err
+	}
+	return coll.CountDocuments(ctx, bson.D{{wc.Field, wc.ID}})
+}
+
+// Await checks the delivery of the write in the background, every interval until all of the documents are found, ctx
+// expires, or a check fails with an error other than a network error or a timeout, and sends the outcome on the
+// returned channel, which is then closed. If the checks stop before all of the documents are found, the result has the
+// number of documents found by the last successful check and the error. An interval that is not positive is replaced
+// by one second.
+func (wc *WriteCorrelation) Await(ctx context.Context, interval time.Duration) <-chan DeliveryResult {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	if interval <= 0 {
+		interval = time.Second
+	}
+
+	results := make(chan DeliveryResult, 1)
+	go func() {
+		defer close(results)
+
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		var delivered int64
+		for {
+			n, err := wc.Check(ctx)
+			switch {
+			case err == nil:
+				delivered = n
+				if delivered >= wc.Count {
+					results <- DeliveryResult{Delivered: delivered}
+					return
+				}
+			case !IsNetworkError(err) && !IsTimeout(err):
+				// Only network errors and timeouts are worth retrying at the next check.
+				results <- DeliveryResult{Delivered: delivered, Err: err}
+				return
+			}
+			select {
+			case <-ticker.C:
+			case <-ctx.Done():
+				results <- DeliveryResult{Delivered: delivered, Err: ctx.Err()}
+				return
+			}
+		}
+	}()
+	return results
+}
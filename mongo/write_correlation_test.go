@@ -0,0 +1,87 @@
+// Copyright (C) MongoDB, Inc. 2021-present.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
+
+package mongo_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/internal/testutil/assert"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/mongotest"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"go.mongodb.org/mongo-driver/mongo/writeconcern"
+)
+
+func TestInsertManyCorrelated(t *testing.T) {
+	d := mongotest.New()
+	client, err := d.NewClient()
+	assert.Nil(t, err, "NewClient error: %v", err)
+	defer func() { _ = client.Disconnect(context.Background()) }()
+	coll := client.Database("db").Collection("metrics",
+		options.Collection().SetWriteConcern(writeconcern.New(writeconcern.W(0))))
+	docs := []interface{}{bson.D{{"v", 1}}, bson.D{{"v", 2}}}
+	countReply := func(n int32) bson.D {
+		return mongotest.CursorReply("db.metrics", bson.D{{"_id", 1}, {"n", n}})
+	}
+
+	t.Run("injects correlation id", func(t *testing.T) {
+		d.Reset()
+		wc, err := coll.InsertManyCorrelated(context.Background(), docs, "batch")
+		assert.Nil(t, err, "InsertManyCorrelated error: %v", err)
+		assert.Equal(t, int64(2), wc.Count, "expected count 2, got %v", wc.Count)
+
+		cmds := d.Commands()
+		assert.Equal(t, 1, len(cmds), "expected 1 command, got %v", len(cmds))
+		inserted, err := cmds[0].Document.Lookup("documents").Array().Values()
+		assert.Nil(t, err, "Values error: %v", err)
+		for _, doc := range inserted {
+			id := doc.Document().Lookup("batch").ObjectID()
+			assert.Equal(t, wc.ID, id, "expected correlation id %v, got %v", wc.ID, id)
+		}
+
+		d.AddReplies(countReply(1))
+		delivered, err := wc.Check(context.Background())
+		assert.Nil(t, err, "Check error: %v", err)
+		assert.Equal(t, int64(1), delivered, "expected 1 document delivered, got %v", delivered)
+		pipeline := d.Commands()[1].Document.Lookup("pipeline").Array().Index(0).Value().Document()
+		match := pipeline.Lookup("$match").Document()
+		assert.Equal(t, wc.ID, match.Lookup("batch").ObjectID(), "expected match on correlation id, got %v", match)
+	})
+	t.Run("await", func(t *testing.T) {
+		d.Reset()
+		wc, err := coll.InsertManyCorrelated(context.Background(), docs, "batch")
+		assert.Nil(t, err, "InsertManyCorrelated error: %v", err)
+
+		d.AddReplies(countReply(1), countReply(2))
+		res := <-wc.Await(context.Background(), time.Millisecond)
+		assert.Nil(t, res.Err, "Await error: %v", res.Err)
+		assert.Equal(t, int64(2), res.Delivered, "expected 2 documents delivered, got %v", res.Delivered)
+	})
+	t.Run("await stops on command errors", func(t *testing.T) {
+		d.Reset()
+		wc, err := coll.InsertManyCorrelated(context.Background(), docs, "batch")
+		assert.Nil(t, err, "InsertManyCorrelated error: %v", err)
+
+		d.AddReplies(countReply(1), mongotest.ErrorReply(13, "unauthorized"))
+		res := <-wc.Await(context.Background(), time.Millisecond)
+		assert.NotNil(t, res.Err, "expected error, got nil")
+		assert.Equal(t, int64(1), res.Delivered, "expected 1 document delivered, got %v", res.Delivered)
+	})
+	t.Run("invalid arguments", func(t *testing.T) {
+		_, err := coll.InsertManyCorrelated(context.Background(), docs, "$batch")
+		assert.NotNil(t, err, "expected error for invalid field, got nil")
+		_, err = coll.InsertManyCorrelated(context.Background(), docs, "meta.batch")
+		assert.NotNil(t, err, "expected error for dotted field, got nil")
+		_, err = coll.InsertManyCorrelated(context.Background(), []interface{}{bson.D{{"batch", 1}}}, "batch")
+		assert.NotNil(t, err, "expected error for existing field, got nil")
+		_, err = coll.InsertManyCorrelated(context.Background(), nil, "batch")
+		assert.Equal(t, mongo.ErrEmptySlice, err, "expected error %v, got %v", mongo.ErrEmptySlice, err)
+	})
+}
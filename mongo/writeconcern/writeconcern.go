@@ -8,6 +8,11 @@ package writeconcern // import "go.mongodb.org/mongo-driver/mongo/writeconcern"
 
 import (
 	"errors"
+	"fmt"
+	"math"
+	"net/url"
+	"strconv"
+	"strings"
 	"time"
 
 	"go.mongodb.org/mongo-driver/bson"
@@ -127,6 +132,89 @@ func (wc *WriteConcern) MarshalBSONValue() (bsontype.Type, []byte, error) {
 	return bsontype.EmbeddedDocument, bsoncore.BuildDocument(nil, elems), nil
 }
 
+// UnmarshalBSONValue implements the bson.ValueUnmarshaler interface. The value must be a document with the fields used
+// in commands ("w", "j", and "wtimeout"). The "journal" and "wtimeoutMS" fields used by connection strings and the
+// specification tests are accepted as aliases for "j" and "wtimeout". A BSON null is decoded to an empty WriteConcern.
+func (wc *WriteConcern) UnmarshalBSONValue(t bsontype.Type, data []byte) error {
+	if t == bsontype.Null {
+		*wc = WriteConcern{}
+		return nil
+	}
+	doc, ok := bsoncore.Value{Type: t, Data: data}.DocumentOK()
+	if !ok {
+		return fmt.Errorf("cannot decode BSON %s into a WriteConcern", t)
+	}
+	elems, err := doc.Elements()
+	if err != nil {
+		return err
+	}
+
+	var decoded WriteConcern
+	for _, elem := range elems {
+		val := elem.Value()
+		switch key := elem.Key(); key {
+		case "w":
+			switch val.Type {
+			case bsontype.String:
+				decoded.w = val.StringValue()
+			case bsontype.Int32, bsontype.Int64:
+				w, ok := val.AsInt64OK()
+				if !ok || w > math.MaxInt32 {
+					return fmt.Errorf("invalid write concern `w` value %v", val)
+				}
+				if w < 0 {
+					return ErrNegativeW
+				}
+				decoded.w = int(w)
+			default:
+				return fmt.Errorf("write concern `w` field must be a string or an integer, got BSON %s", val.Type)
+			}
+		case "j", "journal":
+			j, ok := val.BooleanOK()
+			if !ok {
+				return fmt.Errorf("write concern `%s` field must be a boolean, got BSON %s", key, val.Type)
+			}
+			decoded.j = j
+		case "wtimeout", "wtimeoutMS":
+			ms, ok := val.AsInt64OK()
+			if !ok {
+				return fmt.Errorf("write concern `%s` field must be an integer, got BSON %s", key, val.Type)
+			}
+			decoded.wTimeout = time.Duration(ms) * time.Millisecond
+		default:
+			return fmt.Errorf("unrecognized write concern field %q", key)
+		}
+	}
+
+	*wc = decoded
+	return nil
+}
+
+// URIOptions returns the write concern formatted as connection string options, e.g. "w=majority&journal=true". The
+// result can be appended to the query of a connection string and parsed with options.ClientOptions.ApplyURI. It is
+// empty if no fields are set.
+func (wc *WriteConcern) URIOptions() string {
+	if wc == nil {
+		return ""
+	}
+
+	// url.Values.Encode sorts by key, so the options are built manually to keep the conventional order.
+	var opts []string
+	switch w := wc.w.(type) {
+	case int:
+		opts = append(opts, "w="+strconv.Itoa(w))
+	case string:
+		opts = append(opts, "w="+url.QueryEscape(w))
+	}
+	if wc.j {
+		opts = append(opts, "journal=true")
+	}
+	if wc.wTimeout != 0 {
+		opts = append(opts, "wtimeoutMS="+strconv.FormatInt(int64(wc.wTimeout/time.Millisecond), 10))
+	}
+	return strings.Join(opts, "&")
+}
+
 // AcknowledgedValue returns true if a BSON RawValue for a write concern represents an acknowledged write concern.
 // The element's value must be a document representing a write concern.
 func AcknowledgedValue(rawv bson.RawValue) bool {
@@ -11,7 +11,10 @@ import (
 	"time"
 
 	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/bsontype"
 	"go.mongodb.org/mongo-driver/mongo/writeconcern"
+	"go.mongodb.org/mongo-driver/x/mongo/driver/connstring"
 )
 
 func TestWriteConcernWithOptions(t *testing.T) {
@@ -42,3 +45,80 @@ func TestWriteConcernWithOptions(t *testing.T) {
 		require.Equal(t, wc.GetWTimeout(), time.Second)
 	})
 }
+
+func TestWriteConcernBSON(t *testing.T) {
+	t.Run("round trip", func(t *testing.T) {
+		testCases := []*writeconcern.WriteConcern{
+			writeconcern.New(writeconcern.W(1)),
+			writeconcern.New(writeconcern.WMajority(), writeconcern.J(true)),
+			writeconcern.New(writeconcern.WTagSet("dc1"), writeconcern.WTimeout(5*time.Second)),
+		}
+		for _, wc := range testCases {
+			doc, err := bson.Marshal(bson.M{"writeConcern": wc})
+			require.NoError(t, err)
+
+			var decoded struct {
+				WriteConcern *writeconcern.WriteConcern `bson:"writeConcern"`
+			}
+			require.NoError(t, bson.Unmarshal(doc, &decoded))
+			require.Equal(t, wc, decoded.WriteConcern)
+		}
+	})
+	t.Run("accepts URI option names", func(t *testing.T) {
+		doc, err := bson.Marshal(bson.D{{"w", int64(2)}, {"journal", true}, {"wtimeoutMS", int32(100)}})
+		require.NoError(t, err)
+
+		wc := new(writeconcern.WriteConcern)
+		require.NoError(t, wc.UnmarshalBSONValue(bsontype.EmbeddedDocument, doc))
+		require.Equal(t, writeconcern.New(writeconcern.W(2), writeconcern.J(true),
+			writeconcern.WTimeout(100*time.Millisecond)), wc)
+	})
+	t.Run("errors for invalid documents", func(t *testing.T) {
+		testCases := []bson.D{
+			{{"w", true}},
+			{{"j", "true"}},
+			{{"wtimeout", "1000"}},
+			{{"unknown", 1}},
+		}
+		for _, tc := range testCases {
+			doc, err := bson.Marshal(tc)
+			require.NoError(t, err)
+
+			wc := new(writeconcern.WriteConcern)
+			require.Error(t, wc.UnmarshalBSONValue(bsontype.EmbeddedDocument, doc), "expected error for %v", tc)
+		}
+	})
+	t.Run("errors for negative w", func(t *testing.T) {
+		doc, err := bson.Marshal(bson.D{{"w", -1}})
+		require.NoError(t, err)
+
+		wc := new(writeconcern.WriteConcern)
+		require.Equal(t, writeconcern.ErrNegativeW, wc.UnmarshalBSONValue(bsontype.EmbeddedDocument, doc))
+	})
+}
+
+func TestWriteConcernURIOptions(t *testing.T) {
+	t.Run("empty", func(t *testing.T) {
+		require.Equal(t, "", writeconcern.New().URIOptions())
+
+		var wc *writeconcern.WriteConcern
+		require.Equal(t, "", wc.URIOptions())
+	})
+	t.Run("round trip", func(t *testing.T) {
+		wc := writeconcern.New(writeconcern.WMajority(), writeconcern.J(true), writeconcern.WTimeout(time.Second))
+		uriOpts := wc.URIOptions()
+		require.Equal(t, "w=majority&journal=true&wtimeoutMS=1000", uriOpts)
+
+		cs, err := connstring.ParseAndValidate("mongodb://localhost/?" + uriOpts)
+		require.NoError(t, err)
+		require.Equal(t, "majority", cs.WString)
+		require.True(t, cs.JSet && cs.J)
+		require.Equal(t, time.Second, cs.WTimeout)
+	})
+	t.Run("numeric w", func(t *testing.T) {
+		cs, err := connstring.ParseAndValidate("mongodb://localhost/?" + writeconcern.New(writeconcern.W(0)).URIOptions())
+		require.NoError(t, err)
+		require.True(t, cs.WNumberSet)
+		require.Equal(t, 0, cs.WNumber)
+	})
+}
@@ -826,10 +826,11 @@ func readstring(src []byte) (string, []byte, bool) {
 }
 
 // readLengthBytes attempts to read a length and that number of bytes. This
-// function requires that the length include the four bytes for itself.
+// function requires that the length include the four bytes for itself, so
+// lengths less than 5, the length of an empty document, are rejected.
 func readLengthBytes(src []byte) ([]byte, []byte, bool) {
 	l, _, ok := ReadLength(src)
-	if !ok {
+	if !ok || l < 5 {
 		return nil, src, false
 	}
 	if len(src) < int(l) {
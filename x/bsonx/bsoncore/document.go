@@ -380,7 +380,7 @@ func (d Document) Validate() error {
 	if !ok {
 		return NewInsufficientBytesError(d, rem)
 	}
-	if int(length) > len(d) {
+	if int(length) > len(d) || length < 5 {
 		return NewDocumentLengthError(int(length), len(d))
 	}
 	if d[length-1] != 0x00 {
@@ -52,6 +52,17 @@ func TestDocument(t *testing.T) {
 				t.Errorf("Did not get expected error. got %v; want %v", got, want)
 			}
 		})
+		t.Run("LengthTooSmall", func(t *testing.T) {
+			want := NewDocumentLengthError(0, 5)
+			r := make(Document, 5)
+			got := r.Validate()
+			if !compareErrors(got, want) {
+				t.Errorf("Did not get expected error. got %v; want %v", got, want)
+			}
+			if _, _, ok := ReadDocument(r); ok {
+				t.Errorf("Expected ReadDocument to fail for a document of length 0")
+			}
+		})
 		t.Run("Invalid Element", func(t *testing.T) {
 			want := NewInsufficientBytesError(nil, nil)
 			r := make(Document, 9)
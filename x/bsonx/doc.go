@@ -0,0 +1,10 @@
+// Copyright (C) MongoDB, Inc. 2021-present.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
+
+// Package bsonx contains experimental types for working with BSON documents and values. It is used internally by the
+// driver and is not covered by the API stability guarantees of the driver. Applications should use the bson/bsondoc
+// package instead, which provides the same ordered document and value constructors with a supported API.
+package bsonx
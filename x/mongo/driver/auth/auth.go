@@ -54,12 +54,14 @@ func RegisterAuthenticatorFactory(name string, factory AuthenticatorFactory) {
 // if non-empty, then the connection will do SASL mechanism negotiation.
 type HandshakeOptions struct {
 	AppName               string
+	DriverInfo            *driver.DriverInfo
 	Authenticator         Authenticator
 	Compressors           []string
 	DBUser                string
 	PerformAuthentication func(description.Server) bool
 	ClusterClock          *session.ClusterClock
 	ServerAPI             *driver.ServerAPIOptions
+	LoadBalanced          bool
 }
 
 type authHandshaker struct {
@@ -81,10 +83,12 @@ func (ah *authHandshaker) GetHandshakeInformation(ctx context.Context, addr addr
 
 	op := operation.NewIsMaster().
 		AppName(ah.options.AppName).
+		DriverInfo(ah.options.DriverInfo).
 		Compressors(ah.options.Compressors).
 		SASLSupportedMechs(ah.options.DBUser).
 		ClusterClock(ah.options.ClusterClock).
-		ServerAPI(ah.options.ServerAPI)
+		ServerAPI(ah.options.ServerAPI).
+		LoadBalanced(ah.options.LoadBalanced)
 
 	if ah.options.Authenticator != nil {
 		if speculativeAuth, ok := ah.options.Authenticator.(SpeculativeAuthenticator); ok {
@@ -5,8 +5,10 @@ import (
 	"errors"
 	"fmt"
 	"strings"
+	"time"
 
 	"go.mongodb.org/mongo-driver/bson/bsontype"
+	"go.mongodb.org/mongo-driver/bson/primitive"
 	"go.mongodb.org/mongo-driver/event"
 	"go.mongodb.org/mongo-driver/mongo/description"
 	"go.mongodb.org/mongo-driver/x/bsonx/bsoncore"
@@ -23,14 +25,21 @@ type BatchCursor struct {
 	id                   int64
 	err                  error
 	server               Server
+	connection           PinnedConnection
 	batchSize            int32
 	maxTimeMS            int64
+	comment              bsoncore.Value
 	currentBatch         *bsoncore.DocumentSequence
 	firstBatch           bool
 	cmdMonitor           *event.CommandMonitor
 	postBatchResumeToken bsoncore.Document
 	crypt                *Crypt
 	serverAPI            *ServerAPIOptions
+	operationTime        *primitive.Timestamp
+	getMoreStats         GetMoreStats
+	loadBalanced         bool
+	pinnedCounters       *PinnedCursorCounters
+	lostPinnedConnection bool
 
 	// legacy server (< 3.2) fields
 	legacy      bool // This field is provided for ListCollectionsBatchCursor.
@@ -42,17 +51,22 @@ type BatchCursor struct {
 // be constructed from a CursorResponse.
 type CursorResponse struct {
 	Server               Server
+	Connection           PinnedConnection
 	Desc                 description.Server
 	FirstBatch           *bsoncore.DocumentSequence
 	Database             string
 	Collection           string
 	ID                   int64
 	postBatchResumeToken bsoncore.Document
+	operationTime        *primitive.Timestamp
+	exhaust              bool
 }
 
-// NewCursorResponse constructs a cursor response from the given response and server. This method
-// can be used within the ProcessResponse method for an operation.
-func NewCursorResponse(response bsoncore.Document, server Server, desc description.Server) (CursorResponse, error) {
+// NewCursorResponse constructs a cursor response from the given response and server. If the server is behind a load
+// balancer and the cursor is not exhausted, the cursor is pinned to the connection in info. This method can be used
+// within the ProcessResponse method for an operation.
+func NewCursorResponse(info ResponseInfo) (CursorResponse, error) {
+	response := info.ServerResponse
 	cur, ok := response.Lookup("cursor").DocumentOK()
 	if !ok {
 		return CursorResponse{}, fmt.Errorf("cursor should be an embedded document but is of BSON type %s", response.Lookup("cursor").Type)
@@ -61,7 +75,10 @@ func NewCursorResponse(response bsoncore.Document, server Server, desc descripti
 	if err != nil {
 		return CursorResponse{}, err
 	}
-	curresp := CursorResponse{Server: server, Desc: desc}
+	curresp := CursorResponse{Server: info.Server, Desc: info.ConnectionDescription}
+	if t, i, ok := response.Lookup("operationTime").TimestampOK(); ok {
+		curresp.operationTime = &primitive.Timestamp{T: t, I: i}
+	}
 
 	for _, elem := range elems {
 		switch elem.Key() {
@@ -94,17 +111,97 @@ func NewCursorResponse(response bsoncore.Document, server Server, desc descripti
 			}
 		}
 	}
+
+	// If the server is behind a load balancer and the cursor has a non-zero ID, pin the cursor to the connection so
+	// getMore and killCursors commands are sent to the same service.
+	if curresp.Desc.LoadBalanced() && curresp.ID != 0 {
+		pinnedConn, ok := info.Connection.(PinnedConnection)
+		if !ok {
+			return CursorResponse{}, fmt.Errorf("expected Connection used to establish a cursor to implement PinnedConnection, but got %T", info.Connection)
+		}
+		if err := pinnedConn.PinToCursor(); err != nil {
+			return CursorResponse{}, fmt.Errorf("error pinning connection to a cursor: %v", err)
+		}
+		curresp.Connection = pinnedConn
+	}
 	return curresp, nil
 }
 
+// NewExhaustCursorResponse constructs a cursor response like NewCursorResponse for a cursor whose getMore commands
+// allow the server to stream batches. If the server supports streaming getMore responses and the cursor is not
+// exhausted, the cursor is pinned to the connection in info because the streamed responses can only be read from that
+// connection. This method can be used within the ProcessResponse method for an operation.
+func NewExhaustCursorResponse(info ResponseInfo) (CursorResponse, error) {
+	curresp, err := NewCursorResponse(info)
+	if err != nil {
+		return CursorResponse{}, err
+	}
+	// Streaming getMore responses requires server version 4.2 (wire version 8).
+	if curresp.ID == 0 || curresp.Desc.WireVersion == nil || curresp.Desc.WireVersion.Max < 8 {
+		return curresp, nil
+	}
+
+	if curresp.Connection == nil {
+		pinnedConn, ok := info.Connection.(PinnedConnection)
+		if !ok {
+			return CursorResponse{}, fmt.Errorf("expected Connection used to establish an exhaust cursor to implement PinnedConnection, but got %T", info.Connection)
+		}
+		if err := pinnedConn.PinToCursor(); err != nil {
+			return CursorResponse{}, fmt.Errorf("error pinning connection to a cursor: %v", err)
+		}
+		curresp.Connection = pinnedConn
+	}
+	curresp.exhaust = true
+	return curresp, nil
+}
+
+// GetMoreStats contains statistics about the getMore commands sent by a BatchCursor. For a tailable await cursor or a
+// change stream, the duration of a getMore that returns an empty batch is approximately the time the server awaited
+// new documents, which is bounded by the maxTimeMS of the getMore.
+type GetMoreStats struct {
+	// The number of getMore commands that were sent.
+	Count int64
+
+	// The number of getMore commands that succeeded but returned no documents, e.g. because the await time expired.
+	EmptyBatches int64
+
+	// The total, most recent, and maximum round trip time of the getMore commands, including the time the server
+	// awaited new documents.
+	TotalDuration time.Duration
+	LastDuration  time.Duration
+	MaxDuration   time.Duration
+}
+
+// Add returns the sum of two GetMoreStats. LastDuration is taken from other if it sent any getMore commands.
+func (gms GetMoreStats) Add(other GetMoreStats) GetMoreStats {
+	sum := GetMoreStats{
+		Count:         gms.Count + other.Count,
+		EmptyBatches:  gms.EmptyBatches + other.EmptyBatches,
+		TotalDuration: gms.TotalDuration + other.TotalDuration,
+		LastDuration:  gms.LastDuration,
+		MaxDuration:   gms.MaxDuration,
+	}
+	if other.Count > 0 {
+		sum.LastDuration = other.LastDuration
+	}
+	if other.MaxDuration > sum.MaxDuration {
+		sum.MaxDuration = other.MaxDuration
+	}
+	return sum
+}
+
 // CursorOptions are extra options that are required to construct a BatchCursor.
 type CursorOptions struct {
 	BatchSize      int32
 	MaxTimeMS      int64
+	Comment        bsoncore.Value
 	Limit          int32
 	CommandMonitor *event.CommandMonitor
 	Crypt          *Crypt
 	ServerAPI      *ServerAPIOptions
+
+	// The counters updated when a getMore on a connection pinned in load balanced mode fails. This can be nil.
+	PinnedCursorCounters *PinnedCursorCounters
 }
 
 // NewBatchCursor creates a new BatchCursor from the provided parameters.
@@ -117,13 +214,21 @@ func NewBatchCursor(cr CursorResponse, clientSession *session.Client, clock *ses
 		collection:           cr.Collection,
 		id:                   cr.ID,
 		server:               cr.Server,
+		connection:           cr.Connection,
 		batchSize:            opts.BatchSize,
 		maxTimeMS:            opts.MaxTimeMS,
+		comment:              opts.Comment,
 		cmdMonitor:           opts.CommandMonitor,
 		firstBatch:           true,
 		postBatchResumeToken: cr.postBatchResumeToken,
 		crypt:                opts.Crypt,
 		serverAPI:            opts.ServerAPI,
+		operationTime:        cr.operationTime,
+		loadBalanced:         cr.Desc.LoadBalanced(),
+		pinnedCounters:       opts.PinnedCursorCounters,
+	}
+	if cr.exhaust {
+		bc.connection = &exhaustConnection{PinnedConnection: cr.Connection}
 	}
 
 	if ds != nil {
@@ -203,9 +308,53 @@ func (bc *BatchCursor) Close(ctx context.Context) error {
 	bc.currentBatch.Style = 0
 	bc.currentBatch.ResetIterator()
 
+	if unpinErr := bc.unpinConnection(); err == nil {
+		err = unpinErr
+	}
+	return err
+}
+
+// unpinConnection unpins the cursor from its connection and returns the connection to the pool if the cursor is
+// pinned.
+func (bc *BatchCursor) unpinConnection() error {
+	if bc.connection == nil {
+		return nil
+	}
+
+	err := bc.connection.UnpinFromCursor()
+	closeConn := bc.connection.Close
+	if bc.streaming() {
+		// The server will keep streaming responses on the connection, so it cannot be used by other operations.
+		if expirable, ok := bc.connection.(*exhaustConnection).PinnedConnection.(Expirable); ok {
+			closeConn = expirable.Expire
+		}
+	}
+	closeErr := closeConn()
+	if err == nil && closeErr != nil {
+		err = closeErr
+	}
+	bc.connection = nil
 	return err
 }
 
+// getOperationDeployment returns the Deployment used to run getMore and killCursors commands. If the cursor is pinned
+// to a connection, the commands are run on that connection.
+func (bc *BatchCursor) getOperationDeployment() Deployment {
+	if bc.connection != nil {
+		errorProcessor, _ := bc.server.(ErrorProcessor)
+		kind := description.LoadBalanced
+		if !bc.connection.Description().LoadBalanced() {
+			kind = description.Single
+		}
+		return &loadBalancedCursorDeployment{
+			errorProcessor: errorProcessor,
+			conn:           bc.connection,
+			kind:           kind,
+		}
+	}
+	return SingleServerDeployment{bc.server}
+}
+
 // Server returns the server for this cursor.
 func (bc *BatchCursor) Server() Server {
 	return bc.server
@@ -220,6 +369,12 @@ func (bc *BatchCursor) KillCursor(ctx context.Context) error {
 	if bc.server == nil || bc.id == 0 {
 		return nil
 	}
+	if bc.streaming() {
+		// A killCursors command cannot be sent while the server is streaming responses on the connection. Closing the
+		// connection kills the cursor on the server instead.
+		bc.id = 0
+		return bc.unpinConnection()
+	}
 
 	return Operation{
 		CommandFn: func(dst []byte, desc description.SelectedServer) ([]byte, error) {
@@ -228,7 +383,7 @@ func (bc *BatchCursor) KillCursor(ctx context.Context) error {
 			return dst, nil
 		},
 		Database:       bc.database,
-		Deployment:     SingleServerDeployment{Server: bc.server},
+		Deployment:     bc.getOperationDeployment(),
 		Client:         bc.clientSession,
 		Clock:          bc.clock,
 		Legacy:         LegacyKillCursors,
@@ -256,7 +411,8 @@ func (bc *BatchCursor) getMore(ctx context.Context) {
 		}
 	}
 
-	bc.err = Operation{
+	start := time.Now()
+	op := Operation{
 		CommandFn: func(dst []byte, desc description.SelectedServer) ([]byte, error) {
 			dst = bsoncore.AppendInt64Element(dst, "getMore", bc.id)
 			dst = bsoncore.AppendStringElement(dst, "collection", bc.collection)
@@ -266,11 +422,15 @@ func (bc *BatchCursor) getMore(ctx context.Context) {
 			if bc.maxTimeMS > 0 {
 				dst = bsoncore.AppendInt64Element(dst, "maxTimeMS", bc.maxTimeMS)
 			}
+			if bc.comment.Type != bsontype.Type(0) {
+				dst = bsoncore.AppendValueElement(dst, "comment", bc.comment)
+			}
 			return dst, nil
 		},
 		Database:   bc.database,
-		Deployment: SingleServerDeployment{Server: bc.server},
-		ProcessResponseFn: func(response bsoncore.Document, srvr Server, desc description.Server, currIndex int) error {
+		Deployment: bc.getOperationDeployment(),
+		ProcessResponseFn: func(info ResponseInfo) error {
+			response := info.ServerResponse
 			id, ok := response.Lookup("cursor", "id").Int64OK()
 			if !ok {
 				return fmt.Errorf("cursor.id should be an int64 but is a BSON %s", response.Lookup("cursor", "id").Type)
@@ -307,7 +467,30 @@ func (bc *BatchCursor) getMore(ctx context.Context) {
 		Legacy:         LegacyGetMore,
 		CommandMonitor: bc.cmdMonitor,
 		Crypt:          bc.crypt,
-	}.Execute(ctx, nil)
+	}
+	if bc.streaming() {
+		// The server streams the next response without a getMore command.
+		bc.err = op.ExecuteExhaust(ctx, bc.connection.(*exhaustConnection), nil)
+	} else {
+		bc.err = op.Execute(ctx, nil)
+	}
+	bc.recordGetMore(time.Since(start))
+	if bc.loadBalanced && bc.connection != nil && bc.err != nil && isWrongConnectionError(bc.err) {
+		bc.lostPinnedConnection = true
+		bc.pinnedCounters.addWrongConnectionError()
+	}
+
+	// Once the cursor is exhausted, the connection it is pinned to can be returned to the pool. A network error closes
+	// the pinned connection, so the cursor cannot be iterated or killed on the server and is considered exhausted. The
+	// same applies if the pinned connection reached a server that does not have the cursor.
+	if driverErr, ok := bc.err.(Error); (ok && driverErr.NetworkError()) || bc.lostPinnedConnection {
+		bc.id = 0
+	}
+	if bc.id == 0 {
+		if err := bc.unpinConnection(); err != nil && bc.err == nil {
+			bc.err = err
+		}
+	}
 
 	// Required for legacy operations which don't support limit.
 	if bc.limit != 0 && bc.numReturned >= bc.limit {
@@ -320,7 +503,113 @@ func (bc *BatchCursor) getMore(ctx context.Context) {
 	return
 }
 
+func (bc *BatchCursor) recordGetMore(d time.Duration) {
+	stats := &bc.getMoreStats
+	stats.Count++
+	if bc.err == nil && bc.currentBatch.DocumentCount() == 0 {
+		stats.EmptyBatches++
+	}
+	stats.TotalDuration += d
+	stats.LastDuration = d
+	if d > stats.MaxDuration {
+		stats.MaxDuration = d
+	}
+}
+
+// LostPinnedConnection returns true if the last getMore failed because the connection the cursor is pinned to in load
+// balanced mode was closed or reached a server that does not have the cursor. The cursor cannot be iterated further,
+// but the query can be restarted with a new cursor.
+func (bc *BatchCursor) LostPinnedConnection() bool {
+	return bc.lostPinnedConnection
+}
+
+// OperationTime returns the operation time of the response that created the cursor. It returns nil if the server did not
+// include an operation time in the response.
+func (bc *BatchCursor) OperationTime() *primitive.Timestamp {
+	return bc.operationTime
+}
+
 // PostBatchResumeToken returns the latest seen post batch resume token.
 func (bc *BatchCursor) PostBatchResumeToken() bsoncore.Document {
 	return bc.postBatchResumeToken
 }
+
+// SetMaxTime sets the maxTimeMS of the getMore commands sent after this call, which is the maximum amount of time the
+// server waits for new documents for a tailable await cursor. A duration of 0 means maxTimeMS is not sent and the
+// server default is used. The server returns an error if maxTimeMS is set for other cursors. While the server is
+// streaming the batches of an exhaust cursor, it keeps using the maxTimeMS of the getMore that started the stream.
+func (bc *BatchCursor) SetMaxTime(d time.Duration) {
+	bc.maxTimeMS = int64(d / time.Millisecond)
+}
+
+// GetMoreStats returns statistics about the getMore commands sent by the cursor.
+func (bc *BatchCursor) GetMoreStats() GetMoreStats {
+	return bc.getMoreStats
+}
+
+// streaming returns true if the cursor is an exhaust cursor and the server is streaming responses on its connection.
+func (bc *BatchCursor) streaming() bool {
+	conn, ok := bc.connection.(*exhaustConnection)
+	return ok && conn.CurrentlyStreaming()
+}
+
+// exhaustConnection wraps the connection an exhaust cursor is pinned to. It sets the exhaustAllowed flag on the
+// getMore commands sent on the connection and tracks whether the server is streaming responses on it.
+type exhaustConnection struct {
+	PinnedConnection
+	currentlyStreaming bool
+}
+
+var _ StreamerConnection = (*exhaustConnection)(nil)
+var _ Compressor = (*exhaustConnection)(nil)
+
+func (ec *exhaustConnection) SetStreaming(streaming bool) {
+	ec.currentlyStreaming = streaming
+}
+
+func (ec *exhaustConnection) CurrentlyStreaming() bool {
+	return ec.currentlyStreaming
+}
+
+func (ec *exhaustConnection) SupportsStreaming() bool {
+	return true
+}
+
+func (ec *exhaustConnection) CompressWireMessage(src, dst []byte) ([]byte, error) {
+	if compressor, ok := ec.PinnedConnection.(Compressor); ok {
+		return compressor.CompressWireMessage(src, dst)
+	}
+	return append(dst, src...), nil
+}
+
+// loadBalancedCursorDeployment is used as a Deployment for getMore and killCursors commands when pinning to a
+// connection in load balanced mode or for an exhaust cursor. This type also functions as an ErrorProcessor to ensure
+// that SDAM errors are handled for these commands.
+type loadBalancedCursorDeployment struct {
+	errorProcessor ErrorProcessor
+	conn           PinnedConnection
+	kind           description.TopologyKind
+}
+
+var _ Deployment = (*loadBalancedCursorDeployment)(nil)
+var _ Server = (*loadBalancedCursorDeployment)(nil)
+var _ ErrorProcessor = (*loadBalancedCursorDeployment)(nil)
+
+func (lbcd *loadBalancedCursorDeployment) SelectServer(_ context.Context, _ description.ServerSelector) (Server, error) {
+	return lbcd, nil
+}
+
+func (lbcd *loadBalancedCursorDeployment) Kind() description.TopologyKind {
+	return lbcd.kind
+}
+
+func (lbcd *loadBalancedCursorDeployment) Connection(_ context.Context) (Connection, error) {
+	return lbcd.conn, nil
+}
+
+func (lbcd *loadBalancedCursorDeployment) ProcessError(err error, conn Connection) ProcessErrorResult {
+	if lbcd.errorProcessor == nil {
+		return NoChange
+	}
+	return lbcd.errorProcessor.ProcessError(err, conn)
+}
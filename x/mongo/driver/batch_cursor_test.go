@@ -0,0 +1,180 @@
+// Copyright (C) MongoDB, Inc. 2021-present.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
+
+package driver
+
+import (
+	"context"
+	"strconv"
+	"testing"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/internal/testutil/assert"
+	"go.mongodb.org/mongo-driver/mongo/description"
+	"go.mongodb.org/mongo-driver/x/bsonx/bsoncore"
+)
+
+func TestBatchCursor(t *testing.T) {
+	t.Run("exhaust", func(t *testing.T) {
+		cursorResponse := func(batchKey string, id int64, docs ...bsoncore.Document) bsoncore.Document {
+			aidx, arr := bsoncore.AppendArrayStart(nil)
+			for i, doc := range docs {
+				arr = bsoncore.AppendDocumentElement(arr, strconv.Itoa(i), doc)
+			}
+			arr, _ = bsoncore.AppendArrayEnd(arr, aidx)
+			return bsoncore.BuildDocumentFromElements(nil,
+				bsoncore.AppendDocumentElement(nil, "cursor", bsoncore.BuildDocumentFromElements(nil,
+					bsoncore.AppendInt64Element(nil, "id", id),
+					bsoncore.AppendStringElement(nil, "ns", "db.coll"),
+					bsoncore.AppendArrayElement(nil, batchKey, arr),
+				)),
+				bsoncore.AppendInt32Element(nil, "ok", 1),
+			)
+		}
+		doc := bsoncore.BuildDocumentFromElements(nil, bsoncore.AppendInt32Element(nil, "x", 1))
+
+		newCursor := func(t *testing.T, conn *pinnedMockConnection) *BatchCursor {
+			t.Helper()
+
+			cr, err := NewExhaustCursorResponse(ResponseInfo{
+				ServerResponse:        cursorResponse("firstBatch", 5),
+				Server:                SingleConnectionDeployment{conn},
+				Connection:            conn,
+				ConnectionDescription: conn.rDesc,
+			})
+			assert.Nil(t, err, "NewExhaustCursorResponse error: %v", err)
+			assert.Equal(t, 1, conn.pins, "expected connection to be pinned once, got %v pins", conn.pins)
+
+			bc, err := NewBatchCursor(cr, nil, nil, CursorOptions{})
+			assert.Nil(t, err, "NewBatchCursor error: %v", err)
+			assert.False(t, bc.Next(context.Background()), "expected empty first batch")
+			return bc
+		}
+		newConn := func(replies ...[]byte) *pinnedMockConnection {
+			desc := description.Server{WireVersion: &description.VersionRange{Max: 8}}
+			return &pinnedMockConnection{replySequenceConnection: &replySequenceConnection{
+				mockConnection: &mockConnection{rDesc: desc},
+				replies:        replies,
+			}}
+		}
+
+		t.Run("batches are streamed", func(t *testing.T) {
+			conn := newConn(
+				createExhaustServerResponse(t, cursorResponse("nextBatch", 5, doc), true),
+				createExhaustServerResponse(t, cursorResponse("nextBatch", 5), true),
+				createExhaustServerResponse(t, cursorResponse("nextBatch", 0, doc, doc), false),
+			)
+			bc := newCursor(t, conn)
+
+			assert.True(t, bc.Next(context.Background()), "expected Next true; error: %v", bc.Err())
+			assertExhaustAllowedSet(t, conn.pWriteWM, true)
+			assert.False(t, bc.Next(context.Background()), "expected empty batch; error: %v", bc.Err())
+			assert.True(t, bc.Next(context.Background()), "expected Next true; error: %v", bc.Err())
+			assert.Equal(t, 2, bc.Batch().DocumentCount(), "expected 2 documents, got %v", bc.Batch().DocumentCount())
+
+			assert.Equal(t, 1, conn.writes, "expected only the first getMore to be sent, got %v writes", conn.writes)
+			assert.Equal(t, int64(0), bc.ID(), "expected cursor to be exhausted, got ID %v", bc.ID())
+			assert.Equal(t, 0, conn.pins, "expected connection to be unpinned, got %v pins", conn.pins)
+			assert.False(t, conn.expired, "expected connection to be returned to the pool, but it was closed")
+			assert.Equal(t, int64(3), bc.GetMoreStats().Count, "expected 3 getMores, got %v", bc.GetMoreStats().Count)
+		})
+		t.Run("close while streaming", func(t *testing.T) {
+			conn := newConn(createExhaustServerResponse(t, cursorResponse("nextBatch", 5, doc), true))
+			bc := newCursor(t, conn)
+
+			assert.True(t, bc.Next(context.Background()), "expected Next true; error: %v", bc.Err())
+			err := bc.Close(context.Background())
+			assert.Nil(t, err, "Close error: %v", err)
+
+			assert.Equal(t, 1, conn.writes, "expected no killCursors to be sent, got %v writes", conn.writes)
+			assert.Equal(t, 0, conn.pins, "expected connection to be unpinned, got %v pins", conn.pins)
+			assert.True(t, conn.expired, "expected connection to be closed")
+		})
+		t.Run("old server", func(t *testing.T) {
+			conn := newConn()
+			conn.rDesc.WireVersion.Max = 7
+			cr, err := NewExhaustCursorResponse(ResponseInfo{
+				ServerResponse:        cursorResponse("firstBatch", 5),
+				Connection:            conn,
+				ConnectionDescription: conn.rDesc,
+			})
+			assert.Nil(t, err, "NewExhaustCursorResponse error: %v", err)
+			assert.Nil(t, cr.Connection, "expected cursor not to be pinned, got %v", cr.Connection)
+			assert.Equal(t, 0, conn.pins, "expected connection not to be pinned, got %v pins", conn.pins)
+		})
+	})
+	t.Run("load balanced getMore on wrong connection", func(t *testing.T) {
+		serviceID := primitive.NewObjectID()
+		desc := description.Server{WireVersion: &description.VersionRange{Max: 13}, ServiceID: &serviceID}
+		notFound := bsoncore.BuildDocumentFromElements(nil,
+			bsoncore.AppendInt32Element(nil, "ok", 0),
+			bsoncore.AppendInt32Element(nil, "code", 43),
+			bsoncore.AppendStringElement(nil, "errmsg", "cursor id 5 not found"),
+		)
+		conn := &pinnedMockConnection{replySequenceConnection: &replySequenceConnection{
+			mockConnection: &mockConnection{rDesc: desc},
+			replies:        [][]byte{createExhaustServerResponse(t, notFound, false)},
+		}}
+		firstBatch := bsoncore.BuildDocumentFromElements(nil,
+			bsoncore.AppendDocumentElement(nil, "cursor", bsoncore.BuildDocumentFromElements(nil,
+				bsoncore.AppendInt64Element(nil, "id", 5),
+				bsoncore.AppendStringElement(nil, "ns", "db.coll"),
+				bsoncore.AppendArrayElement(nil, "firstBatch", bsoncore.BuildArray(nil)),
+			)),
+			bsoncore.AppendInt32Element(nil, "ok", 1),
+		)
+		cr, err := NewCursorResponse(ResponseInfo{
+			ServerResponse:        firstBatch,
+			Server:                SingleConnectionDeployment{conn},
+			Connection:            conn,
+			ConnectionDescription: desc,
+		})
+		assert.Nil(t, err, "NewCursorResponse error: %v", err)
+
+		counters := &PinnedCursorCounters{}
+		bc, err := NewBatchCursor(cr, nil, nil, CursorOptions{PinnedCursorCounters: counters})
+		assert.Nil(t, err, "NewBatchCursor error: %v", err)
+		assert.False(t, bc.Next(context.Background()), "expected empty first batch")
+		assert.False(t, bc.LostPinnedConnection(), "expected pinned connection not to be lost after the first batch")
+
+		assert.False(t, bc.Next(context.Background()), "expected getMore to fail")
+		assert.NotNil(t, bc.Err(), "expected getMore error, got nil")
+		assert.True(t, bc.LostPinnedConnection(), "expected pinned connection to be lost")
+		expected := PinnedCursorStats{WrongConnectionErrors: 1}
+		assert.Equal(t, expected, counters.Stats(), "expected stats %v, got %v", expected, counters.Stats())
+		assert.Equal(t, 0, conn.pins, "expected connection to be unpinned, got %v pins", conn.pins)
+	})
+}
+
+// pinnedMockConnection is a replySequenceConnection that can be pinned to a cursor and expired.
+type pinnedMockConnection struct {
+	*replySequenceConnection
+	pins    int
+	expired bool
+}
+
+var _ PinnedConnection = (*pinnedMockConnection)(nil)
+var _ Expirable = (*pinnedMockConnection)(nil)
+
+func (c *pinnedMockConnection) PinToCursor() error {
+	c.pins++
+	return nil
+}
+
+func (c *pinnedMockConnection) UnpinFromCursor() error {
+	c.pins--
+	return nil
+}
+
+func (c *pinnedMockConnection) PinToTransaction() error     { return nil }
+func (c *pinnedMockConnection) UnpinFromTransaction() error { return nil }
+
+func (c *pinnedMockConnection) Expire() error {
+	c.expired = true
+	return nil
+}
+
+func (c *pinnedMockConnection) Alive() bool { return !c.expired }
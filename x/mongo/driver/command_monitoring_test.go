@@ -9,6 +9,7 @@ package driver
 import (
 	"testing"
 
+	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/internal/testutil/assert"
 	"go.mongodb.org/mongo-driver/x/bsonx/bsoncore"
 )
@@ -49,4 +50,45 @@ func TestCommandMonitoring(t *testing.T) {
 			})
 		}
 	})
+	t.Run("commandOptions", func(t *testing.T) {
+		collation := bsoncore.BuildDocumentFromElements(nil, bsoncore.AppendStringElement(nil, "locale", "en"))
+		find := bsoncore.BuildDocumentFromElements(nil,
+			bsoncore.AppendStringElement(nil, "find", "coll"),
+			bsoncore.AppendDocumentElement(nil, "filter", bsoncore.BuildDocumentFromElements(nil)),
+			bsoncore.AppendInt32Element(nil, "batchSize", 10),
+			bsoncore.AppendStringElement(nil, "hint", "x_1"),
+			bsoncore.AppendInt64Element(nil, "maxTimeMS", 500),
+			bsoncore.AppendDocumentElement(nil, "collation", collation),
+		)
+		aggregate := bsoncore.BuildDocumentFromElements(nil,
+			bsoncore.AppendStringElement(nil, "aggregate", "coll"),
+			bsoncore.AppendDocumentElement(nil, "cursor", bsoncore.BuildDocumentFromElements(nil,
+				bsoncore.AppendInt32Element(nil, "batchSize", 5),
+			)),
+		)
+		insert := bsoncore.BuildDocumentFromElements(nil, bsoncore.AppendStringElement(nil, "insert", "coll"))
+
+		testCases := []struct {
+			name     string
+			command  bsoncore.Document
+			expected bsoncore.Document
+		}{
+			{"find", find, bsoncore.BuildDocumentFromElements(nil,
+				bsoncore.AppendInt64Element(nil, "maxTimeMS", 500),
+				bsoncore.AppendInt32Element(nil, "batchSize", 10),
+				bsoncore.AppendStringElement(nil, "hint", "x_1"),
+				bsoncore.AppendDocumentElement(nil, "collation", collation),
+			)},
+			{"aggregate cursor batch size", aggregate, bsoncore.BuildDocumentFromElements(nil,
+				bsoncore.AppendInt32Element(nil, "batchSize", 5),
+			)},
+			{"no options", insert, bsoncore.BuildDocumentFromElements(nil)},
+		}
+		for _, tc := range testCases {
+			t.Run(tc.name, func(t *testing.T) {
+				got := commandOptions(tc.command)
+				assert.Equal(t, bson.Raw(tc.expected), got, "expected options %v, got %v", bson.Raw(tc.expected), got)
+			})
+		}
+	})
 }
@@ -70,10 +70,22 @@ func CompressPayload(in []byte, opts CompressionOpts) ([]byte, error) {
 
 // DecompressPayload takes a byte slice that has been compressed and undoes it according to the options passed
 func DecompressPayload(in []byte, opts CompressionOpts) ([]byte, error) {
+	if opts.UncompressedSize < 0 {
+		return nil, fmt.Errorf("invalid uncompressed size %v", opts.UncompressedSize)
+	}
+
 	switch opts.Compressor {
 	case wiremessage.CompressorNoOp:
 		return in, nil
 	case wiremessage.CompressorSnappy:
+		// Check the decoded length first so the allocation is bounded by the uncompressed size.
+		l, err := snappy.DecodedLen(in)
+		if err != nil {
+			return nil, err
+		}
+		if l != int(opts.UncompressedSize) {
+			return nil, fmt.Errorf("unexpected decompression size, expected %v but got %v", opts.UncompressedSize, l)
+		}
 		uncompressed := make([]byte, opts.UncompressedSize)
 		return snappy.Decode(uncompressed, in)
 	case wiremessage.CompressorZLib:
@@ -88,17 +100,26 @@ func DecompressPayload(in []byte, opts CompressionOpts) ([]byte, error) {
 		}
 		return uncompressed, nil
 	case wiremessage.CompressorZstd:
-		w, err := zstd.NewReader(bytes.NewBuffer(in))
+		// Limit the memory used by the decoder so a payload that decompresses to more than the uncompressed size, or
+		// declares a larger window, is rejected without being decompressed. Frames can declare a window larger than
+		// their content, so the 8MB window that zstd decoders are recommended to support is always allowed.
+		maxMemory := uint64(opts.UncompressedSize) + 1
+		if maxMemory < 8<<20 {
+			maxMemory = 8 << 20
+		}
+		r, err := zstd.NewReader(nil, zstd.WithDecoderMaxMemory(maxMemory))
 		if err != nil {
 			return nil, err
 		}
-		defer w.Close()
-		var b bytes.Buffer
-		_, err = io.Copy(&b, w)
+		defer r.Close()
+		uncompressed, err := r.DecodeAll(in, make([]byte, 0, opts.UncompressedSize))
 		if err != nil {
 			return nil, err
 		}
-		return b.Bytes(), nil
+		if len(uncompressed) != int(opts.UncompressedSize) {
+			return nil, fmt.Errorf("unexpected decompression size, expected %v but got %v", opts.UncompressedSize, len(uncompressed))
+		}
+		return uncompressed, nil
 	default:
 		return nil, fmt.Errorf("unknown compressor ID %v", opts.Compressor)
 	}
@@ -40,3 +40,33 @@ func TestCompression(t *testing.T) {
 		})
 	}
 }
+
+func TestDecompressUncompressedSizeMismatch(t *testing.T) {
+	compressors := []wiremessage.CompressorID{
+		wiremessage.CompressorSnappy,
+		wiremessage.CompressorZLib,
+		wiremessage.CompressorZstd,
+	}
+
+	for _, compressor := range compressors {
+		t.Run(strconv.Itoa(int(compressor)), func(t *testing.T) {
+			payload := []byte("Lorem ipsum dolor sit amet, consectetur adipiscing elit, sed do eiusmod tempor incididunt")
+			opts := CompressionOpts{
+				Compressor:       compressor,
+				ZlibLevel:        wiremessage.DefaultZlibLevel,
+				ZstdLevel:        wiremessage.DefaultZstdLevel,
+				UncompressedSize: int32(len(payload)),
+			}
+			compressed, err := CompressPayload(payload, opts)
+			assert.NoError(t, err)
+
+			opts.UncompressedSize = int32(len(payload)) + 1
+			_, err = DecompressPayload(compressed, opts)
+			assert.Error(t, err)
+
+			opts.UncompressedSize = -1
+			_, err = DecompressPayload(compressed, opts)
+			assert.Error(t, err)
+		})
+	}
+}
@@ -36,6 +36,22 @@ func ParseAndValidate(s string) (ConnString, error) {
 	return p.ConnString, nil
 }
 
+// ParseAndValidateWithSeedlistCache behaves like ParseAndValidate but uses cache to persist the hosts resolved from an
+// SRV URI. If SRV resolution fails, the most recently stored seedlist for the SRV host name is used instead and
+// SeedlistFromCache is set on the returned ConnString. A nil cache is equivalent to calling ParseAndValidate.
+func ParseAndValidateWithSeedlistCache(s string, cache dns.SeedlistCache) (ConnString, error) {
+	p := parser{dnsResolver: dns.DefaultResolver, seedlistCache: cache}
+	err := p.parse(s)
+	if err != nil {
+		return p.ConnString, internal.WrapErrorf(err, "error parsing uri")
+	}
+	err = p.ConnString.Validate()
+	if err != nil {
+		return p.ConnString, internal.WrapErrorf(err, "error validating uri")
+	}
+	return p.ConnString, nil
+}
+
 // Parse parses the provided URI into a ConnString object
 // but does not check that all values are valid. Use `ConnString.Validate()`
 // to run the validation checks separately.
@@ -70,10 +86,14 @@ type ConnString struct {
 	Hosts                              []string
 	J                                  bool
 	JSet                               bool
+	LoadBalanced                       bool
+	LoadBalancedSet                    bool
 	LocalThreshold                     time.Duration
 	LocalThresholdSet                  bool
 	MaxConnIdleTime                    time.Duration
 	MaxConnIdleTimeSet                 bool
+	MaxConnecting                      uint64
+	MaxConnectingSet                   bool
 	MaxPoolSize                        uint64
 	MaxPoolSizeSet                     bool
 	MinPoolSize                        uint64
@@ -91,6 +111,7 @@ type ConnString struct {
 	MaxStalenessSet                    bool
 	ReplicaSet                         string
 	Scheme                             string
+	SeedlistFromCache                  bool
 	ServerSelectionTimeout             time.Duration
 	ServerSelectionTimeoutSet          bool
 	SocketTimeout                      time.Duration
@@ -183,8 +204,38 @@ const (
 type parser struct {
 	ConnString
 
-	dnsResolver *dns.Resolver
-	tlsssl      *bool // used to determine if tls and ssl options are both specified and set differently.
+	dnsResolver   *dns.Resolver
+	seedlistCache dns.SeedlistCache
+	tlsssl        *bool // used to determine if tls and ssl options are both specified and set differently.
+}
+
+// resolveSRV resolves the hosts and the TXT connection options for an SRV host name. If a seedlist cache is
+// configured, a successful lookup is stored in the cache and a failed SRV lookup falls back to the cached seedlist, if
+// there is one. The cached TXT options are used along with the cached hosts because the TXT lookup is not expected to
+// succeed if the SRV lookup failed.
+func (p *parser) resolveSRV(hosts string) ([]string, []string, error) {
+	parsedHosts, err := p.dnsResolver.ParseHosts(hosts, true)
+	if err != nil {
+		if p.seedlistCache == nil {
+			return nil, nil, err
+		}
+		cached, cacheErr := p.seedlistCache.LoadSeedlist(hosts)
+		if cacheErr != nil || cached == nil || len(cached.Hosts) == 0 {
+			return nil, nil, err
+		}
+		p.SeedlistFromCache = true
+		return cached.Hosts, cached.Options, nil
+	}
+
+	connectionArgsFromTXT, err := p.dnsResolver.GetConnectionArgsFromTXT(hosts)
+	if err != nil {
+		return nil, nil, err
+	}
+	if p.seedlistCache != nil {
+		// Failing to persist the seedlist should not prevent the client from being created.
+		_ = p.seedlistCache.StoreSeedlist(hosts, &dns.Seedlist{Hosts: parsedHosts, Options: connectionArgsFromTXT})
+	}
+	return parsedHosts, connectionArgsFromTXT, nil
 }
 
 func (p *parser) parse(original string) error {
@@ -256,11 +307,7 @@ func (p *parser) parse(original string) error {
 	parsedHosts := strings.Split(hosts, ",")
 
 	if p.Scheme == SchemeMongoDBSRV {
-		parsedHosts, err = p.dnsResolver.ParseHosts(hosts, true)
-		if err != nil {
-			return err
-		}
-		connectionArgsFromTXT, err = p.dnsResolver.GetConnectionArgsFromTXT(hosts)
+		parsedHosts, connectionArgsFromTXT, err = p.resolveSRV(hosts)
 		if err != nil {
 			return err
 		}
@@ -340,6 +387,19 @@ func (p *parser) validate() error {
 		}
 	}
 
+	// Validation for load-balanced mode.
+	if p.LoadBalancedSet && p.LoadBalanced {
+		if len(p.Hosts) > 1 {
+			return errors.New("loadBalanced cannot be set to true if multiple hosts are specified")
+		}
+		if p.ReplicaSet != "" {
+			return errors.New("loadBalanced cannot be set to true if a replica set name is specified")
+		}
+		if p.DirectConnectionSet && p.DirectConnection {
+			return errors.New("loadBalanced cannot be set to true if the direct connection option is specified")
+		}
+	}
+
 	return nil
 }
 
@@ -643,6 +703,17 @@ func (p *parser) addOption(pair string) error {
 		}
 
 		p.JSet = true
+	case "loadbalanced":
+		switch value {
+		case "true":
+			p.LoadBalanced = true
+		case "false":
+			p.LoadBalanced = false
+		default:
+			return fmt.Errorf("invalid value for %s: %s", key, value)
+		}
+
+		p.LoadBalancedSet = true
 	case "localthresholdms":
 		n, err := strconv.Atoi(value)
 		if err != nil || n < 0 {
@@ -657,6 +728,13 @@ func (p *parser) addOption(pair string) error {
 		}
 		p.MaxConnIdleTime = time.Duration(n) * time.Millisecond
 		p.MaxConnIdleTimeSet = true
+	case "maxconnecting":
+		n, err := strconv.Atoi(value)
+		if err != nil || n <= 0 {
+			return fmt.Errorf("invalid value for %s: %s", key, value)
+		}
+		p.MaxConnecting = uint64(n)
+		p.MaxConnectingSet = true
 	case "maxpoolsize":
 		n, err := strconv.Atoi(value)
 		if err != nil || n < 0 {
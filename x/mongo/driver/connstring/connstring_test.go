@@ -7,13 +7,19 @@
 package connstring_test
 
 import (
+	"errors"
 	"fmt"
+	"io/ioutil"
+	"net"
+	"os"
+	"path/filepath"
 	"testing"
 	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"go.mongodb.org/mongo-driver/x/mongo/driver/connstring"
+	"go.mongodb.org/mongo-driver/x/mongo/driver/dns"
 )
 
 func TestAppName(t *testing.T) {
@@ -212,6 +218,38 @@ func TestHeartbeatInterval(t *testing.T) {
 	}
 }
 
+func TestLoadBalanced(t *testing.T) {
+	testCases := []struct {
+		s        string
+		expected bool
+		err      bool
+	}{
+		{"mongodb://localhost/?loadBalanced=true", true, false},
+		{"mongodb://localhost/?loadBalanced=false", false, false},
+		{"mongodb://localhost/?loadBalanced=blah", false, true},
+		{"mongodb://localhost,localhost:27018/?loadBalanced=true", false, true},
+		{"mongodb://localhost,localhost:27018/?loadBalanced=false", false, false},
+		{"mongodb://localhost/?loadBalanced=true&replicaSet=rs", false, true},
+		{"mongodb://localhost/?loadBalanced=true&directConnection=true", false, true},
+		{"mongodb://localhost/?loadBalanced=true&directConnection=false", true, false},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.s, func(t *testing.T) {
+			cs, err := connstring.ParseAndValidate(tc.s)
+			if tc.err {
+				assert.NotNil(t, err, "expected error, got nil")
+				return
+			}
+
+			assert.Nil(t, err, "expected no error, got %v", err)
+			assert.Equal(t, tc.expected, cs.LoadBalanced, "expected LoadBalanced value %v, got %v", tc.expected,
+				cs.LoadBalanced)
+			assert.True(t, cs.LoadBalancedSet, "expected LoadBalancedSet to be true, got false")
+		})
+	}
+}
+
 func TestLocalThreshold(t *testing.T) {
 	tests := []struct {
 		s        string
@@ -265,6 +303,34 @@ func TestMaxConnIdleTime(t *testing.T) {
 	}
 }
 
+func TestMaxConnecting(t *testing.T) {
+	tests := []struct {
+		s        string
+		expected uint64
+		err      bool
+	}{
+		{s: "maxConnecting=1", expected: 1},
+		{s: "maxConnecting=10", expected: 10},
+		{s: "maxConnecting=0", err: true},
+		{s: "maxConnecting=-2", err: true},
+		{s: "maxConnecting=gsdge", err: true},
+	}
+
+	for _, test := range tests {
+		s := fmt.Sprintf("mongodb://localhost/?%s", test.s)
+		t.Run(s, func(t *testing.T) {
+			cs, err := connstring.ParseAndValidate(s)
+			if test.err {
+				require.Error(t, err)
+			} else {
+				require.NoError(t, err)
+				require.True(t, cs.MaxConnectingSet)
+				require.Equal(t, test.expected, cs.MaxConnecting)
+			}
+		})
+	}
+}
+
 func TestMaxPoolSize(t *testing.T) {
 	tests := []struct {
 		s        string
@@ -602,3 +668,66 @@ func TestCompressionOptions(t *testing.T) {
 		})
 	}
 }
+
+func TestSeedlistCache(t *testing.T) {
+	dir, err := ioutil.TempDir("", "seedlist")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	origResolver := dns.DefaultResolver
+	defer func() { dns.DefaultResolver = origResolver }()
+
+	resolving := &dns.Resolver{
+		LookupSRV: func(string, string, string) (string, []*net.SRV, error) {
+			return "", []*net.SRV{{Target: "host1.example.com.", Port: 27017}}, nil
+		},
+		LookupTXT: func(string) ([]string, error) {
+			return []string{"replicaSet=rs0&authSource=admin"}, nil
+		},
+	}
+	failing := &dns.Resolver{
+		LookupSRV: func(string, string, string) (string, []*net.SRV, error) {
+			return "", nil, errors.New("dns outage")
+		},
+		LookupTXT: func(string) ([]string, error) {
+			return nil, errors.New("dns outage")
+		},
+	}
+	uri := "mongodb+srv://test.example.com/"
+
+	t.Run("fallback to stored seedlist", func(t *testing.T) {
+		cache := dns.NewFileSeedlistCache(filepath.Join(dir, "fallback.json"))
+
+		dns.DefaultResolver = resolving
+		cs, err := connstring.ParseAndValidateWithSeedlistCache(uri, cache)
+		require.NoError(t, err)
+		assert.Equal(t, []string{"host1.example.com:27017"}, cs.Hosts)
+		assert.False(t, cs.SeedlistFromCache)
+
+		dns.DefaultResolver = failing
+		cs, err = connstring.ParseAndValidateWithSeedlistCache(uri, cache)
+		require.NoError(t, err)
+		assert.Equal(t, []string{"host1.example.com:27017"}, cs.Hosts)
+		assert.True(t, cs.SeedlistFromCache)
+		assert.Equal(t, "rs0", cs.ReplicaSet)
+		assert.Equal(t, "admin", cs.AuthSource)
+	})
+	t.Run("fallback to seedlist stored without TXT options", func(t *testing.T) {
+		path := filepath.Join(dir, "hosts.json")
+		err := ioutil.WriteFile(path, []byte(`{"test.example.com":["host2.example.com:27017"]}`), 0600)
+		require.NoError(t, err)
+
+		dns.DefaultResolver = failing
+		cs, err := connstring.ParseAndValidateWithSeedlistCache(uri, dns.NewFileSeedlistCache(path))
+		require.NoError(t, err)
+		assert.Equal(t, []string{"host2.example.com:27017"}, cs.Hosts)
+		assert.Equal(t, "", cs.ReplicaSet)
+	})
+	t.Run("error without stored seedlist", func(t *testing.T) {
+		cache := dns.NewFileSeedlistCache(filepath.Join(dir, "empty.json"))
+
+		dns.DefaultResolver = failing
+		_, err := connstring.ParseAndValidateWithSeedlistCache(uri, cache)
+		assert.Error(t, err)
+	})
+}
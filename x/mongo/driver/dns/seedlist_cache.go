@@ -0,0 +1,127 @@
+// Copyright (C) MongoDB, Inc. 2021-present.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
+
+package dns
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sync"
+)
+
+// Seedlist is the result of resolving an SRV host name.
+type Seedlist struct {
+	// The hosts from the SRV records, in the form "host:port".
+	Hosts []string `json:"hosts"`
+
+	// The connection options from the TXT record, in the form "key=value", e.g. "replicaSet=rs0".
+	Options []string `json:"options,omitempty"`
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface. Seedlists stored as a plain array of hosts are also
+// accepted, which is how seedlists were stored before the TXT options were cached.
+func (s *Seedlist) UnmarshalJSON(data []byte) error {
+	var hosts []string
+	if err := json.Unmarshal(data, &hosts); err == nil {
+		*s = Seedlist{Hosts: hosts}
+		return nil
+	}
+
+	type seedlist Seedlist
+	return json.Unmarshal(data, (*seedlist)(s))
+}
+
+// SeedlistCache stores the most recently resolved seedlist for an SRV host name. It is used to bootstrap a topology
+// when SRV resolution fails, e.g. during a transient DNS outage. Implementations must be safe for concurrent use.
+type SeedlistCache interface {
+	// LoadSeedlist returns the last stored seedlist for host. It should return a nil Seedlist and a nil error if no
+	// seedlist has been stored for host.
+	LoadSeedlist(host string) (*Seedlist, error)
+
+	// StoreSeedlist records seedlist as the last known result of resolving host.
+	StoreSeedlist(host string, seedlist *Seedlist) error
+}
+
+// FileSeedlistCache is a SeedlistCache that persists seedlists to a JSON file on disk, keyed by SRV host name.
+type FileSeedlistCache struct {
+	path string
+	mu   sync.Mutex
+}
+
+var _ SeedlistCache = (*FileSeedlistCache)(nil)
+
+// NewFileSeedlistCache creates a FileSeedlistCache that reads from and writes to the file at path. The file is
+// created on the first call to StoreSeedlist if it does not already exist.
+func NewFileSeedlistCache(path string) *FileSeedlistCache {
+	return &FileSeedlistCache{path: path}
+}
+
+// LoadSeedlist implements the SeedlistCache interface.
+func (f *FileSeedlistCache) LoadSeedlist(host string) (*Seedlist, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	entries, err := f.read()
+	if err != nil {
+		return nil, err
+	}
+	return entries[host], nil
+}
+
+// StoreSeedlist implements the SeedlistCache interface.
+func (f *FileSeedlistCache) StoreSeedlist(host string, seedlist *Seedlist) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	entries, err := f.read()
+	if err != nil {
+		return err
+	}
+	entries[host] = seedlist
+
+	data, err := json.Marshal(entries)
+	if err != nil {
+		return err
+	}
+
+	// Write to a temporary file and rename it so a concurrent reader never observes a partially written file.
+	tmp, err := ioutil.TempFile(filepath.Dir(f.path), filepath.Base(f.path)+".tmp")
+	if err != nil {
+		return err
+	}
+	if _, err = tmp.Write(data); err != nil {
+		_ = tmp.Close()
+		_ = os.Remove(tmp.Name())
+		return err
+	}
+	if err = tmp.Close(); err != nil {
+		_ = os.Remove(tmp.Name())
+		return err
+	}
+	return os.Rename(tmp.Name(), f.path)
+}
+
+func (f *FileSeedlistCache) read() (map[string]*Seedlist, error) {
+	entries := make(map[string]*Seedlist)
+
+	data, err := ioutil.ReadFile(f.path)
+	if os.IsNotExist(err) {
+		return entries, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if len(data) == 0 {
+		return entries, nil
+	}
+
+	if err = json.Unmarshal(data, &entries); err != nil {
+		return nil, err
+	}
+	return entries, nil
+}
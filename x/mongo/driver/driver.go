@@ -55,6 +55,25 @@ type Connection interface {
 	Stale() bool
 }
 
+// PinnedConnection represents a Connection that can be pinned by one or more cursors or transactions. Implementations
+// of this interface should maintain the following invariants:
+//
+// 1. Each Pin* call should increment the number of references for the connection.
+//
+// 2. Each Unpin* call should decrement the number of references for the connection.
+//
+// 3. Calls to Close() should be ignored until all resources have unpinned the connection.
+//
+// Pinned connections are only used for deployments that are behind a load balancer, where getMore, killCursors, and
+// transaction commands must be sent on the connection that started the cursor or transaction.
+type PinnedConnection interface {
+	Connection
+	PinToCursor() error
+	PinToTransaction() error
+	UnpinFromCursor() error
+	UnpinFromTransaction() error
+}
+
 // LocalAddresser is a type that is able to supply its local address
 type LocalAddresser interface {
 	LocalAddress() address.Address
@@ -125,6 +144,14 @@ type HandshakeInformation struct {
 	SaslSupportedMechs      []string
 }
 
+// DriverInfo contains information about a library that wraps the driver, such as an ODM or framework. It is appended
+// to the driver information sent in the client metadata of the connection handshake. Empty fields are not appended.
+type DriverInfo struct {
+	Name     string
+	Version  string
+	Platform string
+}
+
 // Handshaker is the interface implemented by types that can perform a MongoDB
 // handshake over a provided driver.Connection. This is used during connection
 // initialization. Implementations must be goroutine safe.
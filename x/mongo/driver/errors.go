@@ -16,6 +16,8 @@ var (
 	nodeIsRecoveringCodes   = []int32{11600, 11602, 13436, 189, 91}
 	notMasterCodes          = []int32{10107, 13435}
 	nodeIsShuttingDownCodes = []int32{11600, 91}
+	// StaleShardVersion, StaleEpoch, StaleConfig, and StaleDbVersion
+	staleRoutingCodes = []int32{63, 150, 13388, 249}
 
 	unknownReplWriteConcernCode   = int32(79)
 	unsatisfiableWriteConcernCode = int32(100)
@@ -94,6 +96,19 @@ func (wce WriteCommandError) UnsupportedStorageEngine() bool {
 	return false
 }
 
+// StaleRouting returns true if the write concern error or any of the write errors is a stale routing error.
+func (wce WriteCommandError) StaleRouting() bool {
+	if wce.WriteConcernError != nil && wce.WriteConcernError.StaleRouting() {
+		return true
+	}
+	for _, writeError := range wce.WriteErrors {
+		if isStaleRoutingCode(writeError.Code) {
+			return true
+		}
+	}
+	return false
+}
+
 func (wce WriteCommandError) Error() string {
 	var buf bytes.Buffer
 	fmt.Fprint(&buf, "write command error: [")
@@ -148,6 +163,11 @@ func (wce WriteConcernError) Retryable() bool {
 	return false
 }
 
+// StaleRouting returns true if this error is a stale routing error.
+func (wce WriteConcernError) StaleRouting() bool {
+	return isStaleRoutingCode(wce.Code)
+}
+
 // NodeIsRecovering returns true if this error is a node is recovering error.
 func (wce WriteConcernError) NodeIsRecovering() bool {
 	for _, code := range nodeIsRecoveringCodes {
@@ -320,6 +340,21 @@ func (e Error) NotMaster() bool {
 	return strings.Contains(e.Message, "not master")
 }
 
+// StaleRouting returns true if this error is a StaleConfig, StaleDbVersion, or similar error, which is returned by a
+// shard when the routing information used to target it is out of date.
+func (e Error) StaleRouting() bool {
+	return isStaleRoutingCode(int64(e.Code))
+}
+
+func isStaleRoutingCode(code int64) bool {
+	for _, staleCode := range staleRoutingCodes {
+		if code == int64(staleCode) {
+			return true
+		}
+	}
+	return false
+}
+
 // NamespaceNotFound returns true if this errors is a NamespaceNotFound error.
 func (e Error) NamespaceNotFound() bool {
 	return e.Code == 26 || e.Message == "ns not found"
@@ -434,6 +469,7 @@ func extractError(rdr bsoncore.Document) error {
 				for _, elem := range elems {
 					if str, ok := elem.Value().StringValueOK(); ok {
 						labels = append(labels, str)
+						wcError.WriteConcernError.Labels = append(wcError.WriteConcernError.Labels, str)
 					}
 				}
 			}
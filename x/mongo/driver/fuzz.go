@@ -0,0 +1,23 @@
+// Copyright (C) MongoDB, Inc. 2021-present.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
+
+// +build gofuzz
+
+package driver
+
+// Fuzz is the go-fuzz entry point for parsing server responses. It decompresses data as a wire message and decodes
+// the result the same way a response read from a connection is handled, so it panics if that panics.
+func Fuzz(data []byte) int {
+	var op Operation
+	wm, err := op.decompressWireMessage(data, 0)
+	if err != nil {
+		return 0
+	}
+	if _, err = op.decodeResult(wm); err != nil {
+		return 0
+	}
+	return 1
+}
@@ -40,8 +40,12 @@ var (
 const (
 	// maximum BSON object size when client side encryption is enabled
 	cryptMaxBsonObjectSize uint32 = 2097152
+	// maximum message size used when a server has not reported its maxMessageSizeBytes
+	defaultMaxMessageSize uint32 = 48000000
 	// minimum wire version necessary to use automatic encryption
 	cryptMinWireVersion int32 = 8
+	// minimum wire version necessary to use read snapshots
+	readSnapshotMinWireVersion int32 = 13
 )
 
 // InvalidOperationError is returned from Validate and indicates that a required field is missing
@@ -84,6 +88,15 @@ type finishedInformation struct {
 	redacted  bool
 }
 
+// ResponseInfo contains the context required to parse a server response.
+type ResponseInfo struct {
+	ServerResponse        bsoncore.Document
+	Server                Server
+	Connection            Connection
+	ConnectionDescription description.Server
+	CurrentIndex          int
+}
+
 // Operation is used to execute an operation. It contains all of the common code required to
 // select a server, transform an operation into a command, write the command to a connection from
 // the selected server, read a response from that connection, process the response, and potentially
@@ -110,10 +123,10 @@ type Operation struct {
 	// the SingleConnectionDeployment type.
 	Deployment Deployment
 
-	// ProcessResponseFn is called after a response to the command is returned. The server is
-	// provided for types like Cursor that are required to run subsequent commands using the same
-	// server.
-	ProcessResponseFn func(response bsoncore.Document, srvr Server, desc description.Server, currIndex int) error
+	// ProcessResponseFn is called after a response to the command is returned. The server and
+	// connection are provided for types like Cursor that are required to run subsequent commands
+	// using the same server or connection.
+	ProcessResponseFn func(info ResponseInfo) error
 
 	// Selector is the server selector that's used during both initial server selection and
 	// subsequent selection for retries. Depending on the Deployment implementation, the
@@ -213,6 +226,42 @@ func (op Operation) selectServer(ctx context.Context) (Server, error) {
 	return op.Deployment.SelectServer(ctx, selector)
 }
 
+// getServerAndConnection should be used to retrieve a Server and Connection to execute an operation.
+func (op Operation) getServerAndConnection(ctx context.Context) (Server, Connection, error) {
+	srvr, err := op.selectServer(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// If the provided client session has a pinned connection, it should be used for the operation because this
+	// indicates that we're in a transaction and the target server is behind a load balancer.
+	if op.Client != nil && op.Client.PinnedConnection != nil {
+		return srvr, op.Client.PinnedConnection, nil
+	}
+
+	conn, err := srvr.Connection(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// If we're in load balanced mode and this is the first operation in a transaction, pin the session to a connection.
+	if conn.Description().LoadBalanced() && op.Client != nil && op.Client.TransactionStarting() {
+		pinnedConn, ok := conn.(PinnedConnection)
+		if !ok {
+			// Close the original connection to avoid a leak.
+			_ = conn.Close()
+			return nil, nil, fmt.Errorf("expected Connection used to start a transaction to be a PinnedConnection, but got %T", conn)
+		}
+		if err := pinnedConn.PinToTransaction(); err != nil {
+			_ = conn.Close()
+			return nil, nil, fmt.Errorf("error incrementing connection reference count when starting a transaction: %v", err)
+		}
+		op.Client.PinnedConnection = pinnedConn
+	}
+
+	return srvr, conn, nil
+}
+
 // Validate validates this operation, ensuring the fields are set properly.
 func (op Operation) Validate() error {
 	if op.CommandFn == nil {
@@ -237,13 +286,12 @@ func (op Operation) Execute(ctx context.Context, scratch []byte) error {
 	if err != nil {
 		return err
 	}
-
-	srvr, err := op.selectServer(ctx)
-	if err != nil {
-		return err
+	if ctx == nil {
+		ctx = context.Background()
 	}
+	ctx = withOperationID(ctx)
 
-	conn, err := srvr.Connection(ctx)
+	srvr, conn, err := op.getServerAndConnection(ctx)
 	if err != nil {
 		return err
 	}
@@ -275,6 +323,7 @@ func (op Operation) Execute(ctx context.Context, scratch []byte) error {
 	var operationErr WriteCommandError
 	var original error
 	var retries int
+	var staleRoutingRetried bool
 	retryable := op.retryable(desc.Server)
 	if retryable && op.RetryMode != nil {
 		switch op.Type {
@@ -394,15 +443,29 @@ func (op Operation) Execute(ctx context.Context, scratch []byte) error {
 				tt.Labels = append(tt.Labels, RetryableWriteError)
 			}
 
-			if retryable && retryableErr && retries != 0 {
-				retries--
+			// Shards can also report stale routing information as write errors or write concern errors. These are
+			// retried under the same conditions as a top-level stale routing error below.
+			if tt.StaleRouting() && !staleRoutingRetried && !op.Client.TransactionRunning() &&
+				((op.Type == Read && retryable && retryEnabled) || (retryable && op.Client != nil && op.Client.RetryWrite)) {
+				staleRoutingRetried = true
 				original, err = err, nil
 				conn.Close() // Avoid leaking the connection.
-				srvr, err = op.selectServer(ctx)
-				if err != nil {
+				srvr, conn, err = op.getServerAndConnection(ctx)
+				if err != nil || conn == nil || (op.Type == Write && !op.retryable(conn.Description())) {
+					if conn != nil {
+						conn.Close()
+					}
 					return original
 				}
-				conn, err = srvr.Connection(ctx)
+				defer conn.Close() // Avoid leaking the new connection.
+				continue
+			}
+
+			if retryable && retryableErr && retries != 0 {
+				retries--
+				original, err = err, nil
+				conn.Close() // Avoid leaking the connection.
+				srvr, conn, err = op.getServerAndConnection(ctx)
 				if err != nil || conn == nil || !op.retryable(conn.Description()) {
 					if conn != nil {
 						conn.Close()
@@ -419,9 +482,7 @@ func (op Operation) Execute(ctx context.Context, scratch []byte) error {
 			}
 
 			// If the operation isn't being retried, process the response
-			if op.ProcessResponseFn != nil {
-				perr = op.ProcessResponseFn(res, srvr, desc.Server, currIndex)
-			}
+			perr = op.processResponse(res, srvr, conn, desc.Server, currIndex)
 
 			if batching && len(tt.WriteErrors) > 0 && currIndex > 0 {
 				for i := range tt.WriteErrors {
@@ -457,7 +518,7 @@ func (op Operation) Execute(ctx context.Context, scratch []byte) error {
 			operationErr.Labels = tt.Labels
 		case Error:
 			if tt.HasErrorLabel(TransientTransactionError) || tt.HasErrorLabel(UnknownTransactionCommitResult) {
-				op.Client.ClearPinnedServer()
+				_ = op.Client.ClearPinnedResources()
 			}
 			if e := err.(Error); retryable && op.Type == Write && e.UnsupportedStorageEngine() {
 				return ErrUnsupportedStorageEngine
@@ -479,15 +540,31 @@ func (op Operation) Execute(ctx context.Context, scratch []byte) error {
 				retryableErr = tt.RetryableRead()
 			}
 
-			if retryable && retryableErr && retries != 0 {
-				retries--
+			// A stale routing error means that the shard rejected the operation because the router targeted it with
+			// out of date routing information. The router refreshes its routing information when it sees the error,
+			// so the operation is retried once on a newly selected server. The operation must be retryable under its
+			// retry mode so that writes, including aggregations with a $out or $merge stage, can't be applied twice.
+			if tt.StaleRouting() && !staleRoutingRetried && !op.Client.TransactionRunning() &&
+				((op.Type == Read && retryable && retryEnabled) || (retryable && op.Client != nil && op.Client.RetryWrite)) {
+				staleRoutingRetried = true
 				original, err = err, nil
 				conn.Close() // Avoid leaking the connection.
-				srvr, err = op.selectServer(ctx)
-				if err != nil {
+				srvr, conn, err = op.getServerAndConnection(ctx)
+				if err != nil || conn == nil || (op.Type == Write && !op.retryable(conn.Description())) {
+					if conn != nil {
+						conn.Close()
+					}
 					return original
 				}
-				conn, err = srvr.Connection(ctx)
+				defer conn.Close() // Avoid leaking the new connection.
+				continue
+			}
+
+			if retryable && retryableErr && retries != 0 {
+				retries--
+				original, err = err, nil
+				conn.Close() // Avoid leaking the connection.
+				srvr, conn, err = op.getServerAndConnection(ctx)
 				if err != nil || conn == nil || !op.retryable(conn.Description()) {
 					if conn != nil {
 						conn.Close()
@@ -504,9 +581,7 @@ func (op Operation) Execute(ctx context.Context, scratch []byte) error {
 			}
 
 			// If the operation isn't being retried, process the response
-			if op.ProcessResponseFn != nil {
-				perr = op.ProcessResponseFn(res, srvr, desc.Server, currIndex)
-			}
+			perr = op.processResponse(res, srvr, conn, desc.Server, currIndex)
 
 			if op.Client != nil && op.Client.Committing && (retryableErr || tt.Code == 50) {
 				// If we got a retryable error or MaxTimeMSExpired error, we add UnknownTransactionCommitResult.
@@ -517,16 +592,12 @@ func (op Operation) Execute(ctx context.Context, scratch []byte) error {
 			if moreToCome {
 				return ErrUnacknowledgedWrite
 			}
-			if op.ProcessResponseFn != nil {
-				perr = op.ProcessResponseFn(res, srvr, desc.Server, currIndex)
-			}
+			perr = op.processResponse(res, srvr, conn, desc.Server, currIndex)
 			if perr != nil {
 				return perr
 			}
 		default:
-			if op.ProcessResponseFn != nil {
-				perr = op.ProcessResponseFn(res, srvr, desc.Server, currIndex)
-			}
+			perr = op.processResponse(res, srvr, conn, desc.Server, currIndex)
 			return err
 		}
 
@@ -551,6 +622,23 @@ func (op Operation) Execute(ctx context.Context, scratch []byte) error {
 	return nil
 }
 
+// processResponse calls ProcessResponseFn with the response and the server and connection it was read from, if
+// ProcessResponseFn is set.
+func (op Operation) processResponse(res bsoncore.Document, srvr Server, conn Connection, desc description.Server,
+	currIndex int) error {
+
+	if op.ProcessResponseFn == nil {
+		return nil
+	}
+	return op.ProcessResponseFn(ResponseInfo{
+		ServerResponse:        res,
+		Server:                srvr,
+		Connection:            conn,
+		ConnectionDescription: desc,
+		CurrentIndex:          currIndex,
+	})
+}
+
 // Retryable writes are supported if the server supports sessions, the operation is not
 // within a transaction, and the write is acknowledged
 func (op Operation) retryable(desc description.Server) bool {
@@ -623,7 +711,7 @@ func (op Operation) readWireMessage(ctx context.Context, conn Connection, wm []b
 	}
 
 	// decompress wiremessage
-	wm, err = op.decompressWireMessage(wm)
+	wm, err = op.decompressWireMessage(wm, conn.Description().MaxMessageSize)
 	if err != nil {
 		return nil, err
 	}
@@ -635,6 +723,7 @@ func (op Operation) readWireMessage(ctx context.Context, conn Connection, wm []b
 	op.updateClusterTimes(res)
 	op.updateOperationTime(res)
 	op.Client.UpdateRecoveryToken(bson.Raw(res))
+	op.Client.UpdateSnapshotTime(res)
 
 	if err != nil {
 		return res, err
@@ -661,8 +750,9 @@ func (op *Operation) moreToComeRoundTrip(ctx context.Context, conn Connection, w
 }
 
 // decompressWireMessage handles decompressing a wiremessage. If the wiremessage
-// is not compressed, this method will return the wiremessage.
-func (Operation) decompressWireMessage(wm []byte) ([]byte, error) {
+// is not compressed, this method will return the wiremessage. The uncompressed
+// size must not exceed maxMessageSize, or the default maximum if it is 0.
+func (Operation) decompressWireMessage(wm []byte, maxMessageSize uint32) ([]byte, error) {
 	// read the header and ensure this is a compressed wire message
 	length, reqid, respto, opcode, rem, ok := wiremessage.ReadHeader(wm)
 	if !ok || len(wm) < int(length) {
@@ -680,6 +770,12 @@ func (Operation) decompressWireMessage(wm []byte) ([]byte, error) {
 	if !ok {
 		return nil, errors.New("malformed OP_COMPRESSED: missing uncompressed size")
 	}
+	if maxMessageSize == 0 {
+		maxMessageSize = defaultMaxMessageSize
+	}
+	if uncompressedSize < 0 || uint32(uncompressedSize)+16 > maxMessageSize {
+		return nil, fmt.Errorf("malformed OP_COMPRESSED: invalid uncompressed size %d", uncompressedSize)
+	}
 	// get the compressor ID and decompress the message
 	compressorID, rem, ok := wiremessage.ReadCompressedCompressorID(rem)
 	if !ok {
@@ -825,6 +921,7 @@ func (op Operation) createMsgWireMessage(ctx context.Context, dst []byte, desc d
 	if err != nil {
 		return dst, info, err
 	}
+	dst = op.addOperationLabels(ctx, dst, idx, desc)
 	dst, err = op.addReadConcern(dst, desc)
 	if err != nil {
 		return dst, info, err
@@ -947,6 +1044,13 @@ func (op Operation) addReadConcern(dst []byte, desc description.SelectedServer)
 		rc = readconcern.New()
 	}
 
+	if client != nil && client.Snapshot && op.Type == Read {
+		if desc.WireVersion == nil || desc.WireVersion.Max < readSnapshotMinWireVersion {
+			return dst, errors.New("snapshot reads require MongoDB 5.0 or later")
+		}
+		rc = readconcern.Snapshot()
+	}
+
 	if rc == nil {
 		return dst, nil
 	}
@@ -956,6 +1060,12 @@ func (op Operation) addReadConcern(dst []byte, desc description.SelectedServer)
 		return dst, err
 	}
 
+	if client != nil && client.Snapshot && client.SnapshotTime != nil && op.Type == Read {
+		data = data[:len(data)-1] // remove the null byte
+		data = bsoncore.AppendTimestampElement(data, "atClusterTime", client.SnapshotTime.T, client.SnapshotTime.I)
+		data, _ = bsoncore.AppendDocumentEnd(data, 0)
+	}
+
 	if sessionsSupported(desc.WireVersion) && client != nil && client.Consistent && client.OperationTime != nil {
 		data = data[:len(data)-1] // remove the null byte
 		data = bsoncore.AppendTimestampElement(data, "afterClusterTime", client.OperationTime.T, client.OperationTime.I)
@@ -1251,6 +1361,10 @@ func (Operation) decodeOpReply(wm []byte, includesHeader bool) opReply {
 	}
 
 	if reply.responseFlags&wiremessage.QueryFailure == wiremessage.QueryFailure {
+		if len(reply.documents) == 0 {
+			reply.err = errors.New("malformed OP_REPLY: missing query failure document")
+			return reply
+		}
 		reply.err = QueryFailureError{
 			Message:  "command failure",
 			Response: reply.documents[0],
@@ -1360,6 +1474,29 @@ func (op *Operation) redactCommand(cmd string, doc bsoncore.Document) bool {
 	return err == nil
 }
 
+// commandOptionKeys are the top-level command fields that are reported in CommandStartedEvent.Options.
+var commandOptionKeys = []string{"maxTimeMS", "batchSize", "hint", "collation"}
+
+// commandOptions returns a document containing the fields of cmd that are listed in commandOptionKeys. The batchSize of
+// an aggregate command is nested in its cursor document, so it is reported if there is no top-level batchSize.
+func commandOptions(cmd bsoncore.Document) bson.Raw {
+	idx, opts := bsoncore.AppendDocumentStart(nil)
+	for _, key := range commandOptionKeys {
+		val, err := cmd.LookupErr(key)
+		if err == nil {
+			opts = bsoncore.AppendValueElement(opts, key, val)
+			continue
+		}
+		if key == "batchSize" {
+			if val, err = cmd.LookupErr("cursor", "batchSize"); err == nil {
+				opts = bsoncore.AppendValueElement(opts, key, val)
+			}
+		}
+	}
+	opts, _ = bsoncore.AppendDocumentEnd(opts, idx)
+	return opts
+}
+
 // publishStartedEvent publishes a CommandStartedEvent to the operation's command monitor if possible. If the command is
 // an unacknowledged write, a CommandSucceededEvent will be published as well. If started events are not being monitored,
 // no events are published.
@@ -1382,11 +1519,16 @@ func (op Operation) publishStartedEvent(ctx context.Context, info startedInforma
 	}
 
 	started := &event.CommandStartedEvent{
-		Command:      cmdCopy,
-		DatabaseName: op.Database,
-		CommandName:  info.cmdName,
-		RequestID:    int64(info.requestID),
-		ConnectionID: info.connID,
+		Command:       cmdCopy,
+		DatabaseName:  op.Database,
+		CommandName:   info.cmdName,
+		RequestID:     int64(info.requestID),
+		ConnectionID:  info.connID,
+		OperationID:   OperationID(ctx),
+		CorrelationID: CorrelationID(ctx),
+	}
+	if !info.redacted {
+		started.Options = commandOptions(info.cmd)
 	}
 	op.CommandMonitor.Started(ctx, started)
 }
@@ -1413,6 +1555,8 @@ func (op Operation) publishFinishedEvent(ctx context.Context, info finishedInfor
 		RequestID:     int64(info.requestID),
 		ConnectionID:  info.connID,
 		DurationNanos: durationNanos,
+		OperationID:   OperationID(ctx),
+		CorrelationID: CorrelationID(ctx),
 	}
 
 	if success {
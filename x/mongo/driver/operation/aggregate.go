@@ -29,8 +29,9 @@ type Aggregate struct {
 	batchSize                *int32
 	bypassDocumentValidation *bool
 	collation                bsoncore.Document
-	comment                  *string
+	comment                  bsoncore.Value
 	hint                     bsoncore.Value
+	let                      bsoncore.Document
 	maxTimeMS                *int64
 	pipeline                 bsoncore.Document
 	session                  *session.Client
@@ -71,10 +72,10 @@ func (a *Aggregate) ResultCursorResponse() driver.CursorResponse {
 	return a.result
 }
 
-func (a *Aggregate) processResponse(response bsoncore.Document, srvr driver.Server, desc description.Server, currIndex int) error {
+func (a *Aggregate) processResponse(info driver.ResponseInfo) error {
 	var err error
 
-	a.result, err = driver.NewCursorResponse(response, srvr, desc)
+	a.result, err = driver.NewCursorResponse(info)
 	return err
 
 }
@@ -133,14 +134,18 @@ func (a *Aggregate) command(dst []byte, desc description.SelectedServer) ([]byte
 		}
 		dst = bsoncore.AppendDocumentElement(dst, "collation", a.collation)
 	}
-	if a.comment != nil {
+	if a.comment.Type != bsontype.Type(0) {
 
-		dst = bsoncore.AppendStringElement(dst, "comment", *a.comment)
+		dst = bsoncore.AppendValueElement(dst, "comment", a.comment)
 	}
 	if a.hint.Type != bsontype.Type(0) {
 
 		dst = bsoncore.AppendValueElement(dst, "hint", a.hint)
 	}
+	if a.let != nil {
+
+		dst = bsoncore.AppendDocumentElement(dst, "let", a.let)
+	}
 	if a.maxTimeMS != nil {
 
 		dst = bsoncore.AppendInt64Element(dst, "maxTimeMS", *a.maxTimeMS)
@@ -195,13 +200,13 @@ func (a *Aggregate) Collation(collation bsoncore.Document) *Aggregate {
 	return a
 }
 
-// Comment specifies an arbitrary string to help trace the operation through the database profiler, currentOp, and logs.
-func (a *Aggregate) Comment(comment string) *Aggregate {
+// Comment specifies an arbitrary value to help trace the operation through the database profiler, currentOp, and logs.
+func (a *Aggregate) Comment(comment bsoncore.Value) *Aggregate {
 	if a == nil {
 		a = new(Aggregate)
 	}
 
-	a.comment = &comment
+	a.comment = comment
 	return a
 }
 
@@ -235,6 +240,16 @@ func (a *Aggregate) Pipeline(pipeline bsoncore.Document) *Aggregate {
 	return a
 }
 
+// Let specifies the let document to use. This option is only valid for server versions 5.0 and above.
+func (a *Aggregate) Let(let bsoncore.Document) *Aggregate {
+	if a == nil {
+		a = new(Aggregate)
+	}
+
+	a.let = let
+	return a
+}
+
 // Session sets the session for this operation.
 func (a *Aggregate) Session(session *session.Client) *Aggregate {
 	if a == nil {
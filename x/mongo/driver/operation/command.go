@@ -26,28 +26,38 @@ type Command struct {
 	clock          *session.ClusterClock
 	session        *session.Client
 	monitor        *event.CommandMonitor
-	result         bsoncore.Document
-	srvr           driver.Server
-	desc           description.Server
+	resultResponse bsoncore.Document
+	resultCursor   *driver.BatchCursor
 	crypt          *driver.Crypt
 	serverAPI      *driver.ServerAPIOptions
+	createCursor   bool
+	cursorOpts     driver.CursorOptions
 }
 
 // NewCommand constructs and returns a new Command.
 func NewCommand(command bsoncore.Document) *Command { return &Command{command: command} }
 
+// NewCursorCommand constructs a new Command whose response is parsed as a cursor. The cursor is created while the
+// response is processed so it can be pinned to the connection used for the command in load balanced mode.
+func NewCursorCommand(command bsoncore.Document, cursorOpts driver.CursorOptions) *Command {
+	return &Command{
+		command:      command,
+		cursorOpts:   cursorOpts,
+		createCursor: true,
+	}
+}
+
 // Result returns the result of executing this operation.
-func (c *Command) Result() bsoncore.Document { return c.result }
+func (c *Command) Result() bsoncore.Document { return c.resultResponse }
 
-// ResultCursor parses the command response as a cursor and returns the resulting BatchCursor.
-func (c *Command) ResultCursor(opts driver.CursorOptions) (*driver.BatchCursor, error) {
-	cursorRes, err := driver.NewCursorResponse(c.result, c.srvr, c.desc)
-	if err != nil {
-		return nil, err
+// ResultCursor returns the BatchCursor that was created from the command response. It returns an error if the Command
+// was not created with NewCursorCommand.
+func (c *Command) ResultCursor() (*driver.BatchCursor, error) {
+	if !c.createCursor {
+		return nil, errors.New("command with no cursor options cannot construct a cursor")
 	}
 
-	opts.ServerAPI = c.serverAPI
-	return driver.NewBatchCursor(cursorRes, c.session, c.clock, opts)
+	return c.resultCursor, nil
 }
 
 // Execute runs this operations and returns an error if the operaiton did not execute successfully.
@@ -60,10 +70,19 @@ func (c *Command) Execute(ctx context.Context) error {
 		CommandFn: func(dst []byte, desc description.SelectedServer) ([]byte, error) {
 			return append(dst, c.command[4:len(c.command)-1]...), nil
 		},
-		ProcessResponseFn: func(resp bsoncore.Document, srvr driver.Server, desc description.Server, currIndex int) error {
-			c.result = resp
-			c.srvr = srvr
-			c.desc = desc
+		ProcessResponseFn: func(info driver.ResponseInfo) error {
+			c.resultResponse = info.ServerResponse
+
+			if c.createCursor {
+				cursorRes, err := driver.NewCursorResponse(info)
+				if err != nil {
+					return err
+				}
+
+				c.cursorOpts.ServerAPI = c.serverAPI
+				c.resultCursor, err = driver.NewBatchCursor(cursorRes, c.session, c.clock, c.cursorOpts)
+				return err
+			}
 			return nil
 		},
 		Client:         c.session,
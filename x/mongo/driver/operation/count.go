@@ -13,6 +13,7 @@ import (
 	"errors"
 	"fmt"
 
+	"go.mongodb.org/mongo-driver/bson/bsontype"
 	"go.mongodb.org/mongo-driver/event"
 	"go.mongodb.org/mongo-driver/mongo/description"
 	"go.mongodb.org/mongo-driver/mongo/readconcern"
@@ -24,6 +25,7 @@ import (
 
 // Performs a count operation
 type Count struct {
+	comment        bsoncore.Value
 	maxTimeMS      *int64
 	query          bsoncore.Document
 	session        *session.Client
@@ -97,9 +99,9 @@ func NewCount() *Count {
 // Result returns the result of executing this operation.
 func (c *Count) Result() CountResult { return c.result }
 
-func (c *Count) processResponse(response bsoncore.Document, srvr driver.Server, desc description.Server, _ int) error {
+func (c *Count) processResponse(info driver.ResponseInfo) error {
 	var err error
-	c.result, err = buildCountResult(response, srvr)
+	c.result, err = buildCountResult(info.ServerResponse, info.Server)
 	return err
 }
 
@@ -174,6 +176,9 @@ func (c *Count) command(dst []byte, desc description.SelectedServer) ([]byte, er
 	if c.maxTimeMS != nil {
 		dst = bsoncore.AppendInt64Element(dst, "maxTimeMS", *c.maxTimeMS)
 	}
+	if c.comment.Type != bsontype.Type(0) {
+		dst = bsoncore.AppendValueElement(dst, "comment", c.comment)
+	}
 	return dst, nil
 }
 
@@ -197,6 +202,16 @@ func (c *Count) Query(query bsoncore.Document) *Count {
 	return c
 }
 
+// Comment sets a value to help trace an operation through the database profiler, currentOp, and logs.
+func (c *Count) Comment(comment bsoncore.Value) *Count {
+	if c == nil {
+		c = new(Count)
+	}
+
+	c.comment = comment
+	return c
+}
+
 // Session sets the session for this operation.
 func (c *Count) Session(session *session.Client) *Count {
 	if c == nil {
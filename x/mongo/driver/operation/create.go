@@ -23,13 +23,17 @@ import (
 // Create a create operation
 type Create struct {
 	capped              *bool
+	clusteredIndex      bsoncore.Document
 	collation           bsoncore.Document
 	collectionName      *string
+	encryptedFields     bsoncore.Document
+	expireAfterSeconds  *int64
 	indexOptionDefaults bsoncore.Document
 	max                 *int64
 	pipeline            bsoncore.Document
 	size                *int64
 	storageEngine       bsoncore.Document
+	timeseries          bsoncore.Document
 	validationAction    *string
 	validationLevel     *string
 	validator           bsoncore.Document
@@ -52,7 +56,7 @@ func NewCreate(collectionName string) *Create {
 	}
 }
 
-func (c *Create) processResponse(response bsoncore.Document, srvr driver.Server, desc description.Server, _ int) error {
+func (c *Create) processResponse(info driver.ResponseInfo) error {
 	var err error
 	return err
 }
@@ -86,12 +90,27 @@ func (c *Create) command(dst []byte, desc description.SelectedServer) ([]byte, e
 	if c.capped != nil {
 		dst = bsoncore.AppendBooleanElement(dst, "capped", *c.capped)
 	}
+	if c.clusteredIndex != nil {
+		if desc.WireVersion == nil || !desc.WireVersion.Includes(13) {
+			return nil, errors.New("the 'clusteredIndex' command parameter requires a minimum server wire version of 13")
+		}
+		dst = bsoncore.AppendDocumentElement(dst, "clusteredIndex", c.clusteredIndex)
+	}
 	if c.collation != nil {
 		if desc.WireVersion == nil || !desc.WireVersion.Includes(5) {
 			return nil, errors.New("the 'collation' command parameter requires a minimum server wire version of 5")
 		}
 		dst = bsoncore.AppendDocumentElement(dst, "collation", c.collation)
 	}
+	if c.encryptedFields != nil {
+		if desc.WireVersion == nil || !desc.WireVersion.Includes(21) {
+			return nil, errors.New("the 'encryptedFields' command parameter requires a minimum server wire version of 21")
+		}
+		dst = bsoncore.AppendDocumentElement(dst, "encryptedFields", c.encryptedFields)
+	}
+	if c.expireAfterSeconds != nil {
+		dst = bsoncore.AppendInt64Element(dst, "expireAfterSeconds", *c.expireAfterSeconds)
+	}
 	if c.indexOptionDefaults != nil {
 		dst = bsoncore.AppendDocumentElement(dst, "indexOptionDefaults", c.indexOptionDefaults)
 	}
@@ -107,6 +126,9 @@ func (c *Create) command(dst []byte, desc description.SelectedServer) ([]byte, e
 	if c.storageEngine != nil {
 		dst = bsoncore.AppendDocumentElement(dst, "storageEngine", c.storageEngine)
 	}
+	if c.timeseries != nil {
+		dst = bsoncore.AppendDocumentElement(dst, "timeseries", c.timeseries)
+	}
 	if c.validationAction != nil {
 		dst = bsoncore.AppendStringElement(dst, "validationAction", *c.validationAction)
 	}
@@ -132,6 +154,16 @@ func (c *Create) Capped(capped bool) *Create {
 	return c
 }
 
+// ClusteredIndex specifies the clustered index of the collection.
+func (c *Create) ClusteredIndex(clusteredIndex bsoncore.Document) *Create {
+	if c == nil {
+		c = new(Create)
+	}
+
+	c.clusteredIndex = clusteredIndex
+	return c
+}
+
 // Collation specifies a collation. This option is only valid for server versions 3.4 and above.
 func (c *Create) Collation(collation bsoncore.Document) *Create {
 	if c == nil {
@@ -152,6 +184,26 @@ func (c *Create) CollectionName(collectionName string) *Create {
 	return c
 }
 
+// EncryptedFields specifies the fields of the collection that are encrypted with Queryable Encryption.
+func (c *Create) EncryptedFields(encryptedFields bsoncore.Document) *Create {
+	if c == nil {
+		c = new(Create)
+	}
+
+	c.encryptedFields = encryptedFields
+	return c
+}
+
+// Specifies the number of seconds after which documents in a time series collection are deleted.
+func (c *Create) ExpireAfterSeconds(expireAfterSeconds int64) *Create {
+	if c == nil {
+		c = new(Create)
+	}
+
+	c.expireAfterSeconds = &expireAfterSeconds
+	return c
+}
+
 // Specifies a default configuration for indexes on the collection.
 func (c *Create) IndexOptionDefaults(indexOptionDefaults bsoncore.Document) *Create {
 	if c == nil {
@@ -202,6 +254,16 @@ func (c *Create) StorageEngine(storageEngine bsoncore.Document) *Create {
 	return c
 }
 
+// Specifies the options for a time series collection. This option is only valid for server versions 5.0 and above.
+func (c *Create) Timeseries(timeseries bsoncore.Document) *Create {
+	if c == nil {
+		c = new(Create)
+	}
+
+	c.timeseries = timeseries
+	return c
+}
+
 // Specifies what should happen if a document being inserted does not pass validation.
 func (c *Create) ValidationAction(validationAction string) *Create {
 	if c == nil {
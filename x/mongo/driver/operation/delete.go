@@ -13,6 +13,7 @@ import (
 	"errors"
 	"fmt"
 
+	"go.mongodb.org/mongo-driver/bson/bsontype"
 	"go.mongodb.org/mongo-driver/event"
 	"go.mongodb.org/mongo-driver/mongo/description"
 	"go.mongodb.org/mongo-driver/mongo/writeconcern"
@@ -23,7 +24,9 @@ import (
 
 // Delete performs a delete operation
 type Delete struct {
+	comment      bsoncore.Value
 	deletes      []bsoncore.Document
+	let          bsoncore.Document
 	ordered      *bool
 	session      *session.Client
 	clock        *session.ClusterClock
@@ -43,6 +46,8 @@ type Delete struct {
 type DeleteResult struct {
 	// Number of documents successfully deleted.
 	N int32
+	// The server replies for the delete commands sent for this operation, one per batch.
+	Replies []bsoncore.Document
 }
 
 func buildDeleteResult(response bsoncore.Document, srvr driver.Server) (DeleteResult, error) {
@@ -74,9 +79,10 @@ func NewDelete(deletes ...bsoncore.Document) *Delete {
 // Result returns the result of executing this operation.
 func (d *Delete) Result() DeleteResult { return d.result }
 
-func (d *Delete) processResponse(response bsoncore.Document, srvr driver.Server, desc description.Server, _ int) error {
-	dr, err := buildDeleteResult(response, srvr)
+func (d *Delete) processResponse(info driver.ResponseInfo) error {
+	dr, err := buildDeleteResult(info.ServerResponse, info.Server)
 	d.result.N += dr.N
+	d.result.Replies = append(d.result.Replies, append(bsoncore.Document(nil), info.ServerResponse...))
 	return err
 }
 
@@ -123,6 +129,12 @@ func (d *Delete) command(dst []byte, desc description.SelectedServer) ([]byte, e
 			return nil, errUnacknowledgedHint
 		}
 	}
+	if d.comment.Type != bsontype.Type(0) {
+		dst = bsoncore.AppendValueElement(dst, "comment", d.comment)
+	}
+	if d.let != nil {
+		dst = bsoncore.AppendDocumentElement(dst, "let", d.let)
+	}
 	return dst, nil
 }
 
@@ -149,6 +161,26 @@ func (d *Delete) Ordered(ordered bool) *Delete {
 	return d
 }
 
+// Comment sets a value to help trace an operation through the database profiler, currentOp, and logs.
+func (d *Delete) Comment(comment bsoncore.Value) *Delete {
+	if d == nil {
+		d = new(Delete)
+	}
+
+	d.comment = comment
+	return d
+}
+
+// Let specifies the let document to use. This option is only valid for server versions 5.0 and above.
+func (d *Delete) Let(let bsoncore.Document) *Delete {
+	if d == nil {
+		d = new(Delete)
+	}
+
+	d.let = let
+	return d
+}
+
 // Session sets the session for this operation.
 func (d *Delete) Session(session *session.Client) *Delete {
 	if d == nil {
@@ -12,6 +12,7 @@ import (
 	"context"
 	"errors"
 
+	"go.mongodb.org/mongo-driver/bson/bsontype"
 	"go.mongodb.org/mongo-driver/event"
 	"go.mongodb.org/mongo-driver/mongo/description"
 	"go.mongodb.org/mongo-driver/mongo/readconcern"
@@ -24,6 +25,8 @@ import (
 // Distinct performs a distinct operation.
 type Distinct struct {
 	collation      bsoncore.Document
+	comment        bsoncore.Value
+	hint           bsoncore.Value
 	key            *string
 	maxTimeMS      *int64
 	query          bsoncore.Document
@@ -73,9 +76,9 @@ func NewDistinct(key string, query bsoncore.Document) *Distinct {
 // Result returns the result of executing this operation.
 func (d *Distinct) Result() DistinctResult { return d.result }
 
-func (d *Distinct) processResponse(response bsoncore.Document, srvr driver.Server, desc description.Server, _ int) error {
+func (d *Distinct) processResponse(info driver.ResponseInfo) error {
 	var err error
-	d.result, err = buildDistinctResult(response, srvr)
+	d.result, err = buildDistinctResult(info.ServerResponse, info.Server)
 	return err
 }
 
@@ -112,6 +115,12 @@ func (d *Distinct) command(dst []byte, desc description.SelectedServer) ([]byte,
 		}
 		dst = bsoncore.AppendDocumentElement(dst, "collation", d.collation)
 	}
+	if d.comment.Type != bsontype.Type(0) {
+		dst = bsoncore.AppendValueElement(dst, "comment", d.comment)
+	}
+	if d.hint.Type != bsontype.Type(0) {
+		dst = bsoncore.AppendValueElement(dst, "hint", d.hint)
+	}
 	if d.key != nil {
 		dst = bsoncore.AppendStringElement(dst, "key", *d.key)
 	}
@@ -164,6 +173,26 @@ func (d *Distinct) Query(query bsoncore.Document) *Distinct {
 	return d
 }
 
+// Comment sets a value to help trace an operation through the database profiler, currentOp, and logs.
+func (d *Distinct) Comment(comment bsoncore.Value) *Distinct {
+	if d == nil {
+		d = new(Distinct)
+	}
+
+	d.comment = comment
+	return d
+}
+
+// Hint specifies the index to use.
+func (d *Distinct) Hint(hint bsoncore.Value) *Distinct {
+	if d == nil {
+		d = new(Distinct)
+	}
+
+	d.hint = hint
+	return d
+}
+
 // Session sets the session for this operation.
 func (d *Distinct) Session(session *session.Client) *Distinct {
 	if d == nil {
@@ -39,7 +39,7 @@ func NewEndSessions(sessionIDs bsoncore.Document) *EndSessions {
 	}
 }
 
-func (es *EndSessions) processResponse(response bsoncore.Document, srvr driver.Server, desc description.Server, _ int) error {
+func (es *EndSessions) processResponse(info driver.ResponseInfo) error {
 	var err error
 	return err
 }
@@ -0,0 +1,183 @@
+// Copyright (C) MongoDB, Inc. 2021-present.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
+
+// NOTE: This file is maintained by hand because operationgen cannot generate it.
+
+package operation
+
+import (
+	"context"
+	"errors"
+
+	"go.mongodb.org/mongo-driver/event"
+	"go.mongodb.org/mongo-driver/mongo/description"
+	"go.mongodb.org/mongo-driver/mongo/readpref"
+	"go.mongodb.org/mongo-driver/x/bsonx/bsoncore"
+	"go.mongodb.org/mongo-driver/x/mongo/driver"
+	"go.mongodb.org/mongo-driver/x/mongo/driver/session"
+)
+
+// Explainable is an operation whose command can be explained, e.g. a Find, Aggregate, or Distinct. Only the command
+// of the operation is used; its session, deployment, and other execution settings are ignored.
+type Explainable interface {
+	command(dst []byte, desc description.SelectedServer) ([]byte, error)
+}
+
+// Explain runs the explain command for the command of another operation.
+type Explain struct {
+	explainable    Explainable
+	verbosity      *string
+	database       string
+	deployment     driver.Deployment
+	selector       description.ServerSelector
+	readPreference *readpref.ReadPref
+	clock          *session.ClusterClock
+	session        *session.Client
+	monitor        *event.CommandMonitor
+	serverAPI      *driver.ServerAPIOptions
+	result         bsoncore.Document
+}
+
+// NewExplain constructs and returns a new Explain for the command of explainable.
+func NewExplain(explainable Explainable) *Explain { return &Explain{explainable: explainable} }
+
+// Result returns the explain output returned by the server.
+func (e *Explain) Result() bsoncore.Document { return e.result }
+
+// Execute runs this operation and returns an error if the operation did not execute successfully.
+func (e *Explain) Execute(ctx context.Context) error {
+	if e.deployment == nil {
+		return errors.New("the Explain operation must have a Deployment set before Execute can be called")
+	}
+	if e.explainable == nil {
+		return errors.New("the Explain operation must have an operation to explain set before Execute can be called")
+	}
+
+	return driver.Operation{
+		CommandFn: e.command,
+		ProcessResponseFn: func(info driver.ResponseInfo) error {
+			e.result = info.ServerResponse
+			return nil
+		},
+		Type:           driver.Read,
+		Client:         e.session,
+		Clock:          e.clock,
+		CommandMonitor: e.monitor,
+		Database:       e.database,
+		Deployment:     e.deployment,
+		ReadPreference: e.readPreference,
+		Selector:       e.selector,
+		ServerAPI:      e.serverAPI,
+	}.Execute(ctx, nil)
+}
+
+func (e *Explain) command(dst []byte, desc description.SelectedServer) ([]byte, error) {
+	idx, cmd := bsoncore.AppendDocumentStart(nil)
+	cmd, err := e.explainable.command(cmd, desc)
+	if err != nil {
+		return nil, err
+	}
+	cmd, err = bsoncore.AppendDocumentEnd(cmd, idx)
+	if err != nil {
+		return nil, err
+	}
+
+	dst = bsoncore.AppendDocumentElement(dst, "explain", cmd)
+	if e.verbosity != nil {
+		dst = bsoncore.AppendStringElement(dst, "verbosity", *e.verbosity)
+	}
+	return dst, nil
+}
+
+// Verbosity sets the verbosity of the explain output, e.g. "queryPlanner", "executionStats", or "allPlansExecution".
+func (e *Explain) Verbosity(verbosity string) *Explain {
+	if e == nil {
+		e = new(Explain)
+	}
+
+	e.verbosity = &verbosity
+	return e
+}
+
+// Session sets the session for this operation.
+func (e *Explain) Session(session *session.Client) *Explain {
+	if e == nil {
+		e = new(Explain)
+	}
+
+	e.session = session
+	return e
+}
+
+// ClusterClock sets the cluster clock for this operation.
+func (e *Explain) ClusterClock(clock *session.ClusterClock) *Explain {
+	if e == nil {
+		e = new(Explain)
+	}
+
+	e.clock = clock
+	return e
+}
+
+// CommandMonitor sets the monitor to use for APM events.
+func (e *Explain) CommandMonitor(monitor *event.CommandMonitor) *Explain {
+	if e == nil {
+		e = new(Explain)
+	}
+
+	e.monitor = monitor
+	return e
+}
+
+// Database sets the database to run this operation against.
+func (e *Explain) Database(database string) *Explain {
+	if e == nil {
+		e = new(Explain)
+	}
+
+	e.database = database
+	return e
+}
+
+// Deployment sets the deployment to use for this operation.
+func (e *Explain) Deployment(deployment driver.Deployment) *Explain {
+	if e == nil {
+		e = new(Explain)
+	}
+
+	e.deployment = deployment
+	return e
+}
+
+// ReadPreference set the read preference used with this operation.
+func (e *Explain) ReadPreference(readPreference *readpref.ReadPref) *Explain {
+	if e == nil {
+		e = new(Explain)
+	}
+
+	e.readPreference = readPreference
+	return e
+}
+
+// ServerSelector sets the selector used to retrieve a server.
+func (e *Explain) ServerSelector(selector description.ServerSelector) *Explain {
+	if e == nil {
+		e = new(Explain)
+	}
+
+	e.selector = selector
+	return e
+}
+
+// ServerAPI sets the server API version for this operation.
+func (e *Explain) ServerAPI(serverAPI *driver.ServerAPIOptions) *Explain {
+	if e == nil {
+		e = new(Explain)
+	}
+
+	e.serverAPI = serverAPI
+	return e
+}
@@ -32,6 +32,7 @@ type Find struct {
 	comment             *string
 	filter              bsoncore.Document
 	hint                bsoncore.Value
+	let                 bsoncore.Document
 	limit               *int64
 	max                 bsoncore.Document
 	maxTimeMS           *int64
@@ -59,6 +60,7 @@ type Find struct {
 	retry               *driver.RetryMode
 	result              driver.CursorResponse
 	serverAPI           *driver.ServerAPIOptions
+	exhaust             bool
 }
 
 // NewFind constructs and returns a new Find.
@@ -74,9 +76,13 @@ func (f *Find) Result(opts driver.CursorOptions) (*driver.BatchCursor, error) {
 	return driver.NewBatchCursor(f.result, f.session, f.clock, opts)
 }
 
-func (f *Find) processResponse(response bsoncore.Document, srvr driver.Server, desc description.Server, _ int) error {
+func (f *Find) processResponse(info driver.ResponseInfo) error {
 	var err error
-	f.result, err = driver.NewCursorResponse(response, srvr, desc)
+	if f.exhaust {
+		f.result, err = driver.NewExhaustCursorResponse(info)
+		return err
+	}
+	f.result, err = driver.NewCursorResponse(info)
 	return err
 }
 
@@ -138,6 +144,9 @@ func (f *Find) command(dst []byte, desc description.SelectedServer) ([]byte, err
 	if f.hint.Type != bsontype.Type(0) {
 		dst = bsoncore.AppendValueElement(dst, "hint", f.hint)
 	}
+	if f.let != nil {
+		dst = bsoncore.AppendDocumentElement(dst, "let", f.let)
+	}
 	if f.limit != nil {
 		dst = bsoncore.AppendInt64Element(dst, "limit", *f.limit)
 	}
@@ -403,6 +412,16 @@ func (f *Find) Tailable(tailable bool) *Find {
 	return f
 }
 
+// Let specifies the let document to use. This option is only valid for server versions 5.0 and above.
+func (f *Find) Let(let bsoncore.Document) *Find {
+	if f == nil {
+		f = new(Find)
+	}
+
+	f.let = let
+	return f
+}
+
 // Session sets the session for this operation.
 func (f *Find) Session(session *session.Client) *Find {
 	if f == nil {
@@ -523,3 +542,15 @@ func (f *Find) ServerAPI(serverAPI *driver.ServerAPIOptions) *Find {
 	f.serverAPI = serverAPI
 	return f
 }
+
+// Exhaust allows the server to stream the batches of the cursor created by this operation over the connection used to
+// create it without waiting for a getMore command for each batch. The cursor is pinned to the connection until it is
+// exhausted or closed.
+func (f *Find) Exhaust(exhaust bool) *Find {
+	if f == nil {
+		f = new(Find)
+	}
+
+	f.exhaust = exhaust
+	return f
+}
@@ -28,7 +28,9 @@ type FindAndModify struct {
 	arrayFilters             bsoncore.Document
 	bypassDocumentValidation *bool
 	collation                bsoncore.Document
+	comment                  bsoncore.Value
 	fields                   bsoncore.Document
+	let                      bsoncore.Document
 	maxTimeMS                *int64
 	newDocument              *bool
 	query                    bsoncore.Document
@@ -108,10 +110,10 @@ func NewFindAndModify(query bsoncore.Document) *FindAndModify {
 // Result returns the result of executing this operation.
 func (fam *FindAndModify) Result() FindAndModifyResult { return fam.result }
 
-func (fam *FindAndModify) processResponse(response bsoncore.Document, srvr driver.Server, desc description.Server, _ int) error {
+func (fam *FindAndModify) processResponse(info driver.ResponseInfo) error {
 	var err error
 
-	fam.result, err = buildFindAndModifyResult(response, srvr)
+	fam.result, err = buildFindAndModifyResult(info.ServerResponse, info.Server)
 	return err
 
 }
@@ -161,10 +163,18 @@ func (fam *FindAndModify) command(dst []byte, desc description.SelectedServer) (
 		}
 		dst = bsoncore.AppendDocumentElement(dst, "collation", fam.collation)
 	}
+	if fam.comment.Type != bsontype.Type(0) {
+
+		dst = bsoncore.AppendValueElement(dst, "comment", fam.comment)
+	}
 	if fam.fields != nil {
 
 		dst = bsoncore.AppendDocumentElement(dst, "fields", fam.fields)
 	}
+	if fam.let != nil {
+
+		dst = bsoncore.AppendDocumentElement(dst, "let", fam.let)
+	}
 	if fam.maxTimeMS != nil {
 
 		dst = bsoncore.AppendInt64Element(dst, "maxTimeMS", *fam.maxTimeMS)
@@ -317,6 +327,26 @@ func (fam *FindAndModify) Upsert(upsert bool) *FindAndModify {
 	return fam
 }
 
+// Comment sets a value to help trace an operation through the database profiler, currentOp, and logs.
+func (fam *FindAndModify) Comment(comment bsoncore.Value) *FindAndModify {
+	if fam == nil {
+		fam = new(FindAndModify)
+	}
+
+	fam.comment = comment
+	return fam
+}
+
+// Let specifies the let document to use. This option is only valid for server versions 5.0 and above.
+func (fam *FindAndModify) Let(let bsoncore.Document) *FindAndModify {
+	if fam == nil {
+		fam = new(FindAndModify)
+	}
+
+	fam.let = let
+	return fam
+}
+
 // Session sets the session for this operation.
 func (fam *FindAndModify) Session(session *session.Client) *FindAndModify {
 	if fam == nil {
@@ -13,6 +13,7 @@ import (
 	"errors"
 	"fmt"
 
+	"go.mongodb.org/mongo-driver/bson/bsontype"
 	"go.mongodb.org/mongo-driver/event"
 	"go.mongodb.org/mongo-driver/mongo/description"
 	"go.mongodb.org/mongo-driver/mongo/writeconcern"
@@ -24,6 +25,7 @@ import (
 // Insert performs an insert operation.
 type Insert struct {
 	bypassDocumentValidation *bool
+	comment                  bsoncore.Value
 	documents                []bsoncore.Document
 	ordered                  *bool
 	session                  *session.Client
@@ -43,6 +45,8 @@ type Insert struct {
 type InsertResult struct {
 	// Number of documents successfully inserted.
 	N int32
+	// The server replies for the insert commands sent for this operation, one per batch.
+	Replies []bsoncore.Document
 }
 
 func buildInsertResult(response bsoncore.Document, srvr driver.Server) (InsertResult, error) {
@@ -74,9 +78,10 @@ func NewInsert(documents ...bsoncore.Document) *Insert {
 // Result returns the result of executing this operation.
 func (i *Insert) Result() InsertResult { return i.result }
 
-func (i *Insert) processResponse(response bsoncore.Document, srvr driver.Server, desc description.Server, insert int) error {
-	ir, err := buildInsertResult(response, srvr)
+func (i *Insert) processResponse(info driver.ResponseInfo) error {
+	ir, err := buildInsertResult(info.ServerResponse, info.Server)
 	i.result.N += ir.N
+	i.result.Replies = append(i.result.Replies, append(bsoncore.Document(nil), info.ServerResponse...))
 	return err
 }
 
@@ -118,6 +123,9 @@ func (i *Insert) command(dst []byte, desc description.SelectedServer) ([]byte, e
 	if i.ordered != nil {
 		dst = bsoncore.AppendBooleanElement(dst, "ordered", *i.ordered)
 	}
+	if i.comment.Type != bsontype.Type(0) {
+		dst = bsoncore.AppendValueElement(dst, "comment", i.comment)
+	}
 	return dst, nil
 }
 
@@ -154,6 +162,16 @@ func (i *Insert) Ordered(ordered bool) *Insert {
 	return i
 }
 
+// Comment sets a value to help trace an operation through the database profiler, currentOp, and logs.
+func (i *Insert) Comment(comment bsoncore.Value) *Insert {
+	if i == nil {
+		i = new(Insert)
+	}
+
+	i.comment = comment
+	return i
+}
+
 // Session sets the session for this operation.
 func (i *Insert) Session(session *session.Client) *Insert {
 	if i == nil {
@@ -20,6 +20,7 @@ import (
 // IsMaster is used to run the isMaster handshake operation.
 type IsMaster struct {
 	appname            string
+	driverInfo         *driver.DriverInfo
 	compressors        []string
 	saslSupportedMechs string
 	d                  driver.Deployment
@@ -28,6 +29,7 @@ type IsMaster struct {
 	topologyVersion    *description.TopologyVersion
 	maxAwaitTimeMS     *int64
 	serverAPI          *driver.ServerAPIOptions
+	loadBalanced       bool
 
 	res bsoncore.Document
 }
@@ -43,6 +45,13 @@ func (im *IsMaster) AppName(appname string) *IsMaster {
 	return im
 }
 
+// DriverInfo sets the information about a library wrapping the driver that is appended to the client metadata sent in
+// this operation.
+func (im *IsMaster) DriverInfo(info *driver.DriverInfo) *IsMaster {
+	im.driverInfo = info
+	return im
+}
+
 // ClusterClock sets the cluster clock for this operation.
 func (im *IsMaster) ClusterClock(clock *session.ClusterClock) *IsMaster {
 	if im == nil {
@@ -96,6 +105,12 @@ func (im *IsMaster) ServerAPI(serverAPI *driver.ServerAPIOptions) *IsMaster {
 	return im
 }
 
+// LoadBalanced specifies whether or not this operation is being sent over a connection to a load balanced cluster.
+func (im *IsMaster) LoadBalanced(lb bool) *IsMaster {
+	im.loadBalanced = lb
+	return im
+}
+
 // Result returns the result of executing this operation.
 func (im *IsMaster) Result(addr address.Address) description.Server {
 	return description.NewServer(addr, bson.Raw(im.res))
@@ -155,6 +170,11 @@ func (im *IsMaster) handshakeCommand(dst []byte, desc description.SelectedServer
 	if im.speculativeAuth != nil {
 		dst = bsoncore.AppendDocumentElement(dst, "speculativeAuthenticate", im.speculativeAuth)
 	}
+	if im.loadBalanced {
+		// The loadBalanced parameter should only be added if it's true. We should never explicitly send
+		// loadBalanced=false per the load balancing spec.
+		dst = bsoncore.AppendBooleanElement(dst, "loadBalanced", true)
+	}
 	var idx int32
 	idx, dst = bsoncore.AppendArrayElementStart(dst, "compression")
 	for i, compressor := range im.compressors {
@@ -165,9 +185,15 @@ func (im *IsMaster) handshakeCommand(dst []byte, desc description.SelectedServer
 	// append client metadata
 	idx, dst = bsoncore.AppendDocumentElementStart(dst, "client")
 
+	// Information about a wrapping library is appended to the driver's own, separated by a "|", per the handshake spec.
+	var info driver.DriverInfo
+	if im.driverInfo != nil {
+		info = *im.driverInfo
+	}
+
 	didx, dst := bsoncore.AppendDocumentElementStart(dst, "driver")
-	dst = bsoncore.AppendStringElement(dst, "name", "mongo-go-driver")
-	dst = bsoncore.AppendStringElement(dst, "version", version.Driver)
+	dst = bsoncore.AppendStringElement(dst, "name", appendDriverInfo("mongo-go-driver", info.Name))
+	dst = bsoncore.AppendStringElement(dst, "version", appendDriverInfo(version.Driver, info.Version))
 	dst, _ = bsoncore.AppendDocumentEnd(dst, didx)
 
 	didx, dst = bsoncore.AppendDocumentElementStart(dst, "os")
@@ -175,7 +201,7 @@ func (im *IsMaster) handshakeCommand(dst []byte, desc description.SelectedServer
 	dst = bsoncore.AppendStringElement(dst, "architecture", runtime.GOARCH)
 	dst, _ = bsoncore.AppendDocumentEnd(dst, didx)
 
-	dst = bsoncore.AppendStringElement(dst, "platform", runtime.Version())
+	dst = bsoncore.AppendStringElement(dst, "platform", appendDriverInfo(runtime.Version(), info.Platform))
 	if im.appname != "" {
 		didx, dst = bsoncore.AppendDocumentElementStart(dst, "application")
 		dst = bsoncore.AppendStringElement(dst, "name", im.appname)
@@ -186,6 +212,14 @@ func (im *IsMaster) handshakeCommand(dst []byte, desc description.SelectedServer
 	return dst, nil
 }
 
+// appendDriverInfo appends the wrapping library's value to the driver's value if it is not empty.
+func appendDriverInfo(value, wrapperValue string) string {
+	if wrapperValue == "" {
+		return value
+	}
+	return value + "|" + wrapperValue
+}
+
 // command appends all necessary command fields.
 func (im *IsMaster) command(dst []byte, _ description.SelectedServer) ([]byte, error) {
 	dst = bsoncore.AppendInt32Element(dst, "isMaster", 1)
@@ -225,8 +259,8 @@ func (im *IsMaster) createOperation() driver.Operation {
 		CommandFn:  im.command,
 		Database:   "admin",
 		Deployment: im.d,
-		ProcessResponseFn: func(response bsoncore.Document, _ driver.Server, _ description.Server, _ int) error {
-			im.res = response
+		ProcessResponseFn: func(info driver.ResponseInfo) error {
+			im.res = info.ServerResponse
 			return nil
 		},
 		ServerAPI: im.serverAPI,
@@ -241,8 +275,8 @@ func (im *IsMaster) GetHandshakeInformation(ctx context.Context, _ address.Addre
 		CommandFn:  im.handshakeCommand,
 		Deployment: driver.SingleConnectionDeployment{c},
 		Database:   "admin",
-		ProcessResponseFn: func(response bsoncore.Document, _ driver.Server, _ description.Server, _ int) error {
-			im.res = response
+		ProcessResponseFn: func(info driver.ResponseInfo) error {
+			im.res = info.ServerResponse
 			return nil
 		},
 		ServerAPI: im.serverAPI,
@@ -13,3 +13,7 @@ package operation
 //go:generate operationgen count.toml operation count.go
 //go:generate operationgen end_sessions.toml operation end_sessions.go
 //go:generate operationgen create.toml operation create.go
+//go:generate operationgen coll_mod.toml operation coll_mod.go
+//go:generate operationgen create_search_indexes.toml operation create_search_indexes.go
+//go:generate operationgen drop_search_index.toml operation drop_search_index.go
+//go:generate operationgen update_search_index.toml operation update_search_index.go
@@ -14,6 +14,7 @@ import (
 	"fmt"
 
 	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/bsontype"
 	"go.mongodb.org/mongo-driver/event"
 	"go.mongodb.org/mongo-driver/mongo/description"
 	"go.mongodb.org/mongo-driver/mongo/writeconcern"
@@ -25,6 +26,8 @@ import (
 // Update performs an update operation.
 type Update struct {
 	bypassDocumentValidation *bool
+	comment                  bsoncore.Value
+	let                      bsoncore.Document
 	ordered                  *bool
 	updates                  []bsoncore.Document
 	session                  *session.Client
@@ -57,6 +60,8 @@ type UpdateResult struct {
 	NModified int32
 	// Information about upserted documents.
 	Upserted []Upsert
+	// The server replies for the update commands sent for this operation, one per batch.
+	Replies []bsoncore.Document
 }
 
 func buildUpdateResult(response bsoncore.Document, srvr driver.Server) (UpdateResult, error) {
@@ -122,17 +127,18 @@ func NewUpdate(updates ...bsoncore.Document) *Update {
 // Result returns the result of executing this operation.
 func (u *Update) Result() UpdateResult { return u.result }
 
-func (u *Update) processResponse(response bsoncore.Document, srvr driver.Server, desc description.Server, currIndex int) error {
-	ur, err := buildUpdateResult(response, srvr)
+func (u *Update) processResponse(info driver.ResponseInfo) error {
+	ur, err := buildUpdateResult(info.ServerResponse, info.Server)
 
 	u.result.N += ur.N
 	u.result.NModified += ur.NModified
-	if currIndex > 0 {
+	if info.CurrentIndex > 0 {
 		for ind := range ur.Upserted {
-			ur.Upserted[ind].Index += int64(currIndex)
+			ur.Upserted[ind].Index += int64(info.CurrentIndex)
 		}
 	}
 	u.result.Upserted = append(u.result.Upserted, ur.Upserted...)
+	u.result.Replies = append(u.result.Replies, append(bsoncore.Document(nil), info.ServerResponse...))
 	return err
 
 }
@@ -192,6 +198,12 @@ func (u *Update) command(dst []byte, desc description.SelectedServer) ([]byte, e
 			return nil, errors.New("the 'arrayFilters' command parameter requires a minimum server wire version of 6")
 		}
 	}
+	if u.comment.Type != bsontype.Type(0) {
+		dst = bsoncore.AppendValueElement(dst, "comment", u.comment)
+	}
+	if u.let != nil {
+		dst = bsoncore.AppendDocumentElement(dst, "let", u.let)
+	}
 
 	return dst, nil
 }
@@ -253,6 +265,26 @@ func (u *Update) Updates(updates ...bsoncore.Document) *Update {
 	return u
 }
 
+// Comment sets a value to help trace an operation through the database profiler, currentOp, and logs.
+func (u *Update) Comment(comment bsoncore.Value) *Update {
+	if u == nil {
+		u = new(Update)
+	}
+
+	u.comment = comment
+	return u
+}
+
+// Let specifies the let document to use. This option is only valid for server versions 5.0 and above.
+func (u *Update) Let(let bsoncore.Document) *Update {
+	if u == nil {
+		u = new(Update)
+	}
+
+	u.let = let
+	return u
+}
+
 // Session sets the session for this operation.
 func (u *Update) Session(session *session.Client) *Update {
 	if u == nil {
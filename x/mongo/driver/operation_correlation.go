@@ -0,0 +1,53 @@
+// Copyright (C) MongoDB, Inc. 2021-present.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
+
+package driver
+
+import (
+	"context"
+	"sync/atomic"
+)
+
+// globalOperationID is the last operation ID generated by Operation.Execute.
+var globalOperationID int64
+
+type operationIDKey struct{}
+
+type correlationIDKey struct{}
+
+// WithCorrelationID returns a Context derived from ctx that carries the given correlation ID. The events published for
+// the operations executed with the returned Context include the correlation ID.
+func WithCorrelationID(ctx context.Context, id string) context.Context {
+	return context.WithValue(ctx, correlationIDKey{}, id)
+}
+
+// CorrelationID returns the correlation ID carried by ctx, or an empty string if there is none.
+func CorrelationID(ctx context.Context) string {
+	if ctx == nil {
+		return ""
+	}
+	id, _ := ctx.Value(correlationIDKey{}).(string)
+	return id
+}
+
+// OperationID returns the ID of the operation that is being executed with ctx, or 0 if ctx is not the Context of an
+// operation.
+func OperationID(ctx context.Context) int64 {
+	if ctx == nil {
+		return 0
+	}
+	id, _ := ctx.Value(operationIDKey{}).(int64)
+	return id
+}
+
+// withOperationID returns a Context derived from ctx that carries a new operation ID, or ctx if it already carries one
+// so that the operations executed on behalf of another share its ID.
+func withOperationID(ctx context.Context) context.Context {
+	if OperationID(ctx) != 0 {
+		return ctx
+	}
+	return context.WithValue(ctx, operationIDKey{}, atomic.AddInt64(&globalOperationID, 1))
+}
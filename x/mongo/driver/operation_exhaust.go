@@ -25,11 +25,5 @@ func (op Operation) ExecuteExhaust(ctx context.Context, conn StreamerConnection,
 	if err != nil {
 		return err
 	}
-	if op.ProcessResponseFn != nil {
-		if err = op.ProcessResponseFn(res, nil, description.Server{}, 0); err != nil {
-			return err
-		}
-	}
-
-	return nil
+	return op.processResponse(res, nil, conn, description.Server{}, 0)
 }
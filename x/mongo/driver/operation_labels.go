@@ -0,0 +1,87 @@
+// Copyright (C) MongoDB, Inc. 2021-present.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
+
+package driver
+
+import (
+	"context"
+	"sort"
+
+	"go.mongodb.org/mongo-driver/mongo/description"
+	"go.mongodb.org/mongo-driver/x/bsonx/bsoncore"
+)
+
+// labelsMinWireVersion is the minimum wire version that supports a comment on every command.
+const labelsMinWireVersion int32 = 9
+
+type operationLabelsKey struct{}
+
+// WithOperationLabels returns a Context derived from ctx that carries the given labels. The labels are added to the
+// labels already carried by ctx, replacing labels with the same keys. Commands executed with the returned Context
+// include the labels in their comment.
+func WithOperationLabels(ctx context.Context, labels map[string]string) context.Context {
+	parent := OperationLabels(ctx)
+	merged := make(map[string]string, len(parent)+len(labels))
+	for k, v := range parent {
+		merged[k] = v
+	}
+	for k, v := range labels {
+		merged[k] = v
+	}
+	return context.WithValue(ctx, operationLabelsKey{}, merged)
+}
+
+// OperationLabels returns the labels carried by ctx, or nil if there are none. The returned map must not be modified.
+func OperationLabels(ctx context.Context) map[string]string {
+	if ctx == nil {
+		return nil
+	}
+	labels, _ := ctx.Value(operationLabelsKey{}).(map[string]string)
+	return labels
+}
+
+// addOperationLabels adds a comment of the form {labels: {<key>: <value>, ...}} with the labels carried by ctx to the
+// command document that starts at idx in dst. This assumes that the final 0 byte of the document has not been added.
+// The comment is not added if the command already has one or the server does not support comments on all commands.
+func (op Operation) addOperationLabels(ctx context.Context, dst []byte, idx int32, desc description.SelectedServer) []byte {
+	labels := OperationLabels(ctx)
+	if len(labels) == 0 || desc.WireVersion == nil || desc.WireVersion.Max < labelsMinWireVersion {
+		return dst
+	}
+	if hasElement(dst[idx+4:], "comment") {
+		return dst
+	}
+
+	keys := make([]string, 0, len(labels))
+	for k := range labels {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	lidx, labelsDoc := bsoncore.AppendDocumentStart(nil)
+	for _, k := range keys {
+		labelsDoc = bsoncore.AppendStringElement(labelsDoc, k, labels[k])
+	}
+	labelsDoc, _ = bsoncore.AppendDocumentEnd(labelsDoc, lidx)
+
+	comment := bsoncore.BuildDocumentFromElements(nil, bsoncore.AppendDocumentElement(nil, "labels", labelsDoc))
+	return bsoncore.AppendDocumentElement(dst, "comment", comment)
+}
+
+// hasElement returns true if the BSON elements in elems, which are not wrapped in a document, include the key.
+func hasElement(elems []byte, key string) bool {
+	for len(elems) > 0 {
+		elem, rem, ok := bsoncore.ReadElement(elems)
+		if !ok {
+			return false
+		}
+		if elem.Key() == key {
+			return true
+		}
+		elems = rem
+	}
+	return false
+}
@@ -54,10 +54,7 @@ func (op Operation) legacyFind(ctx context.Context, dst []byte, srvr Server, con
 		return finishedInfo.cmdErr
 	}
 
-	if op.ProcessResponseFn != nil {
-		return op.ProcessResponseFn(finishedInfo.response, srvr, desc.Server, 0)
-	}
-	return nil
+	return op.processResponse(finishedInfo.response, srvr, conn, desc.Server, 0)
 }
 
 // returns wire message, collection name, error
@@ -224,10 +221,7 @@ func (op Operation) legacyGetMore(ctx context.Context, dst []byte, srvr Server,
 		return finishedInfo.cmdErr
 	}
 
-	if op.ProcessResponseFn != nil {
-		return op.ProcessResponseFn(finishedInfo.response, srvr, desc.Server, 0)
-	}
-	return nil
+	return op.processResponse(finishedInfo.response, srvr, conn, desc.Server, 0)
 }
 
 func (op Operation) createLegacyGetMoreWiremessage(dst []byte, desc description.SelectedServer) ([]byte, startedInformation, string, error) {
@@ -392,10 +386,7 @@ func (op Operation) legacyListCollections(ctx context.Context, dst []byte, srvr
 		return finishedInfo.cmdErr
 	}
 
-	if op.ProcessResponseFn != nil {
-		return op.ProcessResponseFn(finishedInfo.response, srvr, desc.Server, 0)
-	}
-	return nil
+	return op.processResponse(finishedInfo.response, srvr, conn, desc.Server, 0)
 }
 
 func (op Operation) createLegacyListCollectionsWiremessage(dst []byte, desc description.SelectedServer) ([]byte, startedInformation, string, error) {
@@ -519,10 +510,7 @@ func (op Operation) legacyListIndexes(ctx context.Context, dst []byte, srvr Serv
 		return finishedInfo.cmdErr
 	}
 
-	if op.ProcessResponseFn != nil {
-		return op.ProcessResponseFn(finishedInfo.response, srvr, desc.Server, 0)
-	}
-	return nil
+	return op.processResponse(finishedInfo.response, srvr, conn, desc.Server, 0)
 }
 
 func (op Operation) createLegacyListIndexesWiremessage(dst []byte, desc description.SelectedServer) ([]byte, startedInformation, string, error) {
@@ -10,6 +10,7 @@ import (
 	"github.com/google/go-cmp/cmp"
 	"go.mongodb.org/mongo-driver/bson/bsontype"
 	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/event"
 	"go.mongodb.org/mongo-driver/internal/testutil/assert"
 	"go.mongodb.org/mongo-driver/mongo/address"
 	"go.mongodb.org/mongo-driver/mongo/description"
@@ -231,6 +232,41 @@ func TestOperation(t *testing.T) {
 			}
 		}
 	})
+	t.Run("addReadConcern snapshot", func(t *testing.T) {
+		id, _ := uuid.New()
+		snapshot := true
+		sess, err := session.NewClientSession(&session.Pool{}, id, session.Explicit, &session.ClientOptions{Snapshot: &snapshot})
+		noerr(t, err)
+
+		desc := description.SelectedServer{Server: description.Server{WireVersion: &description.VersionRange{Max: 13}}}
+		op := Operation{Client: sess, Type: Read, ReadConcern: readconcern.Majority()}
+
+		want := bsoncore.AppendDocumentElement(nil, "readConcern", bsoncore.BuildDocument(nil,
+			bsoncore.AppendStringElement(nil, "level", "snapshot"),
+		))
+		got, err := op.addReadConcern(nil, desc)
+		noerr(t, err)
+		assert.Equal(t, bsoncore.Document(want), bsoncore.Document(got), "expected read concern %v, got %v",
+			bsoncore.Document(want), bsoncore.Document(got))
+
+		sess.SnapshotTime = &primitive.Timestamp{T: 10, I: 5}
+		want = bsoncore.AppendDocumentElement(nil, "readConcern", bsoncore.BuildDocument(nil,
+			bsoncore.AppendStringElement(nil, "level", "snapshot"),
+			bsoncore.AppendTimestampElement(nil, "atClusterTime", 10, 5),
+		))
+		got, err = op.addReadConcern(nil, desc)
+		noerr(t, err)
+		assert.Equal(t, bsoncore.Document(want), bsoncore.Document(got), "expected read concern %v, got %v",
+			bsoncore.Document(want), bsoncore.Document(got))
+
+		got, err = Operation{Client: sess, Type: Write}.addReadConcern(nil, desc)
+		noerr(t, err)
+		assert.Nil(t, got, "expected no read concern for a write, got %v", bsoncore.Document(got))
+
+		oldDesc := description.SelectedServer{Server: description.Server{WireVersion: &description.VersionRange{Max: 9}}}
+		_, err = op.addReadConcern(nil, oldDesc)
+		assert.NotNil(t, err, "expected error for server that does not support snapshot reads, got nil")
+	})
 	t.Run("addWriteConcern", func(t *testing.T) {
 		want := bsoncore.AppendDocumentElement(nil, "writeConcern", bsoncore.BuildDocumentFromElements(
 			nil, bsoncore.AppendStringElement(nil, "w", "majority"),
@@ -241,6 +277,44 @@ func TestOperation(t *testing.T) {
 			t.Errorf("WriteConcern elements do not match. got %v; want %v", got, want)
 		}
 	})
+	t.Run("addOperationLabels", func(t *testing.T) {
+		desc := description.SelectedServer{Server: description.Server{WireVersion: &description.VersionRange{Max: 9}}}
+		ctx := WithOperationLabels(context.Background(), map[string]string{"team": "search", "endpoint": "/a"})
+		ctx = WithOperationLabels(ctx, map[string]string{"endpoint": "/b"})
+		newCommand := func(elems ...[]byte) (int32, []byte) {
+			idx, dst := bsoncore.AppendDocumentStart(nil)
+			dst = bsoncore.AppendInt32Element(dst, "find", 1)
+			for _, elem := range elems {
+				dst = append(dst, elem...)
+			}
+			return idx, dst
+		}
+
+		idx, dst := newCommand()
+		dst = Operation{}.addOperationLabels(ctx, dst, idx, desc)
+		dst, _ = bsoncore.AppendDocumentEnd(dst, idx)
+		want := bsoncore.BuildDocumentFromElements(nil,
+			bsoncore.AppendDocumentElement(nil, "labels", bsoncore.BuildDocumentFromElements(nil,
+				bsoncore.AppendStringElement(nil, "endpoint", "/b"),
+				bsoncore.AppendStringElement(nil, "team", "search"),
+			)),
+		)
+		got := bsoncore.Document(dst).Lookup("comment").Document()
+		assert.Equal(t, bsoncore.Document(want), got, "expected comment %v, got %v", bsoncore.Document(want), got)
+
+		idx, dst = newCommand(bsoncore.AppendStringElement(nil, "comment", "user comment"))
+		dst = Operation{}.addOperationLabels(ctx, dst, idx, desc)
+		dst, _ = bsoncore.AppendDocumentEnd(dst, idx)
+		comment := bsoncore.Document(dst).Lookup("comment").StringValue()
+		assert.Equal(t, "user comment", comment, "expected comment %q, got %q", "user comment", comment)
+
+		oldDesc := description.SelectedServer{Server: description.Server{WireVersion: &description.VersionRange{Max: 8}}}
+		idx, dst = newCommand()
+		dst = Operation{}.addOperationLabels(ctx, dst, idx, oldDesc)
+		dst, _ = bsoncore.AppendDocumentEnd(dst, idx)
+		_, err := bsoncore.Document(dst).LookupErr("comment")
+		assert.NotNil(t, err, "expected no comment for server that does not support it, got %v", bsoncore.Document(dst))
+	})
 	t.Run("addSession", func(t *testing.T) { t.Skip("These tests should be covered by spec tests.") })
 	t.Run("addClusterTime", func(t *testing.T) {
 		t.Run("adds max cluster time", func(t *testing.T) {
@@ -576,6 +650,280 @@ func TestOperation(t *testing.T) {
 		assert.Nil(t, err, "ExecuteExhaust error: %v", err)
 		assert.True(t, conn.CurrentlyStreaming(), "expected CurrentlyStreaming to be true")
 	})
+	t.Run("stale routing retry", func(t *testing.T) {
+		staleConfig := createExhaustServerResponse(t, bsoncore.BuildDocumentFromElements(nil,
+			bsoncore.AppendInt32Element(nil, "ok", 0),
+			bsoncore.AppendInt32Element(nil, "code", 13388),
+			bsoncore.AppendStringElement(nil, "errmsg", "stale config"),
+		), false)
+		success := createExhaustServerResponse(t, bsoncore.BuildDocumentFromElements(nil,
+			bsoncore.AppendInt32Element(nil, "ok", 1),
+		), false)
+		newOp := func(conn Connection, typ Type) Operation {
+			return Operation{
+				CommandFn: func(dst []byte, desc description.SelectedServer) ([]byte, error) {
+					return bsoncore.AppendStringElement(dst, "find", "coll"), nil
+				},
+				Database:   "db",
+				Deployment: SingleConnectionDeployment{conn},
+				Type:       typ,
+			}
+		}
+		desc := description.Server{WireVersion: &description.VersionRange{Max: 6}}
+
+		retryRead := func(conn Connection, mode RetryMode) Operation {
+			op := newOp(conn, Read)
+			op.RetryMode = &mode
+			return op
+		}
+
+		t.Run("read is retried once", func(t *testing.T) {
+			conn := &replySequenceConnection{mockConnection: &mockConnection{rDesc: desc}}
+			conn.replies = [][]byte{staleConfig, success}
+			err := retryRead(conn, RetryOncePerCommand).Execute(context.TODO(), nil)
+			assert.Nil(t, err, "Execute error: %v", err)
+			assert.Equal(t, 2, conn.writes, "expected 2 attempts, got %v", conn.writes)
+
+			conn.replies = [][]byte{staleConfig, staleConfig}
+			conn.writes = 0
+			err = retryRead(conn, RetryOncePerCommand).Execute(context.TODO(), nil)
+			driverErr, ok := err.(Error)
+			assert.True(t, ok && driverErr.StaleRouting(), "expected stale routing error, got %v", err)
+			assert.Equal(t, 2, conn.writes, "expected 2 attempts, got %v", conn.writes)
+		})
+		t.Run("read is not retried without a retry mode", func(t *testing.T) {
+			testCases := []struct {
+				name string
+				op   func(conn Connection) Operation
+			}{
+				{"nil", func(conn Connection) Operation { return newOp(conn, Read) }},
+				{"none", func(conn Connection) Operation { return retryRead(conn, RetryNone) }},
+			}
+			for _, tc := range testCases {
+				t.Run(tc.name, func(t *testing.T) {
+					conn := &replySequenceConnection{mockConnection: &mockConnection{rDesc: desc}}
+					conn.replies = [][]byte{staleConfig, success}
+					err := tc.op(conn).Execute(context.TODO(), nil)
+					driverErr, ok := err.(Error)
+					assert.True(t, ok && driverErr.StaleRouting(), "expected stale routing error, got %v", err)
+					assert.Equal(t, 1, conn.writes, "expected 1 attempt, got %v", conn.writes)
+				})
+			}
+		})
+		t.Run("writes", func(t *testing.T) {
+			id, err := uuid.New()
+			noerr(t, err)
+			sess, err := session.NewClientSession(session.NewPool(nil), id, session.Explicit)
+			noerr(t, err)
+			retryOnce := RetryOnce
+			desc := description.Server{
+				WireVersion:           &description.VersionRange{Max: 6},
+				SessionTimeoutMinutes: 1,
+				Kind:                  description.Mongos,
+			}
+
+			conn := &replySequenceConnection{mockConnection: &mockConnection{rDesc: desc}}
+			conn.replies = [][]byte{staleConfig, success}
+			op := newOp(conn, Write)
+			op.Client = sess
+			op.Clock = new(session.ClusterClock)
+			err = op.Execute(context.TODO(), nil)
+			assert.NotNil(t, err, "expected stale routing error, got nil")
+			assert.Equal(t, 1, conn.writes, "expected non-retryable write to be attempted once, got %v", conn.writes)
+
+			conn.replies = [][]byte{staleConfig, success}
+			conn.writes = 0
+			op.RetryMode = &retryOnce
+			err = op.Execute(context.TODO(), nil)
+			assert.Nil(t, err, "Execute error: %v", err)
+			assert.Equal(t, 2, conn.writes, "expected retryable write to be attempted twice, got %v", conn.writes)
+		})
+		t.Run("write command errors", func(t *testing.T) {
+			staleWriteError := createExhaustServerResponse(t, bsoncore.BuildDocumentFromElements(nil,
+				bsoncore.AppendInt32Element(nil, "ok", 1),
+				bsoncore.BuildArrayElement(nil, "writeErrors", bsoncore.Value{
+					Type: bsontype.EmbeddedDocument,
+					Data: bsoncore.BuildDocumentFromElements(nil,
+						bsoncore.AppendInt32Element(nil, "index", 0),
+						bsoncore.AppendInt32Element(nil, "code", 13388),
+						bsoncore.AppendStringElement(nil, "errmsg", "stale config"),
+					),
+				}),
+			), false)
+			staleWriteConcernError := createExhaustServerResponse(t, bsoncore.BuildDocumentFromElements(nil,
+				bsoncore.AppendInt32Element(nil, "ok", 1),
+				bsoncore.AppendDocumentElement(nil, "writeConcernError", bsoncore.BuildDocumentFromElements(nil,
+					bsoncore.AppendInt32Element(nil, "code", 13388),
+					bsoncore.AppendStringElement(nil, "errmsg", "stale config"),
+				)),
+			), false)
+			id, err := uuid.New()
+			noerr(t, err)
+			sess, err := session.NewClientSession(session.NewPool(nil), id, session.Explicit)
+			noerr(t, err)
+			retryOnce := RetryOnce
+			desc := description.Server{
+				WireVersion:           &description.VersionRange{Max: 6},
+				SessionTimeoutMinutes: 1,
+				Kind:                  description.Mongos,
+			}
+
+			testCases := []struct {
+				name  string
+				reply []byte
+			}{
+				{"write error", staleWriteError},
+				{"write concern error", staleWriteConcernError},
+			}
+			for _, tc := range testCases {
+				t.Run(tc.name, func(t *testing.T) {
+					conn := &replySequenceConnection{mockConnection: &mockConnection{rDesc: desc}}
+					conn.replies = [][]byte{tc.reply, success}
+					op := newOp(conn, Write)
+					op.Client = sess
+					op.Clock = new(session.ClusterClock)
+					op.RetryMode = &retryOnce
+					err := op.Execute(context.TODO(), nil)
+					assert.Nil(t, err, "Execute error: %v", err)
+					assert.Equal(t, 2, conn.writes, "expected retryable write to be attempted twice, got %v", conn.writes)
+
+					conn.replies = [][]byte{tc.reply, tc.reply}
+					conn.writes = 0
+					err = op.Execute(context.TODO(), nil)
+					wce, ok := err.(WriteCommandError)
+					assert.True(t, ok && wce.StaleRouting(), "expected stale routing write command error, got %v", err)
+					assert.Equal(t, 2, conn.writes, "expected retryable write to be attempted twice, got %v", conn.writes)
+				})
+			}
+		})
+	})
+	t.Run("operation and correlation IDs", func(t *testing.T) {
+		staleConfig := createExhaustServerResponse(t, bsoncore.BuildDocumentFromElements(nil,
+			bsoncore.AppendInt32Element(nil, "ok", 0),
+			bsoncore.AppendInt32Element(nil, "code", 13388),
+			bsoncore.AppendStringElement(nil, "errmsg", "stale config"),
+		), false)
+		success := createExhaustServerResponse(t, bsoncore.BuildDocumentFromElements(nil,
+			bsoncore.AppendInt32Element(nil, "ok", 1),
+		), false)
+
+		var started []*event.CommandStartedEvent
+		var finished []event.CommandFinishedEvent
+		monitor := &event.CommandMonitor{
+			Started: func(_ context.Context, evt *event.CommandStartedEvent) {
+				started = append(started, evt)
+			},
+			Succeeded: func(_ context.Context, evt *event.CommandSucceededEvent) {
+				finished = append(finished, evt.CommandFinishedEvent)
+			},
+			Failed: func(_ context.Context, evt *event.CommandFailedEvent) {
+				finished = append(finished, evt.CommandFinishedEvent)
+			},
+		}
+		desc := description.Server{WireVersion: &description.VersionRange{Max: 6}}
+		conn := &replySequenceConnection{mockConnection: &mockConnection{rDesc: desc}}
+		conn.replies = [][]byte{staleConfig, success, success}
+		retry := RetryOncePerCommand
+		op := Operation{
+			CommandFn: func(dst []byte, desc description.SelectedServer) ([]byte, error) {
+				return bsoncore.AppendStringElement(dst, "find", "coll"), nil
+			},
+			Database:       "db",
+			Deployment:     SingleConnectionDeployment{conn},
+			Type:           Read,
+			RetryMode:      &retry,
+			CommandMonitor: monitor,
+		}
+
+		ctx := WithCorrelationID(context.Background(), "trace")
+		err := op.Execute(ctx, nil)
+		assert.Nil(t, err, "Execute error: %v", err)
+		err = op.Execute(context.Background(), nil)
+		assert.Nil(t, err, "Execute error: %v", err)
+		assert.Equal(t, 3, len(started), "expected 3 started events, got %v", len(started))
+		assert.Equal(t, 3, len(finished), "expected 3 finished events, got %v", len(finished))
+
+		// The retry shares the operation ID of the first attempt and the next operation gets a new one.
+		opID := started[0].OperationID
+		assert.NotEqual(t, int64(0), opID, "expected operation ID to be set")
+		for i, wantOpID := range []int64{opID, opID, started[2].OperationID} {
+			wantCorrelationID := "trace"
+			if i == 2 {
+				wantCorrelationID = ""
+			}
+			assert.Equal(t, wantOpID, started[i].OperationID, "expected operation ID %v for started event %d, got %v",
+				wantOpID, i, started[i].OperationID)
+			assert.Equal(t, wantOpID, finished[i].OperationID, "expected operation ID %v for finished event %d, got %v",
+				wantOpID, i, finished[i].OperationID)
+			assert.Equal(t, wantCorrelationID, started[i].CorrelationID,
+				"expected correlation ID %q for started event %d, got %q", wantCorrelationID, i, started[i].CorrelationID)
+			assert.Equal(t, wantCorrelationID, finished[i].CorrelationID,
+				"expected correlation ID %q for finished event %d, got %q", wantCorrelationID, i, finished[i].CorrelationID)
+		}
+		assert.NotEqual(t, opID, started[2].OperationID, "expected a new operation ID for the second operation")
+		assert.Equal(t, "trace", CorrelationID(ctx), "expected correlation ID trace, got %q", CorrelationID(ctx))
+	})
+	t.Run("malformed responses", func(t *testing.T) {
+		doc := bsoncore.BuildDocumentFromElements(nil, bsoncore.AppendInt32Element(nil, "ok", 1))
+		compressed := func(uncompressedSize int32) []byte {
+			idx, wm := wiremessage.AppendHeaderStart(nil, 0, 0, wiremessage.OpCompressed)
+			wm = wiremessage.AppendCompressedOriginalOpCode(wm, wiremessage.OpMsg)
+			wm = wiremessage.AppendCompressedUncompressedSize(wm, uncompressedSize)
+			wm = wiremessage.AppendCompressedCompressorID(wm, wiremessage.CompressorNoOp)
+			wm = wiremessage.AppendMsgFlags(wm, 0)
+			wm = wiremessage.AppendMsgSectionType(wm, wiremessage.SingleDocument)
+			wm = bsoncore.AppendDocument(wm, doc)
+			return bsoncore.UpdateLength(wm, idx, int32(len(wm)))
+		}
+		reply := func(flags wiremessage.ReplyFlag, numReturned int32, docs ...bsoncore.Document) []byte {
+			idx, wm := wiremessage.AppendHeaderStart(nil, 0, 0, wiremessage.OpReply)
+			wm = wiremessage.AppendReplyFlags(wm, flags)
+			wm = wiremessage.AppendReplyCursorID(wm, 0)
+			wm = wiremessage.AppendReplyStartingFrom(wm, 0)
+			wm = wiremessage.AppendReplyNumberReturned(wm, numReturned)
+			for _, doc := range docs {
+				wm = bsoncore.AppendDocument(wm, doc)
+			}
+			return bsoncore.UpdateLength(wm, idx, int32(len(wm)))
+		}
+		msgSequence := func(length int32) []byte {
+			idx, wm := wiremessage.AppendHeaderStart(nil, 0, 0, wiremessage.OpMsg)
+			wm = wiremessage.AppendMsgFlags(wm, 0)
+			wm = wiremessage.AppendMsgSectionType(wm, wiremessage.DocumentSequence)
+			wm = bsoncore.AppendInt32(wm, length)
+			return bsoncore.UpdateLength(wm, idx, int32(len(wm)))
+		}
+
+		testCases := []struct {
+			name string
+			wm   []byte
+		}{
+			{"negative uncompressed size", compressed(-1)},
+			{"uncompressed size exceeds max message size", compressed(int32(defaultMaxMessageSize))},
+			{"query failure without document", reply(wiremessage.QueryFailure, 1)},
+			{"empty document in reply", reply(0, 1, bsoncore.Document{0, 0, 0, 0})},
+			{"document sequence length too short", msgSequence(2)},
+		}
+		for _, tc := range testCases {
+			t.Run(tc.name, func(t *testing.T) {
+				var op Operation
+				wm, err := op.decompressWireMessage(tc.wm, 0)
+				if err == nil {
+					_, err = op.decodeResult(wm)
+				}
+				assert.NotNil(t, err, "expected error, got nil")
+			})
+		}
+		t.Run("valid compressed response", func(t *testing.T) {
+			var op Operation
+			// The uncompressed OP_MSG contains the flags, the section type, and the document.
+			wm, err := op.decompressWireMessage(compressed(int32(4+1+len(doc))), 0)
+			assert.Nil(t, err, "decompressWireMessage error: %v", err)
+			res, err := op.decodeResult(wm)
+			assert.Nil(t, err, "decodeResult error: %v", err)
+			assert.Equal(t, bsoncore.Document(doc), res, "expected response %v, got %v", doc, res)
+		})
+	})
 }
 
 func createExhaustServerResponse(t *testing.T, response bsoncore.Document, moreToCome bool) []byte {
@@ -605,6 +953,24 @@ func assertExhaustAllowedSet(t *testing.T, wm []byte, expected bool) {
 	assert.Equal(t, expected, actual, "expected exhaustAllowed set %v, got %v", expected, actual)
 }
 
+// replySequenceConnection is a mockConnection that returns the wire messages in replies in order.
+type replySequenceConnection struct {
+	*mockConnection
+	replies [][]byte
+	writes  int
+}
+
+func (c *replySequenceConnection) WriteWireMessage(ctx context.Context, wm []byte) error {
+	c.writes++
+	return c.mockConnection.WriteWireMessage(ctx, wm)
+}
+
+func (c *replySequenceConnection) ReadWireMessage(context.Context, []byte) ([]byte, error) {
+	reply := c.replies[0]
+	c.replies = c.replies[1:]
+	return reply, nil
+}
+
 type mockDeployment struct {
 	params struct {
 		selector description.ServerSelector
@@ -0,0 +1,76 @@
+// Copyright (C) MongoDB, Inc. 2021-present.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
+
+package driver
+
+import "sync/atomic"
+
+// cursorNotFoundCode is the error code returned by a server that does not have the cursor of a getMore, e.g. because a
+// load balancer routed the getMore to a different mongos than the one that created the cursor.
+const cursorNotFoundCode = 43
+
+// PinnedCursorStats contains counts of the errors of cursors pinned to connections in load balanced mode and of the
+// attempts to restart them.
+type PinnedCursorStats struct {
+	// The number of getMore commands that failed because the connection the cursor was pinned to was closed or did not
+	// reach the server that owns the cursor.
+	WrongConnectionErrors int64
+
+	// The number of times a cursor that lost its pinned connection was re-established with a new command.
+	Restarts int64
+
+	// The number of attempts to re-establish a cursor that failed.
+	RestartFailures int64
+}
+
+// PinnedCursorCounters accumulates PinnedCursorStats for the cursors of a client. It is safe for concurrent use.
+type PinnedCursorCounters struct {
+	wrongConnectionErrors int64
+	restarts              int64
+	restartFailures       int64
+}
+
+// AddRestart records an attempt to re-establish a cursor, which failed if err is not nil.
+func (pcc *PinnedCursorCounters) AddRestart(err error) {
+	if pcc == nil {
+		return
+	}
+	if err != nil {
+		atomic.AddInt64(&pcc.restartFailures, 1)
+		return
+	}
+	atomic.AddInt64(&pcc.restarts, 1)
+}
+
+// Stats returns the current counts.
+func (pcc *PinnedCursorCounters) Stats() PinnedCursorStats {
+	if pcc == nil {
+		return PinnedCursorStats{}
+	}
+	return PinnedCursorStats{
+		WrongConnectionErrors: atomic.LoadInt64(&pcc.wrongConnectionErrors),
+		Restarts:              atomic.LoadInt64(&pcc.restarts),
+		RestartFailures:       atomic.LoadInt64(&pcc.restartFailures),
+	}
+}
+
+func (pcc *PinnedCursorCounters) addWrongConnectionError() {
+	if pcc == nil {
+		return
+	}
+	atomic.AddInt64(&pcc.wrongConnectionErrors, 1)
+}
+
+// isWrongConnectionError returns true if err means that a getMore sent on a pinned connection can never succeed: the
+// connection was closed by a network error or the server it reached does not have the cursor.
+func isWrongConnectionError(err error) bool {
+	switch e := err.(type) {
+	case Error:
+		return e.NetworkError() || e.Code == cursorNotFoundCode
+	default:
+		return err == ErrCursorNotFound
+	}
+}
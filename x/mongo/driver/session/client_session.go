@@ -7,15 +7,18 @@
 package session // import "go.mongodb.org/mongo-driver/x/mongo/driver/session"
 
 import (
+	"context"
 	"errors"
 	"time"
 
 	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo/address"
 	"go.mongodb.org/mongo-driver/mongo/description"
 	"go.mongodb.org/mongo-driver/mongo/readconcern"
 	"go.mongodb.org/mongo-driver/mongo/readpref"
 	"go.mongodb.org/mongo-driver/mongo/writeconcern"
+	"go.mongodb.org/mongo-driver/x/bsonx/bsoncore"
 	"go.mongodb.org/mongo-driver/x/mongo/driver/uuid"
 )
 
@@ -40,6 +43,9 @@ var ErrCommitAfterAbort = errors.New("cannot call commitTransaction after callin
 // ErrUnackWCUnsupported is returned if an unacknowledged write concern is supported for a transaciton.
 var ErrUnackWCUnsupported = errors.New("transactions do not support unacknowledged write concerns")
 
+// ErrSnapshotTransaction is returned if a transaction is started on a snapshot session.
+var ErrSnapshotTransaction = errors.New("transactions are not supported in snapshot sessions")
+
 // Type describes the type of the session
 type Type uint8
 
@@ -79,6 +85,26 @@ func (s TransactionState) String() string {
 	}
 }
 
+// LoadBalancedTransactionConnection represents a connection that's pinned by a Client because it's being used to
+// execute a transaction against a load balancer. This interface is a copy of driver.PinnedConnection and exists so
+// transactions can be pinned to a connection without causing an import cycle.
+type LoadBalancedTransactionConnection interface {
+	// Functions copied over from driver.Connection.
+	WriteWireMessage(context.Context, []byte) error
+	ReadWireMessage(ctx context.Context, dst []byte) ([]byte, error)
+	Description() description.Server
+	Close() error
+	ID() string
+	Address() address.Address
+	Stale() bool
+
+	// Functions copied over from driver.PinnedConnection that are not part of driver.Connection.
+	PinToCursor() error
+	PinToTransaction() error
+	UnpinFromCursor() error
+	UnpinFromTransaction() error
+}
+
 // Client is a session for clients to run commands.
 type Client struct {
 	*Server
@@ -93,6 +119,8 @@ type Client struct {
 	Aborting       bool
 	RetryWrite     bool
 	RetryRead      bool
+	Snapshot       bool
+	SnapshotTime   *primitive.Timestamp
 
 	// options for the current transaction
 	// most recently set by transactionopt
@@ -111,6 +139,7 @@ type Client struct {
 	TransactionState TransactionState
 	PinnedServer     *description.Server
 	RecoveryToken    bson.Raw
+	PinnedConnection LoadBalancedTransactionConnection
 }
 
 func getClusterTime(clusterTime bson.Raw) (uint32, uint32) {
@@ -159,6 +188,11 @@ func NewClientSession(pool *Pool, clientID uuid.UUID, sessionType Type, opts ...
 	}
 
 	mergedOpts := mergeClientOptions(opts...)
+	if mergedOpts.Snapshot != nil && *mergedOpts.Snapshot {
+		// Snapshot reads are not causally consistent unless explicitly requested.
+		c.Snapshot = true
+		c.Consistent = false
+	}
 	if mergedOpts.CausalConsistency != nil {
 		c.Consistent = *mergedOpts.CausalConsistency
 	}
@@ -239,11 +273,49 @@ func (c *Client) UpdateRecoveryToken(response bson.Raw) {
 	c.RecoveryToken = token.Document()
 }
 
-// ClearPinnedServer sets the PinnedServer to nil.
-func (c *Client) ClearPinnedServer() {
-	if c != nil {
-		c.PinnedServer = nil
+// UpdateSnapshotTime sets the snapshot time of a snapshot session from the atClusterTime field of the first response
+// that contains one. The field is read from the cursor document for cursor-returning commands and from the top level of
+// the response otherwise. Later responses do not change the snapshot time.
+func (c *Client) UpdateSnapshotTime(response bsoncore.Document) {
+	if c == nil || !c.Snapshot || c.SnapshotTime != nil {
+		return
+	}
+
+	doc := response
+	if cursor, ok := response.Lookup("cursor").DocumentOK(); ok {
+		doc = cursor
 	}
+
+	atClusterTime, err := doc.LookupErr("atClusterTime")
+	if err != nil {
+		// atClusterTime not included by the server
+		return
+	}
+
+	t, i, ok := atClusterTime.TimestampOK()
+	if !ok {
+		return
+	}
+	c.SnapshotTime = &primitive.Timestamp{T: t, I: i}
+}
+
+// ClearPinnedResources clears the pinned server and/or connection associated with the session.
+func (c *Client) ClearPinnedResources() error {
+	if c == nil {
+		return nil
+	}
+
+	c.PinnedServer = nil
+	if c.PinnedConnection != nil {
+		if err := c.PinnedConnection.UnpinFromTransaction(); err != nil {
+			return err
+		}
+		if err := c.PinnedConnection.Close(); err != nil {
+			return err
+		}
+	}
+	c.PinnedConnection = nil
+	return nil
 }
 
 // EndSession ends the session.
@@ -253,7 +325,8 @@ func (c *Client) EndSession() {
 	}
 
 	c.Terminated = true
-	c.pool.ReturnSession(c.Server)
+	_ = c.ClearPinnedResources()
+	c.pool.returnSession(c.Server, c.SessionType == Implicit)
 
 	return
 }
@@ -282,6 +355,9 @@ func (c *Client) TransactionCommitted() bool {
 // CheckStartTransaction checks to see if allowed to start transaction and returns
 // an error if not allowed
 func (c *Client) CheckStartTransaction() error {
+	if c.Snapshot {
+		return ErrSnapshotTransaction
+	}
 	if c.TransactionState == InProgress || c.TransactionState == Starting {
 		return ErrTransactInProgress
 	}
@@ -328,8 +404,7 @@ func (c *Client) StartTransaction(opts *TransactionOptions) error {
 	}
 
 	c.TransactionState = Starting
-	c.PinnedServer = nil
-	return nil
+	return c.ClearPinnedResources()
 }
 
 // CheckCommitTransaction checks to see if allowed to commit transaction and returns
@@ -416,6 +491,6 @@ func (c *Client) clearTransactionOpts() {
 	c.CurrentWc = nil
 	c.CurrentRp = nil
 	c.CurrentRc = nil
-	c.PinnedServer = nil
 	c.RecoveryToken = nil
+	_ = c.ClearPinnedResources()
 }
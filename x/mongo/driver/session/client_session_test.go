@@ -8,11 +8,14 @@ package session
 
 import (
 	"bytes"
+	"context"
+	"errors"
 	"testing"
 
 	"github.com/stretchr/testify/require"
 	"go.mongodb.org/mongo-driver/bson/primitive"
 	testhelpers "go.mongodb.org/mongo-driver/internal/testutil/helpers"
+	"go.mongodb.org/mongo-driver/mongo/address"
 	"go.mongodb.org/mongo-driver/mongo/description"
 	"go.mongodb.org/mongo-driver/x/bsonx/bsoncore"
 	"go.mongodb.org/mongo-driver/x/mongo/driver/uuid"
@@ -192,4 +195,102 @@ func TestClientSession(t *testing.T) {
 			t.Errorf("expected error, got %v", err)
 		}
 	})
+
+	t.Run("TestSnapshot", func(t *testing.T) {
+		id, _ := uuid.New()
+		snapshot := true
+		sess, err := NewClientSession(&Pool{}, id, Explicit, &ClientOptions{Snapshot: &snapshot})
+		require.Nil(t, err, "Unexpected error")
+		if !sess.Snapshot || sess.Consistent {
+			t.Errorf("expected snapshot session without causal consistency, got Snapshot %v and Consistent %v",
+				sess.Snapshot, sess.Consistent)
+		}
+
+		err = sess.StartTransaction(nil)
+		if err != ErrSnapshotTransaction {
+			t.Errorf("expected error %v, got %v", ErrSnapshotTransaction, err)
+		}
+
+		sess.UpdateSnapshotTime(bsoncore.BuildDocument(nil, bsoncore.AppendInt32Element(nil, "ok", 1)))
+		if sess.SnapshotTime != nil {
+			t.Errorf("expected no snapshot time, got %v", sess.SnapshotTime)
+		}
+
+		cursorResponse := bsoncore.BuildDocument(nil, bsoncore.AppendDocumentElement(nil, "cursor",
+			bsoncore.BuildDocument(nil, bsoncore.AppendTimestampElement(nil, "atClusterTime", 10, 5))))
+		sess.UpdateSnapshotTime(cursorResponse)
+		require.NotNil(t, sess.SnapshotTime, "expected snapshot time to be set")
+		compareOperationTimes(t, &primitive.Timestamp{T: 10, I: 5}, sess.SnapshotTime)
+
+		// The snapshot time is only set by the first response that contains one.
+		sess.UpdateSnapshotTime(bsoncore.BuildDocument(nil, bsoncore.AppendTimestampElement(nil, "atClusterTime", 20, 1)))
+		compareOperationTimes(t, &primitive.Timestamp{T: 10, I: 5}, sess.SnapshotTime)
+
+		sess, err = NewClientSession(&Pool{}, id, Explicit, sessionOpts)
+		require.Nil(t, err, "Unexpected error")
+		sess.UpdateSnapshotTime(cursorResponse)
+		if sess.SnapshotTime != nil {
+			t.Errorf("expected no snapshot time for a non-snapshot session, got %v", sess.SnapshotTime)
+		}
+	})
+	t.Run("TestClearPinnedResources", func(t *testing.T) {
+		id, _ := uuid.New()
+		sess, err := NewClientSession(&Pool{}, id, Explicit, sessionOpts)
+		require.Nil(t, err, "Unexpected error")
+
+		conn := &testPinnedConnection{pins: 1}
+		sess.PinnedServer = &description.Server{}
+		sess.PinnedConnection = conn
+		err = sess.ClearPinnedResources()
+		require.Nil(t, err, "Unexpected error")
+		if sess.PinnedServer != nil || sess.PinnedConnection != nil {
+			t.Errorf("expected pinned server and connection to be cleared, got %v and %v", sess.PinnedServer, sess.PinnedConnection)
+		}
+		if conn.pins != 0 || !conn.closed {
+			t.Errorf("expected connection to be unpinned and closed, got %d pins and closed %v", conn.pins, conn.closed)
+		}
+
+		// Starting a new transaction unpins the connection used by the previous one.
+		conn = &testPinnedConnection{pins: 1}
+		sess.PinnedConnection = conn
+		err = sess.StartTransaction(nil)
+		require.Nil(t, err, "Unexpected error")
+		if sess.PinnedConnection != nil || conn.pins != 0 {
+			t.Errorf("expected connection to be unpinned when starting a transaction, got %d pins", conn.pins)
+		}
+	})
+}
+
+// testPinnedConnection is a LoadBalancedTransactionConnection that tracks the number of transactions that pinned it.
+type testPinnedConnection struct {
+	pins   int
+	closed bool
+}
+
+var _ LoadBalancedTransactionConnection = (*testPinnedConnection)(nil)
+
+func (c *testPinnedConnection) WriteWireMessage(context.Context, []byte) error { return nil }
+func (c *testPinnedConnection) ReadWireMessage(_ context.Context, dst []byte) ([]byte, error) {
+	return dst, nil
+}
+func (c *testPinnedConnection) Description() description.Server { return description.Server{} }
+func (c *testPinnedConnection) Close() error {
+	c.closed = true
+	return nil
+}
+func (c *testPinnedConnection) ID() string               { return "test" }
+func (c *testPinnedConnection) Address() address.Address { return address.Address("localhost") }
+func (c *testPinnedConnection) Stale() bool              { return false }
+func (c *testPinnedConnection) PinToCursor() error       { return nil }
+func (c *testPinnedConnection) UnpinFromCursor() error   { return nil }
+func (c *testPinnedConnection) PinToTransaction() error {
+	c.pins++
+	return nil
+}
+func (c *testPinnedConnection) UnpinFromTransaction() error {
+	if c.pins == 0 {
+		return errors.New("connection is not pinned to a transaction")
+	}
+	c.pins--
+	return nil
 }
@@ -9,6 +9,8 @@ package session
 import (
 	"sync"
 
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/event"
 	"go.mongodb.org/mongo-driver/mongo/description"
 	"go.mongodb.org/mongo-driver/x/bsonx/bsoncore"
 )
@@ -28,7 +30,20 @@ type Pool struct {
 	timeout  uint32
 	mutex    sync.Mutex // mutex to protect list and sessionTimeout
 
+	// Sessions are not expired in load balanced mode because the topology description does not contain a session
+	// timeout.
+	loadBalanced bool
+
 	checkedOut int // number of sessions checked out of pool
+	pooled     int // number of sessions in the list
+
+	// checkedOutExceeded is called when checkedOut grows beyond checkedOutThreshold. Both are set before the pool is
+	// used, so they are not protected by mutex.
+	checkedOutThreshold int
+	checkedOutExceeded  func(checkedOut int)
+
+	// monitor is set before the pool is used, so it is not protected by mutex.
+	monitor *event.SessionMonitor
 }
 
 func (p *Pool) createServerSession() (*Server, error) {
@@ -55,26 +70,87 @@ func (p *Pool) updateTimeout() {
 	select {
 	case newDesc := <-p.descChan:
 		p.timeout = newDesc.SessionTimeoutMinutes
+		p.loadBalanced = newDesc.Kind == description.LoadBalanced
 	default:
 		// no new description waiting
 	}
 }
 
+// assumes caller has mutex to protect the pool
+func (p *Pool) expired(ss *Server) bool {
+	if p.loadBalanced {
+		return false
+	}
+	return ss.expired(p.timeout)
+}
+
+// SetCheckedOutThreshold configures the pool to call fn each time the number of checked out sessions grows beyond
+// threshold. fn is called without holding the pool's lock. This must be called before the pool is used.
+func (p *Pool) SetCheckedOutThreshold(threshold int, fn func(checkedOut int)) {
+	p.checkedOutThreshold = threshold
+	p.checkedOutExceeded = fn
+}
+
+// SetMonitor configures the pool to publish session events to the Event function of monitor. This must be called
+// before the pool is used.
+func (p *Pool) SetMonitor(monitor *event.SessionMonitor) {
+	p.monitor = monitor
+}
+
+// assumes caller has mutex to protect the pool
+func (p *Pool) appendEvent(events []*event.SessionEvent, eventType string, ss *Server) []*event.SessionEvent {
+	if p.monitor == nil || p.monitor.Event == nil {
+		return events
+	}
+	return append(events, &event.SessionEvent{
+		Type:       eventType,
+		SessionID:  bson.Raw(ss.SessionID),
+		CheckedOut: p.checkedOut,
+		Pooled:     p.pooled,
+	})
+}
+
+// publishEvents publishes events collected while holding mutex. It must be called after mutex is released.
+func (p *Pool) publishEvents(events []*event.SessionEvent) {
+	for _, evt := range events {
+		p.monitor.Event(evt)
+	}
+}
+
 // GetSession retrieves an unexpired session from the pool.
 func (p *Pool) GetSession() (*Server, error) {
+	ss, checkedOut, events, err := p.getSession()
+	p.publishEvents(events)
+	if err == nil && p.checkedOutExceeded != nil && checkedOut == p.checkedOutThreshold+1 {
+		p.checkedOutExceeded(checkedOut)
+	}
+	return ss, err
+}
+
+// getSession retrieves an unexpired session from the pool and returns it with the number of checked out sessions and
+// the events to publish.
+func (p *Pool) getSession() (*Server, int, []*event.SessionEvent, error) {
 	p.mutex.Lock() // prevent changing the linked list while seeing if sessions have expired
 	defer p.mutex.Unlock()
 
+	var events []*event.SessionEvent
 	// empty pool
 	if p.head == nil && p.tail == nil {
-		return p.createServerSession()
+		ss, err := p.createServerSession()
+		if err == nil {
+			events = p.appendEvent(events, event.SessionCreated, ss)
+		}
+		return ss, p.checkedOut, events, err
 	}
 
 	p.updateTimeout()
 	for p.head != nil {
 		// pull session from head of queue and return if it is valid for at least 1 more minute
-		if p.head.expired(p.timeout) {
+		if p.expired(p.head.Server) {
+			expired := p.head.Server
 			p.head = p.head.next
+			p.pooled--
+			events = p.appendEvent(events, event.SessionExpired, expired)
 			continue
 		}
 
@@ -91,42 +167,71 @@ func (p *Pool) GetSession() (*Server, error) {
 		}
 
 		p.checkedOut++
-		return session, nil
+		p.pooled--
+		events = p.appendEvent(events, event.SessionReused, session)
+		return session, p.checkedOut, events, nil
 	}
 
 	// no valid session found
 	p.tail = nil // empty list
-	return p.createServerSession()
+	ss, err := p.createServerSession()
+	if err == nil {
+		events = p.appendEvent(events, event.SessionCreated, ss)
+	}
+	return ss, p.checkedOut, events, err
 }
 
 // ReturnSession returns a session to the pool if it has not expired.
 func (p *Pool) ReturnSession(ss *Server) {
+	p.returnSession(ss, false)
+}
+
+// returnSession returns a session to the pool if it has not expired. implicit reports whether the session was used by
+// an implicit client session and is only used for monitoring.
+func (p *Pool) returnSession(ss *Server, implicit bool) {
 	if ss == nil {
 		return
 	}
 
+	events := p.putSession(ss, implicit)
+	p.publishEvents(events)
+}
+
+// putSession adds a session to the pool if it has not expired and returns the events to publish.
+func (p *Pool) putSession(ss *Server, implicit bool) []*event.SessionEvent {
 	p.mutex.Lock()
 	defer p.mutex.Unlock()
 
 	p.checkedOut--
+	events := p.appendEvent(nil, event.SessionEnded, ss)
+	if len(events) > 0 {
+		events[0].Implicit = implicit
+	}
+
 	p.updateTimeout()
 	// check sessions at end of queue for expired
 	// stop checking after hitting the first valid session
-	for p.tail != nil && p.tail.expired(p.timeout) {
+	for p.tail != nil && p.expired(p.tail.Server) {
+		expired := p.tail.Server
 		if p.tail.prev != nil {
 			p.tail.prev.next = nil
 		}
 		p.tail = p.tail.prev
+		p.pooled--
+		events = p.appendEvent(events, event.SessionExpired, expired)
+	}
+	if p.tail == nil {
+		p.head = nil
 	}
 
 	// session expired
-	if ss.expired(p.timeout) {
-		return
+	if p.expired(ss) {
+		return p.appendEvent(events, event.SessionExpired, ss)
 	}
 
 	// session is dirty
 	if ss.Dirty {
-		return
+		return p.appendEvent(events, event.SessionDiscarded, ss)
 	}
 
 	newNode := &Node{
@@ -135,17 +240,49 @@ func (p *Pool) ReturnSession(ss *Server) {
 		prev:   nil,
 	}
 
+	p.pooled++
+
 	// empty list
 	if p.tail == nil {
 		p.head = newNode
 		p.tail = newNode
-		return
+		return events
 	}
 
 	// at least 1 valid session in list
 	newNode.next = p.head
 	p.head.prev = newNode
 	p.head = newNode
+	return events
+}
+
+// Reset discards the sessions in the pool without ending them and makes the pool use descChan for topology updates.
+// This is used when the deployment is reconnected because the pooled sessions may have been ended or may be in use by
+// another process, e.g. after a fork. Sessions that are checked out are added to the pool when they are returned. It
+// returns the number of sessions that were discarded.
+func (p *Pool) Reset(descChan <-chan description.Topology) int {
+	events, discarded := p.reset(descChan)
+	p.publishEvents(events)
+	return discarded
+}
+
+func (p *Pool) reset(descChan <-chan description.Topology) ([]*event.SessionEvent, int) {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	var events []*event.SessionEvent
+	discarded := 0
+	for node := p.head; node != nil; node = node.next {
+		p.pooled--
+		events = p.appendEvent(events, event.SessionDiscarded, node.Server)
+		discarded++
+	}
+	p.head = nil
+	p.tail = nil
+	p.descChan = descChan
+	p.timeout = 0
+	p.loadBalanced = false
+	return events, discarded
 }
 
 // IDSlice returns a slice of session IDs for each session in the pool
@@ -178,3 +315,11 @@ func (p *Pool) String() string {
 func (p *Pool) CheckedOut() int {
 	return p.checkedOut
 }
+
+// Pooled returns the number of sessions in the pool. This includes expired sessions that have not been removed yet.
+func (p *Pool) Pooled() int {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	return p.pooled
+}
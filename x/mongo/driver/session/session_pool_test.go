@@ -10,6 +10,7 @@ import (
 	"bytes"
 	"testing"
 
+	"go.mongodb.org/mongo-driver/event"
 	"go.mongodb.org/mongo-driver/internal/testutil/assert"
 	"go.mongodb.org/mongo-driver/mongo/description"
 )
@@ -65,4 +66,127 @@ func TestSessionPool(t *testing.T) {
 		assert.False(t, bytes.Equal(sess.SessionID, firstID), "first expired session was not removed")
 		assert.False(t, bytes.Equal(sess.SessionID, secondID), "second expired session was not removed")
 	})
+	t.Run("TestNotExpiredInLoadBalancedMode", func(t *testing.T) {
+		descChan := make(chan description.Topology, 1)
+		p := NewPool(descChan)
+		// The topology description in load balanced mode does not have a session timeout.
+		descChan <- description.Topology{Kind: description.LoadBalanced}
+
+		first, err := p.GetSession()
+		assert.Nil(t, err, "GetSession error: %v", err)
+		firstID := first.SessionID
+		p.ReturnSession(first)
+
+		sess, err := p.GetSession()
+		assert.Nil(t, err, "GetSession error: %v", err)
+		assert.True(t, bytes.Equal(sess.SessionID, firstID),
+			"session ID mismatch; expected %s, got %s", firstID, sess.SessionID)
+	})
+	t.Run("TestCheckedOutThreshold", func(t *testing.T) {
+		p := NewPool(nil)
+		var exceeded []int
+		p.SetCheckedOutThreshold(1, func(checkedOut int) {
+			exceeded = append(exceeded, checkedOut)
+		})
+
+		first, err := p.GetSession()
+		assert.Nil(t, err, "GetSession error: %v", err)
+		assert.Equal(t, 0, len(exceeded), "expected threshold not to be exceeded, got %v", exceeded)
+
+		second, err := p.GetSession()
+		assert.Nil(t, err, "GetSession error: %v", err)
+		_, err = p.GetSession()
+		assert.Nil(t, err, "GetSession error: %v", err)
+		assert.Equal(t, []int{2}, exceeded, "expected threshold to be exceeded once, got %v", exceeded)
+
+		// The threshold is exceeded again after the number of checked out sessions drops back to the threshold.
+		p.ReturnSession(first)
+		p.ReturnSession(second)
+		_, err = p.GetSession()
+		assert.Nil(t, err, "GetSession error: %v", err)
+		assert.Equal(t, []int{2, 2}, exceeded, "expected threshold to be exceeded twice, got %v", exceeded)
+	})
+	t.Run("TestMonitor", func(t *testing.T) {
+		p := NewPool(nil)
+		p.timeout = 30
+		var events []*event.SessionEvent
+		p.SetMonitor(&event.SessionMonitor{
+			Event: func(evt *event.SessionEvent) {
+				events = append(events, evt)
+			},
+		})
+		assertEvents := func(t *testing.T, expected ...string) {
+			t.Helper()
+
+			var types []string
+			for _, evt := range events {
+				types = append(types, evt.Type)
+			}
+			assert.Equal(t, expected, types, "expected events %v, got %v", expected, types)
+			events = nil
+		}
+
+		ss, err := p.GetSession()
+		assert.Nil(t, err, "GetSession error: %v", err)
+		assertEvents(t, event.SessionCreated)
+		p.returnSession(ss, true)
+		assert.True(t, events[0].Implicit, "expected SessionEnded event for implicit session")
+		assert.Equal(t, 1, p.Pooled(), "expected 1 pooled session, got %v", p.Pooled())
+		assertEvents(t, event.SessionEnded)
+
+		reused, err := p.GetSession()
+		assert.Nil(t, err, "GetSession error: %v", err)
+		assert.True(t, bytes.Equal(ss.SessionID, events[0].SessionID), "expected session ID %v, got %v",
+			ss.SessionID, events[0].SessionID)
+		assert.Equal(t, 1, events[0].CheckedOut, "expected 1 checked out session, got %v", events[0].CheckedOut)
+		assert.Equal(t, 0, events[0].Pooled, "expected 0 pooled sessions, got %v", events[0].Pooled)
+		assertEvents(t, event.SessionReused)
+		reused.MarkDirty()
+		p.ReturnSession(reused)
+		assert.False(t, events[0].Implicit, "expected SessionEnded event for explicit session")
+		assertEvents(t, event.SessionEnded, event.SessionDiscarded)
+
+		// New sessions will always become stale when returned
+		p.timeout = 0
+		ss, err = p.GetSession()
+		assert.Nil(t, err, "GetSession error: %v", err)
+		p.ReturnSession(ss)
+		assert.Equal(t, 0, p.Pooled(), "expected 0 pooled sessions, got %v", p.Pooled())
+		assertEvents(t, event.SessionCreated, event.SessionEnded, event.SessionExpired)
+	})
+	t.Run("TestReset", func(t *testing.T) {
+		p := NewPool(nil)
+		p.timeout = 30
+		var discarded int
+		p.SetMonitor(&event.SessionMonitor{
+			Event: func(evt *event.SessionEvent) {
+				if evt.Type == event.SessionDiscarded {
+					discarded++
+				}
+			},
+		})
+
+		first, err := p.GetSession()
+		assert.Nil(t, err, "GetSession error: %v", err)
+		second, err := p.GetSession()
+		assert.Nil(t, err, "GetSession error: %v", err)
+		p.ReturnSession(first)
+
+		descChan := make(chan description.Topology, 1)
+		descChan <- description.Topology{SessionTimeoutMinutes: 30}
+		n := p.Reset(descChan)
+		assert.Equal(t, 1, n, "expected 1 discarded session, got %v", n)
+		assert.Equal(t, 1, discarded, "expected 1 SessionDiscarded event, got %v", discarded)
+		assert.Equal(t, 0, p.Pooled(), "expected 0 pooled sessions, got %v", p.Pooled())
+		assert.Equal(t, 0, len(p.IDSlice()), "expected no session IDs, got %v", p.IDSlice())
+
+		// Sessions checked out before the reset are pooled when they are returned.
+		p.ReturnSession(second)
+		assert.Equal(t, uint32(30), p.timeout, "expected timeout from new channel, got %v", p.timeout)
+		assert.Equal(t, 1, p.Pooled(), "expected 1 pooled session, got %v", p.Pooled())
+		sess, err := p.GetSession()
+		assert.Nil(t, err, "GetSession error: %v", err)
+		assert.True(t, bytes.Equal(second.SessionID, sess.SessionID), "expected session ID %v, got %v",
+			second.SessionID, sess.SessionID)
+	})
 }
@@ -9,6 +9,7 @@ package topology
 import (
 	"context"
 	"crypto/tls"
+	"crypto/x509"
 	"errors"
 	"fmt"
 	"io"
@@ -31,8 +32,9 @@ import (
 var globalConnectionID uint64 = 1
 
 var (
-	defaultMaxMessageSize uint32 = 48000000
-	errResponseTooLarge   error  = errors.New("length of read message too large")
+	defaultMaxMessageSize        uint32 = 48000000
+	errResponseTooLarge          error  = errors.New("length of read message too large")
+	errLoadBalancedStateMismatch        = errors.New("driver attempted to initialize in load balancing mode, but the server does not support this mode")
 )
 
 func nextConnectionID() uint64 { return atomic.AddUint64(&globalConnectionID, 1) }
@@ -60,6 +62,7 @@ type connection struct {
 	currentlyStreaming   bool
 	connectContextMutex  sync.Mutex
 	cancellationListener cancellationListener
+	peerCertificates     []*x509.Certificate
 
 	// pool related fields
 	pool         *pool
@@ -95,15 +98,15 @@ func newConnection(addr address.Address, opts ...ConnectionOption) (*connection,
 	return c, nil
 }
 
-func (c *connection) processInitializationError(err error) {
+func (c *connection) processInitializationError(err error, stage connectionStage) {
 	atomic.StoreInt32(&c.connected, disconnected)
 	if c.nc != nil {
 		_ = c.nc.Close()
 	}
 
-	c.connectErr = ConnectionError{Wrapped: err, init: true}
+	c.connectErr = ConnectionError{Wrapped: err, init: true, stage: stage}
 	if c.config.errorHandlingCallback != nil {
-		c.config.errorHandlingCallback(c.connectErr, c.generation)
+		c.config.errorHandlingCallback(c.connectErr, c.generation, c.desc.ServiceID)
 	}
 }
 
@@ -139,7 +142,7 @@ func (c *connection) connect(ctx context.Context) {
 	var tempNc net.Conn
 	tempNc, err = c.config.dialer.DialContext(ctx, c.addr.Network(), c.addr.String())
 	if err != nil {
-		c.processInitializationError(err)
+		c.processInitializationError(err, stageDial)
 		return
 	}
 	c.nc = tempNc
@@ -155,10 +158,13 @@ func (c *connection) connect(ctx context.Context) {
 		}
 		tlsNc, err := configureTLS(ctx, c.config.tlsConnectionSource, c.nc, c.addr, tlsConfig, ocspOpts)
 		if err != nil {
-			c.processInitializationError(err)
+			c.processInitializationError(err, stageTLS)
 			return
 		}
 		c.nc = tlsNc
+		if tlsConn, ok := tlsNc.(*tls.Conn); ok {
+			c.peerCertificates = tlsConn.ConnectionState().PeerCertificates
+		}
 	}
 
 	c.bumpIdleDeadline()
@@ -168,9 +174,11 @@ func (c *connection) connect(ctx context.Context) {
 	if handshaker == nil {
 		if c.poolMonitor != nil {
 			c.poolMonitor.Event(&event.PoolEvent{
-				Type:         event.ConnectionReady,
-				Address:      c.addr.String(),
-				ConnectionID: c.poolID,
+				Type:                     event.ConnectionReady,
+				Address:                  c.addr.String(),
+				ConnectionID:             c.poolID,
+				PeerCertificates:         c.peerCertificates,
+				PeerCertificatesNotAfter: certificatesNotAfter(c.peerCertificates),
 			})
 		}
 		return
@@ -185,48 +193,61 @@ func (c *connection) connect(ctx context.Context) {
 		// fields in handshakeInfo are tracked by the handshaker if necessary.
 		c.desc = handshakeInfo.Description
 		c.isMasterRTT = time.Since(handshakeStartTime)
-		err = handshaker.FinishHandshake(ctx, handshakeConn)
+
+		if c.config.loadBalanced {
+			// A server behind a load balancer must report a service ID, which is used to clear the pool for the service
+			// the connection is to. Connections use the pool generation for their service.
+			if c.desc.ServiceID == nil {
+				err = errLoadBalancedStateMismatch
+			} else {
+				c.desc.Kind = description.LoadBalancer
+				if c.config.getGenerationFn != nil {
+					c.generation = c.config.getGenerationFn(c.desc.ServiceID)
+				}
+			}
+		}
+
+		if err == nil {
+			err = handshaker.FinishHandshake(ctx, handshakeConn)
+		}
 	}
 
 	// We have a failed handshake here
 	if err != nil {
-		c.processInitializationError(err)
+		c.processInitializationError(err, stageHandshake)
 		return
 	}
 
-	if len(c.desc.Compression) > 0 {
-	clientMethodLoop:
-		for _, method := range c.config.compressors {
-			for _, serverMethod := range c.desc.Compression {
-				if method != serverMethod {
-					continue
-				}
-
-				switch strings.ToLower(method) {
-				case "snappy":
-					c.compressor = wiremessage.CompressorSnappy
-				case "zlib":
-					c.compressor = wiremessage.CompressorZLib
-					c.zliblevel = wiremessage.DefaultZlibLevel
-					if c.config.zlibLevel != nil {
-						c.zliblevel = *c.config.zlibLevel
-					}
-				case "zstd":
-					c.compressor = wiremessage.CompressorZstd
-					c.zstdLevel = wiremessage.DefaultZstdLevel
-					if c.config.zstdLevel != nil {
-						c.zstdLevel = *c.config.zstdLevel
-					}
-				}
-				break clientMethodLoop
-			}
+	var compressor string
+	if !c.config.disableLocalhostCompression || !isLocalhost(c.addr) {
+		compressor = negotiateCompressor(c.config.compressors, c.desc.Compression)
+	}
+	switch compressor {
+	case "snappy":
+		c.compressor = wiremessage.CompressorSnappy
+	case "zlib":
+		c.compressor = wiremessage.CompressorZLib
+		c.zliblevel = wiremessage.DefaultZlibLevel
+		if c.config.zlibLevel != nil {
+			c.zliblevel = *c.config.zlibLevel
+		}
+	case "zstd":
+		c.compressor = wiremessage.CompressorZstd
+		c.zstdLevel = wiremessage.DefaultZstdLevel
+		if c.config.zstdLevel != nil {
+			c.zstdLevel = *c.config.zstdLevel
 		}
 	}
+	if c.config.compressorCallback != nil {
+		c.config.compressorCallback(compressor)
+	}
 	if c.poolMonitor != nil {
 		c.poolMonitor.Event(&event.PoolEvent{
-			Type:         event.ConnectionReady,
-			Address:      c.addr.String(),
-			ConnectionID: c.poolID,
+			Type:                     event.ConnectionReady,
+			Address:                  c.addr.String(),
+			ConnectionID:             c.poolID,
+			PeerCertificates:         c.peerCertificates,
+			PeerCertificatesNotAfter: certificatesNotAfter(c.peerCertificates),
 		})
 	}
 }
@@ -238,6 +259,35 @@ func (c *connection) wait() error {
 	return c.connectErr
 }
 
+// negotiateCompressor returns the first of the client's compressors, in order of preference, that is also supported by
+// the server, or an empty string if there is none.
+func negotiateCompressor(clientCompressors, serverCompressors []string) string {
+	for _, method := range clientCompressors {
+		for _, serverMethod := range serverCompressors {
+			if method == serverMethod {
+				return strings.ToLower(method)
+			}
+		}
+	}
+	return ""
+}
+
+// isLocalhost returns true if addr is a Unix domain socket or a loopback address.
+func isLocalhost(addr address.Address) bool {
+	if addr.Network() == "unix" {
+		return true
+	}
+	host, _, err := net.SplitHostPort(addr.String())
+	if err != nil {
+		return false
+	}
+	if host == "localhost" {
+		return true
+	}
+	ip := net.ParseIP(host)
+	return ip != nil && ip.IsLoopback()
+}
+
 func (c *connection) closeConnectContext() {
 	<-c.connectContextMade
 	var cancelFn context.CancelFunc
@@ -540,15 +590,21 @@ func (c initConnection) SupportsStreaming() bool {
 }
 
 // Connection implements the driver.Connection interface to allow reading and writing wire
-// messages and the driver.Expirable interface to allow expiring.
+// messages and the driver.Expirable interface to allow expiring. It also implements the
+// driver.PinnedConnection interface so it can be pinned to cursors and transactions.
 type Connection struct {
 	*connection
 
+	// cursorPins and transactionPins are the number of cursors and transactions that have pinned the connection.
+	cursorPins      uint64
+	transactionPins uint64
+
 	mu sync.RWMutex
 }
 
 var _ driver.Connection = (*Connection)(nil)
 var _ driver.Expirable = (*Connection)(nil)
+var _ driver.PinnedConnection = (*Connection)(nil)
 
 // WriteWireMessage handles writing a wire message to the underlying connection.
 func (c *Connection) WriteWireMessage(ctx context.Context, wm []byte) error {
@@ -615,11 +671,11 @@ func (c *Connection) Description() description.Server {
 }
 
 // Close returns this connection to the connection pool. This method may not closeConnection the underlying
-// socket.
+// socket. Close is a no-op while the connection is pinned to a cursor or transaction.
 func (c *Connection) Close() error {
 	c.mu.Lock()
 	defer c.mu.Unlock()
-	if c.connection == nil {
+	if c.connection == nil || c.cursorPins > 0 || c.transactionPins > 0 {
 		return nil
 	}
 
@@ -642,6 +698,50 @@ func (c *Connection) Expire() error {
 	return err
 }
 
+// PinToCursor pins the connection to a cursor. The connection will not be returned to the pool until the cursor
+// calls UnpinFromCursor and the connection is closed.
+func (c *Connection) PinToCursor() error {
+	return c.pin("cursor", &c.cursorPins)
+}
+
+// PinToTransaction pins the connection to a transaction. The connection will not be returned to the pool until the
+// transaction calls UnpinFromTransaction and the connection is closed.
+func (c *Connection) PinToTransaction() error {
+	return c.pin("transaction", &c.transactionPins)
+}
+
+// UnpinFromCursor removes a pin added by PinToCursor.
+func (c *Connection) UnpinFromCursor() error {
+	return c.unpin("cursor", &c.cursorPins)
+}
+
+// UnpinFromTransaction removes a pin added by PinToTransaction.
+func (c *Connection) UnpinFromTransaction() error {
+	return c.unpin("transaction", &c.transactionPins)
+}
+
+func (c *Connection) pin(reason string, pins *uint64) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.connection == nil {
+		return fmt.Errorf("attempted to pin a connection for a %s, but the connection has already been returned to the pool", reason)
+	}
+
+	*pins++
+	return nil
+}
+
+func (c *Connection) unpin(reason string, pins *uint64) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if *pins == 0 {
+		return fmt.Errorf("attempted to unpin a connection from a %s, but the connection is not pinned by a %s", reason, reason)
+	}
+
+	*pins--
+	return nil
+}
+
 // Alive returns if the connection is still alive.
 func (c *Connection) Alive() bool {
 	return c.connection != nil
@@ -687,6 +787,18 @@ func (c *Connection) LocalAddress() address.Address {
 var notMasterCodes = []int32{10107, 13435}
 var recoveringCodes = []int32{11600, 11602, 13436, 189, 91}
 
+// certificatesNotAfter returns the earliest expiration time of the certificates in certs, or the zero time if certs is
+// empty.
+func certificatesNotAfter(certs []*x509.Certificate) time.Time {
+	var notAfter time.Time
+	for _, cert := range certs {
+		if notAfter.IsZero() || cert.NotAfter.Before(notAfter) {
+			notAfter = cert.NotAfter
+		}
+	}
+	return notAfter
+}
+
 func configureTLS(ctx context.Context,
 	tlsConnSource tlsConnectionSource,
 	nc net.Conn,
@@ -6,6 +6,7 @@ import (
 	"net"
 	"time"
 
+	"go.mongodb.org/mongo-driver/bson/primitive"
 	"go.mongodb.org/mongo-driver/event"
 	"go.mongodb.org/mongo-driver/x/mongo/driver"
 	"go.mongodb.org/mongo-driver/x/mongo/driver/ocsp"
@@ -35,24 +36,32 @@ var DefaultDialer Dialer = &net.Dialer{}
 // initialization. Implementations must be goroutine safe.
 type Handshaker = driver.Handshaker
 
+// generationNumberFn returns the pool generation for the given service ID, which is nil unless the connection is to a
+// server behind a load balancer.
+type generationNumberFn func(serviceID *primitive.ObjectID) uint64
+
 type connectionConfig struct {
-	appName                  string
-	connectTimeout           time.Duration
-	dialer                   Dialer
-	handshaker               Handshaker
-	idleTimeout              time.Duration
-	cmdMonitor               *event.CommandMonitor
-	poolMonitor              *event.PoolMonitor
-	readTimeout              time.Duration
-	writeTimeout             time.Duration
-	tlsConfig                *tls.Config
-	compressors              []string
-	zlibLevel                *int
-	zstdLevel                *int
-	ocspCache                ocsp.Cache
-	disableOCSPEndpointCheck bool
-	errorHandlingCallback    func(error, uint64)
-	tlsConnectionSource      tlsConnectionSource
+	appName                     string
+	connectTimeout              time.Duration
+	dialer                      Dialer
+	handshaker                  Handshaker
+	idleTimeout                 time.Duration
+	cmdMonitor                  *event.CommandMonitor
+	poolMonitor                 *event.PoolMonitor
+	readTimeout                 time.Duration
+	writeTimeout                time.Duration
+	tlsConfig                   *tls.Config
+	compressors                 []string
+	compressorCallback          func(string)
+	disableLocalhostCompression bool
+	zlibLevel                   *int
+	zstdLevel                   *int
+	ocspCache                   ocsp.Cache
+	disableOCSPEndpointCheck    bool
+	errorHandlingCallback       func(error, uint64, *primitive.ObjectID)
+	tlsConnectionSource         tlsConnectionSource
+	loadBalanced                bool
+	getGenerationFn             generationNumberFn
 }
 
 func newConnectionConfig(opts ...ConnectionOption) (*connectionConfig, error) {
@@ -86,7 +95,7 @@ func withTLSConnectionSource(fn func(tlsConnectionSource) tlsConnectionSource) C
 	}
 }
 
-func withErrorHandlingCallback(fn func(error, uint64)) ConnectionOption {
+func withErrorHandlingCallback(fn func(error, uint64, *primitive.ObjectID)) ConnectionOption {
 	return func(c *connectionConfig) error {
 		c.errorHandlingCallback = fn
 		return nil
@@ -101,6 +110,22 @@ func WithCompressors(fn func([]string) []string) ConnectionOption {
 	}
 }
 
+// WithDisableLocalhostCompression configures whether compression is disabled for connections to Unix domain sockets
+// and loopback addresses, which do not benefit from compression. The default is false.
+func WithDisableLocalhostCompression(fn func(bool) bool) ConnectionOption {
+	return func(c *connectionConfig) error {
+		c.disableLocalhostCompression = fn(c.disableLocalhostCompression)
+		return nil
+	}
+}
+
+func withCompressorCallback(fn func(string)) ConnectionOption {
+	return func(c *connectionConfig) error {
+		c.compressorCallback = fn
+		return nil
+	}
+}
+
 // WithConnectTimeout configures the maximum amount of time a dial will wait for a
 // Connect to complete. The default is 30 seconds.
 func WithConnectTimeout(fn func(time.Duration) time.Duration) ConnectionOption {
@@ -208,3 +233,18 @@ func WithDisableOCSPEndpointCheck(fn func(bool) bool) ConnectionOption {
 		return nil
 	}
 }
+
+// WithConnectionLoadBalanced specifies whether or not the connection is to a server behind a load balancer.
+func WithConnectionLoadBalanced(fn func(bool) bool) ConnectionOption {
+	return func(c *connectionConfig) error {
+		c.loadBalanced = fn(c.loadBalanced)
+		return nil
+	}
+}
+
+func withGenerationNumberFn(fn func(generationNumberFn) generationNumberFn) ConnectionOption {
+	return func(c *connectionConfig) error {
+		c.getGenerationFn = fn(c.getGenerationFn)
+		return nil
+	}
+}
@@ -8,8 +8,14 @@ package topology
 
 import (
 	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
 	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
 	"errors"
+	"math/big"
 	"net"
 	"sync"
 	"sync/atomic"
@@ -17,11 +23,14 @@ import (
 	"time"
 
 	"github.com/google/go-cmp/cmp"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/event"
 	"go.mongodb.org/mongo-driver/internal"
 	"go.mongodb.org/mongo-driver/internal/testutil/assert"
 	"go.mongodb.org/mongo-driver/mongo/address"
 	"go.mongodb.org/mongo-driver/mongo/description"
 	"go.mongodb.org/mongo-driver/x/mongo/driver"
+	"go.mongodb.org/mongo-driver/x/mongo/driver/wiremessage"
 )
 
 type testHandshaker struct {
@@ -128,7 +137,7 @@ func TestConnection(t *testing.T) {
 							return &net.TCPConn{}, nil
 						})
 					}),
-					withErrorHandlingCallback(func(err error, _ uint64) {
+					withErrorHandlingCallback(func(err error, _ uint64, _ *primitive.ObjectID) {
 						got = err
 					}),
 				)
@@ -140,6 +149,99 @@ func TestConnection(t *testing.T) {
 				assert.NotNil(t, err, "expected connect error %v, got nil", want)
 				assert.Equal(t, want, got, "expected error %v, got %v", want, got)
 			})
+			t.Run("load balanced", func(t *testing.T) {
+				serviceID := primitive.NewObjectID()
+				testCases := []struct {
+					name      string
+					serviceID *primitive.ObjectID
+					wantErr   error
+				}{
+					{"service ID is required", nil, ConnectionError{Wrapped: errLoadBalancedStateMismatch, init: true}},
+					{"service ID is set", &serviceID, nil},
+				}
+				for _, tc := range testCases {
+					t.Run(tc.name, func(t *testing.T) {
+						conn, err := newConnection(address.Address(""),
+							WithConnectionLoadBalanced(func(bool) bool { return true }),
+							WithHandshaker(func(Handshaker) Handshaker {
+								return &testHandshaker{
+									getHandshakeInformation: func(context.Context, address.Address, driver.Connection) (driver.HandshakeInformation, error) {
+										return driver.HandshakeInformation{Description: description.Server{ServiceID: tc.serviceID}}, nil
+									},
+								}
+							}),
+							WithDialer(func(Dialer) Dialer {
+								return DialerFunc(func(context.Context, string, string) (net.Conn, error) {
+									return &net.TCPConn{}, nil
+								})
+							}),
+							withGenerationNumberFn(func(generationNumberFn) generationNumberFn {
+								return func(*primitive.ObjectID) uint64 { return 3 }
+							}),
+						)
+						noerr(t, err)
+						conn.connect(context.Background())
+
+						err = conn.wait()
+						if !cmp.Equal(err, tc.wantErr, cmp.Comparer(compareErrors)) {
+							t.Fatalf("errors do not match. got %v; want %v", err, tc.wantErr)
+						}
+						if tc.wantErr != nil {
+							return
+						}
+						assert.Equal(t, description.LoadBalancer, conn.desc.Kind,
+							"expected server kind %v, got %v", description.LoadBalancer, conn.desc.Kind)
+						assert.Equal(t, uint64(3), conn.generation, "expected generation 3, got %d", conn.generation)
+					})
+				}
+			})
+			t.Run("compression", func(t *testing.T) {
+				testCases := []struct {
+					name             string
+					addr             address.Address
+					disableLocalhost bool
+					want             wiremessage.CompressorID
+					wantName         string
+				}{
+					{"client preference order", "example.com:27017", false, wiremessage.CompressorZLib, "zlib"},
+					{"localhost not disabled", "localhost:27017", false, wiremessage.CompressorZLib, "zlib"},
+					{"localhost disabled", "localhost:27017", true, wiremessage.CompressorNoOp, ""},
+					{"loopback disabled", "127.0.0.1:27017", true, wiremessage.CompressorNoOp, ""},
+					{"unix socket disabled", "/tmp/mongodb-27017.sock", true, wiremessage.CompressorNoOp, ""},
+					{"remote not disabled", "example.com:27017", true, wiremessage.CompressorZLib, "zlib"},
+				}
+				for _, tc := range testCases {
+					t.Run(tc.name, func(t *testing.T) {
+						negotiated := "unset"
+						conn, err := newConnection(tc.addr,
+							WithCompressors(func([]string) []string { return []string{"zlib", "snappy"} }),
+							WithDisableLocalhostCompression(func(bool) bool { return tc.disableLocalhost }),
+							withCompressorCallback(func(compressor string) { negotiated = compressor }),
+							WithHandshaker(func(Handshaker) Handshaker {
+								return &testHandshaker{
+									getHandshakeInformation: func(context.Context, address.Address, driver.Connection) (driver.HandshakeInformation, error) {
+										desc := description.Server{Compression: []string{"snappy", "zlib"}}
+										return driver.HandshakeInformation{Description: desc}, nil
+									},
+								}
+							}),
+							WithDialer(func(Dialer) Dialer {
+								return DialerFunc(func(context.Context, string, string) (net.Conn, error) {
+									return &net.TCPConn{}, nil
+								})
+							}),
+						)
+						assert.Nil(t, err, "newConnection error: %v", err)
+
+						conn.connect(context.Background())
+						err = conn.wait()
+						assert.Nil(t, err, "error establishing connection: %v", err)
+						assert.Equal(t, tc.want, conn.compressor, "expected compressor %v, got %v", tc.want, conn.compressor)
+						assert.Equal(t, tc.wantName, negotiated, "expected negotiated compressor %q, got %q",
+							tc.wantName, negotiated)
+					})
+				}
+			})
 			t.Run("context is not pinned by connect", func(t *testing.T) {
 				// connect creates a cancel-able version of the context passed to it and stores the CancelFunc on the
 				// connection. The CancelFunc must be set to nil once the connection has been established so the driver
@@ -251,6 +353,45 @@ func TestConnection(t *testing.T) {
 						})
 					}
 				})
+				t.Run("peer certificates in ready event", func(t *testing.T) {
+					notAfter := time.Now().Add(24 * time.Hour).Truncate(time.Second).UTC()
+					cert := newTestCertificate(t, notAfter)
+
+					server, client := net.Pipe()
+					defer server.Close()
+					go func() {
+						_ = tls.Server(server, &tls.Config{Certificates: []tls.Certificate{cert}}).Handshake()
+					}()
+
+					var readyEvt *event.PoolEvent
+					conn, err := newConnection(address.Address("localhost:27017"),
+						WithDialer(func(Dialer) Dialer {
+							return DialerFunc(func(context.Context, string, string) (net.Conn, error) {
+								return client, nil
+							})
+						}),
+						WithTLSConfig(func(*tls.Config) *tls.Config {
+							return &tls.Config{InsecureSkipVerify: true}
+						}),
+					)
+					assert.Nil(t, err, "newConnection error: %v", err)
+					conn.poolMonitor = &event.PoolMonitor{
+						Event: func(evt *event.PoolEvent) {
+							if evt.Type == event.ConnectionReady {
+								readyEvt = evt
+							}
+						},
+					}
+
+					conn.connect(context.Background())
+					err = conn.wait()
+					assert.Nil(t, err, "connect error: %v", err)
+					assert.NotNil(t, readyEvt, "expected a %v event, got none", event.ConnectionReady)
+					assert.Equal(t, 1, len(readyEvt.PeerCertificates), "expected 1 peer certificate, got %d",
+						len(readyEvt.PeerCertificates))
+					assert.True(t, readyEvt.PeerCertificatesNotAfter.Equal(notAfter), "expected NotAfter %v, got %v",
+						notAfter, readyEvt.PeerCertificatesNotAfter)
+				})
 			})
 		})
 		t.Run("writeWireMessage", func(t *testing.T) {
@@ -635,6 +776,24 @@ func TestConnection(t *testing.T) {
 		})
 	})
 	t.Run("Connection", func(t *testing.T) {
+		t.Run("pinning", func(t *testing.T) {
+			conn := &Connection{connection: &connection{}}
+			noerr(t, conn.PinToCursor())
+			noerr(t, conn.PinToTransaction())
+
+			// Close should not return the connection to the pool while it is pinned.
+			noerr(t, conn.Close())
+			assert.NotNil(t, conn.connection, "expected pinned connection to not be returned to the pool")
+
+			noerr(t, conn.UnpinFromCursor())
+			err := conn.UnpinFromCursor()
+			assert.NotNil(t, err, "expected error unpinning a connection that is not pinned by a cursor, got nil")
+			noerr(t, conn.UnpinFromTransaction())
+
+			conn.connection = nil
+			err = conn.PinToCursor()
+			assert.NotNil(t, err, "expected error pinning a closed connection, got nil")
+		})
 		t.Run("nil connection does not panic", func(t *testing.T) {
 			conn := &Connection{}
 			defer func() {
@@ -993,3 +1152,20 @@ func (t *testCancellationListener) assertMethodsCalled(testingT *testing.T, numL
 	assert.Equal(testingT, numStopListening, t.numStopListening, "expected StopListening to be called %d times, got %d",
 		numListen, t.numListen)
 }
+
+func newTestCertificate(t *testing.T, notAfter time.Time) tls.Certificate {
+	t.Helper()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	assert.Nil(t, err, "GenerateKey error: %v", err)
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "localhost"},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     notAfter,
+		DNSNames:     []string{"localhost"},
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	assert.Nil(t, err, "CreateCertificate error: %v", err)
+	return tls.Certificate{Certificate: [][]byte{der}, PrivateKey: key}
+}
@@ -14,9 +14,20 @@ type ConnectionError struct {
 	// init will be set to true if this error occured during connection initialization or
 	// during a connection handshake.
 	init    bool
+	stage   connectionStage
 	message string
 }
 
+// connectionStage identifies the step of connection initialization during which an error occurred.
+type connectionStage uint8
+
+const (
+	stageUnknown connectionStage = iota
+	stageDial
+	stageTLS
+	stageHandshake
+)
+
 // Error implements the error interface.
 func (e ConnectionError) Error() string {
 	message := e.message
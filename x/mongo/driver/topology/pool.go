@@ -13,8 +13,10 @@ import (
 	"sync/atomic"
 	"time"
 
+	"go.mongodb.org/mongo-driver/bson/primitive"
 	"go.mongodb.org/mongo-driver/event"
 	"go.mongodb.org/mongo-driver/mongo/address"
+	"go.mongodb.org/mongo-driver/x/mongo/driver"
 	"golang.org/x/sync/semaphore"
 )
 
@@ -46,8 +48,15 @@ type poolConfig struct {
 	MaxPoolSize uint64 // MaxPoolSize is not used because handling the max number of connections in the pool is handled in server. This is only used for command monitoring
 	MaxIdleTime time.Duration
 	PoolMonitor *event.PoolMonitor
+
+	// MaxConnecting is the maximum number of connections that can be established concurrently. If it is 0,
+	// defaultMaxConnecting is used.
+	MaxConnecting uint64
 }
 
+// defaultMaxConnecting is the default maximum number of connections a pool establishes concurrently.
+const defaultMaxConnecting = 2
+
 // checkOutResult is all the values that can be returned from a checkOut
 type checkOutResult struct {
 	c      *connection
@@ -63,10 +72,17 @@ type pool struct {
 	generation uint64        // must be accessed using atomic package
 	monitor    *event.PoolMonitor
 
+	// serviceGenerations holds the generation for each service ID when the pool is for a server behind a load
+	// balancer. It must be accessed while holding generationMu.
+	serviceGenerations map[primitive.ObjectID]uint64
+	generationMu       sync.Mutex
+
 	connected int32 // Must be accessed using the sync/atomic package.
 	nextid    uint64
 	opened    map[uint64]*connection // opened holds all of the currently open connections.
 	sem       *semaphore.Weighted
+	// connecting limits the number of connections that are being established at the same time.
+	connecting *semaphore.Weighted
 	sync.Mutex
 }
 
@@ -126,7 +142,7 @@ func (p *pool) connectionInitFunc() interface{} {
 		return nil
 	}
 
-	go c.connect(context.Background())
+	go p.connectConnection(context.Background(), c)
 
 	return c
 }
@@ -134,7 +150,7 @@ func (p *pool) connectionInitFunc() interface{} {
 // newPool creates a new pool that will hold size number of idle connections. It will use the
 // provided options when creating connections.
 func newPool(config poolConfig, connOpts ...ConnectionOption) (*pool, error) {
-	opts := connOpts
+	opts := append([]ConnectionOption(nil), connOpts...)
 	if config.MaxIdleTime != time.Duration(0) {
 		opts = append(opts, WithIdleTimeout(func(_ time.Duration) time.Duration { return config.MaxIdleTime }))
 	}
@@ -146,15 +162,21 @@ func newPool(config poolConfig, connOpts ...ConnectionOption) (*pool, error) {
 	if maxConns == 0 {
 		maxConns = math.MaxInt64
 	}
+	var maxConnecting = config.MaxConnecting
+	if maxConnecting == 0 {
+		maxConnecting = defaultMaxConnecting
+	}
 
 	pool := &pool{
-		address:   config.Address,
-		monitor:   config.PoolMonitor,
-		connected: disconnected,
-		opened:    make(map[uint64]*connection),
-		opts:      opts,
-		sem:       semaphore.NewWeighted(int64(maxConns)),
+		address:            config.Address,
+		monitor:            config.PoolMonitor,
+		connected:          disconnected,
+		opened:             make(map[uint64]*connection),
+		serviceGenerations: make(map[primitive.ObjectID]uint64),
+		sem:                semaphore.NewWeighted(int64(maxConns)),
+		connecting:         semaphore.NewWeighted(int64(maxConnecting)),
 	}
+	pool.opts = append(opts, withGenerationNumberFn(func(generationNumberFn) generationNumberFn { return pool.getGeneration }))
 
 	// we do not pass in config.MaxPoolSize because we manage the max size at this level rather than the resource pool level
 	rpc := resourcePoolConfig{
@@ -187,9 +209,10 @@ func newPool(config poolConfig, connOpts ...ConnectionOption) (*pool, error) {
 	return pool, nil
 }
 
-// stale checks if a given connection's generation is below the generation of the pool
+// stale checks if a given connection's generation is below the generation of the pool. For connections to a server
+// behind a load balancer, the generation for the connection's service ID is used.
 func (p *pool) stale(c *connection) bool {
-	return c == nil || c.generation < atomic.LoadUint64(&p.generation)
+	return c == nil || c.generation < p.getGeneration(c.desc.ServiceID)
 }
 
 // connect puts the pool into the connected state, allowing it to be used and will allow items to begin being processed from the wait queue
@@ -203,14 +226,24 @@ func (p *pool) connect() error {
 
 // disconnect disconnects the pool and closes all connections including those both in and out of the pool
 func (p *pool) disconnect(ctx context.Context) error {
+	_, err := p.disconnectWithStats(ctx)
+	return err
+}
+
+// disconnectWithStats disconnects the pool like disconnect and returns the number of connections that were closed.
+func (p *pool) disconnectWithStats(ctx context.Context) (DisconnectStats, error) {
 	if !atomic.CompareAndSwapInt32(&p.connected, connected, disconnecting) {
-		return ErrPoolDisconnected
+		return DisconnectStats{}, ErrPoolDisconnected
 	}
 
 	if ctx == nil {
 		ctx = context.Background()
 	}
 
+	p.Lock()
+	opened := len(p.opened)
+	p.Unlock()
+
 	p.conns.Close()
 	atomic.AddUint64(&p.generation, 1)
 
@@ -252,6 +285,10 @@ func (p *pool) disconnect(ctx context.Context) error {
 		_ = p.removeConnection(pc, event.ReasonPoolClosed)
 		_ = p.closeConnection(pc) // We don't care about errors while closing the connection.
 	}
+	stats := DisconnectStats{
+		ConnectionsClosed:      opened,
+		InUseConnectionsClosed: len(toClose),
+	}
 	atomic.StoreInt32(&p.connected, disconnected)
 	p.conns.clearTotal()
 
@@ -262,7 +299,7 @@ func (p *pool) disconnect(ctx context.Context) error {
 		})
 	}
 
-	return err
+	return stats, err
 }
 
 // makeNewConnection creates a new connection instance and emits a ConnectionCreatedEvent. The caller must call
@@ -310,8 +347,63 @@ func (p *pool) makeNewConnection() (*connection, string, error) {
 
 }
 
-func (p *pool) getGeneration() uint64 {
-	return atomic.LoadUint64(&p.generation)
+// getGeneration returns the current generation of the pool. If serviceID is not nil, the generation for that service
+// is returned instead.
+func (p *pool) getGeneration(serviceID *primitive.ObjectID) uint64 {
+	if serviceID == nil {
+		return atomic.LoadUint64(&p.generation)
+	}
+
+	p.generationMu.Lock()
+	defer p.generationMu.Unlock()
+	return p.serviceGenerations[*serviceID]
+}
+
+// connectConnection calls c.connect while holding one of the pool's connecting slots so at most MaxConnecting
+// connections are established at the same time.
+func (p *pool) connectConnection(ctx context.Context, c *connection) {
+	if err := p.connecting.Acquire(ctx, 1); err != nil {
+		// The context is done, so connect will fail without dialing and record the error for c.wait.
+		c.connect(ctx)
+		return
+	}
+	defer p.connecting.Release(1)
+
+	c.connect(ctx)
+}
+
+// warmUp makes sure the pool has at least MinPoolSize connections and waits until the idle connections in the pool
+// are established. It returns the first error that occurred while establishing a connection. Connections that could
+// not be established are removed from the pool by the background maintenance routine.
+func (p *pool) warmUp(ctx context.Context) error {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	if atomic.LoadInt32(&p.connected) != connected {
+		return ErrPoolDisconnected
+	}
+
+	// The connections added by Maintain are connected in the background, so we only wait for them here.
+	p.conns.Maintain()
+
+	var err error
+	for _, v := range p.conns.values() {
+		c, ok := v.(*connection)
+		if !ok || c == nil {
+			continue
+		}
+
+		select {
+		case <-c.connectDone:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+		if c.connectErr != nil && err == nil {
+			err = c.connectErr
+		}
+	}
+	return err
 }
 
 // Checkout returns a connection from the pool
@@ -321,25 +413,13 @@ func (p *pool) get(ctx context.Context) (*connection, error) {
 	}
 
 	if atomic.LoadInt32(&p.connected) != connected {
-		if p.monitor != nil {
-			p.monitor.Event(&event.PoolEvent{
-				Type:    event.GetFailed,
-				Address: p.address.String(),
-				Reason:  event.ReasonPoolClosed,
-			})
-		}
+		p.publishCheckoutEvent(ctx, &event.PoolEvent{Type: event.GetFailed, Reason: event.ReasonPoolClosed})
 		return nil, ErrPoolDisconnected
 	}
 
 	err := p.sem.Acquire(ctx, 1)
 	if err != nil {
-		if p.monitor != nil {
-			p.monitor.Event(&event.PoolEvent{
-				Type:    event.GetFailed,
-				Address: p.address.String(),
-				Reason:  event.ReasonTimedOut,
-			})
-		}
+		p.publishCheckoutEvent(ctx, &event.PoolEvent{Type: event.GetFailed, Reason: event.ReasonTimedOut})
 		errWaitQueueTimeout := WaitQueueTimeoutError{
 			Wrapped: ctx.Err(),
 		}
@@ -350,13 +430,7 @@ func (p *pool) get(ctx context.Context) (*connection, error) {
 	// calling p.conns.Get() and making the new connection
 	for {
 		if atomic.LoadInt32(&p.connected) != connected {
-			if p.monitor != nil {
-				p.monitor.Event(&event.PoolEvent{
-					Type:    event.GetFailed,
-					Address: p.address.String(),
-					Reason:  event.ReasonPoolClosed,
-				})
-			}
+			p.publishCheckoutEvent(ctx, &event.PoolEvent{Type: event.GetFailed, Reason: event.ReasonPoolClosed})
 			p.sem.Release(1)
 			return nil, ErrPoolDisconnected
 		}
@@ -365,7 +439,7 @@ func (p *pool) get(ctx context.Context) (*connection, error) {
 		if c, ok := connVal.(*connection); ok && connVal != nil {
 			// call connect if not connected
 			if atomic.LoadInt32(&c.connected) == initialized {
-				c.connect(ctx)
+				p.connectConnection(ctx, c)
 			}
 
 			err := c.wait()
@@ -375,35 +449,17 @@ func (p *pool) get(ctx context.Context) (*connection, error) {
 				p.conns.decrementTotal()
 				p.sem.Release(1)
 
-				if p.monitor != nil {
-					p.monitor.Event(&event.PoolEvent{
-						Type:    event.GetFailed,
-						Address: p.address.String(),
-						Reason:  event.ReasonConnectionErrored,
-					})
-				}
+				p.publishCheckoutEvent(ctx, &event.PoolEvent{Type: event.GetFailed, Reason: event.ReasonConnectionErrored})
 				return nil, err
 			}
 
-			if p.monitor != nil {
-				p.monitor.Event(&event.PoolEvent{
-					Type:         event.GetSucceeded,
-					Address:      p.address.String(),
-					ConnectionID: c.poolID,
-				})
-			}
+			p.publishCheckoutEvent(ctx, &event.PoolEvent{Type: event.GetSucceeded, ConnectionID: c.poolID})
 			return c, nil
 		}
 
 		select {
 		case <-ctx.Done():
-			if p.monitor != nil {
-				p.monitor.Event(&event.PoolEvent{
-					Type:    event.GetFailed,
-					Address: p.address.String(),
-					Reason:  event.ReasonTimedOut,
-				})
-			}
+			p.publishCheckoutEvent(ctx, &event.PoolEvent{Type: event.GetFailed, Reason: event.ReasonTimedOut})
 			p.sem.Release(1)
 			return nil, ctx.Err()
 		default:
@@ -416,21 +472,15 @@ func (p *pool) get(ctx context.Context) (*connection, error) {
 			c, reason, err := p.makeNewConnection()
 
 			if err != nil {
-				if p.monitor != nil {
-					// We only publish a GetFailed event because makeNewConnection has already published
-					// ConnectionClosed if needed.
-					p.monitor.Event(&event.PoolEvent{
-						Type:    event.GetFailed,
-						Address: p.address.String(),
-						Reason:  reason,
-					})
-				}
+				// We only publish a GetFailed event because makeNewConnection has already published ConnectionClosed if
+				// needed.
+				p.publishCheckoutEvent(ctx, &event.PoolEvent{Type: event.GetFailed, Reason: reason})
 				p.conns.decrementTotal()
 				p.sem.Release(1)
 				return nil, err
 			}
 
-			c.connect(ctx)
+			p.connectConnection(ctx, c)
 			// wait for conn to be connected
 			err = c.wait()
 			if err != nil {
@@ -439,28 +489,28 @@ func (p *pool) get(ctx context.Context) (*connection, error) {
 				p.conns.decrementTotal()
 				p.sem.Release(1)
 
-				if p.monitor != nil {
-					p.monitor.Event(&event.PoolEvent{
-						Type:    event.GetFailed,
-						Address: p.address.String(),
-						Reason:  reason,
-					})
-				}
+				p.publishCheckoutEvent(ctx, &event.PoolEvent{Type: event.GetFailed, Reason: reason})
 				return nil, err
 			}
 
-			if p.monitor != nil {
-				p.monitor.Event(&event.PoolEvent{
-					Type:         event.GetSucceeded,
-					Address:      p.address.String(),
-					ConnectionID: c.poolID,
-				})
-			}
+			p.publishCheckoutEvent(ctx, &event.PoolEvent{Type: event.GetSucceeded, ConnectionID: c.poolID})
 			return c, nil
 		}
 	}
 }
 
+// publishCheckoutEvent publishes a GetSucceeded or GetFailed event with the IDs of the operation that is checking out a
+// connection with ctx.
+func (p *pool) publishCheckoutEvent(ctx context.Context, evt *event.PoolEvent) {
+	if p.monitor == nil {
+		return
+	}
+	evt.Address = p.address.String()
+	evt.OperationID = driver.OperationID(ctx)
+	evt.CorrelationID = driver.CorrelationID(ctx)
+	p.monitor.Event(evt)
+}
+
 // closeConnection closes a connection, not the pool itself. This method will actually closeConnection the connection,
 // making it unusable, to instead return the connection to the pool, use put.
 func (p *pool) closeConnection(c *connection) error {
@@ -544,13 +594,23 @@ func (p *pool) put(c *connection) error {
 	return nil
 }
 
-// clear clears the pool by incrementing the generation
-func (p *pool) clear() {
+// clear clears the pool by incrementing the generation. If serviceID is not nil, only the connections for that service
+// are cleared.
+func (p *pool) clear(serviceID *primitive.ObjectID) {
 	if p.monitor != nil {
 		p.monitor.Event(&event.PoolEvent{
-			Type:    event.PoolCleared,
-			Address: p.address.String(),
+			Type:      event.PoolCleared,
+			Address:   p.address.String(),
+			ServiceID: serviceID,
 		})
 	}
-	atomic.AddUint64(&p.generation, 1)
+
+	if serviceID == nil {
+		atomic.AddUint64(&p.generation, 1)
+		return
+	}
+
+	p.generationMu.Lock()
+	p.serviceGenerations[*serviceID]++
+	p.generationMu.Unlock()
 }
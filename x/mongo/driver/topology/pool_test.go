@@ -9,8 +9,12 @@ import (
 	"testing"
 	"time"
 
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/event"
 	"go.mongodb.org/mongo-driver/internal/testutil/assert"
 	"go.mongodb.org/mongo-driver/mongo/address"
+	"go.mongodb.org/mongo-driver/mongo/description"
+	"go.mongodb.org/mongo-driver/x/mongo/driver"
 	"go.mongodb.org/mongo-driver/x/mongo/driver/operation"
 )
 
@@ -155,6 +159,37 @@ func TestPool(t *testing.T) {
 			}
 			close(cleanup)
 		})
+		t.Run("reports closed connections", func(t *testing.T) {
+			cleanup := make(chan struct{})
+			addr := bootstrapConnections(t, 3, func(nc net.Conn) {
+				<-cleanup
+				_ = nc.Close()
+			})
+			d := newdialer(&net.Dialer{})
+			pc := poolConfig{
+				Address: address.Address(addr.String()),
+			}
+			p, err := newPool(pc, WithDialer(func(Dialer) Dialer { return d }))
+			noerr(t, err)
+			err = p.connect()
+			noerr(t, err)
+			conns := [3]*connection{}
+			for idx := range [3]struct{}{} {
+				conns[idx], err = p.get(context.Background())
+				noerr(t, err)
+			}
+			for idx := range [2]struct{}{} {
+				err = p.put(conns[idx])
+				noerr(t, err)
+			}
+
+			stats, err := p.disconnectWithStats(context.Background())
+			noerr(t, err)
+			assertConnectionsClosed(t, d, 3)
+			want := DisconnectStats{ConnectionsClosed: 3, InUseConnectionsClosed: 1}
+			assert.Equal(t, want, stats, "expected stats %v, got %v", want, stats)
+			close(cleanup)
+		})
 		t.Run("properly sets the connection state on return", func(t *testing.T) {
 			cleanup := make(chan struct{})
 			addr := bootstrapConnections(t, 3, func(nc net.Conn) {
@@ -386,9 +421,27 @@ func TestPool(t *testing.T) {
 			}
 			close(cleanup)
 		})
+		t.Run("checkout events include the correlation ID", func(t *testing.T) {
+			var events []*event.PoolEvent
+			pc := poolConfig{
+				Address:     address.Address("localhost:27017"),
+				PoolMonitor: &event.PoolMonitor{Event: func(evt *event.PoolEvent) { events = append(events, evt) }},
+			}
+			p, err := newPool(pc)
+			noerr(t, err)
+			ctx := driver.WithCorrelationID(context.Background(), "trace")
+			_, err = p.get(ctx)
+			if err != ErrPoolDisconnected {
+				t.Errorf("Should return ErrPoolDisconnected from a disconnected pool. got %v", err)
+			}
+			evt := events[len(events)-1]
+			assert.Equal(t, event.GetFailed, evt.Type, "expected event type %v, got %v", event.GetFailed, evt.Type)
+			assert.Equal(t, "trace", evt.CorrelationID, "expected correlation ID trace, got %q", evt.CorrelationID)
+			assert.Equal(t, int64(0), evt.OperationID, "expected operation ID 0, got %v", evt.OperationID)
+		})
 		t.Run("return error when attempting to create new connection", func(t *testing.T) {
 			wanterr := errors.New("create new connection error")
-			var want error = ConnectionError{Wrapped: wanterr, init: true}
+			var want error = ConnectionError{Wrapped: wanterr, init: true, stage: stageDial}
 			var dialer DialerFunc = func(context.Context, string, string) (net.Conn, error) { return nil, wanterr }
 			pc := poolConfig{
 				Address: address.Address(""),
@@ -687,11 +740,133 @@ func TestPool(t *testing.T) {
 
 			// Increment the pool's generation number so the connection will be considered stale and will be closed by
 			// get().
-			p.clear()
+			p.clear(nil)
 			_, err = p.get(context.Background())
 			noerr(t, err)
 		})
 	})
+	t.Run("clear with service ID", func(t *testing.T) {
+		p, err := newPool(poolConfig{Address: address.Address("")})
+		noerr(t, err)
+
+		serviceID := primitive.NewObjectID()
+		otherServiceID := primitive.NewObjectID()
+		c := &connection{generation: 0, desc: description.Server{ServiceID: &serviceID}}
+		other := &connection{generation: 0, desc: description.Server{ServiceID: &otherServiceID}}
+
+		p.clear(&serviceID)
+		assert.True(t, p.stale(c), "expected connection for the cleared service to be stale")
+		assert.False(t, p.stale(other), "expected connection for another service to not be stale")
+		assert.Equal(t, uint64(0), p.getGeneration(nil), "expected pool generation 0, got %d", p.getGeneration(nil))
+		assert.Equal(t, uint64(1), p.getGeneration(&serviceID),
+			"expected service generation 1, got %d", p.getGeneration(&serviceID))
+	})
+	t.Run("warmUp", func(t *testing.T) {
+		t.Run("establishes minimum pool size connections", func(t *testing.T) {
+			cleanup := make(chan struct{})
+			addr := bootstrapConnections(t, 3, func(nc net.Conn) {
+				<-cleanup
+				_ = nc.Close()
+			})
+			d := newdialer(&net.Dialer{})
+			pc := poolConfig{
+				Address:     address.Address(addr.String()),
+				MinPoolSize: 3,
+			}
+			p, err := newPool(pc, WithDialer(func(Dialer) Dialer { return d }))
+			noerr(t, err)
+			err = p.connect()
+			noerr(t, err)
+
+			err = p.warmUp(context.Background())
+			assert.Nil(t, err, "warmUp error: %v", err)
+			conns := p.conns.values()
+			assert.Equal(t, 3, len(conns), "expected 3 idle connections, got %d", len(conns))
+			for _, v := range conns {
+				c := v.(*connection)
+				state := atomic.LoadInt32(&c.connected)
+				assert.Equal(t, int32(connected), state, "expected connection state %d, got %d", connected, state)
+			}
+
+			err = p.disconnect(context.Background())
+			noerr(t, err)
+			close(cleanup)
+		})
+		t.Run("returns connection error", func(t *testing.T) {
+			dialErr := errors.New("dial error")
+			var dialer DialerFunc = func(context.Context, string, string) (net.Conn, error) {
+				return nil, dialErr
+			}
+			pc := poolConfig{
+				Address:     address.Address("localhost:27017"),
+				MinPoolSize: 1,
+			}
+			p, err := newPool(pc, WithDialer(func(Dialer) Dialer { return dialer }))
+			noerr(t, err)
+			err = p.connect()
+			noerr(t, err)
+			defer func() {
+				_ = p.disconnect(context.Background())
+			}()
+
+			err = p.warmUp(context.Background())
+			connErr, ok := err.(ConnectionError)
+			assert.True(t, ok, "expected error of type %T, got %v of type %T", ConnectionError{}, err, err)
+			assert.Equal(t, dialErr, connErr.Wrapped, "expected wrapped error %v, got %v", dialErr, connErr.Wrapped)
+		})
+		t.Run("cannot warm up disconnected pool", func(t *testing.T) {
+			p, err := newPool(poolConfig{Address: address.Address("localhost:27017"), MinPoolSize: 1})
+			noerr(t, err)
+
+			err = p.warmUp(context.Background())
+			assert.Equal(t, ErrPoolDisconnected, err, "expected error %v, got %v", ErrPoolDisconnected, err)
+		})
+	})
+	t.Run("maxConnecting limits concurrent connection establishment", func(t *testing.T) {
+		cleanup := make(chan struct{})
+		addr := bootstrapConnections(t, 4, func(nc net.Conn) {
+			<-cleanup
+			_ = nc.Close()
+		})
+		var connecting, maxConnecting int32
+		var dialer DialerFunc = func(ctx context.Context, network, address string) (net.Conn, error) {
+			curr := atomic.AddInt32(&connecting, 1)
+			defer atomic.AddInt32(&connecting, -1)
+			for {
+				max := atomic.LoadInt32(&maxConnecting)
+				if curr <= max || atomic.CompareAndSwapInt32(&maxConnecting, max, curr) {
+					break
+				}
+			}
+
+			time.Sleep(50 * time.Millisecond)
+			return (&net.Dialer{}).DialContext(ctx, network, address)
+		}
+		pc := poolConfig{
+			Address:       address.Address(addr.String()),
+			MaxConnecting: 1,
+		}
+		p, err := newPool(pc, WithDialer(func(Dialer) Dialer { return dialer }))
+		noerr(t, err)
+		err = p.connect()
+		noerr(t, err)
+
+		errs := make(chan error, 4)
+		for i := 0; i < 4; i++ {
+			go func() {
+				_, err := p.get(context.Background())
+				errs <- err
+			}()
+		}
+		for i := 0; i < 4; i++ {
+			err = <-errs
+			assert.Nil(t, err, "get error: %v", err)
+		}
+		max := atomic.LoadInt32(&maxConnecting)
+		assert.Equal(t, int32(1), max, "expected at most 1 concurrent connection attempt, got %d", max)
+
+		close(cleanup)
+	})
 	t.Run("wait queue timeout error", func(t *testing.T) {
 		cleanup := make(chan struct{})
 		addr := bootstrapConnections(t, 1, func(nc net.Conn) {
@@ -131,6 +131,18 @@ func (rp *resourcePool) Get() interface{} {
 	return nil
 }
 
+// values returns the resources that are currently in the pool without removing them.
+func (rp *resourcePool) values() []interface{} {
+	rp.Lock()
+	defer rp.Unlock()
+
+	values := make([]interface{}, 0, atomic.LoadUint64(&rp.size))
+	for curr := rp.start; curr != nil; curr = curr.next {
+		values = append(values, curr.value)
+	}
+	return values
+}
+
 func (rp *resourcePool) incrementTotal() bool {
 	rp.Lock()
 	defer rp.Unlock()
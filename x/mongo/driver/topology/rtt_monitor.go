@@ -8,15 +8,19 @@ package topology
 
 import (
 	"context"
+	"sort"
 	"sync"
 	"time"
 
+	"go.mongodb.org/mongo-driver/event"
 	"go.mongodb.org/mongo-driver/x/mongo/driver"
 	"go.mongodb.org/mongo-driver/x/mongo/driver/operation"
 )
 
 const (
 	rttAlphaValue = 0.2
+	// The number of most recent samples used to compute the minimum and 90th percentile RTT.
+	rttSampleSize = 10
 )
 
 type rttConfig struct {
@@ -34,6 +38,11 @@ type rttMonitor struct {
 	cfg           *rttConfig
 	ctx           context.Context
 	cancelFn      context.CancelFunc
+
+	// samples is a ring buffer of the most recent samples. offset is the index of the next sample to overwrite once
+	// the buffer is full.
+	samples []time.Duration
+	offset  int
 }
 
 func newRttMonitor(cfg *rttConfig) *rttMonitor {
@@ -101,6 +110,8 @@ func (r *rttMonitor) reset() {
 
 	r.averageRTT = 0
 	r.averageRTTSet = false
+	r.samples = r.samples[:0]
+	r.offset = 0
 }
 
 func (r *rttMonitor) setupRttConnection() error {
@@ -148,6 +159,13 @@ func (r *rttMonitor) addSample(rtt time.Duration) {
 	r.Lock()
 	defer r.Unlock()
 
+	if len(r.samples) < rttSampleSize {
+		r.samples = append(r.samples, rtt)
+	} else {
+		r.samples[r.offset] = rtt
+		r.offset = (r.offset + 1) % rttSampleSize
+	}
+
 	if !r.averageRTTSet {
 		r.averageRTT = rtt
 		r.averageRTTSet = true
@@ -163,3 +181,24 @@ func (r *rttMonitor) getRTT() time.Duration {
 
 	return r.averageRTT
 }
+
+// getStats returns the RTT statistics computed from the samples since the last reset.
+func (r *rttMonitor) getStats() event.RTTStats {
+	r.Lock()
+	sorted := make([]time.Duration, len(r.samples))
+	copy(sorted, r.samples)
+	stats := event.RTTStats{
+		Average: r.averageRTT,
+		Samples: len(sorted),
+	}
+	r.Unlock()
+
+	if len(sorted) == 0 {
+		return stats
+	}
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i] < sorted[j] })
+	stats.Min = sorted[0]
+	// Use the nearest-rank method, i.e. the smallest sample that is greater than or equal to 90% of the samples.
+	stats.P90 = sorted[(len(sorted)*9+9)/10-1]
+	return stats
+}
@@ -10,6 +10,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"math/rand"
 	"net"
 	"sync"
 	"sync/atomic"
@@ -23,7 +24,7 @@ import (
 	"go.mongodb.org/mongo-driver/x/mongo/driver/operation"
 )
 
-const minHeartbeatInterval = 500 * time.Millisecond
+const defaultMinHeartbeatInterval = 500 * time.Millisecond
 
 var (
 	// ErrServerClosed occurs when an attempt to Get a connection is made after
@@ -119,6 +120,7 @@ type Server struct {
 
 	processErrorLock sync.Mutex
 	rttMonitor       *rttMonitor
+	compressor       atomic.Value // holds a string
 }
 
 // updateTopologyCallback is a callback used to create a server that should be called when the parent Topology instance
@@ -172,14 +174,18 @@ func NewServer(addr address.Address, topologyID primitive.ObjectID, opts ...Serv
 	s.rttMonitor = newRttMonitor(rttCfg)
 
 	pc := poolConfig{
-		Address:     addr,
-		MinPoolSize: cfg.minConns,
-		MaxPoolSize: cfg.maxConns,
-		MaxIdleTime: cfg.connectionPoolMaxIdleTime,
-		PoolMonitor: cfg.poolMonitor,
+		Address:       addr,
+		MinPoolSize:   cfg.minConns,
+		MaxPoolSize:   cfg.maxConns,
+		MaxConnecting: cfg.maxConnecting,
+		MaxIdleTime:   cfg.connectionPoolMaxIdleTime,
+		PoolMonitor:   cfg.poolMonitor,
 	}
 
-	connectionOpts := append(cfg.connectionOpts, withErrorHandlingCallback(s.ProcessHandshakeError))
+	connectionOpts := append(cfg.connectionOpts,
+		withErrorHandlingCallback(s.ProcessHandshakeError),
+		withCompressorCallback(func(compressor string) { s.compressor.Store(compressor) }),
+	)
 	s.pool, err = newPool(pc, connectionOpts...)
 	if err != nil {
 		return nil, err
@@ -196,10 +202,15 @@ func (s *Server) Connect(updateCallback updateTopologyCallback) error {
 	if !atomic.CompareAndSwapInt32(&s.connectionstate, disconnected, connected) {
 		return ErrServerConnected
 	}
-	s.desc.Store(description.NewDefaultServer(s.address))
+	desc := description.NewDefaultServer(s.address)
+	if s.cfg.loadBalanced {
+		// A server behind a load balancer is not monitored, so it starts with kind LoadBalancer.
+		desc.Kind = description.LoadBalancer
+	}
+	s.desc.Store(desc)
 	s.updateTopologyCallback.Store(updateCallback)
 
-	if !s.cfg.monitoringDisabled {
+	if !s.cfg.monitoringDisabled && !s.cfg.loadBalanced {
 		s.rttMonitor.connect()
 		s.closewg.Add(1)
 		go s.update()
@@ -217,8 +228,14 @@ func (s *Server) Connect(updateCallback updateTopologyCallback) error {
 // any in flight read or write operations. If this method returns with no
 // errors, all connections associated with this Server have been closed.
 func (s *Server) Disconnect(ctx context.Context) error {
+	_, err := s.disconnectWithStats(ctx)
+	return err
+}
+
+// disconnectWithStats disconnects the server like Disconnect and returns the number of connections that were closed.
+func (s *Server) disconnectWithStats(ctx context.Context) (DisconnectStats, error) {
 	if !atomic.CompareAndSwapInt32(&s.connectionstate, connected, disconnecting) {
-		return ErrServerClosed
+		return DisconnectStats{}, ErrServerClosed
 	}
 
 	s.updateTopologyCallback.Store((updateTopologyCallback)(nil))
@@ -232,15 +249,24 @@ func (s *Server) Disconnect(ctx context.Context) error {
 	s.cancelCheck()
 
 	s.rttMonitor.disconnect()
-	err := s.pool.disconnect(ctx)
+	stats, err := s.pool.disconnectWithStats(ctx)
 	if err != nil {
-		return err
+		return stats, err
 	}
 
 	s.closewg.Wait()
 	atomic.StoreInt32(&s.connectionstate, disconnected)
 
-	return nil
+	return stats, nil
+}
+
+// WarmUp establishes the minimum number of connections configured for the server's connection pool and waits until
+// they are connected. It returns an error if the server is not connected or a connection could not be established.
+func (s *Server) WarmUp(ctx context.Context) error {
+	if atomic.LoadInt32(&s.connectionstate) != connected {
+		return ErrServerClosed
+	}
+	return s.pool.warmUp(ctx)
 }
 
 // Connection gets a connection to the server.
@@ -267,9 +293,20 @@ func (s *Server) Connection(ctx context.Context) (driver.Connection, error) {
 }
 
 // ProcessHandshakeError implements SDAM error handling for errors that occur before a connection finishes handshaking.
-func (s *Server) ProcessHandshakeError(err error, startingGenerationNumber uint64) {
-	// ignore nil or stale error
-	if err == nil || startingGenerationNumber < atomic.LoadUint64(&s.pool.generation) {
+// The serviceID parameter is the service ID of the server the connection is to if the server is behind a load balancer.
+func (s *Server) ProcessHandshakeError(err error, startingGenerationNumber uint64, serviceID *primitive.ObjectID) {
+	// ignore nil error
+	if err == nil {
+		return
+	}
+	// Ignore the error if the server is behind a load balancer but the service ID is unknown. This indicates that the
+	// error happened while dialing the connection or during the handshake, so the pool cannot be cleared for the
+	// right service.
+	if s.cfg.loadBalanced && serviceID == nil {
+		return
+	}
+	// ignore stale error
+	if startingGenerationNumber < s.pool.getGeneration(serviceID) {
 		return
 	}
 
@@ -282,10 +319,23 @@ func (s *Server) ProcessHandshakeError(err error, startingGenerationNumber uint6
 	// the description.Server appropriately. The description should not have a TopologyVersion because the staleness
 	// checking logic above has already determined that this description is not stale.
 	s.updateDescription(description.NewServerFromError(s.address, wrappedConnErr, nil))
-	s.pool.clear()
+	s.pool.clear(serviceID)
 	s.cancelCheck()
 }
 
+// RTTStats returns the round trip time statistics of the server. See the event.RTTStats documentation for more
+// information about how the statistics are computed.
+func (s *Server) RTTStats() event.RTTStats {
+	return s.rttMonitor.getStats()
+}
+
+// Compressor returns the compressor negotiated by the last connection established to the server, or an empty string if
+// no connection has been established yet or the connection does not use compression.
+func (s *Server) Compressor() string {
+	compressor, _ := s.compressor.Load().(string)
+	return compressor
+}
+
 // Description returns a description of the server as of the last heartbeat.
 func (s *Server) Description() description.Server {
 	return s.desc.Load().(description.Server)
@@ -386,7 +436,7 @@ func (s *Server) ProcessError(err error, conn driver.Connection) driver.ProcessE
 		// If the node is shutting down or is older than 4.2, we synchronously clear the pool
 		if cerr.NodeIsShuttingDown() || desc.WireVersion == nil || desc.WireVersion.Max < 8 {
 			res = driver.ConnectionPoolCleared
-			s.pool.clear()
+			s.pool.clear(desc.ServiceID)
 		}
 		return res
 	}
@@ -404,7 +454,7 @@ func (s *Server) ProcessError(err error, conn driver.Connection) driver.ProcessE
 		// If the node is shutting down or is older than 4.2, we synchronously clear the pool
 		if wcerr.NodeIsShuttingDown() || desc.WireVersion == nil || desc.WireVersion.Max < 8 {
 			res = driver.ConnectionPoolCleared
-			s.pool.clear()
+			s.pool.clear(desc.ServiceID)
 		}
 		return res
 	}
@@ -426,7 +476,7 @@ func (s *Server) ProcessError(err error, conn driver.Connection) driver.ProcessE
 	// monitoring check. The check is cancelled last to avoid a post-cancellation reconnect racing with
 	// updateDescription.
 	s.updateDescription(description.NewServerFromError(s.address, err, nil))
-	s.pool.clear()
+	s.pool.clear(desc.ServiceID)
 	s.cancelCheck()
 	return driver.ConnectionPoolCleared
 }
@@ -436,11 +486,13 @@ func (s *Server) ProcessError(err error, conn driver.Connection) driver.ProcessE
 func (s *Server) update() {
 	defer s.closewg.Done()
 	heartbeatTicker := time.NewTicker(s.cfg.heartbeatInterval)
-	rateLimiter := time.NewTicker(minHeartbeatInterval)
+	rateLimiter := time.NewTicker(s.cfg.minHeartbeatInterval)
 	defer heartbeatTicker.Stop()
 	defer rateLimiter.Stop()
 	checkNow := s.checkNow
 	done := s.done
+	// failures is the number of consecutive failed checks.
+	var failures int
 
 	var doneOnce bool
 	defer func() {
@@ -471,6 +523,24 @@ func (s *Server) update() {
 	}
 
 	waitUntilNextCheck := func() {
+		// Back off from a server that keeps failing. Immediate check requests are ignored so that operations that
+		// cannot select a server do not keep dialing it.
+		if backoff := s.failureBackoff(failures); backoff > 0 {
+			timer := time.NewTimer(backoff)
+			defer timer.Stop()
+			select {
+			case <-timer.C:
+			case <-done:
+				return
+			}
+			// Drop a heartbeat tick that fired during the backoff so the next wait is a full interval.
+			select {
+			case <-heartbeatTicker.C:
+			default:
+			}
+			return
+		}
+
 		// Wait until heartbeatFrequency elapses, an application operation requests an immediate check, or the server
 		// is disconnecting.
 		select {
@@ -517,7 +587,10 @@ func (s *Server) update() {
 		s.updateDescription(desc)
 		if desc.LastError != nil {
 			// Clear the pool once the description has been updated to Unknown.
-			s.pool.clear()
+			s.pool.clear(nil)
+			failures++
+		} else {
+			failures = 0
 		}
 
 		// If the server supports streaming or we're already streaming, we want to move to streaming the next response
@@ -538,11 +611,38 @@ func (s *Server) update() {
 	}
 }
 
+// failureBackoff returns how long to wait before the next check of the server after the given number of consecutive
+// failed checks, or 0 if the server should be checked at the heartbeat interval. The wait starts at twice the heartbeat
+// interval after the second failure and doubles after each failure up to the configured maximum. A random jitter of up
+// to half of the wait is subtracted.
+func (s *Server) failureBackoff(failures int) time.Duration {
+	interval, max := s.cfg.heartbeatInterval, s.cfg.failureBackoff
+	if failures < 2 || max <= interval {
+		return 0
+	}
+
+	backoff := interval
+	for i := 1; i < failures && backoff < max; i++ {
+		backoff *= 2
+	}
+	if backoff > max {
+		backoff = max
+	}
+	jitter := time.Duration(rand.Int63n(int64(backoff/2) + 1))
+	return backoff - jitter
+}
+
 // updateDescription handles updating the description on the Server, notifying
 // subscribers, and potentially draining the connection pool. The initial
 // parameter is used to determine if this is the first description from the
 // server.
 func (s *Server) updateDescription(desc description.Server) {
+	if s.cfg.loadBalanced {
+		// In load balanced mode, there are no updates from the monitoring routine. Errors from pooled connections
+		// should not mark the server Unknown so the load balancer remains selectable.
+		return
+	}
+
 	defer func() {
 		//  ¯\_(ツ)_/¯
 		_ = recover()
@@ -577,8 +677,8 @@ func (s *Server) createConnection() (*connection, error) {
 		// We override whatever handshaker is currently attached to the options with a basic
 		// one because need to make sure we don't do auth.
 		WithHandshaker(func(h Handshaker) Handshaker {
-			return operation.NewIsMaster().AppName(s.cfg.appname).Compressors(s.cfg.compressionOpts).
-				ServerAPI(s.cfg.serverAPI)
+			return operation.NewIsMaster().AppName(s.cfg.appname).DriverInfo(s.cfg.driverInfo).
+				Compressors(s.cfg.compressionOpts).ServerAPI(s.cfg.serverAPI)
 		}),
 		// Override any monitors specified in options with nil to avoid monitoring heartbeats.
 		WithMonitor(func(*event.CommandMonitor) *event.CommandMonitor { return nil }),
@@ -601,6 +701,7 @@ func (s *Server) setupHeartbeatConnection() error {
 	s.conn = conn
 	s.heartbeatLock.Unlock()
 
+	s.publishServerHeartbeatStartedEvent(s.conn.ID(), false)
 	s.conn.connect(s.heartbeatCtx)
 	return s.conn.wait()
 }
@@ -650,12 +751,17 @@ func (s *Server) check() (description.Server, error) {
 	// check, or the previous check was cancelled.
 	if s.conn == nil || s.conn.closed() || s.checkWasCancelled() {
 		// Create a new connection and add it's handshake RTT as a sample.
+		start := time.Now()
 		err = s.setupHeartbeatConnection()
 		if err == nil {
 			// Use the description from the connection handshake as the value for this check.
 			s.rttMonitor.addSample(s.conn.isMasterRTT)
 			descPtr = &s.conn.desc
 			durationNanos = s.conn.isMasterRTT.Nanoseconds()
+			s.publishServerHeartbeatSucceededEvent(s.conn.ID(), durationNanos, s.conn.desc, false)
+		} else if s.conn != nil {
+			durationNanos = time.Since(start).Nanoseconds()
+			s.publishServerHeartbeatFailedEvent(s.conn.ID(), durationNanos, err, false)
 		}
 	}
 
@@ -836,6 +942,9 @@ func (s *Server) publishServerHeartbeatSucceededEvent(connectionID string,
 		ConnectionID:  connectionID,
 		Awaited:       await,
 	}
+	if s != nil && s.rttMonitor != nil {
+		serverHeartbeatSucceeded.RTT = s.rttMonitor.getStats()
+	}
 
 	if s != nil && s.cfg.serverMonitor != nil && s.cfg.serverMonitor.ServerHeartbeatSucceeded != nil {
 		s.cfg.serverMonitor.ServerHeartbeatSucceeded(serverHeartbeatSucceeded)
@@ -850,6 +959,7 @@ func (s *Server) publishServerHeartbeatFailedEvent(connectionID string,
 	serverHeartbeatFailed := &event.ServerHeartbeatFailedEvent{
 		DurationNanos: durationNanos,
 		Failure:       err,
+		Reason:        heartbeatFailureReason(err),
 		ConnectionID:  connectionID,
 		Awaited:       await,
 	}
@@ -859,6 +969,60 @@ func (s *Server) publishServerHeartbeatFailedEvent(connectionID string,
 	}
 }
 
+// heartbeatFailureReason classifies the error returned by a failed heartbeat. The error chain is searched for DNS
+// errors first because they are wrapped by the more general network errors.
+func heartbeatFailureReason(err error) event.HeartbeatFailureReason {
+	var stage connectionStage
+	var timeout, network, command bool
+	for ; err != nil; err = unwrapError(err) {
+		switch e := err.(type) {
+		case *net.DNSError:
+			return event.HeartbeatFailureDNS
+		case ConnectionError:
+			network = true
+			if stage == stageUnknown {
+				stage = e.stage
+			}
+		case driver.Error:
+			if e.NetworkError() {
+				network = true
+			} else {
+				command = true
+			}
+		}
+
+		if err == context.DeadlineExceeded {
+			timeout = true
+		}
+		if netErr, ok := err.(net.Error); ok && netErr.Timeout() {
+			timeout = true
+		}
+	}
+
+	switch {
+	case timeout:
+		return event.HeartbeatFailureTimeout
+	case stage == stageTLS:
+		return event.HeartbeatFailureTLS
+	case stage == stageDial:
+		return event.HeartbeatFailureConnect
+	case command:
+		return event.HeartbeatFailureCommand
+	case network:
+		return event.HeartbeatFailureNetwork
+	}
+	return event.HeartbeatFailureUnknown
+}
+
+// unwrapError returns the error wrapped by err, or nil if err does not wrap another error.
+func unwrapError(err error) error {
+	u, ok := err.(interface{ Unwrap() error })
+	if !ok {
+		return nil
+	}
+	return u.Unwrap()
+}
+
 // unwrapConnectionError returns the connection error wrapped by err, or nil if err does not wrap a connection error.
 func unwrapConnectionError(err error) error {
 	// This is essentially an implementation of errors.As to unwrap this error until we get a ConnectionError and then
@@ -7,6 +7,7 @@
 package topology
 
 import (
+	"errors"
 	"time"
 
 	"go.mongodb.org/mongo-driver/bson"
@@ -23,24 +24,30 @@ type serverConfig struct {
 	compressionOpts           []string
 	connectionOpts            []ConnectionOption
 	appname                   string
+	driverInfo                *driver.DriverInfo
 	heartbeatInterval         time.Duration
 	heartbeatTimeout          time.Duration
+	minHeartbeatInterval      time.Duration
+	failureBackoff            time.Duration
 	maxConns                  uint64
 	minConns                  uint64
+	maxConnecting             uint64
 	poolMonitor               *event.PoolMonitor
 	serverMonitor             *event.ServerMonitor
 	connectionPoolMaxIdleTime time.Duration
 	registry                  *bsoncodec.Registry
 	monitoringDisabled        bool
 	serverAPI                 *driver.ServerAPIOptions
+	loadBalanced              bool
 }
 
 func newServerConfig(opts ...ServerOption) (*serverConfig, error) {
 	cfg := &serverConfig{
-		heartbeatInterval: 10 * time.Second,
-		heartbeatTimeout:  10 * time.Second,
-		maxConns:          100,
-		registry:          defaultRegistry,
+		heartbeatInterval:    10 * time.Second,
+		heartbeatTimeout:     10 * time.Second,
+		minHeartbeatInterval: defaultMinHeartbeatInterval,
+		maxConns:             100,
+		registry:             defaultRegistry,
 	}
 
 	for _, opt := range opts {
@@ -49,6 +56,9 @@ func newServerConfig(opts ...ServerOption) (*serverConfig, error) {
 			return nil, err
 		}
 	}
+	if cfg.minHeartbeatInterval <= 0 {
+		return nil, errors.New("minimum heartbeat interval must be positive")
+	}
 
 	return cfg, nil
 }
@@ -87,6 +97,15 @@ func WithServerAppName(fn func(string) string) ServerOption {
 	}
 }
 
+// WithServerDriverInfo configures the information about a library wrapping the driver that the server sends in the
+// handshake of its monitoring connection.
+func WithServerDriverInfo(fn func(*driver.DriverInfo) *driver.DriverInfo) ServerOption {
+	return func(cfg *serverConfig) error {
+		cfg.driverInfo = fn(cfg.driverInfo)
+		return nil
+	}
+}
+
 // WithHeartbeatInterval configures a server's heartbeat interval.
 func WithHeartbeatInterval(fn func(time.Duration) time.Duration) ServerOption {
 	return func(cfg *serverConfig) error {
@@ -104,6 +123,26 @@ func WithHeartbeatTimeout(fn func(time.Duration) time.Duration) ServerOption {
 	}
 }
 
+// WithMinHeartbeatInterval configures the minimum amount of time between two checks of a server, which limits how often
+// a server is checked when operations request immediate checks. It must be positive.
+func WithMinHeartbeatInterval(fn func(time.Duration) time.Duration) ServerOption {
+	return func(cfg *serverConfig) error {
+		cfg.minHeartbeatInterval = fn(cfg.minHeartbeatInterval)
+		return nil
+	}
+}
+
+// WithServerFailureBackoff configures the maximum amount of time between the checks of a server whose checks keep
+// failing. After the second consecutive failed check, the time between checks doubles from twice the heartbeat
+// interval up to this maximum, with jitter, and immediate check requests are ignored. A maximum that is not greater
+// than the heartbeat interval disables the backoff, which is the default.
+func WithServerFailureBackoff(fn func(time.Duration) time.Duration) ServerOption {
+	return func(cfg *serverConfig) error {
+		cfg.failureBackoff = fn(cfg.failureBackoff)
+		return nil
+	}
+}
+
 // WithMaxConnections configures the maximum number of connections to allow for
 // a given server. If max is 0, then the default will be math.MaxInt64.
 func WithMaxConnections(fn func(uint64) uint64) ServerOption {
@@ -123,6 +162,15 @@ func WithMinConnections(fn func(uint64) uint64) ServerOption {
 	}
 }
 
+// WithMaxConnecting configures the maximum number of connections to a given server that can be established
+// concurrently. If max is 0, then the default of 2 will be used.
+func WithMaxConnecting(fn func(uint64) uint64) ServerOption {
+	return func(cfg *serverConfig) error {
+		cfg.maxConnecting = fn(cfg.maxConnecting)
+		return nil
+	}
+}
+
 // WithConnectionPoolMaxIdleTime configures the maximum time that a connection can remain idle in the connection pool
 // before being removed. If connectionPoolMaxIdleTime is 0, then no idle time is set and connections will not be removed
 // because of their age
@@ -173,3 +221,11 @@ func WithServerAPI(fn func(serverAPI *driver.ServerAPIOptions) *driver.ServerAPI
 		return nil
 	}
 }
+
+// WithServerLoadBalanced specifies whether or not the server is behind a load balancer.
+func WithServerLoadBalanced(fn func(bool) bool) ServerOption {
+	return func(cfg *serverConfig) error {
+		cfg.loadBalanced = fn(cfg.loadBalanced)
+		return nil
+	}
+}
@@ -13,6 +13,7 @@ import (
 	"testing"
 	"time"
 
+	"go.mongodb.org/mongo-driver/event"
 	"go.mongodb.org/mongo-driver/internal/testutil/assert"
 	testhelpers "go.mongodb.org/mongo-driver/internal/testutil/helpers"
 )
@@ -57,3 +58,34 @@ func TestServerSelectionRTTSpec(t *testing.T) {
 		}(t, file)
 	}
 }
+
+func TestRTTStats(t *testing.T) {
+	t.Run("no samples", func(t *testing.T) {
+		var monitor rttMonitor
+		stats := monitor.getStats()
+		assert.Equal(t, event.RTTStats{}, stats, "expected empty stats, got %v", stats)
+	})
+	t.Run("most recent samples", func(t *testing.T) {
+		var monitor rttMonitor
+		// Add a slow sample that should be discarded once the window is full.
+		monitor.addSample(time.Second)
+		for i := 1; i <= rttSampleSize; i++ {
+			monitor.addSample(time.Duration(i) * time.Millisecond)
+		}
+
+		stats := monitor.getStats()
+		assert.Equal(t, rttSampleSize, stats.Samples, "expected %d samples, got %d", rttSampleSize, stats.Samples)
+		assert.Equal(t, time.Millisecond, stats.Min, "expected min RTT 1ms, got %v", stats.Min)
+		assert.Equal(t, 9*time.Millisecond, stats.P90, "expected p90 RTT 9ms, got %v", stats.P90)
+		assert.Equal(t, monitor.getRTT(), stats.Average, "expected average RTT %v, got %v", monitor.getRTT(),
+			stats.Average)
+	})
+	t.Run("reset", func(t *testing.T) {
+		var monitor rttMonitor
+		monitor.addSample(time.Millisecond)
+		monitor.reset()
+
+		stats := monitor.getStats()
+		assert.Equal(t, event.RTTStats{}, stats, "expected empty stats after reset, got %v", stats)
+	})
+}
@@ -422,9 +422,14 @@ func TestServer(t *testing.T) {
 			t.Fatalf("error from NewServer: %v", err)
 		}
 
-		// set up heartbeat connection, which doesn't send events
+		// set up heartbeat connection
 		_, err = s.check()
 		assert.Nil(t, err, "check error: %v", err)
+		assert.Equal(t, len(publishedEvents), 2, "expected %v events, got %v", 2, len(publishedEvents))
+		_, ok := publishedEvents[0].(event.ServerHeartbeatStartedEvent)
+		assert.True(t, ok, "expected type %T, got %T", event.ServerHeartbeatStartedEvent{}, publishedEvents[0])
+		_, ok = publishedEvents[1].(event.ServerHeartbeatSucceededEvent)
+		assert.True(t, ok, "expected type %T, got %T", event.ServerHeartbeatSucceededEvent{}, publishedEvents[1])
 
 		channelConn := s.conn.nc.(*drivertest.ChannelNetConn)
 		_ = channelConn.GetWrittenMessage()
@@ -473,6 +478,8 @@ func TestServer(t *testing.T) {
 			assert.Equal(t, failed.ConnectionID, s.conn.ID(), "expected connectionID to match")
 			assert.False(t, failed.Awaited, "expected awaited to be false")
 			assert.True(t, errors.Is(failed.Failure, readErr), "expected Failure to be %v, got: %v", readErr, failed.Failure)
+			assert.Equal(t, event.HeartbeatFailureNetwork, failed.Reason,
+				"expected Reason %v, got %v", event.HeartbeatFailureNetwork, failed.Reason)
 		})
 	})
 	t.Run("WithServerAppName", func(t *testing.T) {
@@ -484,6 +491,15 @@ func TestServer(t *testing.T) {
 		require.Nil(t, err, "error from NewServer: %v", err)
 		require.Equal(t, name, s.cfg.appname, "expected appname to be: %v, got: %v", name, s.cfg.appname)
 	})
+	t.Run("WithServerDriverInfo", func(t *testing.T) {
+		info := &driver.DriverInfo{Name: "my-odm", Version: "1.2.0", Platform: "aws-lambda"}
+
+		s, err := NewServer(address.Address("localhost"),
+			primitive.NewObjectID(),
+			WithServerDriverInfo(func(*driver.DriverInfo) *driver.DriverInfo { return info }))
+		require.Nil(t, err, "error from NewServer: %v", err)
+		require.Equal(t, info, s.cfg.driverInfo, "expected driverInfo to be: %v, got: %v", info, s.cfg.driverInfo)
+	})
 	t.Run("createConnection overwrites WithSocketTimeout", func(t *testing.T) {
 		socketTimeout := 40 * time.Second
 
@@ -509,6 +525,93 @@ func TestServer(t *testing.T) {
 	})
 }
 
+type timeoutError struct{}
+
+func (timeoutError) Error() string   { return "i/o timeout" }
+func (timeoutError) Timeout() bool   { return true }
+func (timeoutError) Temporary() bool { return true }
+
+func TestHeartbeatFailureReason(t *testing.T) {
+	dnsErr := &net.DNSError{Err: "no such host", Name: "foo"}
+	dialErr := &net.OpError{Op: "dial", Net: "tcp", Err: errors.New("connection refused")}
+
+	testCases := []struct {
+		name     string
+		err      error
+		expected event.HeartbeatFailureReason
+	}{
+		{"unknown", errors.New("error"), event.HeartbeatFailureUnknown},
+		{"dns", ConnectionError{Wrapped: &net.OpError{Op: "dial", Err: dnsErr}, init: true, stage: stageDial},
+			event.HeartbeatFailureDNS},
+		{"connect", ConnectionError{Wrapped: dialErr, init: true, stage: stageDial}, event.HeartbeatFailureConnect},
+		{"tls", ConnectionError{Wrapped: errors.New("x509: certificate has expired"), init: true, stage: stageTLS},
+			event.HeartbeatFailureTLS},
+		{"dial timeout", ConnectionError{Wrapped: timeoutError{}, init: true, stage: stageDial},
+			event.HeartbeatFailureTimeout},
+		{"context deadline", ConnectionError{Wrapped: context.DeadlineExceeded, init: true, stage: stageTLS},
+			event.HeartbeatFailureTimeout},
+		{"network", driver.Error{Labels: []string{driver.NetworkError}, Wrapped: ConnectionError{Wrapped: errors.New("EOF")}},
+			event.HeartbeatFailureNetwork},
+		{"command", ConnectionError{Wrapped: driver.Error{Code: 18, Message: "command failed"}, init: true, stage: stageHandshake},
+			event.HeartbeatFailureCommand},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			got := heartbeatFailureReason(tc.err)
+			assert.Equal(t, tc.expected, got, "expected reason %v, got %v", tc.expected, got)
+		})
+	}
+}
+
+func TestServerFailureBackoff(t *testing.T) {
+	newServer := func(t *testing.T, max time.Duration) *Server {
+		t.Helper()
+
+		s, err := NewServer(address.Address("localhost"), primitive.NewObjectID(),
+			WithHeartbeatInterval(func(time.Duration) time.Duration { return time.Second }),
+			WithServerFailureBackoff(func(time.Duration) time.Duration { return max }),
+		)
+		assert.Nil(t, err, "NewServer error: %v", err)
+		return s
+	}
+
+	t.Run("disabled", func(t *testing.T) {
+		s := newServer(t, 0)
+		for _, failures := range []int{0, 1, 2, 10} {
+			got := s.failureBackoff(failures)
+			assert.Equal(t, time.Duration(0), got, "expected no backoff after %d failures, got %v", failures, got)
+		}
+	})
+	t.Run("exponential with jitter", func(t *testing.T) {
+		s := newServer(t, 10*time.Second)
+		testCases := []struct {
+			failures int
+			expected time.Duration
+		}{
+			{0, 0},
+			{1, 0},
+			{2, 2 * time.Second},
+			{3, 4 * time.Second},
+			{4, 8 * time.Second},
+			{5, 10 * time.Second},
+			{50, 10 * time.Second},
+		}
+		for _, tc := range testCases {
+			for i := 0; i < 20; i++ {
+				got := s.failureBackoff(tc.failures)
+				assert.True(t, got <= tc.expected && got >= tc.expected/2,
+					"expected backoff after %d failures in [%v, %v], got %v", tc.failures, tc.expected/2,
+					tc.expected, got)
+			}
+		}
+	})
+	t.Run("min heartbeat interval must be positive", func(t *testing.T) {
+		_, err := NewServer(address.Address("localhost"), primitive.NewObjectID(),
+			WithMinHeartbeatInterval(func(time.Duration) time.Duration { return 0 }))
+		assert.NotNil(t, err, "expected error for zero minimum heartbeat interval, got nil")
+	})
+}
+
 func includesMetadata(t *testing.T, wm []byte) bool {
 	var ok bool
 	_, _, _, _, wm, ok = wiremessage.ReadHeader(wm)
@@ -91,6 +91,11 @@ type Topology struct {
 	serversClosed bool
 	servers       map[address.Address]*Server
 
+	// writable and degradedAt track whether the topology currently has a writable server and when it lost its last
+	// one. They are used to publish TopologyDegraded and TopologyRecovered events and are guarded by serversLock.
+	writable   bool
+	degradedAt time.Time
+
 	id primitive.ObjectID
 }
 
@@ -133,7 +138,8 @@ func New(opts ...Option) (*Topology, error) {
 	}
 
 	if t.cfg.uri != "" {
-		t.pollingRequired = strings.HasPrefix(t.cfg.uri, "mongodb+srv://")
+		// SRV records are not polled in load balanced mode because the DNS records are used for the load balancer.
+		t.pollingRequired = strings.HasPrefix(t.cfg.uri, "mongodb+srv://") && !t.cfg.loadBalanced
 	}
 
 	t.publishTopologyOpeningEvent()
@@ -151,6 +157,14 @@ func (t *Topology) Connect() error {
 	t.desc.Store(description.Topology{})
 	var err error
 	t.serversLock.Lock()
+	t.writable = false
+	t.degradedAt = time.Time{}
+
+	// Discard the servers and state of a previous connection in case the topology was disconnected and is being
+	// reconnected.
+	t.fsm = newFSM()
+	t.servers = make(map[address.Address]*Server)
+	t.serversClosed = false
 
 	// A replica set name sets the initial topology type to ReplicaSetNoPrimary unless a direct connection is also
 	// specified, in which case the initial type is Single.
@@ -164,9 +178,19 @@ func (t *Topology) Connect() error {
 		t.fsm.Kind = description.Single
 	}
 
+	// In load balanced mode, the topology contains a single server for the load balancer. The server is not
+	// monitored, so the kinds are set here rather than being discovered.
+	if t.cfg.loadBalanced {
+		t.fsm.Kind = description.LoadBalanced
+	}
+
 	for _, a := range t.cfg.seedList {
 		addr := address.Address(a).Canonicalize()
-		t.fsm.Servers = append(t.fsm.Servers, description.NewDefaultServer(addr))
+		desc := description.NewDefaultServer(addr)
+		if t.cfg.loadBalanced {
+			desc.Kind = description.LoadBalancer
+		}
+		t.fsm.Servers = append(t.fsm.Servers, desc)
 	}
 
 	// store new description
@@ -183,6 +207,11 @@ func (t *Topology) Connect() error {
 		if err != nil {
 			return err
 		}
+
+		// Monitoring would normally publish a ServerDescriptionChanged event when the server's kind is discovered.
+		if t.cfg.loadBalanced {
+			t.publishServerDescriptionChangedEvent(description.NewDefaultServer(addr), t.servers[addr].Description())
+		}
 	}
 	t.serversLock.Unlock()
 
@@ -197,11 +226,27 @@ func (t *Topology) Connect() error {
 	return nil
 }
 
+// DisconnectStats describes the connections closed when a Topology is disconnected.
+type DisconnectStats struct {
+	// ConnectionsClosed is the total number of connections that were closed.
+	ConnectionsClosed int
+	// InUseConnectionsClosed is the number of connections that were still checked out when they were closed. The
+	// operations using these connections fail.
+	InUseConnectionsClosed int
+}
+
 // Disconnect closes the topology. It stops the monitoring thread and
 // closes all open subscriptions.
 func (t *Topology) Disconnect(ctx context.Context) error {
+	_, err := t.DisconnectWithStats(ctx)
+	return err
+}
+
+// DisconnectWithStats closes the topology like Disconnect and returns the number of connections that were closed
+// across all servers.
+func (t *Topology) DisconnectWithStats(ctx context.Context) (DisconnectStats, error) {
 	if !atomic.CompareAndSwapInt32(&t.connectionstate, connected, disconnecting) {
-		return ErrTopologyClosed
+		return DisconnectStats{}, ErrTopologyClosed
 	}
 
 	servers := make(map[address.Address]*Server)
@@ -212,8 +257,11 @@ func (t *Topology) Disconnect(ctx context.Context) error {
 	}
 	t.serversLock.Unlock()
 
+	var stats DisconnectStats
 	for _, server := range servers {
-		_ = server.Disconnect(ctx)
+		serverStats, _ := server.disconnectWithStats(ctx)
+		stats.ConnectionsClosed += serverStats.ConnectionsClosed
+		stats.InUseConnectionsClosed += serverStats.InUseConnectionsClosed
 		t.publishServerClosedEvent(server.address)
 	}
 
@@ -234,7 +282,7 @@ func (t *Topology) Disconnect(ctx context.Context) error {
 
 	atomic.StoreInt32(&t.connectionstate, disconnected)
 	t.publishTopologyClosedEvent()
-	return nil
+	return stats, nil
 }
 
 // Description returns a description of the topology.
@@ -299,6 +347,35 @@ func (t *Topology) Unsubscribe(sub *driver.Subscription) error {
 	return nil
 }
 
+// Compressors returns the compressors negotiated with the servers currently known to the topology. See
+// Server.Compressor for more information.
+func (t *Topology) Compressors() map[address.Address]string {
+	t.serversLock.Lock()
+	defer t.serversLock.Unlock()
+
+	compressors := make(map[address.Address]string, len(t.servers))
+	for addr, server := range t.servers {
+		compressors[addr] = server.Compressor()
+	}
+	return compressors
+}
+
+// RTTStats returns the round trip time statistics of the servers currently known to the topology.
+func (t *Topology) RTTStats() map[address.Address]event.RTTStats {
+	t.serversLock.Lock()
+	servers := make(map[address.Address]*Server, len(t.servers))
+	for addr, server := range t.servers {
+		servers[addr] = server
+	}
+	t.serversLock.Unlock()
+
+	stats := make(map[address.Address]event.RTTStats, len(servers))
+	for addr, server := range servers {
+		stats[addr] = server.RTTStats()
+	}
+	return stats
+}
+
 // RequestImmediateCheck will send heartbeats to all the servers in the
 // topology right away, instead of waiting for the heartbeat timeout.
 func (t *Topology) RequestImmediateCheck() {
@@ -312,10 +389,84 @@ func (t *Topology) RequestImmediateCheck() {
 	t.serversLock.Unlock()
 }
 
+// WarmUp calls WarmUp on each of the servers currently known to the topology concurrently and waits for them to
+// finish. The returned map contains the result for each server, which is nil if the server's connection pool was
+// warmed up successfully. Servers that are discovered later are not warmed up.
+func (t *Topology) WarmUp(ctx context.Context) (map[address.Address]error, error) {
+	if atomic.LoadInt32(&t.connectionstate) != connected {
+		return nil, ErrTopologyClosed
+	}
+
+	t.serversLock.Lock()
+	servers := make([]*Server, 0, len(t.servers))
+	for _, server := range t.servers {
+		servers = append(servers, server)
+	}
+	t.serversLock.Unlock()
+
+	var wg sync.WaitGroup
+	var resultsMu sync.Mutex
+	results := make(map[address.Address]error, len(servers))
+	for _, server := range servers {
+		wg.Add(1)
+		go func(server *Server) {
+			defer wg.Done()
+
+			err := server.WarmUp(ctx)
+			resultsMu.Lock()
+			results[server.address] = err
+			resultsMu.Unlock()
+		}(server)
+	}
+	wg.Wait()
+	return results, nil
+}
+
 // SelectServer selects a server with given a selector. SelectServer complies with the
 // server selection spec, and will time out after severSelectionTimeout or when the
 // parent context is done.
 func (t *Topology) SelectServer(ctx context.Context, ss description.ServerSelector) (driver.Server, error) {
+	start := time.Now()
+	srvr, err := t.selectServer(ctx, ss)
+	t.publishServerSelectionEvent(ctx, start, srvr, err)
+	return srvr, err
+}
+
+// publishServerSelectionEvent publishes a ServerSelectionSucceededEvent or a ServerSelectionFailedEvent for a server
+// selection with ctx that started at start.
+func (t *Topology) publishServerSelectionEvent(ctx context.Context, start time.Time, srvr driver.Server, err error) {
+	monitor := t.cfg.serverMonitor
+	if monitor == nil {
+		return
+	}
+
+	if err != nil {
+		if monitor.ServerSelectionFailed != nil {
+			monitor.ServerSelectionFailed(&event.ServerSelectionFailedEvent{
+				TopologyID:    t.id,
+				Failure:       err,
+				DurationNanos: time.Since(start).Nanoseconds(),
+				OperationID:   driver.OperationID(ctx),
+				CorrelationID: driver.CorrelationID(ctx),
+			})
+		}
+		return
+	}
+	if monitor.ServerSelectionSucceeded != nil {
+		selected := &event.ServerSelectionSucceededEvent{
+			TopologyID:    t.id,
+			DurationNanos: time.Since(start).Nanoseconds(),
+			OperationID:   driver.OperationID(ctx),
+			CorrelationID: driver.CorrelationID(ctx),
+		}
+		if ss, ok := srvr.(*SelectedServer); ok {
+			selected.Address = ss.Server.address
+		}
+		monitor.ServerSelectionSucceeded(selected)
+	}
+}
+
+func (t *Topology) selectServer(ctx context.Context, ss description.ServerSelector) (driver.Server, error) {
 	if atomic.LoadInt32(&t.connectionstate) != connected {
 		return nil, ErrTopologyClosed
 	}
@@ -481,6 +632,12 @@ func (t *Topology) selectServerFromDescription(desc description.Topology,
 		return nil, desc.CompatibilityErr
 	}
 
+	// In load balanced mode, the load balancer is the only server and it is always selectable. The selectors in the
+	// description package already return it as a candidate, but this ensures custom selectors cannot filter it out.
+	if desc.Kind == description.LoadBalanced {
+		return desc.Servers, nil
+	}
+
 	var allowed []description.Server
 	for _, s := range desc.Servers {
 		if s.Kind != description.Unknown {
@@ -541,6 +698,9 @@ func (t *Topology) pollSRVRecords() {
 			}
 			continue
 		}
+		if t.cfg.seedlistCache != nil {
+			t.storeSeedlist(hosts, parsedHosts)
+		}
 		if t.pollHeartbeatTime.Load().(bool) {
 			pollTicker.Stop()
 			pollTicker = time.NewTicker(t.rescanSRVInterval)
@@ -556,6 +716,16 @@ func (t *Topology) pollSRVRecords() {
 	doneOnce = true
 }
 
+// storeSeedlist stores the hosts found by SRV polling in the seedlist cache. The TXT record is only looked up when the
+// URI is parsed, so the TXT options of the cached seedlist are kept.
+func (t *Topology) storeSeedlist(hosts string, parsedHosts []string) {
+	seedlist := &dns.Seedlist{Hosts: parsedHosts}
+	if cached, err := t.cfg.seedlistCache.LoadSeedlist(hosts); err == nil && cached != nil {
+		seedlist.Options = cached.Options
+	}
+	_ = t.cfg.seedlistCache.StoreSeedlist(hosts, seedlist)
+}
+
 func (t *Topology) processSRVResults(parsedHosts []string) bool {
 	t.serversLock.Lock()
 	defer t.serversLock.Unlock()
@@ -600,6 +770,7 @@ func (t *Topology) processSRVResults(parsedHosts []string) bool {
 
 	if !prev.Equal(newDesc) {
 		t.publishTopologyDescriptionChangedEvent(prev, newDesc)
+		t.publishAvailabilityEvents(prev, newDesc)
 	}
 
 	t.subLock.Lock()
@@ -666,6 +837,7 @@ func (t *Topology) apply(ctx context.Context, desc description.Server) descripti
 	t.desc.Store(current)
 	if !prev.Equal(current) {
 		t.publishTopologyDescriptionChangedEvent(prev, current)
+		t.publishAvailabilityEvents(prev, current)
 	}
 
 	t.subLock.Lock()
@@ -749,6 +921,47 @@ func (t *Topology) publishTopologyDescriptionChangedEvent(prev description.Topol
 	}
 }
 
+// publishes a TopologyDegradedEvent if the topology has lost its last writable server or a TopologyRecoveredEvent if a
+// previously degraded topology has a writable server again. A topology that has never had a writable server is not
+// considered degraded. This must be called with serversLock held.
+func (t *Topology) publishAvailabilityEvents(prev description.Topology, current description.Topology) {
+	writable := current.HasWritableServer()
+	if writable == t.writable {
+		return
+	}
+	t.writable = writable
+
+	if !writable {
+		t.degradedAt = time.Now()
+		topologyDegraded := &event.TopologyDegradedEvent{
+			TopologyID:          t.id,
+			PreviousDescription: prev,
+			NewDescription:      current,
+		}
+
+		if t.cfg.serverMonitor != nil && t.cfg.serverMonitor.TopologyDegraded != nil {
+			t.cfg.serverMonitor.TopologyDegraded(topologyDegraded)
+		}
+		return
+	}
+
+	if t.degradedAt.IsZero() {
+		// first writable server since Connect, nothing has recovered
+		return
+	}
+	topologyRecovered := &event.TopologyRecoveredEvent{
+		TopologyID:          t.id,
+		Downtime:            time.Since(t.degradedAt),
+		PreviousDescription: prev,
+		NewDescription:      current,
+	}
+	t.degradedAt = time.Time{}
+
+	if t.cfg.serverMonitor != nil && t.cfg.serverMonitor.TopologyRecovered != nil {
+		t.cfg.serverMonitor.TopologyRecovered(topologyRecovered)
+	}
+}
+
 // publishes a TopologyOpeningEvent to indicate the topology is being initialized
 func (t *Topology) publishTopologyOpeningEvent() {
 	topologyOpening := &event.TopologyOpeningEvent{
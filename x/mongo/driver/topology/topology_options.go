@@ -21,6 +21,7 @@ import (
 	"go.mongodb.org/mongo-driver/x/mongo/driver"
 	"go.mongodb.org/mongo-driver/x/mongo/driver/auth"
 	"go.mongodb.org/mongo-driver/x/mongo/driver/connstring"
+	"go.mongodb.org/mongo-driver/x/mongo/driver/dns"
 	"go.mongodb.org/mongo-driver/x/mongo/driver/operation"
 )
 
@@ -36,6 +37,8 @@ type config struct {
 	uri                    string
 	serverSelectionTimeout time.Duration
 	serverMonitor          *event.ServerMonitor
+	seedlistCache          dns.SeedlistCache
+	loadBalanced           bool
 }
 
 func newConfig(opts ...Option) (*config, error) {
@@ -101,6 +104,10 @@ func WithConnString(fn func(connstring.ConnString) connstring.ConnString) Option
 			c.serverOpts = append(c.serverOpts, WithMaxConnections(func(uint64) uint64 { return cs.MaxPoolSize }))
 		}
 
+		if cs.MaxConnectingSet {
+			c.serverOpts = append(c.serverOpts, WithMaxConnecting(func(uint64) uint64 { return cs.MaxConnecting }))
+		}
+
 		if cs.MinPoolSizeSet {
 			c.serverOpts = append(c.serverOpts, WithMinConnections(func(u uint64) uint64 { return cs.MinPoolSize }))
 		}
@@ -109,6 +116,12 @@ func WithConnString(fn func(connstring.ConnString) connstring.ConnString) Option
 			c.replicaSetName = cs.ReplicaSet
 		}
 
+		if cs.LoadBalancedSet {
+			c.loadBalanced = cs.LoadBalanced
+			c.serverOpts = append(c.serverOpts, WithServerLoadBalanced(func(bool) bool { return cs.LoadBalanced }))
+			connOpts = append(connOpts, WithConnectionLoadBalanced(func(bool) bool { return cs.LoadBalanced }))
+		}
+
 		var x509Username string
 		if cs.SSL {
 			tlsConfig := new(tls.Config)
@@ -187,6 +200,7 @@ func WithConnString(fn func(connstring.ConnString) connstring.ConnString) Option
 					AppName:       cs.AppName,
 					Authenticator: authenticator,
 					Compressors:   cs.Compressors,
+					LoadBalanced:  cs.LoadBalancedSet && cs.LoadBalanced,
 				}
 				if cs.AuthMechanism == "" {
 					// Required for SASL mechanism negotiation during handshake
@@ -197,7 +211,8 @@ func WithConnString(fn func(connstring.ConnString) connstring.ConnString) Option
 		} else {
 			// We need to add a non-auth Handshaker to the connection options
 			connOpts = append(connOpts, WithHandshaker(func(h driver.Handshaker) driver.Handshaker {
-				return operation.NewIsMaster().AppName(cs.AppName).Compressors(cs.Compressors)
+				return operation.NewIsMaster().AppName(cs.AppName).Compressors(cs.Compressors).
+					LoadBalanced(cs.LoadBalancedSet && cs.LoadBalanced)
 			}))
 		}
 
@@ -234,6 +249,15 @@ func WithConnString(fn func(connstring.ConnString) connstring.ConnString) Option
 	}
 }
 
+// WithLoadBalanced specifies whether or not the cluster is behind a load balancer. In load balanced mode, servers are
+// not monitored and the topology contains a single server that represents the load balancer.
+func WithLoadBalanced(fn func(bool) bool) Option {
+	return func(cfg *config) error {
+		cfg.loadBalanced = fn(cfg.loadBalanced)
+		return nil
+	}
+}
+
 // WithMode configures the topology's monitor mode.
 func WithMode(fn func(MonitorMode) MonitorMode) Option {
 	return func(cfg *config) error {
@@ -258,6 +282,14 @@ func WithSeedList(fn func(...string) []string) Option {
 	}
 }
 
+// WithSeedlistCache configures the cache used to persist the hosts discovered through SRV polling.
+func WithSeedlistCache(fn func(dns.SeedlistCache) dns.SeedlistCache) Option {
+	return func(cfg *config) error {
+		cfg.seedlistCache = fn(cfg.seedlistCache)
+		return nil
+	}
+}
+
 // WithServerOptions configures a topology's server options for when a new server
 // needs to be created.
 func WithServerOptions(fn func(...ServerOption) []ServerOption) Option {
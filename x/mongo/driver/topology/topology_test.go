@@ -14,9 +14,11 @@ import (
 	"testing"
 	"time"
 
+	"go.mongodb.org/mongo-driver/event"
 	"go.mongodb.org/mongo-driver/internal/testutil/assert"
 	"go.mongodb.org/mongo-driver/mongo/address"
 	"go.mongodb.org/mongo-driver/mongo/description"
+	"go.mongodb.org/mongo-driver/mongo/readpref"
 	"go.mongodb.org/mongo-driver/x/mongo/driver"
 	"go.mongodb.org/mongo-driver/x/mongo/driver/connstring"
 )
@@ -393,6 +395,50 @@ func TestServerSelection(t *testing.T) {
 		selectedAddr := selectedServer.(*SelectedServer).address
 		assert.Equal(t, primaryAddr, selectedAddr, "expected address %v, got %v", primaryAddr, selectedAddr)
 	})
+	t.Run("publishes server selection events", func(t *testing.T) {
+		var succeeded []*event.ServerSelectionSucceededEvent
+		var failed []*event.ServerSelectionFailedEvent
+		topo, err := New()
+		noerr(t, err)
+		topo.cfg.serverMonitor = &event.ServerMonitor{
+			ServerSelectionSucceeded: func(evt *event.ServerSelectionSucceededEvent) {
+				succeeded = append(succeeded, evt)
+			},
+			ServerSelectionFailed: func(evt *event.ServerSelectionFailedEvent) {
+				failed = append(failed, evt)
+			},
+		}
+		atomic.StoreInt32(&topo.connectionstate, connected)
+
+		primaryAddr := address.Address("one")
+		desc := description.Topology{
+			Servers: []description.Server{
+				{Addr: primaryAddr, Kind: description.RSPrimary},
+			},
+		}
+		topo.desc.Store(desc)
+		s, err := ConnectServer(primaryAddr, topo.updateCallback, topo.id)
+		noerr(t, err)
+		topo.servers[primaryAddr] = s
+
+		ctx := driver.WithCorrelationID(context.Background(), "trace")
+		_, err = topo.SelectServer(ctx, description.WriteSelector())
+		noerr(t, err)
+		_, selectErr := topo.SelectServer(ctx, selectError)
+		assert.NotNil(t, selectErr, "expected server selection error, got nil")
+
+		assert.Equal(t, 1, len(succeeded), "expected 1 succeeded event, got %v", len(succeeded))
+		assert.Equal(t, primaryAddr, succeeded[0].Address, "expected address %v, got %v", primaryAddr,
+			succeeded[0].Address)
+		assert.Equal(t, topo.id, succeeded[0].TopologyID, "expected topology ID %v, got %v", topo.id,
+			succeeded[0].TopologyID)
+		assert.Equal(t, "trace", succeeded[0].CorrelationID, "expected correlation ID trace, got %q",
+			succeeded[0].CorrelationID)
+		assert.Equal(t, 1, len(failed), "expected 1 failed event, got %v", len(failed))
+		assert.Equal(t, selectErr, failed[0].Failure, "expected failure %v, got %v", selectErr, failed[0].Failure)
+		assert.Equal(t, "trace", failed[0].CorrelationID, "expected correlation ID trace, got %q",
+			failed[0].CorrelationID)
+	})
 	t.Run("default to selecting from subscription if fast path fails", func(t *testing.T) {
 		topo, err := New()
 		noerr(t, err)
@@ -564,6 +610,54 @@ func TestSessionTimeout(t *testing.T) {
 	})
 }
 
+func TestTopologyAvailabilityEvents(t *testing.T) {
+	var degraded []*event.TopologyDegradedEvent
+	var recovered []*event.TopologyRecoveredEvent
+	sm := &event.ServerMonitor{
+		TopologyDegraded: func(evt *event.TopologyDegradedEvent) {
+			degraded = append(degraded, evt)
+		},
+		TopologyRecovered: func(evt *event.TopologyRecoveredEvent) {
+			recovered = append(recovered, evt)
+		},
+	}
+	topo, err := New(WithTopologyServerMonitor(func(*event.ServerMonitor) *event.ServerMonitor { return sm }))
+	noerr(t, err)
+	topo.fsm.Kind = description.Single
+	addr := address.Address("foo").Canonicalize()
+	topo.servers[addr] = nil
+	topo.fsm.Servers = []description.Server{
+		{Addr: addr, Kind: description.Unknown},
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), testTimeout)
+	defer cancel()
+
+	up := description.Server{Addr: addr, Kind: description.Standalone}
+	down := description.Server{Addr: addr, Kind: description.Unknown, LastError: errors.New("connection refused")}
+
+	// The first writable server after Connect is not a recovery.
+	topo.apply(ctx, up)
+	assert.Equal(t, 0, len(degraded), "expected 0 degraded events, got %d", len(degraded))
+	assert.Equal(t, 0, len(recovered), "expected 0 recovered events, got %d", len(recovered))
+
+	topo.apply(ctx, down)
+	assert.Equal(t, 1, len(degraded), "expected 1 degraded event, got %d", len(degraded))
+	assert.Equal(t, topo.id, degraded[0].TopologyID, "expected topology ID %v, got %v", topo.id, degraded[0].TopologyID)
+	assert.False(t, degraded[0].NewDescription.HasWritableServer(), "expected new description to have no writable server")
+
+	// A repeated failure does not publish another degraded event.
+	topo.apply(ctx, down)
+	assert.Equal(t, 1, len(degraded), "expected 1 degraded event, got %d", len(degraded))
+
+	time.Sleep(10 * time.Millisecond)
+	topo.apply(ctx, up)
+	assert.Equal(t, 1, len(recovered), "expected 1 recovered event, got %d", len(recovered))
+	assert.True(t, recovered[0].Downtime >= 10*time.Millisecond,
+		"expected downtime of at least 10ms, got %v", recovered[0].Downtime)
+	assert.True(t, recovered[0].NewDescription.HasWritableServer(), "expected new description to have a writable server")
+}
+
 func TestMinPoolSize(t *testing.T) {
 	connStr := connstring.ConnString{
 		Hosts:          []string{"localhost:27017"},
@@ -609,15 +703,18 @@ func TestTopologyConstruction(t *testing.T) {
 		testCases := []struct {
 			name            string
 			uri             string
+			loadBalanced    bool
 			pollingRequired bool
 		}{
-			{"normal", "mongodb://localhost:27017", false},
-			{"srv", "mongodb+srv://localhost:27017", true},
+			{"normal", "mongodb://localhost:27017", false, false},
+			{"srv", "mongodb+srv://localhost:27017", false, true},
+			{"srv load balanced", "mongodb+srv://localhost:27017", true, false},
 		}
 		for _, tc := range testCases {
 			t.Run(tc.name, func(t *testing.T) {
 				topo, err := New(
 					WithURI(func(string) string { return tc.uri }),
+					WithLoadBalanced(func(bool) bool { return tc.loadBalanced }),
 				)
 				assert.Nil(t, err, "topology.New error: %v", err)
 
@@ -628,3 +725,103 @@ func TestTopologyConstruction(t *testing.T) {
 		}
 	})
 }
+
+func TestLoadBalancedTopology(t *testing.T) {
+	var topologyEvents []*event.TopologyDescriptionChangedEvent
+	var serverEvents []*event.ServerDescriptionChangedEvent
+	sm := &event.ServerMonitor{
+		TopologyDescriptionChanged: func(evt *event.TopologyDescriptionChangedEvent) {
+			topologyEvents = append(topologyEvents, evt)
+		},
+		ServerDescriptionChanged: func(evt *event.ServerDescriptionChangedEvent) {
+			serverEvents = append(serverEvents, evt)
+		},
+	}
+	topo, err := New(
+		WithSeedList(func(...string) []string { return []string{"localhost:27017"} }),
+		WithLoadBalanced(func(bool) bool { return true }),
+		WithServerOptions(func(...ServerOption) []ServerOption {
+			return []ServerOption{WithServerLoadBalanced(func(bool) bool { return true })}
+		}),
+		WithTopologyServerMonitor(func(*event.ServerMonitor) *event.ServerMonitor { return sm }),
+	)
+	assert.Nil(t, err, "topology.New error: %v", err)
+	err = topo.Connect()
+	assert.Nil(t, err, "topology.Connect error: %v", err)
+	defer func() {
+		_ = topo.Disconnect(context.Background())
+	}()
+
+	desc := topo.Description()
+	assert.Equal(t, description.LoadBalanced, desc.Kind, "expected topology kind %v, got %v", description.LoadBalanced, desc.Kind)
+	assert.Equal(t, 1, len(desc.Servers), "expected 1 server, got %d", len(desc.Servers))
+	assert.Equal(t, description.LoadBalancer, desc.Servers[0].Kind,
+		"expected server kind %v, got %v", description.LoadBalancer, desc.Servers[0].Kind)
+
+	assert.Equal(t, 1, len(topologyEvents), "expected 1 TopologyDescriptionChanged event, got %d", len(topologyEvents))
+	assert.Equal(t, description.LoadBalanced, topologyEvents[0].NewDescription.Kind,
+		"expected new topology kind %v, got %v", description.LoadBalanced, topologyEvents[0].NewDescription.Kind)
+	assert.Equal(t, 1, len(serverEvents), "expected 1 ServerDescriptionChanged event, got %d", len(serverEvents))
+	assert.Equal(t, description.ServerKind(description.Unknown), serverEvents[0].PreviousDescription.Kind,
+		"expected previous server kind Unknown, got %v", serverEvents[0].PreviousDescription.Kind)
+	assert.Equal(t, description.LoadBalancer, serverEvents[0].NewDescription.Kind,
+		"expected new server kind %v, got %v", description.LoadBalancer, serverEvents[0].NewDescription.Kind)
+
+	t.Run("load balancer is selectable", func(t *testing.T) {
+		// A selector that never returns candidates must not prevent the load balancer from being selected.
+		noneSelector := description.ServerSelectorFunc(func(description.Topology, []description.Server) ([]description.Server, error) {
+			return nil, nil
+		})
+		selectors := []description.ServerSelector{
+			description.WriteSelector(),
+			description.ReadPrefSelector(readpref.Secondary()),
+			noneSelector,
+		}
+		for _, selector := range selectors {
+			srvr, err := topo.SelectServer(context.Background(), selector)
+			assert.Nil(t, err, "SelectServer error: %v", err)
+			selected := srvr.(*SelectedServer)
+			assert.Equal(t, description.LoadBalanced, selected.Kind,
+				"expected selected server kind %v, got %v", description.LoadBalanced, selected.Kind)
+		}
+	})
+	t.Run("errors do not mark the server unknown", func(t *testing.T) {
+		srvr := topo.servers[address.Address("localhost:27017")]
+		srvr.ProcessHandshakeError(ConnectionError{Wrapped: errors.New("dial error"), init: true}, 0, nil)
+
+		desc := srvr.Description()
+		assert.Equal(t, description.LoadBalancer, desc.Kind, "expected server kind %v, got %v", description.LoadBalancer, desc.Kind)
+		assert.Equal(t, uint64(0), srvr.pool.generation, "expected pool generation 0, got %d", srvr.pool.generation)
+	})
+}
+
+func TestTopologyReconnect(t *testing.T) {
+	addr := address.Address("localhost:27017")
+	topo, err := New(
+		WithSeedList(func(...string) []string { return []string{addr.String()} }),
+		WithLoadBalanced(func(bool) bool { return true }),
+		WithServerOptions(func(...ServerOption) []ServerOption {
+			return []ServerOption{WithServerLoadBalanced(func(bool) bool { return true })}
+		}),
+	)
+	assert.Nil(t, err, "topology.New error: %v", err)
+	err = topo.Connect()
+	assert.Nil(t, err, "topology.Connect error: %v", err)
+	first := topo.servers[addr]
+
+	err = topo.Disconnect(context.Background())
+	assert.Nil(t, err, "topology.Disconnect error: %v", err)
+	err = topo.Connect()
+	assert.Nil(t, err, "topology.Connect error: %v", err)
+	defer func() {
+		_ = topo.Disconnect(context.Background())
+	}()
+
+	desc := topo.Description()
+	assert.Equal(t, 1, len(desc.Servers), "expected 1 server, got %d", len(desc.Servers))
+	assert.Equal(t, 1, len(topo.servers), "expected 1 server, got %d", len(topo.servers))
+	assert.True(t, topo.servers[addr] != first, "expected a new server to be created after reconnecting")
+
+	_, err = topo.SelectServer(context.Background(), description.WriteSelector())
+	assert.Nil(t, err, "SelectServer error: %v", err)
+}